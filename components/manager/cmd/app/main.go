@@ -7,6 +7,8 @@ package main
 import (
 	"fmt"
 	"os"
+	// Embeds the IANA timezone database so report timezones resolve in minimal container images
+	_ "time/tzdata"
 
 	libCommons "github.com/LerianStudio/lib-commons/v2/commons"
 
@@ -0,0 +1,180 @@
+// Copyright (c) 2026 Lerian Studio. All rights reserved.
+// Use of this source code is governed by the Elastic License 2.0
+// that can be found in the LICENSE file.
+
+package in
+
+import (
+	"errors"
+
+	"github.com/LerianStudio/reporter/components/manager/internal/services"
+	"github.com/LerianStudio/reporter/pkg/model"
+	"github.com/LerianStudio/reporter/pkg/net/http"
+
+	"github.com/LerianStudio/lib-commons/v2/commons"
+	commonsHttp "github.com/LerianStudio/lib-commons/v2/commons/net/http"
+	libOpentelemetry "github.com/LerianStudio/lib-commons/v2/commons/opentelemetry"
+	"github.com/gofiber/fiber/v2"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// DeadLetterHandler handles HTTP requests for the administration of the dead letter queue.
+type DeadLetterHandler struct {
+	service *services.UseCase
+}
+
+// NewDeadLetterHandler creates a new DeadLetterHandler with the given service dependency.
+// It returns an error if service is nil.
+func NewDeadLetterHandler(service *services.UseCase) (*DeadLetterHandler, error) {
+	if service == nil {
+		return nil, errors.New("service must not be nil for DeadLetterHandler")
+	}
+
+	return &DeadLetterHandler{service: service}, nil
+}
+
+// GetDeadLetters is a method that lists the messages of the dead letter queue.
+//
+//	@Summary		List dead letter messages
+//	@Description	List the first report generation messages of the dead letter queue, leaving them in the queue, with the reason they were dead-lettered and the queue depth
+//	@Tags			Admin
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			limit	query		int	false	"Limit"	default(10)
+//	@Success		200		{object}	model.DeadLetterMessagesOutput
+//	@Failure		400		{object}	pkg.HTTPError
+//	@Failure		401		{object}	pkg.HTTPError
+//	@Failure		403		{object}	pkg.HTTPError
+//	@Failure		422		{object}	pkg.HTTPError
+//	@Failure		500		{object}	pkg.HTTPError
+//	@Router			/v1/admin/dead-letters [get]
+func (dh *DeadLetterHandler) GetDeadLetters(c *fiber.Ctx) error {
+	ctx := c.UserContext()
+
+	logger, tracer, reqId, _ := commons.NewTrackingFromContext(ctx)
+
+	ctx, span := tracer.Start(ctx, "handler.dead_letter.get_all")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("app.request.request_id", reqId),
+	)
+
+	headerParams, err := http.ValidateParameters(c.Queries())
+	if err != nil {
+		libOpentelemetry.HandleSpanBusinessErrorEvent(&span, "Failed to validate query parameters", err)
+
+		logger.Errorf("Failed to validate query parameters, Error: %s", err.Error())
+
+		return http.WithError(c, err)
+	}
+
+	output, err := dh.service.ListDeadLetters(ctx, headerParams.Limit)
+	if err != nil {
+		if http.IsBusinessError(err) {
+			libOpentelemetry.HandleSpanBusinessErrorEvent(&span, "Failed to list dead letter messages", err)
+		} else {
+			libOpentelemetry.HandleSpanError(&span, "Failed to list dead letter messages", err)
+		}
+
+		logger.Errorf("Failed to list dead letter messages, Error: %s", err.Error())
+
+		return http.WithError(c, err)
+	}
+
+	logger.Infof("Successfully listed %d of %d dead letter messages", len(output.Items), output.Total)
+
+	return commonsHttp.OK(c, output)
+}
+
+// ReplayDeadLetters is a method that replays messages of the dead letter queue.
+//
+//	@Summary		Replay dead letter messages
+//	@Description	Republish to the generation queue the dead letter messages of the given reports, or all of them, among the first 500 messages. Each report is reset to Processing before its message is replayed. Reports that can not be replayed are listed with the reason.
+//	@Tags			Admin
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			messages	body		model.ReplayDeadLettersInput	true	"Messages to replay"
+//	@Success		200			{object}	model.ReplayDeadLettersOutput
+//	@Failure		400			{object}	pkg.HTTPError
+//	@Failure		401			{object}	pkg.HTTPError
+//	@Failure		403			{object}	pkg.HTTPError
+//	@Failure		422			{object}	pkg.HTTPError
+//	@Failure		500			{object}	pkg.HTTPError
+//	@Router			/v1/admin/dead-letters/replay [post]
+func (dh *DeadLetterHandler) ReplayDeadLetters(p any, c *fiber.Ctx) error {
+	ctx := c.UserContext()
+
+	logger, tracer, reqId, _ := commons.NewTrackingFromContext(ctx)
+
+	ctx, span := tracer.Start(ctx, "handler.dead_letter.replay")
+	defer span.End()
+
+	payload := p.(*model.ReplayDeadLettersInput)
+	logger.Infof("Request to replay dead letter messages with details: %#v", payload)
+
+	span.SetAttributes(
+		attribute.String("app.request.request_id", reqId),
+	)
+
+	output, err := dh.service.ReplayDeadLetters(ctx, payload)
+	if err != nil {
+		if http.IsBusinessError(err) {
+			libOpentelemetry.HandleSpanBusinessErrorEvent(&span, "Failed to replay dead letter messages", err)
+		} else {
+			libOpentelemetry.HandleSpanError(&span, "Failed to replay dead letter messages", err)
+		}
+
+		logger.Errorf("Failed to replay dead letter messages, Error: %s", err.Error())
+
+		return http.WithError(c, err)
+	}
+
+	logger.Infof("Replayed %d dead letter messages, %d failed", len(output.Replayed), len(output.Failed))
+
+	return commonsHttp.OK(c, output)
+}
+
+// PurgeDeadLetters is a method that removes every message of the dead letter queue.
+//
+//	@Summary		Purge dead letter messages
+//	@Description	Remove every message of the dead letter queue. The reports of the removed messages keep their status.
+//	@Tags			Admin
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Success		200	{object}	model.PurgeDeadLettersOutput
+//	@Failure		401	{object}	pkg.HTTPError
+//	@Failure		403	{object}	pkg.HTTPError
+//	@Failure		422	{object}	pkg.HTTPError
+//	@Failure		500	{object}	pkg.HTTPError
+//	@Router			/v1/admin/dead-letters [delete]
+func (dh *DeadLetterHandler) PurgeDeadLetters(c *fiber.Ctx) error {
+	ctx := c.UserContext()
+
+	logger, tracer, reqId, _ := commons.NewTrackingFromContext(ctx)
+
+	ctx, span := tracer.Start(ctx, "handler.dead_letter.purge")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("app.request.request_id", reqId),
+	)
+
+	output, err := dh.service.PurgeDeadLetters(ctx)
+	if err != nil {
+		if http.IsBusinessError(err) {
+			libOpentelemetry.HandleSpanBusinessErrorEvent(&span, "Failed to purge dead letter queue", err)
+		} else {
+			libOpentelemetry.HandleSpanError(&span, "Failed to purge dead letter queue", err)
+		}
+
+		logger.Errorf("Failed to purge dead letter queue, Error: %s", err.Error())
+
+		return http.WithError(c, err)
+	}
+
+	logger.Infof("Successfully purged %d dead letter messages", output.Purged)
+
+	return commonsHttp.OK(c, output)
+}
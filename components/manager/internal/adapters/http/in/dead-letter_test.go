@@ -0,0 +1,184 @@
+// Copyright (c) 2026 Lerian Studio. All rights reserved.
+// Use of this source code is governed by the Elastic License 2.0
+// that can be found in the LICENSE file.
+
+package in
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/LerianStudio/reporter/components/manager/internal/services"
+	"github.com/LerianStudio/reporter/pkg/constant"
+	"github.com/LerianStudio/reporter/pkg/model"
+	"github.com/LerianStudio/reporter/pkg/mongodb/report"
+	"github.com/LerianStudio/reporter/pkg/rabbitmq"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestDeadLetterHandler_GetDeadLetters(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name           string
+		dlqConfigured  bool
+		query          string
+		mockSetup      func(mockDeadLetterRepo *rabbitmq.MockDeadLetterRepository)
+		expectedStatus int
+	}{
+		{
+			name:          "Success - lists dead letter messages",
+			dlqConfigured: true,
+			query:         "?limit=5",
+			mockSetup: func(mockDeadLetterRepo *rabbitmq.MockDeadLetterRepository) {
+				mockDeadLetterRepo.EXPECT().
+					Peek(gomock.Any(), 5).
+					Return([]model.DeadLetterMessage{{ReportID: uuid.New(), RetryCount: 3}}, 1, nil)
+			},
+			expectedStatus: fiber.StatusOK,
+		},
+		{
+			name:           "Error - invalid limit",
+			dlqConfigured:  true,
+			query:          "?limit=abc",
+			mockSetup:      func(*rabbitmq.MockDeadLetterRepository) {},
+			expectedStatus: fiber.StatusBadRequest,
+		},
+		{
+			name:           "Error - dead letter queue not configured",
+			mockSetup:      func(*rabbitmq.MockDeadLetterRepository) {},
+			expectedStatus: fiber.StatusUnprocessableEntity,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockDeadLetterRepo := rabbitmq.NewMockDeadLetterRepository(ctrl)
+			tt.mockSetup(mockDeadLetterRepo)
+
+			svc := &services.UseCase{}
+			if tt.dlqConfigured {
+				svc.DeadLetterRepo = mockDeadLetterRepo
+			}
+
+			handler, err := NewDeadLetterHandler(svc)
+			require.NoError(t, err)
+
+			app := fiber.New(fiber.Config{DisableStartupMessage: true})
+			app.Get("/v1/admin/dead-letters", handler.GetDeadLetters)
+
+			resp, err := app.Test(httptest.NewRequest("GET", "/v1/admin/dead-letters"+tt.query, nil))
+			require.NoError(t, err)
+			defer resp.Body.Close()
+
+			assert.Equal(t, tt.expectedStatus, resp.StatusCode)
+		})
+	}
+}
+
+func TestDeadLetterHandler_ReplayDeadLetters(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	reportID := uuid.New()
+	message := &model.ReportMessage{ReportID: reportID, OutputFormat: "csv"}
+
+	mockReportRepo := report.NewMockRepository(ctrl)
+	mockDeadLetterRepo := rabbitmq.NewMockDeadLetterRepository(ctrl)
+
+	mockDeadLetterRepo.EXPECT().
+		Peek(gomock.Any(), constant.MaxDeadLetterMessages).
+		Return([]model.DeadLetterMessage{{ReportID: reportID, Message: message}}, 1, nil)
+
+	mockReportRepo.EXPECT().
+		ResetForRetry(gomock.Any(), reportID, gomock.Any(), gomock.Any(), message, gomock.Any()).
+		Return(&report.Report{ID: reportID, Status: constant.ProcessingStatus}, nil)
+
+	mockDeadLetterRepo.EXPECT().
+		Replay(gomock.Any(), []uuid.UUID{reportID}, constant.MaxDeadLetterMessages).
+		Return([]uuid.UUID{reportID}, nil)
+
+	handler := &DeadLetterHandler{
+		service: &services.UseCase{
+			ReportRepo:     mockReportRepo,
+			DeadLetterRepo: mockDeadLetterRepo,
+		},
+	}
+
+	app := fiber.New(fiber.Config{
+		DisableStartupMessage: true,
+	})
+
+	payload := model.ReplayDeadLettersInput{ReportIDs: []string{reportID.String()}}
+
+	app.Post("/v1/admin/dead-letters/replay", func(c *fiber.Ctx) error {
+		c.SetUserContext(context.Background())
+		return handler.ReplayDeadLetters(&payload, c)
+	})
+
+	payloadBytes, _ := json.Marshal(payload)
+	req := httptest.NewRequest("POST", "/v1/admin/dead-letters/replay", bytes.NewReader(payloadBytes))
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := app.Test(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+
+	var output model.ReplayDeadLettersOutput
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&output))
+	assert.Equal(t, []uuid.UUID{reportID}, output.Replayed)
+	assert.Empty(t, output.Failed)
+}
+
+func TestDeadLetterHandler_PurgeDeadLetters(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockDeadLetterRepo := rabbitmq.NewMockDeadLetterRepository(ctrl)
+	mockDeadLetterRepo.EXPECT().Purge(gomock.Any()).Return(12, nil)
+
+	handler := &DeadLetterHandler{service: &services.UseCase{DeadLetterRepo: mockDeadLetterRepo}}
+
+	app := fiber.New(fiber.Config{DisableStartupMessage: true})
+	app.Delete("/v1/admin/dead-letters", handler.PurgeDeadLetters)
+
+	resp, err := app.Test(httptest.NewRequest("DELETE", "/v1/admin/dead-letters", nil))
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+
+	var output model.PurgeDeadLettersOutput
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&output))
+	assert.Equal(t, 12, output.Purged)
+}
+
+func TestNewDeadLetterHandler_NilService(t *testing.T) {
+	t.Parallel()
+
+	handler, err := NewDeadLetterHandler(nil)
+
+	assert.Nil(t, handler)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "service must not be nil")
+}
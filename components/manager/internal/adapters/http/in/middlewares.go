@@ -5,6 +5,7 @@
 package in
 
 import (
+	"context"
 	"regexp"
 
 	"github.com/LerianStudio/reporter/pkg"
@@ -12,8 +13,10 @@ import (
 	"github.com/LerianStudio/reporter/pkg/net/http"
 
 	"github.com/LerianStudio/lib-commons/v2/commons"
+	commonsHttp "github.com/LerianStudio/lib-commons/v2/commons/net/http"
 	"github.com/gofiber/fiber/v2"
 	"github.com/gofiber/fiber/v2/middleware/recover"
+	"github.com/golang-jwt/jwt/v5"
 	"github.com/google/uuid"
 )
 
@@ -101,3 +104,101 @@ func ParseStringPathParam(paramName string) fiber.Handler {
 		return c.Next()
 	}
 }
+
+// WithAuthClaims returns a Fiber middleware that decodes the claims of the caller's
+// access token and stores them in the request context under constant.AuthClaimsCtx.
+// The token signature is not checked here: it must run after auth.Authorize, which
+// validates the token against the auth service. Requests without a decodable token
+// proceed without claims, leaving the row-level policy to reject them when needed.
+func WithAuthClaims() fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		claims, ok := decodeTokenClaims(c)
+		if !ok {
+			return c.Next()
+		}
+
+		c.SetUserContext(context.WithValue(c.UserContext(), constant.AuthClaimsCtx, map[string]any(claims)))
+
+		return c.Next()
+	}
+}
+
+// WithOrganizationID returns a Fiber middleware that resolves the organization (tenant) of the
+// request and stores it in c.Locals(constant.OrganizationIDLocal). The organization comes from the
+// access token claim constant.OrganizationIDClaim; a constant.OrganizationIDHeader header that
+// contradicts it is rejected. The header alone is honored only for tokens carrying the
+// constant.TrustedServiceClaim claim, so a caller can not pick the tenant of its requests.
+// When required is false a request without organization is served as the default tenant (uuid.Nil).
+// Like WithAuthClaims it must run after auth.Authorize, which validates the token.
+func WithOrganizationID(required bool) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		headerValue := c.Get(constant.OrganizationIDHeader)
+		value := ""
+
+		claims, _ := decodeTokenClaims(c)
+
+		if claimValue, isString := claims[constant.OrganizationIDClaim].(string); isString && claimValue != "" {
+			if headerValue != "" && headerValue != claimValue {
+				err := pkg.ValidateBusinessError(constant.ErrOrganizationMismatch, "")
+				return http.WithError(c, err)
+			}
+
+			value = claimValue
+		} else if headerValue != "" {
+			if trusted, isBool := claims[constant.TrustedServiceClaim].(bool); !isBool || !trusted {
+				err := pkg.ValidateBusinessError(constant.ErrUntrustedOrganizationHeader, "", constant.OrganizationIDHeader)
+				return http.WithError(c, err)
+			}
+
+			value = headerValue
+		}
+
+		if value == "" {
+			if required {
+				err := pkg.ValidateBusinessError(constant.ErrMissingOrganizationID, "", constant.OrganizationIDClaim)
+				return http.WithError(c, err)
+			}
+
+			c.Locals(constant.OrganizationIDLocal, uuid.Nil)
+
+			return c.Next()
+		}
+
+		organizationID, errParse := uuid.Parse(value)
+		if errParse != nil {
+			err := pkg.ValidateBusinessError(constant.ErrInvalidOrganizationID, "", value)
+			return http.WithError(c, err)
+		}
+
+		c.Locals(constant.OrganizationIDLocal, organizationID)
+
+		return c.Next()
+	}
+}
+
+// organizationIDFromLocals returns the organization resolved by WithOrganizationID,
+// or the default tenant (uuid.Nil) when the middleware did not run.
+func organizationIDFromLocals(c *fiber.Ctx) uuid.UUID {
+	organizationID, ok := c.Locals(constant.OrganizationIDLocal).(uuid.UUID)
+	if !ok {
+		return uuid.Nil
+	}
+
+	return organizationID
+}
+
+// decodeTokenClaims decodes the claims of the caller's access token without verifying its signature.
+// It reports false when the request has no decodable token.
+func decodeTokenClaims(c *fiber.Ctx) (jwt.MapClaims, bool) {
+	accessToken := commonsHttp.ExtractTokenFromHeader(c)
+	if accessToken == "" {
+		return nil, false
+	}
+
+	claims := jwt.MapClaims{}
+	if _, _, err := jwt.NewParser().ParseUnverified(accessToken, claims); err != nil {
+		return nil, false
+	}
+
+	return claims, true
+}
@@ -12,7 +12,10 @@ import (
 	"strings"
 	"testing"
 
+	"github.com/LerianStudio/reporter/pkg/constant"
+
 	"github.com/gofiber/fiber/v2"
+	"github.com/golang-jwt/jwt/v5"
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -564,3 +567,196 @@ func TestParseStringPathParam_SlashCharacter(t *testing.T) {
 	// Either way, it should NOT be 200 OK.
 	assert.NotEqual(t, http.StatusOK, resp.StatusCode)
 }
+
+// ---------------------------------------------------------------------------
+// WithAuthClaims tests
+// ---------------------------------------------------------------------------
+
+func TestWithAuthClaims(t *testing.T) {
+	t.Parallel()
+
+	signedToken, err := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
+		"owner": "org-1",
+		"sub":   "user-1",
+	}).SignedString([]byte("test-secret"))
+	require.NoError(t, err)
+
+	tests := []struct {
+		name          string
+		authorization string
+		expectedOwner string
+		expectClaims  bool
+	}{
+		{
+			name:          "Bearer token claims are stored in context",
+			authorization: "Bearer " + signedToken,
+			expectedOwner: "org-1",
+			expectClaims:  true,
+		},
+		{
+			name:          "Missing token proceeds without claims",
+			authorization: "",
+			expectClaims:  false,
+		},
+		{
+			name:          "Malformed token proceeds without claims",
+			authorization: "Bearer not-a-jwt",
+			expectClaims:  false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			app := fiber.New(fiber.Config{
+				DisableStartupMessage: true,
+			})
+
+			app.Use(WithAuthClaims())
+			app.Get("/test", func(c *fiber.Ctx) error {
+				claims, ok := c.UserContext().Value(constant.AuthClaimsCtx).(map[string]any)
+				assert.Equal(t, tt.expectClaims, ok)
+
+				if ok {
+					assert.Equal(t, tt.expectedOwner, claims["owner"])
+				}
+
+				return c.SendStatus(http.StatusOK)
+			})
+
+			req := httptest.NewRequest(http.MethodGet, "/test", nil)
+			if tt.authorization != "" {
+				req.Header.Set(fiber.HeaderAuthorization, tt.authorization)
+			}
+
+			resp, err := app.Test(req)
+			require.NoError(t, err)
+			defer resp.Body.Close()
+
+			assert.Equal(t, http.StatusOK, resp.StatusCode)
+		})
+	}
+}
+
+// ---------------------------------------------------------------------------
+// WithOrganizationID tests
+// ---------------------------------------------------------------------------
+
+func TestWithOrganizationID(t *testing.T) {
+	t.Parallel()
+
+	orgA := uuid.New()
+	orgB := uuid.New()
+
+	tokenFor := func(t *testing.T, claims jwt.MapClaims) string {
+		t.Helper()
+
+		signedToken, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString([]byte("test-secret"))
+		require.NoError(t, err)
+
+		return "Bearer " + signedToken
+	}
+
+	tests := []struct {
+		name           string
+		required       bool
+		authorization  string
+		header         string
+		expectedStatus int
+		expectedOrgID  uuid.UUID
+	}{
+		{
+			name:           "Header organization is used for a trusted service token",
+			authorization:  tokenFor(t, jwt.MapClaims{constant.TrustedServiceClaim: true}),
+			header:         orgA.String(),
+			expectedStatus: http.StatusOK,
+			expectedOrgID:  orgA,
+		},
+		{
+			name:           "Header organization is forbidden when the token has no claim",
+			authorization:  tokenFor(t, jwt.MapClaims{"sub": "user"}),
+			header:         orgA.String(),
+			expectedStatus: http.StatusForbidden,
+		},
+		{
+			name:           "Header organization is forbidden without a token",
+			header:         orgA.String(),
+			expectedStatus: http.StatusForbidden,
+		},
+		{
+			name:           "Missing claim is rejected when required even with a header",
+			required:       true,
+			authorization:  tokenFor(t, jwt.MapClaims{"sub": "user"}),
+			header:         orgA.String(),
+			expectedStatus: http.StatusForbidden,
+		},
+		{
+			name:           "Token claim is used when no header is sent",
+			authorization:  tokenFor(t, jwt.MapClaims{constant.OrganizationIDClaim: orgA.String()}),
+			expectedStatus: http.StatusOK,
+			expectedOrgID:  orgA,
+		},
+		{
+			name:           "Matching header and token claim",
+			authorization:  tokenFor(t, jwt.MapClaims{constant.OrganizationIDClaim: orgA.String()}),
+			header:         orgA.String(),
+			expectedStatus: http.StatusOK,
+			expectedOrgID:  orgA,
+		},
+		{
+			name:           "Header contradicting the token claim is forbidden",
+			authorization:  tokenFor(t, jwt.MapClaims{constant.OrganizationIDClaim: orgA.String()}),
+			header:         orgB.String(),
+			expectedStatus: http.StatusForbidden,
+		},
+		{
+			name:           "Invalid organization ID is rejected",
+			authorization:  tokenFor(t, jwt.MapClaims{constant.TrustedServiceClaim: true}),
+			header:         "not-a-uuid",
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "Missing organization falls back to the default tenant",
+			expectedStatus: http.StatusOK,
+			expectedOrgID:  uuid.Nil,
+		},
+		{
+			name:           "Missing organization is rejected when required",
+			required:       true,
+			expectedStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			app := fiber.New(fiber.Config{
+				DisableStartupMessage: true,
+			})
+
+			app.Use(WithOrganizationID(tt.required))
+			app.Get("/test", func(c *fiber.Ctx) error {
+				assert.Equal(t, tt.expectedOrgID, organizationIDFromLocals(c))
+
+				return c.SendStatus(http.StatusOK)
+			})
+
+			req := httptest.NewRequest(http.MethodGet, "/test", nil)
+			if tt.authorization != "" {
+				req.Header.Set(fiber.HeaderAuthorization, tt.authorization)
+			}
+
+			if tt.header != "" {
+				req.Header.Set(constant.OrganizationIDHeader, tt.header)
+			}
+
+			resp, err := app.Test(req)
+			require.NoError(t, err)
+			defer resp.Body.Close()
+
+			assert.Equal(t, tt.expectedStatus, resp.StatusCode)
+		})
+	}
+}
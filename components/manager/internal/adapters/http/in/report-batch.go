@@ -0,0 +1,182 @@
+// Copyright (c) 2026 Lerian Studio. All rights reserved.
+// Use of this source code is governed by the Elastic License 2.0
+// that can be found in the LICENSE file.
+
+package in
+
+import (
+	"bufio"
+
+	"github.com/LerianStudio/reporter/pkg/model"
+	_ "github.com/LerianStudio/reporter/pkg/mongodb/batch"
+	"github.com/LerianStudio/reporter/pkg/net/http"
+
+	"github.com/LerianStudio/lib-commons/v2/commons"
+	commonsHttp "github.com/LerianStudio/lib-commons/v2/commons/net/http"
+	libOpentelemetry "github.com/LerianStudio/lib-commons/v2/commons/opentelemetry"
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// CreateReportBatch is a method that creates a batch of reports.
+//
+//	@Summary		Create a Report Batch
+//	@Description	Create one Report of a template per item, filtered by the shared filters overridden by the filters of the item. Items are either listed in items or selected by itemsQuery, one per distinct value of a datasource field, up to 5000 per batch.
+//	@Tags			Reports
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			X-Organization-Id	header		string							false	"Organization ID, honored only for trusted service tokens; other callers use the organization_id token claim"
+//	@Param			batch				body		model.CreateReportBatchInput	true	"Report Batch Input"
+//	@Success		201					{object}	batch.Batch
+//	@Failure		400					{object}	pkg.HTTPError
+//	@Failure		401					{object}	pkg.HTTPError
+//	@Failure		403					{object}	pkg.HTTPError
+//	@Failure		404					{object}	pkg.HTTPError
+//	@Failure		500					{object}	pkg.HTTPError
+//	@Router			/v1/report-batches [post]
+func (rh *ReportHandler) CreateReportBatch(p any, c *fiber.Ctx) error {
+	ctx := c.UserContext()
+	logger, tracer, reqId, _ := commons.NewTrackingFromContext(ctx)
+
+	ctx, span := tracer.Start(ctx, "handler.report_batch.create")
+	defer span.End()
+
+	payload := p.(*model.CreateReportBatchInput)
+	logger.Infof("Request to create a report batch of template %s with %d items", payload.TemplateID, len(payload.Items))
+
+	span.SetAttributes(
+		attribute.String("app.request.request_id", reqId),
+		attribute.String("app.request.template_id", payload.TemplateID),
+	)
+
+	batchOut, err := rh.service.CreateReportBatch(ctx, organizationIDFromLocals(c), payload)
+	if err != nil {
+		if http.IsBusinessError(err) {
+			libOpentelemetry.HandleSpanBusinessErrorEvent(&span, "Failed to create report batch", err)
+		} else {
+			libOpentelemetry.HandleSpanError(&span, "Failed to create report batch", err)
+		}
+
+		return http.WithError(c, err)
+	}
+
+	logger.Infof("Successfully created report batch %s with %d reports", batchOut.ID, batchOut.Total)
+
+	return commonsHttp.Created(c, batchOut)
+}
+
+// GetReportBatch is a method to retrieve a report batch with the progress of its reports.
+//
+//	@Summary		Get a Report Batch
+//	@Description	Return a Report Batch passing the ID, with the number of its reports in each status. The batch is Processing while any report is, then Finished when every report finished and Error otherwise.
+//	@Tags			Reports
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			X-Organization-Id	header		string	false	"Organization ID, honored only for trusted service tokens; other callers use the organization_id token claim"
+//	@Param			id					path		string	true	"Report Batch ID"
+//	@Success		200					{object}	batch.Batch
+//	@Failure		400					{object}	pkg.HTTPError
+//	@Failure		401					{object}	pkg.HTTPError
+//	@Failure		403					{object}	pkg.HTTPError
+//	@Failure		404					{object}	pkg.HTTPError
+//	@Failure		500					{object}	pkg.HTTPError
+//	@Router			/v1/report-batches/{id} [get]
+func (rh *ReportHandler) GetReportBatch(c *fiber.Ctx) error {
+	ctx := c.UserContext()
+
+	logger, tracer, reqId, _ := commons.NewTrackingFromContext(ctx)
+
+	ctx, span := tracer.Start(ctx, "handler.report_batch.get")
+	defer span.End()
+
+	id := c.Locals("id").(uuid.UUID)
+	logger.Infof("Initiating get a Report Batch with ID: %s", id)
+
+	span.SetAttributes(
+		attribute.String("app.request.request_id", reqId),
+		attribute.String("app.request.batch_id", id.String()),
+	)
+
+	batchModel, err := rh.service.GetReportBatchByID(ctx, id, organizationIDFromLocals(c))
+	if err != nil {
+		if http.IsBusinessError(err) {
+			libOpentelemetry.HandleSpanBusinessErrorEvent(&span, "Failed to retrieve report batch on query", err)
+		} else {
+			libOpentelemetry.HandleSpanError(&span, "Failed to retrieve report batch on query", err)
+		}
+
+		logger.Errorf("Failed to retrieve Report Batch with ID: %s, Error: %s", id, err.Error())
+
+		return http.WithError(c, err)
+	}
+
+	return commonsHttp.OK(c, batchModel)
+}
+
+// GetDownloadReportBatch is a method to download the finished reports of a batch as a single zip.
+//
+//	@Summary		Download a Report Batch
+//	@Description	Download the finished Reports of a Report Batch passing the ID as a single zip, one file per report named after its ID. Reports still processing, failed or cancelled are left out.
+//	@Tags			Reports
+//	@Accept			json
+//	@Produce		application/zip
+//	@Security		BearerAuth
+//	@Param			X-Organization-Id	header		string	false	"Organization ID, honored only for trusted service tokens; other callers use the organization_id token claim"
+//	@Param			id					path		string	true	"Report Batch ID"
+//	@Success		200					{file}		any
+//	@Failure		400					{object}	pkg.HTTPError
+//	@Failure		401					{object}	pkg.HTTPError
+//	@Failure		403					{object}	pkg.HTTPError
+//	@Failure		404					{object}	pkg.HTTPError
+//	@Failure		500					{object}	pkg.HTTPError
+//	@Router			/v1/report-batches/{id}/download [get]
+func (rh *ReportHandler) GetDownloadReportBatch(c *fiber.Ctx) error {
+	ctx := c.UserContext()
+	logger, tracer, reqId, _ := commons.NewTrackingFromContext(ctx)
+
+	ctx, span := tracer.Start(ctx, "handler.report_batch.get_download")
+	defer span.End()
+
+	id := c.Locals("id").(uuid.UUID)
+	logger.Infof("Initiating download of Report Batch with ID: %s", id)
+
+	span.SetAttributes(
+		attribute.String("app.request.request_id", reqId),
+		attribute.String("app.request.batch_id", id.String()),
+	)
+
+	archive, err := rh.service.PrepareReportBatchDownload(ctx, id, organizationIDFromLocals(c))
+	if err != nil {
+		if http.IsBusinessError(err) {
+			libOpentelemetry.HandleSpanBusinessErrorEvent(&span, "Failed to download report batch", err)
+		} else {
+			libOpentelemetry.HandleSpanError(&span, "Failed to download report batch", err)
+		}
+
+		logger.Errorf("Failed to download Report Batch with ID: %s, Error: %s", id, err.Error())
+
+		return http.WithError(c, err)
+	}
+
+	c.Set("Content-Type", "application/zip")
+	c.Set("Content-Disposition", "attachment; filename=\""+archive.FileName+"\"")
+
+	// The zip is streamed once the headers are sent, so a failure midway can only truncate it
+	c.Context().SetBodyStreamWriter(func(w *bufio.Writer) {
+		if err := rh.service.WriteReportBatchArchive(ctx, archive, w); err != nil {
+			logger.Errorf("Failed to write archive of Report Batch with ID: %s, Error: %s", id, err.Error())
+			return
+		}
+
+		if err := w.Flush(); err != nil {
+			logger.Errorf("Failed to flush archive of Report Batch with ID: %s, Error: %s", id, err.Error())
+		}
+	})
+
+	logger.Infof("Streaming %d reports of Report Batch with ID: %s", len(archive.Files), id)
+
+	return nil
+}
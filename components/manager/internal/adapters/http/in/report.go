@@ -8,8 +8,10 @@ import (
 	"bytes"
 	"context"
 	"errors"
+	"fmt"
 
 	"github.com/LerianStudio/reporter/components/manager/internal/services"
+	"github.com/LerianStudio/reporter/pkg"
 	"github.com/LerianStudio/reporter/pkg/constant"
 	"github.com/LerianStudio/reporter/pkg/model"
 	_ "github.com/LerianStudio/reporter/pkg/mongodb/report"
@@ -24,6 +26,12 @@ import (
 	"go.opentelemetry.io/otel/attribute"
 )
 
+// Download modes of GetDownloadReport handing the report over through a presigned URL of the object storage.
+const (
+	reportDownloadModeRedirect = "redirect"
+	reportDownloadModeURL      = "url"
+)
+
 // ReportHandler handles HTTP requests for report operations.
 type ReportHandler struct {
 	service *services.UseCase
@@ -47,6 +55,7 @@ func NewReportHandler(service *services.UseCase) (*ReportHandler, error) {
 //	@Accept			json
 //	@Produce		json
 //	@Security		BearerAuth
+//	@Param			X-Organization-Id	header		string	false	"Organization ID, honored only for trusted service tokens; other callers use the organization_id token claim"
 //	@Param			X-Idempotency	header		string					false	"Client-provided idempotency key to prevent duplicate report creation"
 //	@Param			reports			body		model.CreateReportInput	true	"Report Input"
 //	@Success		201				{object}	report.Report
@@ -85,7 +94,7 @@ func (rh *ReportHandler) CreateReport(p any, c *fiber.Ctx) error {
 		libOpentelemetry.HandleSpanError(&span, "Failed to convert payload to JSON string", err)
 	}
 
-	reportOut, err := rh.service.CreateReport(ctx, payload)
+	reportOut, err := rh.service.CreateReport(ctx, organizationIDFromLocals(c), payload)
 	if err != nil {
 		if http.IsBusinessError(err) {
 			libOpentelemetry.HandleSpanBusinessErrorEvent(&span, "Failed to create report", err)
@@ -108,17 +117,24 @@ func (rh *ReportHandler) CreateReport(p any, c *fiber.Ctx) error {
 // GetDownloadReport is a method to make download of a report.
 //
 //	@Summary		Download a Report
-//	@Description	Make a download of a Report passing the ID
+//	@Description	Make a download of a Report passing the ID. By default the manager streams the report, serving a single byte range when the request has a Range header. With mode=redirect it redirects to a short-lived presigned URL of the object storage, and with mode=url it returns that URL instead; when the storage cannot presign URLs the report is streamed.
 //	@Tags			Reports
 //	@Accept			json
 //	@Produce		json
 //	@Security		BearerAuth
+//	@Param			X-Organization-Id	header		string	false	"Organization ID, honored only for trusted service tokens; other callers use the organization_id token claim"
 //	@Param			id				path		string	true	"Report ID"
+//	@Param			mode			query		string	false	"Download mode"	Enums(redirect, url)
+//	@Param			Range			header		string	false	"Byte range of the report to stream, e.g. bytes=0-1023"
 //	@Success		200				{file}		any
+//	@Success		206				{file}		any
+//	@Success		307				{string}	string	"Redirect to the presigned URL of the report"
 //	@Failure		400				{object}	pkg.HTTPError
 //	@Failure		401				{object}	pkg.HTTPError
 //	@Failure		403				{object}	pkg.HTTPError
 //	@Failure		404				{object}	pkg.HTTPError
+//	@Failure		410				{object}	pkg.HTTPError
+//	@Failure		416				{string}	string	"Range not satisfiable"
 //	@Failure		500				{object}	pkg.HTTPError
 //	@Router			/v1/reports/{id}/download [get]
 func (rh *ReportHandler) GetDownloadReport(c *fiber.Ctx) error {
@@ -129,14 +145,24 @@ func (rh *ReportHandler) GetDownloadReport(c *fiber.Ctx) error {
 	defer span.End()
 
 	id := c.Locals("id").(uuid.UUID)
+	mode := c.Query("mode")
 	logger.Infof("Initiating download of Report with ID: %s", id)
 
 	span.SetAttributes(
 		attribute.String("app.request.request_id", reqId),
 		attribute.String("app.request.report_id", id.String()),
+		attribute.String("app.request.mode", mode),
 	)
 
-	fileBytes, fileName, contentType, err := rh.service.DownloadReport(ctx, id)
+	if mode != "" && mode != reportDownloadModeRedirect && mode != reportDownloadModeURL {
+		err := pkg.ValidateBusinessError(constant.ErrInvalidQueryParameter, "", "mode")
+
+		libOpentelemetry.HandleSpanBusinessErrorEvent(&span, "Invalid download mode", err)
+
+		return http.WithError(c, err)
+	}
+
+	download, err := rh.service.DownloadReport(ctx, id, organizationIDFromLocals(c), mode != "")
 	if err != nil {
 		if http.IsBusinessError(err) {
 			libOpentelemetry.HandleSpanBusinessErrorEvent(&span, "Failed to download report", err)
@@ -149,10 +175,128 @@ func (rh *ReportHandler) GetDownloadReport(c *fiber.Ctx) error {
 		return http.WithError(c, err)
 	}
 
+	// Without a URL the storage cannot presign, so the report is streamed whatever the mode
+	if download.URL != "" {
+		logger.Infof("Successfully presigned download of Report with ID: %s", id)
+
+		if mode == reportDownloadModeRedirect {
+			return c.Redirect(download.URL, fiber.StatusTemporaryRedirect)
+		}
+
+		return commonsHttp.OK(c, model.ReportDownloadURLOutput{
+			URL:       download.URL,
+			FileName:  download.FileName,
+			ExpiresAt: download.ExpiresAt,
+		})
+	}
+
+	if err := rh.streamReportDownload(ctx, c, download); err != nil {
+		if http.IsBusinessError(err) {
+			libOpentelemetry.HandleSpanBusinessErrorEvent(&span, "Failed to download report", err)
+		} else {
+			libOpentelemetry.HandleSpanError(&span, "Failed to download report", err)
+		}
+
+		logger.Errorf("Failed to download Report with ID: %s, Error: %s", id, err.Error())
+
+		return http.WithError(c, err)
+	}
+
+	logger.Infof("Successfully downloaded Report with ID: %s", id)
+
+	return nil
+}
+
+// streamReportDownload streams the output of a report download, or the single byte range asked by the
+// Range header. Malformed, multiple and non-byte ranges are ignored, streaming the whole output.
+func (rh *ReportHandler) streamReportDownload(ctx context.Context, c *fiber.Ctx, download *services.ReportDownload) error {
+	c.Set("Content-Type", download.ContentType)
+	c.Set("Content-Disposition", "attachment; filename=\""+download.FileName+"\"")
+	c.Set(fiber.HeaderAcceptRanges, "bytes")
+
+	status := fiber.StatusOK
+	offset, length := int64(0), download.Size
+
+	if c.Get(fiber.HeaderRange) != "" {
+		ranges, err := c.Range(int(download.Size))
+		if errors.Is(err, fiber.ErrRangeUnsatisfiable) {
+			c.Set(fiber.HeaderContentRange, fmt.Sprintf("bytes */%d", download.Size))
+
+			return c.Status(fiber.StatusRequestedRangeNotSatisfiable).Send(nil)
+		}
+
+		if err == nil && ranges.Type == "bytes" && len(ranges.Ranges) == 1 {
+			status = fiber.StatusPartialContent
+			offset = int64(ranges.Ranges[0].Start)
+			length = int64(ranges.Ranges[0].End-ranges.Ranges[0].Start) + 1
+
+			c.Set(fiber.HeaderContentRange, fmt.Sprintf("bytes %d-%d/%d", offset, offset+length-1, download.Size))
+		}
+	}
+
+	if length == 0 {
+		return c.Status(status).Send(nil)
+	}
+
+	reader, err := rh.service.OpenReportDownload(ctx, download, offset, length)
+	if err != nil {
+		return err
+	}
+
+	// The body stream is copied to the connection and closed once sent
+	return c.Status(status).SendStream(reader, int(length))
+}
+
+// GetReportSignature is a method to download the detached signature of a report.
+//
+//	@Summary		Download the signature of a Report
+//	@Description	Download the detached CMS/PKCS#7 signature (.p7s) of a Report passing the ID. PDF reports carry an embedded PAdES signature instead.
+//	@Tags			Reports
+//	@Accept			json
+//	@Produce		application/pkcs7-signature
+//	@Security		BearerAuth
+//	@Param			X-Organization-Id	header		string	false	"Organization ID, honored only for trusted service tokens; other callers use the organization_id token claim"
+//	@Param			id					path		string	true	"Report ID"
+//	@Success		200					{file}		any
+//	@Failure		400					{object}	pkg.HTTPError
+//	@Failure		401					{object}	pkg.HTTPError
+//	@Failure		403					{object}	pkg.HTTPError
+//	@Failure		404					{object}	pkg.HTTPError
+//	@Failure		410					{object}	pkg.HTTPError
+//	@Failure		500					{object}	pkg.HTTPError
+//	@Router			/v1/reports/{id}/signature [get]
+func (rh *ReportHandler) GetReportSignature(c *fiber.Ctx) error {
+	ctx := c.UserContext()
+	logger, tracer, reqId, _ := commons.NewTrackingFromContext(ctx)
+
+	ctx, span := tracer.Start(ctx, "handler.report.get_signature")
+	defer span.End()
+
+	id := c.Locals("id").(uuid.UUID)
+	logger.Infof("Initiating download of the signature of Report with ID: %s", id)
+
+	span.SetAttributes(
+		attribute.String("app.request.request_id", reqId),
+		attribute.String("app.request.report_id", id.String()),
+	)
+
+	fileBytes, fileName, contentType, err := rh.service.DownloadReportSignature(ctx, id, organizationIDFromLocals(c))
+	if err != nil {
+		if http.IsBusinessError(err) {
+			libOpentelemetry.HandleSpanBusinessErrorEvent(&span, "Failed to download report signature", err)
+		} else {
+			libOpentelemetry.HandleSpanError(&span, "Failed to download report signature", err)
+		}
+
+		logger.Errorf("Failed to download the signature of Report with ID: %s, Error: %s", id, err.Error())
+
+		return http.WithError(c, err)
+	}
+
 	c.Set("Content-Type", contentType)
 	c.Set("Content-Disposition", "attachment; filename=\""+fileName+"\"")
 
-	logger.Infof("Successfully downloaded Report with ID: %s", id)
+	logger.Infof("Successfully downloaded the signature of Report with ID: %s", id)
 
 	return c.SendStream(bytes.NewReader(fileBytes))
 }
@@ -165,6 +309,7 @@ func (rh *ReportHandler) GetDownloadReport(c *fiber.Ctx) error {
 //	@Accept			json
 //	@Produce		json
 //	@Security		BearerAuth
+//	@Param			X-Organization-Id	header		string	false	"Organization ID, honored only for trusted service tokens; other callers use the organization_id token claim"
 //	@Param			id					path		string	true	"Report ID"
 //	@Success		200					{object}	report.Report
 //	@Failure		400					{object}	pkg.HTTPError
@@ -189,7 +334,7 @@ func (rh *ReportHandler) GetReport(c *fiber.Ctx) error {
 		attribute.String("app.request.report_id", id.String()),
 	)
 
-	reportModel, err := rh.service.GetReportByID(ctx, id)
+	reportModel, err := rh.service.GetReportByID(ctx, id, organizationIDFromLocals(c))
 	if err != nil {
 		if http.IsBusinessError(err) {
 			libOpentelemetry.HandleSpanBusinessErrorEvent(&span, "Failed to retrieve report on query", err)
@@ -205,6 +350,290 @@ func (rh *ReportHandler) GetReport(c *fiber.Ctx) error {
 	return commonsHttp.OK(c, reportModel)
 }
 
+// CancelReport is a method to cancel a report that is still being generated.
+//
+//	@Summary		Cancel a Report
+//	@Description	Cancel a Report in the Processing status passing the ID. The worker stops generating it at its next checkpoint.
+//	@Tags			Reports
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			X-Organization-Id	header		string	false	"Organization ID, honored only for trusted service tokens; other callers use the organization_id token claim"
+//	@Param			id					path		string	true	"Report ID"
+//	@Success		200					{object}	report.Report
+//	@Failure		400					{object}	pkg.HTTPError
+//	@Failure		401					{object}	pkg.HTTPError
+//	@Failure		403					{object}	pkg.HTTPError
+//	@Failure		404					{object}	pkg.HTTPError
+//	@Failure		409					{object}	pkg.HTTPError
+//	@Failure		500					{object}	pkg.HTTPError
+//	@Router			/v1/reports/{id}/cancel [post]
+func (rh *ReportHandler) CancelReport(c *fiber.Ctx) error {
+	ctx := c.UserContext()
+
+	logger, tracer, reqId, _ := commons.NewTrackingFromContext(ctx)
+
+	ctx, span := tracer.Start(ctx, "handler.report.cancel")
+	defer span.End()
+
+	id := c.Locals("id").(uuid.UUID)
+	logger.Infof("Initiating cancellation of Report with ID: %s", id)
+
+	span.SetAttributes(
+		attribute.String("app.request.request_id", reqId),
+		attribute.String("app.request.report_id", id.String()),
+	)
+
+	reportModel, err := rh.service.CancelReport(ctx, id, organizationIDFromLocals(c))
+	if err != nil {
+		if http.IsBusinessError(err) {
+			libOpentelemetry.HandleSpanBusinessErrorEvent(&span, "Failed to cancel report", err)
+		} else {
+			libOpentelemetry.HandleSpanError(&span, "Failed to cancel report", err)
+		}
+
+		logger.Errorf("Failed to cancel Report with ID: %s, Error: %s", id, err.Error())
+
+		return http.WithError(c, err)
+	}
+
+	logger.Infof("Successfully cancelled Report with ID: %s", id)
+
+	return commonsHttp.OK(c, reportModel)
+}
+
+// DeleteReport is a method to delete a report and its file.
+//
+//	@Summary		Delete a Report
+//	@Description	Delete a Report passing the ID, along with its file and detached signature. Reports under legal hold or still Processing can not be deleted. Downloading a deleted Report returns 410. Returns 204 with no content on success.
+//	@Tags			Reports
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			X-Organization-Id	header	string	false	"Organization ID, honored only for trusted service tokens; other callers use the organization_id token claim"
+//	@Param			id					path	string	true	"Report ID"
+//	@Success		204					"No content"
+//	@Failure		400					{object}	pkg.HTTPError
+//	@Failure		401					{object}	pkg.HTTPError
+//	@Failure		403					{object}	pkg.HTTPError
+//	@Failure		404					{object}	pkg.HTTPError
+//	@Failure		409					{object}	pkg.HTTPError
+//	@Failure		500					{object}	pkg.HTTPError
+//	@Router			/v1/reports/{id} [delete]
+func (rh *ReportHandler) DeleteReport(c *fiber.Ctx) error {
+	ctx := c.UserContext()
+
+	logger, tracer, reqId, _ := commons.NewTrackingFromContext(ctx)
+
+	ctx, span := tracer.Start(ctx, "handler.report.delete")
+	defer span.End()
+
+	id := c.Locals("id").(uuid.UUID)
+	logger.Infof("Initiating deletion of Report with ID: %s", id)
+
+	span.SetAttributes(
+		attribute.String("app.request.request_id", reqId),
+		attribute.String("app.request.report_id", id.String()),
+	)
+
+	if err := rh.service.DeleteReport(ctx, id, organizationIDFromLocals(c)); err != nil {
+		if http.IsBusinessError(err) {
+			libOpentelemetry.HandleSpanBusinessErrorEvent(&span, "Failed to delete report", err)
+		} else {
+			libOpentelemetry.HandleSpanError(&span, "Failed to delete report", err)
+		}
+
+		logger.Errorf("Failed to delete Report with ID: %s, Error: %s", id, err.Error())
+
+		return http.WithError(c, err)
+	}
+
+	logger.Infof("Successfully deleted Report with ID: %s", id)
+
+	return commonsHttp.NoContent(c)
+}
+
+// PlaceReportLegalHold is a method to place a report under legal hold.
+//
+//	@Summary		Place a Report under Legal Hold
+//	@Description	Place a Report under legal hold passing the ID. A Report under legal hold can not be deleted and is never purged by its retention policy until the hold is released.
+//	@Tags			Reports
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			X-Organization-Id	header		string	false	"Organization ID, honored only for trusted service tokens; other callers use the organization_id token claim"
+//	@Param			id					path		string	true	"Report ID"
+//	@Success		200					{object}	report.Report
+//	@Failure		400					{object}	pkg.HTTPError
+//	@Failure		401					{object}	pkg.HTTPError
+//	@Failure		403					{object}	pkg.HTTPError
+//	@Failure		404					{object}	pkg.HTTPError
+//	@Failure		500					{object}	pkg.HTTPError
+//	@Router			/v1/reports/{id}/legal-hold [post]
+func (rh *ReportHandler) PlaceReportLegalHold(c *fiber.Ctx) error {
+	return rh.setReportLegalHold(c, true)
+}
+
+// ReleaseReportLegalHold is a method to release the legal hold of a report.
+//
+//	@Summary		Release the Legal Hold of a Report
+//	@Description	Release the legal hold of a Report passing the ID, so that it can be deleted and purged by its retention policy again.
+//	@Tags			Reports
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			X-Organization-Id	header		string	false	"Organization ID, honored only for trusted service tokens; other callers use the organization_id token claim"
+//	@Param			id					path		string	true	"Report ID"
+//	@Success		200					{object}	report.Report
+//	@Failure		400					{object}	pkg.HTTPError
+//	@Failure		401					{object}	pkg.HTTPError
+//	@Failure		403					{object}	pkg.HTTPError
+//	@Failure		404					{object}	pkg.HTTPError
+//	@Failure		500					{object}	pkg.HTTPError
+//	@Router			/v1/reports/{id}/legal-hold [delete]
+func (rh *ReportHandler) ReleaseReportLegalHold(c *fiber.Ctx) error {
+	return rh.setReportLegalHold(c, false)
+}
+
+// setReportLegalHold places or releases the legal hold of the report of the path.
+func (rh *ReportHandler) setReportLegalHold(c *fiber.Ctx, legalHold bool) error {
+	ctx := c.UserContext()
+
+	logger, tracer, reqId, _ := commons.NewTrackingFromContext(ctx)
+
+	ctx, span := tracer.Start(ctx, "handler.report.set_legal_hold")
+	defer span.End()
+
+	id := c.Locals("id").(uuid.UUID)
+	logger.Infof("Initiating legal hold %t of Report with ID: %s", legalHold, id)
+
+	span.SetAttributes(
+		attribute.String("app.request.request_id", reqId),
+		attribute.String("app.request.report_id", id.String()),
+		attribute.Bool("app.request.legal_hold", legalHold),
+	)
+
+	reportModel, err := rh.service.SetReportLegalHold(ctx, id, organizationIDFromLocals(c), legalHold)
+	if err != nil {
+		if http.IsBusinessError(err) {
+			libOpentelemetry.HandleSpanBusinessErrorEvent(&span, "Failed to set report legal hold", err)
+		} else {
+			libOpentelemetry.HandleSpanError(&span, "Failed to set report legal hold", err)
+		}
+
+		logger.Errorf("Failed to set legal hold of Report with ID: %s, Error: %s", id, err.Error())
+
+		return http.WithError(c, err)
+	}
+
+	logger.Infof("Successfully set legal hold of Report with ID: %s to %t", id, legalHold)
+
+	return commonsHttp.OK(c, reportModel)
+}
+
+// RetryReport is a method to retry a failed or cancelled report.
+//
+//	@Summary		Retry a Report
+//	@Description	Reset a Report in the Error or Cancelled status to Processing and requeue it under the same ID, keeping the previous attempt in its history. The Report is requeued with the template revision it was created with, unless refreshTemplate is set.
+//	@Tags			Reports
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			X-Organization-Id	header		string	false	"Organization ID, honored only for trusted service tokens; other callers use the organization_id token claim"
+//	@Param			id					path		string	true	"Report ID"
+//	@Param			refreshTemplate		query		bool	false	"Requeue the report with the current revision of its template"
+//	@Success		200					{object}	report.Report
+//	@Failure		400					{object}	pkg.HTTPError
+//	@Failure		401					{object}	pkg.HTTPError
+//	@Failure		403					{object}	pkg.HTTPError
+//	@Failure		404					{object}	pkg.HTTPError
+//	@Failure		409					{object}	pkg.HTTPError
+//	@Failure		500					{object}	pkg.HTTPError
+//	@Router			/v1/reports/{id}/retry [post]
+func (rh *ReportHandler) RetryReport(c *fiber.Ctx) error {
+	ctx := c.UserContext()
+
+	logger, tracer, reqId, _ := commons.NewTrackingFromContext(ctx)
+
+	ctx, span := tracer.Start(ctx, "handler.report.retry")
+	defer span.End()
+
+	id := c.Locals("id").(uuid.UUID)
+	refreshTemplate := c.QueryBool("refreshTemplate")
+
+	logger.Infof("Initiating retry of Report with ID: %s", id)
+
+	span.SetAttributes(
+		attribute.String("app.request.request_id", reqId),
+		attribute.String("app.request.report_id", id.String()),
+	)
+
+	reportModel, err := rh.service.RetryReport(ctx, id, organizationIDFromLocals(c), refreshTemplate)
+	if err != nil {
+		if http.IsBusinessError(err) {
+			libOpentelemetry.HandleSpanBusinessErrorEvent(&span, "Failed to retry report", err)
+		} else {
+			libOpentelemetry.HandleSpanError(&span, "Failed to retry report", err)
+		}
+
+		logger.Errorf("Failed to retry Report with ID: %s, Error: %s", id, err.Error())
+
+		return http.WithError(c, err)
+	}
+
+	logger.Infof("Successfully requeued Report with ID: %s", id)
+
+	return commonsHttp.OK(c, reportModel)
+}
+
+// RetryReports is a method to retry the failed reports of a template or time window.
+//
+//	@Summary		Retry failed Reports
+//	@Description	Requeue the Reports in the Error status of a template, created in a time window, or both, up to 500 per request. Reports that can not be requeued are listed with the reason.
+//	@Tags			Reports
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			X-Organization-Id	header		string					false	"Organization ID, honored only for trusted service tokens; other callers use the organization_id token claim"
+//	@Param			reports				body		model.RetryReportsInput	true	"Reports to retry"
+//	@Success		200					{object}	model.RetryReportsOutput
+//	@Failure		400					{object}	pkg.HTTPError
+//	@Failure		401					{object}	pkg.HTTPError
+//	@Failure		403					{object}	pkg.HTTPError
+//	@Failure		500					{object}	pkg.HTTPError
+//	@Router			/v1/reports/retry [post]
+func (rh *ReportHandler) RetryReports(p any, c *fiber.Ctx) error {
+	ctx := c.UserContext()
+
+	logger, tracer, reqId, _ := commons.NewTrackingFromContext(ctx)
+
+	ctx, span := tracer.Start(ctx, "handler.report.retry_bulk")
+	defer span.End()
+
+	payload := p.(*model.RetryReportsInput)
+	logger.Infof("Request to retry reports with details: %#v", payload)
+
+	span.SetAttributes(
+		attribute.String("app.request.request_id", reqId),
+	)
+
+	output, err := rh.service.RetryReports(ctx, organizationIDFromLocals(c), payload)
+	if err != nil {
+		if http.IsBusinessError(err) {
+			libOpentelemetry.HandleSpanBusinessErrorEvent(&span, "Failed to retry reports", err)
+		} else {
+			libOpentelemetry.HandleSpanError(&span, "Failed to retry reports", err)
+		}
+
+		logger.Errorf("Failed to retry reports, Error: %s", err.Error())
+
+		return http.WithError(c, err)
+	}
+
+	logger.Infof("Requeued %d reports, %d failed", len(output.Retried), len(output.Failed))
+
+	return commonsHttp.OK(c, output)
+}
+
 // GetAllReports is a method that recovery all Reports information.
 //
 //	@Summary		Get all reports
@@ -212,7 +641,8 @@ func (rh *ReportHandler) GetReport(c *fiber.Ctx) error {
 //	@Tags			Reports
 //	@Produce		json
 //	@Security		BearerAuth
-//	@Param			status			query		string	false	"Report status (processing, finished, error)"
+//	@Param			X-Organization-Id	header		string	false	"Organization ID, honored only for trusted service tokens; other callers use the organization_id token claim"
+//	@Param			status			query		string	false	"Report status (processing, finished, error, cancelled)"
 //	@Param			template_id		query		string	false	"Template ID (also accepts templateId)"
 //	@Param			created_at		query		string	false	"Created at date, YYYY-MM-DD (also accepts createdAt)"
 //	@Param			limit			query		int		false	"Limit"	default(10)
@@ -256,7 +686,7 @@ func (rh *ReportHandler) GetAllReports(c *fiber.Ctx) error {
 		libOpentelemetry.HandleSpanError(&span, "Failed to convert query params to JSON string", err)
 	}
 
-	reports, err := rh.service.GetAllReports(ctx, *headerParams)
+	reports, err := rh.service.GetAllReports(ctx, *headerParams, organizationIDFromLocals(c))
 	if err != nil {
 		if http.IsBusinessError(err) {
 			libOpentelemetry.HandleSpanBusinessErrorEvent(&span, "Failed to retrieve all Reports on query", err)
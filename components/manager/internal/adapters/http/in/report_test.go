@@ -16,6 +16,7 @@ import (
 	"github.com/LerianStudio/reporter/pkg"
 	"github.com/LerianStudio/reporter/pkg/constant"
 	"github.com/LerianStudio/reporter/pkg/model"
+	"github.com/LerianStudio/reporter/pkg/mongodb/outbox"
 	"github.com/LerianStudio/reporter/pkg/mongodb/report"
 	"github.com/LerianStudio/reporter/pkg/mongodb/template"
 	"github.com/LerianStudio/reporter/pkg/rabbitmq"
@@ -26,6 +27,7 @@ import (
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/mongo"
 	"go.uber.org/mock/gomock"
 )
 
@@ -38,7 +40,7 @@ func TestReportHandler_CreateReport(t *testing.T) {
 	tests := []struct {
 		name           string
 		payload        model.CreateReportInput
-		mockSetup      func(mockTempRepo *template.MockRepository, mockReportRepo *report.MockRepository, mockRabbitMQ *rabbitmq.MockProducerRepository)
+		mockSetup      func(mockTempRepo *template.MockRepository, mockReportRepo *report.MockRepository, mockRabbitMQ *rabbitmq.MockProducerRepository, mockOutboxRepo *outbox.MockRepository)
 		expectedStatus int
 		expectError    bool
 	}{
@@ -48,7 +50,7 @@ func TestReportHandler_CreateReport(t *testing.T) {
 				TemplateID: tempID.String(),
 				Filters:    nil,
 			},
-			mockSetup: func(mockTempRepo *template.MockRepository, mockReportRepo *report.MockRepository, mockRabbitMQ *rabbitmq.MockProducerRepository) {
+			mockSetup: func(mockTempRepo *template.MockRepository, mockReportRepo *report.MockRepository, mockRabbitMQ *rabbitmq.MockProducerRepository, mockOutboxRepo *outbox.MockRepository) {
 				outputFormat := "pdf"
 				mappedFields := map[string]map[string][]string{
 					"midaz_onboarding": {
@@ -57,11 +59,11 @@ func TestReportHandler_CreateReport(t *testing.T) {
 				}
 
 				mockTempRepo.EXPECT().
-					FindMappedFieldsAndOutputFormatByID(gomock.Any(), gomock.Any()).
-					Return(&outputFormat, mappedFields, nil)
+					FindMappedFieldsAndOutputFormatByID(gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(&outputFormat, mappedFields, nil, "", nil)
 
 				mockReportRepo.EXPECT().
-					Create(gomock.Any(), gomock.Any()).
+					CreateWithOutbox(gomock.Any(), gomock.Any(), gomock.Any()).
 					Return(&report.Report{
 						ID:         reportID,
 						TemplateID: tempID,
@@ -71,6 +73,10 @@ func TestReportHandler_CreateReport(t *testing.T) {
 				mockRabbitMQ.EXPECT().
 					ProducerDefault(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
 					Return(nil, nil)
+
+				mockOutboxRepo.EXPECT().
+					MarkDispatched(gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(nil)
 			},
 			expectedStatus: fiber.StatusCreated,
 			expectError:    false,
@@ -81,10 +87,10 @@ func TestReportHandler_CreateReport(t *testing.T) {
 				TemplateID: tempID.String(),
 				Filters:    nil,
 			},
-			mockSetup: func(mockTempRepo *template.MockRepository, mockReportRepo *report.MockRepository, mockRabbitMQ *rabbitmq.MockProducerRepository) {
+			mockSetup: func(mockTempRepo *template.MockRepository, mockReportRepo *report.MockRepository, mockRabbitMQ *rabbitmq.MockProducerRepository, mockOutboxRepo *outbox.MockRepository) {
 				mockTempRepo.EXPECT().
-					FindMappedFieldsAndOutputFormatByID(gomock.Any(), gomock.Any()).
-					Return(nil, nil, pkg.ValidateBusinessError(constant.ErrEntityNotFound, "template"))
+					FindMappedFieldsAndOutputFormatByID(gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(nil, nil, nil, "", pkg.ValidateBusinessError(constant.ErrEntityNotFound, "template"))
 			},
 			expectedStatus: fiber.StatusNotFound,
 			expectError:    true,
@@ -95,7 +101,7 @@ func TestReportHandler_CreateReport(t *testing.T) {
 				TemplateID: tempID.String(),
 				Filters:    nil,
 			},
-			mockSetup: func(mockTempRepo *template.MockRepository, mockReportRepo *report.MockRepository, mockRabbitMQ *rabbitmq.MockProducerRepository) {
+			mockSetup: func(mockTempRepo *template.MockRepository, mockReportRepo *report.MockRepository, mockRabbitMQ *rabbitmq.MockProducerRepository, mockOutboxRepo *outbox.MockRepository) {
 				outputFormat := "pdf"
 				mappedFields := map[string]map[string][]string{
 					"midaz_onboarding": {
@@ -104,11 +110,11 @@ func TestReportHandler_CreateReport(t *testing.T) {
 				}
 
 				mockTempRepo.EXPECT().
-					FindMappedFieldsAndOutputFormatByID(gomock.Any(), gomock.Any()).
-					Return(&outputFormat, mappedFields, nil)
+					FindMappedFieldsAndOutputFormatByID(gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(&outputFormat, mappedFields, nil, "", nil)
 
 				mockReportRepo.EXPECT().
-					Create(gomock.Any(), gomock.Any()).
+					CreateWithOutbox(gomock.Any(), gomock.Any(), gomock.Any()).
 					Return(nil, constant.ErrInternalServer)
 			},
 			expectedStatus: fiber.StatusInternalServerError,
@@ -127,13 +133,15 @@ func TestReportHandler_CreateReport(t *testing.T) {
 			mockTempRepo := template.NewMockRepository(ctrl)
 			mockReportRepo := report.NewMockRepository(ctrl)
 			mockRabbitMQ := rabbitmq.NewMockProducerRepository(ctrl)
+			mockOutboxRepo := outbox.NewMockRepository(ctrl)
 
-			tt.mockSetup(mockTempRepo, mockReportRepo, mockRabbitMQ)
+			tt.mockSetup(mockTempRepo, mockReportRepo, mockRabbitMQ, mockOutboxRepo)
 
 			svc := &services.UseCase{
 				TemplateRepo: mockTempRepo,
 				ReportRepo:   mockReportRepo,
 				RabbitMQRepo: mockRabbitMQ,
+				OutboxRepo:   mockOutboxRepo,
 			}
 
 			handler := &ReportHandler{
@@ -182,7 +190,7 @@ func TestReportHandler_GetReport(t *testing.T) {
 			reportID: reportID,
 			mockSetup: func(mockReportRepo *report.MockRepository) {
 				mockReportRepo.EXPECT().
-					FindByID(gomock.Any(), reportID).
+					FindByID(gomock.Any(), reportID, gomock.Any()).
 					Return(&report.Report{
 						ID:          reportID,
 						TemplateID:  tempID,
@@ -199,7 +207,7 @@ func TestReportHandler_GetReport(t *testing.T) {
 			reportID: reportID,
 			mockSetup: func(mockReportRepo *report.MockRepository) {
 				mockReportRepo.EXPECT().
-					FindByID(gomock.Any(), reportID).
+					FindByID(gomock.Any(), reportID, gomock.Any()).
 					Return(nil, pkg.ValidateBusinessError(constant.ErrEntityNotFound, "report"))
 			},
 			expectedStatus: fiber.StatusNotFound,
@@ -281,7 +289,7 @@ func TestReportHandler_GetAllReports(t *testing.T) {
 			queryParams: "?limit=10&page=1",
 			mockSetup: func(mockReportRepo *report.MockRepository) {
 				mockReportRepo.EXPECT().
-					FindList(gomock.Any(), gomock.Any()).
+					FindList(gomock.Any(), gomock.Any(), gomock.Any()).
 					Return([]*report.Report{
 						{
 							ID:          reportID1,
@@ -307,7 +315,7 @@ func TestReportHandler_GetAllReports(t *testing.T) {
 			queryParams: "?limit=10&page=1",
 			mockSetup: func(mockReportRepo *report.MockRepository) {
 				mockReportRepo.EXPECT().
-					FindList(gomock.Any(), gomock.Any()).
+					FindList(gomock.Any(), gomock.Any(), gomock.Any()).
 					Return([]*report.Report{}, nil)
 			},
 			expectedStatus: fiber.StatusOK,
@@ -318,7 +326,7 @@ func TestReportHandler_GetAllReports(t *testing.T) {
 			queryParams: "?limit=10&page=1",
 			mockSetup: func(mockReportRepo *report.MockRepository) {
 				mockReportRepo.EXPECT().
-					FindList(gomock.Any(), gomock.Any()).
+					FindList(gomock.Any(), gomock.Any(), gomock.Any()).
 					Return(nil, constant.ErrInternalServer)
 			},
 			expectedStatus: fiber.StatusInternalServerError,
@@ -385,60 +393,206 @@ func TestReportHandler_GetDownloadReport(t *testing.T) {
 	tempID := uuid.New()
 
 	now := time.Now()
+	content := "PDF content here"
+	presignedURL := "https://storage.example.com/reports/" + reportID.String() + ".pdf?X-Amz-Signature=abc"
+
+	expectFinishedReport := func(mockReportRepo *report.MockRepository, mockTempRepo *template.MockRepository) {
+		mockReportRepo.EXPECT().
+			FindByID(gomock.Any(), reportID, gomock.Any()).
+			Return(&report.Report{
+				ID:          reportID,
+				TemplateID:  tempID,
+				Status:      constant.FinishedStatus,
+				CreatedAt:   now,
+				CompletedAt: &now,
+			}, nil)
+
+		mockTempRepo.EXPECT().
+			FindByID(gomock.Any(), tempID, gomock.Any()).
+			Return(&template.Template{
+				ID:           tempID,
+				OutputFormat: "pdf",
+				FileName:     tempID.String() + ".tpl",
+			}, nil)
+	}
 
 	tests := []struct {
-		name           string
-		reportID       uuid.UUID
-		mockSetup      func(mockReportRepo *report.MockRepository, mockTempRepo *template.MockRepository, mockSeaweedFS *reportSeaweed.MockRepository)
-		expectedStatus int
-		expectError    bool
+		name            string
+		reportID        uuid.UUID
+		query           string
+		rangeHeader     string
+		mockSetup       func(mockReportRepo *report.MockRepository, mockTempRepo *template.MockRepository, mockSeaweedFS *reportSeaweed.MockRepository)
+		expectedStatus  int
+		expectedBody    string
+		expectedHeaders map[string]string
 	}{
 		{
 			name:     "Success - Download report",
 			reportID: reportID,
 			mockSetup: func(mockReportRepo *report.MockRepository, mockTempRepo *template.MockRepository, mockSeaweedFS *reportSeaweed.MockRepository) {
-				mockReportRepo.EXPECT().
-					FindByID(gomock.Any(), reportID).
-					Return(&report.Report{
-						ID:          reportID,
-						TemplateID:  tempID,
-						Status:      constant.FinishedStatus,
-						CreatedAt:   now,
-						CompletedAt: &now,
-					}, nil)
+				expectFinishedReport(mockReportRepo, mockTempRepo)
 
-				mockTempRepo.EXPECT().
-					FindByID(gomock.Any(), tempID).
-					Return(&template.Template{
-						ID:           tempID,
-						OutputFormat: "pdf",
-						FileName:     tempID.String() + ".tpl",
-					}, nil)
+				mockSeaweedFS.EXPECT().
+					Size(gomock.Any(), gomock.Any()).
+					Return(int64(len(content)), nil)
 
 				mockSeaweedFS.EXPECT().
-					Get(gomock.Any(), gomock.Any()).
-					Return([]byte("PDF content here"), nil)
+					GetRange(gomock.Any(), gomock.Any(), int64(0), int64(len(content))).
+					Return(io.NopCloser(bytes.NewReader([]byte(content))), nil)
 			},
 			expectedStatus: fiber.StatusOK,
-			expectError:    false,
+			expectedBody:   content,
+			expectedHeaders: map[string]string{
+				"Content-Type":        "application/pdf",
+				"Content-Disposition": "attachment; filename=\"" + reportID.String() + ".pdf\"",
+				"Accept-Ranges":       "bytes",
+			},
+		},
+		{
+			name:        "Success - Download a byte range of the report",
+			reportID:    reportID,
+			rangeHeader: "bytes=4-10",
+			mockSetup: func(mockReportRepo *report.MockRepository, mockTempRepo *template.MockRepository, mockSeaweedFS *reportSeaweed.MockRepository) {
+				expectFinishedReport(mockReportRepo, mockTempRepo)
+
+				mockSeaweedFS.EXPECT().
+					Size(gomock.Any(), gomock.Any()).
+					Return(int64(len(content)), nil)
+
+				mockSeaweedFS.EXPECT().
+					GetRange(gomock.Any(), gomock.Any(), int64(4), int64(7)).
+					Return(io.NopCloser(bytes.NewReader([]byte(content[4:11]))), nil)
+			},
+			expectedStatus: fiber.StatusPartialContent,
+			expectedBody:   "content",
+			expectedHeaders: map[string]string{
+				"Content-Range": "bytes 4-10/16",
+			},
+		},
+		{
+			name:        "Success - Multiple ranges download the whole report",
+			reportID:    reportID,
+			rangeHeader: "bytes=0-1,4-5",
+			mockSetup: func(mockReportRepo *report.MockRepository, mockTempRepo *template.MockRepository, mockSeaweedFS *reportSeaweed.MockRepository) {
+				expectFinishedReport(mockReportRepo, mockTempRepo)
+
+				mockSeaweedFS.EXPECT().
+					Size(gomock.Any(), gomock.Any()).
+					Return(int64(len(content)), nil)
+
+				mockSeaweedFS.EXPECT().
+					GetRange(gomock.Any(), gomock.Any(), int64(0), int64(len(content))).
+					Return(io.NopCloser(bytes.NewReader([]byte(content))), nil)
+			},
+			expectedStatus: fiber.StatusOK,
+			expectedBody:   content,
+		},
+		{
+			name:        "Error - Range not satisfiable",
+			reportID:    reportID,
+			rangeHeader: "bytes=100-",
+			mockSetup: func(mockReportRepo *report.MockRepository, mockTempRepo *template.MockRepository, mockSeaweedFS *reportSeaweed.MockRepository) {
+				expectFinishedReport(mockReportRepo, mockTempRepo)
+
+				mockSeaweedFS.EXPECT().
+					Size(gomock.Any(), gomock.Any()).
+					Return(int64(len(content)), nil)
+			},
+			expectedStatus: fiber.StatusRequestedRangeNotSatisfiable,
+			expectedHeaders: map[string]string{
+				"Content-Range": "bytes */16",
+			},
+		},
+		{
+			name:     "Success - Redirect to the presigned URL",
+			reportID: reportID,
+			query:    "?mode=redirect",
+			mockSetup: func(mockReportRepo *report.MockRepository, mockTempRepo *template.MockRepository, mockSeaweedFS *reportSeaweed.MockRepository) {
+				expectFinishedReport(mockReportRepo, mockTempRepo)
+
+				mockSeaweedFS.EXPECT().
+					PresignedURL(gomock.Any(), gomock.Any(), reportID.String()+".pdf", gomock.Any()).
+					Return(presignedURL, nil)
+			},
+			expectedStatus: fiber.StatusTemporaryRedirect,
+			expectedHeaders: map[string]string{
+				"Location": presignedURL,
+			},
+		},
+		{
+			name:     "Success - Return the presigned URL",
+			reportID: reportID,
+			query:    "?mode=url",
+			mockSetup: func(mockReportRepo *report.MockRepository, mockTempRepo *template.MockRepository, mockSeaweedFS *reportSeaweed.MockRepository) {
+				expectFinishedReport(mockReportRepo, mockTempRepo)
+
+				mockSeaweedFS.EXPECT().
+					PresignedURL(gomock.Any(), gomock.Any(), reportID.String()+".pdf", gomock.Any()).
+					Return(presignedURL, nil)
+			},
+			expectedStatus: fiber.StatusOK,
+		},
+		{
+			name:     "Success - Stream the report when storage cannot presign",
+			reportID: reportID,
+			query:    "?mode=redirect",
+			mockSetup: func(mockReportRepo *report.MockRepository, mockTempRepo *template.MockRepository, mockSeaweedFS *reportSeaweed.MockRepository) {
+				expectFinishedReport(mockReportRepo, mockTempRepo)
+
+				mockSeaweedFS.EXPECT().
+					PresignedURL(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					Return("", constant.ErrPresignNotSupported)
+
+				mockSeaweedFS.EXPECT().
+					Size(gomock.Any(), gomock.Any()).
+					Return(int64(len(content)), nil)
+
+				mockSeaweedFS.EXPECT().
+					GetRange(gomock.Any(), gomock.Any(), int64(0), int64(len(content))).
+					Return(io.NopCloser(bytes.NewReader([]byte(content))), nil)
+			},
+			expectedStatus: fiber.StatusOK,
+			expectedBody:   content,
+		},
+		{
+			name:           "Error - Invalid download mode",
+			reportID:       reportID,
+			query:          "?mode=inline",
+			mockSetup:      func(*report.MockRepository, *template.MockRepository, *reportSeaweed.MockRepository) {},
+			expectedStatus: fiber.StatusBadRequest,
 		},
 		{
 			name:     "Error - Report not found",
 			reportID: reportID,
 			mockSetup: func(mockReportRepo *report.MockRepository, mockTempRepo *template.MockRepository, mockSeaweedFS *reportSeaweed.MockRepository) {
 				mockReportRepo.EXPECT().
-					FindByID(gomock.Any(), reportID).
+					FindByID(gomock.Any(), reportID, gomock.Any()).
 					Return(nil, pkg.ValidateBusinessError(constant.ErrEntityNotFound, "report"))
+				mockReportRepo.EXPECT().
+					FindDeletedByID(gomock.Any(), reportID, gomock.Any()).
+					Return(nil, mongo.ErrNoDocuments)
 			},
 			expectedStatus: fiber.StatusNotFound,
-			expectError:    true,
+		},
+		{
+			name:     "Error - Report purged",
+			reportID: reportID,
+			mockSetup: func(mockReportRepo *report.MockRepository, mockTempRepo *template.MockRepository, mockSeaweedFS *reportSeaweed.MockRepository) {
+				mockReportRepo.EXPECT().
+					FindByID(gomock.Any(), reportID, gomock.Any()).
+					Return(nil, pkg.ValidateBusinessError(constant.ErrEntityNotFound, "report"))
+				mockReportRepo.EXPECT().
+					FindDeletedByID(gomock.Any(), reportID, gomock.Any()).
+					Return(&report.Report{ID: reportID, TemplateID: tempID, Status: constant.FinishedStatus, DeletedAt: &now}, nil)
+			},
+			expectedStatus: fiber.StatusGone,
 		},
 		{
 			name:     "Error - Report not finished",
 			reportID: reportID,
 			mockSetup: func(mockReportRepo *report.MockRepository, mockTempRepo *template.MockRepository, mockSeaweedFS *reportSeaweed.MockRepository) {
 				mockReportRepo.EXPECT().
-					FindByID(gomock.Any(), reportID).
+					FindByID(gomock.Any(), reportID, gomock.Any()).
 					Return(&report.Report{
 						ID:         reportID,
 						TemplateID: tempID,
@@ -447,14 +601,13 @@ func TestReportHandler_GetDownloadReport(t *testing.T) {
 					}, nil)
 			},
 			expectedStatus: fiber.StatusBadRequest, // ErrReportStatusNotFinished returns ValidationError (400)
-			expectError:    true,
 		},
 		{
 			name:     "Error - Template not found",
 			reportID: reportID,
 			mockSetup: func(mockReportRepo *report.MockRepository, mockTempRepo *template.MockRepository, mockSeaweedFS *reportSeaweed.MockRepository) {
 				mockReportRepo.EXPECT().
-					FindByID(gomock.Any(), reportID).
+					FindByID(gomock.Any(), reportID, gomock.Any()).
 					Return(&report.Report{
 						ID:          reportID,
 						TemplateID:  tempID,
@@ -464,40 +617,38 @@ func TestReportHandler_GetDownloadReport(t *testing.T) {
 					}, nil)
 
 				mockTempRepo.EXPECT().
-					FindByID(gomock.Any(), tempID).
+					FindByID(gomock.Any(), tempID, gomock.Any()).
 					Return(nil, pkg.ValidateBusinessError(constant.ErrEntityNotFound, "template"))
 			},
 			expectedStatus: fiber.StatusNotFound,
-			expectError:    true,
 		},
 		{
 			name:     "Error - File not found in SeaweedFS",
 			reportID: reportID,
 			mockSetup: func(mockReportRepo *report.MockRepository, mockTempRepo *template.MockRepository, mockSeaweedFS *reportSeaweed.MockRepository) {
-				mockReportRepo.EXPECT().
-					FindByID(gomock.Any(), reportID).
-					Return(&report.Report{
-						ID:          reportID,
-						TemplateID:  tempID,
-						Status:      constant.FinishedStatus,
-						CreatedAt:   now,
-						CompletedAt: &now,
-					}, nil)
+				expectFinishedReport(mockReportRepo, mockTempRepo)
 
-				mockTempRepo.EXPECT().
-					FindByID(gomock.Any(), tempID).
-					Return(&template.Template{
-						ID:           tempID,
-						OutputFormat: "pdf",
-						FileName:     tempID.String() + ".tpl",
-					}, nil)
+				mockSeaweedFS.EXPECT().
+					Size(gomock.Any(), gomock.Any()).
+					Return(int64(0), constant.ErrInternalServer)
+			},
+			expectedStatus: fiber.StatusInternalServerError,
+		},
+		{
+			name:     "Error - Streaming the file from SeaweedFS fails",
+			reportID: reportID,
+			mockSetup: func(mockReportRepo *report.MockRepository, mockTempRepo *template.MockRepository, mockSeaweedFS *reportSeaweed.MockRepository) {
+				expectFinishedReport(mockReportRepo, mockTempRepo)
+
+				mockSeaweedFS.EXPECT().
+					Size(gomock.Any(), gomock.Any()).
+					Return(int64(len(content)), nil)
 
 				mockSeaweedFS.EXPECT().
-					Get(gomock.Any(), gomock.Any()).
+					GetRange(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
 					Return(nil, constant.ErrInternalServer)
 			},
 			expectedStatus: fiber.StatusInternalServerError,
-			expectError:    true,
 		},
 	}
 
@@ -535,9 +686,144 @@ func TestReportHandler_GetDownloadReport(t *testing.T) {
 				return handler.GetDownloadReport(c)
 			})
 
-			req := httptest.NewRequest("GET", "/v1/reports/"+tt.reportID.String()+"/download", nil)
+			req := httptest.NewRequest("GET", "/v1/reports/"+tt.reportID.String()+"/download"+tt.query, nil)
 			req.Header.Set("Content-Type", "application/json")
 
+			if tt.rangeHeader != "" {
+				req.Header.Set("Range", tt.rangeHeader)
+			}
+
+			resp, err := app.Test(req)
+			require.NoError(t, err)
+			defer resp.Body.Close()
+
+			assert.Equal(t, tt.expectedStatus, resp.StatusCode)
+
+			for header, value := range tt.expectedHeaders {
+				assert.Equal(t, value, resp.Header.Get(header), header)
+			}
+
+			body, err := io.ReadAll(resp.Body)
+			require.NoError(t, err)
+
+			if tt.expectedBody != "" {
+				assert.Equal(t, tt.expectedBody, string(body))
+			}
+
+			if tt.query == "?mode=url" && tt.expectedStatus == fiber.StatusOK {
+				var output model.ReportDownloadURLOutput
+				require.NoError(t, json.Unmarshal(body, &output))
+				assert.Equal(t, presignedURL, output.URL)
+				assert.Equal(t, reportID.String()+".pdf", output.FileName)
+				assert.False(t, output.ExpiresAt.IsZero())
+			}
+		})
+	}
+}
+
+func TestReportHandler_GetReportSignature(t *testing.T) {
+	t.Parallel()
+
+	reportID := uuid.New()
+	tempID := uuid.New()
+
+	now := time.Now()
+
+	finishedReport := func(metadata map[string]any) *report.Report {
+		return &report.Report{
+			ID:          reportID,
+			TemplateID:  tempID,
+			Status:      constant.FinishedStatus,
+			Metadata:    metadata,
+			CreatedAt:   now,
+			CompletedAt: &now,
+		}
+	}
+
+	tests := []struct {
+		name           string
+		mockSetup      func(mockReportRepo *report.MockRepository, mockTempRepo *template.MockRepository, mockSeaweedFS *reportSeaweed.MockRepository)
+		expectedStatus int
+		expectError    bool
+	}{
+		{
+			name: "Success - Download detached signature",
+			mockSetup: func(mockReportRepo *report.MockRepository, mockTempRepo *template.MockRepository, mockSeaweedFS *reportSeaweed.MockRepository) {
+				mockReportRepo.EXPECT().
+					FindByID(gomock.Any(), reportID, gomock.Any()).
+					Return(finishedReport(map[string]any{"signature": "cms"}), nil)
+
+				mockTempRepo.EXPECT().
+					FindByID(gomock.Any(), tempID, gomock.Any()).
+					Return(&template.Template{
+						ID:           tempID,
+						OutputFormat: "csv",
+						FileName:     tempID.String() + ".tpl",
+					}, nil)
+
+				mockSeaweedFS.EXPECT().
+					Get(gomock.Any(), tempID.String()+"/"+reportID.String()+".csv.p7s").
+					Return([]byte("signature"), nil)
+			},
+			expectedStatus: fiber.StatusOK,
+			expectError:    false,
+		},
+		{
+			name: "Error - Signature embedded in the PDF report",
+			mockSetup: func(mockReportRepo *report.MockRepository, mockTempRepo *template.MockRepository, mockSeaweedFS *reportSeaweed.MockRepository) {
+				mockReportRepo.EXPECT().
+					FindByID(gomock.Any(), reportID, gomock.Any()).
+					Return(finishedReport(map[string]any{"signature": "pades"}), nil)
+			},
+			expectedStatus: fiber.StatusBadRequest,
+			expectError:    true,
+		},
+		{
+			name: "Error - Report not signed",
+			mockSetup: func(mockReportRepo *report.MockRepository, mockTempRepo *template.MockRepository, mockSeaweedFS *reportSeaweed.MockRepository) {
+				mockReportRepo.EXPECT().
+					FindByID(gomock.Any(), reportID, gomock.Any()).
+					Return(finishedReport(nil), nil)
+			},
+			expectedStatus: fiber.StatusNotFound,
+			expectError:    true,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockReportRepo := report.NewMockRepository(ctrl)
+			mockTempRepo := template.NewMockRepository(ctrl)
+			mockSeaweedFS := reportSeaweed.NewMockRepository(ctrl)
+
+			tt.mockSetup(mockReportRepo, mockTempRepo, mockSeaweedFS)
+
+			handler := &ReportHandler{
+				service: &services.UseCase{
+					ReportRepo:      mockReportRepo,
+					TemplateRepo:    mockTempRepo,
+					ReportSeaweedFS: mockSeaweedFS,
+				},
+			}
+
+			app := fiber.New(fiber.Config{
+				DisableStartupMessage: true,
+			})
+
+			app.Get("/v1/reports/:id/signature", func(c *fiber.Ctx) error {
+				c.Locals("id", reportID)
+				c.SetUserContext(context.Background())
+				return handler.GetReportSignature(c)
+			})
+
+			req := httptest.NewRequest("GET", "/v1/reports/"+reportID.String()+"/signature", nil)
+
 			resp, err := app.Test(req)
 			require.NoError(t, err)
 			defer resp.Body.Close()
@@ -545,13 +831,443 @@ func TestReportHandler_GetDownloadReport(t *testing.T) {
 			assert.Equal(t, tt.expectedStatus, resp.StatusCode)
 
 			if !tt.expectError {
-				assert.Contains(t, resp.Header.Get("Content-Type"), "application/pdf")
-				assert.Contains(t, resp.Header.Get("Content-Disposition"), "attachment")
+				assert.Equal(t, "application/pkcs7-signature", resp.Header.Get("Content-Type"))
+				assert.Contains(t, resp.Header.Get("Content-Disposition"), reportID.String()+".csv.p7s")
 			}
 		})
 	}
 }
 
+func TestReportHandler_CancelReport(t *testing.T) {
+	t.Parallel()
+
+	reportID := uuid.New()
+	tempID := uuid.New()
+
+	now := time.Now()
+
+	reportWith := func(status string) *report.Report {
+		return &report.Report{
+			ID:         reportID,
+			TemplateID: tempID,
+			Status:     status,
+			CreatedAt:  now,
+		}
+	}
+
+	tests := []struct {
+		name           string
+		mockSetup      func(mockReportRepo *report.MockRepository)
+		expectedStatus int
+		expectError    bool
+	}{
+		{
+			name: "Success - Cancel processing report",
+			mockSetup: func(mockReportRepo *report.MockRepository) {
+				mockReportRepo.EXPECT().
+					FindByID(gomock.Any(), reportID, gomock.Any()).
+					Return(reportWith(constant.ProcessingStatus), nil)
+
+				mockReportRepo.EXPECT().
+					TransitionReportStatus(gomock.Any(), reportID, []string{constant.ProcessingStatus}, constant.CancelledStatus, gomock.Any(), nil).
+					Return(true, nil)
+			},
+			expectedStatus: fiber.StatusOK,
+			expectError:    false,
+		},
+		{
+			name: "Error - Report already finished",
+			mockSetup: func(mockReportRepo *report.MockRepository) {
+				mockReportRepo.EXPECT().
+					FindByID(gomock.Any(), reportID, gomock.Any()).
+					Return(reportWith(constant.FinishedStatus), nil)
+			},
+			expectedStatus: fiber.StatusConflict,
+			expectError:    true,
+		},
+		{
+			name: "Error - Report not found",
+			mockSetup: func(mockReportRepo *report.MockRepository) {
+				mockReportRepo.EXPECT().
+					FindByID(gomock.Any(), reportID, gomock.Any()).
+					Return(nil, pkg.ValidateBusinessError(constant.ErrEntityNotFound, "report"))
+			},
+			expectedStatus: fiber.StatusNotFound,
+			expectError:    true,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockReportRepo := report.NewMockRepository(ctrl)
+
+			tt.mockSetup(mockReportRepo)
+
+			handler := &ReportHandler{
+				service: &services.UseCase{
+					ReportRepo: mockReportRepo,
+				},
+			}
+
+			app := fiber.New(fiber.Config{
+				DisableStartupMessage: true,
+			})
+
+			app.Post("/v1/reports/:id/cancel", func(c *fiber.Ctx) error {
+				c.Locals("id", reportID)
+				c.SetUserContext(context.Background())
+				return handler.CancelReport(c)
+			})
+
+			req := httptest.NewRequest("POST", "/v1/reports/"+reportID.String()+"/cancel", nil)
+
+			resp, err := app.Test(req)
+			require.NoError(t, err)
+			defer resp.Body.Close()
+
+			assert.Equal(t, tt.expectedStatus, resp.StatusCode)
+
+			if !tt.expectError {
+				body, err := io.ReadAll(resp.Body)
+				require.NoError(t, err)
+
+				var result report.Report
+				err = json.Unmarshal(body, &result)
+				require.NoError(t, err)
+
+				assert.Equal(t, constant.CancelledStatus, result.Status)
+				assert.NotNil(t, result.CompletedAt)
+			}
+		})
+	}
+}
+
+func TestReportHandler_DeleteReport(t *testing.T) {
+	t.Parallel()
+
+	reportID := uuid.New()
+	tempID := uuid.New()
+
+	now := time.Now()
+	objectName := tempID.String() + "/" + reportID.String() + ".csv"
+
+	finished := &report.Report{
+		ID:          reportID,
+		TemplateID:  tempID,
+		Status:      constant.FinishedStatus,
+		CreatedAt:   now,
+		CompletedAt: &now,
+		Message:     &model.ReportMessage{TemplateID: tempID, ReportID: reportID, OutputFormat: "csv"},
+	}
+
+	tests := []struct {
+		name           string
+		mockSetup      func(mockReportRepo *report.MockRepository, mockSeaweedFS *reportSeaweed.MockRepository)
+		expectedStatus int
+	}{
+		{
+			name: "Success - Delete finished report",
+			mockSetup: func(mockReportRepo *report.MockRepository, mockSeaweedFS *reportSeaweed.MockRepository) {
+				mockReportRepo.EXPECT().FindByID(gomock.Any(), reportID, gomock.Any()).Return(finished, nil)
+				mockReportRepo.EXPECT().SoftDelete(gomock.Any(), reportID, uuid.Nil, gomock.Any(), []string{objectName}).Return(true, nil)
+				mockSeaweedFS.EXPECT().Delete(gomock.Any(), objectName).Return(nil)
+				mockReportRepo.EXPECT().ClearPendingFiles(gomock.Any(), reportID, uuid.Nil).Return(nil)
+			},
+			expectedStatus: fiber.StatusNoContent,
+		},
+		{
+			name: "Error - Report under legal hold",
+			mockSetup: func(mockReportRepo *report.MockRepository, mockSeaweedFS *reportSeaweed.MockRepository) {
+				held := *finished
+				held.LegalHold = true
+
+				mockReportRepo.EXPECT().FindByID(gomock.Any(), reportID, gomock.Any()).Return(&held, nil)
+			},
+			expectedStatus: fiber.StatusConflict,
+		},
+		{
+			name: "Error - Report not found",
+			mockSetup: func(mockReportRepo *report.MockRepository, mockSeaweedFS *reportSeaweed.MockRepository) {
+				mockReportRepo.EXPECT().
+					FindByID(gomock.Any(), reportID, gomock.Any()).
+					Return(nil, pkg.ValidateBusinessError(constant.ErrEntityNotFound, "report"))
+			},
+			expectedStatus: fiber.StatusNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockReportRepo := report.NewMockRepository(ctrl)
+			mockSeaweedFS := reportSeaweed.NewMockRepository(ctrl)
+
+			tt.mockSetup(mockReportRepo, mockSeaweedFS)
+
+			handler := &ReportHandler{
+				service: &services.UseCase{
+					ReportRepo:      mockReportRepo,
+					ReportSeaweedFS: mockSeaweedFS,
+				},
+			}
+
+			app := fiber.New(fiber.Config{
+				DisableStartupMessage: true,
+			})
+			app.Delete("/v1/reports/:id", func(c *fiber.Ctx) error {
+				c.Locals("id", reportID)
+				c.SetUserContext(context.Background())
+				return handler.DeleteReport(c)
+			})
+
+			req := httptest.NewRequest("DELETE", "/v1/reports/"+reportID.String(), nil)
+
+			resp, err := app.Test(req)
+			require.NoError(t, err)
+			defer resp.Body.Close()
+
+			assert.Equal(t, tt.expectedStatus, resp.StatusCode)
+		})
+	}
+}
+
+func TestReportHandler_ReportLegalHold(t *testing.T) {
+	t.Parallel()
+
+	reportID := uuid.New()
+
+	tests := []struct {
+		name           string
+		method         string
+		legalHold      bool
+		repoErr        error
+		expectedStatus int
+	}{
+		{name: "Success - Place legal hold", method: "POST", legalHold: true, expectedStatus: fiber.StatusOK},
+		{name: "Success - Release legal hold", method: "DELETE", legalHold: false, expectedStatus: fiber.StatusOK},
+		{name: "Error - Report not found", method: "POST", legalHold: true, repoErr: mongo.ErrNoDocuments, expectedStatus: fiber.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockReportRepo := report.NewMockRepository(ctrl)
+
+			var updated *report.Report
+			if tt.repoErr == nil {
+				updated = &report.Report{ID: reportID, Status: constant.FinishedStatus, LegalHold: tt.legalHold}
+			}
+
+			mockReportRepo.EXPECT().
+				SetLegalHold(gomock.Any(), reportID, uuid.Nil, tt.legalHold).
+				Return(updated, tt.repoErr)
+
+			handler := &ReportHandler{
+				service: &services.UseCase{
+					ReportRepo: mockReportRepo,
+				},
+			}
+
+			app := fiber.New(fiber.Config{
+				DisableStartupMessage: true,
+			})
+			app.Post("/v1/reports/:id/legal-hold", func(c *fiber.Ctx) error {
+				c.Locals("id", reportID)
+				c.SetUserContext(context.Background())
+				return handler.PlaceReportLegalHold(c)
+			})
+			app.Delete("/v1/reports/:id/legal-hold", func(c *fiber.Ctx) error {
+				c.Locals("id", reportID)
+				c.SetUserContext(context.Background())
+				return handler.ReleaseReportLegalHold(c)
+			})
+
+			req := httptest.NewRequest(tt.method, "/v1/reports/"+reportID.String()+"/legal-hold", nil)
+
+			resp, err := app.Test(req)
+			require.NoError(t, err)
+			defer resp.Body.Close()
+
+			assert.Equal(t, tt.expectedStatus, resp.StatusCode)
+
+			if tt.expectedStatus == fiber.StatusOK {
+				var result report.Report
+				require.NoError(t, json.NewDecoder(resp.Body).Decode(&result))
+				assert.Equal(t, tt.legalHold, result.LegalHold)
+			}
+		})
+	}
+}
+
+func TestReportHandler_RetryReport(t *testing.T) {
+	t.Parallel()
+
+	reportID := uuid.New()
+	tempID := uuid.New()
+
+	message := &model.ReportMessage{TemplateID: tempID, ReportID: reportID, OutputFormat: "csv"}
+
+	tests := []struct {
+		name           string
+		mockSetup      func(mockReportRepo *report.MockRepository, mockRabbitMQ *rabbitmq.MockProducerRepository, mockOutboxRepo *outbox.MockRepository)
+		expectedStatus int
+	}{
+		{
+			name: "Success - Retry failed report",
+			mockSetup: func(mockReportRepo *report.MockRepository, mockRabbitMQ *rabbitmq.MockProducerRepository, mockOutboxRepo *outbox.MockRepository) {
+				mockReportRepo.EXPECT().
+					FindByID(gomock.Any(), reportID, gomock.Any()).
+					Return(&report.Report{ID: reportID, TemplateID: tempID, Status: constant.ErrorStatus, Message: message}, nil)
+
+				mockReportRepo.EXPECT().
+					ResetForRetryWithOutbox(gomock.Any(), reportID, gomock.Any(), gomock.Any(), message, gomock.Any(), gomock.Any()).
+					Return(&report.Report{ID: reportID, TemplateID: tempID, Status: constant.ProcessingStatus}, nil)
+
+				mockRabbitMQ.EXPECT().
+					ProducerDefault(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(nil, nil)
+
+				mockOutboxRepo.EXPECT().
+					MarkDispatched(gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(nil)
+			},
+			expectedStatus: fiber.StatusOK,
+		},
+		{
+			name: "Error - Report already finished",
+			mockSetup: func(mockReportRepo *report.MockRepository, _ *rabbitmq.MockProducerRepository, _ *outbox.MockRepository) {
+				mockReportRepo.EXPECT().
+					FindByID(gomock.Any(), reportID, gomock.Any()).
+					Return(&report.Report{ID: reportID, TemplateID: tempID, Status: constant.FinishedStatus}, nil)
+			},
+			expectedStatus: fiber.StatusConflict,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockReportRepo := report.NewMockRepository(ctrl)
+			mockRabbitMQ := rabbitmq.NewMockProducerRepository(ctrl)
+			mockOutboxRepo := outbox.NewMockRepository(ctrl)
+
+			tt.mockSetup(mockReportRepo, mockRabbitMQ, mockOutboxRepo)
+
+			handler := &ReportHandler{
+				service: &services.UseCase{
+					ReportRepo:   mockReportRepo,
+					RabbitMQRepo: mockRabbitMQ,
+					OutboxRepo:   mockOutboxRepo,
+				},
+			}
+
+			app := fiber.New(fiber.Config{
+				DisableStartupMessage: true,
+			})
+
+			app.Post("/v1/reports/:id/retry", func(c *fiber.Ctx) error {
+				c.Locals("id", reportID)
+				c.SetUserContext(context.Background())
+				return handler.RetryReport(c)
+			})
+
+			req := httptest.NewRequest("POST", "/v1/reports/"+reportID.String()+"/retry", nil)
+
+			resp, err := app.Test(req)
+			require.NoError(t, err)
+			defer resp.Body.Close()
+
+			assert.Equal(t, tt.expectedStatus, resp.StatusCode)
+		})
+	}
+}
+
+func TestReportHandler_RetryReports(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	tempID := uuid.New()
+	reportID := uuid.New()
+	message := &model.ReportMessage{TemplateID: tempID, ReportID: reportID, OutputFormat: "csv"}
+
+	mockReportRepo := report.NewMockRepository(ctrl)
+	mockRabbitMQ := rabbitmq.NewMockProducerRepository(ctrl)
+
+	mockReportRepo.EXPECT().
+		FindByStatus(gomock.Any(), gomock.Any()).
+		Return([]*report.Report{{ID: reportID, TemplateID: tempID, Status: constant.ErrorStatus, Message: message}}, nil)
+
+	mockReportRepo.EXPECT().
+		ResetForRetryWithOutbox(gomock.Any(), reportID, gomock.Any(), gomock.Any(), message, gomock.Any(), gomock.Any()).
+		Return(&report.Report{ID: reportID, Status: constant.ProcessingStatus}, nil)
+
+	mockRabbitMQ.EXPECT().
+		ProducerDefault(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+		Return(nil, nil)
+
+	mockOutboxRepo := outbox.NewMockRepository(ctrl)
+	mockOutboxRepo.EXPECT().
+		MarkDispatched(gomock.Any(), gomock.Any(), gomock.Any()).
+		Return(nil)
+
+	handler := &ReportHandler{
+		service: &services.UseCase{
+			ReportRepo:   mockReportRepo,
+			RabbitMQRepo: mockRabbitMQ,
+			OutboxRepo:   mockOutboxRepo,
+		},
+	}
+
+	app := fiber.New(fiber.Config{
+		DisableStartupMessage: true,
+	})
+
+	payload := model.RetryReportsInput{TemplateID: tempID.String()}
+
+	app.Post("/v1/reports/retry", func(c *fiber.Ctx) error {
+		c.SetUserContext(context.Background())
+		return handler.RetryReports(&payload, c)
+	})
+
+	payloadBytes, _ := json.Marshal(payload)
+	req := httptest.NewRequest("POST", "/v1/reports/retry", bytes.NewReader(payloadBytes))
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := app.Test(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+
+	var output model.RetryReportsOutput
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&output))
+	assert.Equal(t, []uuid.UUID{reportID}, output.Retried)
+	assert.Empty(t, output.Failed)
+}
+
 func TestNewReportHandler_NilService(t *testing.T) {
 	t.Parallel()
 
@@ -620,7 +1336,7 @@ func TestReportHandler_GetReport_InternalError(t *testing.T) {
 	mockReportRepo := report.NewMockRepository(ctrl)
 
 	mockReportRepo.EXPECT().
-		FindByID(gomock.Any(), reportID).
+		FindByID(gomock.Any(), reportID, gomock.Any()).
 		Return(nil, constant.ErrInternalServer)
 
 	svc := &services.UseCase{
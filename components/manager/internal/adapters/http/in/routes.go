@@ -29,19 +29,34 @@ const (
 	templateResource      = "templates"
 	reportResource        = "reports"
 	dataSourceResource    = "data-source"
+	adminResource         = "admin"
 	readinessCheckTimeout = 2 * time.Second
 )
 
+// ReportReaperStatsProvider exposes the totals of the stuck-report reaper.
+type ReportReaperStatsProvider interface {
+	Stats() model.ReportReaperStats
+}
+
+// ReportPurgerStatsProvider exposes the totals of the retention purge.
+type ReportPurgerStatsProvider interface {
+	Stats() model.ReportPurgerStats
+}
+
 // ReadinessDeps holds the dependency connections needed for the /ready endpoint.
+// ReportReaper and ReportPurger are nil when the stuck-report reaper or the retention purge are disabled.
 type ReadinessDeps struct {
 	MongoConnection    *mongoDB.MongoConnection
 	RabbitMQConnection *libRabbitmq.RabbitMQConnection
 	RedisConnection    *libRedis.RedisConnection
 	StorageClient      storage.ObjectStorage
+	ReportReaper       ReportReaperStatsProvider
+	ReportPurger       ReportPurgerStatsProvider
 }
 
 // NewRoutes creates a new fiber router with the specified handlers and middleware.
-func NewRoutes(lg log.Logger, tl *opentelemetry.Telemetry, templateHandler *TemplateHandler, reportHandler *ReportHandler, dataSourceHandler *DataSourceHandler, auth *middlewareAuth.AuthClient, deps *ReadinessDeps, corsConfig CORSConfig, rateLimitConfig RateLimitConfig, trustedProxies []string) *fiber.App {
+// When multiTenantEnabled is true, template and report routes require an organization ID.
+func NewRoutes(lg log.Logger, tl *opentelemetry.Telemetry, templateHandler *TemplateHandler, reportHandler *ReportHandler, dataSourceHandler *DataSourceHandler, deadLetterHandler *DeadLetterHandler, auth *middlewareAuth.AuthClient, deps *ReadinessDeps, corsConfig CORSConfig, rateLimitConfig RateLimitConfig, trustedProxies []string, multiTenantEnabled bool) *fiber.App {
 	fiberCfg := fiber.Config{
 		DisableStartupMessage: true,
 		ErrorHandler: func(ctx *fiber.Ctx, err error) error {
@@ -72,24 +87,43 @@ func NewRoutes(lg log.Logger, tl *opentelemetry.Telemetry, templateHandler *Temp
 	f.Use(RateLimiterMiddleware(rateLimitConfig))
 	f.Use(commonsHttp.WithHTTPLogging(commonsHttp.WithCustomLogger(lg)))
 
+	tenant := WithOrganizationID(multiTenantEnabled)
+
 	// Plugin templates routes
 	// Template routes
-	f.Post("/v1/templates", auth.Authorize(applicationName, templateResource, "post"), templateHandler.CreateTemplate)
-	f.Patch("/v1/templates/:id", auth.Authorize(applicationName, templateResource, "patch"), ParsePathParametersUUID, templateHandler.UpdateTemplateByID)
-	f.Get("/v1/templates/:id", auth.Authorize(applicationName, templateResource, "get"), ParsePathParametersUUID, templateHandler.GetTemplateByID)
-	f.Get("/v1/templates", auth.Authorize(applicationName, templateResource, "get"), templateHandler.GetAllTemplates)
-	f.Delete("/v1/templates/:id", auth.Authorize(applicationName, templateResource, "delete"), ParsePathParametersUUID, templateHandler.DeleteTemplateByID)
+	f.Post("/v1/templates", auth.Authorize(applicationName, templateResource, "post"), tenant, templateHandler.CreateTemplate)
+	f.Patch("/v1/templates/:id", auth.Authorize(applicationName, templateResource, "patch"), tenant, ParsePathParametersUUID, templateHandler.UpdateTemplateByID)
+	f.Get("/v1/templates/:id", auth.Authorize(applicationName, templateResource, "get"), tenant, ParsePathParametersUUID, templateHandler.GetTemplateByID)
+	f.Get("/v1/templates", auth.Authorize(applicationName, templateResource, "get"), tenant, templateHandler.GetAllTemplates)
+	f.Delete("/v1/templates/:id", auth.Authorize(applicationName, templateResource, "delete"), tenant, ParsePathParametersUUID, templateHandler.DeleteTemplateByID)
 
 	// Report routes
-	f.Post("/v1/reports", auth.Authorize(applicationName, reportResource, "post"), http.WithBody(new(model.CreateReportInput), reportHandler.CreateReport))
-	f.Get("/v1/reports/:id/download", auth.Authorize(applicationName, reportResource, "get"), ParsePathParametersUUID, reportHandler.GetDownloadReport)
-	f.Get("/v1/reports/:id", auth.Authorize(applicationName, reportResource, "get"), ParsePathParametersUUID, reportHandler.GetReport)
-	f.Get("/v1/reports", auth.Authorize(applicationName, reportResource, "get"), reportHandler.GetAllReports)
+	f.Post("/v1/reports", auth.Authorize(applicationName, reportResource, "post"), tenant, WithAuthClaims(), http.WithBody(new(model.CreateReportInput), reportHandler.CreateReport))
+	f.Post("/v1/reports/retry", auth.Authorize(applicationName, reportResource, "post"), tenant, WithAuthClaims(), http.WithBody(new(model.RetryReportsInput), reportHandler.RetryReports))
+	f.Get("/v1/reports/:id/download", auth.Authorize(applicationName, reportResource, "get"), tenant, ParsePathParametersUUID, reportHandler.GetDownloadReport)
+	f.Get("/v1/reports/:id/signature", auth.Authorize(applicationName, reportResource, "get"), tenant, ParsePathParametersUUID, reportHandler.GetReportSignature)
+	f.Post("/v1/reports/:id/cancel", auth.Authorize(applicationName, reportResource, "post"), tenant, ParsePathParametersUUID, reportHandler.CancelReport)
+	f.Post("/v1/reports/:id/legal-hold", auth.Authorize(applicationName, reportResource, "post"), tenant, ParsePathParametersUUID, reportHandler.PlaceReportLegalHold)
+	f.Delete("/v1/reports/:id/legal-hold", auth.Authorize(applicationName, reportResource, "delete"), tenant, ParsePathParametersUUID, reportHandler.ReleaseReportLegalHold)
+	f.Post("/v1/reports/:id/retry", auth.Authorize(applicationName, reportResource, "post"), tenant, WithAuthClaims(), ParsePathParametersUUID, reportHandler.RetryReport)
+	f.Get("/v1/reports/:id", auth.Authorize(applicationName, reportResource, "get"), tenant, ParsePathParametersUUID, reportHandler.GetReport)
+	f.Delete("/v1/reports/:id", auth.Authorize(applicationName, reportResource, "delete"), tenant, ParsePathParametersUUID, reportHandler.DeleteReport)
+	f.Get("/v1/reports", auth.Authorize(applicationName, reportResource, "get"), tenant, reportHandler.GetAllReports)
+
+	// Report batch routes
+	f.Post("/v1/report-batches", auth.Authorize(applicationName, reportResource, "post"), tenant, WithAuthClaims(), http.WithBody(new(model.CreateReportBatchInput), reportHandler.CreateReportBatch))
+	f.Get("/v1/report-batches/:id/download", auth.Authorize(applicationName, reportResource, "get"), tenant, ParsePathParametersUUID, reportHandler.GetDownloadReportBatch)
+	f.Get("/v1/report-batches/:id", auth.Authorize(applicationName, reportResource, "get"), tenant, ParsePathParametersUUID, reportHandler.GetReportBatch)
 
 	// Data source routes
 	f.Get("/v1/data-sources", auth.Authorize(applicationName, dataSourceResource, "get"), dataSourceHandler.GetDataSourceInformation)
 	f.Get("/v1/data-sources/:dataSourceId", auth.Authorize(applicationName, dataSourceResource, "get"), ParseStringPathParam("dataSourceId"), dataSourceHandler.GetDataSourceInformationByID)
 
+	// Admin routes
+	f.Get("/v1/admin/dead-letters", auth.Authorize(applicationName, adminResource, "get"), deadLetterHandler.GetDeadLetters)
+	f.Post("/v1/admin/dead-letters/replay", auth.Authorize(applicationName, adminResource, "post"), http.WithBody(new(model.ReplayDeadLettersInput), deadLetterHandler.ReplayDeadLetters))
+	f.Delete("/v1/admin/dead-letters", auth.Authorize(applicationName, adminResource, "delete"), deadLetterHandler.PurgeDeadLetters)
+
 	// Doc Swagger
 	f.Get("/swagger/*", WithSwaggerEnvConfig(), fiberSwagger.WrapHandler)
 
@@ -115,6 +149,7 @@ type dependencyResult struct {
 
 // readinessHandler returns a Fiber handler that checks all dependency connections.
 // Each dependency is checked with a 2-second timeout. Returns 200 if all healthy, 503 otherwise.
+// The stuck-report reaper totals are reported when the reaper is enabled and never affect the status.
 func readinessHandler(deps *ReadinessDeps) fiber.Handler {
 	return func(c *fiber.Ctx) error {
 		httpStatus := fiber.StatusOK
@@ -145,10 +180,20 @@ func readinessHandler(deps *ReadinessDeps) fiber.Handler {
 			overallStatus = "not_ready"
 		}
 
-		return commonsHttp.JSONResponse(c, httpStatus, fiber.Map{
+		response := fiber.Map{
 			"status":       overallStatus,
 			"dependencies": results,
-		})
+		}
+
+		if deps.ReportReaper != nil {
+			response["reportReaper"] = deps.ReportReaper.Stats()
+		}
+
+		if deps.ReportPurger != nil {
+			response["reportPurger"] = deps.ReportPurger.Stats()
+		}
+
+		return commonsHttp.JSONResponse(c, httpStatus, response)
 	}
 }
 
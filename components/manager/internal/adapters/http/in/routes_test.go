@@ -5,10 +5,13 @@
 package in
 
 import (
+	"encoding/json"
 	"net/http"
 	"net/http/httptest"
 	"testing"
 
+	"github.com/LerianStudio/reporter/pkg/model"
+
 	"github.com/gofiber/fiber/v2"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -170,3 +173,97 @@ func TestRecoverMiddleware_NonPanicRouteUnaffected(t *testing.T) {
 	assert.Equal(t, http.StatusOK, resp.StatusCode,
 		"Non-panicking routes must work normally with recover middleware")
 }
+
+// staticReaperStats is a ReportReaperStatsProvider returning fixed totals.
+type staticReaperStats model.ReportReaperStats
+
+func (s staticReaperStats) Stats() model.ReportReaperStats {
+	return model.ReportReaperStats(s)
+}
+
+func TestReadinessHandler_ReportReaper(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		reaper     ReportReaperStatsProvider
+		wantReaper bool
+	}{
+		{
+			name:       "Reaper totals reported when enabled",
+			reaper:     staticReaperStats{ReapReportsResult: model.ReapReportsResult{Requeued: 2, TimedOut: 1}, Leader: true, Sweeps: 3},
+			wantReaper: true,
+		},
+		{
+			name: "Reaper omitted when disabled",
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			app := fiber.New()
+			app.Get("/ready", readinessHandler(&ReadinessDeps{ReportReaper: tt.reaper}))
+
+			resp, err := app.Test(httptest.NewRequest(http.MethodGet, "/ready", nil))
+			require.NoError(t, err)
+
+			defer resp.Body.Close()
+
+			// No dependency is configured, so the reaper totals never make the service ready
+			assert.Equal(t, http.StatusServiceUnavailable, resp.StatusCode)
+
+			var body map[string]any
+			require.NoError(t, json.NewDecoder(resp.Body).Decode(&body))
+
+			reaper, ok := body["reportReaper"].(map[string]any)
+			if !tt.wantReaper {
+				assert.False(t, ok)
+
+				return
+			}
+
+			require.True(t, ok)
+			assert.EqualValues(t, 2, reaper["requeued"])
+			assert.EqualValues(t, 1, reaper["timedOut"])
+			assert.EqualValues(t, 3, reaper["sweeps"])
+			assert.Equal(t, true, reaper["leader"])
+		})
+	}
+}
+
+// staticPurgerStats is a ReportPurgerStatsProvider returning fixed totals.
+type staticPurgerStats model.ReportPurgerStats
+
+func (s staticPurgerStats) Stats() model.ReportPurgerStats {
+	return model.ReportPurgerStats(s)
+}
+
+func TestReadinessHandler_ReportPurger(t *testing.T) {
+	t.Parallel()
+
+	app := fiber.New()
+	app.Get("/ready", readinessHandler(&ReadinessDeps{
+		ReportPurger: staticPurgerStats{PurgeReportsResult: model.PurgeReportsResult{Purged: 4, Failed: 1}, Leader: true, Sweeps: 2},
+	}))
+
+	resp, err := app.Test(httptest.NewRequest(http.MethodGet, "/ready", nil))
+	require.NoError(t, err)
+
+	defer resp.Body.Close()
+
+	var body map[string]any
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&body))
+
+	purger, ok := body["reportPurger"].(map[string]any)
+	require.True(t, ok)
+	assert.EqualValues(t, 4, purger["purged"])
+	assert.EqualValues(t, 1, purger["failed"])
+	assert.EqualValues(t, 2, purger["sweeps"])
+	assert.Equal(t, true, purger["leader"])
+
+	_, hasReaper := body["reportReaper"]
+	assert.False(t, hasReaper)
+}
@@ -14,6 +14,7 @@ import (
 	"github.com/LerianStudio/reporter/pkg/model"
 	_ "github.com/LerianStudio/reporter/pkg/mongodb/template"
 	"github.com/LerianStudio/reporter/pkg/net/http"
+	templateUtils "github.com/LerianStudio/reporter/pkg/templateutils"
 
 	"github.com/LerianStudio/lib-commons/v2/commons"
 	libConstants "github.com/LerianStudio/lib-commons/v2/commons/constants"
@@ -47,10 +48,15 @@ func NewTemplateHandler(service *services.UseCase) (*TemplateHandler, error) {
 //	@Accept			mpfd
 //	@Produce		json
 //	@Security		BearerAuth
+//	@Param			X-Organization-Id	header		string	false	"Organization ID, honored only for trusted service tokens; other callers use the organization_id token claim"
 //	@Param			X-Idempotency		header		string	false	"Client-provided idempotency key to prevent duplicate template creation"
 //	@Param			templateFile		formData	file	true	"Template file (.tpl)"
 //	@Param			outputFormat		formData	string	true	"Output format (e.g., pdf, html)"
 //	@Param			description			formData	string	true	"Description of the template"
+//	@Param			partialName			formData	string	false	"Stores the template as a partial that other templates can include, extend or import by this name (e.g., layouts/corporate)"
+//	@Param			priority			formData	string	false	"Generation priority of the reports of the template: high, normal (default) or low"
+//	@Param			retentionDays		formData	integer	false	"Days the reports of the template are kept after completing before they are purged, up to 36500; 0 (default) applies the tenant or global retention"
+//	@Param			outputOptions		formData	string	false	"JSON output options: encoding and line endings of text reports and page setup of PDF reports, e.g. {\"encoding\":\"windows-1252\",\"lineEnding\":\"crlf\"} or {\"pdf\":{\"paperSize\":\"a4\"}}"
 //	@Success		201					{object}	template.Template
 //	@Failure		400					{object}	pkg.HTTPError
 //	@Failure		401					{object}	pkg.HTTPError
@@ -79,13 +85,51 @@ func (th *TemplateHandler) CreateTemplate(c *fiber.Ctx) error {
 
 	outputFormat := c.FormValue("outputFormat")
 	description := c.FormValue("description")
+	partialName := c.FormValue("partialName")
+	priority := c.FormValue("priority")
+	rawRetentionDays := c.FormValue("retentionDays")
+	rawOutputOptions := c.FormValue("outputOptions")
 
 	span.SetAttributes(
 		attribute.String("app.request.request_id", reqId),
 		attribute.String("app.request.output_format", outputFormat),
 		attribute.String("app.request.description", description),
+		attribute.String("app.request.partial_name", partialName),
+		attribute.String("app.request.priority", priority),
+		attribute.String("app.request.retention_days", rawRetentionDays),
+		attribute.String("app.request.output_options", rawOutputOptions),
 	)
 
+	if partialName != "" {
+		if errPartial := templateUtils.ValidatePartialName(partialName); errPartial != nil {
+			errValidate := pkg.ValidateBusinessError(constant.ErrInvalidPartialName, "", partialName)
+
+			libOpentelemetry.HandleSpanBusinessErrorEvent(&span, "Invalid partial name", errValidate)
+
+			return http.WithError(c, errValidate)
+		}
+	}
+
+	if errPriority := pkg.ValidateTemplatePriority(priority); errPriority != nil {
+		libOpentelemetry.HandleSpanBusinessErrorEvent(&span, "Invalid priority", errPriority)
+
+		return http.WithError(c, errPriority)
+	}
+
+	retentionDays, errRetention := pkg.ParseTemplateRetentionDays(rawRetentionDays)
+	if errRetention != nil {
+		libOpentelemetry.HandleSpanBusinessErrorEvent(&span, "Invalid retention days", errRetention)
+
+		return http.WithError(c, errRetention)
+	}
+
+	outputOptions, errOptions := pkg.ParseOutputOptions(rawOutputOptions)
+	if errOptions != nil {
+		libOpentelemetry.HandleSpanBusinessErrorEvent(&span, "Invalid output options", errOptions)
+
+		return http.WithError(c, errOptions)
+	}
+
 	fileHeader, err := c.FormFile("template")
 	if err != nil {
 		libOpentelemetry.HandleSpanBusinessErrorEvent(&span, "Failed to get template file from form", err)
@@ -123,7 +167,7 @@ func (th *TemplateHandler) CreateTemplate(c *fiber.Ctx) error {
 		return http.WithError(c, errValidateFile)
 	}
 
-	templateOut, err := th.service.CreateTemplate(ctx, templateFile, outputFormat, description, fileHeader)
+	templateOut, err := th.service.CreateTemplate(ctx, templateFile, outputFormat, description, partialName, priority, retentionDays, outputOptions, fileHeader, organizationIDFromLocals(c))
 	if err != nil {
 		if http.IsBusinessError(err) {
 			libOpentelemetry.HandleSpanBusinessErrorEvent(&span, "Failed to create template", err)
@@ -151,9 +195,13 @@ func (th *TemplateHandler) CreateTemplate(c *fiber.Ctx) error {
 //	@Accept			mpfd
 //	@Produce		json
 //	@Security		BearerAuth
+//	@Param			X-Organization-Id	header		string	false	"Organization ID, honored only for trusted service tokens; other callers use the organization_id token claim"
 //	@Param			templateFile	formData	file	true	"Template file (.tpl)"
 //	@Param			outputFormat	formData	string	true	"Output format (e.g., pdf, html)"
 //	@Param			description		formData	string	true	"Description of the template"
+//	@Param			priority		formData	string	false	"Generation priority of the reports of the template: high, normal or low"
+//	@Param			retentionDays	formData	integer	false	"Days the reports of the template are kept after completing before they are purged, up to 36500; 0 applies the tenant or global retention"
+//	@Param			outputOptions	formData	string	false	"JSON output options of text and PDF reports; replaces the current options"
 //	@Param			id				path		string	true	"Template ID"
 //	@Success		200				{object}	template.Template
 //	@Failure		400				{object}	pkg.HTTPError
@@ -175,14 +223,40 @@ func (th *TemplateHandler) UpdateTemplateByID(c *fiber.Ctx) error {
 
 	outputFormat := c.FormValue("outputFormat")
 	description := c.FormValue("description")
+	priority := c.FormValue("priority")
+	rawRetentionDays := c.FormValue("retentionDays")
+	rawOutputOptions := c.FormValue("outputOptions")
 
 	span.SetAttributes(
 		attribute.String("app.request.request_id", reqId),
 		attribute.String("app.request.template_id", id.String()),
 		attribute.String("app.request.output_format", outputFormat),
 		attribute.String("app.request.description", description),
+		attribute.String("app.request.priority", priority),
+		attribute.String("app.request.retention_days", rawRetentionDays),
+		attribute.String("app.request.output_options", rawOutputOptions),
 	)
 
+	if errPriority := pkg.ValidateTemplatePriority(priority); errPriority != nil {
+		libOpentelemetry.HandleSpanBusinessErrorEvent(&span, "Invalid priority", errPriority)
+
+		return http.WithError(c, errPriority)
+	}
+
+	retentionDays, errRetention := pkg.ParseTemplateRetentionDays(rawRetentionDays)
+	if errRetention != nil {
+		libOpentelemetry.HandleSpanBusinessErrorEvent(&span, "Invalid retention days", errRetention)
+
+		return http.WithError(c, errRetention)
+	}
+
+	outputOptions, errOptions := pkg.ParseOutputOptions(rawOutputOptions)
+	if errOptions != nil {
+		libOpentelemetry.HandleSpanBusinessErrorEvent(&span, "Invalid output options", errOptions)
+
+		return http.WithError(c, errOptions)
+	}
+
 	fileHeader, err := c.FormFile("template")
 	if err != nil && err.Error() != constant.ErrFileAccepted {
 		libOpentelemetry.HandleSpanBusinessErrorEvent(&span, "Failed to get template file from form", err)
@@ -195,7 +269,7 @@ func (th *TemplateHandler) UpdateTemplateByID(c *fiber.Ctx) error {
 		libOpentelemetry.HandleSpanError(&span, "Failed to set span attributes from struct", err)
 	}
 
-	templateUpdated, errUpdate := th.service.UpdateTemplateByID(ctx, outputFormat, description, id, fileHeader)
+	templateUpdated, errUpdate := th.service.UpdateTemplateByID(ctx, outputFormat, description, priority, retentionDays, outputOptions, id, fileHeader, organizationIDFromLocals(c))
 	if errUpdate != nil {
 		if http.IsBusinessError(errUpdate) {
 			libOpentelemetry.HandleSpanBusinessErrorEvent(&span, "Failed to update template", errUpdate)
@@ -220,6 +294,7 @@ func (th *TemplateHandler) UpdateTemplateByID(c *fiber.Ctx) error {
 //	@Tags			Templates
 //	@Produce		json
 //	@Security		BearerAuth
+//	@Param			X-Organization-Id	header		string	false	"Organization ID, honored only for trusted service tokens; other callers use the organization_id token claim"
 //	@Param			id				path		string	true	"Template ID"
 //	@Success		200				{object}	template.Template
 //	@Failure		400				{object}	pkg.HTTPError
@@ -245,7 +320,7 @@ func (th *TemplateHandler) GetTemplateByID(c *fiber.Ctx) error {
 		attribute.String("app.request.template_id", id.String()),
 	)
 
-	templateModel, err := th.service.GetTemplateByID(ctx, id)
+	templateModel, err := th.service.GetTemplateByID(ctx, id, organizationIDFromLocals(c))
 	if err != nil {
 		if http.IsBusinessError(err) {
 			libOpentelemetry.HandleSpanBusinessErrorEvent(&span, "Failed to retrieve template on query", err)
@@ -270,7 +345,8 @@ func (th *TemplateHandler) GetTemplateByID(c *fiber.Ctx) error {
 //	@Tags			Templates
 //	@Produce		json
 //	@Security		BearerAuth
-//	@Param			output_format	query		string	false	"Output format filter: XML, HTML, TXT, CSV (also accepts outputFormat)"
+//	@Param			X-Organization-Id	header		string	false	"Organization ID, honored only for trusted service tokens; other callers use the organization_id token claim"
+//	@Param			output_format	query		string	false	"Output format filter: XML, HTML, TXT, CSV, FIXED-WIDTH (also accepts outputFormat)"
 //	@Param			description		query		string	false	"Description of template"
 //	@Param			limit			query		int		false	"Limit"	default(10)
 //	@Param			page			query		int		false	"Page"	default(1)
@@ -313,7 +389,7 @@ func (th *TemplateHandler) GetAllTemplates(c *fiber.Ctx) error {
 		libOpentelemetry.HandleSpanError(&span, "Failed to convert query params to JSON string", err)
 	}
 
-	templates, err := th.service.GetAllTemplates(ctx, *headerParams)
+	templates, err := th.service.GetAllTemplates(ctx, *headerParams, organizationIDFromLocals(c))
 	if err != nil {
 		if http.IsBusinessError(err) {
 			libOpentelemetry.HandleSpanBusinessErrorEvent(&span, "Failed to retrieve all Templates on query", err)
@@ -341,6 +417,7 @@ func (th *TemplateHandler) GetAllTemplates(c *fiber.Ctx) error {
 //	@Tags			Templates
 //	@Produce		json
 //	@Security		BearerAuth
+//	@Param			X-Organization-Id	header		string	false	"Organization ID, honored only for trusted service tokens; other callers use the organization_id token claim"
 //	@Param			id				path	string	true	"Template ID"
 //	@Success		204				"No content"
 //	@Failure		400				{object}	pkg.HTTPError
@@ -365,7 +442,7 @@ func (th *TemplateHandler) DeleteTemplateByID(c *fiber.Ctx) error {
 		attribute.String("app.request.template_id", id.String()),
 	)
 
-	if err := th.service.DeleteTemplateByID(ctx, id, false); err != nil {
+	if err := th.service.DeleteTemplateByID(ctx, id, false, organizationIDFromLocals(c)); err != nil {
 		if http.IsBusinessError(err) {
 			libOpentelemetry.HandleSpanBusinessErrorEvent(&span, "Failed to remove template on database", err)
 		} else {
@@ -104,7 +104,7 @@ func TestTemplateHandler_GetTemplateByID(t *testing.T) {
 			templateID: templateID.String(),
 			mockSetup: func(mockTemplateRepo *template.MockRepository) {
 				mockTemplateRepo.EXPECT().
-					FindByID(gomock.Any(), templateID).
+					FindByID(gomock.Any(), templateID, gomock.Any()).
 					Return(templateEntity, nil)
 			},
 			expectedStatus: http.StatusOK,
@@ -115,7 +115,7 @@ func TestTemplateHandler_GetTemplateByID(t *testing.T) {
 			templateID: templateID.String(),
 			mockSetup: func(mockTemplateRepo *template.MockRepository) {
 				mockTemplateRepo.EXPECT().
-					FindByID(gomock.Any(), templateID).
+					FindByID(gomock.Any(), templateID, gomock.Any()).
 					Return(nil, errors.New("template not found"))
 			},
 			expectedStatus: http.StatusInternalServerError,
@@ -195,7 +195,7 @@ func TestTemplateHandler_GetAllTemplates(t *testing.T) {
 			queryParams: "",
 			mockSetup: func(mockTemplateRepo *template.MockRepository) {
 				mockTemplateRepo.EXPECT().
-					FindList(gomock.Any(), gomock.Any()).
+					FindList(gomock.Any(), gomock.Any(), gomock.Any()).
 					Return(templates, nil)
 			},
 			expectedStatus: http.StatusOK,
@@ -206,7 +206,7 @@ func TestTemplateHandler_GetAllTemplates(t *testing.T) {
 			queryParams: "?limit=5&page=2",
 			mockSetup: func(mockTemplateRepo *template.MockRepository) {
 				mockTemplateRepo.EXPECT().
-					FindList(gomock.Any(), gomock.Any()).
+					FindList(gomock.Any(), gomock.Any(), gomock.Any()).
 					Return(templates, nil)
 			},
 			expectedStatus: http.StatusOK,
@@ -217,7 +217,7 @@ func TestTemplateHandler_GetAllTemplates(t *testing.T) {
 			queryParams: "?outputFormat=HTML",
 			mockSetup: func(mockTemplateRepo *template.MockRepository) {
 				mockTemplateRepo.EXPECT().
-					FindList(gomock.Any(), gomock.Any()).
+					FindList(gomock.Any(), gomock.Any(), gomock.Any()).
 					Return([]*template.Template{templates[0]}, nil)
 			},
 			expectedStatus: http.StatusOK,
@@ -228,7 +228,7 @@ func TestTemplateHandler_GetAllTemplates(t *testing.T) {
 			queryParams: "",
 			mockSetup: func(mockTemplateRepo *template.MockRepository) {
 				mockTemplateRepo.EXPECT().
-					FindList(gomock.Any(), gomock.Any()).
+					FindList(gomock.Any(), gomock.Any(), gomock.Any()).
 					Return(nil, errors.New("database error"))
 			},
 			expectedStatus: http.StatusInternalServerError,
@@ -291,7 +291,10 @@ func TestTemplateHandler_DeleteTemplateByID(t *testing.T) {
 			templateID: templateID.String(),
 			mockSetup: func(mockTemplateRepo *template.MockRepository) {
 				mockTemplateRepo.EXPECT().
-					Delete(gomock.Any(), templateID, false).
+					FindByID(gomock.Any(), templateID, gomock.Any()).
+					Return(&template.Template{ID: templateID}, nil)
+				mockTemplateRepo.EXPECT().
+					Delete(gomock.Any(), templateID, false, gomock.Any()).
 					Return(nil)
 			},
 			expectedStatus: http.StatusNoContent,
@@ -302,7 +305,10 @@ func TestTemplateHandler_DeleteTemplateByID(t *testing.T) {
 			templateID: templateID.String(),
 			mockSetup: func(mockTemplateRepo *template.MockRepository) {
 				mockTemplateRepo.EXPECT().
-					Delete(gomock.Any(), templateID, false).
+					FindByID(gomock.Any(), templateID, gomock.Any()).
+					Return(&template.Template{ID: templateID}, nil)
+				mockTemplateRepo.EXPECT().
+					Delete(gomock.Any(), templateID, false, gomock.Any()).
 					Return(errors.New("template not found"))
 			},
 			expectedStatus: http.StatusInternalServerError,
@@ -365,7 +371,7 @@ func TestTemplateHandler_GetAllTemplates_EmptyResult(t *testing.T) {
 	handler := &TemplateHandler{service: useCase}
 
 	mockTemplateRepo.EXPECT().
-		FindList(gomock.Any(), gomock.Any()).
+		FindList(gomock.Any(), gomock.Any(), gomock.Any()).
 		Return([]*template.Template{}, nil)
 
 	app := setupTemplateTestApp(handler)
@@ -459,6 +465,60 @@ func TestTemplateHandler_CreateTemplate_ValidationErrors(t *testing.T) {
 	}
 }
 
+func TestTemplateHandler_InvalidOutputOptions(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name          string
+		method        string
+		outputOptions string
+	}{
+		{name: "Create - unsupported encoding", method: http.MethodPost, outputOptions: `{"encoding":"utf-16"}`},
+		{name: "Create - malformed JSON", method: http.MethodPost, outputOptions: `{"encoding"`},
+		{name: "Update - BOM outside utf-8", method: http.MethodPatch, outputOptions: `{"encoding":"iso-8859-1","bom":true}`},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			useCase := &services.UseCase{
+				TemplateRepo:      template.NewMockRepository(ctrl),
+				TemplateSeaweedFS: templateSeaweedFS.NewMockRepository(ctrl),
+			}
+			handler := &TemplateHandler{service: useCase}
+
+			app := setupTemplateTestApp(handler)
+			app.Post("/templates", setupTemplateContextMiddleware(), handler.CreateTemplate)
+			app.Patch("/templates/:id", setupTemplateContextMiddleware(), ParsePathParametersUUID, handler.UpdateTemplateByID)
+
+			body := new(bytes.Buffer)
+			writer := multipart.NewWriter(body)
+			require.NoError(t, writer.WriteField("outputFormat", "csv"))
+			require.NoError(t, writer.WriteField("description", "Legacy export"))
+			require.NoError(t, writer.WriteField("outputOptions", tt.outputOptions))
+			require.NoError(t, writer.Close())
+
+			path := "/templates"
+			if tt.method == http.MethodPatch {
+				path += "/" + uuid.New().String()
+			}
+
+			req := httptest.NewRequest(tt.method, path, body)
+			req.Header.Set("Content-Type", writer.FormDataContentType())
+
+			resp, err := app.Test(req)
+
+			require.NoError(t, err)
+			assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+		})
+	}
+}
+
 func TestTemplateHandler_CreateTemplate_EmptyFile(t *testing.T) {
 	t.Parallel()
 
@@ -748,7 +808,7 @@ func TestTemplateHandler_UpdateTemplateByID_ServiceError(t *testing.T) {
 	// UpdateTemplateByID calls validateOutputFormatAndFile first, then service layer
 	// With outputFormat="xml" and file, it will attempt file validation
 	mockTemplateRepo.EXPECT().
-		FindByID(gomock.Any(), gomock.Any()).
+		FindByID(gomock.Any(), gomock.Any(), gomock.Any()).
 		Return(&template.Template{
 			FileName:     "test.tpl",
 			OutputFormat: "xml",
@@ -759,7 +819,7 @@ func TestTemplateHandler_UpdateTemplateByID_ServiceError(t *testing.T) {
 		Return(nil)
 
 	mockTemplateRepo.EXPECT().
-		Update(gomock.Any(), gomock.Any(), gomock.Any()).
+		Update(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
 		Return(errors.New("database update failed"))
 
 	useCase := &services.UseCase{
@@ -795,11 +855,11 @@ func TestTemplateHandler_UpdateTemplateByID_NoFile(t *testing.T) {
 
 	// Update without file, only description
 	mockTemplateRepo.EXPECT().
-		Update(gomock.Any(), gomock.Any(), gomock.Any()).
+		Update(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
 		Return(nil)
 
 	mockTemplateRepo.EXPECT().
-		FindByID(gomock.Any(), gomock.Any()).
+		FindByID(gomock.Any(), gomock.Any(), gomock.Any()).
 		Return(&template.Template{
 			ID:           templateID,
 			FileName:     "test.tpl",
@@ -0,0 +1,332 @@
+// Copyright (c) 2026 Lerian Studio. All rights reserved.
+// Use of this source code is governed by the Elastic License 2.0
+// that can be found in the LICENSE file.
+
+package rabbitmq
+
+import (
+	"context"
+	"encoding/json"
+	"maps"
+	"strings"
+	"time"
+
+	"github.com/LerianStudio/reporter/pkg/constant"
+	"github.com/LerianStudio/reporter/pkg/model"
+	pkgRabbitmq "github.com/LerianStudio/reporter/pkg/rabbitmq"
+
+	libCommons "github.com/LerianStudio/lib-commons/v2/commons"
+	libOpentelemetry "github.com/LerianStudio/lib-commons/v2/commons/opentelemetry"
+	libRabbitmq "github.com/LerianStudio/lib-commons/v2/commons/rabbitmq"
+	"github.com/google/uuid"
+	amqp "github.com/rabbitmq/amqp091-go"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// deathHeader is the header the broker adds to a dead-lettered message, most recent death first.
+const deathHeader = "x-death"
+
+// deadLetterChannel is the part of an AMQP channel used to manage the dead letter queue.
+type deadLetterChannel interface {
+	Get(queue string, autoAck bool) (amqp.Delivery, bool, error)
+	PublishWithContext(ctx context.Context, exchange, key string, mandatory, immediate bool, msg amqp.Publishing) error
+	QueuePurge(name string, noWait bool) (int, error)
+	Close() error
+}
+
+// DeadLetterRabbitMQRepository is a rabbitmq implementation of the DeadLetterRepository. Every operation
+// reads the queue on a channel of its own, so the messages it leaves unacknowledged go back to the
+// dead letter queue when the channel is closed.
+type DeadLetterRabbitMQRepository struct {
+	conn       *libRabbitmq.RabbitMQConnection
+	queue      string
+	exchange   string
+	routingKey string
+	lanes      pkgRabbitmq.GenerationLanes
+
+	// openChannel opens the channel of an operation. Overridable in tests.
+	openChannel func() (deadLetterChannel, error)
+}
+
+// Compile-time interface satisfaction check.
+var _ pkgRabbitmq.DeadLetterRepository = (*DeadLetterRabbitMQRepository)(nil)
+
+// NewDeadLetterRabbitMQ returns a new instance of DeadLetterRabbitMQRepository managing the given dead letter
+// queue, whose messages are replayed to the given generation exchange with the routing key of the lane of
+// their priority, or the given routing key when it has no lane.
+func NewDeadLetterRabbitMQ(c *libRabbitmq.RabbitMQConnection, queue, exchange, routingKey string, lanes pkgRabbitmq.GenerationLanes) *DeadLetterRabbitMQRepository {
+	dl := &DeadLetterRabbitMQRepository{
+		conn:       c,
+		queue:      queue,
+		exchange:   exchange,
+		routingKey: routingKey,
+		lanes:      lanes,
+	}
+
+	dl.openChannel = dl.newChannel
+
+	return dl
+}
+
+// newChannel opens a channel on the connection, reconnecting first when it dropped.
+func (dl *DeadLetterRabbitMQRepository) newChannel() (deadLetterChannel, error) {
+	if err := dl.conn.EnsureChannel(); err != nil {
+		return nil, err
+	}
+
+	ch, err := dl.conn.Connection.Channel()
+	if err != nil {
+		return nil, err
+	}
+
+	return ch, nil
+}
+
+// Peek returns up to count messages of the dead letter queue and its depth. The messages are fetched
+// without being acknowledged, so they stay in the queue once the channel is closed.
+func (dl *DeadLetterRabbitMQRepository) Peek(ctx context.Context, count int) ([]model.DeadLetterMessage, int, error) {
+	logger, tracer, reqId, _ := libCommons.NewTrackingFromContext(ctx)
+
+	_, span := tracer.Start(ctx, "repository.rabbitmq.peek_dead_letters")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("app.request.request_id", reqId),
+		attribute.String("app.request.queue", dl.queue),
+		attribute.Int("app.request.count", count),
+	)
+
+	ch, err := dl.openChannel()
+	if err != nil {
+		libOpentelemetry.HandleSpanError(&span, "Failed to open channel", err)
+
+		return nil, 0, err
+	}
+
+	defer func() {
+		if errClose := ch.Close(); errClose != nil {
+			logger.Warnf("Failed to close dead letter channel: %v", errClose)
+		}
+	}()
+
+	messages := make([]model.DeadLetterMessage, 0)
+	total := 0
+
+	for len(messages) < count {
+		delivery, ok, err := ch.Get(dl.queue, false)
+		if err != nil {
+			libOpentelemetry.HandleSpanError(&span, "Failed to get dead letter message", err)
+
+			return nil, 0, err
+		}
+
+		if !ok {
+			break
+		}
+
+		if len(messages) == 0 {
+			total = int(delivery.MessageCount) + 1
+		}
+
+		messages = append(messages, parseDeadLetter(delivery))
+	}
+
+	return messages, total, nil
+}
+
+// Replay publishes back to the generation exchange the message of each given report found among the first
+// count messages of the dead letter queue, and acknowledges it. Further messages of a replayed report are
+// acknowledged without being published, so the report is not generated twice.
+func (dl *DeadLetterRabbitMQRepository) Replay(ctx context.Context, reportIDs []uuid.UUID, count int) ([]uuid.UUID, error) {
+	logger, tracer, reqId, _ := libCommons.NewTrackingFromContext(ctx)
+
+	ctx, span := tracer.Start(ctx, "repository.rabbitmq.replay_dead_letters")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("app.request.request_id", reqId),
+		attribute.String("app.request.queue", dl.queue),
+		attribute.Int("app.request.reports", len(reportIDs)),
+	)
+
+	selected := make(map[uuid.UUID]bool, len(reportIDs))
+	for _, id := range reportIDs {
+		selected[id] = true
+	}
+
+	ch, err := dl.openChannel()
+	if err != nil {
+		libOpentelemetry.HandleSpanError(&span, "Failed to open channel", err)
+
+		return nil, err
+	}
+
+	defer func() {
+		if errClose := ch.Close(); errClose != nil {
+			logger.Warnf("Failed to close dead letter channel: %v", errClose)
+		}
+	}()
+
+	replayed := make([]uuid.UUID, 0, len(reportIDs))
+	done := make(map[uuid.UUID]bool, len(reportIDs))
+
+	for range count {
+		delivery, ok, err := ch.Get(dl.queue, false)
+		if err != nil {
+			libOpentelemetry.HandleSpanError(&span, "Failed to get dead letter message", err)
+
+			return replayed, err
+		}
+
+		if !ok {
+			break
+		}
+
+		deadLetter := parseDeadLetter(delivery)
+
+		reportID := deadLetter.ReportID
+		if !selected[reportID] {
+			continue
+		}
+
+		if !done[reportID] {
+			if err := ch.PublishWithContext(ctx, dl.exchange, dl.replayKey(deadLetter), false, false, amqp.Publishing{
+				ContentType:  delivery.ContentType,
+				DeliveryMode: amqp.Persistent,
+				Headers:      replayHeaders(delivery.Headers),
+				Body:         delivery.Body,
+			}); err != nil {
+				libOpentelemetry.HandleSpanError(&span, "Failed to replay dead letter message", err)
+
+				return replayed, err
+			}
+
+			done[reportID] = true
+			replayed = append(replayed, reportID)
+		}
+
+		if err := delivery.Ack(false); err != nil {
+			// The message stays in the dead letter queue; the worker skips the report if it was generated
+			logger.Warnf("Failed to remove replayed message of report %s from the dead letter queue: %v", reportID, err)
+		}
+	}
+
+	return replayed, nil
+}
+
+// Purge removes every message of the dead letter queue and returns how many were removed.
+func (dl *DeadLetterRabbitMQRepository) Purge(ctx context.Context) (int, error) {
+	logger, tracer, reqId, _ := libCommons.NewTrackingFromContext(ctx)
+
+	_, span := tracer.Start(ctx, "repository.rabbitmq.purge_dead_letters")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("app.request.request_id", reqId),
+		attribute.String("app.request.queue", dl.queue),
+	)
+
+	ch, err := dl.openChannel()
+	if err != nil {
+		libOpentelemetry.HandleSpanError(&span, "Failed to open channel", err)
+
+		return 0, err
+	}
+
+	defer func() {
+		if errClose := ch.Close(); errClose != nil {
+			logger.Warnf("Failed to close dead letter channel: %v", errClose)
+		}
+	}()
+
+	purged, err := ch.QueuePurge(dl.queue, false)
+	if err != nil {
+		libOpentelemetry.HandleSpanError(&span, "Failed to purge dead letter queue", err)
+
+		return 0, err
+	}
+
+	return purged, nil
+}
+
+// replayKey returns the routing key a dead letter message is replayed with, the one of the lane of its priority.
+func (dl *DeadLetterRabbitMQRepository) replayKey(deadLetter model.DeadLetterMessage) string {
+	if deadLetter.Message == nil {
+		return dl.routingKey
+	}
+
+	return dl.lanes.RoutingKey(deadLetter.Message.Priority, dl.routingKey)
+}
+
+// parseDeadLetter describes a dead letter message from its body and the headers set by the worker and the broker.
+func parseDeadLetter(delivery amqp.Delivery) model.DeadLetterMessage {
+	deadLetter := model.DeadLetterMessage{
+		RetryCount: headerInt(delivery.Headers[constant.RetryCountHeader]),
+	}
+
+	if reason, ok := delivery.Headers[constant.RetryFailureReasonHeader].(string); ok {
+		deadLetter.LastError = reason
+	}
+
+	var message model.ReportMessage
+	if err := json.Unmarshal(delivery.Body, &message); err == nil && message.ReportID != uuid.Nil {
+		deadLetter.ReportID = message.ReportID
+		deadLetter.TemplateID = message.TemplateID
+		deadLetter.OrganizationID = message.OrganizationID
+		deadLetter.Message = &message
+	}
+
+	deaths, _ := delivery.Headers[deathHeader].([]any)
+	if len(deaths) == 0 {
+		return deadLetter
+	}
+
+	death, ok := deaths[0].(amqp.Table)
+	if !ok {
+		return deadLetter
+	}
+
+	deadLetter.Reason, _ = death["reason"].(string)
+	deadLetter.Queue, _ = death["queue"].(string)
+
+	if at, ok := death["time"].(time.Time); ok {
+		deadLetter.DeadLetteredAt = &at
+	}
+
+	return deadLetter
+}
+
+// replayHeaders copies the headers of a dead letter message for its replay, dropping the ones set by the
+// broker when dead-lettering it and resetting the retry count, so the worker retries it from scratch.
+func replayHeaders(original amqp.Table) amqp.Table {
+	headers := make(amqp.Table, len(original))
+	maps.Copy(headers, original)
+
+	for key := range headers {
+		if key == deathHeader || strings.HasPrefix(key, "x-first-death-") || strings.HasPrefix(key, "x-last-death-") {
+			delete(headers, key)
+		}
+	}
+
+	delete(headers, constant.RetryFailureReasonHeader)
+
+	headers[constant.RetryCountHeader] = 0
+
+	return headers
+}
+
+// headerInt reads an integer header, which the broker may return with any numeric type.
+func headerInt(value any) int {
+	switch v := value.(type) {
+	case int:
+		return v
+	case int32:
+		return int(v)
+	case int64:
+		return int(v)
+	case float64:
+		return int(v)
+	default:
+		return 0
+	}
+}
@@ -0,0 +1,228 @@
+// Copyright (c) 2026 Lerian Studio. All rights reserved.
+// Use of this source code is governed by the Elastic License 2.0
+// that can be found in the LICENSE file.
+
+package rabbitmq
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/LerianStudio/reporter/pkg/constant"
+	"github.com/LerianStudio/reporter/pkg/model"
+	pkgRabbitmq "github.com/LerianStudio/reporter/pkg/rabbitmq"
+
+	libRabbitmq "github.com/LerianStudio/lib-commons/v2/commons/rabbitmq"
+	"github.com/LerianStudio/lib-commons/v2/commons/zap"
+	"github.com/google/uuid"
+	amqp "github.com/rabbitmq/amqp091-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeDeadLetterChannel serves the deliveries of a dead letter queue and records what is done with them.
+type fakeDeadLetterChannel struct {
+	deliveries []amqp.Delivery
+	published  []amqp.Publishing
+	keys       []string
+	acked      []uint64
+	purged     int
+	publishErr error
+	closed     bool
+}
+
+func (f *fakeDeadLetterChannel) Get(_ string, _ bool) (amqp.Delivery, bool, error) {
+	if len(f.deliveries) == 0 {
+		return amqp.Delivery{}, false, nil
+	}
+
+	delivery := f.deliveries[0]
+	f.deliveries = f.deliveries[1:]
+	delivery.MessageCount = uint32(len(f.deliveries))
+	delivery.Acknowledger = f
+
+	return delivery, true, nil
+}
+
+func (f *fakeDeadLetterChannel) PublishWithContext(_ context.Context, _, key string, _, _ bool, msg amqp.Publishing) error {
+	if f.publishErr != nil {
+		return f.publishErr
+	}
+
+	f.published = append(f.published, msg)
+	f.keys = append(f.keys, key)
+
+	return nil
+}
+
+func (f *fakeDeadLetterChannel) QueuePurge(_ string, _ bool) (int, error) {
+	return f.purged, nil
+}
+
+func (f *fakeDeadLetterChannel) Close() error {
+	f.closed = true
+
+	return nil
+}
+
+func (f *fakeDeadLetterChannel) Ack(tag uint64, _ bool) error {
+	f.acked = append(f.acked, tag)
+
+	return nil
+}
+
+func (f *fakeDeadLetterChannel) Nack(uint64, bool, bool) error { return nil }
+
+func (f *fakeDeadLetterChannel) Reject(uint64, bool) error { return nil }
+
+// newTestDeadLetterRepository creates a DeadLetterRabbitMQRepository reading the given fake channel.
+func newTestDeadLetterRepository(ch *fakeDeadLetterChannel) *DeadLetterRabbitMQRepository {
+	dl := NewDeadLetterRabbitMQ(&libRabbitmq.RabbitMQConnection{Logger: zap.InitializeLogger()}, "reporter.dlq", "reporter.generate-report.exchange", "reporter.generate-report.key", pkgRabbitmq.GenerationLanes{
+		constant.ReportPriorityLow: {Queue: "reporter.generate-report.low.queue", RoutingKey: "reporter.generate-report.low.key"},
+	})
+	dl.openChannel = func() (deadLetterChannel, error) { return ch, nil }
+
+	return dl
+}
+
+// newDeadLetterDelivery builds the delivery of a dead-lettered report message.
+func newDeadLetterDelivery(t *testing.T, tag uint64, reportID uuid.UUID, headers amqp.Table) amqp.Delivery {
+	t.Helper()
+
+	return newPriorityDeadLetterDelivery(t, tag, reportID, "", headers)
+}
+
+// newPriorityDeadLetterDelivery builds the delivery of a dead-lettered report message of the given priority.
+func newPriorityDeadLetterDelivery(t *testing.T, tag uint64, reportID uuid.UUID, priority string, headers amqp.Table) amqp.Delivery {
+	t.Helper()
+
+	body, err := json.Marshal(model.ReportMessage{ReportID: reportID, TemplateID: uuid.New(), OutputFormat: "csv", Priority: priority})
+	require.NoError(t, err)
+
+	return amqp.Delivery{DeliveryTag: tag, ContentType: "application/json", Headers: headers, Body: body}
+}
+
+func TestDeadLetterRabbitMQRepository_Peek(t *testing.T) {
+	t.Parallel()
+
+	reportID := uuid.New()
+	deadLetteredAt := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	ch := &fakeDeadLetterChannel{deliveries: []amqp.Delivery{
+		newDeadLetterDelivery(t, 1, reportID, amqp.Table{
+			constant.RetryCountHeader:         int32(3),
+			constant.RetryFailureReasonHeader: "connection refused",
+			deathHeader: []any{amqp.Table{
+				"reason": "rejected",
+				"queue":  "reporter.generate-report.queue",
+				"time":   deadLetteredAt,
+			}},
+		}),
+		{DeliveryTag: 2, Body: []byte("not a report message")},
+		newDeadLetterDelivery(t, 3, uuid.New(), nil),
+	}}
+
+	messages, total, err := newTestDeadLetterRepository(ch).Peek(context.Background(), 2)
+	require.NoError(t, err)
+
+	assert.Equal(t, 3, total)
+	require.Len(t, messages, 2)
+
+	assert.Equal(t, reportID, messages[0].ReportID)
+	assert.Equal(t, 3, messages[0].RetryCount)
+	assert.Equal(t, "connection refused", messages[0].LastError)
+	assert.Equal(t, "rejected", messages[0].Reason)
+	assert.Equal(t, "reporter.generate-report.queue", messages[0].Queue)
+	require.NotNil(t, messages[0].DeadLetteredAt)
+	assert.Equal(t, deadLetteredAt, *messages[0].DeadLetteredAt)
+	require.NotNil(t, messages[0].Message)
+
+	assert.Equal(t, uuid.Nil, messages[1].ReportID)
+	assert.Nil(t, messages[1].Message)
+
+	// Peeked messages are left unacknowledged, so closing the channel puts them back in the queue
+	assert.Empty(t, ch.acked)
+	assert.True(t, ch.closed)
+}
+
+func TestDeadLetterRabbitMQRepository_Replay(t *testing.T) {
+	t.Parallel()
+
+	replayedID, duplicatedID, keptID := uuid.New(), uuid.New(), uuid.New()
+
+	t.Run("Success - replays the selected reports once and leaves the others", func(t *testing.T) {
+		t.Parallel()
+
+		ch := &fakeDeadLetterChannel{deliveries: []amqp.Delivery{
+			newDeadLetterDelivery(t, 1, replayedID, amqp.Table{
+				constant.RetryCountHeader:         int32(3),
+				constant.RetryFailureReasonHeader: "connection refused",
+				"x-first-death-reason":            "rejected",
+				deathHeader:                       []any{amqp.Table{"reason": "rejected"}},
+				"traceparent":                     "00-trace",
+			}),
+			newDeadLetterDelivery(t, 2, keptID, nil),
+			newDeadLetterDelivery(t, 3, duplicatedID, nil),
+			newDeadLetterDelivery(t, 4, duplicatedID, nil),
+		}}
+
+		replayed, err := newTestDeadLetterRepository(ch).Replay(context.Background(), []uuid.UUID{replayedID, duplicatedID}, constant.MaxDeadLetterMessages)
+		require.NoError(t, err)
+
+		assert.Equal(t, []uuid.UUID{replayedID, duplicatedID}, replayed)
+		assert.Equal(t, []uint64{1, 3, 4}, ch.acked)
+		require.Len(t, ch.published, 2)
+
+		assert.Equal(t, amqp.Table{constant.RetryCountHeader: 0, "traceparent": "00-trace"}, ch.published[0].Headers)
+		assert.Equal(t, uint8(amqp.Persistent), ch.published[0].DeliveryMode)
+		assert.Equal(t, []string{"reporter.generate-report.key", "reporter.generate-report.key"}, ch.keys)
+	})
+
+	t.Run("Success - replays to the lane of the report priority", func(t *testing.T) {
+		t.Parallel()
+
+		lowID, highID := uuid.New(), uuid.New()
+
+		ch := &fakeDeadLetterChannel{deliveries: []amqp.Delivery{
+			newPriorityDeadLetterDelivery(t, 1, lowID, constant.ReportPriorityLow, nil),
+			newPriorityDeadLetterDelivery(t, 2, highID, constant.ReportPriorityHigh, nil),
+		}}
+
+		replayed, err := newTestDeadLetterRepository(ch).Replay(context.Background(), []uuid.UUID{lowID, highID}, constant.MaxDeadLetterMessages)
+		require.NoError(t, err)
+
+		assert.Equal(t, []uuid.UUID{lowID, highID}, replayed)
+		// The high priority has no lane of its own and goes to the generation queue
+		assert.Equal(t, []string{"reporter.generate-report.low.key", "reporter.generate-report.key"}, ch.keys)
+	})
+
+	t.Run("Error - publish fails", func(t *testing.T) {
+		t.Parallel()
+
+		ch := &fakeDeadLetterChannel{
+			deliveries: []amqp.Delivery{newDeadLetterDelivery(t, 1, replayedID, nil)},
+			publishErr: errors.New("channel closed"),
+		}
+
+		replayed, err := newTestDeadLetterRepository(ch).Replay(context.Background(), []uuid.UUID{replayedID}, constant.MaxDeadLetterMessages)
+		require.Error(t, err)
+
+		assert.Empty(t, replayed)
+		assert.Empty(t, ch.acked)
+	})
+}
+
+func TestDeadLetterRabbitMQRepository_Purge(t *testing.T) {
+	t.Parallel()
+
+	ch := &fakeDeadLetterChannel{purged: 12}
+
+	purged, err := newTestDeadLetterRepository(ch).Purge(context.Background())
+	require.NoError(t, err)
+
+	assert.Equal(t, 12, purged)
+	assert.True(t, ch.closed)
+}
@@ -0,0 +1,168 @@
+// Copyright (c) 2026 Lerian Studio. All rights reserved.
+// Use of this source code is governed by the Elastic License 2.0
+// that can be found in the LICENSE file.
+
+package rabbitmq
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"time"
+
+	pkgRabbitmq "github.com/LerianStudio/reporter/pkg/rabbitmq"
+
+	libCommons "github.com/LerianStudio/lib-commons/v2/commons"
+	libOpentelemetry "github.com/LerianStudio/lib-commons/v2/commons/opentelemetry"
+	libRabbitmq "github.com/LerianStudio/lib-commons/v2/commons/rabbitmq"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+const (
+	// managementVHost is the virtual host of the reporter queues, URL-encoded for the management API.
+	managementVHost = "%2F"
+	// managementRequestTimeout bounds every call to the RabbitMQ management API.
+	managementRequestTimeout = 5 * time.Second
+)
+
+// QueueInspectorRabbitMQ is a QueueInspector backed by the RabbitMQ management HTTP API,
+// reached through the health check URL of the connection.
+type QueueInspectorRabbitMQ struct {
+	conn   *libRabbitmq.RabbitMQConnection
+	client *http.Client
+}
+
+// Compile-time interface satisfaction check.
+var _ pkgRabbitmq.QueueInspector = (*QueueInspectorRabbitMQ)(nil)
+
+// NewQueueInspectorRabbitMQ returns a new instance of QueueInspectorRabbitMQ using the given rabbitmq connection.
+func NewQueueInspectorRabbitMQ(c *libRabbitmq.RabbitMQConnection) *QueueInspectorRabbitMQ {
+	return &QueueInspectorRabbitMQ{
+		conn:   c,
+		client: &http.Client{Timeout: managementRequestTimeout},
+	}
+}
+
+// managementQueue is the part of the management API queue description used by the inspector.
+type managementQueue struct {
+	Messages int `json:"messages"`
+}
+
+// managementMessage is a message returned by the management API get endpoint.
+type managementMessage struct {
+	Payload         string `json:"payload"`
+	PayloadEncoding string `json:"payload_encoding"`
+}
+
+// QueueDepth returns the number of messages of a queue, counting both ready and unacknowledged ones.
+func (qi *QueueInspectorRabbitMQ) QueueDepth(ctx context.Context, queue string) (int, error) {
+	_, tracer, reqId, _ := libCommons.NewTrackingFromContext(ctx)
+
+	ctx, span := tracer.Start(ctx, "repository.rabbitmq.queue_depth")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("app.request.request_id", reqId),
+		attribute.String("app.request.queue", queue),
+	)
+
+	var description managementQueue
+
+	if err := qi.call(ctx, http.MethodGet, "/api/queues/"+managementVHost+"/"+url.PathEscape(queue), nil, &description); err != nil {
+		libOpentelemetry.HandleSpanError(&span, "Failed to get queue depth", err)
+
+		return 0, err
+	}
+
+	return description.Messages, nil
+}
+
+// PeekMessages returns the bodies of up to count messages of a queue. The messages are fetched
+// with ack_requeue_true, so they stay in the queue, possibly in a different order.
+func (qi *QueueInspectorRabbitMQ) PeekMessages(ctx context.Context, queue string, count int) ([][]byte, error) {
+	_, tracer, reqId, _ := libCommons.NewTrackingFromContext(ctx)
+
+	ctx, span := tracer.Start(ctx, "repository.rabbitmq.peek_messages")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("app.request.request_id", reqId),
+		attribute.String("app.request.queue", queue),
+		attribute.Int("app.request.count", count),
+	)
+
+	request := map[string]any{
+		"count":    count,
+		"ackmode":  "ack_requeue_true",
+		"encoding": "auto",
+	}
+
+	var messages []managementMessage
+
+	if err := qi.call(ctx, http.MethodPost, "/api/queues/"+managementVHost+"/"+url.PathEscape(queue)+"/get", request, &messages); err != nil {
+		libOpentelemetry.HandleSpanError(&span, "Failed to peek queue messages", err)
+
+		return nil, err
+	}
+
+	bodies := make([][]byte, 0, len(messages))
+
+	for _, message := range messages {
+		if message.PayloadEncoding == "base64" {
+			body, err := base64.StdEncoding.DecodeString(message.Payload)
+			if err != nil {
+				libOpentelemetry.HandleSpanError(&span, "Failed to decode message payload", err)
+
+				return nil, err
+			}
+
+			bodies = append(bodies, body)
+
+			continue
+		}
+
+		bodies = append(bodies, []byte(message.Payload))
+	}
+
+	return bodies, nil
+}
+
+// call sends an authenticated request to the management API and decodes its JSON response into out.
+func (qi *QueueInspectorRabbitMQ) call(ctx context.Context, method, path string, body, out any) error {
+	var reader io.Reader
+
+	if body != nil {
+		payload, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+
+		reader = bytes.NewReader(payload)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, qi.conn.HealthCheckURL+path, reader)
+	if err != nil {
+		return err
+	}
+
+	req.SetBasicAuth(qi.conn.User, qi.conn.Pass)
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := qi.client.Do(req) //#nosec G704 -- HealthCheckURL is operator-configured, not user input
+	if err != nil {
+		return err
+	}
+
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("rabbitmq management api %s %s returned status %d", method, path, resp.StatusCode)
+	}
+
+	return json.NewDecoder(resp.Body).Decode(out)
+}
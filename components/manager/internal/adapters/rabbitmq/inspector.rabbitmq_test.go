@@ -0,0 +1,109 @@
+// Copyright (c) 2026 Lerian Studio. All rights reserved.
+// Use of this source code is governed by the Elastic License 2.0
+// that can be found in the LICENSE file.
+
+package rabbitmq
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	libRabbitmq "github.com/LerianStudio/lib-commons/v2/commons/rabbitmq"
+	"github.com/LerianStudio/lib-commons/v2/commons/zap"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newTestInspector creates a QueueInspectorRabbitMQ pointed at the given management API server.
+func newTestInspector(serverURL string) *QueueInspectorRabbitMQ {
+	return NewQueueInspectorRabbitMQ(&libRabbitmq.RabbitMQConnection{
+		HealthCheckURL: serverURL,
+		User:           "reporter",
+		Pass:           "secret",
+		Logger:         zap.InitializeLogger(),
+	})
+}
+
+func TestQueueInspectorRabbitMQ_QueueDepth(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		status    int
+		body      string
+		wantDepth int
+		wantErr   bool
+	}{
+		{
+			name:      "Success - ready and unacknowledged messages",
+			status:    http.StatusOK,
+			body:      `{"name":"reporter.generate-report.queue","messages":7,"messages_ready":5}`,
+			wantDepth: 7,
+		},
+		{
+			name:    "Error - queue not found",
+			status:  http.StatusNotFound,
+			body:    `{"error":"Object Not Found"}`,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				user, pass, ok := r.BasicAuth()
+				assert.True(t, ok)
+				assert.Equal(t, "reporter", user)
+				assert.Equal(t, "secret", pass)
+				assert.Equal(t, http.MethodGet, r.Method)
+				assert.Equal(t, "/api/queues/%2F/reporter.generate-report.queue", r.URL.EscapedPath())
+
+				w.WriteHeader(tt.status)
+				_, _ = w.Write([]byte(tt.body))
+			}))
+			defer server.Close()
+
+			depth, err := newTestInspector(server.URL).QueueDepth(context.Background(), "reporter.generate-report.queue")
+			if tt.wantErr {
+				require.Error(t, err)
+
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantDepth, depth)
+		})
+	}
+}
+
+func TestQueueInspectorRabbitMQ_PeekMessages(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Equal(t, "/api/queues/%2F/reporter.dlq/get", r.URL.EscapedPath())
+
+		var request map[string]any
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&request))
+		assert.Equal(t, "ack_requeue_true", request["ackmode"])
+		assert.EqualValues(t, 2, request["count"])
+
+		_, _ = w.Write([]byte(`[
+			{"payload":"{\"reportId\":\"a\"}","payload_encoding":"string"},
+			{"payload":"eyJyZXBvcnRJZCI6ImIifQ==","payload_encoding":"base64"}
+		]`))
+	}))
+	defer server.Close()
+
+	bodies, err := newTestInspector(server.URL).PeekMessages(context.Background(), "reporter.dlq", 2)
+	require.NoError(t, err)
+	require.Len(t, bodies, 2)
+	assert.JSONEq(t, `{"reportId":"a"}`, string(bodies[0]))
+	assert.JSONEq(t, `{"reportId":"b"}`, string(bodies[1]))
+}
@@ -14,6 +14,8 @@ import (
 	"github.com/LerianStudio/reporter/components/manager/internal/services"
 	"github.com/LerianStudio/reporter/pkg"
 	"github.com/LerianStudio/reporter/pkg/constant"
+	"github.com/LerianStudio/reporter/pkg/model"
+	pkgRabbitmq "github.com/LerianStudio/reporter/pkg/rabbitmq"
 	reportSeaweedFS "github.com/LerianStudio/reporter/pkg/seaweedfs/report"
 	templateSeaweedFS "github.com/LerianStudio/reporter/pkg/seaweedfs/template"
 
@@ -65,6 +67,13 @@ type Config struct {
 	RabbitMQGenerateReportQueue string `env:"RABBITMQ_GENERATE_REPORT_QUEUE"`
 	RabbitMQExchange            string `env:"RABBITMQ_EXCHANGE"`
 	RabbitMQGenerateReportKey   string `env:"RABBITMQ_GENERATE_REPORT_KEY"`
+	RabbitMQDLQQueue            string `env:"RABBITMQ_DLQ_QUEUE"`
+	// Priority lanes: the queue and routing key of the high and low priority reports. A priority without
+	// a lane is generated from RABBITMQ_GENERATE_REPORT_QUEUE.
+	RabbitMQHighPriorityQueue string `env:"RABBITMQ_GENERATE_REPORT_HIGH_QUEUE"`
+	RabbitMQHighPriorityKey   string `env:"RABBITMQ_GENERATE_REPORT_HIGH_KEY"`
+	RabbitMQLowPriorityQueue  string `env:"RABBITMQ_GENERATE_REPORT_LOW_QUEUE"`
+	RabbitMQLowPriorityKey    string `env:"RABBITMQ_GENERATE_REPORT_LOW_KEY"`
 	// Redis/Valkey configuration envs
 	RedisHost                    string `env:"REDIS_HOST"`
 	RedisMasterName              string `env:"REDIS_MASTER_NAME" default:""`
@@ -93,6 +102,22 @@ type Config struct {
 	RateLimitWindow   int  `env:"RATE_LIMIT_WINDOW_SECONDS" default:"60"`
 	// Trusted proxies configuration
 	TrustedProxies string `env:"TRUSTED_PROXIES"`
+	// Multi-tenant isolation: when enabled every template and report request must carry an organization ID
+	MultiTenantEnabled bool `env:"MULTI_TENANT_ENABLED"`
+	// Key signing the row-level scope and filters of report messages, shared with the worker
+	RowLevelSigningKey string `env:"ROW_LEVEL_SIGNING_KEY"`
+	// Stuck-report reaper: reports in Processing for longer than the timeout are requeued or marked as Error.
+	// A zero interval disables the reaper.
+	ReportReaperIntervalSeconds    int `env:"REPORT_REAPER_INTERVAL_SECONDS"`
+	ReportProcessingTimeoutSeconds int `env:"REPORT_PROCESSING_TIMEOUT_SECONDS"`
+	// Validity of the presigned report download URLs; zero uses the default
+	ReportDownloadURLExpirySeconds int `env:"REPORT_DOWNLOAD_URL_EXPIRY_SECONDS" default:"300"`
+	// Report retention: reports older than the retention of their template, tenant or the default are purged
+	// every interval. Tenant rules are "organizationID=days" pairs separated by commas; zero days keeps the
+	// reports forever. A zero interval disables the purge.
+	ReportRetentionDays        int    `env:"REPORT_RETENTION_DAYS"`
+	ReportRetentionTenantDays  string `env:"REPORT_RETENTION_TENANT_DAYS"`
+	ReportPurgeIntervalSeconds int    `env:"REPORT_PURGE_INTERVAL_SECONDS"`
 }
 
 // Validate checks that all required configuration fields are present
@@ -104,6 +129,10 @@ func (c *Config) Validate() error {
 	errs = c.validateRequiredFields(errs)
 	errs = c.validateMongoPoolBounds(errs)
 	errs = c.validateRateLimitBounds(errs)
+	errs = c.validateReportReaper(errs)
+	errs = c.validateReportDownload(errs)
+	errs = c.validateReportRetention(errs)
+	errs = c.validatePriorityLanes(errs)
 	errs = c.validateProductionConfig(errs)
 
 	if len(errs) > 0 {
@@ -191,6 +220,90 @@ func (c *Config) validateRateLimitBounds(errs []string) []string {
 	return errs
 }
 
+// validateReportReaper checks that an enabled stuck-report reaper has a processing timeout.
+func (c *Config) validateReportReaper(errs []string) []string {
+	if c.ReportReaperIntervalSeconds < 0 {
+		errs = append(errs, "REPORT_REAPER_INTERVAL_SECONDS must not be negative")
+	}
+
+	if c.ReportReaperIntervalSeconds > 0 && c.ReportProcessingTimeoutSeconds <= 0 {
+		errs = append(errs, "REPORT_PROCESSING_TIMEOUT_SECONDS must be greater than 0 when the report reaper is enabled")
+	}
+
+	return errs
+}
+
+// validateReportDownload checks that the validity of the presigned report download URLs is accepted by S3.
+func (c *Config) validateReportDownload(errs []string) []string {
+	if c.ReportDownloadURLExpirySeconds < 0 || c.ReportDownloadURLExpirySeconds > constant.MaxReportDownloadURLExpirySeconds {
+		errs = append(errs, fmt.Sprintf("REPORT_DOWNLOAD_URL_EXPIRY_SECONDS must be between 0 and %d", constant.MaxReportDownloadURLExpirySeconds))
+	}
+
+	return errs
+}
+
+// validateReportRetention checks the bounds of the retention days and that the tenant rules parse.
+func (c *Config) validateReportRetention(errs []string) []string {
+	if c.ReportRetentionDays < 0 || c.ReportRetentionDays > constant.MaxReportRetentionDays {
+		errs = append(errs, fmt.Sprintf("REPORT_RETENTION_DAYS must be between 0 and %d", constant.MaxReportRetentionDays))
+	}
+
+	if c.ReportPurgeIntervalSeconds < 0 {
+		errs = append(errs, "REPORT_PURGE_INTERVAL_SECONDS must not be negative")
+	}
+
+	if _, err := model.ParseTenantRetentionDays(c.ReportRetentionTenantDays); err != nil {
+		errs = append(errs, "REPORT_RETENTION_TENANT_DAYS is invalid: "+err.Error())
+	}
+
+	return errs
+}
+
+// retentionPolicy returns the retention policy configured. It is called after Validate, so the tenant rules parse.
+func (c *Config) retentionPolicy() *model.RetentionPolicy {
+	tenantDays, _ := model.ParseTenantRetentionDays(c.ReportRetentionTenantDays)
+
+	return &model.RetentionPolicy{
+		DefaultDays: c.ReportRetentionDays,
+		TenantDays:  tenantDays,
+	}
+}
+
+// validatePriorityLanes checks that every priority lane has both its queue and its routing key, as the
+// reaper reads the queue of the lanes the reports are routed to.
+func (c *Config) validatePriorityLanes(errs []string) []string {
+	lanes := []struct {
+		queue, key         string
+		queueName, keyName string
+	}{
+		{c.RabbitMQHighPriorityQueue, c.RabbitMQHighPriorityKey, "RABBITMQ_GENERATE_REPORT_HIGH_QUEUE", "RABBITMQ_GENERATE_REPORT_HIGH_KEY"},
+		{c.RabbitMQLowPriorityQueue, c.RabbitMQLowPriorityKey, "RABBITMQ_GENERATE_REPORT_LOW_QUEUE", "RABBITMQ_GENERATE_REPORT_LOW_KEY"},
+	}
+
+	for _, lane := range lanes {
+		if (lane.queue == "") != (lane.key == "") {
+			errs = append(errs, lane.queueName+" and "+lane.keyName+" must be set together")
+		}
+	}
+
+	return errs
+}
+
+// generationLanes returns the priority lanes configured, keyed by report priority.
+func (c *Config) generationLanes() pkgRabbitmq.GenerationLanes {
+	lanes := make(pkgRabbitmq.GenerationLanes)
+
+	if c.RabbitMQHighPriorityKey != "" {
+		lanes[constant.ReportPriorityHigh] = pkgRabbitmq.GenerationLane{Queue: c.RabbitMQHighPriorityQueue, RoutingKey: c.RabbitMQHighPriorityKey}
+	}
+
+	if c.RabbitMQLowPriorityKey != "" {
+		lanes[constant.ReportPriorityLow] = pkgRabbitmq.GenerationLane{Queue: c.RabbitMQLowPriorityQueue, RoutingKey: c.RabbitMQLowPriorityKey}
+	}
+
+	return lanes
+}
+
 // validateProductionConfig enforces stricter rules when EnvName is "production".
 // Telemetry, authentication, and real credentials are required in production.
 func (c *Config) validateProductionConfig(errs []string) []string {
@@ -318,6 +431,18 @@ func InitServers() (_ *Service, err error) {
 	// A single instance is shared across all services that need external data sources.
 	externalDataSources := pkg.NewSafeDataSources(pkg.ExternalDatasourceConnectionsLazy(logger))
 
+	// Load the row-level rules that scope report filters to the caller's auth claims
+	rowLevelPolicy, err := pkg.LoadRowLevelPolicy(logger)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load row-level filter rules: %w", err)
+	}
+
+	if rowLevelPolicy.Enabled() && cfg.RowLevelSigningKey == "" {
+		return nil, fmt.Errorf("ROW_LEVEL_SIGNING_KEY is required when row-level filter rules are configured")
+	}
+
+	rowLevelPolicy.WithSigningKey(cfg.RowLevelSigningKey)
+
 	// Use same storage client for both templates and reports (repositories handle prefixes)
 	templateStorageRepo := templateSeaweedFS.NewStorageRepository(storageClient)
 	reportStorageRepo := reportSeaweedFS.NewStorageRepository(storageClient)
@@ -333,20 +458,55 @@ func InitServers() (_ *Service, err error) {
 		return nil, fmt.Errorf("failed to initialize template handler: %w", err)
 	}
 
-	reportHandler, err := httpIn.NewReportHandler(&services.UseCase{
-		ReportRepo:                mongo.reportRepo,
-		RabbitMQRepo:              rabbit.producer,
-		TemplateRepo:              mongo.templateRepo,
-		ReportSeaweedFS:           reportStorageRepo,
-		ExternalDataSources:       externalDataSources,
-		RedisRepo:                 redisConsumerRepository,
-		RabbitMQExchange:          cfg.RabbitMQExchange,
-		RabbitMQGenerateReportKey: cfg.RabbitMQGenerateReportKey,
-	})
+	reportUseCase := &services.UseCase{
+		ReportRepo:                  mongo.reportRepo,
+		OutboxRepo:                  mongo.outboxRepo,
+		BatchRepo:                   mongo.batchRepo,
+		RabbitMQRepo:                rabbit.producer,
+		TemplateRepo:                mongo.templateRepo,
+		ReportSeaweedFS:             reportStorageRepo,
+		ExternalDataSources:         externalDataSources,
+		RedisRepo:                   redisConsumerRepository,
+		RabbitMQExchange:            cfg.RabbitMQExchange,
+		RabbitMQGenerateReportKey:   cfg.RabbitMQGenerateReportKey,
+		RabbitMQLanes:               cfg.generationLanes(),
+		RowLevelPolicy:              rowLevelPolicy,
+		RabbitMQGenerateReportQueue: cfg.RabbitMQGenerateReportQueue,
+		RabbitMQDLQQueue:            cfg.RabbitMQDLQQueue,
+		ReportDownloadURLExpiry:     time.Duration(cfg.ReportDownloadURLExpirySeconds) * time.Second,
+		RetentionPolicy:             cfg.retentionPolicy(),
+	}
+
+	// The queue inspector reads the queues through the RabbitMQ management API
+	if cfg.RabbitMQHealthCheckURL != "" {
+		reportUseCase.QueueInspector = rabbit.inspector
+	}
+
+	// The dead letter queue is managed through the admin routes when it is configured
+	if rabbit.deadLetters != nil {
+		reportUseCase.DeadLetterRepo = rabbit.deadLetters
+	}
+
+	reportHandler, err := httpIn.NewReportHandler(reportUseCase)
 	if err != nil {
 		return nil, fmt.Errorf("failed to initialize report handler: %w", err)
 	}
 
+	// Start the outbox relay; like the reaper, it is stopped before RabbitMQ and MongoDB are closed
+	cleanups = append(cleanups, initOutboxRelay(reportUseCase, logger))
+
+	// Start the stuck-report reaper; it is stopped before Redis and MongoDB are closed
+	reportReaper, reaperCleanup := initReportReaper(cfg, reportUseCase, redisConsumerRepository, logger)
+	if reaperCleanup != nil {
+		cleanups = append(cleanups, reaperCleanup)
+	}
+
+	// Start the retention purge; like the reaper, it is stopped before Redis and MongoDB are closed
+	reportPurger, purgerCleanup := initReportPurger(cfg, reportUseCase, redisConsumerRepository, logger)
+	if purgerCleanup != nil {
+		cleanups = append(cleanups, purgerCleanup)
+	}
+
 	dataSourceHandler, err := httpIn.NewDataSourceHandler(&services.UseCase{
 		ExternalDataSources: externalDataSources,
 		RedisRepo:           redisConsumerRepository,
@@ -355,6 +515,11 @@ func InitServers() (_ *Service, err error) {
 		return nil, fmt.Errorf("failed to initialize data source handler: %w", err)
 	}
 
+	deadLetterHandler, err := httpIn.NewDeadLetterHandler(reportUseCase)
+	if err != nil {
+		return nil, fmt.Errorf("failed to initialize dead letter handler: %w", err)
+	}
+
 	// Build HTTP server with routes, middleware, and readiness probes
 	authClient := middleware.NewAuthClient(cfg.AuthAddress, cfg.AuthEnabled, &logger)
 
@@ -365,6 +530,14 @@ func InitServers() (_ *Service, err error) {
 		StorageClient:      storageClient,
 	}
 
+	if reportReaper != nil {
+		readinessDeps.ReportReaper = reportReaper
+	}
+
+	if reportPurger != nil {
+		readinessDeps.ReportPurger = reportPurger
+	}
+
 	corsConfig := httpIn.CORSConfig{
 		AllowedOrigins: cfg.CORSAllowedOrigins,
 		AllowedMethods: cfg.CORSAllowedMethods,
@@ -374,7 +547,7 @@ func InitServers() (_ *Service, err error) {
 	rateLimitConfig := buildRateLimitConfig(cfg, redisConnection, logger)
 	trustedProxies := parseTrustedProxies(cfg.TrustedProxies)
 
-	httpApp := httpIn.NewRoutes(logger, telemetry, templateHandler, reportHandler, dataSourceHandler, authClient, readinessDeps, corsConfig, rateLimitConfig, trustedProxies)
+	httpApp := httpIn.NewRoutes(logger, telemetry, templateHandler, reportHandler, dataSourceHandler, deadLetterHandler, authClient, readinessDeps, corsConfig, rateLimitConfig, trustedProxies, cfg.MultiTenantEnabled)
 	serverAPI := NewServer(cfg, httpApp, logger, telemetry)
 
 	// Build consolidated shutdown cleanup from the same cleanup stack used for
@@ -8,6 +8,7 @@ import (
 	"strings"
 	"testing"
 
+	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -183,3 +184,185 @@ func TestConfig_Validate_OptionalFieldsCanBeEmpty(t *testing.T) {
 	err := cfg.Validate()
 	require.NoError(t, err)
 }
+
+func TestConfig_Validate_ReportReaper(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		interval    int
+		timeout     int
+		errContains string
+	}{
+		{name: "Disabled without timeout", interval: 0, timeout: 0},
+		{name: "Enabled with timeout", interval: 60, timeout: 1800},
+		{name: "Enabled without timeout", interval: 60, timeout: 0, errContains: "REPORT_PROCESSING_TIMEOUT_SECONDS must be greater than 0"},
+		{name: "Negative interval", interval: -1, timeout: 1800, errContains: "REPORT_REAPER_INTERVAL_SECONDS must not be negative"},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			cfg := validManagerConfig()
+			cfg.ReportReaperIntervalSeconds = tt.interval
+			cfg.ReportProcessingTimeoutSeconds = tt.timeout
+
+			err := cfg.Validate()
+			if tt.errContains == "" {
+				require.NoError(t, err)
+
+				return
+			}
+
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.errContains)
+		})
+	}
+}
+
+func TestConfig_Validate_ReportDownload(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		expiry      int
+		errContains string
+	}{
+		{name: "Default expiry", expiry: 0},
+		{name: "Configured expiry", expiry: 300},
+		{name: "Longest expiry", expiry: 604800},
+		{name: "Negative expiry", expiry: -1, errContains: "REPORT_DOWNLOAD_URL_EXPIRY_SECONDS must be between 0 and 604800"},
+		{name: "Expiry longer than 7 days", expiry: 604801, errContains: "REPORT_DOWNLOAD_URL_EXPIRY_SECONDS must be between 0 and 604800"},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			cfg := validManagerConfig()
+			cfg.ReportDownloadURLExpirySeconds = tt.expiry
+
+			err := cfg.Validate()
+			if tt.errContains == "" {
+				require.NoError(t, err)
+
+				return
+			}
+
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.errContains)
+		})
+	}
+}
+
+func TestConfig_Validate_PriorityLanes(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		highQueue   string
+		highKey     string
+		lowQueue    string
+		lowKey      string
+		errContains string
+	}{
+		{name: "No lanes"},
+		{name: "Both lanes", highQueue: "high.queue", highKey: "high.key", lowQueue: "low.queue", lowKey: "low.key"},
+		{name: "High queue without key", highQueue: "high.queue", errContains: "RABBITMQ_GENERATE_REPORT_HIGH_QUEUE and RABBITMQ_GENERATE_REPORT_HIGH_KEY must be set together"},
+		{name: "Low key without queue", lowKey: "low.key", errContains: "RABBITMQ_GENERATE_REPORT_LOW_QUEUE and RABBITMQ_GENERATE_REPORT_LOW_KEY must be set together"},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			cfg := validManagerConfig()
+			cfg.RabbitMQHighPriorityQueue = tt.highQueue
+			cfg.RabbitMQHighPriorityKey = tt.highKey
+			cfg.RabbitMQLowPriorityQueue = tt.lowQueue
+			cfg.RabbitMQLowPriorityKey = tt.lowKey
+
+			err := cfg.Validate()
+			if tt.errContains == "" {
+				require.NoError(t, err)
+
+				return
+			}
+
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.errContains)
+		})
+	}
+}
+
+func TestConfig_GenerationLanes(t *testing.T) {
+	t.Parallel()
+
+	cfg := validManagerConfig()
+	cfg.RabbitMQLowPriorityQueue = "reporter.generate-report.low.queue"
+	cfg.RabbitMQLowPriorityKey = "reporter.generate-report.low.key"
+
+	lanes := cfg.generationLanes()
+
+	require.Len(t, lanes, 1)
+	assert.Equal(t, "reporter.generate-report.low.key", lanes.RoutingKey("low", cfg.RabbitMQGenerateReportKey))
+	assert.Equal(t, cfg.RabbitMQGenerateReportKey, lanes.RoutingKey("high", cfg.RabbitMQGenerateReportKey))
+}
+
+func TestConfig_Validate_ReportRetention(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		days        int
+		tenantDays  string
+		interval    int
+		errContains string
+	}{
+		{name: "Disabled", days: 0, interval: 0},
+		{name: "Default and tenant rules", days: 90, tenantDays: "9a9b3c4e-8f41-4d4e-9b1a-2f6d5c7e8a90=30,1c2d3e4f-5a6b-4c7d-8e9f-0a1b2c3d4e5f=0", interval: 3600},
+		{name: "Negative days", days: -1, errContains: "REPORT_RETENTION_DAYS must be between 0 and 36500"},
+		{name: "Days too long", days: 36501, errContains: "REPORT_RETENTION_DAYS must be between 0 and 36500"},
+		{name: "Negative interval", days: 90, interval: -1, errContains: "REPORT_PURGE_INTERVAL_SECONDS must not be negative"},
+		{name: "Invalid tenant rule", days: 90, tenantDays: "not-a-uuid=30", interval: 3600, errContains: "REPORT_RETENTION_TENANT_DAYS is invalid"},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			cfg := validManagerConfig()
+			cfg.ReportRetentionDays = tt.days
+			cfg.ReportRetentionTenantDays = tt.tenantDays
+			cfg.ReportPurgeIntervalSeconds = tt.interval
+
+			err := cfg.Validate()
+			if tt.errContains == "" {
+				require.NoError(t, err)
+
+				return
+			}
+
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.errContains)
+		})
+	}
+}
+
+func TestConfig_RetentionPolicy(t *testing.T) {
+	t.Parallel()
+
+	cfg := validManagerConfig()
+	cfg.ReportRetentionDays = 90
+	cfg.ReportRetentionTenantDays = "9a9b3c4e-8f41-4d4e-9b1a-2f6d5c7e8a90=30"
+
+	policy := cfg.retentionPolicy()
+
+	assert.Equal(t, 90, policy.DefaultDays)
+	assert.Equal(t, map[uuid.UUID]int{uuid.MustParse("9a9b3c4e-8f41-4d4e-9b1a-2f6d5c7e8a90"): 30}, policy.TenantDays)
+}
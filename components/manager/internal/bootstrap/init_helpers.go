@@ -14,8 +14,11 @@ import (
 
 	"github.com/LerianStudio/reporter/components/manager/internal/adapters/rabbitmq"
 	"github.com/LerianStudio/reporter/components/manager/internal/adapters/redis"
+	"github.com/LerianStudio/reporter/components/manager/internal/services"
 	"github.com/LerianStudio/reporter/pkg"
 	"github.com/LerianStudio/reporter/pkg/constant"
+	"github.com/LerianStudio/reporter/pkg/mongodb/batch"
+	"github.com/LerianStudio/reporter/pkg/mongodb/outbox"
 	"github.com/LerianStudio/reporter/pkg/mongodb/report"
 	"github.com/LerianStudio/reporter/pkg/mongodb/template"
 	"github.com/LerianStudio/reporter/pkg/storage"
@@ -34,13 +37,19 @@ type mongoResources struct {
 	connection   *mongoDB.MongoConnection
 	templateRepo *template.TemplateMongoDBRepository
 	reportRepo   *report.ReportMongoDBRepository
+	outboxRepo   *outbox.OutboxMongoDBRepository
+	batchRepo    *batch.BatchMongoDBRepository
 }
 
 // rabbitResources holds RabbitMQ-related resources created during initialization.
 type rabbitResources struct {
 	connection *libRabbitmq.RabbitMQConnection
 	producer   *rabbitmq.ProducerRabbitMQRepository
+	inspector  *rabbitmq.QueueInspectorRabbitMQ
 	monitor    *RabbitMQMonitor
+
+	// deadLetters manages the dead letter queue. Nil when RABBITMQ_DLQ_QUEUE is not set.
+	deadLetters *rabbitmq.DeadLetterRabbitMQRepository
 }
 
 // initConfigAndLogger loads configuration from environment variables, validates it,
@@ -112,8 +121,8 @@ func initStorage(cfg *Config, logger log.Logger) (storage.ObjectStorage, error)
 	return storageClient, nil
 }
 
-// initMongoDB establishes the MongoDB connection, creates template and report
-// repositories, ensures indexes exist, and returns a cleanup function that
+// initMongoDB establishes the MongoDB connection, creates template, report, report outbox and
+// report batch repositories, ensures indexes exist, and returns a cleanup function that
 // disconnects the client.
 func initMongoDB(cfg *Config, logger log.Logger) (*mongoResources, func(), error) {
 	escapedPass := url.QueryEscape(cfg.MongoDBPassword)
@@ -148,8 +157,18 @@ func initMongoDB(cfg *Config, logger log.Logger) (*mongoResources, func(), error
 		return nil, nil, fmt.Errorf("failed to initialize report mongodb repository: %w", err)
 	}
 
+	outboxMongoDBRepository, err := outbox.NewOutboxMongoDBRepository(mongoConnection)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to initialize report outbox mongodb repository: %w", err)
+	}
+
+	batchMongoDBRepository, err := batch.NewBatchMongoDBRepository(mongoConnection)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to initialize report batch mongodb repository: %w", err)
+	}
+
 	// Create MongoDB indexes
-	logger.Info("Ensuring MongoDB indexes exist for templates and reports...")
+	logger.Info("Ensuring MongoDB indexes exist for templates, reports and the report outbox...")
 
 	ctx := pkg.ContextWithLogger(context.Background(), logger)
 
@@ -161,6 +180,10 @@ func initMongoDB(cfg *Config, logger log.Logger) (*mongoResources, func(), error
 		return nil, nil, fmt.Errorf("failed to ensure report indexes: %w", err)
 	}
 
+	if err = outboxMongoDBRepository.EnsureIndexes(ctx); err != nil {
+		return nil, nil, fmt.Errorf("failed to ensure report outbox indexes: %w", err)
+	}
+
 	cleanup := func() {
 		if mongoConnection.DB != nil {
 			logger.Info("Cleanup: disconnecting MongoDB")
@@ -175,6 +198,8 @@ func initMongoDB(cfg *Config, logger log.Logger) (*mongoResources, func(), error
 		connection:   mongoConnection,
 		templateRepo: templateMongoDBRepository,
 		reportRepo:   reportMongoDBRepository,
+		outboxRepo:   outboxMongoDBRepository,
+		batchRepo:    batchMongoDBRepository,
 	}, cleanup, nil
 }
 
@@ -231,11 +256,76 @@ func initRabbitMQ(cfg *Config, logger log.Logger) (*rabbitResources, []func()) {
 		},
 	}
 
-	return &rabbitResources{
+	resources := &rabbitResources{
 		connection: rabbitMQConnection,
 		producer:   producerRabbitMQRepository,
+		inspector:  rabbitmq.NewQueueInspectorRabbitMQ(rabbitMQConnection),
 		monitor:    rabbitMQMonitor,
-	}, cleanups
+	}
+
+	if cfg.RabbitMQDLQQueue != "" {
+		resources.deadLetters = rabbitmq.NewDeadLetterRabbitMQ(rabbitMQConnection, cfg.RabbitMQDLQQueue, cfg.RabbitMQExchange, cfg.RabbitMQGenerateReportKey, cfg.generationLanes())
+	}
+
+	return resources, cleanups
+}
+
+// initOutboxRelay starts the relay publishing the report outbox and returns a cleanup function that stops it.
+func initOutboxRelay(useCase *services.UseCase, logger log.Logger) func() {
+	relay := NewOutboxRelay(useCase, logger, constant.OutboxRelayInterval)
+	relay.Start()
+
+	logger.Infof("Outbox relay started: every %v", constant.OutboxRelayInterval)
+
+	return func() {
+		logger.Info("Cleanup: stopping outbox relay")
+		relay.Stop()
+	}
+}
+
+// initReportReaper starts the stuck-report reaper when REPORT_REAPER_INTERVAL_SECONDS is set and returns it
+// along with a cleanup function that stops it. Both are nil when the reaper is disabled.
+func initReportReaper(cfg *Config, useCase *services.UseCase, redisRepo *redis.RedisConsumerRepository, logger log.Logger) (*ReportReaper, func()) {
+	if cfg.ReportReaperIntervalSeconds <= 0 {
+		logger.Info("Report reaper disabled")
+
+		return nil, nil
+	}
+
+	interval := time.Duration(cfg.ReportReaperIntervalSeconds) * time.Second
+	timeout := time.Duration(cfg.ReportProcessingTimeoutSeconds) * time.Second
+
+	reaper := NewReportReaper(useCase, redisRepo, logger, interval, timeout)
+	reaper.Start()
+
+	logger.Infof("Report reaper started: every %v for reports in Processing for more than %v", interval, timeout)
+
+	return reaper, func() {
+		logger.Info("Cleanup: stopping report reaper")
+		reaper.Stop()
+	}
+}
+
+// initReportPurger starts the retention purge when REPORT_PURGE_INTERVAL_SECONDS is set and returns it
+// along with a cleanup function that stops it. Both are nil when the purge is disabled.
+func initReportPurger(cfg *Config, useCase *services.UseCase, redisRepo *redis.RedisConsumerRepository, logger log.Logger) (*ReportPurger, func()) {
+	if cfg.ReportPurgeIntervalSeconds <= 0 {
+		logger.Info("Report purger disabled")
+
+		return nil, nil
+	}
+
+	interval := time.Duration(cfg.ReportPurgeIntervalSeconds) * time.Second
+
+	purger := NewReportPurger(useCase, redisRepo, logger, interval)
+	purger.Start()
+
+	logger.Infof("Report purger started: every %v for reports kept for more than %d days by default", interval, cfg.ReportRetentionDays)
+
+	return purger, func() {
+		logger.Info("Cleanup: stopping report purger")
+		purger.Stop()
+	}
 }
 
 // initRedis establishes the Redis/Valkey connection and returns the consumer
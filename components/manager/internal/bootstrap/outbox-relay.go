@@ -0,0 +1,83 @@
+// Copyright (c) 2026 Lerian Studio. All rights reserved.
+// Use of this source code is governed by the Elastic License 2.0
+// that can be found in the LICENSE file.
+
+package bootstrap
+
+import (
+	"context"
+	"time"
+
+	"github.com/LerianStudio/reporter/components/manager/internal/services"
+	"github.com/LerianStudio/reporter/pkg"
+
+	"github.com/LerianStudio/lib-commons/v2/commons/log"
+)
+
+// relayTickerFactory creates a channel that ticks at the given interval and a stop function.
+// Overridable in tests for deterministic behavior.
+var relayTickerFactory = func(interval time.Duration) (<-chan time.Time, func()) {
+	t := time.NewTicker(interval)
+	return t.C, t.Stop
+}
+
+// OutboxRelay periodically publishes the report outbox entries that were not published when their
+// report was created, e.g. because the broker was unavailable or the manager stopped in between.
+// Entries are claimed before being published, so every manager instance runs its own relay.
+type OutboxRelay struct {
+	useCase  *services.UseCase
+	logger   log.Logger
+	interval time.Duration
+
+	stop chan struct{}
+	done chan struct{}
+}
+
+// NewOutboxRelay creates a relay publishing the due outbox entries every interval.
+func NewOutboxRelay(useCase *services.UseCase, logger log.Logger, interval time.Duration) *OutboxRelay {
+	return &OutboxRelay{
+		useCase:  useCase,
+		logger:   logger,
+		interval: interval,
+		stop:     make(chan struct{}),
+		done:     make(chan struct{}),
+	}
+}
+
+// Start launches the background relay goroutine.
+func (r *OutboxRelay) Start() {
+	pkg.GoNamed(r.logger, "outbox-relay", func() { r.relayLoop() })
+}
+
+// Stop signals the relay to shut down and waits for it to finish.
+func (r *OutboxRelay) Stop() {
+	close(r.stop)
+	<-r.done
+}
+
+// relayLoop is the background goroutine that dispatches the outbox on every tick.
+func (r *OutboxRelay) relayLoop() {
+	defer close(r.done)
+
+	tickCh, stopTicker := relayTickerFactory(r.interval)
+	defer stopTicker()
+
+	for {
+		select {
+		case <-r.stop:
+			r.logger.Info("Outbox relay stopped")
+
+			return
+		case <-tickCh:
+			r.dispatch()
+		}
+	}
+}
+
+// dispatch publishes the due outbox entries. Failures are logged by the use case and retried on the next tick.
+func (r *OutboxRelay) dispatch() {
+	ctx, cancel := context.WithTimeout(pkg.ContextWithLogger(context.Background(), r.logger), r.interval+time.Minute)
+	defer cancel()
+
+	_, _ = r.useCase.DispatchOutbox(ctx)
+}
@@ -0,0 +1,53 @@
+// Copyright (c) 2026 Lerian Studio. All rights reserved.
+// Use of this source code is governed by the Elastic License 2.0
+// that can be found in the LICENSE file.
+
+package bootstrap
+
+import (
+	"testing"
+	"time"
+
+	"github.com/LerianStudio/reporter/components/manager/internal/services"
+	"github.com/LerianStudio/reporter/pkg/mongodb/outbox"
+
+	"github.com/LerianStudio/lib-commons/v2/commons/zap"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.uber.org/mock/gomock"
+)
+
+// TestOutboxRelay_Lifecycle modifies the package-level relayTickerFactory, so it does not run in parallel.
+func TestOutboxRelay_Lifecycle(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	tickCh := make(chan time.Time, 1)
+	dispatched := make(chan struct{})
+
+	original := relayTickerFactory
+	relayTickerFactory = func(time.Duration) (<-chan time.Time, func()) { return tickCh, func() {} }
+
+	defer func() { relayTickerFactory = original }()
+
+	mockOutboxRepo := outbox.NewMockRepository(ctrl)
+	mockOutboxRepo.EXPECT().
+		Claim(gomock.Any(), gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_, _, _ any) (*outbox.Entry, error) {
+			close(dispatched)
+
+			return nil, mongo.ErrNoDocuments
+		})
+
+	relay := NewOutboxRelay(&services.UseCase{OutboxRepo: mockOutboxRepo}, zap.InitializeLogger(), time.Second)
+	relay.Start()
+
+	tickCh <- time.Now()
+
+	select {
+	case <-dispatched:
+	case <-time.After(5 * time.Second):
+		t.Fatal("relay did not dispatch the outbox on tick")
+	}
+
+	relay.Stop()
+}
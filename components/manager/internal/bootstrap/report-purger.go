@@ -0,0 +1,161 @@
+// Copyright (c) 2026 Lerian Studio. All rights reserved.
+// Use of this source code is governed by the Elastic License 2.0
+// that can be found in the LICENSE file.
+
+package bootstrap
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/LerianStudio/reporter/components/manager/internal/services"
+	"github.com/LerianStudio/reporter/pkg"
+	"github.com/LerianStudio/reporter/pkg/constant"
+	"github.com/LerianStudio/reporter/pkg/model"
+	pkgRedis "github.com/LerianStudio/reporter/pkg/redis"
+
+	"github.com/LerianStudio/lib-commons/v2/commons/log"
+	"github.com/google/uuid"
+)
+
+// purgerTickerFactory creates a channel that ticks at the given interval and a stop function.
+// Overridable in tests for deterministic behavior.
+var purgerTickerFactory = func(interval time.Duration) (<-chan time.Time, func()) {
+	t := time.NewTicker(interval)
+	return t.C, t.Stop
+}
+
+// ReportPurger periodically deletes the reports kept for longer than their retention policy. Like the
+// reaper, only the manager instance holding the leader lease in Redis runs the sweeps.
+type ReportPurger struct {
+	useCase    *services.UseCase
+	redisRepo  pkgRedis.RedisRepository
+	logger     log.Logger
+	instanceID string
+	interval   time.Duration
+
+	mu    sync.Mutex
+	stats model.ReportPurgerStats
+
+	stop chan struct{}
+	done chan struct{}
+}
+
+// NewReportPurger creates a purger deleting the expired reports every interval.
+func NewReportPurger(useCase *services.UseCase, redisRepo pkgRedis.RedisRepository, logger log.Logger, interval time.Duration) *ReportPurger {
+	return &ReportPurger{
+		useCase:    useCase,
+		redisRepo:  redisRepo,
+		logger:     logger,
+		instanceID: uuid.NewString(),
+		interval:   interval,
+		stop:       make(chan struct{}),
+		done:       make(chan struct{}),
+	}
+}
+
+// Start launches the background purger goroutine.
+func (p *ReportPurger) Start() {
+	pkg.GoNamed(p.logger, "report-purger", func() { p.purgeLoop() })
+}
+
+// Stop signals the purger to shut down and waits for it to finish.
+func (p *ReportPurger) Stop() {
+	close(p.stop)
+	<-p.done
+}
+
+// Stats returns the totals of the purger since the manager started.
+func (p *ReportPurger) Stats() model.ReportPurgerStats {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	return p.stats
+}
+
+// purgeLoop is the background goroutine that runs a sweep on every tick.
+func (p *ReportPurger) purgeLoop() {
+	defer close(p.done)
+
+	tickCh, stopTicker := purgerTickerFactory(p.interval)
+	defer stopTicker()
+
+	for {
+		select {
+		case <-p.stop:
+			p.logger.Info("Report purger stopped")
+
+			return
+		case <-tickCh:
+			p.sweep()
+		}
+	}
+}
+
+// sweep purges the expired reports when this instance holds the leader lease.
+func (p *ReportPurger) sweep() {
+	ctx, cancel := context.WithTimeout(pkg.ContextWithLogger(context.Background(), p.logger), p.interval)
+	defer cancel()
+
+	leader, err := p.acquireLeadership(ctx)
+	if err != nil {
+		p.logger.Errorf("Report purger failed to acquire the leader lease: %v", err)
+	}
+
+	p.mu.Lock()
+	p.stats.Leader = leader
+	p.mu.Unlock()
+
+	if !leader {
+		return
+	}
+
+	result, err := p.useCase.PurgeExpiredReports(ctx)
+
+	now := time.Now()
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.stats.Sweeps++
+	p.stats.LastRunAt = &now
+	p.stats.LastError = ""
+
+	if err != nil {
+		p.stats.LastError = err.Error()
+
+		return
+	}
+
+	p.stats.Add(*result)
+}
+
+// acquireLeadership takes the leader lease when it is free or renews it when this instance already holds it.
+func (p *ReportPurger) acquireLeadership(ctx context.Context) (bool, error) {
+	lease := 2 * p.interval
+
+	acquired, err := p.redisRepo.SetNX(ctx, constant.ReportPurgerLeaderKey, p.instanceID, lease)
+	if err != nil {
+		return false, err
+	}
+
+	if acquired {
+		return true, nil
+	}
+
+	holder, err := p.redisRepo.Get(ctx, constant.ReportPurgerLeaderKey)
+	if err != nil {
+		return false, err
+	}
+
+	if holder != p.instanceID {
+		return false, nil
+	}
+
+	if err := p.redisRepo.Set(ctx, constant.ReportPurgerLeaderKey, p.instanceID, lease); err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
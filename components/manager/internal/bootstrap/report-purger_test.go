@@ -0,0 +1,148 @@
+// Copyright (c) 2026 Lerian Studio. All rights reserved.
+// Use of this source code is governed by the Elastic License 2.0
+// that can be found in the LICENSE file.
+
+package bootstrap
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/LerianStudio/reporter/components/manager/internal/services"
+	"github.com/LerianStudio/reporter/pkg/constant"
+	"github.com/LerianStudio/reporter/pkg/model"
+	"github.com/LerianStudio/reporter/pkg/mongodb/report"
+	"github.com/LerianStudio/reporter/pkg/mongodb/template"
+	pkgRedis "github.com/LerianStudio/reporter/pkg/redis"
+
+	"github.com/LerianStudio/lib-commons/v2/commons/zap"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestReportPurger_Sweep(t *testing.T) {
+	t.Parallel()
+
+	interval := time.Hour
+	lease := 2 * interval
+
+	tests := []struct {
+		name       string
+		mockSetup  func(redisRepo *pkgRedis.MockRedisRepository, reportRepo *report.MockRepository, tempRepo *template.MockRepository, instanceID string)
+		wantLeader bool
+		wantSweeps int
+		wantError  string
+	}{
+		{
+			name: "Lease acquired - sweep runs",
+			mockSetup: func(redisRepo *pkgRedis.MockRedisRepository, reportRepo *report.MockRepository, tempRepo *template.MockRepository, instanceID string) {
+				redisRepo.EXPECT().SetNX(gomock.Any(), constant.ReportPurgerLeaderKey, instanceID, lease).Return(true, nil)
+				tempRepo.EXPECT().FindRetentionDays(gomock.Any()).Return(map[uuid.UUID]int{}, nil)
+				reportRepo.EXPECT().FindPendingFiles(gomock.Any(), gomock.Any()).Return(nil, nil)
+				reportRepo.EXPECT().FindExpired(gomock.Any(), gomock.Any()).Return([]*report.Report{}, nil)
+			},
+			wantLeader: true,
+			wantSweeps: 1,
+		},
+		{
+			name: "Lease renewed - sweep runs",
+			mockSetup: func(redisRepo *pkgRedis.MockRedisRepository, reportRepo *report.MockRepository, tempRepo *template.MockRepository, instanceID string) {
+				redisRepo.EXPECT().SetNX(gomock.Any(), constant.ReportPurgerLeaderKey, instanceID, lease).Return(false, nil)
+				redisRepo.EXPECT().Get(gomock.Any(), constant.ReportPurgerLeaderKey).Return(instanceID, nil)
+				redisRepo.EXPECT().Set(gomock.Any(), constant.ReportPurgerLeaderKey, instanceID, lease).Return(nil)
+				tempRepo.EXPECT().FindRetentionDays(gomock.Any()).Return(nil, errors.New("connection refused"))
+			},
+			wantLeader: true,
+			wantSweeps: 1,
+			wantError:  "connection refused",
+		},
+		{
+			name: "Lease held by another instance - sweep skipped",
+			mockSetup: func(redisRepo *pkgRedis.MockRedisRepository, _ *report.MockRepository, _ *template.MockRepository, instanceID string) {
+				redisRepo.EXPECT().SetNX(gomock.Any(), constant.ReportPurgerLeaderKey, instanceID, lease).Return(false, nil)
+				redisRepo.EXPECT().Get(gomock.Any(), constant.ReportPurgerLeaderKey).Return("another-instance", nil)
+			},
+		},
+		{
+			name: "Redis unavailable - sweep skipped",
+			mockSetup: func(redisRepo *pkgRedis.MockRedisRepository, _ *report.MockRepository, _ *template.MockRepository, _ string) {
+				redisRepo.EXPECT().SetNX(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(false, errors.New("connection refused"))
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockRedisRepo := pkgRedis.NewMockRedisRepository(ctrl)
+			mockReportRepo := report.NewMockRepository(ctrl)
+			mockTempRepo := template.NewMockRepository(ctrl)
+
+			useCase := &services.UseCase{
+				ReportRepo:      mockReportRepo,
+				TemplateRepo:    mockTempRepo,
+				RetentionPolicy: &model.RetentionPolicy{DefaultDays: 30},
+			}
+
+			purger := NewReportPurger(useCase, mockRedisRepo, zap.InitializeLogger(), interval)
+
+			tt.mockSetup(mockRedisRepo, mockReportRepo, mockTempRepo, purger.instanceID)
+
+			purger.sweep()
+
+			stats := purger.Stats()
+			assert.Equal(t, tt.wantLeader, stats.Leader)
+			assert.Equal(t, tt.wantSweeps, stats.Sweeps)
+			assert.Equal(t, tt.wantError, stats.LastError)
+
+			if tt.wantSweeps > 0 {
+				require.NotNil(t, stats.LastRunAt)
+			}
+		})
+	}
+}
+
+// TestReportPurger_Lifecycle modifies the package-level purgerTickerFactory, so it does not run in parallel.
+func TestReportPurger_Lifecycle(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	tickCh := make(chan time.Time, 1)
+	swept := make(chan struct{})
+
+	original := purgerTickerFactory
+	purgerTickerFactory = func(time.Duration) (<-chan time.Time, func()) { return tickCh, func() {} }
+
+	defer func() { purgerTickerFactory = original }()
+
+	mockRedisRepo := pkgRedis.NewMockRedisRepository(ctrl)
+	mockRedisRepo.EXPECT().
+		SetNX(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_, _, _, _ any) (bool, error) {
+			close(swept)
+
+			return false, nil
+		})
+	mockRedisRepo.EXPECT().Get(gomock.Any(), gomock.Any()).Return("another-instance", nil)
+
+	purger := NewReportPurger(&services.UseCase{}, mockRedisRepo, zap.InitializeLogger(), time.Hour)
+	purger.Start()
+
+	tickCh <- time.Now()
+
+	select {
+	case <-swept:
+	case <-time.After(5 * time.Second):
+		t.Fatal("purger did not sweep on tick")
+	}
+
+	purger.Stop()
+}
@@ -0,0 +1,165 @@
+// Copyright (c) 2026 Lerian Studio. All rights reserved.
+// Use of this source code is governed by the Elastic License 2.0
+// that can be found in the LICENSE file.
+
+package bootstrap
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/LerianStudio/reporter/components/manager/internal/services"
+	"github.com/LerianStudio/reporter/pkg"
+	"github.com/LerianStudio/reporter/pkg/constant"
+	"github.com/LerianStudio/reporter/pkg/model"
+	pkgRedis "github.com/LerianStudio/reporter/pkg/redis"
+
+	"github.com/LerianStudio/lib-commons/v2/commons/log"
+	"github.com/google/uuid"
+)
+
+// reaperTickerFactory creates a channel that ticks at the given interval and a stop function.
+// Overridable in tests for deterministic behavior.
+var reaperTickerFactory = func(interval time.Duration) (<-chan time.Time, func()) {
+	t := time.NewTicker(interval)
+	return t.C, t.Stop
+}
+
+// ReportReaper periodically reconciles the reports left in Processing, e.g. after a worker crash or
+// when their message was dead-lettered. Only the manager instance holding the leader lease in Redis
+// runs the sweeps, so running several replicas never reaps the same report twice.
+type ReportReaper struct {
+	useCase    *services.UseCase
+	redisRepo  pkgRedis.RedisRepository
+	logger     log.Logger
+	instanceID string
+	interval   time.Duration
+	timeout    time.Duration
+
+	mu    sync.Mutex
+	stats model.ReportReaperStats
+
+	stop chan struct{}
+	done chan struct{}
+}
+
+// NewReportReaper creates a reaper sweeping every interval the reports in Processing for longer than timeout.
+func NewReportReaper(useCase *services.UseCase, redisRepo pkgRedis.RedisRepository, logger log.Logger, interval, timeout time.Duration) *ReportReaper {
+	return &ReportReaper{
+		useCase:    useCase,
+		redisRepo:  redisRepo,
+		logger:     logger,
+		instanceID: uuid.NewString(),
+		interval:   interval,
+		timeout:    timeout,
+		stop:       make(chan struct{}),
+		done:       make(chan struct{}),
+	}
+}
+
+// Start launches the background reaper goroutine.
+func (r *ReportReaper) Start() {
+	pkg.GoNamed(r.logger, "report-reaper", func() { r.reapLoop() })
+}
+
+// Stop signals the reaper to shut down and waits for it to finish.
+func (r *ReportReaper) Stop() {
+	close(r.stop)
+	<-r.done
+}
+
+// Stats returns the totals of the reaper since the manager started.
+func (r *ReportReaper) Stats() model.ReportReaperStats {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.stats
+}
+
+// reapLoop is the background goroutine that runs a sweep on every tick.
+func (r *ReportReaper) reapLoop() {
+	defer close(r.done)
+
+	tickCh, stopTicker := reaperTickerFactory(r.interval)
+	defer stopTicker()
+
+	for {
+		select {
+		case <-r.stop:
+			r.logger.Info("Report reaper stopped")
+
+			return
+		case <-tickCh:
+			r.sweep()
+		}
+	}
+}
+
+// sweep reaps the stuck reports when this instance holds the leader lease.
+func (r *ReportReaper) sweep() {
+	ctx, cancel := context.WithTimeout(pkg.ContextWithLogger(context.Background(), r.logger), r.interval)
+	defer cancel()
+
+	leader, err := r.acquireLeadership(ctx)
+	if err != nil {
+		r.logger.Errorf("Report reaper failed to acquire the leader lease: %v", err)
+	}
+
+	r.mu.Lock()
+	r.stats.Leader = leader
+	r.mu.Unlock()
+
+	if !leader {
+		return
+	}
+
+	result, err := r.useCase.ReapStuckReports(ctx, r.timeout)
+
+	now := time.Now()
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.stats.Sweeps++
+	r.stats.LastRunAt = &now
+	r.stats.LastError = ""
+
+	if err != nil {
+		r.stats.LastError = err.Error()
+
+		return
+	}
+
+	r.stats.Add(*result)
+}
+
+// acquireLeadership takes the leader lease when it is free or renews it when this instance already holds it.
+// The lease outlives two intervals, so another instance takes over shortly after the leader stops.
+func (r *ReportReaper) acquireLeadership(ctx context.Context) (bool, error) {
+	lease := 2 * r.interval
+
+	acquired, err := r.redisRepo.SetNX(ctx, constant.ReportReaperLeaderKey, r.instanceID, lease)
+	if err != nil {
+		return false, err
+	}
+
+	if acquired {
+		return true, nil
+	}
+
+	holder, err := r.redisRepo.Get(ctx, constant.ReportReaperLeaderKey)
+	if err != nil {
+		return false, err
+	}
+
+	if holder != r.instanceID {
+		return false, nil
+	}
+
+	if err := r.redisRepo.Set(ctx, constant.ReportReaperLeaderKey, r.instanceID, lease); err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
@@ -0,0 +1,136 @@
+// Copyright (c) 2026 Lerian Studio. All rights reserved.
+// Use of this source code is governed by the Elastic License 2.0
+// that can be found in the LICENSE file.
+
+package bootstrap
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/LerianStudio/reporter/components/manager/internal/services"
+	"github.com/LerianStudio/reporter/pkg/constant"
+	"github.com/LerianStudio/reporter/pkg/mongodb/report"
+	pkgRedis "github.com/LerianStudio/reporter/pkg/redis"
+
+	"github.com/LerianStudio/lib-commons/v2/commons/zap"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestReportReaper_Sweep(t *testing.T) {
+	t.Parallel()
+
+	interval := time.Minute
+	lease := 2 * interval
+
+	tests := []struct {
+		name       string
+		mockSetup  func(redisRepo *pkgRedis.MockRedisRepository, reportRepo *report.MockRepository, instanceID string)
+		wantLeader bool
+		wantSweeps int
+		wantError  string
+	}{
+		{
+			name: "Lease acquired - sweep runs",
+			mockSetup: func(redisRepo *pkgRedis.MockRedisRepository, reportRepo *report.MockRepository, instanceID string) {
+				redisRepo.EXPECT().SetNX(gomock.Any(), constant.ReportReaperLeaderKey, instanceID, lease).Return(true, nil)
+				reportRepo.EXPECT().FindByStatus(gomock.Any(), gomock.Any()).Return([]*report.Report{}, nil)
+			},
+			wantLeader: true,
+			wantSweeps: 1,
+		},
+		{
+			name: "Lease renewed - sweep runs",
+			mockSetup: func(redisRepo *pkgRedis.MockRedisRepository, reportRepo *report.MockRepository, instanceID string) {
+				redisRepo.EXPECT().SetNX(gomock.Any(), constant.ReportReaperLeaderKey, instanceID, lease).Return(false, nil)
+				redisRepo.EXPECT().Get(gomock.Any(), constant.ReportReaperLeaderKey).Return(instanceID, nil)
+				redisRepo.EXPECT().Set(gomock.Any(), constant.ReportReaperLeaderKey, instanceID, lease).Return(nil)
+				reportRepo.EXPECT().FindByStatus(gomock.Any(), gomock.Any()).Return(nil, errors.New("connection refused"))
+			},
+			wantLeader: true,
+			wantSweeps: 1,
+			wantError:  "connection refused",
+		},
+		{
+			name: "Lease held by another instance - sweep skipped",
+			mockSetup: func(redisRepo *pkgRedis.MockRedisRepository, _ *report.MockRepository, instanceID string) {
+				redisRepo.EXPECT().SetNX(gomock.Any(), constant.ReportReaperLeaderKey, instanceID, lease).Return(false, nil)
+				redisRepo.EXPECT().Get(gomock.Any(), constant.ReportReaperLeaderKey).Return("another-instance", nil)
+			},
+		},
+		{
+			name: "Redis unavailable - sweep skipped",
+			mockSetup: func(redisRepo *pkgRedis.MockRedisRepository, _ *report.MockRepository, _ string) {
+				redisRepo.EXPECT().SetNX(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(false, errors.New("connection refused"))
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockRedisRepo := pkgRedis.NewMockRedisRepository(ctrl)
+			mockReportRepo := report.NewMockRepository(ctrl)
+
+			reaper := NewReportReaper(&services.UseCase{ReportRepo: mockReportRepo}, mockRedisRepo, zap.InitializeLogger(), interval, 30*time.Minute)
+
+			tt.mockSetup(mockRedisRepo, mockReportRepo, reaper.instanceID)
+
+			reaper.sweep()
+
+			stats := reaper.Stats()
+			assert.Equal(t, tt.wantLeader, stats.Leader)
+			assert.Equal(t, tt.wantSweeps, stats.Sweeps)
+			assert.Equal(t, tt.wantError, stats.LastError)
+
+			if tt.wantSweeps > 0 {
+				require.NotNil(t, stats.LastRunAt)
+			}
+		})
+	}
+}
+
+// TestReportReaper_Lifecycle modifies the package-level reaperTickerFactory, so it does not run in parallel.
+func TestReportReaper_Lifecycle(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	tickCh := make(chan time.Time, 1)
+	swept := make(chan struct{})
+
+	original := reaperTickerFactory
+	reaperTickerFactory = func(time.Duration) (<-chan time.Time, func()) { return tickCh, func() {} }
+
+	defer func() { reaperTickerFactory = original }()
+
+	mockRedisRepo := pkgRedis.NewMockRedisRepository(ctrl)
+	mockRedisRepo.EXPECT().
+		SetNX(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_, _, _, _ any) (bool, error) {
+			close(swept)
+
+			return false, nil
+		})
+	mockRedisRepo.EXPECT().Get(gomock.Any(), gomock.Any()).Return("another-instance", nil)
+
+	reaper := NewReportReaper(&services.UseCase{}, mockRedisRepo, zap.InitializeLogger(), time.Minute, 30*time.Minute)
+	reaper.Start()
+
+	tickCh <- time.Now()
+
+	select {
+	case <-swept:
+	case <-time.After(5 * time.Second):
+		t.Fatal("reaper did not sweep on tick")
+	}
+
+	reaper.Stop()
+}
@@ -0,0 +1,100 @@
+// Copyright (c) 2026 Lerian Studio. All rights reserved.
+// Use of this source code is governed by the Elastic License 2.0
+// that can be found in the LICENSE file.
+
+package services
+
+import (
+	"context"
+	"time"
+
+	"github.com/LerianStudio/reporter/pkg"
+	"github.com/LerianStudio/reporter/pkg/constant"
+	"github.com/LerianStudio/reporter/pkg/mongodb/report"
+	pkgHTTP "github.com/LerianStudio/reporter/pkg/net/http"
+
+	"github.com/LerianStudio/lib-commons/v2/commons"
+	libOpentelemetry "github.com/LerianStudio/lib-commons/v2/commons/opentelemetry"
+	"github.com/google/uuid"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// CancelReport moves a report of the organization that is still Processing to the Cancelled status.
+// The worker picks the cancellation up at its next checkpoint and stops generating the report, and
+// a message consumed after the cancellation is skipped. Reports already Finished or in Error can not
+// be cancelled.
+func (uc *UseCase) CancelReport(ctx context.Context, id, organizationID uuid.UUID) (*report.Report, error) {
+	logger, tracer, reqId, _ := commons.NewTrackingFromContext(ctx)
+
+	ctx, span := tracer.Start(ctx, "service.report.cancel")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("app.request.request_id", reqId),
+		attribute.String("app.request.report_id", id.String()),
+		attribute.String("app.request.organization_id", organizationID.String()),
+	)
+
+	logger.Infof("Cancelling report for id %v", id)
+
+	reportModel, err := uc.GetReportByID(ctx, id, organizationID)
+	if err != nil {
+		if pkgHTTP.IsBusinessError(err) {
+			libOpentelemetry.HandleSpanBusinessErrorEvent(&span, "Failed to retrieve report on query", err)
+		} else {
+			libOpentelemetry.HandleSpanError(&span, "Failed to retrieve report on query", err)
+		}
+
+		logger.Errorf("Failed to retrieve Report with ID: %s, Error: %s", id, err.Error())
+
+		return nil, err
+	}
+
+	if reportModel.Status != constant.ProcessingStatus {
+		errStatus := pkg.ValidateBusinessError(constant.ErrReportNotCancellable, "", reportModel.Status)
+
+		libOpentelemetry.HandleSpanBusinessErrorEvent(&span, "Report is not cancellable", errStatus)
+
+		logger.Errorf("Report with ID %s is %s and can not be cancelled", id, reportModel.Status)
+
+		return nil, errStatus
+	}
+
+	cancelledAt := time.Now()
+
+	// The transition only applies while the report is still Processing, so a worker
+	// finishing the report concurrently wins over the cancellation and vice versa.
+	cancelled, err := uc.ReportRepo.TransitionReportStatus(ctx, id, []string{constant.ProcessingStatus}, constant.CancelledStatus, cancelledAt, nil)
+	if err != nil {
+		libOpentelemetry.HandleSpanError(&span, "Failed to cancel report", err)
+
+		logger.Errorf("Failed to cancel Report with ID: %s, Error: %s", id, err.Error())
+
+		return nil, err
+	}
+
+	if !cancelled {
+		current, errGet := uc.GetReportByID(ctx, id, organizationID)
+		if errGet != nil {
+			libOpentelemetry.HandleSpanError(&span, "Failed to retrieve report on query", errGet)
+
+			return nil, errGet
+		}
+
+		errStatus := pkg.ValidateBusinessError(constant.ErrReportNotCancellable, "", current.Status)
+
+		libOpentelemetry.HandleSpanBusinessErrorEvent(&span, "Report is not cancellable", errStatus)
+
+		logger.Errorf("Report with ID %s became %s before it could be cancelled", id, current.Status)
+
+		return nil, errStatus
+	}
+
+	reportModel.Status = constant.CancelledStatus
+	reportModel.CompletedAt = &cancelledAt
+	reportModel.UpdatedAt = cancelledAt
+
+	logger.Infof("Report %s cancelled", id)
+
+	return reportModel, nil
+}
@@ -0,0 +1,147 @@
+// Copyright (c) 2026 Lerian Studio. All rights reserved.
+// Use of this source code is governed by the Elastic License 2.0
+// that can be found in the LICENSE file.
+
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/LerianStudio/reporter/pkg/constant"
+	"github.com/LerianStudio/reporter/pkg/mongodb/report"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.uber.org/mock/gomock"
+)
+
+func TestUseCase_CancelReport(t *testing.T) {
+	t.Parallel()
+
+	reportId := uuid.New()
+	orgId := uuid.New()
+	timeNow := time.Now()
+
+	reportWith := func(status string) *report.Report {
+		return &report.Report{
+			ID:         reportId,
+			TemplateID: uuid.New(),
+			Status:     status,
+			CreatedAt:  timeNow,
+			UpdatedAt:  timeNow,
+		}
+	}
+
+	processingOnly := []string{constant.ProcessingStatus}
+
+	tests := []struct {
+		name        string
+		mockSetup   func(mockReportRepo *report.MockRepository)
+		errContains string
+	}{
+		{
+			name: "Success - Cancel processing report",
+			mockSetup: func(mockReportRepo *report.MockRepository) {
+				mockReportRepo.EXPECT().
+					FindByID(gomock.Any(), reportId, orgId).
+					Return(reportWith(constant.ProcessingStatus), nil)
+
+				mockReportRepo.EXPECT().
+					TransitionReportStatus(gomock.Any(), reportId, processingOnly, constant.CancelledStatus, gomock.Any(), nil).
+					Return(true, nil)
+			},
+		},
+		{
+			name: "Error - Report not found",
+			mockSetup: func(mockReportRepo *report.MockRepository) {
+				mockReportRepo.EXPECT().
+					FindByID(gomock.Any(), reportId, orgId).
+					Return(nil, mongo.ErrNoDocuments)
+			},
+			errContains: "No report entity was found",
+		},
+		{
+			name: "Error - Report already finished",
+			mockSetup: func(mockReportRepo *report.MockRepository) {
+				mockReportRepo.EXPECT().
+					FindByID(gomock.Any(), reportId, orgId).
+					Return(reportWith(constant.FinishedStatus), nil)
+			},
+			errContains: "Finished status and can no longer be cancelled",
+		},
+		{
+			name: "Error - Report already cancelled",
+			mockSetup: func(mockReportRepo *report.MockRepository) {
+				mockReportRepo.EXPECT().
+					FindByID(gomock.Any(), reportId, orgId).
+					Return(reportWith(constant.CancelledStatus), nil)
+			},
+			errContains: "Cancelled status and can no longer be cancelled",
+		},
+		{
+			name: "Error - Report finished before the cancellation",
+			mockSetup: func(mockReportRepo *report.MockRepository) {
+				gomock.InOrder(
+					mockReportRepo.EXPECT().
+						FindByID(gomock.Any(), reportId, orgId).
+						Return(reportWith(constant.ProcessingStatus), nil),
+					mockReportRepo.EXPECT().
+						TransitionReportStatus(gomock.Any(), reportId, processingOnly, constant.CancelledStatus, gomock.Any(), nil).
+						Return(false, nil),
+					mockReportRepo.EXPECT().
+						FindByID(gomock.Any(), reportId, orgId).
+						Return(reportWith(constant.FinishedStatus), nil),
+				)
+			},
+			errContains: "Finished status and can no longer be cancelled",
+		},
+		{
+			name: "Error - Transition fails",
+			mockSetup: func(mockReportRepo *report.MockRepository) {
+				mockReportRepo.EXPECT().
+					FindByID(gomock.Any(), reportId, orgId).
+					Return(reportWith(constant.ProcessingStatus), nil)
+
+				mockReportRepo.EXPECT().
+					TransitionReportStatus(gomock.Any(), reportId, processingOnly, constant.CancelledStatus, gomock.Any(), nil).
+					Return(false, errors.New("database unavailable"))
+			},
+			errContains: "database unavailable",
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockReportRepo := report.NewMockRepository(ctrl)
+			tt.mockSetup(mockReportRepo)
+
+			reportSvc := &UseCase{
+				ReportRepo: mockReportRepo,
+			}
+
+			result, err := reportSvc.CancelReport(context.Background(), reportId, orgId)
+			if tt.errContains != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.errContains)
+				assert.Nil(t, result)
+
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, constant.CancelledStatus, result.Status)
+			assert.NotNil(t, result.CompletedAt)
+		})
+	}
+}
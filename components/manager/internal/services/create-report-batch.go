@@ -0,0 +1,384 @@
+// Copyright (c) 2026 Lerian Studio. All rights reserved.
+// Use of this source code is governed by the Elastic License 2.0
+// that can be found in the LICENSE file.
+
+package services
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/LerianStudio/reporter/pkg"
+	"github.com/LerianStudio/reporter/pkg/constant"
+	"github.com/LerianStudio/reporter/pkg/model"
+	"github.com/LerianStudio/reporter/pkg/mongodb/batch"
+	"github.com/LerianStudio/reporter/pkg/mongodb/outbox"
+	"github.com/LerianStudio/reporter/pkg/mongodb/report"
+	pkgHTTP "github.com/LerianStudio/reporter/pkg/net/http"
+
+	"github.com/LerianStudio/lib-commons/v2/commons"
+	libOpentelemetry "github.com/LerianStudio/lib-commons/v2/commons/opentelemetry"
+	"github.com/google/uuid"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// reportFilters are the filters of a report, by datasource, table and field.
+type reportFilters = map[string]map[string]map[string]model.FilterCondition
+
+// CreateReportBatch creates a report batch owned by the given organization with one child report per item,
+// filtered by the shared filters of the batch overridden by the filters of the item. The child reports are
+// queued by the outbox relay, so creating a batch never waits for thousands of publishes. When a chunk of reports
+// can not be stored, the reports stored before it are cancelled and the batch is marked failed.
+func (uc *UseCase) CreateReportBatch(ctx context.Context, organizationID uuid.UUID, input *model.CreateReportBatchInput) (*batch.Batch, error) {
+	logger, tracer, reqId, _ := commons.NewTrackingFromContext(ctx)
+
+	ctx, span := tracer.Start(ctx, "service.report_batch.create")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("app.request.request_id", reqId),
+		attribute.String("app.request.template_id", input.TemplateID),
+		attribute.String("app.request.organization_id", organizationID.String()),
+	)
+
+	logger.Infof("Creating report batch")
+
+	templateId, errParseUUID := uuid.Parse(input.TemplateID)
+	if errParseUUID != nil {
+		errInvalidID := pkg.ValidateBusinessError(constant.ErrInvalidTemplateID, "")
+
+		libOpentelemetry.HandleSpanBusinessErrorEvent(&span, "Invalid template ID format", errInvalidID)
+
+		return nil, errInvalidID
+	}
+
+	tOutputFormat, tMappedFields, tOutputOptions, tPriority, err := uc.findReportTemplate(ctx, templateId, organizationID, &span)
+	if err != nil {
+		return nil, err
+	}
+
+	rowLevelScope := uc.resolveRowLevelScope(ctx)
+
+	items, err := uc.reportBatchItems(ctx, input, rowLevelScope, &span)
+	if err != nil {
+		return nil, err
+	}
+
+	span.SetAttributes(attribute.Int("app.request.items", len(items)))
+
+	itemFilters := make([]reportFilters, 0, len(items))
+	allFilters := reportFilters{}
+
+	for _, item := range items {
+		filters := mergeReportBatchFilters(input.Filters, item)
+
+		itemFilters = append(itemFilters, filters)
+		allFilters = mergeReportBatchFilters(allFilters, filters)
+	}
+
+	// The fields of every item are validated at once, so each table schema is read a single time
+	if len(allFilters) > 0 {
+		if err := uc.validateReportFilters(ctx, allFilters, &span); err != nil {
+			return nil, err
+		}
+	}
+
+	batchModel, err := batch.NewBatch(commons.GenerateUUIDv7(), templateId, organizationID, input.Filters)
+	if err != nil {
+		libOpentelemetry.HandleSpanError(&span, "Failed to create report batch entity", err)
+
+		return nil, err
+	}
+
+	priority := reportPriority(input.Priority, tPriority)
+	now := time.Now()
+
+	reports := make([]*report.Report, 0, len(itemFilters))
+	entries := make([]*outbox.Entry, 0, len(itemFilters))
+
+	for _, item := range itemFilters {
+		// Inject the mandatory row-level predicates derived from the caller's auth claims
+		filters, err := uc.RowLevelPolicy.Apply(rowLevelScope, tMappedFields, item)
+		if err != nil {
+			libOpentelemetry.HandleSpanBusinessErrorEvent(&span, "Failed to apply row-level filters", err)
+
+			logger.Warnf("Rejected report batch request without required row-level scope: %v", err)
+
+			return nil, err
+		}
+
+		reportModel, err := report.NewReport(commons.GenerateUUIDv7(), templateId, organizationID, constant.ProcessingStatus, filters)
+		if err != nil {
+			libOpentelemetry.HandleSpanError(&span, "Failed to create report entity", err)
+
+			return nil, err
+		}
+
+		reportModel.BatchID = batchModel.ID
+		reportModel.Message = &model.ReportMessage{
+			TemplateID:     templateId,
+			ReportID:       reportModel.ID,
+			Filters:        filters,
+			OutputFormat:   *tOutputFormat,
+			MappedFields:   tMappedFields,
+			OrganizationID: organizationID,
+			RowLevelScope:  rowLevelScope,
+			Locale:         input.Locale,
+			Timezone:       input.Timezone,
+			OutputOptions:  tOutputOptions,
+			Priority:       priority,
+			ReuseOutput:    input.ReuseOutput,
+		}
+
+		if err := uc.signRowLevelScope(reportModel.Message); err != nil {
+			libOpentelemetry.HandleSpanError(&span, "Failed to sign row-level scope", err)
+
+			return nil, err
+		}
+
+		// Entries are created unclaimed, so the outbox relay publishes them right away
+		reports = append(reports, reportModel)
+		entries = append(entries, outbox.NewEntry(*reportModel.Message, uc.RabbitMQExchange, uc.generateReportKey(priority), now, 0))
+	}
+
+	result, err := uc.BatchRepo.Create(ctx, batchModel)
+	if err != nil {
+		libOpentelemetry.HandleSpanError(&span, "Failed to create report batch in repository", err)
+
+		logger.Errorf("Error creating report batch in database: %v", err)
+
+		return nil, err
+	}
+
+	for start := 0; start < len(reports); start += constant.ReportBatchInsertChunk {
+		end := min(start+constant.ReportBatchInsertChunk, len(reports))
+
+		if err := uc.ReportRepo.CreateManyWithOutbox(ctx, reports[start:end], entries[start:end]); err != nil {
+			libOpentelemetry.HandleSpanError(&span, "Failed to create report batch reports in repository", err)
+
+			logger.Errorf("Error creating reports %d to %d of report batch %s in database: %v", start, end, result.ID, err)
+
+			uc.abortReportBatch(ctx, result, start, err)
+
+			return nil, err
+		}
+	}
+
+	result.Progress = batch.NewProgress(map[string]int{constant.ProcessingStatus: len(reports)})
+
+	logger.Infof("Created report batch %s with %d reports", result.ID, len(reports))
+
+	return result, nil
+}
+
+// abortReportBatch cancels the reports of a batch stored before one of its chunks failed, so workers skip their
+// queued messages, and marks the batch failed. Errors are only logged: the caller already returns the chunk error.
+func (uc *UseCase) abortReportBatch(ctx context.Context, batchModel *batch.Batch, stored int, cause error) {
+	logger, _, _, _ := commons.NewTrackingFromContext(ctx) //nolint:dogsled // only logger needed from tracking context
+
+	now := time.Now()
+
+	if stored > 0 {
+		cancelled, err := uc.ReportRepo.CancelByBatch(ctx, batchModel.ID, batchModel.OrganizationID, now)
+		if err != nil {
+			logger.Errorf("Failed to cancel the %d stored reports of failed report batch %s: %v", stored, batchModel.ID, err)
+		} else {
+			logger.Warnf("Cancelled %d reports of failed report batch %s", cancelled, batchModel.ID)
+		}
+	}
+
+	reason := fmt.Sprintf("failed to store reports %d onwards: %v", stored, cause)
+
+	if err := uc.BatchRepo.MarkFailed(ctx, batchModel.ID, batchModel.OrganizationID, reason, now); err != nil {
+		logger.Errorf("Failed to mark report batch %s as failed: %v", batchModel.ID, err)
+	}
+}
+
+// reportBatchItems returns the filter overrides of the items of a batch, either listed in the input or
+// selected by its items query.
+func (uc *UseCase) reportBatchItems(ctx context.Context, input *model.CreateReportBatchInput, rowLevelScope map[string]string, span *trace.Span) ([]reportFilters, error) {
+	var (
+		items []reportFilters
+		err   error
+	)
+
+	switch {
+	case len(input.Items) > 0 && input.ItemsQuery != nil:
+		err = pkg.ValidateBusinessError(constant.ErrInvalidReportBatchItems, "", "items and itemsQuery are mutually exclusive")
+	case len(input.Items) > 0:
+		items = make([]reportFilters, 0, len(input.Items))
+		for _, item := range input.Items {
+			items = append(items, item.Filters)
+		}
+	case input.ItemsQuery != nil:
+		items, err = uc.queryReportBatchItems(ctx, input.ItemsQuery, rowLevelScope)
+	}
+
+	if err == nil && len(items) == 0 {
+		err = pkg.ValidateBusinessError(constant.ErrInvalidReportBatchItems, "", "the batch selects no items")
+	}
+
+	if err == nil && len(items) > constant.MaxReportBatchItems {
+		err = pkg.ValidateBusinessError(constant.ErrInvalidReportBatchItems, "", fmt.Sprintf("the batch selects more than %d items", constant.MaxReportBatchItems))
+	}
+
+	if err != nil {
+		if pkgHTTP.IsBusinessError(err) {
+			libOpentelemetry.HandleSpanBusinessErrorEvent(span, "Invalid report batch items", err)
+		} else {
+			libOpentelemetry.HandleSpanError(span, "Failed to select report batch items", err)
+		}
+
+		return nil, err
+	}
+
+	return items, nil
+}
+
+// queryReportBatchItems selects the distinct values of the field of an items query, in ascending order, each
+// becoming an item filtering the query target equal to it. The row-level predicates of the caller apply to the
+// query as they do to reports. The datasource returns at most MaxReportBatchItems+1 values, so the caller rejects
+// larger selections without the table ever being read whole.
+func (uc *UseCase) queryReportBatchItems(ctx context.Context, query *model.ReportBatchItemsQuery, rowLevelScope map[string]string) ([]reportFilters, error) {
+	logger, tracer, reqId, _ := commons.NewTrackingFromContext(ctx)
+
+	ctx, span := tracer.Start(ctx, "service.report_batch.query_items")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("app.request.request_id", reqId),
+		attribute.String("app.request.data_source_id", query.DataSource),
+		attribute.String("app.request.table", query.Table),
+	)
+
+	queried := map[string]map[string][]string{query.DataSource: {query.Table: {query.Field}}}
+
+	for field := range query.Filters {
+		queried[query.DataSource][query.Table] = append(queried[query.DataSource][query.Table], field)
+	}
+
+	if err := uc.ValidateIfFieldsExistOnTables(ctx, queried); err != nil {
+		return nil, err
+	}
+
+	scoped, err := uc.RowLevelPolicy.Apply(rowLevelScope, queried, reportFilters{query.DataSource: {query.Table: query.Filters}})
+	if err != nil {
+		return nil, err
+	}
+
+	filters := scoped[query.DataSource][query.Table]
+
+	dataSource, ok := uc.ExternalDataSources.Get(query.DataSource)
+	if !ok {
+		return nil, pkg.ValidateBusinessError(constant.ErrMissingDataSource, "", query.DataSource)
+	}
+
+	if err := uc.ensureDataSourceConnected(logger, query.DataSource, &dataSource); err != nil {
+		return nil, err
+	}
+
+	var values []any
+
+	// The connection is closed once the items are read, like after validating the fields
+	switch dataSource.DatabaseType {
+	case pkg.PostgreSQLType:
+		values, err = queryPostgresReportBatchItems(ctx, query, dataSource, filters)
+
+		if errClose := dataSource.PostgresRepository.CloseConnection(); errClose != nil {
+			logger.Warnf("Error to close postgres connection, Err: %s", errClose)
+		}
+	case pkg.MongoDBType:
+		values, err = dataSource.MongoDBRepository.QueryDistinctValues(ctx, query.Table, query.Field, filters, constant.MaxReportBatchItems+1)
+
+		if errClose := dataSource.MongoDBRepository.CloseConnection(ctx); errClose != nil {
+			logger.Warnf("Error to close mongodb connection, Err: %s", errClose)
+		}
+	default:
+		err = fmt.Errorf("unsupported database type: %s for database: %s", dataSource.DatabaseType, query.DataSource)
+	}
+
+	if err != nil {
+		libOpentelemetry.HandleSpanError(&span, "Failed to query report batch items", err)
+
+		logger.Errorf("Error querying report batch items from %s.%s: %v", query.DataSource, query.Table, err)
+
+		return nil, err
+	}
+
+	target := model.ReportBatchFilterTarget{DataSource: query.DataSource, Table: query.Table, Field: query.Field}
+	if query.Target != nil {
+		target = *query.Target
+	}
+
+	items := make([]reportFilters, 0, len(values))
+
+	for _, value := range values {
+		items = append(items, reportFilters{target.DataSource: {target.Table: {target.Field: {Equals: []any{value}}}}})
+	}
+
+	span.SetAttributes(attribute.Int("app.response.items", len(items)))
+
+	return items, nil
+}
+
+// queryPostgresReportBatchItems reads the distinct values of the field of an items query from a PostgreSQL table,
+// resolving its schema like the worker does for reports.
+func queryPostgresReportBatchItems(ctx context.Context, query *model.ReportBatchItemsQuery, dataSource pkg.DataSource, filters map[string]model.FilterCondition) ([]any, error) {
+	configuredSchemas := dataSource.Schemas
+	if len(configuredSchemas) == 0 {
+		configuredSchemas = []string{"public"}
+	}
+
+	schema, err := dataSource.PostgresRepository.GetDatabaseSchema(ctx, configuredSchemas)
+	if err != nil {
+		return nil, err
+	}
+
+	resolver := pkg.NewSchemaResolver()
+	resolver.RegisterDatabase(query.DataSource, schema)
+
+	var explicitSchema, tableName string
+
+	if strings.Contains(query.Table, ".") {
+		parts := strings.SplitN(query.Table, ".", constant.SplitKeyValueParts)
+		explicitSchema, tableName = parts[0], parts[1]
+	} else {
+		tableName = query.Table
+	}
+
+	schemaName, err := resolver.ResolveSchema(query.DataSource, explicitSchema, tableName)
+	if err != nil {
+		return nil, err
+	}
+
+	return dataSource.PostgresRepository.QueryDistinctValues(ctx, schema, schemaName, tableName, query.Field, filters, constant.MaxReportBatchItems+1)
+}
+
+// mergeReportBatchFilters returns a copy of shared with the conditions of override, which replace the shared
+// condition of the same datasource, table and field.
+func mergeReportBatchFilters(shared, override reportFilters) reportFilters {
+	merged := make(reportFilters, len(shared))
+
+	for _, filters := range []reportFilters{shared, override} {
+		for dataSource, tables := range filters {
+			if merged[dataSource] == nil {
+				merged[dataSource] = make(map[string]map[string]model.FilterCondition, len(tables))
+			}
+
+			for table, fields := range tables {
+				if merged[dataSource][table] == nil {
+					merged[dataSource][table] = make(map[string]model.FilterCondition, len(fields))
+				}
+
+				for field, condition := range fields {
+					merged[dataSource][table][field] = condition
+				}
+			}
+		}
+	}
+
+	return merged
+}
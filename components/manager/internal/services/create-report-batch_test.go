@@ -0,0 +1,458 @@
+// Copyright (c) 2026 Lerian Studio. All rights reserved.
+// Use of this source code is governed by the Elastic License 2.0
+// that can be found in the LICENSE file.
+
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/LerianStudio/reporter/pkg"
+	"github.com/LerianStudio/reporter/pkg/constant"
+	"github.com/LerianStudio/reporter/pkg/model"
+	"github.com/LerianStudio/reporter/pkg/mongodb"
+	"github.com/LerianStudio/reporter/pkg/mongodb/batch"
+	"github.com/LerianStudio/reporter/pkg/mongodb/outbox"
+	"github.com/LerianStudio/reporter/pkg/mongodb/report"
+	"github.com/LerianStudio/reporter/pkg/mongodb/template"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+var batchMongoSchema = []mongodb.CollectionSchema{
+	{
+		CollectionName: "transactions",
+		Fields: []mongodb.FieldInformation{
+			{Name: "account_id", DataType: "string"},
+			{Name: "amount", DataType: "number"},
+			{Name: "status", DataType: "string"},
+		},
+	},
+	{
+		CollectionName: "accounts",
+		Fields: []mongodb.FieldInformation{
+			{Name: "id", DataType: "string"},
+			{Name: "status", DataType: "string"},
+		},
+	},
+}
+
+func TestUseCase_CreateReportBatch(t *testing.T) {
+	// NOTE: Cannot use t.Parallel() because ResetRegisteredDataSourceIDsForTesting mutates global state
+	pkg.ResetRegisteredDataSourceIDsForTesting()
+	pkg.RegisterDataSourceIDsForTesting([]string{"test_mongo_db"})
+
+	templateID := uuid.New()
+	outputFormat := "pdf"
+	mappedFields := map[string]map[string][]string{
+		"test_mongo_db": {"transactions": {"account_id", "amount"}},
+	}
+
+	sharedFilters := map[string]map[string]map[string]model.FilterCondition{
+		"test_mongo_db": {"transactions": {
+			"status":     {Equals: []any{"approved"}},
+			"account_id": {Equals: []any{"shared"}},
+		}},
+	}
+
+	t.Run("Success - creates a report per item with the shared filters overridden, in chunks", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		mockTempRepo := template.NewMockRepository(ctrl)
+		mockReportRepo := report.NewMockRepository(ctrl)
+		mockBatchRepo := batch.NewMockRepository(ctrl)
+		mockMongoRepo := mongodb.NewMockRepository(ctrl)
+
+		items := make([]model.ReportBatchItem, 150)
+		for i := range items {
+			items[i] = model.ReportBatchItem{Filters: map[string]map[string]map[string]model.FilterCondition{
+				"test_mongo_db": {"transactions": {"account_id": {Equals: []any{i}}}},
+			}}
+		}
+
+		mockTempRepo.EXPECT().
+			FindMappedFieldsAndOutputFormatByID(gomock.Any(), templateID, gomock.Any()).
+			Return(&outputFormat, mappedFields, nil, "", nil)
+
+		mockMongoRepo.EXPECT().GetDatabaseSchema(gomock.Any()).Return(batchMongoSchema, nil)
+		mockMongoRepo.EXPECT().CloseConnection(gomock.Any()).Return(nil)
+
+		var batchID uuid.UUID
+
+		mockBatchRepo.EXPECT().
+			Create(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, b *batch.Batch) (*batch.Batch, error) {
+				batchID = b.ID
+
+				assert.Equal(t, templateID, b.TemplateID)
+				assert.Equal(t, sharedFilters, b.Filters)
+
+				return b, nil
+			})
+
+		var chunks []int
+
+		mockReportRepo.EXPECT().
+			CreateManyWithOutbox(gomock.Any(), gomock.Any(), gomock.Any()).
+			Times(2).
+			DoAndReturn(func(_ context.Context, reports []*report.Report, entries []*outbox.Entry) error {
+				require.Len(t, entries, len(reports))
+
+				offset := 0
+				for _, size := range chunks {
+					offset += size
+				}
+
+				for i, r := range reports {
+					assert.Equal(t, batchID, r.BatchID)
+					assert.Equal(t, constant.ProcessingStatus, r.Status)
+					assert.Equal(t, map[string]model.FilterCondition{
+						"status":     {Equals: []any{"approved"}},
+						"account_id": {Equals: []any{offset + i}},
+					}, r.Filters["test_mongo_db"]["transactions"])
+
+					assert.Equal(t, r.ID, entries[i].ReportID)
+					assert.Equal(t, "generate", entries[i].RoutingKey)
+					assert.Equal(t, constant.ReportPriorityLow, entries[i].Message.Priority)
+					// Unclaimed entries are published by the outbox relay
+					require.NotNil(t, entries[i].LockedUntil)
+					assert.Equal(t, entries[i].CreatedAt, *entries[i].LockedUntil)
+				}
+
+				chunks = append(chunks, len(reports))
+
+				return nil
+			})
+
+		uc := &UseCase{
+			TemplateRepo:              mockTempRepo,
+			ReportRepo:                mockReportRepo,
+			BatchRepo:                 mockBatchRepo,
+			RabbitMQGenerateReportKey: "generate",
+			ExternalDataSources: pkg.NewSafeDataSources(map[string]pkg.DataSource{
+				"test_mongo_db": {DatabaseType: pkg.MongoDBType, MongoDBRepository: mockMongoRepo, Initialized: true},
+			}),
+		}
+
+		result, err := uc.CreateReportBatch(context.Background(), uuid.Nil, &model.CreateReportBatchInput{
+			TemplateID: templateID.String(),
+			Filters:    sharedFilters,
+			Items:      items,
+			Priority:   constant.ReportPriorityLow,
+		})
+		require.NoError(t, err)
+		require.NotNil(t, result)
+
+		assert.Equal(t, []int{constant.ReportBatchInsertChunk, 50}, chunks)
+		assert.Equal(t, batch.Progress{Status: constant.ProcessingStatus, Total: 150, Processing: 150}, result.Progress)
+	})
+
+	t.Run("Success - selects the items with a datasource query", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		mockTempRepo := template.NewMockRepository(ctrl)
+		mockReportRepo := report.NewMockRepository(ctrl)
+		mockBatchRepo := batch.NewMockRepository(ctrl)
+		mockMongoRepo := mongodb.NewMockRepository(ctrl)
+
+		mockTempRepo.EXPECT().
+			FindMappedFieldsAndOutputFormatByID(gomock.Any(), templateID, gomock.Any()).
+			Return(&outputFormat, mappedFields, nil, "", nil)
+
+		// The query fields and then the report filters are validated
+		mockMongoRepo.EXPECT().GetDatabaseSchema(gomock.Any()).Return(batchMongoSchema, nil).Times(2)
+		mockMongoRepo.EXPECT().CloseConnection(gomock.Any()).Return(nil).Times(3)
+
+		mockMongoRepo.EXPECT().
+			QueryDistinctValues(gomock.Any(), "accounts", "id", map[string]model.FilterCondition{
+				"status": {Equals: []any{"active"}},
+			}, constant.MaxReportBatchItems+1).
+			Return([]any{"acc-1", "acc-2", "acc-3"}, nil)
+
+		mockBatchRepo.EXPECT().
+			Create(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, b *batch.Batch) (*batch.Batch, error) {
+				return b, nil
+			})
+
+		mockReportRepo.EXPECT().
+			CreateManyWithOutbox(gomock.Any(), gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, reports []*report.Report, _ []*outbox.Entry) error {
+				require.Len(t, reports, 3)
+
+				for i, accountID := range []string{"acc-1", "acc-2", "acc-3"} {
+					assert.Equal(t, model.FilterCondition{Equals: []any{accountID}}, reports[i].Filters["test_mongo_db"]["transactions"]["account_id"])
+				}
+
+				return nil
+			})
+
+		uc := &UseCase{
+			TemplateRepo: mockTempRepo,
+			ReportRepo:   mockReportRepo,
+			BatchRepo:    mockBatchRepo,
+			ExternalDataSources: pkg.NewSafeDataSources(map[string]pkg.DataSource{
+				"test_mongo_db": {DatabaseType: pkg.MongoDBType, MongoDBRepository: mockMongoRepo, Initialized: true},
+			}),
+		}
+
+		result, err := uc.CreateReportBatch(context.Background(), uuid.Nil, &model.CreateReportBatchInput{
+			TemplateID: templateID.String(),
+			ItemsQuery: &model.ReportBatchItemsQuery{
+				DataSource: "test_mongo_db",
+				Table:      "accounts",
+				Field:      "id",
+				Filters:    map[string]model.FilterCondition{"status": {Equals: []any{"active"}}},
+				Target:     &model.ReportBatchFilterTarget{DataSource: "test_mongo_db", Table: "transactions", Field: "account_id"},
+			},
+		})
+		require.NoError(t, err)
+		assert.Equal(t, 3, result.Total)
+	})
+
+	t.Run("Error - the datasource query selects more items than allowed", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		mockTempRepo := template.NewMockRepository(ctrl)
+		mockMongoRepo := mongodb.NewMockRepository(ctrl)
+
+		mockTempRepo.EXPECT().
+			FindMappedFieldsAndOutputFormatByID(gomock.Any(), templateID, gomock.Any()).
+			Return(&outputFormat, mappedFields, nil, "", nil)
+
+		mockMongoRepo.EXPECT().GetDatabaseSchema(gomock.Any()).Return(batchMongoSchema, nil)
+		mockMongoRepo.EXPECT().CloseConnection(gomock.Any()).Return(nil).Times(2)
+
+		mockMongoRepo.EXPECT().
+			QueryDistinctValues(gomock.Any(), "accounts", "id", gomock.Any(), constant.MaxReportBatchItems+1).
+			Return(make([]any, constant.MaxReportBatchItems+1), nil)
+
+		uc := &UseCase{
+			TemplateRepo: mockTempRepo,
+			ReportRepo:   report.NewMockRepository(ctrl),
+			BatchRepo:    batch.NewMockRepository(ctrl),
+			ExternalDataSources: pkg.NewSafeDataSources(map[string]pkg.DataSource{
+				"test_mongo_db": {DatabaseType: pkg.MongoDBType, MongoDBRepository: mockMongoRepo, Initialized: true},
+			}),
+		}
+
+		result, err := uc.CreateReportBatch(context.Background(), uuid.Nil, &model.CreateReportBatchInput{
+			TemplateID: templateID.String(),
+			ItemsQuery: &model.ReportBatchItemsQuery{DataSource: "test_mongo_db", Table: "accounts", Field: "id"},
+		})
+		require.Error(t, err)
+		assert.Nil(t, result)
+
+		var validationErr pkg.ValidationError
+		require.True(t, errors.As(err, &validationErr))
+		assert.Equal(t, constant.ErrInvalidReportBatchItems.Error(), validationErr.Code)
+	})
+
+	invalidItems := []struct {
+		name  string
+		input *model.CreateReportBatchInput
+	}{
+		{
+			name: "Error - items and itemsQuery are both set",
+			input: &model.CreateReportBatchInput{
+				TemplateID: templateID.String(),
+				Items:      []model.ReportBatchItem{{Filters: map[string]map[string]map[string]model.FilterCondition{}}},
+				ItemsQuery: &model.ReportBatchItemsQuery{DataSource: "test_mongo_db", Table: "accounts", Field: "id"},
+			},
+		},
+		{
+			name:  "Error - no items",
+			input: &model.CreateReportBatchInput{TemplateID: templateID.String()},
+		},
+		{
+			name: "Error - more items than allowed",
+			input: &model.CreateReportBatchInput{
+				TemplateID: templateID.String(),
+				Items:      make([]model.ReportBatchItem, constant.MaxReportBatchItems+1),
+			},
+		},
+	}
+
+	for _, tt := range invalidItems {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			mockTempRepo := template.NewMockRepository(ctrl)
+
+			mockTempRepo.EXPECT().
+				FindMappedFieldsAndOutputFormatByID(gomock.Any(), templateID, gomock.Any()).
+				Return(&outputFormat, mappedFields, nil, "", nil)
+
+			uc := &UseCase{
+				TemplateRepo: mockTempRepo,
+				ReportRepo:   report.NewMockRepository(ctrl),
+				BatchRepo:    batch.NewMockRepository(ctrl),
+			}
+
+			result, err := uc.CreateReportBatch(context.Background(), uuid.Nil, tt.input)
+			require.Error(t, err)
+			assert.Nil(t, result)
+
+			var validationErr pkg.ValidationError
+			require.True(t, errors.As(err, &validationErr))
+			assert.Equal(t, constant.ErrInvalidReportBatchItems.Error(), validationErr.Code)
+		})
+	}
+
+	t.Run("Error - invalid template ID", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+
+		uc := &UseCase{
+			TemplateRepo: template.NewMockRepository(ctrl),
+			BatchRepo:    batch.NewMockRepository(ctrl),
+		}
+
+		result, err := uc.CreateReportBatch(context.Background(), uuid.Nil, &model.CreateReportBatchInput{TemplateID: "not-a-uuid"})
+		require.Error(t, err)
+		assert.Nil(t, result)
+		assert.Contains(t, err.Error(), constant.ErrInvalidTemplateID.Error())
+	})
+
+	t.Run("Error - batch creation fails", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		mockTempRepo := template.NewMockRepository(ctrl)
+		mockBatchRepo := batch.NewMockRepository(ctrl)
+
+		mockTempRepo.EXPECT().
+			FindMappedFieldsAndOutputFormatByID(gomock.Any(), templateID, gomock.Any()).
+			Return(&outputFormat, mappedFields, nil, "", nil)
+
+		mockBatchRepo.EXPECT().
+			Create(gomock.Any(), gomock.Any()).
+			Return(nil, constant.ErrInternalServer)
+
+		uc := &UseCase{
+			TemplateRepo: mockTempRepo,
+			ReportRepo:   report.NewMockRepository(ctrl),
+			BatchRepo:    mockBatchRepo,
+		}
+
+		result, err := uc.CreateReportBatch(context.Background(), uuid.Nil, &model.CreateReportBatchInput{
+			TemplateID: templateID.String(),
+			Items:      []model.ReportBatchItem{{Filters: map[string]map[string]map[string]model.FilterCondition{}}},
+		})
+		require.ErrorIs(t, err, constant.ErrInternalServer)
+		assert.Nil(t, result)
+	})
+
+	chunkFailures := []struct {
+		name         string
+		items        int
+		failingChunk int
+		cancels      bool
+	}{
+		{name: "Error - the first chunk fails and the batch is marked failed", items: 10, failingChunk: 0},
+		{name: "Error - a later chunk fails and the stored reports are cancelled", items: constant.ReportBatchInsertChunk + 10, failingChunk: 1, cancels: true},
+	}
+
+	for _, tt := range chunkFailures {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			mockTempRepo := template.NewMockRepository(ctrl)
+			mockReportRepo := report.NewMockRepository(ctrl)
+			mockBatchRepo := batch.NewMockRepository(ctrl)
+
+			items := make([]model.ReportBatchItem, tt.items)
+			for i := range items {
+				items[i] = model.ReportBatchItem{Filters: map[string]map[string]map[string]model.FilterCondition{}}
+			}
+
+			mockTempRepo.EXPECT().
+				FindMappedFieldsAndOutputFormatByID(gomock.Any(), templateID, gomock.Any()).
+				Return(&outputFormat, mappedFields, nil, "", nil)
+
+			var batchID uuid.UUID
+
+			mockBatchRepo.EXPECT().
+				Create(gomock.Any(), gomock.Any()).
+				DoAndReturn(func(_ context.Context, b *batch.Batch) (*batch.Batch, error) {
+					batchID = b.ID
+
+					return b, nil
+				})
+
+			chunk := 0
+
+			mockReportRepo.EXPECT().
+				CreateManyWithOutbox(gomock.Any(), gomock.Any(), gomock.Any()).
+				Times(tt.failingChunk + 1).
+				DoAndReturn(func(_ context.Context, _ []*report.Report, _ []*outbox.Entry) error {
+					defer func() { chunk++ }()
+
+					if chunk == tt.failingChunk {
+						return constant.ErrInternalServer
+					}
+
+					return nil
+				})
+
+			if tt.cancels {
+				mockReportRepo.EXPECT().
+					CancelByBatch(gomock.Any(), gomock.Any(), uuid.Nil, gomock.Any()).
+					DoAndReturn(func(_ context.Context, id, _ uuid.UUID, _ time.Time) (int64, error) {
+						assert.Equal(t, batchID, id)
+
+						return int64(constant.ReportBatchInsertChunk), nil
+					})
+			}
+
+			mockBatchRepo.EXPECT().
+				MarkFailed(gomock.Any(), gomock.Any(), uuid.Nil, gomock.Any(), gomock.Any()).
+				DoAndReturn(func(_ context.Context, id, _ uuid.UUID, reason string, _ time.Time) error {
+					assert.Equal(t, batchID, id)
+					assert.Contains(t, reason, constant.ErrInternalServer.Error())
+
+					return nil
+				})
+
+			uc := &UseCase{
+				TemplateRepo: mockTempRepo,
+				ReportRepo:   mockReportRepo,
+				BatchRepo:    mockBatchRepo,
+			}
+
+			result, err := uc.CreateReportBatch(context.Background(), uuid.Nil, &model.CreateReportBatchInput{
+				TemplateID: templateID.String(),
+				Items:      items,
+			})
+			require.ErrorIs(t, err, constant.ErrInternalServer)
+			assert.Nil(t, result)
+		})
+	}
+}
+
+func TestMergeReportBatchFilters(t *testing.T) {
+	t.Parallel()
+
+	shared := map[string]map[string]map[string]model.FilterCondition{
+		"db": {"table": {
+			"status": {Equals: []any{"approved"}},
+			"date":   {Between: []any{"2026-01-01", "2026-01-31"}},
+		}},
+	}
+
+	override := map[string]map[string]map[string]model.FilterCondition{
+		"db":    {"table": {"date": {Equals: []any{"2026-01-15"}}}},
+		"other": {"table": {"id": {In: []any{1, 2}}}},
+	}
+
+	merged := mergeReportBatchFilters(shared, override)
+
+	assert.Equal(t, map[string]map[string]map[string]model.FilterCondition{
+		"db": {"table": {
+			"status": {Equals: []any{"approved"}},
+			"date":   {Equals: []any{"2026-01-15"}},
+		}},
+		"other": {"table": {"id": {In: []any{1, 2}}}},
+	}, merged)
+
+	// The shared filters are left untouched
+	assert.Equal(t, model.FilterCondition{Between: []any{"2026-01-01", "2026-01-31"}}, shared["db"]["table"]["date"])
+}
@@ -14,6 +14,7 @@ import (
 	"github.com/LerianStudio/reporter/pkg"
 	"github.com/LerianStudio/reporter/pkg/constant"
 	"github.com/LerianStudio/reporter/pkg/model"
+	"github.com/LerianStudio/reporter/pkg/mongodb/outbox"
 	"github.com/LerianStudio/reporter/pkg/mongodb/report"
 	pkgHTTP "github.com/LerianStudio/reporter/pkg/net/http"
 
@@ -25,8 +26,8 @@ import (
 	"go.opentelemetry.io/otel/trace"
 )
 
-// CreateReport create a new report
-func (uc *UseCase) CreateReport(ctx context.Context, reportInput *model.CreateReportInput) (*report.Report, error) {
+// CreateReport create a new report owned by the given organization
+func (uc *UseCase) CreateReport(ctx context.Context, organizationID uuid.UUID, reportInput *model.CreateReportInput) (*report.Report, error) {
 	logger, tracer, reqId, _ := commons.NewTrackingFromContext(ctx)
 
 	ctx, span := tracer.Start(ctx, "service.report.create")
@@ -35,6 +36,7 @@ func (uc *UseCase) CreateReport(ctx context.Context, reportInput *model.CreateRe
 	span.SetAttributes(
 		attribute.String("app.request.request_id", reqId),
 		attribute.String("app.request.template_id", reportInput.TemplateID),
+		attribute.String("app.request.organization_id", organizationID.String()),
 	)
 
 	err := libOpentelemetry.SetSpanAttributesFromStruct(&span, "app.request.payload", reportInput)
@@ -46,7 +48,7 @@ func (uc *UseCase) CreateReport(ctx context.Context, reportInput *model.CreateRe
 
 	// Idempotency check: acquire lock via Redis SetNX before proceeding
 	if uc.RedisRepo != nil {
-		cachedResult, err := uc.checkReportIdempotency(ctx, reportInput, &span)
+		cachedResult, err := uc.checkReportIdempotency(ctx, organizationID, reportInput, &span)
 		if err != nil {
 			return nil, err
 		}
@@ -67,20 +69,8 @@ func (uc *UseCase) CreateReport(ctx context.Context, reportInput *model.CreateRe
 	}
 
 	// Find a template to generate a report
-	tOutputFormat, tMappedFields, err := uc.TemplateRepo.FindMappedFieldsAndOutputFormatByID(ctx, templateId)
+	tOutputFormat, tMappedFields, tOutputOptions, tPriority, err := uc.findReportTemplate(ctx, templateId, organizationID, &span)
 	if err != nil {
-		logger.Errorf("Error to find template by id, Error: %v", err)
-
-		if errors.Is(err, mongo.ErrNoDocuments) {
-			errNotFound := pkg.ValidateBusinessError(constant.ErrEntityNotFound, "", constant.MongoCollectionTemplate)
-
-			libOpentelemetry.HandleSpanBusinessErrorEvent(&span, "Template not found", errNotFound)
-
-			return nil, errNotFound
-		}
-
-		libOpentelemetry.HandleSpanError(&span, "Failed to find template by ID", err)
-
 		return nil, err
 	}
 
@@ -90,12 +80,25 @@ func (uc *UseCase) CreateReport(ctx context.Context, reportInput *model.CreateRe
 		}
 	}
 
+	// Inject the mandatory row-level predicates derived from the caller's auth claims
+	rowLevelScope := uc.resolveRowLevelScope(ctx)
+
+	filters, err := uc.RowLevelPolicy.Apply(rowLevelScope, tMappedFields, reportInput.Filters)
+	if err != nil {
+		libOpentelemetry.HandleSpanBusinessErrorEvent(&span, "Failed to apply row-level filters", err)
+
+		logger.Warnf("Rejected report request without required row-level scope: %v", err)
+
+		return nil, err
+	}
+
 	// Build the report model using constructor with invariant validation
 	reportModel, err := report.NewReport(
 		commons.GenerateUUIDv7(),
 		templateId,
+		organizationID,
 		constant.ProcessingStatus,
-		reportInput.Filters,
+		filters,
 	)
 	if err != nil {
 		if pkgHTTP.IsBusinessError(err) {
@@ -107,46 +110,55 @@ func (uc *UseCase) CreateReport(ctx context.Context, reportInput *model.CreateRe
 		return nil, err
 	}
 
-	result, err := uc.ReportRepo.Create(ctx, reportModel)
-	if err != nil {
-		libOpentelemetry.HandleSpanError(&span, "Failed to create report in repository", err)
+	// Build report message model, stored with the report so a retry republishes it
+	reportMessage := model.ReportMessage{
+		TemplateID:     templateId,
+		ReportID:       reportModel.ID,
+		Filters:        filters,
+		OutputFormat:   *tOutputFormat,
+		MappedFields:   tMappedFields,
+		OrganizationID: organizationID,
+		RowLevelScope:  rowLevelScope,
+		Locale:         reportInput.Locale,
+		Timezone:       reportInput.Timezone,
+		OutputOptions:  tOutputOptions,
+		Priority:       reportPriority(reportInput.Priority, tPriority),
+		ReuseOutput:    reportInput.ReuseOutput,
+	}
 
-		logger.Errorf("Error creating report in database: %v", err)
+	span.SetAttributes(attribute.String("app.request.priority", reportMessage.Priority))
 
-		return nil, err
-	}
+	if err := uc.signRowLevelScope(&reportMessage); err != nil {
+		libOpentelemetry.HandleSpanError(&span, "Failed to sign row-level scope", err)
 
-	// Build report message model
-	reportMessage := model.ReportMessage{
-		TemplateID:   templateId,
-		ReportID:     result.ID,
-		Filters:      reportInput.Filters,
-		OutputFormat: *tOutputFormat,
-		MappedFields: tMappedFields,
+		return nil, err
 	}
 
-	logger.Infof("Sending report to reports queue...")
+	reportModel.Message = &reportMessage
 
-	if err := uc.SendReportQueueReports(ctx, reportMessage); err != nil {
-		libOpentelemetry.HandleSpanError(&span, "Failed to send report to queue", err)
+	// The report and the outbox entry of its message are written together, so a report is never left without
+	// the message that generates it. The entry is claimed for this request, which publishes it right away
+	entry := outbox.NewEntry(reportMessage, uc.RabbitMQExchange, uc.generateReportKey(reportMessage.Priority), time.Now(), constant.OutboxClaimLease)
 
-		logger.Errorf("Error sending report to queue: %v", err)
+	result, err := uc.ReportRepo.CreateWithOutbox(ctx, reportModel, entry)
+	if err != nil {
+		libOpentelemetry.HandleSpanError(&span, "Failed to create report in repository", err)
 
-		// Update report status to error since queue send failed
-		metadata := map[string]any{
-			"error": "Failed to send report to queue",
-		}
-		if updateErr := uc.ReportRepo.UpdateReportStatusById(ctx, constant.ErrorStatus, result.ID, time.Now(), metadata); updateErr != nil {
-			libOpentelemetry.HandleSpanError(&span, "Failed to update report status to error", updateErr)
-			logger.Errorf("Error updating report status to error: %v", updateErr)
-		}
+		logger.Errorf("Error creating report in database: %v", err)
 
 		return nil, err
 	}
 
+	logger.Infof("Sending report to reports queue...")
+
+	// A failed publish is retried by the outbox relay; the report stays in Processing until it is published
+	if err := uc.dispatchOutboxEntry(ctx, entry); err != nil {
+		logger.Warnf("Report %s will be sent to queue by the outbox relay: %v", result.ID, err)
+	}
+
 	// Cache the successful result for idempotency deduplication of future identical requests
 	if uc.RedisRepo != nil {
-		idempotencyKey, keyErr := uc.buildIdempotencyKey(ctx, reportInput)
+		idempotencyKey, keyErr := uc.buildIdempotencyKey(ctx, organizationID, reportInput)
 		if keyErr == nil {
 			uc.cacheIdempotencyResult(ctx, idempotencyKey, result)
 		}
@@ -155,12 +167,63 @@ func (uc *UseCase) CreateReport(ctx context.Context, reportInput *model.CreateRe
 	return result, nil
 }
 
+// findReportTemplate returns the output format, mapped fields, output options and priority of the template of a report.
+func (uc *UseCase) findReportTemplate(ctx context.Context, templateID, organizationID uuid.UUID, span *trace.Span) (*string, map[string]map[string][]string, *model.OutputOptions, string, error) {
+	logger, _, _, _ := commons.NewTrackingFromContext(ctx) //nolint:dogsled // only logger needed from tracking context
+
+	outputFormat, mappedFields, outputOptions, priority, err := uc.TemplateRepo.FindMappedFieldsAndOutputFormatByID(ctx, templateID, organizationID)
+	if err != nil {
+		logger.Errorf("Error to find template by id, Error: %v", err)
+
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			errNotFound := pkg.ValidateBusinessError(constant.ErrEntityNotFound, "", constant.MongoCollectionTemplate)
+
+			libOpentelemetry.HandleSpanBusinessErrorEvent(span, "Template not found", errNotFound)
+
+			return nil, nil, nil, "", errNotFound
+		}
+
+		if errors.Is(err, constant.ErrPartialTemplateReport) {
+			errPartial := pkg.ValidateBusinessError(constant.ErrPartialTemplateReport, "")
+
+			libOpentelemetry.HandleSpanBusinessErrorEvent(span, "Template is a partial", errPartial)
+
+			return nil, nil, nil, "", errPartial
+		}
+
+		libOpentelemetry.HandleSpanError(span, "Failed to find template by ID", err)
+
+		return nil, nil, nil, "", err
+	}
+
+	return outputFormat, mappedFields, outputOptions, priority, nil
+}
+
+// reportPriority resolves the generation priority of a report: the one requested, else the one of its
+// template, else normal.
+func reportPriority(requested, templatePriority string) string {
+	if requested != "" {
+		return requested
+	}
+
+	if templatePriority != "" {
+		return templatePriority
+	}
+
+	return constant.ReportPriorityNormal
+}
+
+// generateReportKey returns the routing key of the generation lane of a priority.
+func (uc *UseCase) generateReportKey(priority string) string {
+	return uc.RabbitMQLanes.RoutingKey(priority, uc.RabbitMQGenerateReportKey)
+}
+
 // checkReportIdempotency acquires an idempotency lock via Redis SetNX.
 // Returns a cached report if this is a duplicate request, or nil to proceed with creation.
-func (uc *UseCase) checkReportIdempotency(ctx context.Context, reportInput *model.CreateReportInput, span *trace.Span) (*report.Report, error) {
+func (uc *UseCase) checkReportIdempotency(ctx context.Context, organizationID uuid.UUID, reportInput *model.CreateReportInput, span *trace.Span) (*report.Report, error) {
 	logger, _, _, _ := commons.NewTrackingFromContext(ctx) //nolint:dogsled // only logger needed from tracking context
 
-	idempotencyKey, keyErr := uc.buildIdempotencyKey(ctx, reportInput)
+	idempotencyKey, keyErr := uc.buildIdempotencyKey(ctx, organizationID, reportInput)
 	if keyErr != nil {
 		libOpentelemetry.HandleSpanError(span, "Failed to compute idempotency key", keyErr)
 
@@ -206,7 +269,8 @@ func (uc *UseCase) validateReportFilters(ctx context.Context, filters map[string
 // buildIdempotencyKey resolves the idempotency key for the request.
 // If a client-provided Idempotency-Key header value exists in context, it is used as-is.
 // Otherwise, a SHA256 hash of the JSON-serialized request body is computed.
-func (uc *UseCase) buildIdempotencyKey(ctx context.Context, reportInput *model.CreateReportInput) (string, error) {
+// Keys are scoped to the organization so tenants never share a cached result.
+func (uc *UseCase) buildIdempotencyKey(ctx context.Context, organizationID uuid.UUID, reportInput *model.CreateReportInput) (string, error) {
 	logger, tracer, reqId, _ := commons.NewTrackingFromContext(ctx)
 
 	ctx, span := tracer.Start(ctx, "service.report.build_idempotency_key")
@@ -214,9 +278,17 @@ func (uc *UseCase) buildIdempotencyKey(ctx context.Context, reportInput *model.C
 
 	span.SetAttributes(attribute.String("app.request.request_id", reqId))
 
+	// Requests of different row-level scopes must never share a cached result
+	scopeSuffix, err := uc.rowLevelScopeKeySuffix(ctx)
+	if err != nil {
+		libOpentelemetry.HandleSpanError(&span, "Failed to marshal row-level scope for idempotency key", err)
+
+		return "", err
+	}
+
 	// Check for client-provided idempotency key from context
 	if clientKey, ok := ctx.Value(constant.IdempotencyKeyCtx).(string); ok && clientKey != "" {
-		key := constant.IdempotencyKeyPrefix + ":" + clientKey
+		key := constant.IdempotencyKeyPrefix + ":" + clientKey + organizationKeySuffix(organizationID) + scopeSuffix
 
 		logger.Infof("Using client-provided idempotency key: %s", key)
 
@@ -232,13 +304,64 @@ func (uc *UseCase) buildIdempotencyKey(ctx context.Context, reportInput *model.C
 	}
 
 	hash := commons.HashSHA256(string(data))
-	key := constant.IdempotencyKeyPrefix + ":" + hash
+	key := constant.IdempotencyKeyPrefix + ":" + hash + organizationKeySuffix(organizationID) + scopeSuffix
 
 	logger.Infof("Computed idempotency key from request body hash: %s", key)
 
 	return key, nil
 }
 
+// resolveRowLevelScope returns the claim values the row-level policy needs from the caller's access token.
+// It returns nil when no policy is configured.
+func (uc *UseCase) resolveRowLevelScope(ctx context.Context) map[string]string {
+	if !uc.RowLevelPolicy.Enabled() {
+		return nil
+	}
+
+	claims, _ := ctx.Value(constant.AuthClaimsCtx).(map[string]any)
+
+	return uc.RowLevelPolicy.ResolveScope(claims)
+}
+
+// signRowLevelScope signs the row-level scope, mapped fields and filters of a report message, so the worker
+// can check they are the ones resolved by the manager.
+func (uc *UseCase) signRowLevelScope(message *model.ReportMessage) error {
+	signature, err := uc.RowLevelPolicy.Sign(message.RowLevelScope, message.MappedFields, message.Filters)
+	if err != nil {
+		return err
+	}
+
+	message.RowLevelSignature = signature
+
+	return nil
+}
+
+// rowLevelScopeKeySuffix returns the idempotency key suffix identifying the caller's row-level scope.
+// It is empty when no policy is configured, keeping keys unchanged for single-tenant deployments.
+func (uc *UseCase) rowLevelScopeKeySuffix(ctx context.Context) (string, error) {
+	scope := uc.resolveRowLevelScope(ctx)
+	if len(scope) == 0 {
+		return "", nil
+	}
+
+	data, err := json.Marshal(scope)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal row-level scope for idempotency key: %w", err)
+	}
+
+	return ":" + commons.HashSHA256(string(data)), nil
+}
+
+// organizationKeySuffix returns the idempotency key suffix identifying the organization.
+// It is empty for the default organization, keeping keys unchanged for single-tenant deployments.
+func organizationKeySuffix(organizationID uuid.UUID) string {
+	if organizationID == uuid.Nil {
+		return ""
+	}
+
+	return ":" + organizationID.String()
+}
+
 // handleDuplicateRequest handles the case where SetNX returned false (key already exists).
 // It attempts to retrieve the cached response from Redis. If a cached response exists,
 // it is unmarshaled and returned. If no cached response exists yet (in-flight request),
@@ -11,8 +11,10 @@ import (
 	"testing"
 	"time"
 
+	"github.com/LerianStudio/reporter/pkg"
 	"github.com/LerianStudio/reporter/pkg/constant"
 	"github.com/LerianStudio/reporter/pkg/model"
+	"github.com/LerianStudio/reporter/pkg/mongodb/outbox"
 	"github.com/LerianStudio/reporter/pkg/mongodb/report"
 	"github.com/LerianStudio/reporter/pkg/mongodb/template"
 	"github.com/LerianStudio/reporter/pkg/rabbitmq"
@@ -74,11 +76,11 @@ func TestUseCase_CreateReport(t *testing.T) {
 				mockRabbitMQ := rabbitmq.NewMockProducerRepository(ctrl)
 
 				mockTempRepo.EXPECT().
-					FindMappedFieldsAndOutputFormatByID(gomock.Any(), gomock.Any()).
-					Return(&outputFormat, mappedFields, nil)
+					FindMappedFieldsAndOutputFormatByID(gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(&outputFormat, mappedFields, nil, "", nil)
 
 				mockReportRepo.EXPECT().
-					Create(gomock.Any(), gomock.Any()).
+					CreateWithOutbox(gomock.Any(), gomock.Any(), gomock.Any()).
 					Return(reportEntity, nil)
 
 				mockRabbitMQ.EXPECT().
@@ -89,6 +91,7 @@ func TestUseCase_CreateReport(t *testing.T) {
 					TemplateRepo: mockTempRepo,
 					ReportRepo:   mockReportRepo,
 					RabbitMQRepo: mockRabbitMQ,
+					OutboxRepo:   newDispatchedOutboxRepo(ctrl),
 				}
 			},
 			expectErr: false,
@@ -108,8 +111,8 @@ func TestUseCase_CreateReport(t *testing.T) {
 				mockRabbitMQ := rabbitmq.NewMockProducerRepository(ctrl)
 
 				mockTempRepo.EXPECT().
-					FindMappedFieldsAndOutputFormatByID(gomock.Any(), gomock.Any()).
-					Return(nil, nil, constant.ErrInternalServer)
+					FindMappedFieldsAndOutputFormatByID(gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(nil, nil, nil, "", constant.ErrInternalServer)
 
 				return &UseCase{
 					TemplateRepo: mockTempRepo,
@@ -130,11 +133,11 @@ func TestUseCase_CreateReport(t *testing.T) {
 				mockRabbitMQ := rabbitmq.NewMockProducerRepository(ctrl)
 
 				mockTempRepo.EXPECT().
-					FindMappedFieldsAndOutputFormatByID(gomock.Any(), gomock.Any()).
-					Return(&outputFormat, mappedFields, nil)
+					FindMappedFieldsAndOutputFormatByID(gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(&outputFormat, mappedFields, nil, "", nil)
 
 				mockReportRepo.EXPECT().
-					Create(gomock.Any(), gomock.Any()).
+					CreateWithOutbox(gomock.Any(), gomock.Any(), gomock.Any()).
 					Return(nil, constant.ErrInternalServer)
 
 				return &UseCase{
@@ -148,39 +151,40 @@ func TestUseCase_CreateReport(t *testing.T) {
 			expectedResult: nil,
 		},
 		{
-			name:        "Error - Send message on RabbitMQ",
+			name:        "Success - Send message on RabbitMQ fails and is left to the outbox relay",
 			reportInput: reportInput,
 			mockSetup: func(ctrl *gomock.Controller) *UseCase {
 				mockTempRepo := template.NewMockRepository(ctrl)
 				mockReportRepo := report.NewMockRepository(ctrl)
 				mockRabbitMQ := rabbitmq.NewMockProducerRepository(ctrl)
+				mockOutboxRepo := outbox.NewMockRepository(ctrl)
 
 				mockTempRepo.EXPECT().
-					FindMappedFieldsAndOutputFormatByID(gomock.Any(), gomock.Any()).
-					Return(&outputFormat, mappedFields, nil)
+					FindMappedFieldsAndOutputFormatByID(gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(&outputFormat, mappedFields, nil, "", nil)
 
 				mockReportRepo.EXPECT().
-					Create(gomock.Any(), gomock.Any()).
+					CreateWithOutbox(gomock.Any(), gomock.Any(), gomock.Any()).
 					Return(reportEntity, nil)
 
 				mockRabbitMQ.EXPECT().
 					ProducerDefault(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
 					Return(nil, constant.ErrInternalServer)
 
-				// Expect the report status to be updated to error when queue send fails
-				mockReportRepo.EXPECT().
-					UpdateReportStatusById(gomock.Any(), constant.ErrorStatus, reportId, gomock.Any(), gomock.Any()).
+				// The report stays in Processing and its outbox entry is rescheduled for the relay
+				mockOutboxRepo.EXPECT().
+					MarkFailed(gomock.Any(), gomock.Any(), constant.ErrInternalServer.Error(), gomock.Any()).
 					Return(nil)
 
 				return &UseCase{
 					TemplateRepo: mockTempRepo,
 					ReportRepo:   mockReportRepo,
 					RabbitMQRepo: mockRabbitMQ,
+					OutboxRepo:   mockOutboxRepo,
 				}
 			},
-			expectErr:      true,
-			errContains:    constant.ErrInternalServer.Error(),
-			expectedResult: nil,
+			expectErr:      false,
+			expectedResult: reportEntity,
 		},
 		{
 			name: "Error - Invalid template ID (not a UUID)",
@@ -208,8 +212,8 @@ func TestUseCase_CreateReport(t *testing.T) {
 				mockRabbitMQ := rabbitmq.NewMockProducerRepository(ctrl)
 
 				mockTempRepo.EXPECT().
-					FindMappedFieldsAndOutputFormatByID(gomock.Any(), gomock.Any()).
-					Return(nil, nil, mongo.ErrNoDocuments)
+					FindMappedFieldsAndOutputFormatByID(gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(nil, nil, nil, "", mongo.ErrNoDocuments)
 
 				return &UseCase{
 					TemplateRepo: mockTempRepo,
@@ -221,6 +225,26 @@ func TestUseCase_CreateReport(t *testing.T) {
 			errContains:    "template",
 			expectedResult: nil,
 		},
+		{
+			name:        "Error - Template is a partial",
+			reportInput: reportInput,
+			mockSetup: func(ctrl *gomock.Controller) *UseCase {
+				mockTempRepo := template.NewMockRepository(ctrl)
+
+				mockTempRepo.EXPECT().
+					FindMappedFieldsAndOutputFormatByID(gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(nil, nil, nil, "", constant.ErrPartialTemplateReport)
+
+				return &UseCase{
+					TemplateRepo: mockTempRepo,
+					ReportRepo:   report.NewMockRepository(ctrl),
+					RabbitMQRepo: rabbitmq.NewMockProducerRepository(ctrl),
+				}
+			},
+			expectErr:      true,
+			errContains:    constant.ErrPartialTemplateReport.Error(),
+			expectedResult: nil,
+		},
 		{
 			name: "Error - Filters validation fails",
 			reportInput: &model.CreateReportInput{
@@ -239,8 +263,8 @@ func TestUseCase_CreateReport(t *testing.T) {
 				mockRabbitMQ := rabbitmq.NewMockProducerRepository(ctrl)
 
 				mockTempRepo.EXPECT().
-					FindMappedFieldsAndOutputFormatByID(gomock.Any(), gomock.Any()).
-					Return(&outputFormat, mappedFields, nil)
+					FindMappedFieldsAndOutputFormatByID(gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(&outputFormat, mappedFields, nil, "", nil)
 
 				return &UseCase{
 					TemplateRepo: mockTempRepo,
@@ -253,39 +277,40 @@ func TestUseCase_CreateReport(t *testing.T) {
 			expectedResult: nil,
 		},
 		{
-			name:        "Error - Queue send fails and status update also fails",
+			name:        "Success - Queue send fails and outbox reschedule also fails",
 			reportInput: reportInput,
 			mockSetup: func(ctrl *gomock.Controller) *UseCase {
 				mockTempRepo := template.NewMockRepository(ctrl)
 				mockReportRepo := report.NewMockRepository(ctrl)
 				mockRabbitMQ := rabbitmq.NewMockProducerRepository(ctrl)
+				mockOutboxRepo := outbox.NewMockRepository(ctrl)
 
 				mockTempRepo.EXPECT().
-					FindMappedFieldsAndOutputFormatByID(gomock.Any(), gomock.Any()).
-					Return(&outputFormat, mappedFields, nil)
+					FindMappedFieldsAndOutputFormatByID(gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(&outputFormat, mappedFields, nil, "", nil)
 
 				mockReportRepo.EXPECT().
-					Create(gomock.Any(), gomock.Any()).
+					CreateWithOutbox(gomock.Any(), gomock.Any(), gomock.Any()).
 					Return(reportEntity, nil)
 
 				mockRabbitMQ.EXPECT().
 					ProducerDefault(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
 					Return(nil, constant.ErrInternalServer)
 
-				// Expect the report status update to also fail
-				mockReportRepo.EXPECT().
-					UpdateReportStatusById(gomock.Any(), constant.ErrorStatus, reportId, gomock.Any(), gomock.Any()).
+				// The claim on the entry expires, so the relay still publishes it
+				mockOutboxRepo.EXPECT().
+					MarkFailed(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
 					Return(constant.ErrInternalServer)
 
 				return &UseCase{
 					TemplateRepo: mockTempRepo,
 					ReportRepo:   mockReportRepo,
 					RabbitMQRepo: mockRabbitMQ,
+					OutboxRepo:   mockOutboxRepo,
 				}
 			},
-			expectErr:      true,
-			errContains:    constant.ErrInternalServer.Error(),
-			expectedResult: nil,
+			expectErr:      false,
+			expectedResult: reportEntity,
 		},
 	}
 
@@ -300,7 +325,7 @@ func TestUseCase_CreateReport(t *testing.T) {
 			reportSvc := tt.mockSetup(ctrl)
 
 			ctx := context.Background()
-			result, err := reportSvc.CreateReport(ctx, tt.reportInput)
+			result, err := reportSvc.CreateReport(ctx, uuid.Nil, tt.reportInput)
 
 			if tt.expectErr {
 				require.Error(t, err)
@@ -316,6 +341,18 @@ func TestUseCase_CreateReport(t *testing.T) {
 	}
 }
 
+// newDispatchedOutboxRepo returns an outbox repository expecting the entry of a created report to be
+// marked as dispatched once its message is published.
+func newDispatchedOutboxRepo(ctrl *gomock.Controller) *outbox.MockRepository {
+	mockOutboxRepo := outbox.NewMockRepository(ctrl)
+
+	mockOutboxRepo.EXPECT().
+		MarkDispatched(gomock.Any(), gomock.Any(), gomock.Any()).
+		Return(nil)
+
+	return mockOutboxRepo
+}
+
 // hashRequestBody computes a SHA256 hash of the JSON-serialized request body.
 // This is a test helper that mirrors the expected hashing logic in the idempotency implementation.
 func hashRequestBody(t *testing.T, input *model.CreateReportInput) string {
@@ -389,11 +426,11 @@ func TestUseCase_CreateReport_Idempotency(t *testing.T) {
 					Return(true, nil)
 
 				mockTempRepo.EXPECT().
-					FindMappedFieldsAndOutputFormatByID(gomock.Any(), gomock.Any()).
-					Return(&outputFormat, mappedFields, nil)
+					FindMappedFieldsAndOutputFormatByID(gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(&outputFormat, mappedFields, nil, "", nil)
 
 				mockReportRepo.EXPECT().
-					Create(gomock.Any(), gomock.Any()).
+					CreateWithOutbox(gomock.Any(), gomock.Any(), gomock.Any()).
 					Return(reportEntity, nil)
 
 				mockRabbitMQ.EXPECT().
@@ -409,6 +446,7 @@ func TestUseCase_CreateReport_Idempotency(t *testing.T) {
 					TemplateRepo: mockTempRepo,
 					ReportRepo:   mockReportRepo,
 					RabbitMQRepo: mockRabbitMQ,
+					OutboxRepo:   newDispatchedOutboxRepo(ctrl),
 					RedisRepo:    mockRedisRepo,
 				}
 			},
@@ -481,11 +519,11 @@ func TestUseCase_CreateReport_Idempotency(t *testing.T) {
 					Return(true, nil)
 
 				mockTempRepo.EXPECT().
-					FindMappedFieldsAndOutputFormatByID(gomock.Any(), gomock.Any()).
-					Return(&outputFormat, mappedFields, nil)
+					FindMappedFieldsAndOutputFormatByID(gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(&outputFormat, mappedFields, nil, "", nil)
 
 				mockReportRepo.EXPECT().
-					Create(gomock.Any(), gomock.Any()).
+					CreateWithOutbox(gomock.Any(), gomock.Any(), gomock.Any()).
 					Return(reportEntity, nil)
 
 				mockRabbitMQ.EXPECT().
@@ -500,6 +538,7 @@ func TestUseCase_CreateReport_Idempotency(t *testing.T) {
 					TemplateRepo: mockTempRepo,
 					ReportRepo:   mockReportRepo,
 					RabbitMQRepo: mockRabbitMQ,
+					OutboxRepo:   newDispatchedOutboxRepo(ctrl),
 					RedisRepo:    mockRedisRepo,
 				}
 			},
@@ -526,11 +565,11 @@ func TestUseCase_CreateReport_Idempotency(t *testing.T) {
 					Return(true, nil)
 
 				mockTempRepo.EXPECT().
-					FindMappedFieldsAndOutputFormatByID(gomock.Any(), gomock.Any()).
-					Return(&outputFormat, mappedFields, nil)
+					FindMappedFieldsAndOutputFormatByID(gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(&outputFormat, mappedFields, nil, "", nil)
 
 				mockReportRepo.EXPECT().
-					Create(gomock.Any(), gomock.Any()).
+					CreateWithOutbox(gomock.Any(), gomock.Any(), gomock.Any()).
 					Return(reportEntity, nil)
 
 				mockRabbitMQ.EXPECT().
@@ -545,6 +584,7 @@ func TestUseCase_CreateReport_Idempotency(t *testing.T) {
 					TemplateRepo: mockTempRepo,
 					ReportRepo:   mockReportRepo,
 					RabbitMQRepo: mockRabbitMQ,
+					OutboxRepo:   newDispatchedOutboxRepo(ctrl),
 					RedisRepo:    mockRedisRepo,
 				}
 			},
@@ -631,7 +671,7 @@ func TestUseCase_CreateReport_Idempotency(t *testing.T) {
 				ctx = context.WithValue(ctx, constant.IdempotencyKeyCtx, tt.idempotencyKey)
 			}
 
-			result, err := reportSvc.CreateReport(ctx, tt.reportInput)
+			result, err := reportSvc.CreateReport(ctx, uuid.Nil, tt.reportInput)
 
 			if tt.expectErr {
 				require.Error(t, err)
@@ -647,6 +687,190 @@ func TestUseCase_CreateReport_Idempotency(t *testing.T) {
 	}
 }
 
+func TestUseCase_CreateReport_RowLevelFilters(t *testing.T) {
+	t.Parallel()
+
+	templateID := uuid.New()
+	outputFormat := "csv"
+
+	mappedFields := map[string]map[string][]string{
+		"midaz_onboarding": {
+			"account": {"id", "name"},
+		},
+	}
+
+	policy := pkg.NewRowLevelPolicy([]pkg.RowLevelRule{
+		{DataSource: "midaz_onboarding", Table: "account", Field: "organization_id", Claim: "owner"},
+	}).WithSigningKey("test-signing-key")
+
+	t.Run("Success - injects the caller's organization into filters and message", func(t *testing.T) {
+		t.Parallel()
+
+		ctrl := gomock.NewController(t)
+		mockTempRepo := template.NewMockRepository(ctrl)
+		mockReportRepo := report.NewMockRepository(ctrl)
+		mockRabbitMQ := rabbitmq.NewMockProducerRepository(ctrl)
+
+		expectedFilters := map[string]map[string]map[string]model.FilterCondition{
+			"midaz_onboarding": {
+				"account": {"organization_id": {Equals: []any{"org-1"}}},
+			},
+		}
+
+		mockTempRepo.EXPECT().
+			FindMappedFieldsAndOutputFormatByID(gomock.Any(), templateID, gomock.Any()).
+			Return(&outputFormat, mappedFields, nil, "", nil)
+
+		mockReportRepo.EXPECT().
+			CreateWithOutbox(gomock.Any(), gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, r *report.Report, _ *outbox.Entry) (*report.Report, error) {
+				assert.Equal(t, expectedFilters, r.Filters)
+
+				return r, nil
+			})
+
+		mockRabbitMQ.EXPECT().
+			ProducerDefault(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, _, _ string, message model.ReportMessage) (*string, error) {
+				assert.Equal(t, expectedFilters, message.Filters)
+				assert.Equal(t, map[string]string{"owner": "org-1"}, message.RowLevelScope)
+				assert.NoError(t, policy.Verify(message.RowLevelScope, message.RowLevelSignature, message.MappedFields, message.Filters))
+
+				return nil, nil
+			})
+
+		uc := &UseCase{
+			TemplateRepo:   mockTempRepo,
+			ReportRepo:     mockReportRepo,
+			RabbitMQRepo:   mockRabbitMQ,
+			OutboxRepo:     newDispatchedOutboxRepo(ctrl),
+			RowLevelPolicy: policy,
+		}
+
+		ctx := context.WithValue(context.Background(), constant.AuthClaimsCtx, map[string]any{"owner": "org-1"})
+
+		result, err := uc.CreateReport(ctx, uuid.Nil, &model.CreateReportInput{TemplateID: templateID.String()})
+		require.NoError(t, err)
+		require.NotNil(t, result)
+	})
+
+	t.Run("Error - caller without the required claim is rejected", func(t *testing.T) {
+		t.Parallel()
+
+		ctrl := gomock.NewController(t)
+		mockTempRepo := template.NewMockRepository(ctrl)
+
+		mockTempRepo.EXPECT().
+			FindMappedFieldsAndOutputFormatByID(gomock.Any(), templateID, gomock.Any()).
+			Return(&outputFormat, mappedFields, nil, "", nil)
+
+		uc := &UseCase{
+			TemplateRepo:   mockTempRepo,
+			ReportRepo:     report.NewMockRepository(ctrl),
+			RabbitMQRepo:   rabbitmq.NewMockProducerRepository(ctrl),
+			RowLevelPolicy: policy,
+		}
+
+		result, err := uc.CreateReport(context.Background(), uuid.Nil, &model.CreateReportInput{TemplateID: templateID.String()})
+		require.Error(t, err)
+		assert.Nil(t, result)
+
+		var forbidden pkg.ForbiddenError
+		require.True(t, errors.As(err, &forbidden))
+		assert.Equal(t, constant.ErrMissingAuthClaim.Error(), forbidden.Code)
+	})
+}
+
+func TestUseCase_CreateReport_LocaleAndTimezone(t *testing.T) {
+	t.Parallel()
+
+	templateID := uuid.New()
+	outputFormat := "html"
+
+	ctrl := gomock.NewController(t)
+	mockTempRepo := template.NewMockRepository(ctrl)
+	mockReportRepo := report.NewMockRepository(ctrl)
+	mockRabbitMQ := rabbitmq.NewMockProducerRepository(ctrl)
+
+	mockTempRepo.EXPECT().
+		FindMappedFieldsAndOutputFormatByID(gomock.Any(), templateID, gomock.Any()).
+		Return(&outputFormat, map[string]map[string][]string{}, nil, "", nil)
+
+	mockReportRepo.EXPECT().
+		CreateWithOutbox(gomock.Any(), gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, r *report.Report, _ *outbox.Entry) (*report.Report, error) {
+			return r, nil
+		})
+
+	mockRabbitMQ.EXPECT().
+		ProducerDefault(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, _, _ string, message model.ReportMessage) (*string, error) {
+			assert.Equal(t, "pt-BR", message.Locale)
+			assert.Equal(t, "America/Sao_Paulo", message.Timezone)
+
+			return nil, nil
+		})
+
+	uc := &UseCase{
+		TemplateRepo: mockTempRepo,
+		ReportRepo:   mockReportRepo,
+		RabbitMQRepo: mockRabbitMQ,
+		OutboxRepo:   newDispatchedOutboxRepo(ctrl),
+	}
+
+	result, err := uc.CreateReport(context.Background(), uuid.Nil, &model.CreateReportInput{
+		TemplateID: templateID.String(),
+		Locale:     "pt-BR",
+		Timezone:   "America/Sao_Paulo",
+	})
+	require.NoError(t, err)
+	require.NotNil(t, result)
+}
+
+func TestUseCase_BuildIdempotencyKey_RowLevelScope(t *testing.T) {
+	t.Parallel()
+
+	uc := &UseCase{
+		RowLevelPolicy: pkg.NewRowLevelPolicy([]pkg.RowLevelRule{
+			{DataSource: "midaz_onboarding", Table: "*", Field: "organization_id", Claim: "owner"},
+		}),
+	}
+
+	base := context.WithValue(context.Background(), constant.IdempotencyKeyCtx, "my-client-key")
+	tenantA := context.WithValue(base, constant.AuthClaimsCtx, map[string]any{"owner": "org-a"})
+	tenantB := context.WithValue(base, constant.AuthClaimsCtx, map[string]any{"owner": "org-b"})
+	input := &model.CreateReportInput{TemplateID: uuid.New().String()}
+
+	keyA, err := uc.buildIdempotencyKey(tenantA, uuid.Nil, input)
+	require.NoError(t, err)
+
+	keyB, err := uc.buildIdempotencyKey(tenantB, uuid.Nil, input)
+	require.NoError(t, err)
+
+	assert.NotEqual(t, keyA, keyB, "different tenants must not share idempotency keys")
+	assert.Contains(t, keyA, "idempotency:my-client-key:")
+}
+
+func TestUseCase_BuildIdempotencyKey_Organization(t *testing.T) {
+	t.Parallel()
+
+	uc := &UseCase{}
+
+	ctx := context.WithValue(context.Background(), constant.IdempotencyKeyCtx, "my-client-key")
+	input := &model.CreateReportInput{TemplateID: uuid.New().String()}
+	orgA := uuid.New()
+	orgB := uuid.New()
+
+	keyA, err := uc.buildIdempotencyKey(ctx, orgA, input)
+	require.NoError(t, err)
+
+	keyB, err := uc.buildIdempotencyKey(ctx, orgB, input)
+	require.NoError(t, err)
+
+	assert.Equal(t, "idempotency:my-client-key:"+orgA.String(), keyA)
+	assert.NotEqual(t, keyA, keyB, "different organizations must not share idempotency keys")
+}
+
 func TestUseCase_ConvertFiltersToMappedFieldsType(t *testing.T) {
 	t.Parallel()
 
@@ -862,7 +1086,7 @@ func TestUseCase_BuildIdempotencyKey_ClientProvidedKey(t *testing.T) {
 
 	ctx := context.WithValue(context.Background(), constant.IdempotencyKeyCtx, "my-client-key")
 
-	key, err := uc.buildIdempotencyKey(ctx, &model.CreateReportInput{
+	key, err := uc.buildIdempotencyKey(ctx, uuid.Nil, &model.CreateReportInput{
 		TemplateID: uuid.New().String(),
 	})
 
@@ -880,13 +1104,13 @@ func TestUseCase_BuildIdempotencyKey_HashBased(t *testing.T) {
 		TemplateID: uuid.New().String(),
 	}
 
-	key, err := uc.buildIdempotencyKey(ctx, input)
+	key, err := uc.buildIdempotencyKey(ctx, uuid.Nil, input)
 
 	require.NoError(t, err)
 	assert.Contains(t, key, "idempotency:")
 
 	// Verify the key is deterministic
-	key2, err2 := uc.buildIdempotencyKey(ctx, input)
+	key2, err2 := uc.buildIdempotencyKey(ctx, uuid.Nil, input)
 	require.NoError(t, err2)
 	assert.Equal(t, key, key2)
 }
@@ -986,3 +1210,130 @@ func TestUseCase_ConvertFiltersToMappedFieldsType_EmptyInput(t *testing.T) {
 		})
 	}
 }
+
+func TestUseCase_CreateReport_Priority(t *testing.T) {
+	t.Parallel()
+
+	lanes := rabbitmq.GenerationLanes{
+		constant.ReportPriorityHigh: {Queue: "reporter.generate-report.high.queue", RoutingKey: "reporter.generate-report.high.key"},
+		constant.ReportPriorityLow:  {Queue: "reporter.generate-report.low.queue", RoutingKey: "reporter.generate-report.low.key"},
+	}
+
+	tests := []struct {
+		name             string
+		requested        string
+		templatePriority string
+		expectedPriority string
+		expectedKey      string
+	}{
+		{name: "Default priority", expectedPriority: constant.ReportPriorityNormal, expectedKey: "reporter.generate-report.key"},
+		{name: "Template priority", templatePriority: constant.ReportPriorityLow, expectedPriority: constant.ReportPriorityLow, expectedKey: "reporter.generate-report.low.key"},
+		{name: "Requested priority overrides the template", requested: constant.ReportPriorityHigh, templatePriority: constant.ReportPriorityLow, expectedPriority: constant.ReportPriorityHigh, expectedKey: "reporter.generate-report.high.key"},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			templateID := uuid.New()
+			outputFormat := "csv"
+
+			ctrl := gomock.NewController(t)
+			mockTempRepo := template.NewMockRepository(ctrl)
+			mockReportRepo := report.NewMockRepository(ctrl)
+			mockRabbitMQ := rabbitmq.NewMockProducerRepository(ctrl)
+
+			mockTempRepo.EXPECT().
+				FindMappedFieldsAndOutputFormatByID(gomock.Any(), templateID, gomock.Any()).
+				Return(&outputFormat, map[string]map[string][]string{}, nil, tt.templatePriority, nil)
+
+			mockReportRepo.EXPECT().
+				CreateWithOutbox(gomock.Any(), gomock.Any(), gomock.Any()).
+				DoAndReturn(func(_ context.Context, r *report.Report, entry *outbox.Entry) (*report.Report, error) {
+					assert.Equal(t, tt.expectedKey, entry.RoutingKey)
+
+					return r, nil
+				})
+
+			mockRabbitMQ.EXPECT().
+				ProducerDefault(gomock.Any(), gomock.Any(), tt.expectedKey, gomock.Any()).
+				DoAndReturn(func(_ context.Context, _, _ string, message model.ReportMessage) (*string, error) {
+					assert.Equal(t, tt.expectedPriority, message.Priority)
+
+					return nil, nil
+				})
+
+			uc := &UseCase{
+				TemplateRepo:              mockTempRepo,
+				ReportRepo:                mockReportRepo,
+				RabbitMQRepo:              mockRabbitMQ,
+				OutboxRepo:                newDispatchedOutboxRepo(ctrl),
+				RabbitMQGenerateReportKey: "reporter.generate-report.key",
+				RabbitMQLanes:             lanes,
+			}
+
+			_, err := uc.CreateReport(context.Background(), uuid.Nil, &model.CreateReportInput{
+				TemplateID: templateID.String(),
+				Priority:   tt.requested,
+			})
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestUseCase_CreateReport_StoresQueuedMessage(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	tempId := uuid.New()
+	outputFormat := "csv"
+	mappedFields := map[string]map[string][]string{"onboarding": {"organization": {"name"}}}
+
+	mockTempRepo := template.NewMockRepository(ctrl)
+	mockReportRepo := report.NewMockRepository(ctrl)
+	mockRabbitMQ := rabbitmq.NewMockProducerRepository(ctrl)
+
+	mockTempRepo.EXPECT().
+		FindMappedFieldsAndOutputFormatByID(gomock.Any(), tempId, gomock.Any()).
+		Return(&outputFormat, mappedFields, nil, "", nil)
+
+	var stored *report.Report
+
+	mockReportRepo.EXPECT().
+		CreateWithOutbox(gomock.Any(), gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, record *report.Report, _ *outbox.Entry) (*report.Report, error) {
+			stored = record
+
+			return record, nil
+		})
+
+	var published model.ReportMessage
+
+	mockRabbitMQ.EXPECT().
+		ProducerDefault(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, _, _ string, message model.ReportMessage) (*string, error) {
+			published = message
+
+			return nil, nil
+		})
+
+	reportSvc := &UseCase{
+		TemplateRepo: mockTempRepo,
+		ReportRepo:   mockReportRepo,
+		RabbitMQRepo: mockRabbitMQ,
+		OutboxRepo:   newDispatchedOutboxRepo(ctrl),
+	}
+
+	result, err := reportSvc.CreateReport(context.Background(), uuid.Nil, &model.CreateReportInput{TemplateID: tempId.String(), Locale: "pt-BR", ReuseOutput: true})
+	require.NoError(t, err)
+
+	// The stored message is the one published, so a retry republishes the same request
+	require.NotNil(t, stored.Message)
+	assert.Equal(t, published, *stored.Message)
+	assert.Equal(t, result.ID, published.ReportID)
+	assert.Equal(t, "pt-BR", published.Locale)
+	assert.True(t, published.ReuseOutput)
+}
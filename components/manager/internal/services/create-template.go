@@ -13,19 +13,26 @@ import (
 
 	"github.com/LerianStudio/reporter/pkg"
 	"github.com/LerianStudio/reporter/pkg/constant"
+	"github.com/LerianStudio/reporter/pkg/model"
 	"github.com/LerianStudio/reporter/pkg/mongodb/template"
 	pkgHTTP "github.com/LerianStudio/reporter/pkg/net/http"
 	templateUtils "github.com/LerianStudio/reporter/pkg/templateutils"
 
 	"github.com/LerianStudio/lib-commons/v2/commons"
 	libOpentelemetry "github.com/LerianStudio/lib-commons/v2/commons/opentelemetry"
+	"github.com/google/uuid"
 	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/trace"
 )
 
 // CreateTemplate creates a new template with specified parameters, stores it in the repository,
 // uploads the file to object storage, and performs a compensating transaction on storage failure.
-func (uc *UseCase) CreateTemplate(ctx context.Context, templateFile, outFormat, description string, fileHeader *multipart.FileHeader) (*template.Template, error) {
+// The template and its file are owned by the given organization. When partialName is set, the
+// template is stored as a partial that other templates can include, extend or import by that name.
+// The output options, when given, set the encoding and line endings of the reports of the template,
+// the priority, when given, picks the queue its reports are generated from and the retention days,
+// when given, set how long its reports are kept before they are purged.
+func (uc *UseCase) CreateTemplate(ctx context.Context, templateFile, outFormat, description, partialName, priority string, retentionDays *int, outputOptions *model.OutputOptions, fileHeader *multipart.FileHeader, organizationID uuid.UUID) (*template.Template, error) {
 	logger, tracer, reqId, _ := commons.NewTrackingFromContext(ctx)
 
 	ctx, span := tracer.Start(ctx, "service.template.create")
@@ -36,13 +43,16 @@ func (uc *UseCase) CreateTemplate(ctx context.Context, templateFile, outFormat,
 		attribute.String("app.request.template_file", templateFile),
 		attribute.String("app.request.output_format", outFormat),
 		attribute.String("app.request.description", description),
+		attribute.String("app.request.partial_name", partialName),
+		attribute.String("app.request.priority", priority),
+		attribute.String("app.request.organization_id", organizationID.String()),
 	)
 
 	logger.Infof("Creating template")
 
 	// Idempotency check: acquire lock via Redis SetNX before proceeding
 	if uc.RedisRepo != nil {
-		cachedResult, err := uc.checkTemplateIdempotency(ctx, templateFile, outFormat, description, &span)
+		cachedResult, err := uc.checkTemplateIdempotency(ctx, organizationID, templateFile, outFormat, description, partialName, priority, retentionDays, outputOptions, &span)
 		if err != nil {
 			return nil, err
 		}
@@ -61,7 +71,39 @@ func (uc *UseCase) CreateTemplate(ctx context.Context, templateFile, outFormat,
 		return nil, errScript
 	}
 
-	mappedFields := templateUtils.MappedFieldsOfTemplate(templateFile)
+	if err := outputOptions.ValidateForFormat(outFormat); err != nil {
+		errOptions := pkg.ValidateBusinessError(constant.ErrInvalidOutputOptions, "", err.Error())
+
+		libOpentelemetry.HandleSpanBusinessErrorEvent(&span, "Output options are not valid for the output format", errOptions)
+
+		return nil, errOptions
+	}
+
+	if partialName != "" {
+		if err := uc.checkPartialNameAvailable(ctx, partialName, organizationID); err != nil {
+			if pkgHTTP.IsBusinessError(err) {
+				libOpentelemetry.HandleSpanBusinessErrorEvent(&span, "Partial name is not available", err)
+			} else {
+				libOpentelemetry.HandleSpanError(&span, "Failed to check partial name availability", err)
+			}
+
+			return nil, err
+		}
+	}
+
+	mappedFields, partials, err := uc.mappedFieldsOfTemplate(ctx, templateFile, partialName, organizationID, nil)
+	if err != nil {
+		if pkgHTTP.IsBusinessError(err) {
+			libOpentelemetry.HandleSpanBusinessErrorEvent(&span, "Failed to resolve template partials", err)
+		} else {
+			libOpentelemetry.HandleSpanError(&span, "Failed to resolve template partials", err)
+		}
+
+		logger.Errorf("Error to resolve template partials, Error: %v", err)
+
+		return nil, err
+	}
+
 	logger.Infof("Mapped Fields is valid to continue %v", mappedFields)
 
 	if errValidateFields := uc.ValidateIfFieldsExistOnTables(ctx, mappedFields); errValidateFields != nil {
@@ -92,8 +134,12 @@ func (uc *UseCase) CreateTemplate(ctx context.Context, templateFile, outFormat,
 	templateId := commons.GenerateUUIDv7()
 	fileName := fmt.Sprintf("%s.tpl", templateId.String())
 
+	if partialName != "" {
+		fileName = fmt.Sprintf("%s%s.tpl", constant.PartialObjectPrefix, partialName)
+	}
+
 	// Build domain entity with invariant validation, then convert to MongoDB model
-	templateEntity, err := template.NewTemplate(templateId, strings.ToLower(outFormat), description, fileName)
+	templateEntity, err := template.NewTemplate(templateId, organizationID, strings.ToLower(outFormat), description, fileName)
 	if err != nil {
 		if pkgHTTP.IsBusinessError(err) {
 			libOpentelemetry.HandleSpanBusinessErrorEvent(&span, "Failed to create template entity", err)
@@ -104,7 +150,17 @@ func (uc *UseCase) CreateTemplate(ctx context.Context, templateFile, outFormat,
 		return nil, err
 	}
 
+	templateEntity.PartialName = partialName
+	templateEntity.Priority = priority
+
+	if retentionDays != nil {
+		templateEntity.RetentionDays = *retentionDays
+	}
+
+	templateEntity.OutputOptions = outputOptions
+
 	templateModel := template.FromTemplateEntity(templateEntity, transformedMappedFields)
+	templateModel.Partials = partials
 
 	resultTemplateModel, err := uc.TemplateRepo.Create(ctx, templateModel)
 	if err != nil {
@@ -125,12 +181,12 @@ func (uc *UseCase) CreateTemplate(ctx context.Context, templateFile, outFormat,
 		return nil, err
 	}
 
-	errPutStorage := uc.TemplateSeaweedFS.Put(ctx, resultTemplateModel.FileName, outFormat, fileBytes)
+	errPutStorage := uc.TemplateSeaweedFS.Put(ctx, pkg.TenantObjectName(organizationID, resultTemplateModel.FileName), outFormat, fileBytes)
 	if errPutStorage != nil {
 		libOpentelemetry.HandleSpanError(&span, "Error putting template file on storage", errPutStorage)
 
 		// Compensating transaction: Attempt to roll back the database change to prevent an orphaned record.
-		if errDelete := uc.DeleteTemplateByID(ctx, resultTemplateModel.ID, true); errDelete != nil {
+		if errDelete := uc.TemplateRepo.Delete(ctx, resultTemplateModel.ID, true, organizationID); errDelete != nil {
 			logger.Errorf("Failed to roll back template creation for ID %s after storage failure. Error: %s", resultTemplateModel.ID.String(), errDelete.Error())
 		}
 
@@ -141,7 +197,7 @@ func (uc *UseCase) CreateTemplate(ctx context.Context, templateFile, outFormat,
 
 	// Cache the successful result for idempotency deduplication of future identical requests
 	if uc.RedisRepo != nil {
-		idempotencyKey, keyErr := uc.buildTemplateIdempotencyKey(ctx, templateFile, outFormat, description)
+		idempotencyKey, keyErr := uc.buildTemplateIdempotencyKey(ctx, organizationID, templateFile, outFormat, description, partialName, priority, retentionDays, outputOptions)
 		if keyErr == nil {
 			uc.cacheTemplateIdempotencyResult(ctx, idempotencyKey, resultTemplateModel)
 		}
@@ -152,10 +208,10 @@ func (uc *UseCase) CreateTemplate(ctx context.Context, templateFile, outFormat,
 
 // checkTemplateIdempotency acquires an idempotency lock via Redis SetNX.
 // Returns a cached template if this is a duplicate request, or nil to proceed with creation.
-func (uc *UseCase) checkTemplateIdempotency(ctx context.Context, templateFile, outFormat, description string, span *trace.Span) (*template.Template, error) {
+func (uc *UseCase) checkTemplateIdempotency(ctx context.Context, organizationID uuid.UUID, templateFile, outFormat, description, partialName, priority string, retentionDays *int, outputOptions *model.OutputOptions, span *trace.Span) (*template.Template, error) {
 	logger, _, _, _ := commons.NewTrackingFromContext(ctx) //nolint:dogsled // only logger needed from tracking context
 
-	idempotencyKey, keyErr := uc.buildTemplateIdempotencyKey(ctx, templateFile, outFormat, description)
+	idempotencyKey, keyErr := uc.buildTemplateIdempotencyKey(ctx, organizationID, templateFile, outFormat, description, partialName, priority, retentionDays, outputOptions)
 	if keyErr != nil {
 		libOpentelemetry.HandleSpanError(span, "Failed to compute template idempotency key", keyErr)
 
@@ -182,17 +238,22 @@ func (uc *UseCase) checkTemplateIdempotency(ctx context.Context, templateFile, o
 
 // templateIdempotencyInput is the internal struct used to compute idempotency hashes
 // for template creation requests. It captures the unique combination of template content,
-// output format, and description that defines a distinct template.
+// output format, description, partial name, priority, retention and output options that defines a distinct template.
 type templateIdempotencyInput struct {
-	TemplateFile string `json:"templateFile"`
-	OutputFormat string `json:"outputFormat"`
-	Description  string `json:"description"`
+	TemplateFile  string               `json:"templateFile"`
+	OutputFormat  string               `json:"outputFormat"`
+	Description   string               `json:"description"`
+	PartialName   string               `json:"partialName,omitempty"`
+	Priority      string               `json:"priority,omitempty"`
+	RetentionDays *int                 `json:"retentionDays,omitempty"`
+	OutputOptions *model.OutputOptions `json:"outputOptions,omitempty"`
 }
 
 // buildTemplateIdempotencyKey resolves the idempotency key for the template creation request.
 // If a client-provided Idempotency-Key header value exists in context, it is used as-is.
 // Otherwise, a SHA256 hash of the JSON-serialized request fields is computed.
-func (uc *UseCase) buildTemplateIdempotencyKey(ctx context.Context, templateFile, outFormat, description string) (string, error) {
+// Keys are scoped to the organization so tenants never share a cached result.
+func (uc *UseCase) buildTemplateIdempotencyKey(ctx context.Context, organizationID uuid.UUID, templateFile, outFormat, description, partialName, priority string, retentionDays *int, outputOptions *model.OutputOptions) (string, error) {
 	logger, tracer, reqId, _ := commons.NewTrackingFromContext(ctx)
 
 	ctx, span := tracer.Start(ctx, "service.template.build_idempotency_key")
@@ -202,7 +263,7 @@ func (uc *UseCase) buildTemplateIdempotencyKey(ctx context.Context, templateFile
 
 	// Check for client-provided idempotency key from context
 	if clientKey, ok := ctx.Value(constant.IdempotencyKeyCtx).(string); ok && clientKey != "" {
-		key := constant.IdempotencyKeyPrefix + ":template:" + clientKey
+		key := constant.IdempotencyKeyPrefix + ":template:" + clientKey + organizationKeySuffix(organizationID)
 
 		logger.Infof("Using client-provided template idempotency key: %s", key)
 
@@ -211,9 +272,13 @@ func (uc *UseCase) buildTemplateIdempotencyKey(ctx context.Context, templateFile
 
 	// Compute SHA256 hash of the serialized request fields
 	input := templateIdempotencyInput{
-		TemplateFile: templateFile,
-		OutputFormat: outFormat,
-		Description:  description,
+		TemplateFile:  templateFile,
+		OutputFormat:  outFormat,
+		Description:   description,
+		PartialName:   partialName,
+		Priority:      priority,
+		RetentionDays: retentionDays,
+		OutputOptions: outputOptions,
 	}
 
 	data, err := json.Marshal(input)
@@ -224,7 +289,7 @@ func (uc *UseCase) buildTemplateIdempotencyKey(ctx context.Context, templateFile
 	}
 
 	hash := commons.HashSHA256(string(data))
-	key := constant.IdempotencyKeyPrefix + ":template:" + hash
+	key := constant.IdempotencyKeyPrefix + ":template:" + hash + organizationKeySuffix(organizationID)
 
 	logger.Infof("Computed template idempotency key from request body hash: %s", key)
 
@@ -15,6 +15,7 @@ import (
 
 	"github.com/LerianStudio/reporter/pkg"
 	"github.com/LerianStudio/reporter/pkg/constant"
+	"github.com/LerianStudio/reporter/pkg/model"
 	"github.com/LerianStudio/reporter/pkg/mongodb"
 	"github.com/LerianStudio/reporter/pkg/mongodb/template"
 	"github.com/LerianStudio/reporter/pkg/postgres"
@@ -306,7 +307,7 @@ func TestUseCase_CreateTemplate(t *testing.T) {
 					Put(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
 					Return(errors.New("storage unavailable"))
 				mockTempRepo.EXPECT().
-					Delete(gomock.Any(), gomock.Any(), true).
+					Delete(gomock.Any(), gomock.Any(), true, gomock.Any()).
 					Return(nil)
 
 				return &UseCase{
@@ -368,7 +369,7 @@ func TestUseCase_CreateTemplate(t *testing.T) {
 					Put(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
 					Return(errors.New("storage unavailable"))
 				mockTempRepo.EXPECT().
-					Delete(gomock.Any(), gomock.Any(), true).
+					Delete(gomock.Any(), gomock.Any(), true, gomock.Any()).
 					Return(errors.New("delete failed"))
 
 				return &UseCase{
@@ -403,7 +404,7 @@ func TestUseCase_CreateTemplate(t *testing.T) {
 			tempSvc := tt.mockSetup(ctrl)
 
 			ctx := context.Background()
-			result, err := tempSvc.CreateTemplate(ctx, tt.templateFile, tt.outFormat, tt.description, tt.fileHeader)
+			result, err := tempSvc.CreateTemplate(ctx, tt.templateFile, tt.outFormat, tt.description, "", "", nil, nil, tt.fileHeader, uuid.Nil)
 
 			if tt.expectErr {
 				require.Error(t, err)
@@ -417,6 +418,20 @@ func TestUseCase_CreateTemplate(t *testing.T) {
 	}
 }
 
+func TestUseCase_CreateTemplate_OutputOptionsForFixedWidth(t *testing.T) {
+	t.Parallel()
+
+	tempSvc := &UseCase{
+		ExternalDataSources: pkg.NewSafeDataSources(map[string]pkg.DataSource{}),
+	}
+
+	_, err := tempSvc.CreateTemplate(context.Background(), `{% fixed_width "layout" %}`, "fixed-width", "CNAB",
+		"", "", nil, &model.OutputOptions{LineEnding: "lf"}, &multipart.FileHeader{}, uuid.New())
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "fixed-width records end with crlf")
+}
+
 func TestUseCase_CreateTemplateWithPluginCRM(t *testing.T) {
 	t.Parallel()
 
@@ -504,7 +519,7 @@ func TestUseCase_CreateTemplateWithPluginCRM(t *testing.T) {
 			Return(nil)
 
 		ctx := context.Background()
-		result, err := tempSvc.CreateTemplate(ctx, templateCRM, "xml", "CRM Template", templateCRMFileHeader)
+		result, err := tempSvc.CreateTemplate(ctx, templateCRM, "xml", "CRM Template", "", "", nil, nil, templateCRMFileHeader, uuid.Nil)
 
 		require.NoError(t, err)
 		require.NotNil(t, result)
@@ -997,7 +1012,7 @@ func TestUseCase_CreateTemplate_Idempotency(t *testing.T) {
 				ctx = context.WithValue(ctx, constant.IdempotencyKeyCtx, tt.idempotencyKey)
 			}
 
-			result, err := tempSvc.CreateTemplate(ctx, tt.templateFile, tt.outFormat, tt.description, templateTestFileHeader)
+			result, err := tempSvc.CreateTemplate(ctx, tt.templateFile, tt.outFormat, tt.description, "", "", nil, nil, templateTestFileHeader, uuid.Nil)
 
 			if tt.expectErr {
 				require.Error(t, err)
@@ -1134,7 +1149,7 @@ func TestUseCase_BuildTemplateIdempotencyKey_ClientProvidedKey(t *testing.T) {
 
 	ctx := context.WithValue(context.Background(), constant.IdempotencyKeyCtx, "my-client-key")
 
-	key, err := uc.buildTemplateIdempotencyKey(ctx, "file-content", "xml", "desc")
+	key, err := uc.buildTemplateIdempotencyKey(ctx, uuid.Nil, "file-content", "xml", "desc", "", "", nil, nil)
 
 	require.NoError(t, err)
 	assert.Equal(t, "idempotency:template:my-client-key", key)
@@ -1147,16 +1162,50 @@ func TestUseCase_BuildTemplateIdempotencyKey_HashBased(t *testing.T) {
 
 	ctx := context.Background()
 
-	key, err := uc.buildTemplateIdempotencyKey(ctx, "file-content", "xml", "desc")
+	key, err := uc.buildTemplateIdempotencyKey(ctx, uuid.Nil, "file-content", "xml", "desc", "", "", nil, nil)
 
 	require.NoError(t, err)
 	assert.Contains(t, key, "idempotency:template:")
 	// Verify the key is deterministic
-	key2, err2 := uc.buildTemplateIdempotencyKey(ctx, "file-content", "xml", "desc")
+	key2, err2 := uc.buildTemplateIdempotencyKey(ctx, uuid.Nil, "file-content", "xml", "desc", "", "", nil, nil)
 	require.NoError(t, err2)
 	assert.Equal(t, key, key2)
 }
 
+func TestUseCase_BuildTemplateIdempotencyKey_Priority(t *testing.T) {
+	t.Parallel()
+
+	uc := &UseCase{}
+
+	ctx := context.Background()
+
+	key, err := uc.buildTemplateIdempotencyKey(ctx, uuid.Nil, "file-content", "xml", "desc", "", "", nil, nil)
+	require.NoError(t, err)
+
+	lowKey, err := uc.buildTemplateIdempotencyKey(ctx, uuid.Nil, "file-content", "xml", "desc", "", constant.ReportPriorityLow, nil, nil)
+	require.NoError(t, err)
+
+	assert.NotEqual(t, key, lowKey)
+}
+
+func TestUseCase_BuildTemplateIdempotencyKey_RetentionDays(t *testing.T) {
+	t.Parallel()
+
+	uc := &UseCase{}
+
+	ctx := context.Background()
+
+	key, err := uc.buildTemplateIdempotencyKey(ctx, uuid.Nil, "file-content", "xml", "desc", "", "", nil, nil)
+	require.NoError(t, err)
+
+	retentionDays := 90
+
+	retentionKey, err := uc.buildTemplateIdempotencyKey(ctx, uuid.Nil, "file-content", "xml", "desc", "", "", &retentionDays, nil)
+	require.NoError(t, err)
+
+	assert.NotEqual(t, key, retentionKey)
+}
+
 func TestUseCase_HandleDuplicateTemplateRequest_EmptyStringResponse(t *testing.T) {
 	t.Parallel()
 
@@ -0,0 +1,248 @@
+// Copyright (c) 2026 Lerian Studio. All rights reserved.
+// Use of this source code is governed by the Elastic License 2.0
+// that can be found in the LICENSE file.
+
+package services
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"time"
+
+	"github.com/LerianStudio/reporter/pkg"
+	"github.com/LerianStudio/reporter/pkg/constant"
+	"github.com/LerianStudio/reporter/pkg/model"
+
+	"github.com/LerianStudio/lib-commons/v2/commons"
+	libOpentelemetry "github.com/LerianStudio/lib-commons/v2/commons/opentelemetry"
+	"github.com/google/uuid"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// replayableStatuses are the statuses of the reports whose dead letter message can be replayed.
+var replayableStatuses = []string{constant.ErrorStatus, constant.ProcessingStatus}
+
+const (
+	// deadLetterNotFound is the failure of a report without a message among the first dead letter messages.
+	deadLetterNotFound = "message not found in the dead letter queue"
+	// deadLetterNotReplayable is the failure of a report that is missing or no longer in Error or Processing.
+	deadLetterNotReplayable = "report not found or not in the Error or Processing status"
+)
+
+// ListDeadLetters returns up to limit messages of the dead letter queue and its depth.
+func (uc *UseCase) ListDeadLetters(ctx context.Context, limit int) (*model.DeadLetterMessagesOutput, error) {
+	logger, tracer, reqId, _ := commons.NewTrackingFromContext(ctx)
+
+	ctx, span := tracer.Start(ctx, "service.dead_letter.list")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("app.request.request_id", reqId),
+		attribute.Int("app.request.limit", limit),
+	)
+
+	if uc.DeadLetterRepo == nil {
+		errConfig := pkg.ValidateBusinessError(constant.ErrDeadLetterQueueNotConfigured, "")
+
+		libOpentelemetry.HandleSpanBusinessErrorEvent(&span, "Dead letter queue not configured", errConfig)
+
+		return nil, errConfig
+	}
+
+	messages, total, err := uc.DeadLetterRepo.Peek(ctx, limit)
+	if err != nil {
+		libOpentelemetry.HandleSpanError(&span, "Failed to peek dead letter messages", err)
+
+		logger.Errorf("Failed to peek dead letter messages: %v", err)
+
+		return nil, err
+	}
+
+	return &model.DeadLetterMessagesOutput{Items: messages, Total: total}, nil
+}
+
+// ReplayDeadLetters republishes to the generation queue the dead letter messages of the reports selected by
+// the input, among the first constant.MaxDeadLetterMessages messages. Each report is reset to Processing with
+// its dead letter message before the message is replayed, recording the failed attempt. A report that can not
+// be replayed does not stop the others and is listed in the output with the reason.
+func (uc *UseCase) ReplayDeadLetters(ctx context.Context, input *model.ReplayDeadLettersInput) (*model.ReplayDeadLettersOutput, error) {
+	logger, tracer, reqId, _ := commons.NewTrackingFromContext(ctx)
+
+	ctx, span := tracer.Start(ctx, "service.dead_letter.replay")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("app.request.request_id", reqId),
+	)
+
+	err := libOpentelemetry.SetSpanAttributesFromStruct(&span, "app.request.payload", input)
+	if err != nil {
+		libOpentelemetry.HandleSpanError(&span, "Failed to convert payload to JSON string", err)
+	}
+
+	if uc.DeadLetterRepo == nil {
+		errConfig := pkg.ValidateBusinessError(constant.ErrDeadLetterQueueNotConfigured, "")
+
+		libOpentelemetry.HandleSpanBusinessErrorEvent(&span, "Dead letter queue not configured", errConfig)
+
+		return nil, errConfig
+	}
+
+	reportIDs, err := deadLetterSelection(input)
+	if err != nil {
+		libOpentelemetry.HandleSpanBusinessErrorEvent(&span, "Invalid dead letter selection", err)
+
+		return nil, err
+	}
+
+	requested := make(map[uuid.UUID]bool, len(reportIDs))
+	for _, id := range reportIDs {
+		requested[id] = true
+	}
+
+	messages, _, err := uc.DeadLetterRepo.Peek(ctx, constant.MaxDeadLetterMessages)
+	if err != nil {
+		libOpentelemetry.HandleSpanError(&span, "Failed to peek dead letter messages", err)
+
+		logger.Errorf("Failed to peek dead letter messages: %v", err)
+
+		return nil, err
+	}
+
+	output := &model.ReplayDeadLettersOutput{
+		Replayed: make([]uuid.UUID, 0),
+		Failed:   make([]model.RetryReportFailure, 0),
+	}
+
+	found := make(map[uuid.UUID]bool, len(messages))
+	reset := make([]uuid.UUID, 0, len(messages))
+
+	for _, message := range messages {
+		if message.Message == nil || found[message.ReportID] {
+			continue
+		}
+
+		if !input.All && !requested[message.ReportID] {
+			continue
+		}
+
+		found[message.ReportID] = true
+
+		// Reset first: the worker skips the reports in Error, so a replayed message would otherwise be dropped
+		if _, errReset := uc.ReportRepo.ResetForRetry(ctx, message.ReportID, message.OrganizationID, replayableStatuses, message.Message, time.Now()); errReset != nil {
+			reason := errReset.Error()
+			if errors.Is(errReset, mongo.ErrNoDocuments) {
+				reason = deadLetterNotReplayable
+			}
+
+			logger.Errorf("Failed to reset report %s for its dead letter replay: %v", message.ReportID, errReset)
+
+			output.Failed = append(output.Failed, model.RetryReportFailure{ReportID: message.ReportID, Error: reason})
+
+			continue
+		}
+
+		reset = append(reset, message.ReportID)
+	}
+
+	for _, id := range reportIDs {
+		if !found[id] {
+			output.Failed = append(output.Failed, model.RetryReportFailure{ReportID: id, Error: deadLetterNotFound})
+		}
+	}
+
+	if len(reset) == 0 {
+		return output, nil
+	}
+
+	replayed, errReplay := uc.DeadLetterRepo.Replay(ctx, reset, constant.MaxDeadLetterMessages)
+	if errReplay != nil {
+		libOpentelemetry.HandleSpanError(&span, "Failed to replay dead letter messages", errReplay)
+
+		logger.Errorf("Failed to replay dead letter messages: %v", errReplay)
+	}
+
+	output.Replayed = append(output.Replayed, replayed...)
+
+	replayedSet := make(map[uuid.UUID]bool, len(replayed))
+	for _, id := range replayed {
+		replayedSet[id] = true
+	}
+
+	// Reports reset but not replayed stay in Processing with their message dead-lettered, so the reaper fails them
+	for _, id := range reset {
+		if replayedSet[id] {
+			continue
+		}
+
+		reason := deadLetterNotFound
+		if errReplay != nil {
+			reason = errReplay.Error()
+		}
+
+		output.Failed = append(output.Failed, model.RetryReportFailure{ReportID: id, Error: reason})
+	}
+
+	logger.Infof("Replayed %d dead letter messages, %d failed", len(output.Replayed), len(output.Failed))
+
+	return output, nil
+}
+
+// PurgeDeadLetters removes every message of the dead letter queue and returns how many were removed.
+// The reports of the purged messages keep their status.
+func (uc *UseCase) PurgeDeadLetters(ctx context.Context) (*model.PurgeDeadLettersOutput, error) {
+	logger, tracer, reqId, _ := commons.NewTrackingFromContext(ctx)
+
+	ctx, span := tracer.Start(ctx, "service.dead_letter.purge")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("app.request.request_id", reqId),
+	)
+
+	if uc.DeadLetterRepo == nil {
+		errConfig := pkg.ValidateBusinessError(constant.ErrDeadLetterQueueNotConfigured, "")
+
+		libOpentelemetry.HandleSpanBusinessErrorEvent(&span, "Dead letter queue not configured", errConfig)
+
+		return nil, errConfig
+	}
+
+	purged, err := uc.DeadLetterRepo.Purge(ctx)
+	if err != nil {
+		libOpentelemetry.HandleSpanError(&span, "Failed to purge dead letter queue", err)
+
+		logger.Errorf("Failed to purge dead letter queue: %v", err)
+
+		return nil, err
+	}
+
+	logger.Infof("Purged %d dead letter messages", purged)
+
+	return &model.PurgeDeadLettersOutput{Purged: purged}, nil
+}
+
+// deadLetterSelection returns the distinct reports selected by a replay, in request order, requiring either
+// report IDs or all. All selects no report in particular.
+func deadLetterSelection(input *model.ReplayDeadLettersInput) ([]uuid.UUID, error) {
+	if input.All == (len(input.ReportIDs) > 0) {
+		return nil, pkg.ValidateBusinessError(constant.ErrInvalidDeadLetterSelection, "", "reportIds and all can not be set together or both be empty")
+	}
+
+	reportIDs := make([]uuid.UUID, 0, len(input.ReportIDs))
+
+	for _, value := range input.ReportIDs {
+		id, err := uuid.Parse(value)
+		if err != nil {
+			return nil, pkg.ValidateBusinessError(constant.ErrInvalidDeadLetterSelection, "", value+" is not a valid report ID")
+		}
+
+		if !slices.Contains(reportIDs, id) {
+			reportIDs = append(reportIDs, id)
+		}
+	}
+
+	return reportIDs, nil
+}
@@ -0,0 +1,210 @@
+// Copyright (c) 2026 Lerian Studio. All rights reserved.
+// Use of this source code is governed by the Elastic License 2.0
+// that can be found in the LICENSE file.
+
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/LerianStudio/reporter/pkg/constant"
+	"github.com/LerianStudio/reporter/pkg/model"
+	"github.com/LerianStudio/reporter/pkg/mongodb/report"
+	"github.com/LerianStudio/reporter/pkg/rabbitmq"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.uber.org/mock/gomock"
+)
+
+func TestUseCase_ListDeadLetters(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Success - lists the first messages and the queue depth", func(t *testing.T) {
+		t.Parallel()
+
+		ctrl := gomock.NewController(t)
+		mockDeadLetterRepo := rabbitmq.NewMockDeadLetterRepository(ctrl)
+
+		messages := []model.DeadLetterMessage{{ReportID: uuid.New(), RetryCount: 3, LastError: "connection refused"}}
+
+		mockDeadLetterRepo.EXPECT().Peek(gomock.Any(), 10).Return(messages, 12, nil)
+
+		uc := &UseCase{DeadLetterRepo: mockDeadLetterRepo}
+
+		output, err := uc.ListDeadLetters(context.Background(), 10)
+		require.NoError(t, err)
+
+		assert.Equal(t, messages, output.Items)
+		assert.Equal(t, 12, output.Total)
+	})
+
+	t.Run("Error - dead letter queue not configured", func(t *testing.T) {
+		t.Parallel()
+
+		uc := &UseCase{}
+
+		output, err := uc.ListDeadLetters(context.Background(), 10)
+		require.Error(t, err)
+
+		assert.Nil(t, output)
+		assert.Contains(t, err.Error(), "RABBITMQ_DLQ_QUEUE")
+	})
+}
+
+func TestUseCase_ReplayDeadLetters(t *testing.T) {
+	t.Parallel()
+
+	orgID := uuid.New()
+
+	deadLetter := func() model.DeadLetterMessage {
+		reportID := uuid.New()
+
+		return model.DeadLetterMessage{
+			ReportID:       reportID,
+			OrganizationID: orgID,
+			Message:        &model.ReportMessage{ReportID: reportID, OrganizationID: orgID, OutputFormat: "csv"},
+		}
+	}
+
+	replayed, finished, lost, unreadable := deadLetter(), deadLetter(), deadLetter(), model.DeadLetterMessage{}
+	missingID := uuid.New()
+
+	tests := []struct {
+		name         string
+		input        *model.ReplayDeadLettersInput
+		mockSetup    func(reportRepo *report.MockRepository, deadLetterRepo *rabbitmq.MockDeadLetterRepository)
+		wantReplayed []uuid.UUID
+		wantFailed   []model.RetryReportFailure
+		errContains  string
+	}{
+		{
+			name:  "Success - replays the selected reports",
+			input: &model.ReplayDeadLettersInput{ReportIDs: []string{replayed.ReportID.String(), finished.ReportID.String(), missingID.String()}},
+			mockSetup: func(reportRepo *report.MockRepository, deadLetterRepo *rabbitmq.MockDeadLetterRepository) {
+				deadLetterRepo.EXPECT().
+					Peek(gomock.Any(), constant.MaxDeadLetterMessages).
+					Return([]model.DeadLetterMessage{unreadable, replayed, lost, finished, replayed}, 5, nil)
+
+				reportRepo.EXPECT().
+					ResetForRetry(gomock.Any(), replayed.ReportID, orgID, replayableStatuses, replayed.Message, gomock.Any()).
+					Return(&report.Report{ID: replayed.ReportID}, nil)
+				reportRepo.EXPECT().
+					ResetForRetry(gomock.Any(), finished.ReportID, orgID, replayableStatuses, finished.Message, gomock.Any()).
+					Return(nil, mongo.ErrNoDocuments)
+
+				deadLetterRepo.EXPECT().
+					Replay(gomock.Any(), []uuid.UUID{replayed.ReportID}, constant.MaxDeadLetterMessages).
+					Return([]uuid.UUID{replayed.ReportID}, nil)
+			},
+			wantReplayed: []uuid.UUID{replayed.ReportID},
+			wantFailed: []model.RetryReportFailure{
+				{ReportID: finished.ReportID, Error: deadLetterNotReplayable},
+				{ReportID: missingID, Error: deadLetterNotFound},
+			},
+		},
+		{
+			name:  "Success - replays every readable message",
+			input: &model.ReplayDeadLettersInput{All: true},
+			mockSetup: func(reportRepo *report.MockRepository, deadLetterRepo *rabbitmq.MockDeadLetterRepository) {
+				deadLetterRepo.EXPECT().
+					Peek(gomock.Any(), constant.MaxDeadLetterMessages).
+					Return([]model.DeadLetterMessage{unreadable, replayed, lost}, 3, nil)
+
+				reportRepo.EXPECT().ResetForRetry(gomock.Any(), replayed.ReportID, orgID, gomock.Any(), gomock.Any(), gomock.Any()).Return(&report.Report{}, nil)
+				reportRepo.EXPECT().ResetForRetry(gomock.Any(), lost.ReportID, orgID, gomock.Any(), gomock.Any(), gomock.Any()).Return(&report.Report{}, nil)
+
+				// The message of lost was consumed in between, e.g. by another replay
+				deadLetterRepo.EXPECT().
+					Replay(gomock.Any(), []uuid.UUID{replayed.ReportID, lost.ReportID}, constant.MaxDeadLetterMessages).
+					Return([]uuid.UUID{replayed.ReportID}, nil)
+			},
+			wantReplayed: []uuid.UUID{replayed.ReportID},
+			wantFailed:   []model.RetryReportFailure{{ReportID: lost.ReportID, Error: deadLetterNotFound}},
+		},
+		{
+			name:  "Success - replay fails midway",
+			input: &model.ReplayDeadLettersInput{All: true},
+			mockSetup: func(reportRepo *report.MockRepository, deadLetterRepo *rabbitmq.MockDeadLetterRepository) {
+				deadLetterRepo.EXPECT().Peek(gomock.Any(), gomock.Any()).Return([]model.DeadLetterMessage{replayed}, 1, nil)
+
+				reportRepo.EXPECT().ResetForRetry(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(&report.Report{}, nil)
+
+				deadLetterRepo.EXPECT().Replay(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, errors.New("channel closed"))
+			},
+			wantReplayed: []uuid.UUID{},
+			wantFailed:   []model.RetryReportFailure{{ReportID: replayed.ReportID, Error: "channel closed"}},
+		},
+		{
+			name:        "Error - neither report IDs nor all",
+			input:       &model.ReplayDeadLettersInput{},
+			mockSetup:   func(*report.MockRepository, *rabbitmq.MockDeadLetterRepository) {},
+			errContains: "can not be set together or both be empty",
+		},
+		{
+			name:        "Error - both report IDs and all",
+			input:       &model.ReplayDeadLettersInput{ReportIDs: []string{uuid.NewString()}, All: true},
+			mockSetup:   func(*report.MockRepository, *rabbitmq.MockDeadLetterRepository) {},
+			errContains: "can not be set together or both be empty",
+		},
+		{
+			name:  "Error - peek fails",
+			input: &model.ReplayDeadLettersInput{All: true},
+			mockSetup: func(_ *report.MockRepository, deadLetterRepo *rabbitmq.MockDeadLetterRepository) {
+				deadLetterRepo.EXPECT().Peek(gomock.Any(), gomock.Any()).Return(nil, 0, errors.New("connection refused"))
+			},
+			errContains: "connection refused",
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockReportRepo := report.NewMockRepository(ctrl)
+			mockDeadLetterRepo := rabbitmq.NewMockDeadLetterRepository(ctrl)
+
+			tt.mockSetup(mockReportRepo, mockDeadLetterRepo)
+
+			uc := &UseCase{ReportRepo: mockReportRepo, DeadLetterRepo: mockDeadLetterRepo}
+
+			output, err := uc.ReplayDeadLetters(context.Background(), tt.input)
+
+			if tt.errContains != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.errContains)
+				assert.Nil(t, output)
+
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantReplayed, output.Replayed)
+			assert.Equal(t, tt.wantFailed, output.Failed)
+		})
+	}
+}
+
+func TestUseCase_PurgeDeadLetters(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	mockDeadLetterRepo := rabbitmq.NewMockDeadLetterRepository(ctrl)
+
+	mockDeadLetterRepo.EXPECT().Purge(gomock.Any()).Return(12, nil)
+
+	uc := &UseCase{DeadLetterRepo: mockDeadLetterRepo}
+
+	output, err := uc.PurgeDeadLetters(context.Background())
+	require.NoError(t, err)
+
+	assert.Equal(t, 12, output.Purged)
+}
@@ -0,0 +1,125 @@
+// Copyright (c) 2026 Lerian Studio. All rights reserved.
+// Use of this source code is governed by the Elastic License 2.0
+// that can be found in the LICENSE file.
+
+package services
+
+import (
+	"context"
+	"time"
+
+	"github.com/LerianStudio/reporter/pkg"
+	"github.com/LerianStudio/reporter/pkg/constant"
+	"github.com/LerianStudio/reporter/pkg/mongodb/report"
+	pkgHTTP "github.com/LerianStudio/reporter/pkg/net/http"
+
+	"github.com/LerianStudio/lib-commons/v2/commons"
+	libOpentelemetry "github.com/LerianStudio/lib-commons/v2/commons/opentelemetry"
+	"github.com/google/uuid"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// DeleteReport soft-deletes a report of the organization and deletes its file and detached signature from
+// the storage. Reports under legal hold or still Processing can not be deleted; downloading a deleted
+// report answers that it is gone. The files are recorded as pending with the deletion, so files the storage
+// fails to delete are retried by the retention purge.
+func (uc *UseCase) DeleteReport(ctx context.Context, id, organizationID uuid.UUID) error {
+	logger, tracer, reqId, _ := commons.NewTrackingFromContext(ctx)
+
+	ctx, span := tracer.Start(ctx, "service.report.delete")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("app.request.request_id", reqId),
+		attribute.String("app.request.report_id", id.String()),
+		attribute.String("app.request.organization_id", organizationID.String()),
+	)
+
+	logger.Infof("Deleting report for id %v", id)
+
+	reportModel, err := uc.GetReportByID(ctx, id, organizationID)
+	if err != nil {
+		if pkgHTTP.IsBusinessError(err) {
+			libOpentelemetry.HandleSpanBusinessErrorEvent(&span, "Failed to retrieve report on query", err)
+		} else {
+			libOpentelemetry.HandleSpanError(&span, "Failed to retrieve report on query", err)
+		}
+
+		logger.Errorf("Failed to retrieve Report with ID: %s, Error: %s", id, err.Error())
+
+		return err
+	}
+
+	if errDeletable := reportDeletable(reportModel); errDeletable != nil {
+		libOpentelemetry.HandleSpanBusinessErrorEvent(&span, "Report is not deletable", errDeletable)
+
+		logger.Errorf("Report with ID %s can not be deleted: %s", id, errDeletable.Error())
+
+		return errDeletable
+	}
+
+	objectNames, err := uc.reportObjectNames(ctx, reportModel)
+	if err != nil {
+		libOpentelemetry.HandleSpanError(&span, "Failed to resolve report files", err)
+
+		logger.Errorf("Failed to resolve files of Report with ID: %s, Error: %s", id, err.Error())
+
+		return err
+	}
+
+	// The report is deleted only while it is neither under legal hold nor Processing, so a legal hold
+	// placed or a retry started concurrently wins over the deletion.
+	deleted, err := uc.ReportRepo.SoftDelete(ctx, id, organizationID, time.Now(), objectNames)
+	if err != nil {
+		libOpentelemetry.HandleSpanError(&span, "Failed to delete report", err)
+
+		logger.Errorf("Failed to delete Report with ID: %s, Error: %s", id, err.Error())
+
+		return err
+	}
+
+	if !deleted {
+		current, errGet := uc.GetReportByID(ctx, id, organizationID)
+		if errGet != nil {
+			libOpentelemetry.HandleSpanBusinessErrorEvent(&span, "Report was deleted concurrently", errGet)
+
+			return errGet
+		}
+
+		errDeletable := reportDeletable(current)
+		if errDeletable == nil {
+			errDeletable = pkg.ValidateBusinessError(constant.ErrReportNotDeletable, "")
+		}
+
+		libOpentelemetry.HandleSpanBusinessErrorEvent(&span, "Report is not deletable", errDeletable)
+
+		logger.Errorf("Report with ID %s changed before it could be deleted", id)
+
+		return errDeletable
+	}
+
+	if err := uc.deleteReportFiles(ctx, reportModel, objectNames); err != nil {
+		libOpentelemetry.HandleSpanError(&span, "Failed to delete report files", err)
+
+		logger.Errorf("Report with ID %s was deleted but its files are pending deletion: %v", id, err)
+
+		return err
+	}
+
+	logger.Infof("Report %s deleted", id)
+
+	return nil
+}
+
+// reportDeletable returns the business error preventing a report from being deleted, if any.
+func reportDeletable(reportModel *report.Report) error {
+	if reportModel.LegalHold {
+		return pkg.ValidateBusinessError(constant.ErrReportUnderLegalHold, "")
+	}
+
+	if reportModel.Status == constant.ProcessingStatus {
+		return pkg.ValidateBusinessError(constant.ErrReportNotDeletable, "")
+	}
+
+	return nil
+}
@@ -0,0 +1,161 @@
+// Copyright (c) 2026 Lerian Studio. All rights reserved.
+// Use of this source code is governed by the Elastic License 2.0
+// that can be found in the LICENSE file.
+
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/LerianStudio/reporter/pkg"
+	"github.com/LerianStudio/reporter/pkg/constant"
+	"github.com/LerianStudio/reporter/pkg/model"
+	"github.com/LerianStudio/reporter/pkg/mongodb/report"
+	"github.com/LerianStudio/reporter/pkg/mongodb/template"
+	reportSeaweedFS "github.com/LerianStudio/reporter/pkg/seaweedfs/report"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.uber.org/mock/gomock"
+)
+
+func TestUseCase_DeleteReport(t *testing.T) {
+	t.Parallel()
+
+	reportId := uuid.New()
+	tempId := uuid.New()
+	orgId := uuid.New()
+	timeNow := time.Now()
+
+	objectName := pkg.TenantObjectName(orgId, tempId.String()+"/"+reportId.String()+".pdf")
+
+	reportWith := func(status string, legalHold bool, metadata map[string]any) *report.Report {
+		return &report.Report{
+			ID:             reportId,
+			TemplateID:     tempId,
+			OrganizationID: orgId,
+			Status:         status,
+			Metadata:       metadata,
+			CreatedAt:      timeNow,
+			LegalHold:      legalHold,
+			Message:        &model.ReportMessage{TemplateID: tempId, ReportID: reportId, OutputFormat: "PDF"},
+		}
+	}
+
+	tests := []struct {
+		name        string
+		mockSetup   func(mockReportRepo *report.MockRepository, mockTempRepo *template.MockRepository, mockStorage *reportSeaweedFS.MockRepository)
+		errContains string
+	}{
+		{
+			name: "Success - Delete finished report and its signature",
+			mockSetup: func(mockReportRepo *report.MockRepository, _ *template.MockRepository, mockStorage *reportSeaweedFS.MockRepository) {
+				signed := map[string]any{constant.ReportSignatureMetadataKey: constant.SignatureCMS}
+
+				mockReportRepo.EXPECT().FindByID(gomock.Any(), reportId, orgId).Return(reportWith(constant.FinishedStatus, false, signed), nil)
+				mockReportRepo.EXPECT().SoftDelete(gomock.Any(), reportId, orgId, gomock.Any(), []string{objectName, objectName + ".p7s"}).Return(true, nil)
+				mockStorage.EXPECT().Delete(gomock.Any(), objectName).Return(nil)
+				mockStorage.EXPECT().Delete(gomock.Any(), objectName+".p7s").Return(nil)
+				mockReportRepo.EXPECT().ClearPendingFiles(gomock.Any(), reportId, orgId).Return(nil)
+			},
+		},
+		{
+			name: "Success - Delete failed report without file",
+			mockSetup: func(mockReportRepo *report.MockRepository, _ *template.MockRepository, _ *reportSeaweedFS.MockRepository) {
+				mockReportRepo.EXPECT().FindByID(gomock.Any(), reportId, orgId).Return(reportWith(constant.ErrorStatus, false, nil), nil)
+				mockReportRepo.EXPECT().SoftDelete(gomock.Any(), reportId, orgId, gomock.Any(), gomock.Nil()).Return(true, nil)
+			},
+		},
+		{
+			name: "Success - Output format read from the template of a report without message",
+			mockSetup: func(mockReportRepo *report.MockRepository, mockTempRepo *template.MockRepository, mockStorage *reportSeaweedFS.MockRepository) {
+				legacy := reportWith(constant.FinishedStatus, false, nil)
+				legacy.Message = nil
+				outputFormat := "pdf"
+
+				mockReportRepo.EXPECT().FindByID(gomock.Any(), reportId, orgId).Return(legacy, nil)
+				mockTempRepo.EXPECT().FindOutputFormatByID(gomock.Any(), tempId, orgId).Return(&outputFormat, nil)
+				mockReportRepo.EXPECT().SoftDelete(gomock.Any(), reportId, orgId, gomock.Any(), []string{objectName}).Return(true, nil)
+				mockStorage.EXPECT().Delete(gomock.Any(), objectName).Return(nil)
+				mockReportRepo.EXPECT().ClearPendingFiles(gomock.Any(), reportId, orgId).Return(nil)
+			},
+		},
+		{
+			name: "Error - Report not found",
+			mockSetup: func(mockReportRepo *report.MockRepository, _ *template.MockRepository, _ *reportSeaweedFS.MockRepository) {
+				mockReportRepo.EXPECT().FindByID(gomock.Any(), reportId, orgId).Return(nil, mongo.ErrNoDocuments)
+			},
+			errContains: "No report entity was found",
+		},
+		{
+			name: "Error - Report under legal hold",
+			mockSetup: func(mockReportRepo *report.MockRepository, _ *template.MockRepository, _ *reportSeaweedFS.MockRepository) {
+				mockReportRepo.EXPECT().FindByID(gomock.Any(), reportId, orgId).Return(reportWith(constant.FinishedStatus, true, nil), nil)
+			},
+			errContains: "under legal hold",
+		},
+		{
+			name: "Error - Report still processing",
+			mockSetup: func(mockReportRepo *report.MockRepository, _ *template.MockRepository, _ *reportSeaweedFS.MockRepository) {
+				mockReportRepo.EXPECT().FindByID(gomock.Any(), reportId, orgId).Return(reportWith(constant.ProcessingStatus, false, nil), nil)
+			},
+			errContains: "still processing",
+		},
+		{
+			name: "Error - Legal hold placed before the deletion",
+			mockSetup: func(mockReportRepo *report.MockRepository, _ *template.MockRepository, _ *reportSeaweedFS.MockRepository) {
+				gomock.InOrder(
+					mockReportRepo.EXPECT().FindByID(gomock.Any(), reportId, orgId).Return(reportWith(constant.FinishedStatus, false, nil), nil),
+					mockReportRepo.EXPECT().SoftDelete(gomock.Any(), reportId, orgId, gomock.Any(), gomock.Any()).Return(false, nil),
+					mockReportRepo.EXPECT().FindByID(gomock.Any(), reportId, orgId).Return(reportWith(constant.FinishedStatus, true, nil), nil),
+				)
+			},
+			errContains: "under legal hold",
+		},
+		{
+			name: "Error - Storage delete fails and the files stay pending",
+			mockSetup: func(mockReportRepo *report.MockRepository, _ *template.MockRepository, mockStorage *reportSeaweedFS.MockRepository) {
+				mockReportRepo.EXPECT().FindByID(gomock.Any(), reportId, orgId).Return(reportWith(constant.FinishedStatus, false, nil), nil)
+				mockReportRepo.EXPECT().SoftDelete(gomock.Any(), reportId, orgId, gomock.Any(), []string{objectName}).Return(true, nil)
+				mockStorage.EXPECT().Delete(gomock.Any(), objectName).Return(errors.New("storage unavailable"))
+			},
+			errContains: "storage unavailable",
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockReportRepo := report.NewMockRepository(ctrl)
+			mockTempRepo := template.NewMockRepository(ctrl)
+			mockStorage := reportSeaweedFS.NewMockRepository(ctrl)
+			tt.mockSetup(mockReportRepo, mockTempRepo, mockStorage)
+
+			reportSvc := &UseCase{
+				ReportRepo:      mockReportRepo,
+				TemplateRepo:    mockTempRepo,
+				ReportSeaweedFS: mockStorage,
+			}
+
+			err := reportSvc.DeleteReport(context.Background(), reportId, orgId)
+			if tt.errContains != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.errContains)
+
+				return
+			}
+
+			require.NoError(t, err)
+		})
+	}
+}
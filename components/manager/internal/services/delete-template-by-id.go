@@ -6,17 +6,19 @@ package services
 
 import (
 	"context"
+	"errors"
 
 	pkgHTTP "github.com/LerianStudio/reporter/pkg/net/http"
 
 	"github.com/LerianStudio/lib-commons/v2/commons"
 	"github.com/LerianStudio/lib-commons/v2/commons/opentelemetry"
 	"github.com/google/uuid"
+	"go.mongodb.org/mongo-driver/mongo"
 	"go.opentelemetry.io/otel/attribute"
 )
 
-// DeleteTemplateByID delete a template from the repository
-func (uc *UseCase) DeleteTemplateByID(ctx context.Context, id uuid.UUID, hardDelete bool) error {
+// DeleteTemplateByID delete a template of the organization from the repository
+func (uc *UseCase) DeleteTemplateByID(ctx context.Context, id uuid.UUID, hardDelete bool, organizationID uuid.UUID) error {
 	logger, tracer, reqId, _ := commons.NewTrackingFromContext(ctx)
 
 	ctx, span := tracer.Start(ctx, "service.template.delete")
@@ -25,11 +27,36 @@ func (uc *UseCase) DeleteTemplateByID(ctx context.Context, id uuid.UUID, hardDel
 	span.SetAttributes(
 		attribute.String("app.request.request_id", reqId),
 		attribute.String("app.request.template_id", id.String()),
+		attribute.String("app.request.organization_id", organizationID.String()),
 	)
 
 	logger.Infof("Remove template for id: %s", id)
 
-	if err := uc.TemplateRepo.Delete(ctx, id, hardDelete); err != nil {
+	// A partial can not be removed while templates still render it
+	currentTemplate, err := uc.TemplateRepo.FindByID(ctx, id, organizationID)
+	if err != nil && !errors.Is(err, mongo.ErrNoDocuments) {
+		opentelemetry.HandleSpanError(&span, "Failed to retrieve template to delete", err)
+
+		logger.Errorf("Error retrieving template to delete by id: %v", err)
+
+		return err
+	}
+
+	if currentTemplate != nil && currentTemplate.IsPartial() {
+		if err := uc.checkPartialNotReferenced(ctx, currentTemplate.PartialName, organizationID); err != nil {
+			if pkgHTTP.IsBusinessError(err) {
+				opentelemetry.HandleSpanBusinessErrorEvent(&span, "Partial is still referenced by templates", err)
+			} else {
+				opentelemetry.HandleSpanError(&span, "Failed to find templates referencing the partial", err)
+			}
+
+			logger.Errorf("Error deleting partial %s: %v", currentTemplate.PartialName, err)
+
+			return err
+		}
+	}
+
+	if err := uc.TemplateRepo.Delete(ctx, id, hardDelete, organizationID); err != nil {
 		if pkgHTTP.IsBusinessError(err) {
 			opentelemetry.HandleSpanBusinessErrorEvent(&span, "Failed to delete template on repo by id", err)
 		} else {
@@ -30,6 +30,7 @@ func TestUseCase_DeleteTemplateByID(t *testing.T) {
 		mockSetup      func(ctrl *gomock.Controller) *UseCase
 		expectErr      bool
 		expectedResult error
+		errContains    string
 	}{
 		{
 			name:       "Success - Delete a template",
@@ -38,7 +39,10 @@ func TestUseCase_DeleteTemplateByID(t *testing.T) {
 			mockSetup: func(ctrl *gomock.Controller) *UseCase {
 				mockTempRepo := template.NewMockRepository(ctrl)
 				mockTempRepo.EXPECT().
-					Delete(gomock.Any(), gomock.Any(), gomock.Any()).
+					FindByID(gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(&template.Template{ID: tempID}, nil)
+				mockTempRepo.EXPECT().
+					Delete(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
 					Return(nil)
 				return &UseCase{TemplateRepo: mockTempRepo}
 			},
@@ -52,7 +56,10 @@ func TestUseCase_DeleteTemplateByID(t *testing.T) {
 			mockSetup: func(ctrl *gomock.Controller) *UseCase {
 				mockTempRepo := template.NewMockRepository(ctrl)
 				mockTempRepo.EXPECT().
-					Delete(gomock.Any(), gomock.Any(), gomock.Any()).
+					FindByID(gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(&template.Template{ID: tempID}, nil)
+				mockTempRepo.EXPECT().
+					Delete(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
 					Return(constant.ErrBadRequest)
 				return &UseCase{TemplateRepo: mockTempRepo}
 			},
@@ -66,13 +73,53 @@ func TestUseCase_DeleteTemplateByID(t *testing.T) {
 			mockSetup: func(ctrl *gomock.Controller) *UseCase {
 				mockTempRepo := template.NewMockRepository(ctrl)
 				mockTempRepo.EXPECT().
-					Delete(gomock.Any(), gomock.Any(), gomock.Any()).
+					FindByID(gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(nil, mongo.ErrNoDocuments)
+				mockTempRepo.EXPECT().
+					Delete(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
 					Return(mongo.ErrNoDocuments)
 				return &UseCase{TemplateRepo: mockTempRepo}
 			},
 			expectErr:      true,
 			expectedResult: mongo.ErrNoDocuments,
 		},
+		{
+			name:       "Error Conflict - Delete a partial still referenced by templates",
+			tempID:     tempID,
+			hardDelete: false,
+			mockSetup: func(ctrl *gomock.Controller) *UseCase {
+				mockTempRepo := template.NewMockRepository(ctrl)
+				mockTempRepo.EXPECT().
+					FindByID(gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(&template.Template{ID: tempID, PartialName: "layouts/corporate"}, nil)
+				mockTempRepo.EXPECT().
+					FindByPartialReference(gomock.Any(), "layouts/corporate", gomock.Any()).
+					Return([]*template.Template{{ID: uuid.New()}}, nil)
+				return &UseCase{TemplateRepo: mockTempRepo}
+			},
+			expectErr:   true,
+			errContains: "still referenced",
+		},
+		{
+			name:       "Success - Delete a partial no template references",
+			tempID:     tempID,
+			hardDelete: false,
+			mockSetup: func(ctrl *gomock.Controller) *UseCase {
+				mockTempRepo := template.NewMockRepository(ctrl)
+				mockTempRepo.EXPECT().
+					FindByID(gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(&template.Template{ID: tempID, PartialName: "layouts/corporate"}, nil)
+				mockTempRepo.EXPECT().
+					FindByPartialReference(gomock.Any(), "layouts/corporate", gomock.Any()).
+					Return([]*template.Template{}, nil)
+				mockTempRepo.EXPECT().
+					Delete(gomock.Any(), tempID, false, gomock.Any()).
+					Return(nil)
+				return &UseCase{TemplateRepo: mockTempRepo}
+			},
+			expectErr:      false,
+			expectedResult: nil,
+		},
 	}
 
 	for _, tt := range tests {
@@ -86,9 +133,12 @@ func TestUseCase_DeleteTemplateByID(t *testing.T) {
 			tempSvc := tt.mockSetup(ctrl)
 
 			ctx := context.Background()
-			err := tempSvc.DeleteTemplateByID(ctx, tt.tempID, tt.hardDelete)
+			err := tempSvc.DeleteTemplateByID(ctx, tt.tempID, tt.hardDelete, uuid.Nil)
 
-			if tt.expectErr {
+			if tt.expectErr && tt.errContains != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.errContains)
+			} else if tt.expectErr {
 				assert.ErrorIs(t, err, tt.expectedResult)
 			} else {
 				require.NoError(t, err)
@@ -0,0 +1,139 @@
+// Copyright (c) 2026 Lerian Studio. All rights reserved.
+// Use of this source code is governed by the Elastic License 2.0
+// that can be found in the LICENSE file.
+
+package services
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/LerianStudio/reporter/pkg/constant"
+	"github.com/LerianStudio/reporter/pkg/mongodb/outbox"
+
+	"github.com/LerianStudio/lib-commons/v2/commons"
+	libOpentelemetry "github.com/LerianStudio/lib-commons/v2/commons/opentelemetry"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// DispatchOutbox publishes the due outbox entries, at most constant.OutboxDispatchBatchSize per run, and
+// returns how many were published. Each entry is claimed first, so several manager instances can relay
+// the outbox concurrently without publishing an entry twice at the same time.
+func (uc *UseCase) DispatchOutbox(ctx context.Context) (int, error) {
+	logger, tracer, reqId, _ := commons.NewTrackingFromContext(ctx)
+
+	ctx, span := tracer.Start(ctx, "service.outbox.dispatch")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("app.request.request_id", reqId),
+	)
+
+	dispatched := 0
+
+	for range constant.OutboxDispatchBatchSize {
+		entry, err := uc.OutboxRepo.Claim(ctx, time.Now(), constant.OutboxClaimLease)
+		if err != nil {
+			if errors.Is(err, mongo.ErrNoDocuments) {
+				break
+			}
+
+			libOpentelemetry.HandleSpanError(&span, "Failed to claim outbox entry", err)
+
+			logger.Errorf("Failed to claim outbox entry: %v", err)
+
+			return dispatched, err
+		}
+
+		if uc.dispatchOutboxEntry(ctx, entry) == nil {
+			dispatched++
+		}
+	}
+
+	if dispatched > 0 {
+		logger.Infof("Outbox relay published %d report messages", dispatched)
+	}
+
+	span.SetAttributes(attribute.Int("app.response.dispatched", dispatched))
+
+	return dispatched, nil
+}
+
+// dispatchOutboxEntry publishes the message of a claimed outbox entry and marks the entry as dispatched.
+// A failed publish is rescheduled with an exponential backoff; after constant.OutboxMaxAttempts the entry
+// is abandoned and its report marked as Error.
+func (uc *UseCase) dispatchOutboxEntry(ctx context.Context, entry *outbox.Entry) error {
+	logger, tracer, _, _ := commons.NewTrackingFromContext(ctx)
+
+	ctx, span := tracer.Start(ctx, "service.outbox.dispatch_entry")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("app.request.outbox_id", entry.ID.String()),
+		attribute.String("app.request.report_id", entry.ReportID.String()),
+		attribute.Int("app.request.attempts", entry.Attempts),
+	)
+
+	_, errPublish := uc.RabbitMQRepo.ProducerDefault(ctx, entry.Exchange, entry.RoutingKey, entry.Message)
+	if errPublish == nil {
+		if err := uc.OutboxRepo.MarkDispatched(ctx, entry.ID, time.Now()); err != nil {
+			// The entry is published again once its claim expires; the worker skips reports already processed
+			libOpentelemetry.HandleSpanError(&span, "Failed to mark outbox entry as dispatched", err)
+
+			logger.Errorf("Failed to mark outbox entry %s as dispatched: %v", entry.ID, err)
+		}
+
+		return nil
+	}
+
+	libOpentelemetry.HandleSpanError(&span, "Failed to send report to queue", errPublish)
+
+	logger.Errorf("Error sending report %s to queue (attempt %d/%d): %v", entry.ReportID, entry.Attempts+1, constant.OutboxMaxAttempts, errPublish)
+
+	if entry.Attempts+1 < constant.OutboxMaxAttempts {
+		nextAttemptAt := time.Now().Add(outboxBackoff(entry.Attempts))
+
+		if err := uc.OutboxRepo.MarkFailed(ctx, entry.ID, errPublish.Error(), nextAttemptAt); err != nil {
+			libOpentelemetry.HandleSpanError(&span, "Failed to reschedule outbox entry", err)
+
+			logger.Errorf("Failed to reschedule outbox entry %s: %v", entry.ID, err)
+		}
+
+		return errPublish
+	}
+
+	if err := uc.OutboxRepo.MarkAbandoned(ctx, entry.ID, errPublish.Error(), time.Now()); err != nil {
+		libOpentelemetry.HandleSpanError(&span, "Failed to abandon outbox entry", err)
+
+		logger.Errorf("Failed to abandon outbox entry %s: %v", entry.ID, err)
+
+		return errPublish
+	}
+
+	metadata := map[string]any{
+		"error": "Failed to send report to queue",
+	}
+	if _, err := uc.ReportRepo.TransitionReportStatus(ctx, entry.ReportID, processingOnly, constant.ErrorStatus, time.Now(), metadata); err != nil {
+		libOpentelemetry.HandleSpanError(&span, "Failed to update report status to error", err)
+
+		logger.Errorf("Error updating report status to error: %v", err)
+	}
+
+	return errPublish
+}
+
+// outboxBackoff returns the delay before the next publish of an entry that already failed attempts times.
+func outboxBackoff(attempts int) time.Duration {
+	backoff := constant.OutboxInitialBackoff
+
+	for range attempts {
+		backoff *= 2
+		if backoff >= constant.OutboxMaxBackoff {
+			return constant.OutboxMaxBackoff
+		}
+	}
+
+	return backoff
+}
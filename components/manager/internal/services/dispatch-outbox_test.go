@@ -0,0 +1,172 @@
+// Copyright (c) 2026 Lerian Studio. All rights reserved.
+// Use of this source code is governed by the Elastic License 2.0
+// that can be found in the LICENSE file.
+
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/LerianStudio/reporter/pkg/constant"
+	"github.com/LerianStudio/reporter/pkg/model"
+	"github.com/LerianStudio/reporter/pkg/mongodb/outbox"
+	"github.com/LerianStudio/reporter/pkg/mongodb/report"
+	"github.com/LerianStudio/reporter/pkg/rabbitmq"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.uber.org/mock/gomock"
+)
+
+func TestUseCase_DispatchOutbox(t *testing.T) {
+	t.Parallel()
+
+	const (
+		exchange   = "reporter.generate-report.exchange"
+		routingKey = "reporter.generate-report.key"
+	)
+
+	newEntry := func(attempts int) *outbox.Entry {
+		entry := outbox.NewEntry(model.ReportMessage{ReportID: uuid.New(), OutputFormat: "csv"}, exchange, routingKey, time.Now(), constant.OutboxClaimLease)
+		entry.Attempts = attempts
+
+		return entry
+	}
+
+	tests := []struct {
+		name           string
+		mockSetup      func(outboxRepo *outbox.MockRepository, reportRepo *report.MockRepository, producer *rabbitmq.MockProducerRepository)
+		wantDispatched int
+		errContains    string
+	}{
+		{
+			name: "Success - No entry due",
+			mockSetup: func(outboxRepo *outbox.MockRepository, _ *report.MockRepository, _ *rabbitmq.MockProducerRepository) {
+				outboxRepo.EXPECT().Claim(gomock.Any(), gomock.Any(), constant.OutboxClaimLease).Return(nil, mongo.ErrNoDocuments)
+			},
+		},
+		{
+			name: "Success - Due entries are published and marked as dispatched",
+			mockSetup: func(outboxRepo *outbox.MockRepository, _ *report.MockRepository, producer *rabbitmq.MockProducerRepository) {
+				first, second := newEntry(0), newEntry(3)
+
+				gomock.InOrder(
+					outboxRepo.EXPECT().Claim(gomock.Any(), gomock.Any(), constant.OutboxClaimLease).Return(first, nil),
+					outboxRepo.EXPECT().Claim(gomock.Any(), gomock.Any(), constant.OutboxClaimLease).Return(second, nil),
+					outboxRepo.EXPECT().Claim(gomock.Any(), gomock.Any(), constant.OutboxClaimLease).Return(nil, mongo.ErrNoDocuments),
+				)
+
+				producer.EXPECT().ProducerDefault(gomock.Any(), exchange, routingKey, first.Message).Return(nil, nil)
+				producer.EXPECT().ProducerDefault(gomock.Any(), exchange, routingKey, second.Message).Return(nil, nil)
+
+				outboxRepo.EXPECT().MarkDispatched(gomock.Any(), first.ID, gomock.Any()).Return(nil)
+				outboxRepo.EXPECT().MarkDispatched(gomock.Any(), second.ID, gomock.Any()).Return(nil)
+			},
+			wantDispatched: 2,
+		},
+		{
+			name: "Success - Failed publish is rescheduled",
+			mockSetup: func(outboxRepo *outbox.MockRepository, _ *report.MockRepository, producer *rabbitmq.MockProducerRepository) {
+				entry := newEntry(1)
+
+				gomock.InOrder(
+					outboxRepo.EXPECT().Claim(gomock.Any(), gomock.Any(), gomock.Any()).Return(entry, nil),
+					outboxRepo.EXPECT().Claim(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, mongo.ErrNoDocuments),
+				)
+
+				producer.EXPECT().ProducerDefault(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, errors.New("channel closed"))
+
+				outboxRepo.EXPECT().
+					MarkFailed(gomock.Any(), entry.ID, "channel closed", gomock.Cond(func(x any) bool {
+						nextAttemptAt, ok := x.(time.Time)
+
+						return ok && nextAttemptAt.After(time.Now().Add(constant.OutboxInitialBackoff))
+					})).
+					Return(nil)
+			},
+		},
+		{
+			name: "Success - Last failed publish abandons the entry and fails the report",
+			mockSetup: func(outboxRepo *outbox.MockRepository, reportRepo *report.MockRepository, producer *rabbitmq.MockProducerRepository) {
+				entry := newEntry(constant.OutboxMaxAttempts - 1)
+
+				gomock.InOrder(
+					outboxRepo.EXPECT().Claim(gomock.Any(), gomock.Any(), gomock.Any()).Return(entry, nil),
+					outboxRepo.EXPECT().Claim(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, mongo.ErrNoDocuments),
+				)
+
+				producer.EXPECT().ProducerDefault(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, errors.New("channel closed"))
+
+				outboxRepo.EXPECT().MarkAbandoned(gomock.Any(), entry.ID, "channel closed", gomock.Any()).Return(nil)
+
+				reportRepo.EXPECT().
+					TransitionReportStatus(gomock.Any(), entry.ReportID, []string{constant.ProcessingStatus}, constant.ErrorStatus, gomock.Any(), map[string]any{"error": "Failed to send report to queue"}).
+					Return(true, nil)
+			},
+		},
+		{
+			name: "Error - Claim fails",
+			mockSetup: func(outboxRepo *outbox.MockRepository, _ *report.MockRepository, producer *rabbitmq.MockProducerRepository) {
+				entry := newEntry(0)
+
+				gomock.InOrder(
+					outboxRepo.EXPECT().Claim(gomock.Any(), gomock.Any(), gomock.Any()).Return(entry, nil),
+					outboxRepo.EXPECT().Claim(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, errors.New("connection refused")),
+				)
+
+				producer.EXPECT().ProducerDefault(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, nil)
+
+				outboxRepo.EXPECT().MarkDispatched(gomock.Any(), entry.ID, gomock.Any()).Return(nil)
+			},
+			wantDispatched: 1,
+			errContains:    "connection refused",
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockOutboxRepo := outbox.NewMockRepository(ctrl)
+			mockReportRepo := report.NewMockRepository(ctrl)
+			mockRabbitMQ := rabbitmq.NewMockProducerRepository(ctrl)
+
+			tt.mockSetup(mockOutboxRepo, mockReportRepo, mockRabbitMQ)
+
+			uc := &UseCase{
+				OutboxRepo:   mockOutboxRepo,
+				ReportRepo:   mockReportRepo,
+				RabbitMQRepo: mockRabbitMQ,
+			}
+
+			dispatched, err := uc.DispatchOutbox(context.Background())
+
+			if tt.errContains != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.errContains)
+			} else {
+				require.NoError(t, err)
+			}
+
+			assert.Equal(t, tt.wantDispatched, dispatched)
+		})
+	}
+}
+
+func TestOutboxBackoff(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, constant.OutboxInitialBackoff, outboxBackoff(0))
+	assert.Equal(t, 2*constant.OutboxInitialBackoff, outboxBackoff(1))
+	assert.Equal(t, 4*constant.OutboxInitialBackoff, outboxBackoff(2))
+	assert.Equal(t, constant.OutboxMaxBackoff, outboxBackoff(constant.OutboxMaxAttempts))
+}
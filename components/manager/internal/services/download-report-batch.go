@@ -0,0 +1,165 @@
+// Copyright (c) 2026 Lerian Studio. All rights reserved.
+// Use of this source code is governed by the Elastic License 2.0
+// that can be found in the LICENSE file.
+
+package services
+
+import (
+	"archive/zip"
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/LerianStudio/reporter/pkg"
+	"github.com/LerianStudio/reporter/pkg/constant"
+	"github.com/LerianStudio/reporter/pkg/mongodb/report"
+	pkgHTTP "github.com/LerianStudio/reporter/pkg/net/http"
+	templateUtils "github.com/LerianStudio/reporter/pkg/templateutils"
+
+	"github.com/LerianStudio/lib-commons/v2/commons"
+	libOpentelemetry "github.com/LerianStudio/lib-commons/v2/commons/opentelemetry"
+	"github.com/google/uuid"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// ReportBatchArchive is the zip download of the finished reports of a batch, written file by file
+// so the outputs of a large batch are never held in memory together.
+type ReportBatchArchive struct {
+	// FileName is the name of the zip file.
+	FileName string
+
+	// Files are the report outputs of the zip, in creation order.
+	Files []ReportBatchArchiveFile
+}
+
+// ReportBatchArchiveFile is a report output written to a ReportBatchArchive.
+type ReportBatchArchiveFile struct {
+	ObjectName string
+	Name       string
+}
+
+// PrepareReportBatchDownload lists the finished reports of a batch of the organization to download as a single
+// zip. Reports still processing, failed or cancelled are left out; a batch without finished reports is rejected.
+func (uc *UseCase) PrepareReportBatchDownload(ctx context.Context, id, organizationID uuid.UUID) (*ReportBatchArchive, error) {
+	logger, tracer, reqId, _ := commons.NewTrackingFromContext(ctx)
+
+	ctx, span := tracer.Start(ctx, "service.report_batch.prepare_download")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("app.request.request_id", reqId),
+		attribute.String("app.request.batch_id", id.String()),
+		attribute.String("app.request.organization_id", organizationID.String()),
+	)
+
+	logger.Infof("Preparing download of report batch %v", id)
+
+	batchModel, err := uc.GetReportBatchByID(ctx, id, organizationID)
+	if err != nil {
+		if pkgHTTP.IsBusinessError(err) {
+			libOpentelemetry.HandleSpanBusinessErrorEvent(&span, "Failed to retrieve report batch", err)
+		} else {
+			libOpentelemetry.HandleSpanError(&span, "Failed to retrieve report batch", err)
+		}
+
+		return nil, err
+	}
+
+	if batchModel.Finished == 0 {
+		errStatus := pkg.ValidateBusinessError(constant.ErrReportBatchNotFinished, "")
+
+		libOpentelemetry.HandleSpanBusinessErrorEvent(&span, "Report batch has no finished reports", errStatus)
+
+		return nil, errStatus
+	}
+
+	templateModel, err := uc.GetTemplateByID(ctx, batchModel.TemplateID, organizationID)
+	if err != nil {
+		if pkgHTTP.IsBusinessError(err) {
+			libOpentelemetry.HandleSpanBusinessErrorEvent(&span, "Failed to retrieve template on query", err)
+		} else {
+			libOpentelemetry.HandleSpanError(&span, "Failed to retrieve template on query", err)
+		}
+
+		return nil, err
+	}
+
+	reports, err := uc.ReportRepo.FindByStatus(ctx, report.StatusQuery{
+		OrganizationID: organizationID,
+		Statuses:       []string{constant.FinishedStatus},
+		BatchID:        id,
+	})
+	if err != nil {
+		libOpentelemetry.HandleSpanError(&span, "Failed to find finished reports of report batch", err)
+
+		logger.Errorf("Error finding finished reports of report batch %s: %v", id, err)
+
+		return nil, err
+	}
+
+	extension := templateUtils.GetFileExtension(templateModel.OutputFormat)
+	archive := &ReportBatchArchive{
+		FileName: "batch-" + id.String() + ".zip",
+		Files:    make([]ReportBatchArchiveFile, 0, len(reports)),
+	}
+
+	for _, reportModel := range reports {
+		archive.Files = append(archive.Files, ReportBatchArchiveFile{
+			ObjectName: pkg.TenantObjectName(organizationID, templateModel.ID.String()+"/"+reportModel.ID.String()+"."+extension),
+			Name:       reportModel.ID.String() + "." + extension,
+		})
+	}
+
+	span.SetAttributes(attribute.Int("app.response.files", len(archive.Files)))
+
+	return archive, nil
+}
+
+// WriteReportBatchArchive writes the zip of a report batch archive to w, downloading one report at a time.
+func (uc *UseCase) WriteReportBatchArchive(ctx context.Context, archive *ReportBatchArchive, w io.Writer) error {
+	logger, tracer, reqId, _ := commons.NewTrackingFromContext(ctx)
+
+	ctx, span := tracer.Start(ctx, "service.report_batch.write_archive")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("app.request.request_id", reqId),
+		attribute.Int("app.request.files", len(archive.Files)),
+	)
+
+	zw := zip.NewWriter(w)
+
+	for _, file := range archive.Files {
+		fileBytes, err := uc.ReportSeaweedFS.Get(ctx, file.ObjectName)
+		if err != nil {
+			libOpentelemetry.HandleSpanError(&span, "Failed to download file from storage", err)
+
+			logger.Errorf("Failed to download file %s of report batch archive: %v", file.ObjectName, err)
+
+			return fmt.Errorf("failed to download %s: %w", file.ObjectName, err)
+		}
+
+		entry, err := zw.Create(file.Name)
+		if err != nil {
+			libOpentelemetry.HandleSpanError(&span, "Failed to add file to report batch archive", err)
+
+			return err
+		}
+
+		if _, err := entry.Write(fileBytes); err != nil {
+			libOpentelemetry.HandleSpanError(&span, "Failed to write file to report batch archive", err)
+
+			return err
+		}
+	}
+
+	if err := zw.Close(); err != nil {
+		libOpentelemetry.HandleSpanError(&span, "Failed to close report batch archive", err)
+
+		return err
+	}
+
+	logger.Infof("Wrote report batch archive %s with %d files", archive.FileName, len(archive.Files))
+
+	return nil
+}
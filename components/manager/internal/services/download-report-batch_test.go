@@ -0,0 +1,167 @@
+// Copyright (c) 2026 Lerian Studio. All rights reserved.
+// Use of this source code is governed by the Elastic License 2.0
+// that can be found in the LICENSE file.
+
+package services
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"testing"
+
+	"github.com/LerianStudio/reporter/pkg"
+	"github.com/LerianStudio/reporter/pkg/constant"
+	"github.com/LerianStudio/reporter/pkg/mongodb/batch"
+	"github.com/LerianStudio/reporter/pkg/mongodb/report"
+	"github.com/LerianStudio/reporter/pkg/mongodb/template"
+	reportSeaweedFS "github.com/LerianStudio/reporter/pkg/seaweedfs/report"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestUseCase_PrepareReportBatchDownload(t *testing.T) {
+	t.Parallel()
+
+	batchID := uuid.New()
+	templateID := uuid.New()
+	organizationID := uuid.New()
+	reportIDs := []uuid.UUID{uuid.New(), uuid.New()}
+
+	t.Run("Success - lists the finished reports of the batch", func(t *testing.T) {
+		t.Parallel()
+
+		ctrl := gomock.NewController(t)
+		mockBatchRepo := batch.NewMockRepository(ctrl)
+		mockReportRepo := report.NewMockRepository(ctrl)
+		mockTempRepo := template.NewMockRepository(ctrl)
+
+		mockBatchRepo.EXPECT().
+			FindByID(gomock.Any(), batchID, organizationID).
+			Return(&batch.Batch{ID: batchID, TemplateID: templateID}, nil)
+
+		mockReportRepo.EXPECT().
+			CountByBatch(gomock.Any(), batchID, organizationID).
+			Return(map[string]int{constant.FinishedStatus: 2, constant.ErrorStatus: 1}, nil)
+
+		mockTempRepo.EXPECT().
+			FindByID(gomock.Any(), templateID, organizationID).
+			Return(&template.Template{ID: templateID, OutputFormat: "csv"}, nil)
+
+		mockReportRepo.EXPECT().
+			FindByStatus(gomock.Any(), report.StatusQuery{
+				OrganizationID: organizationID,
+				Statuses:       []string{constant.FinishedStatus},
+				BatchID:        batchID,
+			}).
+			Return([]*report.Report{{ID: reportIDs[0]}, {ID: reportIDs[1]}}, nil)
+
+		uc := &UseCase{BatchRepo: mockBatchRepo, ReportRepo: mockReportRepo, TemplateRepo: mockTempRepo}
+
+		archive, err := uc.PrepareReportBatchDownload(context.Background(), batchID, organizationID)
+		require.NoError(t, err)
+
+		assert.Equal(t, "batch-"+batchID.String()+".zip", archive.FileName)
+		assert.Equal(t, []ReportBatchArchiveFile{
+			{
+				ObjectName: pkg.TenantObjectName(organizationID, templateID.String()+"/"+reportIDs[0].String()+".csv"),
+				Name:       reportIDs[0].String() + ".csv",
+			},
+			{
+				ObjectName: pkg.TenantObjectName(organizationID, templateID.String()+"/"+reportIDs[1].String()+".csv"),
+				Name:       reportIDs[1].String() + ".csv",
+			},
+		}, archive.Files)
+	})
+
+	t.Run("Error - batch without finished reports", func(t *testing.T) {
+		t.Parallel()
+
+		ctrl := gomock.NewController(t)
+		mockBatchRepo := batch.NewMockRepository(ctrl)
+		mockReportRepo := report.NewMockRepository(ctrl)
+
+		mockBatchRepo.EXPECT().
+			FindByID(gomock.Any(), batchID, organizationID).
+			Return(&batch.Batch{ID: batchID, TemplateID: templateID}, nil)
+
+		mockReportRepo.EXPECT().
+			CountByBatch(gomock.Any(), batchID, organizationID).
+			Return(map[string]int{constant.ProcessingStatus: 3}, nil)
+
+		uc := &UseCase{BatchRepo: mockBatchRepo, ReportRepo: mockReportRepo, TemplateRepo: template.NewMockRepository(ctrl)}
+
+		archive, err := uc.PrepareReportBatchDownload(context.Background(), batchID, organizationID)
+		require.Error(t, err)
+		assert.Nil(t, archive)
+
+		var validationErr pkg.ValidationError
+		require.True(t, errors.As(err, &validationErr))
+		assert.Equal(t, constant.ErrReportBatchNotFinished.Error(), validationErr.Code)
+	})
+}
+
+func TestUseCase_WriteReportBatchArchive(t *testing.T) {
+	t.Parallel()
+
+	archive := &ReportBatchArchive{
+		FileName: "batch.zip",
+		Files: []ReportBatchArchiveFile{
+			{ObjectName: "tpl/a.csv", Name: "a.csv"},
+			{ObjectName: "tpl/b.csv", Name: "b.csv"},
+		},
+	}
+
+	t.Run("Success - writes one zip entry per report", func(t *testing.T) {
+		t.Parallel()
+
+		ctrl := gomock.NewController(t)
+		mockReportStorage := reportSeaweedFS.NewMockRepository(ctrl)
+
+		mockReportStorage.EXPECT().Get(gomock.Any(), "tpl/a.csv").Return([]byte("a;1"), nil)
+		mockReportStorage.EXPECT().Get(gomock.Any(), "tpl/b.csv").Return([]byte("b;2"), nil)
+
+		uc := &UseCase{ReportSeaweedFS: mockReportStorage}
+
+		var buf bytes.Buffer
+		require.NoError(t, uc.WriteReportBatchArchive(context.Background(), archive, &buf))
+
+		zr, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+		require.NoError(t, err)
+		require.Len(t, zr.File, 2)
+
+		contents := map[string]string{}
+
+		for _, f := range zr.File {
+			rc, err := f.Open()
+			require.NoError(t, err)
+
+			data, err := io.ReadAll(rc)
+			require.NoError(t, err)
+			require.NoError(t, rc.Close())
+
+			contents[f.Name] = string(data)
+		}
+
+		assert.Equal(t, map[string]string{"a.csv": "a;1", "b.csv": "b;2"}, contents)
+	})
+
+	t.Run("Error - storage download fails", func(t *testing.T) {
+		t.Parallel()
+
+		ctrl := gomock.NewController(t)
+		mockReportStorage := reportSeaweedFS.NewMockRepository(ctrl)
+
+		mockReportStorage.EXPECT().Get(gomock.Any(), "tpl/a.csv").Return(nil, constant.ErrInternalServer)
+
+		uc := &UseCase{ReportSeaweedFS: mockReportStorage}
+
+		err := uc.WriteReportBatchArchive(context.Background(), archive, io.Discard)
+		require.ErrorIs(t, err, constant.ErrInternalServer)
+	})
+}
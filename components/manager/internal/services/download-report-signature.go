@@ -0,0 +1,113 @@
+// Copyright (c) 2026 Lerian Studio. All rights reserved.
+// Use of this source code is governed by the Elastic License 2.0
+// that can be found in the LICENSE file.
+
+package services
+
+import (
+	"context"
+
+	"github.com/LerianStudio/reporter/pkg"
+	"github.com/LerianStudio/reporter/pkg/constant"
+	pkgHTTP "github.com/LerianStudio/reporter/pkg/net/http"
+	templateUtils "github.com/LerianStudio/reporter/pkg/templateutils"
+
+	"github.com/LerianStudio/lib-commons/v2/commons"
+	libOpentelemetry "github.com/LerianStudio/lib-commons/v2/commons/opentelemetry"
+	"github.com/google/uuid"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// DownloadReportSignature retrieves the detached CMS signature (.p7s) of a finished report, with its
+// file name and content type. The report metadata records how the worker signed it: PDF reports carry
+// an embedded signature instead, and reports generated without a signing certificate have none.
+// Both the report and its template must belong to the given organization.
+func (uc *UseCase) DownloadReportSignature(ctx context.Context, id, organizationID uuid.UUID) ([]byte, string, string, error) {
+	logger, tracer, reqId, _ := commons.NewTrackingFromContext(ctx)
+
+	ctx, span := tracer.Start(ctx, "service.report.download_signature")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("app.request.request_id", reqId),
+		attribute.String("app.request.report_id", id.String()),
+		attribute.String("app.request.organization_id", organizationID.String()),
+	)
+
+	logger.Infof("Downloading signature of report for id %v", id)
+
+	reportModel, err := uc.GetReportByID(ctx, id, organizationID)
+	if err != nil {
+		err = uc.deletedReportError(ctx, id, organizationID, err)
+
+		if pkgHTTP.IsBusinessError(err) {
+			libOpentelemetry.HandleSpanBusinessErrorEvent(&span, "Failed to retrieve report on query", err)
+		} else {
+			libOpentelemetry.HandleSpanError(&span, "Failed to retrieve report on query", err)
+		}
+
+		logger.Errorf("Failed to retrieve Report with ID: %s, Error: %s", id, err.Error())
+
+		return nil, "", "", err
+	}
+
+	if reportModel.Status != constant.FinishedStatus {
+		errStatus := pkg.ValidateBusinessError(constant.ErrReportStatusNotFinished, "")
+
+		libOpentelemetry.HandleSpanBusinessErrorEvent(&span, "Report status is not finished", errStatus)
+
+		logger.Errorf("Report with ID %s is not Finished", id)
+
+		return nil, "", "", errStatus
+	}
+
+	switch reportModel.Metadata[constant.ReportSignatureMetadataKey] {
+	case constant.SignatureCMS:
+	case constant.SignaturePAdES:
+		errEmbedded := pkg.ValidateBusinessError(constant.ErrSignatureEmbedded, "")
+
+		libOpentelemetry.HandleSpanBusinessErrorEvent(&span, "Report signature is embedded", errEmbedded)
+
+		logger.Errorf("Report with ID %s has an embedded signature", id)
+
+		return nil, "", "", errEmbedded
+	default:
+		errNotFound := pkg.ValidateBusinessError(constant.ErrSignatureNotFound, "")
+
+		libOpentelemetry.HandleSpanBusinessErrorEvent(&span, "Report has no signature", errNotFound)
+
+		logger.Errorf("Report with ID %s has no signature", id)
+
+		return nil, "", "", errNotFound
+	}
+
+	templateModel, err := uc.GetTemplateByID(ctx, reportModel.TemplateID, organizationID)
+	if err != nil {
+		if pkgHTTP.IsBusinessError(err) {
+			libOpentelemetry.HandleSpanBusinessErrorEvent(&span, "Failed to retrieve template on query", err)
+		} else {
+			libOpentelemetry.HandleSpanError(&span, "Failed to retrieve template on query", err)
+		}
+
+		logger.Errorf("Failed to retrieve Template with ID: %s, Error: %s", reportModel.TemplateID, err.Error())
+
+		return nil, "", "", err
+	}
+
+	// The signature is stored next to the report, as <report file>.p7s
+	fileName := reportModel.ID.String() + "." + templateUtils.GetFileExtension(templateModel.OutputFormat) + "." + constant.SignatureFileExtension
+	objectName := pkg.TenantObjectName(organizationID, templateModel.ID.String()+"/"+fileName)
+
+	fileBytes, errFile := uc.ReportSeaweedFS.Get(ctx, objectName)
+	if errFile != nil {
+		libOpentelemetry.HandleSpanError(&span, "Failed to download signature from storage", errFile)
+
+		logger.Errorf("Failed to download signature from storage: %s", errFile.Error())
+
+		return nil, "", "", errFile
+	}
+
+	logger.Infof("Downloaded report signature from storage: %s (size: %d bytes)", objectName, len(fileBytes))
+
+	return fileBytes, fileName, constant.SignatureContentType, nil
+}
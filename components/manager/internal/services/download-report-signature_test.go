@@ -0,0 +1,141 @@
+// Copyright (c) 2026 Lerian Studio. All rights reserved.
+// Use of this source code is governed by the Elastic License 2.0
+// that can be found in the LICENSE file.
+
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/LerianStudio/reporter/pkg/constant"
+	"github.com/LerianStudio/reporter/pkg/mongodb/report"
+	"github.com/LerianStudio/reporter/pkg/mongodb/template"
+	reportSeaweedFS "github.com/LerianStudio/reporter/pkg/seaweedfs/report"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestUseCase_DownloadReportSignature(t *testing.T) {
+	t.Parallel()
+
+	reportId := uuid.New()
+	tempId := uuid.New()
+	orgId := uuid.New()
+	timeNow := time.Now()
+
+	reportWith := func(status string, metadata map[string]any) *report.Report {
+		return &report.Report{
+			ID:          reportId,
+			TemplateID:  tempId,
+			Status:      status,
+			Metadata:    metadata,
+			CompletedAt: &timeNow,
+			CreatedAt:   timeNow,
+			UpdatedAt:   timeNow,
+		}
+	}
+
+	templateEntity := &template.Template{
+		ID:           tempId,
+		OutputFormat: "csv",
+		FileName:     tempId.String() + "_1744119295.tpl",
+		CreatedAt:    timeNow,
+		UpdatedAt:    timeNow,
+	}
+
+	signature := []byte("cms-signature")
+	expectedObjectName := orgId.String() + "/" + tempId.String() + "/" + reportId.String() + ".csv.p7s"
+
+	tests := []struct {
+		name        string
+		report      *report.Report
+		storageErr  error
+		expectGet   bool
+		errContains string
+	}{
+		{
+			name:      "Success - Download detached signature",
+			report:    reportWith(constant.FinishedStatus, map[string]any{"signature": "cms"}),
+			expectGet: true,
+		},
+		{
+			name:        "Error - Report status not finished",
+			report:      reportWith(constant.ProcessingStatus, nil),
+			errContains: constant.ErrReportStatusNotFinished.Error(),
+		},
+		{
+			name:        "Error - Signature embedded in the PDF report",
+			report:      reportWith(constant.FinishedStatus, map[string]any{"signature": "pades"}),
+			errContains: constant.ErrSignatureEmbedded.Error(),
+		},
+		{
+			name:        "Error - Report not signed",
+			report:      reportWith(constant.FinishedStatus, nil),
+			errContains: "no detached signature",
+		},
+		{
+			name:        "Error - Storage Get fails",
+			report:      reportWith(constant.FinishedStatus, map[string]any{"signature": "cms"}),
+			storageErr:  errors.New("storage unavailable"),
+			expectGet:   true,
+			errContains: "storage unavailable",
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockReportRepo := report.NewMockRepository(ctrl)
+			mockTempRepo := template.NewMockRepository(ctrl)
+			mockReportStorage := reportSeaweedFS.NewMockRepository(ctrl)
+
+			mockReportRepo.EXPECT().
+				FindByID(gomock.Any(), gomock.Any(), gomock.Any()).
+				Return(tt.report, nil)
+
+			if tt.expectGet {
+				mockTempRepo.EXPECT().
+					FindByID(gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(templateEntity, nil)
+
+				getCall := mockReportStorage.EXPECT().Get(gomock.Any(), expectedObjectName)
+				if tt.storageErr != nil {
+					getCall.Return(nil, tt.storageErr)
+				} else {
+					getCall.Return(signature, nil)
+				}
+			}
+
+			reportSvc := &UseCase{
+				ReportRepo:      mockReportRepo,
+				TemplateRepo:    mockTempRepo,
+				ReportSeaweedFS: mockReportStorage,
+			}
+
+			fileBytes, fileName, contentType, err := reportSvc.DownloadReportSignature(context.Background(), reportId, orgId)
+			if tt.errContains != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.errContains)
+				assert.Nil(t, fileBytes)
+
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, signature, fileBytes)
+			assert.Equal(t, reportId.String()+".csv.p7s", fileName)
+			assert.Equal(t, "application/pkcs7-signature", contentType)
+		})
+	}
+}
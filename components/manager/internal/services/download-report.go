@@ -6,6 +6,9 @@ package services
 
 import (
 	"context"
+	"errors"
+	"io"
+	"time"
 
 	"github.com/LerianStudio/reporter/pkg"
 	"github.com/LerianStudio/reporter/pkg/constant"
@@ -18,10 +21,32 @@ import (
 	"go.opentelemetry.io/otel/attribute"
 )
 
-// DownloadReport retrieves the report file bytes, file name, and content type for a given report ID.
-// It validates the report status, fetches the associated template for output format, constructs the
-// storage object name, and downloads the file from object storage.
-func (uc *UseCase) DownloadReport(ctx context.Context, id uuid.UUID) ([]byte, string, string, error) {
+// ReportDownload is the output of a finished report to download, either from a presigned URL of the
+// object storage or streamed by the manager.
+type ReportDownload struct {
+	// ObjectName is the name of the output in the report storage.
+	ObjectName string
+
+	// FileName is the name the output is downloaded as (reportID.extension).
+	FileName string
+
+	// ContentType is the content type of the output.
+	ContentType string
+
+	// URL is the presigned URL of the output, valid until ExpiresAt. Empty when the output is streamed.
+	URL       string
+	ExpiresAt time.Time
+
+	// Size is the size in bytes of the output. Only set when the output is streamed.
+	Size int64
+}
+
+// DownloadReport resolves the output of a report for download. It validates the report status, fetches the
+// associated template for output format and constructs the storage object name.
+// With presign, it returns a short-lived presigned URL of the output, unless the storage cannot presign;
+// otherwise it reads the output size so that it can be streamed with OpenReportDownload.
+// Both the report and its template must belong to the given organization.
+func (uc *UseCase) DownloadReport(ctx context.Context, id, organizationID uuid.UUID, presign bool) (*ReportDownload, error) {
 	logger, tracer, reqId, _ := commons.NewTrackingFromContext(ctx)
 
 	ctx, span := tracer.Start(ctx, "service.report.download")
@@ -30,13 +55,17 @@ func (uc *UseCase) DownloadReport(ctx context.Context, id uuid.UUID) ([]byte, st
 	span.SetAttributes(
 		attribute.String("app.request.request_id", reqId),
 		attribute.String("app.request.report_id", id.String()),
+		attribute.String("app.request.organization_id", organizationID.String()),
+		attribute.Bool("app.request.presign", presign),
 	)
 
 	logger.Infof("Downloading report for id %v", id)
 
 	// Fetch the report
-	reportModel, err := uc.GetReportByID(ctx, id)
+	reportModel, err := uc.GetReportByID(ctx, id, organizationID)
 	if err != nil {
+		err = uc.deletedReportError(ctx, id, organizationID, err)
+
 		if pkgHTTP.IsBusinessError(err) {
 			libOpentelemetry.HandleSpanBusinessErrorEvent(&span, "Failed to retrieve report on query", err)
 		} else {
@@ -45,7 +74,7 @@ func (uc *UseCase) DownloadReport(ctx context.Context, id uuid.UUID) ([]byte, st
 
 		logger.Errorf("Failed to retrieve Report with ID: %s, Error: %s", id, err.Error())
 
-		return nil, "", "", err
+		return nil, err
 	}
 
 	// Validate report status
@@ -56,11 +85,11 @@ func (uc *UseCase) DownloadReport(ctx context.Context, id uuid.UUID) ([]byte, st
 
 		logger.Errorf("Report with ID %s is not Finished", id)
 
-		return nil, "", "", errStatus
+		return nil, errStatus
 	}
 
 	// Fetch the associated template for output format
-	templateModel, err := uc.GetTemplateByID(ctx, reportModel.TemplateID)
+	templateModel, err := uc.GetTemplateByID(ctx, reportModel.TemplateID, organizationID)
 	if err != nil {
 		if pkgHTTP.IsBusinessError(err) {
 			libOpentelemetry.HandleSpanBusinessErrorEvent(&span, "Failed to retrieve template on query", err)
@@ -70,29 +99,106 @@ func (uc *UseCase) DownloadReport(ctx context.Context, id uuid.UUID) ([]byte, st
 
 		logger.Errorf("Failed to retrieve Template with ID: %s, Error: %s", reportModel.TemplateID, err.Error())
 
-		return nil, "", "", err
+		return nil, err
+	}
+
+	extension := templateUtils.GetFileExtension(templateModel.OutputFormat)
+
+	download := &ReportDownload{
+		// Construct the storage object name
+		ObjectName: pkg.TenantObjectName(organizationID, templateModel.ID.String()+"/"+reportModel.ID.String()+"."+extension),
+		// Construct proper filename for download (reportID.extension, not templateID/reportID.extension)
+		FileName: reportModel.ID.String() + "." + extension,
+		// Determine content type from the template output format, with the charset of its output options
+		ContentType: templateUtils.WithCharset(templateUtils.GetMimeType(templateModel.OutputFormat), templateModel.OutputOptions),
+	}
+
+	if presign {
+		expiry := uc.reportDownloadURLExpiry()
+
+		url, errPresign := uc.ReportSeaweedFS.PresignedURL(ctx, download.ObjectName, download.FileName, expiry)
+		if errPresign == nil {
+			download.URL = url
+			download.ExpiresAt = time.Now().Add(expiry)
+
+			logger.Infof("Presigned report file from storage: %s (expires at %s)", download.ObjectName, download.ExpiresAt)
+
+			return download, nil
+		}
+
+		if !errors.Is(errPresign, constant.ErrPresignNotSupported) {
+			libOpentelemetry.HandleSpanError(&span, "Failed to presign file from storage", errPresign)
+
+			logger.Errorf("Failed to presign file from storage: %s", errPresign.Error())
+
+			return nil, errPresign
+		}
+
+		logger.Infof("Storage cannot presign report file %s, streaming it instead", download.ObjectName)
+	}
+
+	size, errSize := uc.ReportSeaweedFS.Size(ctx, download.ObjectName)
+	if errSize != nil {
+		libOpentelemetry.HandleSpanError(&span, "Failed to read file size from storage", errSize)
+
+		logger.Errorf("Failed to read file size from storage: %s", errSize.Error())
+
+		return nil, errSize
 	}
 
-	// Construct the storage object name
-	objectName := templateModel.ID.String() + "/" + reportModel.ID.String() + "." + templateModel.OutputFormat
+	download.Size = size
+
+	logger.Infof("Resolved report file from storage: %s (size: %d bytes)", download.ObjectName, size)
+
+	return download, nil
+}
+
+// OpenReportDownload streams length bytes of the output of a report download, starting at offset.
+// The caller must close the returned ReadCloser.
+func (uc *UseCase) OpenReportDownload(ctx context.Context, download *ReportDownload, offset, length int64) (io.ReadCloser, error) {
+	logger, tracer, reqId, _ := commons.NewTrackingFromContext(ctx)
+
+	ctx, span := tracer.Start(ctx, "service.report.open_download")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("app.request.request_id", reqId),
+		attribute.Int64("app.request.offset", offset),
+		attribute.Int64("app.request.length", length),
+	)
 
-	// Download the file from storage
-	fileBytes, errFile := uc.ReportSeaweedFS.Get(ctx, objectName)
-	if errFile != nil {
-		libOpentelemetry.HandleSpanError(&span, "Failed to download file from storage", errFile)
+	reader, err := uc.ReportSeaweedFS.GetRange(ctx, download.ObjectName, offset, length)
+	if err != nil {
+		libOpentelemetry.HandleSpanError(&span, "Failed to download file from storage", err)
 
-		logger.Errorf("Failed to download file from storage: %s", errFile.Error())
+		logger.Errorf("Failed to download file from storage: %s", err.Error())
 
-		return nil, "", "", errFile
+		return nil, err
 	}
 
-	logger.Infof("Downloaded report file from storage: %s (size: %d bytes)", objectName, len(fileBytes))
+	return reader, nil
+}
 
-	// Determine content type from the template output format
-	contentType := templateUtils.GetMimeType(templateModel.OutputFormat)
+// reportDownloadURLExpiry returns how long the presigned report download URLs are valid.
+func (uc *UseCase) reportDownloadURLExpiry() time.Duration {
+	if uc.ReportDownloadURLExpiry > 0 {
+		return uc.ReportDownloadURLExpiry
+	}
 
-	// Construct proper filename for download (reportID.extension, not templateID/reportID.extension)
-	fileName := reportModel.ID.String() + "." + templateModel.OutputFormat
+	return constant.DefaultReportDownloadURLExpiry
+}
+
+// deletedReportError replaces the not found error of a report with ErrReportPurged when the report exists
+// but was deleted, by request or by its retention policy, so that downloading it answers it is gone.
+func (uc *UseCase) deletedReportError(ctx context.Context, id, organizationID uuid.UUID, err error) error {
+	var notFound pkg.EntityNotFoundError
+	if !errors.As(err, &notFound) {
+		return err
+	}
+
+	if _, errDeleted := uc.ReportRepo.FindDeletedByID(ctx, id, organizationID); errDeleted != nil {
+		return err
+	}
 
-	return fileBytes, fileName, contentType, nil
+	return pkg.ValidateBusinessError(constant.ErrReportPurged, constant.MongoCollectionReport)
 }
@@ -7,10 +7,14 @@ package services
 import (
 	"context"
 	"errors"
+	"io"
+	"strings"
 	"testing"
 	"time"
 
+	"github.com/LerianStudio/reporter/pkg"
 	"github.com/LerianStudio/reporter/pkg/constant"
+	"github.com/LerianStudio/reporter/pkg/model"
 	"github.com/LerianStudio/reporter/pkg/mongodb/report"
 	"github.com/LerianStudio/reporter/pkg/mongodb/template"
 	reportSeaweedFS "github.com/LerianStudio/reporter/pkg/seaweedfs/report"
@@ -18,6 +22,7 @@ import (
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/mongo"
 	"go.uber.org/mock/gomock"
 )
 
@@ -58,15 +63,18 @@ func TestUseCase_DownloadReport(t *testing.T) {
 		UpdatedAt:    timeNow,
 	}
 
-	expectedFileBytes := []byte("report-file-content")
+	objectName := pkg.TenantObjectName(uuid.Nil, tempId.String()+"/"+reportId.String()+".pdf")
+	fileName := reportId.String() + ".pdf"
+	presignedURL := "https://storage.example.com/reports/" + fileName + "?X-Amz-Signature=abc"
 
 	tests := []struct {
-		name          string
-		reportId      uuid.UUID
-		mockSetup     func(ctrl *gomock.Controller) *UseCase
-		expectErr     bool
-		errContains   string
-		expectedBytes []byte
+		name             string
+		reportId         uuid.UUID
+		presign          bool
+		mockSetup        func(ctrl *gomock.Controller) *UseCase
+		expectErr        bool
+		errContains      string
+		expectedDownload *ReportDownload
 	}{
 		{
 			name:     "Success - Download finished report",
@@ -77,16 +85,16 @@ func TestUseCase_DownloadReport(t *testing.T) {
 				mockReportStorage := reportSeaweedFS.NewMockRepository(ctrl)
 
 				mockReportRepo.EXPECT().
-					FindByID(gomock.Any(), gomock.Any()).
+					FindByID(gomock.Any(), gomock.Any(), gomock.Any()).
 					Return(finishedReport, nil)
 
 				mockTempRepo.EXPECT().
-					FindByID(gomock.Any(), gomock.Any()).
+					FindByID(gomock.Any(), gomock.Any(), gomock.Any()).
 					Return(templateEntity, nil)
 
 				mockReportStorage.EXPECT().
-					Get(gomock.Any(), gomock.Any()).
-					Return(expectedFileBytes, nil)
+					Size(gomock.Any(), objectName).
+					Return(int64(19), nil)
 
 				return &UseCase{
 					ReportRepo:      mockReportRepo,
@@ -94,8 +102,123 @@ func TestUseCase_DownloadReport(t *testing.T) {
 					ReportSeaweedFS: mockReportStorage,
 				}
 			},
-			expectErr:     false,
-			expectedBytes: expectedFileBytes,
+			expectErr: false,
+			expectedDownload: &ReportDownload{
+				ObjectName:  objectName,
+				FileName:    fileName,
+				ContentType: "application/pdf",
+				Size:        19,
+			},
+		},
+		{
+			name:     "Success - Presigned download of finished report",
+			reportId: reportId,
+			presign:  true,
+			mockSetup: func(ctrl *gomock.Controller) *UseCase {
+				mockReportRepo := report.NewMockRepository(ctrl)
+				mockTempRepo := template.NewMockRepository(ctrl)
+				mockReportStorage := reportSeaweedFS.NewMockRepository(ctrl)
+
+				mockReportRepo.EXPECT().
+					FindByID(gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(finishedReport, nil)
+
+				mockTempRepo.EXPECT().
+					FindByID(gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(templateEntity, nil)
+
+				mockReportStorage.EXPECT().
+					PresignedURL(gomock.Any(), objectName, fileName, constant.DefaultReportDownloadURLExpiry).
+					Return(presignedURL, nil)
+
+				return &UseCase{
+					ReportRepo:      mockReportRepo,
+					TemplateRepo:    mockTempRepo,
+					ReportSeaweedFS: mockReportStorage,
+				}
+			},
+			expectErr: false,
+			expectedDownload: &ReportDownload{
+				ObjectName:  objectName,
+				FileName:    fileName,
+				ContentType: "application/pdf",
+				URL:         presignedURL,
+			},
+		},
+		{
+			name:     "Success - Presigned download with configured expiry",
+			reportId: reportId,
+			presign:  true,
+			mockSetup: func(ctrl *gomock.Controller) *UseCase {
+				mockReportRepo := report.NewMockRepository(ctrl)
+				mockTempRepo := template.NewMockRepository(ctrl)
+				mockReportStorage := reportSeaweedFS.NewMockRepository(ctrl)
+
+				mockReportRepo.EXPECT().
+					FindByID(gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(finishedReport, nil)
+
+				mockTempRepo.EXPECT().
+					FindByID(gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(templateEntity, nil)
+
+				mockReportStorage.EXPECT().
+					PresignedURL(gomock.Any(), objectName, fileName, time.Hour).
+					Return(presignedURL, nil)
+
+				return &UseCase{
+					ReportRepo:              mockReportRepo,
+					TemplateRepo:            mockTempRepo,
+					ReportSeaweedFS:         mockReportStorage,
+					ReportDownloadURLExpiry: time.Hour,
+				}
+			},
+			expectErr: false,
+			expectedDownload: &ReportDownload{
+				ObjectName:  objectName,
+				FileName:    fileName,
+				ContentType: "application/pdf",
+				URL:         presignedURL,
+			},
+		},
+		{
+			name:     "Success - Falls back to streaming when storage cannot presign",
+			reportId: reportId,
+			presign:  true,
+			mockSetup: func(ctrl *gomock.Controller) *UseCase {
+				mockReportRepo := report.NewMockRepository(ctrl)
+				mockTempRepo := template.NewMockRepository(ctrl)
+				mockReportStorage := reportSeaweedFS.NewMockRepository(ctrl)
+
+				mockReportRepo.EXPECT().
+					FindByID(gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(finishedReport, nil)
+
+				mockTempRepo.EXPECT().
+					FindByID(gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(templateEntity, nil)
+
+				mockReportStorage.EXPECT().
+					PresignedURL(gomock.Any(), objectName, fileName, gomock.Any()).
+					Return("", constant.ErrPresignNotSupported)
+
+				mockReportStorage.EXPECT().
+					Size(gomock.Any(), objectName).
+					Return(int64(19), nil)
+
+				return &UseCase{
+					ReportRepo:      mockReportRepo,
+					TemplateRepo:    mockTempRepo,
+					ReportSeaweedFS: mockReportStorage,
+				}
+			},
+			expectErr: false,
+			expectedDownload: &ReportDownload{
+				ObjectName:  objectName,
+				FileName:    fileName,
+				ContentType: "application/pdf",
+				Size:        19,
+			},
 		},
 		{
 			name:     "Error - GetReportByID fails",
@@ -106,7 +229,7 @@ func TestUseCase_DownloadReport(t *testing.T) {
 				mockReportStorage := reportSeaweedFS.NewMockRepository(ctrl)
 
 				mockReportRepo.EXPECT().
-					FindByID(gomock.Any(), gomock.Any()).
+					FindByID(gomock.Any(), gomock.Any(), gomock.Any()).
 					Return(nil, constant.ErrInternalServer)
 
 				return &UseCase{
@@ -115,9 +238,8 @@ func TestUseCase_DownloadReport(t *testing.T) {
 					ReportSeaweedFS: mockReportStorage,
 				}
 			},
-			expectErr:     true,
-			errContains:   constant.ErrInternalServer.Error(),
-			expectedBytes: nil,
+			expectErr:   true,
+			errContains: constant.ErrInternalServer.Error(),
 		},
 		{
 			name:     "Error - Report status not finished",
@@ -128,7 +250,7 @@ func TestUseCase_DownloadReport(t *testing.T) {
 				mockReportStorage := reportSeaweedFS.NewMockRepository(ctrl)
 
 				mockReportRepo.EXPECT().
-					FindByID(gomock.Any(), gomock.Any()).
+					FindByID(gomock.Any(), gomock.Any(), gomock.Any()).
 					Return(processingReport, nil)
 
 				return &UseCase{
@@ -137,9 +259,50 @@ func TestUseCase_DownloadReport(t *testing.T) {
 					ReportSeaweedFS: mockReportStorage,
 				}
 			},
-			expectErr:     true,
-			errContains:   constant.ErrReportStatusNotFinished.Error(),
-			expectedBytes: nil,
+			expectErr:   true,
+			errContains: constant.ErrReportStatusNotFinished.Error(),
+		},
+		{
+			name:     "Error - Report purged",
+			reportId: reportId,
+			mockSetup: func(ctrl *gomock.Controller) *UseCase {
+				mockReportRepo := report.NewMockRepository(ctrl)
+
+				mockReportRepo.EXPECT().
+					FindByID(gomock.Any(), reportId, gomock.Any()).
+					Return(nil, mongo.ErrNoDocuments)
+
+				mockReportRepo.EXPECT().
+					FindDeletedByID(gomock.Any(), reportId, gomock.Any()).
+					Return(&report.Report{ID: reportId, TemplateID: tempId, Status: constant.FinishedStatus, DeletedAt: &timeNow}, nil)
+
+				return &UseCase{
+					ReportRepo: mockReportRepo,
+				}
+			},
+			expectErr:   true,
+			errContains: "deleted or purged",
+		},
+		{
+			name:     "Error - Report not found",
+			reportId: reportId,
+			mockSetup: func(ctrl *gomock.Controller) *UseCase {
+				mockReportRepo := report.NewMockRepository(ctrl)
+
+				mockReportRepo.EXPECT().
+					FindByID(gomock.Any(), reportId, gomock.Any()).
+					Return(nil, mongo.ErrNoDocuments)
+
+				mockReportRepo.EXPECT().
+					FindDeletedByID(gomock.Any(), reportId, gomock.Any()).
+					Return(nil, mongo.ErrNoDocuments)
+
+				return &UseCase{
+					ReportRepo: mockReportRepo,
+				}
+			},
+			expectErr:   true,
+			errContains: "No report entity was found",
 		},
 		{
 			name:     "Error - GetTemplateByID fails",
@@ -150,11 +313,11 @@ func TestUseCase_DownloadReport(t *testing.T) {
 				mockReportStorage := reportSeaweedFS.NewMockRepository(ctrl)
 
 				mockReportRepo.EXPECT().
-					FindByID(gomock.Any(), gomock.Any()).
+					FindByID(gomock.Any(), gomock.Any(), gomock.Any()).
 					Return(finishedReport, nil)
 
 				mockTempRepo.EXPECT().
-					FindByID(gomock.Any(), gomock.Any()).
+					FindByID(gomock.Any(), gomock.Any(), gomock.Any()).
 					Return(nil, errors.New("template not found"))
 
 				return &UseCase{
@@ -163,12 +326,41 @@ func TestUseCase_DownloadReport(t *testing.T) {
 					ReportSeaweedFS: mockReportStorage,
 				}
 			},
-			expectErr:     true,
-			errContains:   "template not found",
-			expectedBytes: nil,
+			expectErr:   true,
+			errContains: "template not found",
+		},
+		{
+			name:     "Error - Storage presign fails",
+			reportId: reportId,
+			presign:  true,
+			mockSetup: func(ctrl *gomock.Controller) *UseCase {
+				mockReportRepo := report.NewMockRepository(ctrl)
+				mockTempRepo := template.NewMockRepository(ctrl)
+				mockReportStorage := reportSeaweedFS.NewMockRepository(ctrl)
+
+				mockReportRepo.EXPECT().
+					FindByID(gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(finishedReport, nil)
+
+				mockTempRepo.EXPECT().
+					FindByID(gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(templateEntity, nil)
+
+				mockReportStorage.EXPECT().
+					PresignedURL(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					Return("", errors.New("storage unavailable"))
+
+				return &UseCase{
+					ReportRepo:      mockReportRepo,
+					TemplateRepo:    mockTempRepo,
+					ReportSeaweedFS: mockReportStorage,
+				}
+			},
+			expectErr:   true,
+			errContains: "storage unavailable",
 		},
 		{
-			name:     "Error - Storage Get fails",
+			name:     "Error - Storage Size fails",
 			reportId: reportId,
 			mockSetup: func(ctrl *gomock.Controller) *UseCase {
 				mockReportRepo := report.NewMockRepository(ctrl)
@@ -176,16 +368,16 @@ func TestUseCase_DownloadReport(t *testing.T) {
 				mockReportStorage := reportSeaweedFS.NewMockRepository(ctrl)
 
 				mockReportRepo.EXPECT().
-					FindByID(gomock.Any(), gomock.Any()).
+					FindByID(gomock.Any(), gomock.Any(), gomock.Any()).
 					Return(finishedReport, nil)
 
 				mockTempRepo.EXPECT().
-					FindByID(gomock.Any(), gomock.Any()).
+					FindByID(gomock.Any(), gomock.Any(), gomock.Any()).
 					Return(templateEntity, nil)
 
 				mockReportStorage.EXPECT().
-					Get(gomock.Any(), gomock.Any()).
-					Return(nil, errors.New("storage unavailable"))
+					Size(gomock.Any(), gomock.Any()).
+					Return(int64(0), errors.New("storage unavailable"))
 
 				return &UseCase{
 					ReportRepo:      mockReportRepo,
@@ -193,9 +385,8 @@ func TestUseCase_DownloadReport(t *testing.T) {
 					ReportSeaweedFS: mockReportStorage,
 				}
 			},
-			expectErr:     true,
-			errContains:   "storage unavailable",
-			expectedBytes: nil,
+			expectErr:   true,
+			errContains: "storage unavailable",
 		},
 	}
 
@@ -210,21 +401,118 @@ func TestUseCase_DownloadReport(t *testing.T) {
 			reportSvc := tt.mockSetup(ctrl)
 
 			ctx := context.Background()
-			fileBytes, objectName, contentType, err := reportSvc.DownloadReport(ctx, tt.reportId)
+			download, err := reportSvc.DownloadReport(ctx, tt.reportId, uuid.Nil, tt.presign)
 
 			if tt.expectErr {
 				require.Error(t, err)
 				assert.Contains(t, err.Error(), tt.errContains)
-				assert.Nil(t, fileBytes)
-				assert.Empty(t, objectName)
-				assert.Empty(t, contentType)
-			} else {
-				require.NoError(t, err)
-				require.NotNil(t, fileBytes)
-				assert.Equal(t, tt.expectedBytes, fileBytes)
-				assert.NotEmpty(t, objectName)
-				assert.NotEmpty(t, contentType)
+				assert.Nil(t, download)
+
+				return
+			}
+
+			require.NoError(t, err)
+			require.NotNil(t, download)
+
+			if tt.expectedDownload.URL != "" {
+				assert.WithinDuration(t, time.Now().Add(reportSvc.reportDownloadURLExpiry()), download.ExpiresAt, time.Minute)
 			}
+
+			download.ExpiresAt = time.Time{}
+			assert.Equal(t, tt.expectedDownload, download)
 		})
 	}
 }
+
+func TestUseCase_OpenReportDownload(t *testing.T) {
+	t.Parallel()
+
+	download := &ReportDownload{ObjectName: "tpl/report.pdf", Size: 10}
+
+	t.Run("Success - streams the range of the output", func(t *testing.T) {
+		t.Parallel()
+
+		ctrl := gomock.NewController(t)
+		mockReportStorage := reportSeaweedFS.NewMockRepository(ctrl)
+
+		mockReportStorage.EXPECT().
+			GetRange(gomock.Any(), "tpl/report.pdf", int64(2), int64(4)).
+			Return(io.NopCloser(strings.NewReader("2345")), nil)
+
+		reportSvc := &UseCase{ReportSeaweedFS: mockReportStorage}
+
+		reader, err := reportSvc.OpenReportDownload(context.Background(), download, 2, 4)
+		require.NoError(t, err)
+
+		data, err := io.ReadAll(reader)
+		require.NoError(t, err)
+		assert.Equal(t, "2345", string(data))
+	})
+
+	t.Run("Error - storage fails", func(t *testing.T) {
+		t.Parallel()
+
+		ctrl := gomock.NewController(t)
+		mockReportStorage := reportSeaweedFS.NewMockRepository(ctrl)
+
+		mockReportStorage.EXPECT().
+			GetRange(gomock.Any(), "tpl/report.pdf", int64(0), int64(10)).
+			Return(nil, constant.ErrInternalServer)
+
+		reportSvc := &UseCase{ReportSeaweedFS: mockReportStorage}
+
+		reader, err := reportSvc.OpenReportDownload(context.Background(), download, 0, 10)
+		require.ErrorIs(t, err, constant.ErrInternalServer)
+		assert.Nil(t, reader)
+	})
+}
+
+func TestUseCase_DownloadReport_OutputOptionsCharset(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	timeNow := time.Now()
+	tempId := uuid.New()
+
+	finishedReport := &report.Report{
+		ID:          uuid.New(),
+		TemplateID:  tempId,
+		Status:      constant.FinishedStatus,
+		CompletedAt: &timeNow,
+	}
+
+	templateEntity := &template.Template{
+		ID:            tempId,
+		OutputFormat:  "csv",
+		OutputOptions: &model.OutputOptions{Encoding: "windows-1252", LineEnding: "crlf"},
+	}
+
+	mockReportRepo := report.NewMockRepository(ctrl)
+	mockTempRepo := template.NewMockRepository(ctrl)
+	mockReportStorage := reportSeaweedFS.NewMockRepository(ctrl)
+
+	mockReportRepo.EXPECT().
+		FindByID(gomock.Any(), gomock.Any(), gomock.Any()).
+		Return(finishedReport, nil)
+
+	mockTempRepo.EXPECT().
+		FindByID(gomock.Any(), gomock.Any(), gomock.Any()).
+		Return(templateEntity, nil)
+
+	mockReportStorage.EXPECT().
+		Size(gomock.Any(), gomock.Any()).
+		Return(int64(17), nil)
+
+	reportSvc := &UseCase{
+		ReportRepo:      mockReportRepo,
+		TemplateRepo:    mockTempRepo,
+		ReportSeaweedFS: mockReportStorage,
+	}
+
+	download, err := reportSvc.DownloadReport(context.Background(), finishedReport.ID, uuid.Nil, false)
+	require.NoError(t, err)
+	assert.Equal(t, finishedReport.ID.String()+".csv", download.FileName)
+	assert.Equal(t, "text/csv; charset=windows-1252", download.ContentType)
+}
@@ -12,11 +12,12 @@ import (
 
 	"github.com/LerianStudio/lib-commons/v2/commons"
 	"github.com/LerianStudio/lib-commons/v2/commons/opentelemetry"
+	"github.com/google/uuid"
 	"go.opentelemetry.io/otel/attribute"
 )
 
-// GetAllReports fetch all Reports from the repository
-func (uc *UseCase) GetAllReports(ctx context.Context, filters http.QueryHeader) ([]*report.Report, error) {
+// GetAllReports fetch all Reports of the organization from the repository
+func (uc *UseCase) GetAllReports(ctx context.Context, filters http.QueryHeader, organizationID uuid.UUID) ([]*report.Report, error) {
 	logger, tracer, reqId, _ := commons.NewTrackingFromContext(ctx)
 
 	ctx, span := tracer.Start(ctx, "service.report.get_all")
@@ -24,6 +25,7 @@ func (uc *UseCase) GetAllReports(ctx context.Context, filters http.QueryHeader)
 
 	span.SetAttributes(
 		attribute.String("app.request.request_id", reqId),
+		attribute.String("app.request.organization_id", organizationID.String()),
 	)
 
 	err := opentelemetry.SetSpanAttributesFromStruct(&span, "app.request.payload", filters)
@@ -33,7 +35,7 @@ func (uc *UseCase) GetAllReports(ctx context.Context, filters http.QueryHeader)
 
 	logger.Infof("Retrieving reports")
 
-	reports, err := uc.ReportRepo.FindList(ctx, filters)
+	reports, err := uc.ReportRepo.FindList(ctx, filters, organizationID)
 	if err != nil {
 		opentelemetry.HandleSpanError(&span, "Failed to get all reports on repo", err)
 
@@ -71,7 +71,7 @@ func TestUseCase_GetAllReports(t *testing.T) {
 			mockSetup: func(ctrl *gomock.Controller) *UseCase {
 				mockReportRepo := report.NewMockRepository(ctrl)
 				mockReportRepo.EXPECT().
-					FindList(gomock.Any(), gomock.Any()).
+					FindList(gomock.Any(), gomock.Any(), gomock.Any()).
 					Return(mockReports, nil)
 				return &UseCase{ReportRepo: mockReportRepo}
 			},
@@ -86,7 +86,7 @@ func TestUseCase_GetAllReports(t *testing.T) {
 				mockReportRepo := report.NewMockRepository(ctrl)
 				filteredReports := []*report.Report{mockReports[0]} // Only finished reports
 				mockReportRepo.EXPECT().
-					FindList(gomock.Any(), gomock.Any()).
+					FindList(gomock.Any(), gomock.Any(), gomock.Any()).
 					Return(filteredReports, nil)
 				return &UseCase{ReportRepo: mockReportRepo}
 			},
@@ -100,7 +100,7 @@ func TestUseCase_GetAllReports(t *testing.T) {
 			mockSetup: func(ctrl *gomock.Controller) *UseCase {
 				mockReportRepo := report.NewMockRepository(ctrl)
 				mockReportRepo.EXPECT().
-					FindList(gomock.Any(), gomock.Any()).
+					FindList(gomock.Any(), gomock.Any(), gomock.Any()).
 					Return(nil, constant.ErrInternalServer)
 				return &UseCase{ReportRepo: mockReportRepo}
 			},
@@ -115,7 +115,7 @@ func TestUseCase_GetAllReports(t *testing.T) {
 			mockSetup: func(ctrl *gomock.Controller) *UseCase {
 				mockReportRepo := report.NewMockRepository(ctrl)
 				mockReportRepo.EXPECT().
-					FindList(gomock.Any(), gomock.Any()).
+					FindList(gomock.Any(), gomock.Any(), gomock.Any()).
 					Return([]*report.Report{}, nil)
 				return &UseCase{ReportRepo: mockReportRepo}
 			},
@@ -129,7 +129,7 @@ func TestUseCase_GetAllReports(t *testing.T) {
 			mockSetup: func(ctrl *gomock.Controller) *UseCase {
 				mockReportRepo := report.NewMockRepository(ctrl)
 				mockReportRepo.EXPECT().
-					FindList(gomock.Any(), gomock.Any()).
+					FindList(gomock.Any(), gomock.Any(), gomock.Any()).
 					Return(nil, nil)
 				return &UseCase{ReportRepo: mockReportRepo}
 			},
@@ -150,7 +150,7 @@ func TestUseCase_GetAllReports(t *testing.T) {
 			reportSvc := tt.mockSetup(ctrl)
 
 			ctx := context.Background()
-			result, err := reportSvc.GetAllReports(ctx, tt.filters)
+			result, err := reportSvc.GetAllReports(ctx, tt.filters, uuid.Nil)
 
 			if tt.expectErr {
 				require.Error(t, err)
@@ -12,11 +12,12 @@ import (
 
 	"github.com/LerianStudio/lib-commons/v2/commons"
 	"github.com/LerianStudio/lib-commons/v2/commons/opentelemetry"
+	"github.com/google/uuid"
 	"go.opentelemetry.io/otel/attribute"
 )
 
-// GetAllTemplates fetch all Templates from the repository
-func (uc *UseCase) GetAllTemplates(ctx context.Context, filters http.QueryHeader) ([]*template.Template, error) {
+// GetAllTemplates fetch all Templates of the organization from the repository
+func (uc *UseCase) GetAllTemplates(ctx context.Context, filters http.QueryHeader, organizationID uuid.UUID) ([]*template.Template, error) {
 	logger, tracer, reqId, _ := commons.NewTrackingFromContext(ctx)
 
 	ctx, span := tracer.Start(ctx, "service.template.get_all")
@@ -24,6 +25,7 @@ func (uc *UseCase) GetAllTemplates(ctx context.Context, filters http.QueryHeader
 
 	span.SetAttributes(
 		attribute.String("app.request.request_id", reqId),
+		attribute.String("app.request.organization_id", organizationID.String()),
 	)
 
 	err := opentelemetry.SetSpanAttributesFromStruct(&span, "app.request.payload", filters)
@@ -33,7 +35,7 @@ func (uc *UseCase) GetAllTemplates(ctx context.Context, filters http.QueryHeader
 
 	logger.Infof("Retrieving templates")
 
-	templates, errFind := uc.TemplateRepo.FindList(ctx, filters)
+	templates, errFind := uc.TemplateRepo.FindList(ctx, filters, organizationID)
 	if errFind != nil {
 		opentelemetry.HandleSpanError(&span, "Failed to get all templates on repo", errFind)
 
@@ -50,7 +50,7 @@ func TestUseCase_GetAllTemplates(t *testing.T) {
 			mockSetup: func(ctrl *gomock.Controller) *UseCase {
 				mockTempRepo := template.NewMockRepository(ctrl)
 				mockTempRepo.EXPECT().
-					FindList(gomock.Any(), filter).
+					FindList(gomock.Any(), filter, gomock.Any()).
 					Return(resultEntity, nil)
 				return &UseCase{TemplateRepo: mockTempRepo}
 			},
@@ -70,7 +70,7 @@ func TestUseCase_GetAllTemplates(t *testing.T) {
 			mockSetup: func(ctrl *gomock.Controller) *UseCase {
 				mockTempRepo := template.NewMockRepository(ctrl)
 				mockTempRepo.EXPECT().
-					FindList(gomock.Any(), filter).
+					FindList(gomock.Any(), filter, gomock.Any()).
 					Return(nil, constant.ErrBadRequest)
 				return &UseCase{TemplateRepo: mockTempRepo}
 			},
@@ -91,7 +91,7 @@ func TestUseCase_GetAllTemplates(t *testing.T) {
 			tempSvc := tt.mockSetup(ctrl)
 
 			ctx := context.Background()
-			result, err := tempSvc.GetAllTemplates(ctx, tt.filter)
+			result, err := tempSvc.GetAllTemplates(ctx, tt.filter, uuid.Nil)
 
 			if tt.expectErr {
 				require.Error(t, err)
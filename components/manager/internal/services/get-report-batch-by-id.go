@@ -0,0 +1,71 @@
+// Copyright (c) 2026 Lerian Studio. All rights reserved.
+// Use of this source code is governed by the Elastic License 2.0
+// that can be found in the LICENSE file.
+
+package services
+
+import (
+	"context"
+	"errors"
+
+	"github.com/LerianStudio/reporter/pkg"
+	"github.com/LerianStudio/reporter/pkg/constant"
+	"github.com/LerianStudio/reporter/pkg/mongodb/batch"
+
+	"github.com/LerianStudio/lib-commons/v2/commons"
+	"github.com/LerianStudio/lib-commons/v2/commons/opentelemetry"
+	"github.com/google/uuid"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// GetReportBatchByID recovers a report batch of the organization by ID, with the progress of its reports.
+func (uc *UseCase) GetReportBatchByID(ctx context.Context, id, organizationID uuid.UUID) (*batch.Batch, error) {
+	logger, tracer, reqId, _ := commons.NewTrackingFromContext(ctx)
+
+	ctx, span := tracer.Start(ctx, "service.report_batch.get_by_id")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("app.request.request_id", reqId),
+		attribute.String("app.request.batch_id", id.String()),
+		attribute.String("app.request.organization_id", organizationID.String()),
+	)
+
+	logger.Infof("Retrieving report batch for id %v.", id)
+
+	batchModel, err := uc.BatchRepo.FindByID(ctx, id, organizationID)
+	if err != nil {
+		logger.Errorf("Error getting report batch on repo by id: %v", err)
+
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			errNotFound := pkg.ValidateBusinessError(constant.ErrEntityNotFound, "", constant.MongoCollectionBatch)
+
+			opentelemetry.HandleSpanBusinessErrorEvent(&span, "Report batch not found", errNotFound)
+
+			return nil, errNotFound
+		}
+
+		opentelemetry.HandleSpanError(&span, "Failed to get report batch on repo by id", err)
+
+		return nil, err
+	}
+
+	counts, err := uc.ReportRepo.CountByBatch(ctx, id, organizationID)
+	if err != nil {
+		logger.Errorf("Error counting reports of report batch %s: %v", id, err)
+
+		opentelemetry.HandleSpanError(&span, "Failed to count reports of report batch", err)
+
+		return nil, err
+	}
+
+	batchModel.Progress = batch.NewProgress(counts)
+
+	// A batch that could not store all of its reports failed, whatever its stored reports became
+	if batchModel.FailedAt != nil {
+		batchModel.Progress.Status = constant.ErrorStatus
+	}
+
+	return batchModel, nil
+}
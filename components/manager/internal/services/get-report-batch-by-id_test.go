@@ -0,0 +1,126 @@
+// Copyright (c) 2026 Lerian Studio. All rights reserved.
+// Use of this source code is governed by the Elastic License 2.0
+// that can be found in the LICENSE file.
+
+package services
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/LerianStudio/reporter/pkg/constant"
+	"github.com/LerianStudio/reporter/pkg/mongodb/batch"
+	"github.com/LerianStudio/reporter/pkg/mongodb/report"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.uber.org/mock/gomock"
+)
+
+func TestUseCase_GetReportBatchByID(t *testing.T) {
+	t.Parallel()
+
+	batchID := uuid.New()
+	organizationID := uuid.New()
+
+	tests := []struct {
+		name             string
+		mockSetup        func(ctrl *gomock.Controller) *UseCase
+		errContains      string
+		expectedProgress batch.Progress
+	}{
+		{
+			name: "Success - aggregates the statuses of the batch reports",
+			mockSetup: func(ctrl *gomock.Controller) *UseCase {
+				mockBatchRepo := batch.NewMockRepository(ctrl)
+				mockReportRepo := report.NewMockRepository(ctrl)
+
+				mockBatchRepo.EXPECT().
+					FindByID(gomock.Any(), batchID, organizationID).
+					Return(&batch.Batch{ID: batchID, OrganizationID: organizationID}, nil)
+
+				mockReportRepo.EXPECT().
+					CountByBatch(gomock.Any(), batchID, organizationID).
+					Return(map[string]int{constant.ProcessingStatus: 2, constant.FinishedStatus: 7, constant.ErrorStatus: 1}, nil)
+
+				return &UseCase{BatchRepo: mockBatchRepo, ReportRepo: mockReportRepo}
+			},
+			expectedProgress: batch.Progress{Status: constant.ProcessingStatus, Total: 10, Processing: 2, Finished: 7, Failed: 1},
+		},
+		{
+			name: "Success - a batch that failed to store its reports is in error",
+			mockSetup: func(ctrl *gomock.Controller) *UseCase {
+				mockBatchRepo := batch.NewMockRepository(ctrl)
+				mockReportRepo := report.NewMockRepository(ctrl)
+
+				failedAt := time.Now()
+
+				mockBatchRepo.EXPECT().
+					FindByID(gomock.Any(), batchID, organizationID).
+					Return(&batch.Batch{ID: batchID, OrganizationID: organizationID, FailedAt: &failedAt}, nil)
+
+				mockReportRepo.EXPECT().
+					CountByBatch(gomock.Any(), batchID, organizationID).
+					Return(map[string]int{constant.CancelledStatus: 500}, nil)
+
+				return &UseCase{BatchRepo: mockBatchRepo, ReportRepo: mockReportRepo}
+			},
+			expectedProgress: batch.Progress{Status: constant.ErrorStatus, Total: 500, Cancelled: 500},
+		},
+		{
+			name: "Error - batch not found",
+			mockSetup: func(ctrl *gomock.Controller) *UseCase {
+				mockBatchRepo := batch.NewMockRepository(ctrl)
+
+				mockBatchRepo.EXPECT().
+					FindByID(gomock.Any(), batchID, organizationID).
+					Return(nil, mongo.ErrNoDocuments)
+
+				return &UseCase{BatchRepo: mockBatchRepo, ReportRepo: report.NewMockRepository(ctrl)}
+			},
+			errContains: "No report_batch entity was found",
+		},
+		{
+			name: "Error - counting reports fails",
+			mockSetup: func(ctrl *gomock.Controller) *UseCase {
+				mockBatchRepo := batch.NewMockRepository(ctrl)
+				mockReportRepo := report.NewMockRepository(ctrl)
+
+				mockBatchRepo.EXPECT().
+					FindByID(gomock.Any(), batchID, organizationID).
+					Return(&batch.Batch{ID: batchID}, nil)
+
+				mockReportRepo.EXPECT().
+					CountByBatch(gomock.Any(), batchID, organizationID).
+					Return(nil, constant.ErrInternalServer)
+
+				return &UseCase{BatchRepo: mockBatchRepo, ReportRepo: mockReportRepo}
+			},
+			errContains: constant.ErrInternalServer.Error(),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			uc := tt.mockSetup(ctrl)
+
+			result, err := uc.GetReportBatchByID(context.Background(), batchID, organizationID)
+			if tt.errContains != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.errContains)
+				assert.Nil(t, result)
+
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedProgress, result.Progress)
+		})
+	}
+}
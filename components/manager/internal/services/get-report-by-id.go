@@ -19,8 +19,8 @@ import (
 	"go.opentelemetry.io/otel/attribute"
 )
 
-// GetReportByID recover a report by ID
-func (uc *UseCase) GetReportByID(ctx context.Context, id uuid.UUID) (*report.Report, error) {
+// GetReportByID recover a report of the organization by ID
+func (uc *UseCase) GetReportByID(ctx context.Context, id, organizationID uuid.UUID) (*report.Report, error) {
 	logger, tracer, reqId, _ := commons.NewTrackingFromContext(ctx)
 
 	ctx, span := tracer.Start(ctx, "service.report.get_by_id")
@@ -29,11 +29,12 @@ func (uc *UseCase) GetReportByID(ctx context.Context, id uuid.UUID) (*report.Rep
 	span.SetAttributes(
 		attribute.String("app.request.request_id", reqId),
 		attribute.String("app.request.report_id", id.String()),
+		attribute.String("app.request.organization_id", organizationID.String()),
 	)
 
 	logger.Infof("Retrieving report for id %v.", id)
 
-	reportModel, err := uc.ReportRepo.FindByID(ctx, id)
+	reportModel, err := uc.ReportRepo.FindByID(ctx, id, organizationID)
 	if err != nil {
 		logger.Errorf("Error getting report on repo by id: %v", err)
 
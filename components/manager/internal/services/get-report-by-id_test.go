@@ -52,7 +52,7 @@ func TestUseCase_GetReportByID(t *testing.T) {
 			mockSetup: func(ctrl *gomock.Controller) *UseCase {
 				mockReportRepo := report.NewMockRepository(ctrl)
 				mockReportRepo.EXPECT().
-					FindByID(gomock.Any(), gomock.Any()).
+					FindByID(gomock.Any(), gomock.Any(), gomock.Any()).
 					Return(reportModel, nil)
 				return &UseCase{ReportRepo: mockReportRepo}
 			},
@@ -74,7 +74,7 @@ func TestUseCase_GetReportByID(t *testing.T) {
 			mockSetup: func(ctrl *gomock.Controller) *UseCase {
 				mockReportRepo := report.NewMockRepository(ctrl)
 				mockReportRepo.EXPECT().
-					FindByID(gomock.Any(), gomock.Any()).
+					FindByID(gomock.Any(), gomock.Any(), gomock.Any()).
 					Return(nil, constant.ErrInternalServer)
 				return &UseCase{ReportRepo: mockReportRepo}
 			},
@@ -88,7 +88,7 @@ func TestUseCase_GetReportByID(t *testing.T) {
 			mockSetup: func(ctrl *gomock.Controller) *UseCase {
 				mockReportRepo := report.NewMockRepository(ctrl)
 				mockReportRepo.EXPECT().
-					FindByID(gomock.Any(), gomock.Any()).
+					FindByID(gomock.Any(), gomock.Any(), gomock.Any()).
 					Return(nil, mongo.ErrNoDocuments)
 				return &UseCase{ReportRepo: mockReportRepo}
 			},
@@ -109,7 +109,7 @@ func TestUseCase_GetReportByID(t *testing.T) {
 			reportSvc := tt.mockSetup(ctrl)
 
 			ctx := context.Background()
-			result, err := reportSvc.GetReportByID(ctx, tt.reportId)
+			result, err := reportSvc.GetReportByID(ctx, tt.reportId, uuid.Nil)
 
 			if tt.expectErr {
 				require.Error(t, err)
@@ -19,8 +19,8 @@ import (
 	"go.opentelemetry.io/otel/attribute"
 )
 
-// GetTemplateByID recover a package by ID
-func (uc *UseCase) GetTemplateByID(ctx context.Context, id uuid.UUID) (*template.Template, error) {
+// GetTemplateByID recover a template of the organization by ID
+func (uc *UseCase) GetTemplateByID(ctx context.Context, id, organizationID uuid.UUID) (*template.Template, error) {
 	logger, tracer, reqId, _ := commons.NewTrackingFromContext(ctx)
 
 	ctx, span := tracer.Start(ctx, "service.template.get_by_id")
@@ -29,11 +29,12 @@ func (uc *UseCase) GetTemplateByID(ctx context.Context, id uuid.UUID) (*template
 	span.SetAttributes(
 		attribute.String("app.request.request_id", reqId),
 		attribute.String("app.request.template_id", id.String()),
+		attribute.String("app.request.organization_id", organizationID.String()),
 	)
 
 	logger.Infof("Retrieving template for id %v.", id)
 
-	templateModel, err := uc.TemplateRepo.FindByID(ctx, id)
+	templateModel, err := uc.TemplateRepo.FindByID(ctx, id, organizationID)
 	if err != nil {
 		logger.Errorf("Error getting template on repo by id: %v", err)
 
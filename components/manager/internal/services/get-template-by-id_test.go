@@ -47,7 +47,7 @@ func TestUseCase_GetTemplateByID(t *testing.T) {
 			mockSetup: func(ctrl *gomock.Controller) *UseCase {
 				mockTempRepo := template.NewMockRepository(ctrl)
 				mockTempRepo.EXPECT().
-					FindByID(gomock.Any(), gomock.Any()).
+					FindByID(gomock.Any(), gomock.Any(), gomock.Any()).
 					Return(templateEntity, nil)
 				return &UseCase{TemplateRepo: mockTempRepo}
 			},
@@ -66,7 +66,7 @@ func TestUseCase_GetTemplateByID(t *testing.T) {
 			mockSetup: func(ctrl *gomock.Controller) *UseCase {
 				mockTempRepo := template.NewMockRepository(ctrl)
 				mockTempRepo.EXPECT().
-					FindByID(gomock.Any(), gomock.Any()).
+					FindByID(gomock.Any(), gomock.Any(), gomock.Any()).
 					Return(nil, constant.ErrInternalServer)
 				return &UseCase{TemplateRepo: mockTempRepo}
 			},
@@ -80,7 +80,7 @@ func TestUseCase_GetTemplateByID(t *testing.T) {
 			mockSetup: func(ctrl *gomock.Controller) *UseCase {
 				mockTempRepo := template.NewMockRepository(ctrl)
 				mockTempRepo.EXPECT().
-					FindByID(gomock.Any(), gomock.Any()).
+					FindByID(gomock.Any(), gomock.Any(), gomock.Any()).
 					Return(nil, mongo.ErrNoDocuments)
 				return &UseCase{TemplateRepo: mockTempRepo}
 			},
@@ -101,7 +101,7 @@ func TestUseCase_GetTemplateByID(t *testing.T) {
 			tempSvc := tt.mockSetup(ctrl)
 
 			ctx := context.Background()
-			result, err := tempSvc.GetTemplateByID(ctx, tt.tempId)
+			result, err := tempSvc.GetTemplateByID(ctx, tt.tempId, uuid.Nil)
 
 			if tt.expectErr {
 				require.Error(t, err)
@@ -0,0 +1,278 @@
+// Copyright (c) 2026 Lerian Studio. All rights reserved.
+// Use of this source code is governed by the Elastic License 2.0
+// that can be found in the LICENSE file.
+
+package services
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/LerianStudio/reporter/pkg"
+	"github.com/LerianStudio/reporter/pkg/constant"
+	"github.com/LerianStudio/reporter/pkg/model"
+	"github.com/LerianStudio/reporter/pkg/mongodb/report"
+	templateUtils "github.com/LerianStudio/reporter/pkg/templateutils"
+
+	"github.com/LerianStudio/lib-commons/v2/commons"
+	libOpentelemetry "github.com/LerianStudio/lib-commons/v2/commons/opentelemetry"
+	"github.com/google/uuid"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// PurgeExpiredReports deletes the reports of every organization kept for longer than their retention, up to
+// constant.MaxPurgedReports per call. The retention of a report is the one of its template when set, then the
+// one of its tenant, then the default of the retention policy; reports under legal hold are never purged.
+//
+// Each report is soft-deleted first, atomically with the legal hold check and recording its file and detached
+// signature as pending, and the files are deleted from the storage afterwards. A report whose files could not be
+// deleted is counted as failed; its pending files are retried by the next purges, before any expired report.
+func (uc *UseCase) PurgeExpiredReports(ctx context.Context) (*model.PurgeReportsResult, error) {
+	logger, tracer, reqId, _ := commons.NewTrackingFromContext(ctx)
+
+	ctx, span := tracer.Start(ctx, "service.report.purge_expired")
+	defer span.End()
+
+	span.SetAttributes(attribute.String("app.request.request_id", reqId))
+
+	templateDays, err := uc.TemplateRepo.FindRetentionDays(ctx)
+	if err != nil {
+		libOpentelemetry.HandleSpanError(&span, "Failed to find template retention days", err)
+
+		logger.Errorf("Failed to find template retention days: %v", err)
+
+		return nil, err
+	}
+
+	result := &model.PurgeReportsResult{}
+	budget := constant.MaxPurgedReports
+
+	pending, err := uc.ReportRepo.FindPendingFiles(ctx, int64(budget))
+	if err != nil {
+		libOpentelemetry.HandleSpanError(&span, "Failed to find reports with pending files", err)
+
+		logger.Errorf("Failed to find reports with pending files: %v", err)
+
+		return nil, err
+	}
+
+	budget -= len(pending)
+
+	for _, reportModel := range pending {
+		if err := uc.deleteReportFiles(ctx, reportModel, reportModel.PendingFiles); err != nil {
+			libOpentelemetry.HandleSpanError(&span, "Failed to delete pending files of deleted report", err)
+
+			logger.Errorf("Failed to delete pending files of deleted report %s: %v", reportModel.ID, err)
+
+			result.Failed++
+
+			continue
+		}
+
+		result.Purged++
+	}
+
+	for _, query := range retentionQueries(uc.RetentionPolicy, templateDays, time.Now()) {
+		if budget <= 0 {
+			break
+		}
+
+		query.Limit = int64(budget)
+
+		reports, err := uc.ReportRepo.FindExpired(ctx, query)
+		if err != nil {
+			libOpentelemetry.HandleSpanError(&span, "Failed to find expired reports", err)
+
+			logger.Errorf("Failed to find expired reports: %v", err)
+
+			return nil, err
+		}
+
+		budget -= len(reports)
+
+		for _, reportModel := range reports {
+			purged, errPurge := uc.purgeReport(ctx, reportModel, &span)
+
+			switch {
+			case errPurge != nil:
+				result.Failed++
+			case purged:
+				result.Purged++
+			}
+		}
+	}
+
+	if result.Purged > 0 || result.Failed > 0 {
+		logger.Infof("Purged %d expired reports, %d failed", result.Purged, result.Failed)
+	}
+
+	span.SetAttributes(
+		attribute.Int("app.response.purged", result.Purged),
+		attribute.Int("app.response.failed", result.Failed),
+	)
+
+	return result, nil
+}
+
+// retentionQueries builds the queries of the reports past their retention at now: one per retention days of
+// the templates, one per tenant and one for the default, each leaving out the reports of a more specific rule.
+func retentionQueries(policy *model.RetentionPolicy, templateDays map[uuid.UUID]int, now time.Time) []report.RetentionQuery {
+	cutoff := func(days int) time.Time {
+		return now.AddDate(0, 0, -days)
+	}
+
+	templatesByDays := make(map[int][]uuid.UUID)
+	templateIDs := make([]uuid.UUID, 0, len(templateDays))
+
+	for templateID, days := range templateDays {
+		templatesByDays[days] = append(templatesByDays[days], templateID)
+		templateIDs = append(templateIDs, templateID)
+	}
+
+	slices.SortFunc(templateIDs, compareUUID)
+
+	queries := make([]report.RetentionQuery, 0, len(templatesByDays)+1)
+
+	for _, days := range slices.Sorted(maps.Keys(templatesByDays)) {
+		ids := templatesByDays[days]
+		slices.SortFunc(ids, compareUUID)
+
+		queries = append(queries, report.RetentionQuery{
+			TemplateIDs:     ids,
+			CompletedBefore: cutoff(days),
+		})
+	}
+
+	if policy == nil {
+		return queries
+	}
+
+	tenantIDs := slices.SortedFunc(maps.Keys(policy.TenantDays), compareUUID)
+
+	for _, organizationID := range tenantIDs {
+		days := policy.TenantDays[organizationID]
+		if days <= 0 {
+			continue
+		}
+
+		queries = append(queries, report.RetentionQuery{
+			OrganizationIDs:    []uuid.UUID{organizationID},
+			ExcludeTemplateIDs: templateIDs,
+			CompletedBefore:    cutoff(days),
+		})
+	}
+
+	if policy.DefaultDays > 0 {
+		queries = append(queries, report.RetentionQuery{
+			ExcludeTemplateIDs:     templateIDs,
+			ExcludeOrganizationIDs: tenantIDs,
+			CompletedBefore:        cutoff(policy.DefaultDays),
+		})
+	}
+
+	return queries
+}
+
+// purgeReport soft-deletes an expired report and deletes its files from the storage.
+// It reports false when the report was placed under legal hold or deleted in the meantime.
+func (uc *UseCase) purgeReport(ctx context.Context, reportModel *report.Report, span *trace.Span) (bool, error) {
+	logger, _, _, _ := commons.NewTrackingFromContext(ctx) //nolint:dogsled // only logger needed from tracking context
+
+	objectNames, err := uc.reportObjectNames(ctx, reportModel)
+	if err != nil {
+		libOpentelemetry.HandleSpanError(span, "Failed to resolve report files", err)
+
+		logger.Errorf("Failed to resolve files of expired report %s: %v", reportModel.ID, err)
+
+		return false, err
+	}
+
+	deleted, err := uc.ReportRepo.SoftDelete(ctx, reportModel.ID, reportModel.OrganizationID, time.Now(), objectNames)
+	if err != nil {
+		libOpentelemetry.HandleSpanError(span, "Failed to soft delete expired report", err)
+
+		logger.Errorf("Failed to soft delete expired report %s: %v", reportModel.ID, err)
+
+		return false, err
+	}
+
+	if !deleted {
+		return false, nil
+	}
+
+	if err := uc.deleteReportFiles(ctx, reportModel, objectNames); err != nil {
+		libOpentelemetry.HandleSpanError(span, "Failed to delete files of expired report", err)
+
+		logger.Errorf("Expired report %s was deleted but its files are pending deletion: %v", reportModel.ID, err)
+
+		return false, err
+	}
+
+	logger.Infof("Expired report %s purged", reportModel.ID)
+
+	return true, nil
+}
+
+// reportObjectNames returns the storage object names of the file and detached signature of a report.
+// Only Finished reports have files. The output format is the one the report was queued with, falling
+// back to the one of its template for reports queued before it was recorded.
+func (uc *UseCase) reportObjectNames(ctx context.Context, reportModel *report.Report) ([]string, error) {
+	if reportModel.Status != constant.FinishedStatus {
+		return nil, nil
+	}
+
+	outputFormat := ""
+	if reportModel.Message != nil {
+		outputFormat = reportModel.Message.OutputFormat
+	}
+
+	if outputFormat == "" {
+		templateOutputFormat, err := uc.TemplateRepo.FindOutputFormatByID(ctx, reportModel.TemplateID, reportModel.OrganizationID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to find output format of template %s: %w", reportModel.TemplateID, err)
+		}
+
+		outputFormat = *templateOutputFormat
+	}
+
+	objectName := pkg.TenantObjectName(reportModel.OrganizationID,
+		reportModel.TemplateID.String()+"/"+reportModel.ID.String()+"."+templateUtils.GetFileExtension(outputFormat))
+
+	objectNames := []string{objectName}
+
+	if signature, _ := reportModel.Metadata[constant.ReportSignatureMetadataKey].(string); signature == constant.SignatureCMS {
+		objectNames = append(objectNames, objectName+"."+constant.SignatureFileExtension)
+	}
+
+	return objectNames, nil
+}
+
+// deleteReportFiles deletes the given files of a deleted report from the storage and then clears them from
+// its pending files. Deleting a missing file succeeds, so pending files can be retried safely.
+func (uc *UseCase) deleteReportFiles(ctx context.Context, reportModel *report.Report, objectNames []string) error {
+	if len(objectNames) == 0 {
+		return nil
+	}
+
+	for _, objectName := range objectNames {
+		if err := uc.ReportSeaweedFS.Delete(ctx, objectName); err != nil {
+			return fmt.Errorf("failed to delete %s: %w", objectName, err)
+		}
+	}
+
+	if err := uc.ReportRepo.ClearPendingFiles(ctx, reportModel.ID, reportModel.OrganizationID); err != nil {
+		return fmt.Errorf("failed to clear pending files: %w", err)
+	}
+
+	return nil
+}
+
+// compareUUID orders UUIDs by their string form, keeping the retention queries deterministic.
+func compareUUID(a, b uuid.UUID) int {
+	return strings.Compare(a.String(), b.String())
+}
@@ -0,0 +1,255 @@
+// Copyright (c) 2026 Lerian Studio. All rights reserved.
+// Use of this source code is governed by the Elastic License 2.0
+// that can be found in the LICENSE file.
+
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/LerianStudio/reporter/pkg"
+	"github.com/LerianStudio/reporter/pkg/constant"
+	"github.com/LerianStudio/reporter/pkg/model"
+	"github.com/LerianStudio/reporter/pkg/mongodb/report"
+	"github.com/LerianStudio/reporter/pkg/mongodb/template"
+	reportSeaweedFS "github.com/LerianStudio/reporter/pkg/seaweedfs/report"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestRetentionQueries(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2026, 6, 30, 12, 0, 0, 0, time.UTC)
+	templateA := uuid.MustParse("00000000-0000-0000-0000-00000000000a")
+	templateB := uuid.MustParse("00000000-0000-0000-0000-00000000000b")
+	tenantA := uuid.MustParse("10000000-0000-0000-0000-000000000000")
+	tenantForever := uuid.MustParse("20000000-0000-0000-0000-000000000000")
+
+	t.Run("template, tenant and default rules", func(t *testing.T) {
+		t.Parallel()
+
+		policy := &model.RetentionPolicy{
+			DefaultDays: 90,
+			TenantDays:  map[uuid.UUID]int{tenantA: 30, tenantForever: 0},
+		}
+
+		queries := retentionQueries(policy, map[uuid.UUID]int{templateA: 7, templateB: 7}, now)
+
+		require.Len(t, queries, 3)
+
+		assert.Equal(t, report.RetentionQuery{
+			TemplateIDs:     []uuid.UUID{templateA, templateB},
+			CompletedBefore: now.AddDate(0, 0, -7),
+		}, queries[0])
+
+		assert.Equal(t, report.RetentionQuery{
+			OrganizationIDs:    []uuid.UUID{tenantA},
+			ExcludeTemplateIDs: []uuid.UUID{templateA, templateB},
+			CompletedBefore:    now.AddDate(0, 0, -30),
+		}, queries[1])
+
+		assert.Equal(t, report.RetentionQuery{
+			ExcludeTemplateIDs:     []uuid.UUID{templateA, templateB},
+			ExcludeOrganizationIDs: []uuid.UUID{tenantA, tenantForever},
+			CompletedBefore:        now.AddDate(0, 0, -90),
+		}, queries[2], "tenants keeping their reports forever are left out of the default")
+	})
+
+	t.Run("no policy keeps only the template rules", func(t *testing.T) {
+		t.Parallel()
+
+		queries := retentionQueries(nil, map[uuid.UUID]int{templateA: 7}, now)
+
+		require.Len(t, queries, 1)
+		assert.Equal(t, []uuid.UUID{templateA}, queries[0].TemplateIDs)
+	})
+
+	t.Run("nothing to purge", func(t *testing.T) {
+		t.Parallel()
+
+		assert.Empty(t, retentionQueries(&model.RetentionPolicy{}, nil, now))
+	})
+}
+
+func TestUseCase_PurgeExpiredReports(t *testing.T) {
+	t.Parallel()
+
+	orgId := uuid.New()
+	tempId := uuid.New()
+
+	expired := func(status string) *report.Report {
+		reportId := uuid.New()
+
+		return &report.Report{
+			ID:             reportId,
+			TemplateID:     tempId,
+			OrganizationID: orgId,
+			Status:         status,
+			Message:        &model.ReportMessage{TemplateID: tempId, ReportID: reportId, OutputFormat: "csv"},
+		}
+	}
+
+	objectName := func(r *report.Report) string {
+		return pkg.TenantObjectName(orgId, tempId.String()+"/"+r.ID.String()+".csv")
+	}
+
+	t.Run("purges expired reports and counts failures", func(t *testing.T) {
+		t.Parallel()
+
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockReportRepo := report.NewMockRepository(ctrl)
+		mockTempRepo := template.NewMockRepository(ctrl)
+		mockStorage := reportSeaweedFS.NewMockRepository(ctrl)
+
+		finished := expired(constant.FinishedStatus)
+		failed := expired(constant.ErrorStatus)
+		held := expired(constant.FinishedStatus)
+		broken := expired(constant.FinishedStatus)
+
+		mockTempRepo.EXPECT().FindRetentionDays(gomock.Any()).Return(map[uuid.UUID]int{}, nil)
+		mockReportRepo.EXPECT().FindPendingFiles(gomock.Any(), int64(constant.MaxPurgedReports)).Return(nil, nil)
+
+		mockReportRepo.EXPECT().
+			FindExpired(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, query report.RetentionQuery) ([]*report.Report, error) {
+				assert.Equal(t, int64(constant.MaxPurgedReports), query.Limit)
+				assert.WithinDuration(t, time.Now().AddDate(0, 0, -30), query.CompletedBefore, time.Minute)
+
+				return []*report.Report{finished, failed, held, broken}, nil
+			})
+
+		mockReportRepo.EXPECT().SoftDelete(gomock.Any(), finished.ID, orgId, gomock.Any(), []string{objectName(finished)}).Return(true, nil)
+		mockStorage.EXPECT().Delete(gomock.Any(), objectName(finished)).Return(nil)
+		mockReportRepo.EXPECT().ClearPendingFiles(gomock.Any(), finished.ID, orgId).Return(nil)
+
+		mockReportRepo.EXPECT().SoftDelete(gomock.Any(), failed.ID, orgId, gomock.Any(), gomock.Nil()).Return(true, nil)
+
+		// Placed under legal hold after it was found: left untouched
+		mockReportRepo.EXPECT().SoftDelete(gomock.Any(), held.ID, orgId, gomock.Any(), gomock.Any()).Return(false, nil)
+
+		// Its files stay pending and are retried by the next purge
+		mockReportRepo.EXPECT().SoftDelete(gomock.Any(), broken.ID, orgId, gomock.Any(), []string{objectName(broken)}).Return(true, nil)
+		mockStorage.EXPECT().Delete(gomock.Any(), objectName(broken)).Return(errors.New("storage unavailable"))
+
+		reportSvc := &UseCase{
+			ReportRepo:      mockReportRepo,
+			TemplateRepo:    mockTempRepo,
+			ReportSeaweedFS: mockStorage,
+			RetentionPolicy: &model.RetentionPolicy{DefaultDays: 30},
+		}
+
+		result, err := reportSvc.PurgeExpiredReports(context.Background())
+		require.NoError(t, err)
+		assert.Equal(t, model.PurgeReportsResult{Purged: 2, Failed: 1}, *result)
+	})
+
+	t.Run("retries the pending files of deleted reports first", func(t *testing.T) {
+		t.Parallel()
+
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockReportRepo := report.NewMockRepository(ctrl)
+		mockTempRepo := template.NewMockRepository(ctrl)
+		mockStorage := reportSeaweedFS.NewMockRepository(ctrl)
+
+		retried := expired(constant.FinishedStatus)
+		retried.PendingFiles = []string{objectName(retried), objectName(retried) + ".p7s"}
+
+		stillBroken := expired(constant.FinishedStatus)
+		stillBroken.PendingFiles = []string{objectName(stillBroken)}
+
+		mockTempRepo.EXPECT().FindRetentionDays(gomock.Any()).Return(map[uuid.UUID]int{}, nil)
+		mockReportRepo.EXPECT().FindPendingFiles(gomock.Any(), int64(constant.MaxPurgedReports)).Return([]*report.Report{retried, stillBroken}, nil)
+
+		mockStorage.EXPECT().Delete(gomock.Any(), objectName(retried)).Return(nil)
+		mockStorage.EXPECT().Delete(gomock.Any(), objectName(retried)+".p7s").Return(nil)
+		mockReportRepo.EXPECT().ClearPendingFiles(gomock.Any(), retried.ID, orgId).Return(nil)
+
+		mockStorage.EXPECT().Delete(gomock.Any(), objectName(stillBroken)).Return(errors.New("storage unavailable"))
+
+		mockReportRepo.EXPECT().
+			FindExpired(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, query report.RetentionQuery) ([]*report.Report, error) {
+				assert.Equal(t, int64(constant.MaxPurgedReports-2), query.Limit)
+
+				return nil, nil
+			})
+
+		reportSvc := &UseCase{
+			ReportRepo:      mockReportRepo,
+			TemplateRepo:    mockTempRepo,
+			ReportSeaweedFS: mockStorage,
+			RetentionPolicy: &model.RetentionPolicy{DefaultDays: 30},
+		}
+
+		result, err := reportSvc.PurgeExpiredReports(context.Background())
+		require.NoError(t, err)
+		assert.Equal(t, model.PurgeReportsResult{Purged: 1, Failed: 1}, *result)
+	})
+
+	t.Run("stops once the sweep budget is spent", func(t *testing.T) {
+		t.Parallel()
+
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockReportRepo := report.NewMockRepository(ctrl)
+		mockTempRepo := template.NewMockRepository(ctrl)
+
+		reports := make([]*report.Report, constant.MaxPurgedReports)
+		for i := range reports {
+			reports[i] = expired(constant.CancelledStatus)
+		}
+
+		mockTempRepo.EXPECT().FindRetentionDays(gomock.Any()).Return(map[uuid.UUID]int{tempId: 7}, nil)
+		mockReportRepo.EXPECT().FindPendingFiles(gomock.Any(), gomock.Any()).Return(nil, nil)
+
+		// Only the template rule is queried; the default rule has no budget left
+		mockReportRepo.EXPECT().FindExpired(gomock.Any(), gomock.Any()).Return(reports, nil).Times(1)
+		mockReportRepo.EXPECT().SoftDelete(gomock.Any(), gomock.Any(), orgId, gomock.Any(), gomock.Any()).Return(true, nil).Times(constant.MaxPurgedReports)
+
+		reportSvc := &UseCase{
+			ReportRepo:      mockReportRepo,
+			TemplateRepo:    mockTempRepo,
+			RetentionPolicy: &model.RetentionPolicy{DefaultDays: 30},
+		}
+
+		result, err := reportSvc.PurgeExpiredReports(context.Background())
+		require.NoError(t, err)
+		assert.Equal(t, constant.MaxPurgedReports, result.Purged)
+	})
+
+	t.Run("fails when the expired reports can not be found", func(t *testing.T) {
+		t.Parallel()
+
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockReportRepo := report.NewMockRepository(ctrl)
+		mockTempRepo := template.NewMockRepository(ctrl)
+
+		mockTempRepo.EXPECT().FindRetentionDays(gomock.Any()).Return(nil, nil)
+		mockReportRepo.EXPECT().FindPendingFiles(gomock.Any(), gomock.Any()).Return(nil, nil)
+		mockReportRepo.EXPECT().FindExpired(gomock.Any(), gomock.Any()).Return(nil, errors.New("database unavailable"))
+
+		reportSvc := &UseCase{
+			ReportRepo:      mockReportRepo,
+			TemplateRepo:    mockTempRepo,
+			RetentionPolicy: &model.RetentionPolicy{DefaultDays: 30},
+		}
+
+		result, err := reportSvc.PurgeExpiredReports(context.Background())
+		require.Error(t, err)
+		assert.Nil(t, result)
+	})
+}
@@ -0,0 +1,271 @@
+// Copyright (c) 2026 Lerian Studio. All rights reserved.
+// Use of this source code is governed by the Elastic License 2.0
+// that can be found in the LICENSE file.
+
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/LerianStudio/reporter/pkg/constant"
+	"github.com/LerianStudio/reporter/pkg/model"
+	"github.com/LerianStudio/reporter/pkg/mongodb/report"
+
+	"github.com/LerianStudio/lib-commons/v2/commons"
+	libOpentelemetry "github.com/LerianStudio/lib-commons/v2/commons/opentelemetry"
+	"github.com/google/uuid"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const (
+	// reapTimedOutReason is the error recorded on a report whose generation never completed.
+	reapTimedOutReason = "Report generation timed out"
+	// reapDeadLetteredReason is the error recorded on a report whose message was routed to the dead letter queue.
+	reapDeadLetteredReason = "Report generation failed and its message was dead-lettered"
+)
+
+// processingOnly is the source status of every transition made by the reaper.
+var processingOnly = []string{constant.ProcessingStatus}
+
+// ReapStuckReports reconciles the reports of every organization left in Processing whose worker heartbeat,
+// or last update when no worker picked them up, is older than timeout.
+//
+// Each report is decided on its own. A report whose message is in the dead letter queue is marked as Error.
+// A report a worker picked up and stopped reporting on was abandoned by that worker. A report no worker
+// picked up may still be waiting in the generation queue of its priority: it is left alone while that queue
+// holds messages and, since without the queue inspector that can not be ruled out, it is never requeued
+// without one. An abandoned or lost report is requeued once with its stored message, and marked as Error
+// with a timeout when it is found stuck again.
+func (uc *UseCase) ReapStuckReports(ctx context.Context, timeout time.Duration) (*model.ReapReportsResult, error) {
+	logger, tracer, reqId, _ := commons.NewTrackingFromContext(ctx)
+
+	ctx, span := tracer.Start(ctx, "service.report.reap_stuck")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("app.request.request_id", reqId),
+		attribute.String("app.request.processing_timeout", timeout.String()),
+	)
+
+	reports, err := uc.ReportRepo.FindByStatus(ctx, report.StatusQuery{
+		AllOrganizations: true,
+		Statuses:         processingOnly,
+		StaleBefore:      time.Now().Add(-timeout),
+		Limit:            constant.MaxReapedReports,
+	})
+	if err != nil {
+		libOpentelemetry.HandleSpanError(&span, "Failed to find reports left in Processing", err)
+
+		logger.Errorf("Failed to find reports left in Processing: %v", err)
+
+		return nil, err
+	}
+
+	result := &model.ReapReportsResult{}
+
+	if len(reports) == 0 {
+		return result, nil
+	}
+
+	deadLettered, err := uc.deadLetteredReports(ctx)
+	if err != nil {
+		libOpentelemetry.HandleSpanError(&span, "Failed to inspect the dead letter queue", err)
+
+		logger.Errorf("Failed to inspect the dead letter queue: %v", err)
+
+		return nil, err
+	}
+
+	logger.Infof("Reconciling %d reports left in Processing for more than %v", len(reports), timeout)
+
+	tally := func(counter *int, done bool, err error) {
+		if err != nil {
+			result.Failed++
+
+			return
+		}
+
+		if done {
+			*counter++
+		}
+	}
+
+	queueDepths := make(map[string]int)
+
+	for _, reportModel := range reports {
+		if deadLettered[reportModel.ID] {
+			marked, errMark := uc.markReportStuck(ctx, reportModel, reapDeadLetteredReason, &span)
+			tally(&result.DeadLettered, marked, errMark)
+
+			continue
+		}
+
+		if reportModel.HeartbeatAt == nil {
+			queued, errQueued := uc.mayBeQueued(ctx, reportModel, queueDepths)
+			if errQueued != nil {
+				libOpentelemetry.HandleSpanError(&span, "Failed to inspect report queues", errQueued)
+
+				logger.Errorf("Failed to inspect report queues: %v", errQueued)
+
+				return nil, errQueued
+			}
+
+			if queued {
+				result.Deferred++
+
+				continue
+			}
+		}
+
+		if reportModel.Message == nil || requeuedByReaper(reportModel) {
+			marked, errMark := uc.markReportStuck(ctx, reportModel, reapTimedOutReason, &span)
+			tally(&result.TimedOut, marked, errMark)
+
+			continue
+		}
+
+		requeued, errRequeue := uc.requeueStuckReport(ctx, reportModel, &span)
+		tally(&result.Requeued, requeued, errRequeue)
+	}
+
+	if result.Deferred > 0 {
+		logger.Infof("%d reports no worker picked up may still be queued; checking again later", result.Deferred)
+	}
+
+	return result, nil
+}
+
+// deadLetteredReports returns the reports whose message is in the dead letter queue. Without a queue
+// inspector or a dead letter queue none are found.
+func (uc *UseCase) deadLetteredReports(ctx context.Context) (map[uuid.UUID]bool, error) {
+	logger, _, _, _ := commons.NewTrackingFromContext(ctx) //nolint:dogsled // only logger needed from tracking context
+
+	deadLettered := make(map[uuid.UUID]bool)
+
+	if uc.QueueInspector == nil || uc.RabbitMQDLQQueue == "" {
+		return deadLettered, nil
+	}
+
+	bodies, err := uc.QueueInspector.PeekMessages(ctx, uc.RabbitMQDLQQueue, constant.MaxReapedReports)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, body := range bodies {
+		var message model.ReportMessage
+		if errUnmarshal := json.Unmarshal(body, &message); errUnmarshal != nil {
+			logger.Warnf("Skipping dead-lettered message that is not a report message: %v", errUnmarshal)
+
+			continue
+		}
+
+		deadLettered[message.ReportID] = true
+	}
+
+	return deadLettered, nil
+}
+
+// mayBeQueued reports whether the message of a report no worker picked up may still be waiting in the
+// generation queue of its priority, i.e. that queue holds messages, ready or being processed. Without a
+// queue inspector this can not be ruled out. The depth of each queue is read once per sweep into depths.
+func (uc *UseCase) mayBeQueued(ctx context.Context, reportModel *report.Report, depths map[string]int) (bool, error) {
+	if uc.QueueInspector == nil {
+		return true, nil
+	}
+
+	var priority string
+	if reportModel.Message != nil {
+		priority = reportModel.Message.Priority
+	}
+
+	queue := uc.RabbitMQLanes.Queue(priority, uc.RabbitMQGenerateReportQueue)
+
+	depth, ok := depths[queue]
+	if !ok {
+		var err error
+
+		depth, err = uc.QueueInspector.QueueDepth(ctx, queue)
+		if err != nil {
+			return false, err
+		}
+
+		depths[queue] = depth
+	}
+
+	return depth > 0, nil
+}
+
+// requeuedByReaper reports whether the reaper already requeued the report. An attempt recorded while the
+// report was still in Processing can only come from the reaper.
+func requeuedByReaper(reportModel *report.Report) bool {
+	for _, attempt := range reportModel.Attempts {
+		if attempt.Status == constant.ProcessingStatus {
+			return true
+		}
+	}
+
+	return false
+}
+
+// requeueStuckReport records the stuck attempt and republishes the stored message of the report.
+// It reports false when the report left Processing in the meantime.
+func (uc *UseCase) requeueStuckReport(ctx context.Context, reportModel *report.Report, span *trace.Span) (bool, error) {
+	logger, _, _, _ := commons.NewTrackingFromContext(ctx) //nolint:dogsled // only logger needed from tracking context
+
+	_, err := uc.ReportRepo.ResetForRetry(ctx, reportModel.ID, reportModel.OrganizationID, processingOnly, reportModel.Message, time.Now())
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return false, nil
+		}
+
+		libOpentelemetry.HandleSpanError(span, "Failed to reset stuck report", err)
+
+		logger.Errorf("Failed to reset stuck Report with ID %s: %v", reportModel.ID, err)
+
+		return false, err
+	}
+
+	if err := uc.SendReportQueueReports(ctx, *reportModel.Message); err != nil {
+		libOpentelemetry.HandleSpanError(span, "Failed to send report to queue", err)
+
+		logger.Errorf("Error sending stuck report %s to queue: %v", reportModel.ID, err)
+
+		_, _ = uc.markReportStuck(ctx, reportModel, "Failed to send report to queue", span)
+
+		return false, err
+	}
+
+	logger.Infof("Stuck report %s requeued", reportModel.ID)
+
+	return true, nil
+}
+
+// markReportStuck moves a report still in Processing to Error with the given reason.
+// It reports false when the report left Processing in the meantime.
+func (uc *UseCase) markReportStuck(ctx context.Context, reportModel *report.Report, reason string, span *trace.Span) (bool, error) {
+	logger, _, _, _ := commons.NewTrackingFromContext(ctx) //nolint:dogsled // only logger needed from tracking context
+
+	metadata := map[string]any{
+		"error": reason,
+	}
+
+	marked, err := uc.ReportRepo.TransitionReportStatus(ctx, reportModel.ID, processingOnly, constant.ErrorStatus, time.Now(), metadata)
+	if err != nil {
+		libOpentelemetry.HandleSpanError(span, "Failed to update report status to error", err)
+
+		logger.Errorf("Error updating stuck report %s status to error: %v", reportModel.ID, err)
+
+		return false, err
+	}
+
+	if marked {
+		logger.Warnf("Stuck report %s marked as Error: %s", reportModel.ID, reason)
+	}
+
+	return marked, nil
+}
@@ -0,0 +1,248 @@
+// Copyright (c) 2026 Lerian Studio. All rights reserved.
+// Use of this source code is governed by the Elastic License 2.0
+// that can be found in the LICENSE file.
+
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/LerianStudio/reporter/pkg/constant"
+	"github.com/LerianStudio/reporter/pkg/model"
+	"github.com/LerianStudio/reporter/pkg/mongodb/report"
+	"github.com/LerianStudio/reporter/pkg/rabbitmq"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.uber.org/mock/gomock"
+)
+
+func TestUseCase_ReapStuckReports(t *testing.T) {
+	t.Parallel()
+
+	const (
+		generateQueue = "reporter.generate-report.queue"
+		lowQueue      = "reporter.generate-report.low.queue"
+		dlqQueue      = "reporter.dlq"
+	)
+
+	orgId := uuid.New()
+	timeNow := time.Now()
+
+	stuckReport := func(requeued, pickedUp bool, priority string) *report.Report {
+		reportId := uuid.New()
+
+		stuck := &report.Report{
+			ID:             reportId,
+			TemplateID:     uuid.New(),
+			OrganizationID: orgId,
+			Status:         constant.ProcessingStatus,
+			CreatedAt:      timeNow.Add(-time.Hour),
+			UpdatedAt:      timeNow.Add(-time.Hour),
+			Message:        &model.ReportMessage{ReportID: reportId, OrganizationID: orgId, OutputFormat: "csv", Priority: priority},
+		}
+
+		if requeued {
+			stuck.Attempts = []report.ReportAttempt{{Status: constant.ProcessingStatus, RetriedAt: timeNow.Add(-2 * time.Hour)}}
+		}
+
+		if pickedUp {
+			heartbeatAt := timeNow.Add(-45 * time.Minute)
+			stuck.HeartbeatAt = &heartbeatAt
+		}
+
+		return stuck
+	}
+
+	// abandoned was picked up by a worker that stopped reporting; lost and lostLow were never picked up
+	abandoned := stuckReport(false, true, "")
+	requeued := stuckReport(true, true, "")
+	lost := stuckReport(false, false, "")
+	lostLow := stuckReport(false, false, constant.ReportPriorityLow)
+	deadLettered := stuckReport(false, false, "")
+
+	deadLetteredBody, err := json.Marshal(deadLettered.Message)
+	require.NoError(t, err)
+
+	stuckQuery := gomock.Cond(func(x any) bool {
+		query, ok := x.(report.StatusQuery)
+
+		return ok && query.AllOrganizations && len(query.Statuses) == 1 && query.Statuses[0] == constant.ProcessingStatus &&
+			query.StaleBefore.Before(timeNow.Add(-29*time.Minute)) && query.UpdatedBefore.IsZero() && query.Limit == constant.MaxReapedReports
+	})
+
+	errorWith := func(reason string) map[string]any {
+		return map[string]any{"error": reason}
+	}
+
+	expectRequeue := func(reportRepo *report.MockRepository, producer *rabbitmq.MockProducerRepository, stuck *report.Report) {
+		reportRepo.EXPECT().
+			ResetForRetry(gomock.Any(), stuck.ID, orgId, processingOnly, stuck.Message, gomock.Any()).
+			Return(stuck, nil)
+		producer.EXPECT().
+			ProducerDefault(gomock.Any(), gomock.Any(), gomock.Any(), *stuck.Message).
+			Return(nil, nil)
+	}
+
+	tests := []struct {
+		name        string
+		inspector   bool
+		mockSetup   func(reportRepo *report.MockRepository, inspector *rabbitmq.MockQueueInspector, producer *rabbitmq.MockProducerRepository)
+		want        model.ReapReportsResult
+		errContains string
+	}{
+		{
+			name:      "Success - No report left in Processing",
+			inspector: true,
+			mockSetup: func(reportRepo *report.MockRepository, _ *rabbitmq.MockQueueInspector, _ *rabbitmq.MockProducerRepository) {
+				reportRepo.EXPECT().FindByStatus(gomock.Any(), stuckQuery).Return([]*report.Report{}, nil)
+			},
+		},
+		{
+			name:      "Success - Each report decided against the queue of its priority",
+			inspector: true,
+			mockSetup: func(reportRepo *report.MockRepository, inspector *rabbitmq.MockQueueInspector, producer *rabbitmq.MockProducerRepository) {
+				reportRepo.EXPECT().FindByStatus(gomock.Any(), stuckQuery).Return([]*report.Report{lost, lostLow, deadLettered}, nil)
+				inspector.EXPECT().
+					PeekMessages(gomock.Any(), dlqQueue, constant.MaxReapedReports).
+					Return([][]byte{[]byte("not a report message"), deadLetteredBody}, nil)
+				inspector.EXPECT().QueueDepth(gomock.Any(), generateQueue).Return(3, nil)
+				inspector.EXPECT().QueueDepth(gomock.Any(), lowQueue).Return(0, nil)
+
+				expectRequeue(reportRepo, producer, lostLow)
+
+				// The dead-lettered report fails although the generation queue holds messages
+				reportRepo.EXPECT().
+					TransitionReportStatus(gomock.Any(), deadLettered.ID, processingOnly, constant.ErrorStatus, gomock.Any(), errorWith(reapDeadLetteredReason)).
+					Return(true, nil)
+			},
+			want: model.ReapReportsResult{Requeued: 1, DeadLettered: 1, Deferred: 1},
+		},
+		{
+			name:      "Success - Abandoned report requeued and requeued report timed out whatever the queue depth",
+			inspector: true,
+			mockSetup: func(reportRepo *report.MockRepository, inspector *rabbitmq.MockQueueInspector, producer *rabbitmq.MockProducerRepository) {
+				reportRepo.EXPECT().FindByStatus(gomock.Any(), stuckQuery).Return([]*report.Report{abandoned, requeued}, nil)
+				inspector.EXPECT().PeekMessages(gomock.Any(), dlqQueue, constant.MaxReapedReports).Return(nil, nil)
+
+				expectRequeue(reportRepo, producer, abandoned)
+
+				reportRepo.EXPECT().
+					TransitionReportStatus(gomock.Any(), requeued.ID, processingOnly, constant.ErrorStatus, gomock.Any(), errorWith(reapTimedOutReason)).
+					Return(true, nil)
+			},
+			want: model.ReapReportsResult{Requeued: 1, TimedOut: 1},
+		},
+		{
+			name: "Success - Without a queue inspector only abandoned reports are requeued",
+			mockSetup: func(reportRepo *report.MockRepository, _ *rabbitmq.MockQueueInspector, producer *rabbitmq.MockProducerRepository) {
+				reportRepo.EXPECT().FindByStatus(gomock.Any(), stuckQuery).Return([]*report.Report{lost, abandoned}, nil)
+
+				expectRequeue(reportRepo, producer, abandoned)
+			},
+			want: model.ReapReportsResult{Requeued: 1, Deferred: 1},
+		},
+		{
+			name: "Success - Reports that left Processing in the meantime are not counted",
+			mockSetup: func(reportRepo *report.MockRepository, _ *rabbitmq.MockQueueInspector, _ *rabbitmq.MockProducerRepository) {
+				reportRepo.EXPECT().FindByStatus(gomock.Any(), stuckQuery).Return([]*report.Report{abandoned, requeued}, nil)
+				reportRepo.EXPECT().
+					ResetForRetry(gomock.Any(), abandoned.ID, orgId, processingOnly, abandoned.Message, gomock.Any()).
+					Return(nil, mongo.ErrNoDocuments)
+				reportRepo.EXPECT().
+					TransitionReportStatus(gomock.Any(), requeued.ID, processingOnly, constant.ErrorStatus, gomock.Any(), gomock.Any()).
+					Return(false, nil)
+			},
+		},
+		{
+			name: "Success - Report that can not be queued is marked as Error and counted as failed",
+			mockSetup: func(reportRepo *report.MockRepository, _ *rabbitmq.MockQueueInspector, producer *rabbitmq.MockProducerRepository) {
+				reportRepo.EXPECT().FindByStatus(gomock.Any(), stuckQuery).Return([]*report.Report{abandoned}, nil)
+				reportRepo.EXPECT().
+					ResetForRetry(gomock.Any(), abandoned.ID, orgId, processingOnly, abandoned.Message, gomock.Any()).
+					Return(abandoned, nil)
+				producer.EXPECT().
+					ProducerDefault(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(nil, errors.New("channel closed"))
+				reportRepo.EXPECT().
+					TransitionReportStatus(gomock.Any(), abandoned.ID, processingOnly, constant.ErrorStatus, gomock.Any(), errorWith("Failed to send report to queue")).
+					Return(true, nil)
+			},
+			want: model.ReapReportsResult{Failed: 1},
+		},
+		{
+			name:      "Error - Dead letter queue inspection failure",
+			inspector: true,
+			mockSetup: func(reportRepo *report.MockRepository, inspector *rabbitmq.MockQueueInspector, _ *rabbitmq.MockProducerRepository) {
+				reportRepo.EXPECT().FindByStatus(gomock.Any(), stuckQuery).Return([]*report.Report{abandoned}, nil)
+				inspector.EXPECT().PeekMessages(gomock.Any(), dlqQueue, constant.MaxReapedReports).Return(nil, errors.New("management api unavailable"))
+			},
+			errContains: "management api unavailable",
+		},
+		{
+			name:      "Error - Queue inspection failure",
+			inspector: true,
+			mockSetup: func(reportRepo *report.MockRepository, inspector *rabbitmq.MockQueueInspector, _ *rabbitmq.MockProducerRepository) {
+				reportRepo.EXPECT().FindByStatus(gomock.Any(), stuckQuery).Return([]*report.Report{lost}, nil)
+				inspector.EXPECT().PeekMessages(gomock.Any(), dlqQueue, constant.MaxReapedReports).Return(nil, nil)
+				inspector.EXPECT().QueueDepth(gomock.Any(), generateQueue).Return(0, errors.New("management api unavailable"))
+			},
+			errContains: "management api unavailable",
+		},
+		{
+			name: "Error - Database failure",
+			mockSetup: func(reportRepo *report.MockRepository, _ *rabbitmq.MockQueueInspector, _ *rabbitmq.MockProducerRepository) {
+				reportRepo.EXPECT().FindByStatus(gomock.Any(), gomock.Any()).Return(nil, errors.New("connection refused"))
+			},
+			errContains: "connection refused",
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockReportRepo := report.NewMockRepository(ctrl)
+			mockInspector := rabbitmq.NewMockQueueInspector(ctrl)
+			mockRabbitMQ := rabbitmq.NewMockProducerRepository(ctrl)
+
+			tt.mockSetup(mockReportRepo, mockInspector, mockRabbitMQ)
+
+			reportSvc := &UseCase{
+				ReportRepo:                  mockReportRepo,
+				RabbitMQRepo:                mockRabbitMQ,
+				RabbitMQGenerateReportQueue: generateQueue,
+				RabbitMQDLQQueue:            dlqQueue,
+				RabbitMQLanes: rabbitmq.GenerationLanes{
+					constant.ReportPriorityLow: {Queue: lowQueue, RoutingKey: "reporter.generate-report.low.key"},
+				},
+			}
+
+			if tt.inspector {
+				reportSvc.QueueInspector = mockInspector
+			}
+
+			result, err := reportSvc.ReapStuckReports(context.Background(), 30*time.Minute)
+			if tt.errContains != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.errContains)
+				assert.Nil(t, result)
+
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, *result)
+		})
+	}
+}
@@ -0,0 +1,291 @@
+// Copyright (c) 2026 Lerian Studio. All rights reserved.
+// Use of this source code is governed by the Elastic License 2.0
+// that can be found in the LICENSE file.
+
+package services
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"time"
+
+	"github.com/LerianStudio/reporter/pkg"
+	"github.com/LerianStudio/reporter/pkg/constant"
+	"github.com/LerianStudio/reporter/pkg/model"
+	"github.com/LerianStudio/reporter/pkg/mongodb/outbox"
+	"github.com/LerianStudio/reporter/pkg/mongodb/report"
+	pkgHTTP "github.com/LerianStudio/reporter/pkg/net/http"
+
+	"github.com/LerianStudio/lib-commons/v2/commons"
+	libOpentelemetry "github.com/LerianStudio/lib-commons/v2/commons/opentelemetry"
+	"github.com/google/uuid"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// retryableStatuses are the statuses of the reports that can be retried.
+var retryableStatuses = []string{constant.ErrorStatus, constant.CancelledStatus}
+
+// RetryReport resets a report of the organization in Error or Cancelled back to Processing and requeues it
+// under the same ID. The previous attempt is kept in the report attempts. The report is republished with the
+// message it was created with or, when refreshTemplate is set, with the current revision of its template.
+func (uc *UseCase) RetryReport(ctx context.Context, id, organizationID uuid.UUID, refreshTemplate bool) (*report.Report, error) {
+	logger, tracer, reqId, _ := commons.NewTrackingFromContext(ctx)
+
+	ctx, span := tracer.Start(ctx, "service.report.retry")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("app.request.request_id", reqId),
+		attribute.String("app.request.report_id", id.String()),
+		attribute.String("app.request.organization_id", organizationID.String()),
+		attribute.Bool("app.request.refresh_template", refreshTemplate),
+	)
+
+	logger.Infof("Retrying report for id %v", id)
+
+	reportModel, err := uc.GetReportByID(ctx, id, organizationID)
+	if err != nil {
+		if pkgHTTP.IsBusinessError(err) {
+			libOpentelemetry.HandleSpanBusinessErrorEvent(&span, "Failed to retrieve report on query", err)
+		} else {
+			libOpentelemetry.HandleSpanError(&span, "Failed to retrieve report on query", err)
+		}
+
+		logger.Errorf("Failed to retrieve Report with ID: %s, Error: %s", id, err.Error())
+
+		return nil, err
+	}
+
+	return uc.retryReport(ctx, reportModel, refreshTemplate, &span)
+}
+
+// RetryReports requeues the reports in Error of the organization selected by the input, at most
+// constant.MaxBulkRetryReports per request. A report that can not be requeued does not stop the others
+// and is listed in the output with the reason.
+func (uc *UseCase) RetryReports(ctx context.Context, organizationID uuid.UUID, input *model.RetryReportsInput) (*model.RetryReportsOutput, error) {
+	logger, tracer, reqId, _ := commons.NewTrackingFromContext(ctx)
+
+	ctx, span := tracer.Start(ctx, "service.report.retry_bulk")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("app.request.request_id", reqId),
+		attribute.String("app.request.organization_id", organizationID.String()),
+	)
+
+	err := libOpentelemetry.SetSpanAttributesFromStruct(&span, "app.request.payload", input)
+	if err != nil {
+		libOpentelemetry.HandleSpanError(&span, "Failed to convert payload to JSON string", err)
+	}
+
+	query, err := retryStatusQuery(organizationID, input)
+	if err != nil {
+		libOpentelemetry.HandleSpanBusinessErrorEvent(&span, "Invalid bulk retry selection", err)
+
+		return nil, err
+	}
+
+	reports, err := uc.ReportRepo.FindByStatus(ctx, query)
+	if err != nil {
+		libOpentelemetry.HandleSpanError(&span, "Failed to find reports to retry", err)
+
+		logger.Errorf("Failed to find reports to retry: %v", err)
+
+		return nil, err
+	}
+
+	logger.Infof("Retrying %d failed reports", len(reports))
+
+	output := &model.RetryReportsOutput{
+		Retried: make([]uuid.UUID, 0, len(reports)),
+		Failed:  make([]model.RetryReportFailure, 0),
+	}
+
+	for _, reportModel := range reports {
+		if _, errRetry := uc.retryReport(ctx, reportModel, input.RefreshTemplate, &span); errRetry != nil {
+			output.Failed = append(output.Failed, model.RetryReportFailure{ReportID: reportModel.ID, Error: errRetry.Error()})
+
+			continue
+		}
+
+		output.Retried = append(output.Retried, reportModel.ID)
+	}
+
+	return output, nil
+}
+
+// retryStatusQuery builds the selection of the reports in Error of a bulk retry, requiring a template,
+// a complete time window or both.
+func retryStatusQuery(organizationID uuid.UUID, input *model.RetryReportsInput) (report.StatusQuery, error) {
+	query := report.StatusQuery{
+		OrganizationID: organizationID,
+		Statuses:       []string{constant.ErrorStatus},
+		Limit:          constant.MaxBulkRetryReports,
+	}
+
+	if input.TemplateID != "" {
+		templateID, err := uuid.Parse(input.TemplateID)
+		if err != nil {
+			return query, pkg.ValidateBusinessError(constant.ErrInvalidTemplateID, "")
+		}
+
+		query.TemplateID = templateID
+	}
+
+	if (input.CreatedFrom == nil) != (input.CreatedTo == nil) {
+		return query, pkg.ValidateBusinessError(constant.ErrInvalidRetrySelection, "", "createdFrom and createdTo must be set together")
+	}
+
+	if input.CreatedFrom != nil {
+		if !input.CreatedFrom.Before(*input.CreatedTo) {
+			return query, pkg.ValidateBusinessError(constant.ErrInvalidRetrySelection, "", "createdFrom must be before createdTo")
+		}
+
+		query.CreatedFrom = *input.CreatedFrom
+		query.CreatedTo = *input.CreatedTo
+	}
+
+	if query.TemplateID == uuid.Nil && query.CreatedFrom.IsZero() {
+		return query, pkg.ValidateBusinessError(constant.ErrInvalidRetrySelection, "", "no template or time window selected")
+	}
+
+	return query, nil
+}
+
+// retryReport resets a report for a new attempt and requeues it through the outbox.
+func (uc *UseCase) retryReport(ctx context.Context, reportModel *report.Report, refreshTemplate bool, span *trace.Span) (*report.Report, error) {
+	logger, _, _, _ := commons.NewTrackingFromContext(ctx) //nolint:dogsled // only logger needed from tracking context
+
+	if !slices.Contains(retryableStatuses, reportModel.Status) {
+		errStatus := pkg.ValidateBusinessError(constant.ErrReportNotRetryable, "", reportModel.Status)
+
+		libOpentelemetry.HandleSpanBusinessErrorEvent(span, "Report is not retryable", errStatus)
+
+		logger.Errorf("Report with ID %s is %s and can not be retried", reportModel.ID, reportModel.Status)
+
+		return nil, errStatus
+	}
+
+	message := reportModel.Message
+
+	// Reports created before their message was stored are always republished with the current template
+	if refreshTemplate || message == nil {
+		refreshed, err := uc.refreshReportMessage(ctx, reportModel, span)
+		if err != nil {
+			return nil, err
+		}
+
+		message = refreshed
+	}
+
+	// Messages stored before they were signed are signed when republished
+	if message.RowLevelSignature == "" {
+		if err := uc.signRowLevelScope(message); err != nil {
+			libOpentelemetry.HandleSpanError(span, "Failed to sign row-level scope", err)
+
+			return nil, err
+		}
+	}
+
+	// The report is reset and the outbox entry of its message written together, so a report is never back in
+	// Processing without the message that generates it. The entry is claimed for this request, which publishes it
+	now := time.Now()
+	entry := outbox.NewEntry(*message, uc.RabbitMQExchange, uc.generateReportKey(message.Priority), now, constant.OutboxClaimLease)
+
+	retried, err := uc.ReportRepo.ResetForRetryWithOutbox(ctx, reportModel.ID, reportModel.OrganizationID, retryableStatuses, message, now, entry)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			current, errGet := uc.GetReportByID(ctx, reportModel.ID, reportModel.OrganizationID)
+			if errGet != nil {
+				libOpentelemetry.HandleSpanError(span, "Failed to retrieve report on query", errGet)
+
+				return nil, errGet
+			}
+
+			errStatus := pkg.ValidateBusinessError(constant.ErrReportNotRetryable, "", current.Status)
+
+			libOpentelemetry.HandleSpanBusinessErrorEvent(span, "Report changed before it could be retried", errStatus)
+
+			logger.Errorf("Report with ID %s became %s before it could be retried", reportModel.ID, current.Status)
+
+			return nil, errStatus
+		}
+
+		libOpentelemetry.HandleSpanError(span, "Failed to reset report for retry", err)
+
+		logger.Errorf("Failed to reset Report with ID %s for retry: %v", reportModel.ID, err)
+
+		return nil, err
+	}
+
+	// A failed publish is retried by the outbox relay; the report stays in Processing until it is published
+	if err := uc.dispatchOutboxEntry(ctx, entry); err != nil {
+		logger.Warnf("Report %s will be sent to queue by the outbox relay: %v", reportModel.ID, err)
+	}
+
+	logger.Infof("Report %s requeued for attempt %d", reportModel.ID, len(retried.Attempts)+1)
+
+	return retried, nil
+}
+
+// refreshReportMessage rebuilds the message of a report from the current revision of its template, keeping the
+// report filters, locale, timezone, priority and row-level scope. The row-level predicates are applied again, so tables
+// added to the template get theirs.
+func (uc *UseCase) refreshReportMessage(ctx context.Context, reportModel *report.Report, span *trace.Span) (*model.ReportMessage, error) {
+	logger, _, _, _ := commons.NewTrackingFromContext(ctx) //nolint:dogsled // only logger needed from tracking context
+
+	tOutputFormat, tMappedFields, tOutputOptions, tPriority, err := uc.TemplateRepo.FindMappedFieldsAndOutputFormatByID(ctx, reportModel.TemplateID, reportModel.OrganizationID)
+	if err != nil {
+		logger.Errorf("Error to find template by id, Error: %v", err)
+
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			errNotFound := pkg.ValidateBusinessError(constant.ErrEntityNotFound, "", constant.MongoCollectionTemplate)
+
+			libOpentelemetry.HandleSpanBusinessErrorEvent(span, "Template not found", errNotFound)
+
+			return nil, errNotFound
+		}
+
+		libOpentelemetry.HandleSpanError(span, "Failed to find template by ID", err)
+
+		return nil, err
+	}
+
+	message := &model.ReportMessage{
+		TemplateID:     reportModel.TemplateID,
+		ReportID:       reportModel.ID,
+		OutputFormat:   *tOutputFormat,
+		MappedFields:   tMappedFields,
+		OrganizationID: reportModel.OrganizationID,
+		OutputOptions:  tOutputOptions,
+	}
+
+	if reportModel.Message != nil {
+		message.RowLevelScope = reportModel.Message.RowLevelScope
+		message.Locale = reportModel.Message.Locale
+		message.Timezone = reportModel.Message.Timezone
+		message.Priority = reportModel.Message.Priority
+		message.ReuseOutput = reportModel.Message.ReuseOutput
+	} else {
+		message.RowLevelScope = uc.resolveRowLevelScope(ctx)
+		message.Priority = reportPriority("", tPriority)
+	}
+
+	message.Filters, err = uc.RowLevelPolicy.Apply(message.RowLevelScope, tMappedFields, reportModel.Filters)
+	if err != nil {
+		libOpentelemetry.HandleSpanBusinessErrorEvent(span, "Failed to apply row-level filters", err)
+
+		return nil, err
+	}
+
+	if err := uc.signRowLevelScope(message); err != nil {
+		libOpentelemetry.HandleSpanError(span, "Failed to sign row-level scope", err)
+
+		return nil, err
+	}
+
+	return message, nil
+}
@@ -0,0 +1,360 @@
+// Copyright (c) 2026 Lerian Studio. All rights reserved.
+// Use of this source code is governed by the Elastic License 2.0
+// that can be found in the LICENSE file.
+
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/LerianStudio/reporter/pkg/constant"
+	"github.com/LerianStudio/reporter/pkg/model"
+	"github.com/LerianStudio/reporter/pkg/mongodb/outbox"
+	"github.com/LerianStudio/reporter/pkg/mongodb/report"
+	"github.com/LerianStudio/reporter/pkg/mongodb/template"
+	"github.com/LerianStudio/reporter/pkg/rabbitmq"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.uber.org/mock/gomock"
+)
+
+func TestUseCase_RetryReport(t *testing.T) {
+	t.Parallel()
+
+	reportId := uuid.New()
+	tempId := uuid.New()
+	orgId := uuid.New()
+	timeNow := time.Now()
+
+	storedMessage := &model.ReportMessage{
+		TemplateID:     tempId,
+		ReportID:       reportId,
+		OutputFormat:   "csv",
+		MappedFields:   map[string]map[string][]string{"onboarding": {"organization": {"name"}}},
+		OrganizationID: orgId,
+		Locale:         "pt-BR",
+	}
+
+	reportWith := func(status string, message *model.ReportMessage) *report.Report {
+		return &report.Report{
+			ID:             reportId,
+			TemplateID:     tempId,
+			OrganizationID: orgId,
+			Status:         status,
+			Metadata:       map[string]any{"error": "connection refused"},
+			CompletedAt:    &timeNow,
+			CreatedAt:      timeNow,
+			UpdatedAt:      timeNow,
+			Message:        message,
+		}
+	}
+
+	retried := &report.Report{
+		ID:             reportId,
+		TemplateID:     tempId,
+		OrganizationID: orgId,
+		Status:         constant.ProcessingStatus,
+		Attempts: []report.ReportAttempt{
+			{Status: constant.ErrorStatus, Metadata: map[string]any{"error": "connection refused"}, CompletedAt: &timeNow, RetriedAt: timeNow},
+		},
+	}
+
+	refreshedFormat := "xml"
+	refreshedFields := map[string]map[string][]string{"onboarding": {"organization": {"name", "legal_name"}}}
+
+	tests := []struct {
+		name            string
+		refreshTemplate bool
+		mockSetup       func(reportRepo *report.MockRepository, tempRepo *template.MockRepository, producer *rabbitmq.MockProducerRepository, outboxRepo *outbox.MockRepository)
+		errContains     string
+	}{
+		{
+			name: "Success - Requeue with the stored message",
+			mockSetup: func(reportRepo *report.MockRepository, _ *template.MockRepository, producer *rabbitmq.MockProducerRepository, outboxRepo *outbox.MockRepository) {
+				reportRepo.EXPECT().
+					FindByID(gomock.Any(), reportId, orgId).
+					Return(reportWith(constant.ErrorStatus, storedMessage), nil)
+
+				var stored *outbox.Entry
+
+				reportRepo.EXPECT().
+					ResetForRetryWithOutbox(gomock.Any(), reportId, orgId, retryableStatuses, storedMessage, gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, _, _ uuid.UUID, _ []string, _ *model.ReportMessage, _ time.Time, entry *outbox.Entry) (*report.Report, error) {
+						// The entry is claimed by the retry, so the relay does not publish it concurrently
+						assert.NotNil(t, entry.LockedUntil)
+						assert.Equal(t, *storedMessage, entry.Message)
+
+						stored = entry
+
+						return retried, nil
+					})
+
+				producer.EXPECT().
+					ProducerDefault(gomock.Any(), gomock.Any(), gomock.Any(), *storedMessage).
+					Return(nil, nil)
+
+				outboxRepo.EXPECT().
+					MarkDispatched(gomock.Any(), gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, id uuid.UUID, _ time.Time) error {
+						assert.Equal(t, stored.ID, id)
+
+						return nil
+					})
+			},
+		},
+		{
+			name:            "Success - Requeue cancelled report with the current template revision",
+			refreshTemplate: true,
+			mockSetup: func(reportRepo *report.MockRepository, tempRepo *template.MockRepository, producer *rabbitmq.MockProducerRepository, outboxRepo *outbox.MockRepository) {
+				reportRepo.EXPECT().
+					FindByID(gomock.Any(), reportId, orgId).
+					Return(reportWith(constant.CancelledStatus, storedMessage), nil)
+
+				tempRepo.EXPECT().
+					FindMappedFieldsAndOutputFormatByID(gomock.Any(), tempId, orgId).
+					Return(&refreshedFormat, refreshedFields, nil, "", nil)
+
+				refreshed := gomock.Cond(func(x any) bool {
+					message, ok := x.(*model.ReportMessage)
+
+					return ok && message.OutputFormat == refreshedFormat && message.Locale == "pt-BR" && len(message.MappedFields["onboarding"]["organization"]) == 2
+				})
+
+				reportRepo.EXPECT().
+					ResetForRetryWithOutbox(gomock.Any(), reportId, orgId, retryableStatuses, refreshed, gomock.Any(), gomock.Any()).
+					Return(retried, nil)
+
+				producer.EXPECT().
+					ProducerDefault(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(nil, nil)
+
+				outboxRepo.EXPECT().
+					MarkDispatched(gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(nil)
+			},
+		},
+		{
+			name: "Success - Report without a stored message uses the current template revision",
+			mockSetup: func(reportRepo *report.MockRepository, tempRepo *template.MockRepository, producer *rabbitmq.MockProducerRepository, outboxRepo *outbox.MockRepository) {
+				reportRepo.EXPECT().
+					FindByID(gomock.Any(), reportId, orgId).
+					Return(reportWith(constant.ErrorStatus, nil), nil)
+
+				tempRepo.EXPECT().
+					FindMappedFieldsAndOutputFormatByID(gomock.Any(), tempId, orgId).
+					Return(&refreshedFormat, refreshedFields, nil, "", nil)
+
+				reportRepo.EXPECT().
+					ResetForRetryWithOutbox(gomock.Any(), reportId, orgId, retryableStatuses, gomock.Not(gomock.Nil()), gomock.Any(), gomock.Any()).
+					Return(retried, nil)
+
+				producer.EXPECT().
+					ProducerDefault(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(nil, nil)
+
+				outboxRepo.EXPECT().
+					MarkDispatched(gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(nil)
+			},
+		},
+		{
+			name: "Error - Report not found",
+			mockSetup: func(reportRepo *report.MockRepository, _ *template.MockRepository, _ *rabbitmq.MockProducerRepository, _ *outbox.MockRepository) {
+				reportRepo.EXPECT().
+					FindByID(gomock.Any(), reportId, orgId).
+					Return(nil, mongo.ErrNoDocuments)
+			},
+			errContains: "No report entity was found",
+		},
+		{
+			name: "Error - Report still processing",
+			mockSetup: func(reportRepo *report.MockRepository, _ *template.MockRepository, _ *rabbitmq.MockProducerRepository, _ *outbox.MockRepository) {
+				reportRepo.EXPECT().
+					FindByID(gomock.Any(), reportId, orgId).
+					Return(reportWith(constant.ProcessingStatus, storedMessage), nil)
+			},
+			errContains: "Processing status and can not be retried",
+		},
+		{
+			name: "Error - Report retried concurrently",
+			mockSetup: func(reportRepo *report.MockRepository, _ *template.MockRepository, _ *rabbitmq.MockProducerRepository, _ *outbox.MockRepository) {
+				gomock.InOrder(
+					reportRepo.EXPECT().
+						FindByID(gomock.Any(), reportId, orgId).
+						Return(reportWith(constant.ErrorStatus, storedMessage), nil),
+					reportRepo.EXPECT().
+						ResetForRetryWithOutbox(gomock.Any(), reportId, orgId, retryableStatuses, storedMessage, gomock.Any(), gomock.Any()).
+						Return(nil, mongo.ErrNoDocuments),
+					reportRepo.EXPECT().
+						FindByID(gomock.Any(), reportId, orgId).
+						Return(reportWith(constant.ProcessingStatus, storedMessage), nil),
+				)
+			},
+			errContains: "Processing status and can not be retried",
+		},
+		{
+			name: "Success - Queue unavailable leaves the report to the outbox relay",
+			mockSetup: func(reportRepo *report.MockRepository, _ *template.MockRepository, producer *rabbitmq.MockProducerRepository, outboxRepo *outbox.MockRepository) {
+				reportRepo.EXPECT().
+					FindByID(gomock.Any(), reportId, orgId).
+					Return(reportWith(constant.ErrorStatus, storedMessage), nil)
+
+				reportRepo.EXPECT().
+					ResetForRetryWithOutbox(gomock.Any(), reportId, orgId, retryableStatuses, storedMessage, gomock.Any(), gomock.Any()).
+					Return(retried, nil)
+
+				producer.EXPECT().
+					ProducerDefault(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(nil, errors.New("channel closed"))
+
+				outboxRepo.EXPECT().
+					MarkFailed(gomock.Any(), gomock.Any(), "channel closed", gomock.Any()).
+					Return(nil)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockReportRepo := report.NewMockRepository(ctrl)
+			mockTempRepo := template.NewMockRepository(ctrl)
+			mockRabbitMQ := rabbitmq.NewMockProducerRepository(ctrl)
+			mockOutboxRepo := outbox.NewMockRepository(ctrl)
+
+			tt.mockSetup(mockReportRepo, mockTempRepo, mockRabbitMQ, mockOutboxRepo)
+
+			reportSvc := &UseCase{
+				ReportRepo:   mockReportRepo,
+				TemplateRepo: mockTempRepo,
+				RabbitMQRepo: mockRabbitMQ,
+				OutboxRepo:   mockOutboxRepo,
+			}
+
+			result, err := reportSvc.RetryReport(context.Background(), reportId, orgId, tt.refreshTemplate)
+			if tt.errContains != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.errContains)
+				assert.Nil(t, result)
+
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, constant.ProcessingStatus, result.Status)
+			assert.Len(t, result.Attempts, 1)
+		})
+	}
+}
+
+func TestUseCase_RetryReports(t *testing.T) {
+	t.Parallel()
+
+	tempId := uuid.New()
+	orgId := uuid.New()
+	from := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	to := from.Add(24 * time.Hour)
+
+	failedReport := func() *report.Report {
+		id := uuid.New()
+
+		return &report.Report{
+			ID:             id,
+			TemplateID:     tempId,
+			OrganizationID: orgId,
+			Status:         constant.ErrorStatus,
+			Message:        &model.ReportMessage{TemplateID: tempId, ReportID: id, OutputFormat: "csv", OrganizationID: orgId},
+		}
+	}
+
+	t.Run("Requeues the selected reports and lists the failures", func(t *testing.T) {
+		t.Parallel()
+
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockReportRepo := report.NewMockRepository(ctrl)
+		mockRabbitMQ := rabbitmq.NewMockProducerRepository(ctrl)
+
+		first, second := failedReport(), failedReport()
+
+		mockReportRepo.EXPECT().
+			FindByStatus(gomock.Any(), report.StatusQuery{
+				OrganizationID: orgId,
+				Statuses:       []string{constant.ErrorStatus},
+				TemplateID:     tempId,
+				CreatedFrom:    from,
+				CreatedTo:      to,
+				Limit:          constant.MaxBulkRetryReports,
+			}).
+			Return([]*report.Report{first, second}, nil)
+
+		mockReportRepo.EXPECT().
+			ResetForRetryWithOutbox(gomock.Any(), first.ID, orgId, retryableStatuses, first.Message, gomock.Any(), gomock.Any()).
+			Return(&report.Report{ID: first.ID, Status: constant.ProcessingStatus}, nil)
+
+		mockReportRepo.EXPECT().
+			ResetForRetryWithOutbox(gomock.Any(), second.ID, orgId, retryableStatuses, second.Message, gomock.Any(), gomock.Any()).
+			Return(nil, errors.New("database unavailable"))
+
+		mockRabbitMQ.EXPECT().
+			ProducerDefault(gomock.Any(), gomock.Any(), gomock.Any(), *first.Message).
+			Return(nil, nil)
+
+		reportSvc := &UseCase{
+			ReportRepo:   mockReportRepo,
+			RabbitMQRepo: mockRabbitMQ,
+			OutboxRepo:   newDispatchedOutboxRepo(ctrl),
+		}
+
+		output, err := reportSvc.RetryReports(context.Background(), orgId, &model.RetryReportsInput{
+			TemplateID:  tempId.String(),
+			CreatedFrom: &from,
+			CreatedTo:   &to,
+		})
+		require.NoError(t, err)
+		assert.Equal(t, []uuid.UUID{first.ID}, output.Retried)
+		require.Len(t, output.Failed, 1)
+		assert.Equal(t, second.ID, output.Failed[0].ReportID)
+		assert.Equal(t, "database unavailable", output.Failed[0].Error)
+	})
+
+	invalid := []struct {
+		name        string
+		input       *model.RetryReportsInput
+		errContains string
+	}{
+		{name: "Nothing selected", input: &model.RetryReportsInput{}, errContains: "no template or time window selected"},
+		{name: "Incomplete window", input: &model.RetryReportsInput{CreatedFrom: &from}, errContains: "must be set together"},
+		{name: "Inverted window", input: &model.RetryReportsInput{CreatedFrom: &to, CreatedTo: &from}, errContains: "createdFrom must be before createdTo"},
+		{name: "Invalid template ID", input: &model.RetryReportsInput{TemplateID: "not-a-uuid"}, errContains: constant.ErrInvalidTemplateID.Error()},
+	}
+
+	for _, tt := range invalid {
+		tt := tt
+		t.Run("Error - "+tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			reportSvc := &UseCase{ReportRepo: report.NewMockRepository(ctrl)}
+
+			output, err := reportSvc.RetryReports(context.Background(), orgId, tt.input)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.errContains)
+			assert.Nil(t, output)
+		})
+	}
+}
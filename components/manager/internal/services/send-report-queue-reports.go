@@ -15,6 +15,7 @@ import (
 )
 
 // SendReportQueueReports sends a report to the queue of a generation reports message to a RabbitMQ queue for further processing.
+// The message is routed to the lane of its priority.
 // It uses context for logger and tracer management and handles data serialization and queue message construction.
 func (uc *UseCase) SendReportQueueReports(ctx context.Context, reportMessage model.ReportMessage) error {
 	logger, tracer, reqId, _ := libCommons.NewTrackingFromContext(ctx)
@@ -34,7 +35,7 @@ func (uc *UseCase) SendReportQueueReports(ctx context.Context, reportMessage mod
 	if _, err := uc.RabbitMQRepo.ProducerDefault(
 		ctx,
 		uc.RabbitMQExchange,
-		uc.RabbitMQGenerateReportKey,
+		uc.generateReportKey(reportMessage.Priority),
 		reportMessage,
 	); err != nil {
 		libOpentelemetry.HandleSpanError(&span, "Failed to send message to queue", err)
@@ -5,7 +5,12 @@
 package services
 
 import (
+	"time"
+
 	"github.com/LerianStudio/reporter/pkg"
+	"github.com/LerianStudio/reporter/pkg/model"
+	"github.com/LerianStudio/reporter/pkg/mongodb/batch"
+	"github.com/LerianStudio/reporter/pkg/mongodb/outbox"
 	"github.com/LerianStudio/reporter/pkg/mongodb/report"
 	"github.com/LerianStudio/reporter/pkg/mongodb/template"
 	pkgRabbitmq "github.com/LerianStudio/reporter/pkg/rabbitmq"
@@ -25,6 +30,12 @@ type UseCase struct {
 	// ReportRepo provides an abstraction on top of the report data source.
 	ReportRepo report.Repository
 
+	// BatchRepo provides an abstraction on top of the report batch data source.
+	BatchRepo batch.Repository
+
+	// OutboxRepo provides an abstraction on top of the report outbox, holding the messages waiting to be published.
+	OutboxRepo outbox.Repository
+
 	// ReportSeaweed is a repository interface for storing report files in SeaweedFS.
 	ReportSeaweedFS reportSeaweedFS.Repository
 
@@ -42,4 +53,31 @@ type UseCase struct {
 
 	// RabbitMQGenerateReportKey is the routing key for report generation messages.
 	RabbitMQGenerateReportKey string
+
+	// RabbitMQLanes are the queues and routing keys of the report priorities with a lane of their own.
+	// Reports of other priorities are routed with RabbitMQGenerateReportKey.
+	RabbitMQLanes pkgRabbitmq.GenerationLanes
+
+	// RowLevelPolicy holds the per-datasource rules that scope report filters to the caller's auth claims.
+	RowLevelPolicy *pkg.RowLevelPolicy
+
+	// QueueInspector provides a read-only view of the broker queues. Nil when the management API is not configured.
+	QueueInspector pkgRabbitmq.QueueInspector
+
+	// RabbitMQGenerateReportQueue is the queue the workers consume report generation messages from.
+	RabbitMQGenerateReportQueue string
+
+	// RabbitMQDLQQueue is the dead letter queue of the report generation messages. Empty when not configured.
+	RabbitMQDLQQueue string
+
+	// DeadLetterRepo manages the messages of the dead letter queue. Nil when RabbitMQDLQQueue is not configured.
+	DeadLetterRepo pkgRabbitmq.DeadLetterRepository
+
+	// ReportDownloadURLExpiry is how long the presigned report download URLs are valid.
+	// Zero uses constant.DefaultReportDownloadURLExpiry.
+	ReportDownloadURLExpiry time.Duration
+
+	// RetentionPolicy sets how long the reports of each tenant are kept. Nil keeps the reports of the
+	// templates without retention days of their own forever.
+	RetentionPolicy *model.RetentionPolicy
 }
@@ -0,0 +1,59 @@
+// Copyright (c) 2026 Lerian Studio. All rights reserved.
+// Use of this source code is governed by the Elastic License 2.0
+// that can be found in the LICENSE file.
+
+package services
+
+import (
+	"context"
+	"errors"
+
+	"github.com/LerianStudio/reporter/pkg"
+	"github.com/LerianStudio/reporter/pkg/constant"
+	"github.com/LerianStudio/reporter/pkg/mongodb/report"
+
+	"github.com/LerianStudio/lib-commons/v2/commons"
+	libOpentelemetry "github.com/LerianStudio/lib-commons/v2/commons/opentelemetry"
+	"github.com/google/uuid"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// SetReportLegalHold places or releases the legal hold of a report of the organization. A report under legal
+// hold can not be deleted and is skipped by the retention purge until the hold is released.
+func (uc *UseCase) SetReportLegalHold(ctx context.Context, id, organizationID uuid.UUID, legalHold bool) (*report.Report, error) {
+	logger, tracer, reqId, _ := commons.NewTrackingFromContext(ctx)
+
+	ctx, span := tracer.Start(ctx, "service.report.set_legal_hold")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("app.request.request_id", reqId),
+		attribute.String("app.request.report_id", id.String()),
+		attribute.String("app.request.organization_id", organizationID.String()),
+		attribute.Bool("app.request.legal_hold", legalHold),
+	)
+
+	logger.Infof("Setting legal hold of report %v to %t", id, legalHold)
+
+	reportModel, err := uc.ReportRepo.SetLegalHold(ctx, id, organizationID, legalHold)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			errNotFound := pkg.ValidateBusinessError(constant.ErrEntityNotFound, "", constant.MongoCollectionReport)
+
+			libOpentelemetry.HandleSpanBusinessErrorEvent(&span, "Report not found", errNotFound)
+
+			return nil, errNotFound
+		}
+
+		libOpentelemetry.HandleSpanError(&span, "Failed to set report legal hold", err)
+
+		logger.Errorf("Failed to set legal hold of Report with ID: %s, Error: %s", id, err.Error())
+
+		return nil, err
+	}
+
+	logger.Infof("Legal hold of report %s set to %t", id, legalHold)
+
+	return reportModel, nil
+}
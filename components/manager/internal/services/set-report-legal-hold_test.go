@@ -0,0 +1,89 @@
+// Copyright (c) 2026 Lerian Studio. All rights reserved.
+// Use of this source code is governed by the Elastic License 2.0
+// that can be found in the LICENSE file.
+
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/LerianStudio/reporter/pkg/constant"
+	"github.com/LerianStudio/reporter/pkg/mongodb/report"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.uber.org/mock/gomock"
+)
+
+func TestUseCase_SetReportLegalHold(t *testing.T) {
+	t.Parallel()
+
+	reportId := uuid.New()
+	orgId := uuid.New()
+
+	tests := []struct {
+		name        string
+		legalHold   bool
+		repoReport  *report.Report
+		repoErr     error
+		errContains string
+	}{
+		{
+			name:       "Success - Place legal hold",
+			legalHold:  true,
+			repoReport: &report.Report{ID: reportId, Status: constant.FinishedStatus, LegalHold: true},
+		},
+		{
+			name:       "Success - Release legal hold",
+			legalHold:  false,
+			repoReport: &report.Report{ID: reportId, Status: constant.FinishedStatus},
+		},
+		{
+			name:        "Error - Report not found",
+			legalHold:   true,
+			repoErr:     mongo.ErrNoDocuments,
+			errContains: "No report entity was found",
+		},
+		{
+			name:        "Error - Database failure",
+			legalHold:   true,
+			repoErr:     errors.New("database unavailable"),
+			errContains: "database unavailable",
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockReportRepo := report.NewMockRepository(ctrl)
+			mockReportRepo.EXPECT().
+				SetLegalHold(gomock.Any(), reportId, orgId, tt.legalHold).
+				Return(tt.repoReport, tt.repoErr)
+
+			reportSvc := &UseCase{
+				ReportRepo: mockReportRepo,
+			}
+
+			result, err := reportSvc.SetReportLegalHold(context.Background(), reportId, orgId, tt.legalHold)
+			if tt.errContains != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.errContains)
+				assert.Nil(t, result)
+
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.legalHold, result.LegalHold)
+		})
+	}
+}
@@ -0,0 +1,182 @@
+// Copyright (c) 2026 Lerian Studio. All rights reserved.
+// Use of this source code is governed by the Elastic License 2.0
+// that can be found in the LICENSE file.
+
+package services
+
+import (
+	"context"
+	"errors"
+	"sort"
+
+	"github.com/LerianStudio/reporter/pkg"
+	"github.com/LerianStudio/reporter/pkg/constant"
+	"github.com/LerianStudio/reporter/pkg/mongodb/template"
+	templateUtils "github.com/LerianStudio/reporter/pkg/templateutils"
+
+	"github.com/google/uuid"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// partialResolver returns a resolver that reads the partials of the organization from the
+// template repository and object storage. Partials in overrides resolve to the given content
+// instead, so templates can be analyzed against a partial before it is stored.
+func (uc *UseCase) partialResolver(ctx context.Context, organizationID uuid.UUID, overrides map[string]string) templateUtils.PartialResolver {
+	return func(name string) (string, error) {
+		if content, ok := overrides[name]; ok {
+			return content, nil
+		}
+
+		if _, err := uc.TemplateRepo.FindByPartialName(ctx, name, organizationID); err != nil {
+			if errors.Is(err, mongo.ErrNoDocuments) {
+				return "", &templateUtils.PartialError{Err: constant.ErrPartialNotFound, Name: name}
+			}
+
+			return "", err
+		}
+
+		content, err := uc.TemplateSeaweedFS.Get(ctx, pkg.TenantPartialObjectName(organizationID, name))
+		if err != nil {
+			return "", err
+		}
+
+		return string(content), nil
+	}
+}
+
+// mappedFieldsOfTemplate returns the mapped fields of a template, including those used by the
+// partials it references, and the sorted names of those partials. Partials are only checked for
+// missing or cyclic references: their fields are validated as part of the templates that use them,
+// so no mapped fields are returned.
+func (uc *UseCase) mappedFieldsOfTemplate(ctx context.Context, templateFile, partialName string, organizationID uuid.UUID, overrides map[string]string) (map[string]map[string][]string, []string, error) {
+	var (
+		mappedFields map[string]map[string][]string
+		partials     []string
+		err          error
+	)
+
+	resolvePartial := uc.partialResolver(ctx, organizationID, overrides)
+	referenced := make(map[string]bool)
+
+	resolve := func(name string) (string, error) {
+		if !referenced[name] {
+			referenced[name] = true
+			partials = append(partials, name)
+		}
+
+		return resolvePartial(name)
+	}
+
+	if partialName != "" {
+		_, err = templateUtils.ExpandPartial(partialName, templateFile, resolve)
+	} else {
+		mappedFields, err = templateUtils.MappedFieldsOfTemplateWithPartials(templateFile, resolve)
+	}
+
+	if err != nil {
+		var partialErr *templateUtils.PartialError
+		if errors.As(err, &partialErr) {
+			return nil, nil, pkg.ValidateBusinessError(partialErr.Err, "", partialErr.Name)
+		}
+
+		return nil, nil, err
+	}
+
+	sort.Strings(partials)
+
+	return mappedFields, partials, nil
+}
+
+// dependentTemplate holds the mapped fields and partials of a template recomputed after one of
+// the partials it references changed.
+type dependentTemplate struct {
+	template     *template.Template
+	mappedFields map[string]map[string][]string
+	partials     []string
+}
+
+// analyzeDependentTemplates recomputes the mapped fields and partials of every template that
+// references the partial, with the partial resolved to its new content, and validates the fields
+// against the datasources. Nothing is stored, so an invalid change leaves every template untouched.
+func (uc *UseCase) analyzeDependentTemplates(ctx context.Context, partialName, content string, organizationID uuid.UUID) ([]dependentTemplate, error) {
+	dependents, err := uc.TemplateRepo.FindByPartialReference(ctx, partialName, organizationID)
+	if err != nil {
+		return nil, err
+	}
+
+	overrides := map[string]string{partialName: content}
+	analyzed := make([]dependentTemplate, 0, len(dependents))
+
+	for _, dependent := range dependents {
+		templateFile, err := uc.TemplateSeaweedFS.Get(ctx, pkg.TenantObjectName(organizationID, dependent.FileName))
+		if err != nil {
+			return nil, err
+		}
+
+		mappedFields, partials, err := uc.mappedFieldsOfTemplate(ctx, string(templateFile), dependent.PartialName, organizationID, overrides)
+		if err != nil {
+			return nil, err
+		}
+
+		if !dependent.IsPartial() {
+			if err := uc.ValidateIfFieldsExistOnTables(ctx, mappedFields); err != nil {
+				return nil, err
+			}
+		}
+
+		analyzed = append(analyzed, dependentTemplate{template: dependent, mappedFields: mappedFields, partials: partials})
+	}
+
+	return analyzed, nil
+}
+
+// updateDependentTemplates stores the mapped fields and partials recomputed by analyzeDependentTemplates,
+// so reports of templates using an updated partial query the fields the partial now renders.
+func (uc *UseCase) updateDependentTemplates(ctx context.Context, dependents []dependentTemplate, organizationID uuid.UUID) error {
+	for _, dependent := range dependents {
+		setFields := bson.M{constant.MongoFieldPartials: dependent.partials}
+
+		if !dependent.template.IsPartial() {
+			setFields["mapped_fields"] = dependent.mappedFields
+		}
+
+		if err := uc.TemplateRepo.Update(ctx, dependent.template.ID, &bson.M{"$set": setFields}, organizationID); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// checkPartialNotReferenced returns an error if templates of the organization still reference the partial.
+func (uc *UseCase) checkPartialNotReferenced(ctx context.Context, partialName string, organizationID uuid.UUID) error {
+	dependents, err := uc.TemplateRepo.FindByPartialReference(ctx, partialName, organizationID)
+	if err != nil {
+		return err
+	}
+
+	if len(dependents) > 0 {
+		return pkg.ValidateBusinessError(constant.ErrPartialInUse, constant.MongoCollectionTemplate, partialName, len(dependents))
+	}
+
+	return nil
+}
+
+// checkPartialNameAvailable returns an error if the organization already has a partial with the given name.
+func (uc *UseCase) checkPartialNameAvailable(ctx context.Context, partialName string, organizationID uuid.UUID) error {
+	if err := templateUtils.ValidatePartialName(partialName); err != nil {
+		return pkg.ValidateBusinessError(constant.ErrInvalidPartialName, "", partialName)
+	}
+
+	_, err := uc.TemplateRepo.FindByPartialName(ctx, partialName, organizationID)
+	if err == nil {
+		return pkg.ValidateBusinessError(constant.ErrPartialNameConflict, "", partialName)
+	}
+
+	if !errors.Is(err, mongo.ErrNoDocuments) {
+		return err
+	}
+
+	return nil
+}
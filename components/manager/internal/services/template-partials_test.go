@@ -0,0 +1,321 @@
+// Copyright (c) 2026 Lerian Studio. All rights reserved.
+// Use of this source code is governed by the Elastic License 2.0
+// that can be found in the LICENSE file.
+
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/LerianStudio/reporter/pkg"
+	"github.com/LerianStudio/reporter/pkg/constant"
+	"github.com/LerianStudio/reporter/pkg/mongodb/template"
+	templateSeaweedFS "github.com/LerianStudio/reporter/pkg/seaweedfs/template"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.uber.org/mock/gomock"
+)
+
+func TestUseCase_MappedFieldsOfTemplate_WithPartials(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockTempRepo := template.NewMockRepository(ctrl)
+	mockTemplateStorage := templateSeaweedFS.NewMockRepository(ctrl)
+	orgID := uuid.New()
+
+	tempSvc := &UseCase{
+		TemplateRepo:      mockTempRepo,
+		TemplateSeaweedFS: mockTemplateStorage,
+	}
+
+	mockTempRepo.EXPECT().
+		FindByPartialName(gomock.Any(), "rows/organization", orgID).
+		Return(&template.Template{PartialName: "rows/organization"}, nil)
+	mockTemplateStorage.EXPECT().
+		Get(gomock.Any(), pkg.TenantPartialObjectName(orgID, "rows/organization")).
+		Return([]byte(`<td>{{ org.legal_name }}</td>`), nil)
+
+	templateFile := `{% for org in midaz_organization.organization %}{% include "rows/organization" %}{% endfor %}`
+
+	mappedFields, partials, err := tempSvc.mappedFieldsOfTemplate(context.Background(), templateFile, "", orgID, nil)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"legal_name"}, mappedFields["midaz_organization"]["organization"])
+	assert.Equal(t, []string{"rows/organization"}, partials)
+}
+
+func TestUseCase_MappedFieldsOfTemplate_PartialErrors(t *testing.T) {
+	t.Parallel()
+
+	orgID := uuid.New()
+
+	tests := []struct {
+		name         string
+		templateFile string
+		partialName  string
+		mockSetup    func(repo *template.MockRepository, storage *templateSeaweedFS.MockRepository)
+		errContains  string
+	}{
+		{
+			name:         "Error - Included partial not found",
+			templateFile: `{% include "layouts/missing" %}`,
+			mockSetup: func(repo *template.MockRepository, _ *templateSeaweedFS.MockRepository) {
+				repo.EXPECT().
+					FindByPartialName(gomock.Any(), "layouts/missing", orgID).
+					Return(nil, mongo.ErrNoDocuments)
+			},
+			errContains: constant.ErrPartialNotFound.Error(),
+		},
+		{
+			name:         "Error - Partial including itself",
+			templateFile: `{% include "./corporate" %}`,
+			partialName:  "layouts/corporate",
+			mockSetup:    func(_ *template.MockRepository, _ *templateSeaweedFS.MockRepository) {},
+			errContains:  constant.ErrPartialIncludeCycle.Error(),
+		},
+		{
+			name:         "Error - Storage failure is returned as is",
+			templateFile: `{% extends "layouts/corporate" %}`,
+			mockSetup: func(repo *template.MockRepository, storage *templateSeaweedFS.MockRepository) {
+				repo.EXPECT().
+					FindByPartialName(gomock.Any(), "layouts/corporate", orgID).
+					Return(&template.Template{PartialName: "layouts/corporate"}, nil)
+				storage.EXPECT().
+					Get(gomock.Any(), gomock.Any()).
+					Return(nil, errors.New("storage unavailable"))
+			},
+			errContains: "storage unavailable",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockTempRepo := template.NewMockRepository(ctrl)
+			mockTemplateStorage := templateSeaweedFS.NewMockRepository(ctrl)
+			tt.mockSetup(mockTempRepo, mockTemplateStorage)
+
+			tempSvc := &UseCase{
+				TemplateRepo:      mockTempRepo,
+				TemplateSeaweedFS: mockTemplateStorage,
+			}
+
+			_, _, err := tempSvc.mappedFieldsOfTemplate(context.Background(), tt.templateFile, tt.partialName, orgID, nil)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.errContains)
+		})
+	}
+}
+
+func TestUseCase_CreateTemplate_Partial(t *testing.T) {
+	t.Parallel()
+
+	orgID := uuid.New()
+	partialContent := `<html><header>ACME</header>{% block content %}{% endblock %}</html>`
+
+	tests := []struct {
+		name        string
+		partialName string
+		mockSetup   func(repo *template.MockRepository, storage *templateSeaweedFS.MockRepository)
+		expectErr   bool
+		errContains string
+	}{
+		{
+			name:        "Success - Create a partial",
+			partialName: "layouts/corporate",
+			mockSetup: func(repo *template.MockRepository, storage *templateSeaweedFS.MockRepository) {
+				repo.EXPECT().
+					FindByPartialName(gomock.Any(), "layouts/corporate", orgID).
+					Return(nil, mongo.ErrNoDocuments)
+				repo.EXPECT().
+					Create(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, model *template.TemplateMongoDBModel) (*template.Template, error) {
+						assert.Equal(t, "layouts/corporate", model.PartialName)
+						assert.Equal(t, "partials/layouts/corporate.tpl", model.FileName)
+
+						return model.ToEntity(), nil
+					})
+				storage.EXPECT().
+					Put(gomock.Any(), pkg.TenantPartialObjectName(orgID, "layouts/corporate"), "html", []byte(partialContent)).
+					Return(nil)
+			},
+		},
+		{
+			name:        "Error - Partial name already in use",
+			partialName: "layouts/corporate",
+			mockSetup: func(repo *template.MockRepository, _ *templateSeaweedFS.MockRepository) {
+				repo.EXPECT().
+					FindByPartialName(gomock.Any(), "layouts/corporate", orgID).
+					Return(&template.Template{PartialName: "layouts/corporate"}, nil)
+			},
+			expectErr:   true,
+			errContains: "already exists",
+		},
+		{
+			name:        "Error - Invalid partial name",
+			partialName: "../layouts",
+			mockSetup:   func(_ *template.MockRepository, _ *templateSeaweedFS.MockRepository) {},
+			expectErr:   true,
+			errContains: constant.ErrInvalidPartialName.Error(),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockTempRepo := template.NewMockRepository(ctrl)
+			mockTemplateStorage := templateSeaweedFS.NewMockRepository(ctrl)
+			tt.mockSetup(mockTempRepo, mockTemplateStorage)
+
+			tempSvc := &UseCase{
+				TemplateRepo:        mockTempRepo,
+				TemplateSeaweedFS:   mockTemplateStorage,
+				ExternalDataSources: pkg.NewSafeDataSources(map[string]pkg.DataSource{}),
+			}
+
+			fileHeader, err := createFileHeaderFromString(partialContent, "corporate.tpl")
+			require.NoError(t, err)
+
+			result, err := tempSvc.CreateTemplate(context.Background(), partialContent, "html", "Corporate layout", tt.partialName, "", nil, nil, fileHeader, orgID)
+
+			if tt.expectErr {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.errContains)
+
+				return
+			}
+
+			require.NoError(t, err)
+			assert.True(t, result.IsPartial())
+		})
+	}
+}
+
+func TestUseCase_UpdateTemplateByID_Partial(t *testing.T) {
+	t.Parallel()
+
+	orgID := uuid.New()
+	partialID := uuid.New()
+	dependentID := uuid.New()
+	nestedID := uuid.New()
+
+	partial := &template.Template{ID: partialID, PartialName: "layouts/corporate", FileName: "partials/layouts/corporate.tpl", OutputFormat: "html"}
+	dependent := &template.Template{ID: dependentID, FileName: dependentID.String() + ".tpl", OutputFormat: "html"}
+	nested := &template.Template{ID: nestedID, PartialName: "layouts/report", FileName: "partials/layouts/report.tpl", OutputFormat: "html"}
+
+	tests := []struct {
+		name           string
+		partialContent string
+		mockSetup      func(repo *template.MockRepository, storage *templateSeaweedFS.MockRepository)
+		expectErr      bool
+		errContains    string
+	}{
+		{
+			name:           "Success - Templates using the partial are analyzed again",
+			partialContent: `<html><header>ACME</header>{% block content %}{% endblock %}</html>`,
+			mockSetup: func(repo *template.MockRepository, storage *templateSeaweedFS.MockRepository) {
+				repo.EXPECT().FindByID(gomock.Any(), partialID, orgID).Return(partial, nil).Times(2)
+				repo.EXPECT().
+					FindByPartialReference(gomock.Any(), "layouts/corporate", orgID).
+					Return([]*template.Template{dependent, nested}, nil)
+				storage.EXPECT().
+					Get(gomock.Any(), pkg.TenantObjectName(orgID, dependent.FileName)).
+					Return([]byte(`{% include "layouts/report" %}`), nil)
+				repo.EXPECT().
+					FindByPartialName(gomock.Any(), "layouts/report", orgID).
+					Return(nested, nil)
+				storage.EXPECT().
+					Get(gomock.Any(), pkg.TenantPartialObjectName(orgID, "layouts/report")).
+					Return([]byte(`{% extends "layouts/corporate" %}`), nil)
+				storage.EXPECT().
+					Get(gomock.Any(), pkg.TenantObjectName(orgID, nested.FileName)).
+					Return([]byte(`{% extends "layouts/corporate" %}`), nil)
+				repo.EXPECT().FindOutputFormatByID(gomock.Any(), partialID, orgID).Return(&partial.OutputFormat, nil)
+				storage.EXPECT().Put(gomock.Any(), pkg.TenantPartialObjectName(orgID, "layouts/corporate"), "html", gomock.Any()).Return(nil)
+				repo.EXPECT().Update(gomock.Any(), partialID, gomock.Any(), orgID).Return(nil)
+				repo.EXPECT().
+					Update(gomock.Any(), dependentID, gomock.Any(), orgID).
+					DoAndReturn(func(_ context.Context, _ uuid.UUID, updateFields *bson.M, _ uuid.UUID) error {
+						setFields := (*updateFields)["$set"].(bson.M)
+						assert.Equal(t, []string{"layouts/corporate", "layouts/report"}, setFields[constant.MongoFieldPartials])
+						assert.Contains(t, setFields, "mapped_fields")
+
+						return nil
+					})
+				repo.EXPECT().
+					Update(gomock.Any(), nestedID, gomock.Any(), orgID).
+					DoAndReturn(func(_ context.Context, _ uuid.UUID, updateFields *bson.M, _ uuid.UUID) error {
+						setFields := (*updateFields)["$set"].(bson.M)
+						assert.Equal(t, []string{"layouts/corporate"}, setFields[constant.MongoFieldPartials])
+						assert.NotContains(t, setFields, "mapped_fields")
+
+						return nil
+					})
+			},
+		},
+		{
+			name:           "Error - Partial rendering fields a template can not query",
+			partialContent: `{% for row in unknown_datasource.table %}{{ row.id }}{% endfor %}`,
+			mockSetup: func(repo *template.MockRepository, storage *templateSeaweedFS.MockRepository) {
+				repo.EXPECT().FindByID(gomock.Any(), partialID, orgID).Return(partial, nil)
+				repo.EXPECT().
+					FindByPartialReference(gomock.Any(), "layouts/corporate", orgID).
+					Return([]*template.Template{dependent}, nil)
+				storage.EXPECT().
+					Get(gomock.Any(), pkg.TenantObjectName(orgID, dependent.FileName)).
+					Return([]byte(`{% include "layouts/corporate" %}`), nil)
+			},
+			expectErr:   true,
+			errContains: "unknown_datasource",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockTempRepo := template.NewMockRepository(ctrl)
+			mockTemplateStorage := templateSeaweedFS.NewMockRepository(ctrl)
+			tt.mockSetup(mockTempRepo, mockTemplateStorage)
+
+			tempSvc := &UseCase{
+				TemplateRepo:        mockTempRepo,
+				TemplateSeaweedFS:   mockTemplateStorage,
+				ExternalDataSources: pkg.NewSafeDataSources(map[string]pkg.DataSource{}),
+			}
+
+			fileHeader, err := createFileHeaderFromString(tt.partialContent, "corporate.tpl")
+			require.NoError(t, err)
+
+			_, err = tempSvc.UpdateTemplateByID(context.Background(), "", "", "", nil, nil, partialID, fileHeader, orgID)
+
+			if tt.expectErr {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.errContains)
+
+				return
+			}
+
+			require.NoError(t, err)
+		})
+	}
+}
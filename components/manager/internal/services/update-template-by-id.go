@@ -13,6 +13,7 @@ import (
 
 	"github.com/LerianStudio/reporter/pkg"
 	"github.com/LerianStudio/reporter/pkg/constant"
+	"github.com/LerianStudio/reporter/pkg/model"
 	"github.com/LerianStudio/reporter/pkg/mongodb/template"
 	pkgHTTP "github.com/LerianStudio/reporter/pkg/net/http"
 	templateUtils "github.com/LerianStudio/reporter/pkg/templateutils"
@@ -28,11 +29,16 @@ import (
 )
 
 // UpdateTemplateByID updates an existing template, optionally uploading a new file to storage,
-// and returns the updated template.
-func (uc *UseCase) UpdateTemplateByID(ctx context.Context, outputFormat, description string, id uuid.UUID, fileHeader *multipart.FileHeader) (*template.Template, error) {
+// and returns the updated template. Only templates of the given organization can be updated.
+// Output options, when given, replace the encoding and line ending options of the template,
+// the priority, when given, replaces its generation priority and the retention days, when given, replace its retention.
+func (uc *UseCase) UpdateTemplateByID(ctx context.Context, outputFormat, description, priority string, retentionDays *int, outputOptions *model.OutputOptions, id uuid.UUID, fileHeader *multipart.FileHeader, organizationID uuid.UUID) (*template.Template, error) {
 	var (
-		templateFile string
-		mappedFields map[string]map[string][]string
+		templateFile    string
+		currentTemplate *template.Template
+		mappedFields    map[string]map[string][]string
+		partials        []string
+		dependents      []dependentTemplate
 	)
 
 	logger, tracer, reqId, _ := commons.NewTrackingFromContext(ctx)
@@ -43,6 +49,7 @@ func (uc *UseCase) UpdateTemplateByID(ctx context.Context, outputFormat, descrip
 	span.SetAttributes(
 		attribute.String("app.request.request_id", reqId),
 		attribute.String("app.request.template_id", id.String()),
+		attribute.String("app.request.organization_id", organizationID.String()),
 	)
 
 	logger.Infof("Updating template")
@@ -50,7 +57,7 @@ func (uc *UseCase) UpdateTemplateByID(ctx context.Context, outputFormat, descrip
 	if fileHeader != nil {
 		var err error
 
-		templateFile, mappedFields, err = uc.processTemplateFile(ctx, fileHeader)
+		templateFile, err = uc.processTemplateFile(ctx, fileHeader)
 		if err != nil {
 			if pkgHTTP.IsBusinessError(err) {
 				libOpentelemetry.HandleSpanBusinessErrorEvent(&span, "Failed to process template file", err)
@@ -61,6 +68,35 @@ func (uc *UseCase) UpdateTemplateByID(ctx context.Context, outputFormat, descrip
 			return nil, err
 		}
 
+		// Fetch the current template to know whether it is a partial and where its file is stored
+		currentTemplate, err = uc.TemplateRepo.FindByID(ctx, id, organizationID)
+		if err != nil {
+			if pkgHTTP.IsBusinessError(err) {
+				libOpentelemetry.HandleSpanBusinessErrorEvent(&span, "Failed to retrieve current template", err)
+			} else {
+				libOpentelemetry.HandleSpanError(&span, "Failed to retrieve current template", err)
+			}
+
+			logger.Errorf("Failed to retrieve Template with ID: %s, Error: %s", id, err.Error())
+
+			return nil, err
+		}
+
+		mappedFields, partials, err = uc.mappedFieldsOfTemplate(ctx, templateFile, currentTemplate.PartialName, organizationID, nil)
+		if err != nil {
+			if pkgHTTP.IsBusinessError(err) {
+				libOpentelemetry.HandleSpanBusinessErrorEvent(&span, "Failed to resolve template partials", err)
+			} else {
+				libOpentelemetry.HandleSpanError(&span, "Failed to resolve template partials", err)
+			}
+
+			logger.Errorf("Error to resolve template partials, Error: %v", err)
+
+			return nil, err
+		}
+
+		logger.Infof("Mapped Fields is valid to continue %v", mappedFields)
+
 		if errValidateFields := uc.ValidateIfFieldsExistOnTables(ctx, mappedFields); errValidateFields != nil {
 			if pkgHTTP.IsBusinessError(errValidateFields) {
 				libOpentelemetry.HandleSpanBusinessErrorEvent(&span, "Failed to validate fields existence on tables", errValidateFields)
@@ -72,29 +108,59 @@ func (uc *UseCase) UpdateTemplateByID(ctx context.Context, outputFormat, descrip
 
 			return nil, errValidateFields
 		}
+
+		// Templates using the partial render its new content, so their fields are checked before anything is stored
+		if currentTemplate.IsPartial() {
+			dependents, err = uc.analyzeDependentTemplates(ctx, currentTemplate.PartialName, templateFile, organizationID)
+			if err != nil {
+				if pkgHTTP.IsBusinessError(err) {
+					libOpentelemetry.HandleSpanBusinessErrorEvent(&span, "Failed to analyze templates using the partial", err)
+				} else {
+					libOpentelemetry.HandleSpanError(&span, "Failed to analyze templates using the partial", err)
+				}
+
+				logger.Errorf("Error to analyze templates using partial %s, Error: %v", currentTemplate.PartialName, err)
+
+				return nil, err
+			}
+		}
 	}
 
 	// Validate output format and file format compatibility
-	if err := uc.validateOutputFormatAndFile(ctx, id, fileHeader, outputFormat, templateFile); err != nil {
+	if err := uc.validateOutputFormatAndFile(ctx, id, organizationID, fileHeader, outputFormat, templateFile); err != nil {
+		return nil, err
+	}
+
+	if err := uc.validateOutputOptionsForFormat(ctx, id, organizationID, currentTemplate, outputFormat, outputOptions); err != nil {
+		if pkgHTTP.IsBusinessError(err) {
+			libOpentelemetry.HandleSpanBusinessErrorEvent(&span, "Output options are not valid for the output format", err)
+		} else {
+			libOpentelemetry.HandleSpanError(&span, "Failed to validate output options for the output format", err)
+		}
+
 		return nil, err
 	}
 
 	// If a new file was provided, upload it to object storage FIRST (before DB update)
 	if fileHeader != nil {
-		if err := uc.uploadTemplateFileToStorage(ctx, id, outputFormat, fileHeader, &span); err != nil {
+		if err := uc.uploadTemplateFileToStorage(ctx, currentTemplate, organizationID, outputFormat, fileHeader, &span); err != nil {
 			return nil, err
 		}
 	}
 
 	// Now update the database
-	setFields := uc.buildSetFields(description, outputFormat, mappedFields)
+	setFields := uc.buildSetFields(description, outputFormat, priority, retentionDays, outputOptions, mappedFields)
+	if fileHeader != nil {
+		setFields[constant.MongoFieldPartials] = partials
+	}
+
 	updateFields := bson.M{}
 
 	if len(setFields) > 0 {
 		updateFields["$set"] = setFields
 	}
 
-	if errUpdate := uc.TemplateRepo.Update(ctx, id, &updateFields); errUpdate != nil {
+	if errUpdate := uc.TemplateRepo.Update(ctx, id, &updateFields, organizationID); errUpdate != nil {
 		libOpentelemetry.HandleSpanError(&span, "Failed to update template in repository", errUpdate)
 
 		logger.Errorf("Error into updating a template, Error: %v", errUpdate)
@@ -105,8 +171,16 @@ func (uc *UseCase) UpdateTemplateByID(ctx context.Context, outputFormat, descrip
 		return nil, errUpdate
 	}
 
+	if errDependents := uc.updateDependentTemplates(ctx, dependents, organizationID); errDependents != nil {
+		libOpentelemetry.HandleSpanError(&span, "Failed to update templates using the partial", errDependents)
+
+		logger.Errorf("Error updating templates using partial %s, Error: %v", currentTemplate.PartialName, errDependents)
+
+		return nil, errDependents
+	}
+
 	// Fetch the updated template to return
-	templateUpdated, err := uc.GetTemplateByID(ctx, id)
+	templateUpdated, err := uc.GetTemplateByID(ctx, id, organizationID)
 	if err != nil {
 		if pkgHTTP.IsBusinessError(err) {
 			libOpentelemetry.HandleSpanBusinessErrorEvent(&span, "Failed to retrieve updated template", err)
@@ -122,25 +196,11 @@ func (uc *UseCase) UpdateTemplateByID(ctx context.Context, outputFormat, descrip
 	return templateUpdated, nil
 }
 
-// uploadTemplateFileToStorage fetches the current template, reads the file bytes, and uploads
-// the new file content to object storage.
-func (uc *UseCase) uploadTemplateFileToStorage(ctx context.Context, id uuid.UUID, outputFormat string, fileHeader *multipart.FileHeader, span *trace.Span) error {
+// uploadTemplateFileToStorage reads the file bytes and uploads the new file content to object
+// storage, replacing the file of the current template.
+func (uc *UseCase) uploadTemplateFileToStorage(ctx context.Context, currentTemplate *template.Template, organizationID uuid.UUID, outputFormat string, fileHeader *multipart.FileHeader, span *trace.Span) error {
 	logger, _, _, _ := commons.NewTrackingFromContext(ctx) //nolint:dogsled // only logger needed from tracking context
 
-	// Fetch the current template BEFORE updating to get the FileName for storage upload
-	currentTemplate, err := uc.TemplateRepo.FindByID(ctx, id)
-	if err != nil {
-		if pkgHTTP.IsBusinessError(err) {
-			libOpentelemetry.HandleSpanBusinessErrorEvent(span, "Failed to retrieve current template", err)
-		} else {
-			libOpentelemetry.HandleSpanError(span, "Failed to retrieve current template", err)
-		}
-
-		logger.Errorf("Failed to retrieve Template with ID: %s, Error: %s", id, err.Error())
-
-		return err
-	}
-
 	fileBytes, errRead := pkgHTTP.ReadMultipartFile(fileHeader)
 	if errRead != nil {
 		libOpentelemetry.HandleSpanError(span, "Failed to read multipart file", errRead)
@@ -156,7 +216,7 @@ func (uc *UseCase) uploadTemplateFileToStorage(ctx context.Context, id uuid.UUID
 		storageContentType = currentTemplate.OutputFormat
 	}
 
-	errPutStorage := uc.TemplateSeaweedFS.Put(ctx, currentTemplate.FileName, storageContentType, fileBytes)
+	errPutStorage := uc.TemplateSeaweedFS.Put(ctx, pkg.TenantObjectName(organizationID, currentTemplate.FileName), storageContentType, fileBytes)
 	if errPutStorage != nil {
 		libOpentelemetry.HandleSpanError(span, "Error putting template file on storage", errPutStorage)
 
@@ -168,9 +228,9 @@ func (uc *UseCase) uploadTemplateFileToStorage(ctx context.Context, id uuid.UUID
 	return nil
 }
 
-// processTemplateFile handles file extraction, script tag validation, and mapped fields extraction.
-func (uc *UseCase) processTemplateFile(ctx context.Context, fileHeader *multipart.FileHeader) (string, map[string]map[string][]string, error) {
-	logger, tracer, reqId, _ := commons.NewTrackingFromContext(ctx)
+// processTemplateFile handles file extraction and script tag validation.
+func (uc *UseCase) processTemplateFile(ctx context.Context, fileHeader *multipart.FileHeader) (string, error) {
+	_, tracer, reqId, _ := commons.NewTrackingFromContext(ctx)
 
 	_, span := tracer.Start(ctx, "service.template.process_template_file")
 	defer span.End()
@@ -182,24 +242,21 @@ func (uc *UseCase) processTemplateFile(ctx context.Context, fileHeader *multipar
 	templateFile, errFile := pkgHTTP.GetFileFromHeader(fileHeader)
 	if errFile != nil {
 		libOpentelemetry.HandleSpanError(&span, "Failed to get file from header", errFile)
-		return "", nil, errFile
+		return "", errFile
 	}
 
 	if err := templateUtils.ValidateNoScriptTag(templateFile); err != nil {
 		errBusiness := pkg.ValidateBusinessError(constant.ErrScriptTagDetected, "")
 		libOpentelemetry.HandleSpanBusinessErrorEvent(&span, "Script tag detected in template file", errBusiness)
 
-		return "", nil, errBusiness
+		return "", errBusiness
 	}
 
-	mappedFields := templateUtils.MappedFieldsOfTemplate(templateFile)
-	logger.Infof("Mapped Fields is valid to continue %v", mappedFields)
-
-	return templateFile, mappedFields, nil
+	return templateFile, nil
 }
 
 // buildSetFields builds the setFields map for the update operation.
-func (uc *UseCase) buildSetFields(description, outputFormat string, mappedFields map[string]map[string][]string) bson.M {
+func (uc *UseCase) buildSetFields(description, outputFormat, priority string, retentionDays *int, outputOptions *model.OutputOptions, mappedFields map[string]map[string][]string) bson.M {
 	setFields := bson.M{}
 	if !commons.IsNilOrEmpty(&description) {
 		setFields["description"] = description
@@ -209,6 +266,18 @@ func (uc *UseCase) buildSetFields(description, outputFormat string, mappedFields
 		setFields["output_format"] = strings.ToLower(outputFormat)
 	}
 
+	if priority != "" {
+		setFields["priority"] = priority
+	}
+
+	if retentionDays != nil {
+		setFields["retention_days"] = *retentionDays
+	}
+
+	if outputOptions != nil {
+		setFields["output_options"] = outputOptions
+	}
+
 	if mappedFields != nil {
 		setFields["mapped_fields"] = mappedFields
 	}
@@ -218,8 +287,39 @@ func (uc *UseCase) buildSetFields(description, outputFormat string, mappedFields
 	return setFields
 }
 
+// validateOutputOptionsForFormat checks the output options the template will have against its output
+// format. Whichever of the two the update leaves unchanged is read from the stored template.
+func (uc *UseCase) validateOutputOptionsForFormat(ctx context.Context, id, organizationID uuid.UUID, currentTemplate *template.Template, outputFormat string, outputOptions *model.OutputOptions) error {
+	if outputOptions == nil && commons.IsNilOrEmpty(&outputFormat) {
+		return nil
+	}
+
+	if currentTemplate == nil && (outputOptions == nil || commons.IsNilOrEmpty(&outputFormat)) {
+		stored, err := uc.TemplateRepo.FindByID(ctx, id, organizationID)
+		if err != nil {
+			return err
+		}
+
+		currentTemplate = stored
+	}
+
+	if commons.IsNilOrEmpty(&outputFormat) {
+		outputFormat = currentTemplate.OutputFormat
+	}
+
+	if outputOptions == nil {
+		outputOptions = currentTemplate.OutputOptions
+	}
+
+	if err := outputOptions.ValidateForFormat(outputFormat); err != nil {
+		return pkg.ValidateBusinessError(constant.ErrInvalidOutputOptions, "", err.Error())
+	}
+
+	return nil
+}
+
 // validateOutputFormatAndFile validates output format and file format compatibility.
-func (uc *UseCase) validateOutputFormatAndFile(ctx context.Context, id uuid.UUID, fileHeader *multipart.FileHeader, outputFormat, templateFile string) error {
+func (uc *UseCase) validateOutputFormatAndFile(ctx context.Context, id, organizationID uuid.UUID, fileHeader *multipart.FileHeader, outputFormat, templateFile string) error {
 	logger, tracer, reqId, _ := commons.NewTrackingFromContext(ctx)
 
 	ctx, span := tracer.Start(ctx, "service.template.validate_output_format")
@@ -232,7 +332,7 @@ func (uc *UseCase) validateOutputFormatAndFile(ctx context.Context, id uuid.UUID
 
 	// If file is provided without explicit outputFormat, validate against existing template's outputFormat
 	if fileHeader != nil && commons.IsNilOrEmpty(&outputFormat) {
-		outputFormatExistentTemplate, err := uc.TemplateRepo.FindOutputFormatByID(ctx, id)
+		outputFormatExistentTemplate, err := uc.TemplateRepo.FindOutputFormatByID(ctx, id, organizationID)
 		if err != nil {
 			if pkgHTTP.IsBusinessError(err) {
 				libOpentelemetry.HandleSpanBusinessErrorEvent(&span, "Failed to get outputFormat of template by ID", err)
@@ -16,6 +16,7 @@ import (
 
 	"github.com/LerianStudio/reporter/pkg"
 	"github.com/LerianStudio/reporter/pkg/constant"
+	"github.com/LerianStudio/reporter/pkg/model"
 	"github.com/LerianStudio/reporter/pkg/mongodb"
 	"github.com/LerianStudio/reporter/pkg/mongodb/template"
 	"github.com/LerianStudio/reporter/pkg/postgres"
@@ -207,9 +208,9 @@ func TestUseCase_UpdateTemplateByID(t *testing.T) {
 					CloseConnection().
 					Return(nil)
 
-				// First FindByID to get current template (before update)
+				// First FindByID to get current template (before processing the file)
 				mockTempRepo.EXPECT().
-					FindByID(gomock.Any(), gomock.Any()).
+					FindByID(gomock.Any(), gomock.Any(), gomock.Any()).
 					Return(&template.Template{
 						FileName:     "test-template.tpl",
 						OutputFormat: "xml",
@@ -220,12 +221,12 @@ func TestUseCase_UpdateTemplateByID(t *testing.T) {
 					Return(nil)
 
 				mockTempRepo.EXPECT().
-					Update(gomock.Any(), gomock.Any(), gomock.Any()).
+					Update(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
 					Return(nil)
 
 				// Second FindByID to get updated template (after update)
 				mockTempRepo.EXPECT().
-					FindByID(gomock.Any(), gomock.Any()).
+					FindByID(gomock.Any(), gomock.Any(), gomock.Any()).
 					Return(&template.Template{
 						FileName:     "test-template.tpl",
 						OutputFormat: "xml",
@@ -240,6 +241,14 @@ func TestUseCase_UpdateTemplateByID(t *testing.T) {
 			tempId:       uuid.New(),
 			errContains:  constant.ErrInternalServer.Error(),
 			mockSetup: func() {
+				// FindByID to get current template (before processing the file)
+				mockTempRepo.EXPECT().
+					FindByID(gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(&template.Template{
+						FileName:     "test-template.tpl",
+						OutputFormat: "xml",
+					}, nil)
+
 				mockDataSourceMongo.EXPECT().
 					GetDatabaseSchema(gomock.Any()).
 					Return(mongoSchemas, nil)
@@ -257,7 +266,7 @@ func TestUseCase_UpdateTemplateByID(t *testing.T) {
 					Return(nil)
 
 				mockTempRepo.EXPECT().
-					FindOutputFormatByID(gomock.Any(), gomock.Any()).
+					FindOutputFormatByID(gomock.Any(), gomock.Any(), gomock.Any()).
 					Return(nil, constant.ErrInternalServer)
 			},
 			expectErr: true,
@@ -269,6 +278,14 @@ func TestUseCase_UpdateTemplateByID(t *testing.T) {
 			tempId:       uuid.New(),
 			errContains:  constant.ErrFileContentInvalid.Error(),
 			mockSetup: func() {
+				// FindByID to get current template (before processing the file)
+				mockTempRepo.EXPECT().
+					FindByID(gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(&template.Template{
+						FileName:     "test-template.tpl",
+						OutputFormat: "xml",
+					}, nil)
+
 				htmlTypeP := &htmlType
 				mockDataSourceMongo.EXPECT().
 					GetDatabaseSchema(gomock.Any()).
@@ -287,7 +304,7 @@ func TestUseCase_UpdateTemplateByID(t *testing.T) {
 					Return(nil)
 
 				mockTempRepo.EXPECT().
-					FindOutputFormatByID(gomock.Any(), gomock.Any()).
+					FindOutputFormatByID(gomock.Any(), gomock.Any(), gomock.Any()).
 					Return(htmlTypeP, nil)
 			},
 			expectErr: true,
@@ -300,6 +317,14 @@ func TestUseCase_UpdateTemplateByID(t *testing.T) {
 			tempId:       uuid.New(),
 			errContains:  constant.ErrInvalidOutputFormat.Error(),
 			mockSetup: func() {
+				// FindByID to get current template (before processing the file)
+				mockTempRepo.EXPECT().
+					FindByID(gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(&template.Template{
+						FileName:     "test-template.tpl",
+						OutputFormat: "xml",
+					}, nil)
+
 				mockDataSourceMongo.EXPECT().
 					GetDatabaseSchema(gomock.Any()).
 					Return(mongoSchemas, nil)
@@ -326,6 +351,14 @@ func TestUseCase_UpdateTemplateByID(t *testing.T) {
 			tempId:       uuid.New(),
 			errContains:  constant.ErrFileContentInvalid.Error(),
 			mockSetup: func() {
+				// FindByID to get current template (before processing the file)
+				mockTempRepo.EXPECT().
+					FindByID(gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(&template.Template{
+						FileName:     "test-template.tpl",
+						OutputFormat: "xml",
+					}, nil)
+
 				mockDataSourceMongo.EXPECT().
 					GetDatabaseSchema(gomock.Any()).
 					Return(mongoSchemas, nil)
@@ -368,9 +401,9 @@ func TestUseCase_UpdateTemplateByID(t *testing.T) {
 					CloseConnection().
 					Return(nil)
 
-				// FindByID to get current template (before update)
+				// FindByID to get current template (before processing the file)
 				mockTempRepo.EXPECT().
-					FindByID(gomock.Any(), gomock.Any()).
+					FindByID(gomock.Any(), gomock.Any(), gomock.Any()).
 					Return(&template.Template{
 						FileName:     "test-template.tpl",
 						OutputFormat: "xml",
@@ -381,7 +414,7 @@ func TestUseCase_UpdateTemplateByID(t *testing.T) {
 					Return(nil)
 
 				mockTempRepo.EXPECT().
-					Update(gomock.Any(), gomock.Any(), gomock.Any()).
+					Update(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
 					Return(constant.ErrInternalServer)
 			},
 			expectErr: true,
@@ -423,9 +456,9 @@ func TestUseCase_UpdateTemplateByID(t *testing.T) {
 					CloseConnection().
 					Return(nil)
 
-				// First FindByID to get current template (before update)
+				// First FindByID to get current template (before processing the file)
 				mockTempRepo.EXPECT().
-					FindByID(gomock.Any(), gomock.Any()).
+					FindByID(gomock.Any(), gomock.Any(), gomock.Any()).
 					Return(&template.Template{
 						FileName:     "test-template.tpl",
 						OutputFormat: "xml",
@@ -436,12 +469,12 @@ func TestUseCase_UpdateTemplateByID(t *testing.T) {
 					Return(nil)
 
 				mockTempRepo.EXPECT().
-					Update(gomock.Any(), gomock.Any(), gomock.Any()).
+					Update(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
 					Return(nil)
 
 				// Second FindByID (after update) fails
 				mockTempRepo.EXPECT().
-					FindByID(gomock.Any(), gomock.Any()).
+					FindByID(gomock.Any(), gomock.Any(), gomock.Any()).
 					Return(nil, errors.New("template not found after update"))
 			},
 			expectErr: true,
@@ -480,9 +513,9 @@ func TestUseCase_UpdateTemplateByID(t *testing.T) {
 					CloseConnection().
 					Return(nil)
 
-				// FindByID to get current template (before storage upload)
+				// FindByID to get current template (before processing the file)
 				mockTempRepo.EXPECT().
-					FindByID(gomock.Any(), gomock.Any()).
+					FindByID(gomock.Any(), gomock.Any(), gomock.Any()).
 					Return(&template.Template{
 						FileName:     "test-template.tpl",
 						OutputFormat: "xml",
@@ -502,25 +535,9 @@ func TestUseCase_UpdateTemplateByID(t *testing.T) {
 			tempId:       uuid.New(),
 			errContains:  "template not found",
 			mockSetup: func() {
-				mockDataSourceMongo.EXPECT().
-					GetDatabaseSchema(gomock.Any()).
-					Return(mongoSchemas, nil)
-
-				mockDataSourceMongo.EXPECT().
-					CloseConnection(gomock.Any()).
-					Return(nil)
-
-				mockDataSourcePostgres.EXPECT().
-					GetDatabaseSchema(gomock.Any(), gomock.Any()).
-					Return(postgresSchemas, nil)
-
-				mockDataSourcePostgres.EXPECT().
-					CloseConnection().
-					Return(nil)
-
-				// FindByID fails before update
+				// FindByID fails before the file fields are validated
 				mockTempRepo.EXPECT().
-					FindByID(gomock.Any(), gomock.Any()).
+					FindByID(gomock.Any(), gomock.Any(), gomock.Any()).
 					Return(nil, errors.New("template not found"))
 			},
 			expectErr: true,
@@ -533,11 +550,11 @@ func TestUseCase_UpdateTemplateByID(t *testing.T) {
 			mockSetup: func() {
 				// No FindByID before update when no file is provided
 				mockTempRepo.EXPECT().
-					Update(gomock.Any(), gomock.Any(), gomock.Any()).
+					Update(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
 					Return(nil)
 
 				mockTempRepo.EXPECT().
-					FindByID(gomock.Any(), gomock.Any()).
+					FindByID(gomock.Any(), gomock.Any(), gomock.Any()).
 					Return(&template.Template{
 						FileName:     "test-template.tpl",
 						OutputFormat: "xml",
@@ -553,7 +570,7 @@ func TestUseCase_UpdateTemplateByID(t *testing.T) {
 			tt.mockSetup()
 
 			ctx := context.Background()
-			_, err := tempSvc.UpdateTemplateByID(ctx, tt.outFormat, tt.description, tt.tempId, tt.templateFile)
+			_, err := tempSvc.UpdateTemplateByID(ctx, tt.outFormat, tt.description, "", nil, nil, tt.tempId, tt.templateFile, uuid.Nil)
 
 			if tt.expectErr {
 				require.Error(t, err)
@@ -583,12 +600,66 @@ func TestUseCase_UpdateTemplateByID_OutputFormatWithoutFile(t *testing.T) {
 
 	// Attempt to update outputFormat without providing a file
 	ctx := context.Background()
-	_, err := tempSvc.UpdateTemplateByID(ctx, "xml", "Updated Desc", uuid.New(), nil)
+	_, err := tempSvc.UpdateTemplateByID(ctx, "xml", "Updated Desc", "", nil, nil, uuid.New(), nil, uuid.Nil)
 
 	require.Error(t, err)
 	assert.Contains(t, err.Error(), constant.ErrOutputFormatWithoutTemplateFile.Error())
 }
 
+func TestUseCase_UpdateTemplateByID_OutputOptionsForFixedWidth(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		options *model.OutputOptions
+		wantErr bool
+	}{
+		{name: "windows-1252 with crlf is accepted", options: &model.OutputOptions{Encoding: "windows-1252", LineEnding: "crlf"}},
+		{name: "lf line endings are rejected", options: &model.OutputOptions{LineEnding: "lf"}, wantErr: true},
+		{name: "stripped trailing newline is rejected", options: &model.OutputOptions{TrailingNewline: "strip"}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			mockTempRepo := template.NewMockRepository(ctrl)
+
+			tempSvc := &UseCase{
+				TemplateRepo:        mockTempRepo,
+				ExternalDataSources: pkg.NewSafeDataSources(map[string]pkg.DataSource{}),
+			}
+
+			templateID := uuid.New()
+
+			mockTempRepo.EXPECT().
+				FindByID(gomock.Any(), templateID, gomock.Any()).
+				Return(&template.Template{ID: templateID, OutputFormat: "fixed-width"}, nil)
+
+			if !tt.wantErr {
+				mockTempRepo.EXPECT().
+					Update(gomock.Any(), templateID, gomock.Any(), gomock.Any()).
+					Return(nil)
+				mockTempRepo.EXPECT().
+					FindByID(gomock.Any(), templateID, gomock.Any()).
+					Return(&template.Template{ID: templateID, OutputFormat: "fixed-width", OutputOptions: tt.options}, nil)
+			}
+
+			_, err := tempSvc.UpdateTemplateByID(context.Background(), "", "", "", nil, tt.options, templateID, nil, uuid.New())
+			if tt.wantErr {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), "fixed-width records end with crlf")
+
+				return
+			}
+
+			require.NoError(t, err)
+		})
+	}
+}
+
 func TestUseCase_UpdateTemplateByID_NilOutputFormatFromDB(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -635,13 +706,17 @@ func TestUseCase_UpdateTemplateByID_NilOutputFormatFromDB(t *testing.T) {
 		CloseConnection(gomock.Any()).
 		Return(nil)
 
+	mockTempRepo.EXPECT().
+		FindByID(gomock.Any(), gomock.Any(), gomock.Any()).
+		Return(&template.Template{FileName: "test_template.tpl"}, nil)
+
 	// FindOutputFormatByID returns nil output format
 	mockTempRepo.EXPECT().
-		FindOutputFormatByID(gomock.Any(), gomock.Any()).
+		FindOutputFormatByID(gomock.Any(), gomock.Any(), gomock.Any()).
 		Return(nil, nil)
 
 	ctx := context.Background()
-	_, err := tempSvc.UpdateTemplateByID(ctx, "", "Updated Desc", uuid.New(), fileHeader)
+	_, err := tempSvc.UpdateTemplateByID(ctx, "", "Updated Desc", "", nil, nil, uuid.New(), fileHeader, uuid.Nil)
 
 	require.Error(t, err)
 	assert.Contains(t, err.Error(), "output format not found for template")
@@ -652,12 +727,17 @@ func TestUseCase_BuildSetFields(t *testing.T) {
 
 	uc := &UseCase{}
 
+	ninetyDays := 90
+
 	tests := []struct {
-		name         string
-		description  string
-		outputFormat string
-		mappedFields map[string]map[string][]string
-		expectKeys   []string
+		name          string
+		description   string
+		outputFormat  string
+		priority      string
+		retentionDays *int
+		outputOptions *model.OutputOptions
+		mappedFields  map[string]map[string][]string
+		expectKeys    []string
 	}{
 		{
 			name:         "All fields provided",
@@ -680,13 +760,28 @@ func TestUseCase_BuildSetFields(t *testing.T) {
 			mappedFields: nil,
 			expectKeys:   []string{"updated_at"},
 		},
+		{
+			name:          "Only output options",
+			outputOptions: &model.OutputOptions{Encoding: "iso-8859-1"},
+			expectKeys:    []string{"output_options", "updated_at"},
+		},
+		{
+			name:       "Only priority",
+			priority:   constant.ReportPriorityLow,
+			expectKeys: []string{"priority", "updated_at"},
+		},
+		{
+			name:          "Only retention days",
+			retentionDays: &ninetyDays,
+			expectKeys:    []string{"retention_days", "updated_at"},
+		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			t.Parallel()
 
-			result := uc.buildSetFields(tt.description, tt.outputFormat, tt.mappedFields)
+			result := uc.buildSetFields(tt.description, tt.outputFormat, tt.priority, tt.retentionDays, tt.outputOptions, tt.mappedFields)
 
 			for _, key := range tt.expectKeys {
 				assert.Contains(t, result, key)
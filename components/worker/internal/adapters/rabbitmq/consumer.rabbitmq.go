@@ -37,6 +37,7 @@ import (
 type ConsumerRoutes struct {
 	conn       *rabbitmq.RabbitMQConnection
 	routes     map[string]pkgRabbitmq.QueueHandlerFunc
+	workers    map[string]int
 	numWorkers int
 	sleepFunc  func(time.Duration)
 	log.Logger
@@ -59,6 +60,7 @@ func NewConsumerRoutes(conn *rabbitmq.RabbitMQConnection, numWorkers int, logger
 	cr := &ConsumerRoutes{
 		conn:       conn,
 		routes:     make(map[string]pkgRabbitmq.QueueHandlerFunc),
+		workers:    make(map[string]int),
 		numWorkers: numWorkers,
 		sleepFunc:  time.Sleep,
 		Logger:     logger,
@@ -73,17 +75,30 @@ func NewConsumerRoutes(conn *rabbitmq.RabbitMQConnection, numWorkers int, logger
 	return cr, nil
 }
 
-// Register add a new queue to handler.
+// Register add a new queue to handler, consumed by the default number of workers.
 func (cr *ConsumerRoutes) Register(queueName string, handler pkgRabbitmq.QueueHandlerFunc) {
+	cr.RegisterLane(queueName, 0, handler)
+}
+
+// RegisterLane add a new queue to handler, consumed by numWorkers workers of its own so a busy queue
+// never holds back the others. A zero numWorkers uses the default number of workers.
+func (cr *ConsumerRoutes) RegisterLane(queueName string, numWorkers int, handler pkgRabbitmq.QueueHandlerFunc) {
+	if numWorkers <= 0 {
+		numWorkers = cr.numWorkers
+	}
+
 	cr.routes[queueName] = handler
+	cr.workers[queueName] = numWorkers
 }
 
 // RunConsumers  init consume for all registry queues.
 func (cr *ConsumerRoutes) RunConsumers(ctx context.Context, wg *sync.WaitGroup) error {
 	for queueName, handler := range cr.routes {
-		cr.Info("Starting consumer for queue " + queueName)
+		numWorkers := cr.queueWorkers(queueName)
+
+		cr.Infof("Starting consumer for queue %s with %d workers", queueName, numWorkers)
 
-		if err := cr.setupQos(); err != nil {
+		if err := cr.setupQos(numWorkers); err != nil {
 			return err
 		}
 
@@ -92,14 +107,23 @@ func (cr *ConsumerRoutes) RunConsumers(ctx context.Context, wg *sync.WaitGroup)
 			return err
 		}
 
-		cr.startWorkers(ctx, wg, messages, queueName, handler)
+		cr.startWorkers(ctx, wg, messages, queueName, numWorkers, handler)
 	}
 
 	return nil
 }
 
-func (cr *ConsumerRoutes) startWorkers(ctx context.Context, wg *sync.WaitGroup, messages <-chan amqp091.Delivery, queueName string, handler pkgRabbitmq.QueueHandlerFunc) {
-	for i := range cr.numWorkers {
+// queueWorkers returns the number of workers consuming a queue.
+func (cr *ConsumerRoutes) queueWorkers(queueName string) int {
+	if numWorkers, ok := cr.workers[queueName]; ok {
+		return numWorkers
+	}
+
+	return cr.numWorkers
+}
+
+func (cr *ConsumerRoutes) startWorkers(ctx context.Context, wg *sync.WaitGroup, messages <-chan amqp091.Delivery, queueName string, numWorkers int, handler pkgRabbitmq.QueueHandlerFunc) {
+	for i := range numWorkers {
 		wg.Add(1)
 
 		go func(workerID int, queue string, handlerFunc pkgRabbitmq.QueueHandlerFunc) {
@@ -205,12 +229,13 @@ func (cr *ConsumerRoutes) consumeMessages(queueName string) (<-chan amqp091.Deli
 }
 
 // setupQos configures QoS settings for the RabbitMQ channel to limit message prefetch count and improve message processing.
-func (cr *ConsumerRoutes) setupQos() error {
+// The limit applies to the next consumer of the channel, which prefetches DefaultPrefetchCount messages per worker.
+func (cr *ConsumerRoutes) setupQos(numWorkers int) error {
 	if cr.conn.Channel == nil {
 		return fmt.Errorf("rabbitmq channel is nil, cannot setup QoS")
 	}
 
-	return cr.conn.Channel.Qos(pkgConstant.DefaultPrefetchCount, 0, false)
+	return cr.conn.Channel.Qos(pkgConstant.DefaultPrefetchCount*numWorkers, 0, false)
 }
 
 // handleFailedMessage determines whether a failed message should be retried or sent to the DLQ.
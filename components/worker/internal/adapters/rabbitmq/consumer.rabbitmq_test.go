@@ -13,6 +13,7 @@ import (
 
 	"github.com/LerianStudio/reporter/pkg"
 	pkgConstant "github.com/LerianStudio/reporter/pkg/constant"
+	pkgRabbitmq "github.com/LerianStudio/reporter/pkg/rabbitmq"
 
 	amqp091 "github.com/rabbitmq/amqp091-go"
 	"github.com/stretchr/testify/assert"
@@ -433,3 +434,24 @@ func TestConsumerRoutes_SanitizeFailureReason(t *testing.T) {
 		})
 	}
 }
+
+func TestConsumerRoutes_RegisterLane(t *testing.T) {
+	t.Parallel()
+
+	cr := &ConsumerRoutes{
+		routes:     make(map[string]pkgRabbitmq.QueueHandlerFunc),
+		workers:    make(map[string]int),
+		numWorkers: pkgConstant.DefaultWorkerCount,
+	}
+
+	handler := func(context.Context, []byte) error { return nil }
+
+	cr.Register("reporter.generate-report.queue", handler)
+	cr.RegisterLane("reporter.generate-report.high.queue", 8, handler)
+	cr.RegisterLane("reporter.generate-report.low.queue", 0, handler)
+
+	assert.Len(t, cr.routes, 3)
+	assert.Equal(t, pkgConstant.DefaultWorkerCount, cr.queueWorkers("reporter.generate-report.queue"))
+	assert.Equal(t, 8, cr.queueWorkers("reporter.generate-report.high.queue"))
+	assert.Equal(t, pkgConstant.DefaultWorkerCount, cr.queueWorkers("reporter.generate-report.low.queue"))
+}
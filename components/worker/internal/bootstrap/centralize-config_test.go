@@ -37,16 +37,16 @@ func TestConfig_HasCryptoEncryptSecretKeyPluginCRMField(t *testing.T) {
 }
 
 // TestNewMultiQueueConsumer_ReceivesQueueName verifies that NewMultiQueueConsumer
-// accepts the queue name as a parameter instead of reading it from os.Getenv.
+// accepts the queue names as a parameter instead of reading them from os.Getenv.
 func TestNewMultiQueueConsumer_ReceivesQueueName(t *testing.T) {
 	t.Parallel()
 
-	// This test verifies that NewMultiQueueConsumer accepts a queueName and logger parameter.
+	// This test verifies that NewMultiQueueConsumer accepts the consumer lanes and logger parameter.
 
-	queueName := "reporter.generate-report.queue"
+	lanes := []ConsumerLane{{Queue: "reporter.generate-report.queue"}}
 	logger := &log.NoneLogger{}
 
-	consumer := NewMultiQueueConsumer(nil, nil, queueName, logger)
+	consumer := NewMultiQueueConsumer(nil, nil, lanes, logger)
 
 	require.NotNil(t, consumer)
 }
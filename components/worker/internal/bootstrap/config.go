@@ -20,6 +20,7 @@ import (
 	"github.com/LerianStudio/reporter/pkg/pongo"
 	reportSeaweedFS "github.com/LerianStudio/reporter/pkg/seaweedfs/report"
 	templateSeaweedFS "github.com/LerianStudio/reporter/pkg/seaweedfs/template"
+	"github.com/LerianStudio/reporter/pkg/signature"
 	"github.com/LerianStudio/reporter/pkg/storage"
 
 	libCommons "github.com/LerianStudio/lib-commons/v2/commons"
@@ -43,6 +44,12 @@ type Config struct {
 	RabbitMQPass                string `env:"RABBITMQ_DEFAULT_PASS"`
 	RabbitMQGenerateReportQueue string `env:"RABBITMQ_GENERATE_REPORT_QUEUE"`
 	RabbitMQNumWorkers          int    `env:"RABBITMQ_NUMBERS_OF_WORKERS"`
+	// Priority lanes: the queues of the high and low priority reports and the workers consuming each of them.
+	// A lane without a queue is not consumed; a lane without a worker count uses the default one.
+	RabbitMQHighPriorityQueue   string `env:"RABBITMQ_GENERATE_REPORT_HIGH_QUEUE"`
+	RabbitMQHighPriorityWorkers int    `env:"RABBITMQ_HIGH_PRIORITY_WORKERS"`
+	RabbitMQLowPriorityQueue    string `env:"RABBITMQ_GENERATE_REPORT_LOW_QUEUE"`
+	RabbitMQLowPriorityWorkers  int    `env:"RABBITMQ_LOW_PRIORITY_WORKERS"`
 	RabbitMQHealthCheckURL      string `env:"RABBITMQ_HEALTH_CHECK_URL"`
 	OtelServiceName             string `env:"OTEL_RESOURCE_SERVICE_NAME"`
 	OtelLibraryName             string `env:"OTEL_LIBRARY_NAME"`
@@ -70,9 +77,25 @@ type Config struct {
 	// Crypto configuration envs (for plugin_crm decryption)
 	CryptoHashSecretKeyPluginCRM    string `env:"CRYPTO_HASH_SECRET_KEY_PLUGIN_CRM"`
 	CryptoEncryptSecretKeyPluginCRM string `env:"CRYPTO_ENCRYPT_SECRET_KEY_PLUGIN_CRM"`
+	// Key checking the row-level scope and filters signed by the manager
+	RowLevelSigningKey string `env:"ROW_LEVEL_SIGNING_KEY"`
 	// PDF Pool configuration envs
 	PdfPoolWorkers        int `env:"PDF_POOL_WORKERS" default:"2"`
 	PdfPoolTimeoutSeconds int `env:"PDF_TIMEOUT_SECONDS" default:"90"`
+	// Report signing envs: a PKCS#12 certificate from a file path or its base64 content (secret providers)
+	SigningCertificatePath     string `env:"SIGNING_CERTIFICATE_PATH"`
+	SigningCertificate         string `env:"SIGNING_CERTIFICATE"`
+	SigningCertificatePassword string `env:"SIGNING_CERTIFICATE_PASSWORD"`
+	// Report cancellation: how often the status of a report being generated is checked
+	ReportCancellationPollSeconds int `env:"REPORT_CANCELLATION_POLL_SECONDS" default:"5"`
+	// Report heartbeat: how often a report being generated is recorded as alive for the manager's stuck-report reaper
+	ReportHeartbeatSeconds int `env:"REPORT_HEARTBEAT_SECONDS" default:"30"`
+	// Output cache: reuse the output of a finished report with the same template revision, filters and
+	// datasource data versions (DATASOURCE_{NAME}_DATA_VERSION)
+	ReportOutputCacheEnabled bool `env:"REPORT_OUTPUT_CACHE_ENABLED"`
+	// Holiday calendar of the business day filters (BR or none) and extra holidays as comma-separated YYYY-MM-DD dates
+	HolidayCalendar      string `env:"HOLIDAY_CALENDAR"`
+	HolidayCalendarDates string `env:"HOLIDAY_CALENDAR_DATES"`
 }
 
 // Validate checks that all required configuration fields are present.
@@ -100,6 +123,21 @@ func (c *Config) Validate() error {
 		errs = append(errs, "RABBITMQ_GENERATE_REPORT_QUEUE is required")
 	}
 
+	workerCounts := []struct {
+		value int
+		name  string
+	}{
+		{c.RabbitMQNumWorkers, "RABBITMQ_NUMBERS_OF_WORKERS"},
+		{c.RabbitMQHighPriorityWorkers, "RABBITMQ_HIGH_PRIORITY_WORKERS"},
+		{c.RabbitMQLowPriorityWorkers, "RABBITMQ_LOW_PRIORITY_WORKERS"},
+	}
+
+	for _, w := range workerCounts {
+		if w.value < 0 {
+			errs = append(errs, w.name+" must not be negative")
+		}
+	}
+
 	if c.MongoDBHost == "" {
 		errs = append(errs, "MONGO_HOST is required")
 	}
@@ -151,6 +189,45 @@ func (c *Config) validateProductionConfig(errs []string) []string {
 	return errs
 }
 
+// heartbeatInterval returns how often a report being generated is recorded as alive. The heartbeat is
+// always on, since the stuck-report reaper of the manager relies on it.
+func (c *Config) heartbeatInterval() time.Duration {
+	if c.ReportHeartbeatSeconds <= 0 {
+		return pkgConstant.DefaultReportHeartbeatSeconds * time.Second
+	}
+
+	return time.Duration(c.ReportHeartbeatSeconds) * time.Second
+}
+
+// holidayCalendarDates returns the extra holiday dates of HOLIDAY_CALENDAR_DATES.
+func (c *Config) holidayCalendarDates() []string {
+	var dates []string
+
+	for _, date := range strings.Split(c.HolidayCalendarDates, ",") {
+		if date = strings.TrimSpace(date); date != "" {
+			dates = append(dates, date)
+		}
+	}
+
+	return dates
+}
+
+// consumerLanes returns the generation queues consumed by the worker: the generation queue with
+// RABBITMQ_NUMBERS_OF_WORKERS workers and each priority lane configured with its own workers.
+func (c *Config) consumerLanes() []ConsumerLane {
+	lanes := []ConsumerLane{{Queue: c.RabbitMQGenerateReportQueue, Workers: c.RabbitMQNumWorkers}}
+
+	if c.RabbitMQHighPriorityQueue != "" {
+		lanes = append(lanes, ConsumerLane{Queue: c.RabbitMQHighPriorityQueue, Workers: c.RabbitMQHighPriorityWorkers})
+	}
+
+	if c.RabbitMQLowPriorityQueue != "" {
+		lanes = append(lanes, ConsumerLane{Queue: c.RabbitMQLowPriorityQueue, Workers: c.RabbitMQLowPriorityWorkers})
+	}
+
+	return lanes
+}
+
 // InitWorker initializes and configures the application's dependencies and returns the Service instance.
 // Uses a cleanup stack pattern: if any initialization step fails, all previously
 // opened connections are closed in reverse order to prevent resource leaks.
@@ -169,6 +246,10 @@ func InitWorker() (_ *Service, err error) {
 		return nil, fmt.Errorf("failed to register pongo2 filters and tags: %w", err)
 	}
 
+	if err := pongo.ConfigureHolidayCalendar(cfg.HolidayCalendar, cfg.holidayCalendarDates()); err != nil {
+		return nil, fmt.Errorf("failed to configure HOLIDAY_CALENDAR: %w", err)
+	}
+
 	logger, err := libZap.InitializeLoggerWithError()
 	if err != nil {
 		return nil, fmt.Errorf("failed to initialize logger: %w", err)
@@ -290,6 +371,36 @@ func InitWorker() (_ *Service, err error) {
 	externalDataSources := pkg.NewSafeDataSources(externalDataSourcesMap)
 	healthChecker := pkg.NewHealthChecker(&externalDataSourcesMap, circuitBreakerManager, logger)
 
+	// Load the row-level rules used to verify the mandatory filters of every report message
+	rowLevelPolicy, err := pkg.LoadRowLevelPolicy(logger)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load row-level filter rules: %w", err)
+	}
+
+	if rowLevelPolicy.Enabled() && cfg.RowLevelSigningKey == "" {
+		return nil, fmt.Errorf("ROW_LEVEL_SIGNING_KEY is required when row-level filter rules are configured")
+	}
+
+	rowLevelPolicy.WithSigningKey(cfg.RowLevelSigningKey)
+
+	// Load the field transformers of encrypted datasources; plugin_crm keeps its built-in transformer unless overridden
+	fieldTransformers, err := pkg.LoadFieldTransformerRegistry(logger)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load field transformers: %w", err)
+	}
+
+	fieldTransformers.RegisterDefault(pkg.PluginCRMFieldTransformer(cfg.CryptoHashSecretKeyPluginCRM, cfg.CryptoEncryptSecretKeyPluginCRM))
+
+	// Load the certificate used to sign reports; without one, reports are not signed
+	signer, err := signature.LoadSigner(cfg.SigningCertificatePath, cfg.SigningCertificate, cfg.SigningCertificatePassword)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load signing certificate: %w", err)
+	}
+
+	if signer != nil {
+		logger.Infof("Reports will be signed with certificate %s", signer.Certificate().Subject)
+	}
+
 	// Initialize PDF Pool for PDF generation
 	pdfPool := pdf.NewWorkerPool(cfg.PdfPoolWorkers, time.Duration(cfg.PdfPoolTimeoutSeconds)*time.Second, logger)
 	logger.Infof("PDF Pool initialized with %d workers and %d seconds timeout", cfg.PdfPoolWorkers, cfg.PdfPoolTimeoutSeconds)
@@ -300,16 +411,24 @@ func InitWorker() (_ *Service, err error) {
 	})
 
 	service := &services.UseCase{
-		TemplateSeaweedFS:               templateSeaweedFSRepository,
-		ReportSeaweedFS:                 reportSeaweedFSRepository,
-		ExternalDataSources:             externalDataSources,
-		ReportDataRepo:                  reportMongoDBRepository,
-		CircuitBreakerManager:           circuitBreakerManager,
-		HealthChecker:                   healthChecker,
-		ReportTTL:                       "", // TTL not supported in S3 mode - use bucket lifecycle policies
-		PdfPool:                         pdfPool,
-		CryptoHashSecretKeyPluginCRM:    cfg.CryptoHashSecretKeyPluginCRM,
-		CryptoEncryptSecretKeyPluginCRM: cfg.CryptoEncryptSecretKeyPluginCRM,
+		TemplateSeaweedFS:        templateSeaweedFSRepository,
+		ReportSeaweedFS:          reportSeaweedFSRepository,
+		ExternalDataSources:      externalDataSources,
+		ReportDataRepo:           reportMongoDBRepository,
+		CircuitBreakerManager:    circuitBreakerManager,
+		HealthChecker:            healthChecker,
+		ReportTTL:                "", // TTL not supported in S3 mode - use bucket lifecycle policies
+		PdfPool:                  pdfPool,
+		FieldTransformers:        fieldTransformers,
+		RowLevelPolicy:           rowLevelPolicy,
+		Signer:                   signer,
+		CancellationPollInterval: time.Duration(cfg.ReportCancellationPollSeconds) * time.Second,
+		HeartbeatInterval:        cfg.heartbeatInterval(),
+		OutputCacheEnabled:       cfg.ReportOutputCacheEnabled,
+	}
+
+	if cfg.ReportOutputCacheEnabled {
+		logger.Info("Report output cache enabled for datasources with a data version")
 	}
 
 	logger.Infof("Reports will be stored permanently (no TTL - use S3 bucket lifecycle policies for expiration)")
@@ -322,7 +441,7 @@ func InitWorker() (_ *Service, err error) {
 		healthChecker.Stop()
 	})
 
-	multiQueueConsumer := NewMultiQueueConsumer(routes, service, cfg.RabbitMQGenerateReportQueue, logger)
+	multiQueueConsumer := NewMultiQueueConsumer(routes, service, cfg.consumerLanes(), logger)
 
 	healthServer := NewHealthServer(cfg.HealthPort, rabbitMQConnection, logger)
 	logger.Infof("Health server configured on port %s (/health, /ready)", cfg.HealthPort)
@@ -7,6 +7,7 @@ package bootstrap
 import (
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -257,3 +258,53 @@ func TestConfig_Validate_OptionalFieldsCanBeEmpty(t *testing.T) {
 	err := cfg.Validate()
 	require.NoError(t, err)
 }
+
+func TestConfig_Validate_NegativeWorkerCounts(t *testing.T) {
+	t.Parallel()
+
+	cfg := validWorkerConfig()
+	cfg.RabbitMQHighPriorityWorkers = -1
+
+	err := cfg.Validate()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "RABBITMQ_HIGH_PRIORITY_WORKERS must not be negative")
+}
+
+func TestConfig_ConsumerLanes(t *testing.T) {
+	t.Parallel()
+
+	cfg := validWorkerConfig()
+	cfg.RabbitMQNumWorkers = 2
+
+	assert.Equal(t, []ConsumerLane{{Queue: "reporter.generate-report.queue", Workers: 2}}, cfg.consumerLanes())
+
+	cfg.RabbitMQHighPriorityQueue = "reporter.generate-report.high.queue"
+	cfg.RabbitMQHighPriorityWorkers = 4
+	cfg.RabbitMQLowPriorityQueue = "reporter.generate-report.low.queue"
+
+	assert.Equal(t, []ConsumerLane{
+		{Queue: "reporter.generate-report.queue", Workers: 2},
+		{Queue: "reporter.generate-report.high.queue", Workers: 4},
+		{Queue: "reporter.generate-report.low.queue"},
+	}, cfg.consumerLanes())
+}
+
+func TestConfig_HolidayCalendarDates(t *testing.T) {
+	t.Parallel()
+
+	cfg := validWorkerConfig()
+	assert.Empty(t, cfg.holidayCalendarDates())
+
+	cfg.HolidayCalendarDates = "2026-12-24, 2026-12-31,,"
+	assert.Equal(t, []string{"2026-12-24", "2026-12-31"}, cfg.holidayCalendarDates())
+}
+
+func TestConfig_HeartbeatInterval(t *testing.T) {
+	t.Parallel()
+
+	cfg := validWorkerConfig()
+	assert.Equal(t, 30*time.Second, cfg.heartbeatInterval())
+
+	cfg.ReportHeartbeatSeconds = 10
+	assert.Equal(t, 10*time.Second, cfg.heartbeatInterval())
+}
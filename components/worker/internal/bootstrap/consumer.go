@@ -29,8 +29,15 @@ type MultiQueueConsumer struct {
 	logger         log.Logger
 }
 
-// NewMultiQueueConsumer create a new instance of MultiQueueConsumer.
-func NewMultiQueueConsumer(routes *rabbitmq.ConsumerRoutes, useCase *services.UseCase, queueName string, logger log.Logger) *MultiQueueConsumer {
+// ConsumerLane is a queue of report generation messages and the number of workers consuming it.
+// A zero Workers uses the default number of workers.
+type ConsumerLane struct {
+	Queue   string
+	Workers int
+}
+
+// NewMultiQueueConsumer create a new instance of MultiQueueConsumer consuming every lane with workers of its own.
+func NewMultiQueueConsumer(routes *rabbitmq.ConsumerRoutes, useCase *services.UseCase, lanes []ConsumerLane, logger log.Logger) *MultiQueueConsumer {
 	consumer := &MultiQueueConsumer{
 		consumerRoutes: routes,
 		UseCase:        useCase,
@@ -39,7 +46,9 @@ func NewMultiQueueConsumer(routes *rabbitmq.ConsumerRoutes, useCase *services.Us
 
 	// Registry handlers for each queue
 	if routes != nil {
-		routes.Register(queueName, consumer.handlerGenerateReport)
+		for _, lane := range lanes {
+			routes.RegisterLane(lane.Queue, lane.Workers, consumer.handlerGenerateReport)
+		}
 	}
 
 	return consumer
@@ -44,7 +44,7 @@ func TestMultiQueueConsumer_HandlerGenerateReport_ErrorClassification(t *testing
 
 				// FindByID returns a report in processing state so it doesn't skip
 				mockReportDataRepo.EXPECT().
-					FindByID(gomock.Any(), gomock.Any()).
+					FindByID(gomock.Any(), gomock.Any(), gomock.Any()).
 					Return(&reportData.Report{
 						ID:     reportID,
 						Status: "processing",
@@ -83,7 +83,7 @@ func TestMultiQueueConsumer_HandlerGenerateReport_ErrorClassification(t *testing
 
 				// FindByID returns a report in processing state so it doesn't skip
 				mockReportDataRepo.EXPECT().
-					FindByID(gomock.Any(), gomock.Any()).
+					FindByID(gomock.Any(), gomock.Any(), gomock.Any()).
 					Return(&reportData.Report{
 						ID:     reportID,
 						Status: "processing",
@@ -118,7 +118,7 @@ func TestMultiQueueConsumer_HandlerGenerateReport_ErrorClassification(t *testing
 
 				// FindByID returns a report in processing state
 				mockReportDataRepo.EXPECT().
-					FindByID(gomock.Any(), gomock.Any()).
+					FindByID(gomock.Any(), gomock.Any(), gomock.Any()).
 					Return(&reportData.Report{
 						ID:     reportID,
 						Status: "processing",
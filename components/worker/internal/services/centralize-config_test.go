@@ -7,31 +7,23 @@ package services
 import (
 	"testing"
 
+	"github.com/LerianStudio/reporter/pkg"
+	"github.com/LerianStudio/reporter/pkg/constant"
+
 	"github.com/stretchr/testify/assert"
 )
 
-// TestUseCase_HasCryptoHashSecretKeyField verifies that the worker UseCase struct
-// has a CryptoHashSecretKeyPluginCRM field for centralized configuration
-// instead of using os.Getenv("CRYPTO_HASH_SECRET_KEY_PLUGIN_CRM").
-func TestUseCase_HasCryptoHashSecretKeyField(t *testing.T) {
-	t.Parallel()
-
-	uc := &UseCase{
-		CryptoHashSecretKeyPluginCRM: "test-hash-secret-key",
-	}
-
-	assert.Equal(t, "test-hash-secret-key", uc.CryptoHashSecretKeyPluginCRM)
-}
-
-// TestUseCase_HasCryptoEncryptSecretKeyField verifies that the worker UseCase struct
-// has a CryptoEncryptSecretKeyPluginCRM field for centralized configuration
-// instead of using os.Getenv("CRYPTO_ENCRYPT_SECRET_KEY_PLUGIN_CRM").
-func TestUseCase_HasCryptoEncryptSecretKeyField(t *testing.T) {
+// TestUseCase_HasFieldTransformersField verifies that the worker UseCase struct
+// receives the datasource field transformers (and their crypto keys) through centralized
+// configuration instead of reading CRYPTO_*_SECRET_KEY_PLUGIN_CRM with os.Getenv.
+func TestUseCase_HasFieldTransformersField(t *testing.T) {
 	t.Parallel()
 
 	uc := &UseCase{
-		CryptoEncryptSecretKeyPluginCRM: "test-encrypt-secret-key",
+		FieldTransformers: pkg.NewFieldTransformerRegistry(pkg.PluginCRMFieldTransformer("test-hash-secret-key", "test-encrypt-secret-key")),
 	}
 
-	assert.Equal(t, "test-encrypt-secret-key", uc.CryptoEncryptSecretKeyPluginCRM)
+	transformer := uc.FieldTransformers.Get(constant.PluginCRMDataSourceID)
+	assert.Equal(t, "test-hash-secret-key", transformer.HashSecretKey)
+	assert.Equal(t, "test-encrypt-secret-key", transformer.EncryptSecretKey)
 }
@@ -0,0 +1,236 @@
+// Copyright (c) 2026 Lerian Studio. All rights reserved.
+// Use of this source code is governed by the Elastic License 2.0
+// that can be found in the LICENSE file.
+
+package services
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"maps"
+	"regexp"
+	"strings"
+
+	"github.com/LerianStudio/reporter/pkg"
+	"github.com/LerianStudio/reporter/pkg/constant"
+	"github.com/LerianStudio/reporter/pkg/model"
+	"github.com/LerianStudio/reporter/pkg/templateutils"
+
+	libCommons "github.com/LerianStudio/lib-commons/v2/commons"
+	"github.com/LerianStudio/lib-commons/v2/commons/log"
+	libOtel "github.com/LerianStudio/lib-commons/v2/commons/opentelemetry"
+	"go.mongodb.org/mongo-driver/mongo"
+
+	// otel/attribute is used for span attribute types (no lib-commons wrapper available)
+	"go.opentelemetry.io/otel/attribute"
+	// otel/trace is used for trace.Span parameter types in internal helpers
+	"go.opentelemetry.io/otel/trace"
+)
+
+// dynamicPartialTagPattern matches the include, extends and import tags left after expanding the partials
+// referenced by a literal name, i.e. the ones whose partial is only known when rendering.
+var dynamicPartialTagPattern = regexp.MustCompile(`{%-?\s*(include|extends|import)\b`)
+
+// currentTimeTagPattern matches the date_time and now tags, which render the time of the generation.
+var currentTimeTagPattern = regexp.MustCompile(`{%-?\s*(date_time|now)\b`)
+
+// outputFingerprintInput is everything the output of a report depends on. Its JSON form is hashed into the
+// fingerprint; encoding/json sorts map keys, so equal inputs always give the same fingerprint.
+type outputFingerprintInput struct {
+	Template      string                                                 `json:"template"`
+	OutputFormat  string                                                 `json:"outputFormat"`
+	OutputOptions *model.OutputOptions                                   `json:"outputOptions,omitempty"`
+	Locale        string                                                 `json:"locale,omitempty"`
+	Timezone      string                                                 `json:"timezone,omitempty"`
+	DataQueries   map[string]map[string][]string                         `json:"dataQueries"`
+	Filters       map[string]map[string]map[string]model.FilterCondition `json:"filters,omitempty"`
+	RowLevelScope map[string]string                                      `json:"rowLevelScope,omitempty"`
+	DataVersions  map[string]string                                      `json:"dataVersions"`
+	Signer        string                                                 `json:"signer,omitempty"`
+}
+
+// outputFingerprint returns the fingerprint of the output of a report: a hash of the template revision with its
+// partials, the resolved filters and options of the report and the data version of every datasource it queries.
+// It is empty when the output cache is disabled, the request did not declare a closed period or the output can not
+// be reused: a datasource queried has no data version, or the template renders the current time or references
+// partials by variable names.
+func (uc *UseCase) outputFingerprint(ctx context.Context, message GenerateReportMessage, templateBytes []byte) string {
+	if !uc.OutputCacheEnabled || !message.ReuseOutput {
+		return ""
+	}
+
+	logger, _, _, _ := libCommons.NewTrackingFromContext(ctx) //nolint:dogsled // only logger needed from tracking context
+
+	dataVersions := make(map[string]string, len(message.DataQueries))
+
+	for databaseName := range message.DataQueries {
+		dataSource, exists := uc.ExternalDataSources.Get(databaseName)
+		if !exists || dataSource.DataVersion == "" {
+			logger.Debugf("Output of report %s is not reusable: datasource %s has no data version", message.ReportID, databaseName)
+
+			return ""
+		}
+
+		dataVersions[databaseName] = dataSource.DataVersion
+	}
+
+	expanded, err := templateutils.ExpandPartials(string(templateBytes), func(name string) (string, error) {
+		partial, errGet := uc.TemplateSeaweedFS.Get(ctx, pkg.TenantPartialObjectName(message.OrganizationID, name))
+
+		return string(partial), errGet
+	})
+	if err != nil {
+		logger.Warnf("Output of report %s is not reusable: failed to expand the partials of its template: %v", message.ReportID, err)
+
+		return ""
+	}
+
+	if dynamicPartialTagPattern.MatchString(expanded) {
+		logger.Debugf("Output of report %s is not reusable: its template references partials by variable names", message.ReportID)
+
+		return ""
+	}
+
+	if currentTimeTagPattern.MatchString(expanded) {
+		logger.Debugf("Output of report %s is not reusable: its template renders the current time", message.ReportID)
+
+		return ""
+	}
+
+	templateHash := sha256.Sum256([]byte(expanded))
+
+	input := outputFingerprintInput{
+		Template:      hex.EncodeToString(templateHash[:]),
+		OutputFormat:  strings.ToLower(message.OutputFormat),
+		OutputOptions: message.OutputOptions,
+		Locale:        message.Locale,
+		Timezone:      message.Timezone,
+		DataQueries:   message.DataQueries,
+		Filters:       message.Filters,
+		RowLevelScope: message.RowLevelScope,
+		DataVersions:  dataVersions,
+	}
+
+	if uc.Signer != nil {
+		certificateHash := sha256.Sum256(uc.Signer.Certificate().Raw)
+		input.Signer = hex.EncodeToString(certificateHash[:])
+	}
+
+	encoded, err := json.Marshal(input)
+	if err != nil {
+		logger.Warnf("Output of report %s is not reusable: failed to encode its fingerprint: %v", message.ReportID, err)
+
+		return ""
+	}
+
+	fingerprint := sha256.Sum256(encoded)
+
+	return hex.EncodeToString(fingerprint[:])
+}
+
+// reuseReportOutput finishes a report with a copy of the output of the latest finished report of the organization
+// with the same fingerprint, recording the report reused in its metadata. It reports false when there is no such
+// report or its output could not be copied, in which case the report is generated as usual.
+func (uc *UseCase) reuseReportOutput(ctx context.Context, message GenerateReportMessage, fingerprint string, span *trace.Span, logger log.Logger) (bool, error) {
+	_, tracer, reqId, _ := libCommons.NewTrackingFromContext(ctx)
+
+	ctx, spanReuse := tracer.Start(ctx, "service.report.reuse_output")
+	defer spanReuse.End()
+
+	spanReuse.SetAttributes(
+		attribute.String("app.request.request_id", reqId),
+		attribute.String("app.request.fingerprint", fingerprint),
+	)
+
+	source, err := uc.ReportDataRepo.FindFinishedByFingerprint(ctx, fingerprint, message.OrganizationID)
+	if err != nil {
+		if !errors.Is(err, mongo.ErrNoDocuments) {
+			libOtel.HandleSpanError(&spanReuse, "Failed to find report by fingerprint", err)
+
+			logger.Warnf("Failed to find a finished report to reuse for report %s, generating it: %v", message.ReportID, err)
+		}
+
+		return false, nil
+	}
+
+	if source.ID == message.ReportID {
+		return false, nil
+	}
+
+	spanReuse.SetAttributes(attribute.String("app.response.reused_from", source.ID.String()))
+
+	objectName := pkg.TenantObjectName(source.OrganizationID,
+		source.TemplateID.String()+"/"+source.ID.String()+"."+templateutils.GetFileExtension(strings.ToLower(message.OutputFormat)))
+
+	output, err := uc.ReportSeaweedFS.Get(ctx, objectName)
+	if err != nil {
+		libOtel.HandleSpanError(&spanReuse, "Failed to get output of report to reuse", err)
+
+		logger.Warnf("Failed to get the output of report %s to reuse for report %s, generating it: %v", source.ID, message.ReportID, err)
+
+		return false, nil
+	}
+
+	var detachedSignature []byte
+
+	if signatureKind, _ := source.Metadata[constant.ReportSignatureMetadataKey].(string); signatureKind == constant.SignatureCMS {
+		detachedSignature, err = uc.ReportSeaweedFS.Get(ctx, objectName+"."+constant.SignatureFileExtension)
+		if err != nil {
+			libOtel.HandleSpanError(&spanReuse, "Failed to get signature of report to reuse", err)
+
+			logger.Warnf("Failed to get the signature of report %s to reuse for report %s, generating it: %v", source.ID, message.ReportID, err)
+
+			return false, nil
+		}
+	}
+
+	if err := checkCancellation(ctx); err != nil {
+		return true, err
+	}
+
+	if err := uc.saveReport(ctx, message, string(output)); err != nil {
+		return true, uc.handleErrorWithUpdate(ctx, message.ReportID, span, "Error saving reused report", err, logger)
+	}
+
+	if detachedSignature != nil {
+		if err := uc.saveReportSignature(ctx, message, detachedSignature); err != nil {
+			return true, uc.handleErrorWithUpdate(ctx, message.ReportID, span, "Error saving reused report signature", err, logger)
+		}
+	}
+
+	metadata := maps.Clone(source.Metadata)
+	if metadata == nil {
+		metadata = make(map[string]any)
+	}
+
+	metadata[constant.ReportFingerprintMetadataKey] = fingerprint
+	metadata[constant.ReportReusedFromMetadataKey] = source.ID.String()
+
+	if err := uc.markReportAsFinished(ctx, message.ReportID, metadata, span, logger); err != nil {
+		return true, err
+	}
+
+	logger.Infof("Report %s finished with the output of report %s", message.ReportID, source.ID)
+
+	return true, nil
+}
+
+// finishedMetadata returns the metadata of a generated report: how it was signed and the fingerprint of its
+// output when reusable. It is nil when there is neither.
+func (uc *UseCase) finishedMetadata(message GenerateReportMessage, fingerprint string) map[string]any {
+	metadata := uc.signatureMetadata(message)
+	if fingerprint == "" {
+		return metadata
+	}
+
+	if metadata == nil {
+		metadata = make(map[string]any)
+	}
+
+	metadata[constant.ReportFingerprintMetadataKey] = fingerprint
+
+	return metadata
+}
@@ -0,0 +1,312 @@
+// Copyright (c) 2026 Lerian Studio. All rights reserved.
+// Use of this source code is governed by the Elastic License 2.0
+// that can be found in the LICENSE file.
+
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/LerianStudio/reporter/pkg"
+	"github.com/LerianStudio/reporter/pkg/constant"
+	"github.com/LerianStudio/reporter/pkg/model"
+	reportData "github.com/LerianStudio/reporter/pkg/mongodb/report"
+	postgres2 "github.com/LerianStudio/reporter/pkg/postgres"
+	"github.com/LerianStudio/reporter/pkg/seaweedfs/report"
+	"github.com/LerianStudio/reporter/pkg/seaweedfs/template"
+
+	libCommons "github.com/LerianStudio/lib-commons/v2/commons"
+	"github.com/LerianStudio/lib-commons/v2/commons/zap"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.uber.org/mock/gomock"
+)
+
+func TestUseCase_OutputFingerprint(t *testing.T) {
+	t.Parallel()
+
+	orgId := uuid.New()
+	templateBytes := []byte(`{% include "headers/bank" %}{% for a in ledger.account %}{{ a.id }}{% endfor %}`)
+
+	message := GenerateReportMessage{
+		TemplateID:     uuid.New(),
+		ReportID:       uuid.New(),
+		OrganizationID: orgId,
+		OutputFormat:   "CSV",
+		DataQueries:    map[string]map[string][]string{"ledger": {"account": {"id"}}},
+		Filters: map[string]map[string]map[string]model.FilterCondition{
+			"ledger": {"account": {"created_at": {Between: []any{"2025-01-01", "2025-12-31"}}}},
+		},
+		ReuseOutput: true,
+	}
+
+	dataSources := func(version string) *pkg.SafeDataSources {
+		return pkg.NewSafeDataSources(map[string]pkg.DataSource{"ledger": {DataVersion: version}})
+	}
+
+	partialStorage := func(t *testing.T, content string) *template.MockRepository {
+		t.Helper()
+
+		ctrl := gomock.NewController(t)
+		mockTemplateRepo := template.NewMockRepository(ctrl)
+		mockTemplateRepo.EXPECT().
+			Get(gomock.Any(), pkg.TenantPartialObjectName(orgId, "headers/bank")).
+			Return([]byte(content), nil).
+			AnyTimes()
+
+		return mockTemplateRepo
+	}
+
+	fingerprint := func(t *testing.T, uc *UseCase, msg GenerateReportMessage, tpl []byte) string {
+		t.Helper()
+
+		return uc.outputFingerprint(context.Background(), msg, tpl)
+	}
+
+	t.Run("Same inputs give the same fingerprint", func(t *testing.T) {
+		t.Parallel()
+
+		uc := &UseCase{OutputCacheEnabled: true, ExternalDataSources: dataSources("2025"), TemplateSeaweedFS: partialStorage(t, "Bank")}
+
+		first := fingerprint(t, uc, message, templateBytes)
+		require.Len(t, first, 64)
+
+		other := message
+		other.ReportID = uuid.New()
+		other.TemplateID = uuid.New()
+		other.OutputFormat = "csv"
+
+		assert.Equal(t, first, fingerprint(t, uc, other, templateBytes), "report and template IDs are not part of the fingerprint")
+	})
+
+	t.Run("Any input change gives another fingerprint", func(t *testing.T) {
+		t.Parallel()
+
+		uc := &UseCase{OutputCacheEnabled: true, ExternalDataSources: dataSources("2025"), TemplateSeaweedFS: partialStorage(t, "Bank")}
+		base := fingerprint(t, uc, message, templateBytes)
+
+		otherFilters := message
+		otherFilters.Filters = map[string]map[string]map[string]model.FilterCondition{
+			"ledger": {"account": {"created_at": {Between: []any{"2024-01-01", "2024-12-31"}}}},
+		}
+		assert.NotEqual(t, base, fingerprint(t, uc, otherFilters, templateBytes))
+
+		otherLocale := message
+		otherLocale.Locale = "pt-BR"
+		assert.NotEqual(t, base, fingerprint(t, uc, otherLocale, templateBytes))
+
+		assert.NotEqual(t, base, fingerprint(t, uc, message, append([]byte("# "), templateBytes...)))
+
+		newVersion := &UseCase{OutputCacheEnabled: true, ExternalDataSources: dataSources("2025-restated"), TemplateSeaweedFS: partialStorage(t, "Bank")}
+		assert.NotEqual(t, base, fingerprint(t, newVersion, message, templateBytes))
+
+		newPartial := &UseCase{OutputCacheEnabled: true, ExternalDataSources: dataSources("2025"), TemplateSeaweedFS: partialStorage(t, "Bank v2")}
+		assert.NotEqual(t, base, fingerprint(t, newPartial, message, templateBytes))
+	})
+
+	t.Run("Not reusable", func(t *testing.T) {
+		t.Parallel()
+
+		disabled := &UseCase{ExternalDataSources: dataSources("2025")}
+		assert.Empty(t, fingerprint(t, disabled, message, templateBytes))
+
+		unversioned := &UseCase{OutputCacheEnabled: true, ExternalDataSources: dataSources("")}
+		assert.Empty(t, fingerprint(t, unversioned, message, templateBytes))
+
+		unknown := &UseCase{OutputCacheEnabled: true, ExternalDataSources: pkg.NewSafeDataSources(nil)}
+		assert.Empty(t, fingerprint(t, unknown, message, templateBytes))
+
+		dynamic := &UseCase{OutputCacheEnabled: true, ExternalDataSources: dataSources("2025")}
+		assert.Empty(t, fingerprint(t, dynamic, message, []byte(`{% include header_name %}`)))
+
+		generatedAt := &UseCase{OutputCacheEnabled: true, ExternalDataSources: dataSources("2025")}
+		assert.Empty(t, fingerprint(t, generatedAt, message, []byte(`Generated at {% date_time "dd/MM/YYYY HH:mm" %}`)))
+		assert.Empty(t, fingerprint(t, generatedAt, message, []byte(`Generated at {%- now "2006-01-02" -%}`)))
+
+		openPeriod := message
+		openPeriod.ReuseOutput = false
+
+		notRequested := &UseCase{OutputCacheEnabled: true, ExternalDataSources: dataSources("2025"), TemplateSeaweedFS: partialStorage(t, "Bank")}
+		assert.Empty(t, fingerprint(t, notRequested, openPeriod, templateBytes))
+	})
+}
+
+func TestUseCase_ReuseReportOutput(t *testing.T) {
+	t.Parallel()
+
+	orgId := uuid.New()
+	templateId := uuid.New()
+	sourceId := uuid.New()
+	fingerprint := "f1ee"
+
+	message := GenerateReportMessage{
+		TemplateID:     templateId,
+		ReportID:       uuid.New(),
+		OrganizationID: orgId,
+		OutputFormat:   "csv",
+	}
+
+	sourceObject := pkg.TenantObjectName(orgId, templateId.String()+"/"+sourceId.String()+".csv")
+	targetObject := pkg.TenantObjectName(orgId, templateId.String()+"/"+message.ReportID.String()+".csv")
+
+	tests := []struct {
+		name       string
+		mockSetup  func(reportRepo *reportData.MockRepository, storage *report.MockRepository)
+		wantReused bool
+	}{
+		{
+			name: "Reuses the output and signature of a finished report",
+			mockSetup: func(reportRepo *reportData.MockRepository, storage *report.MockRepository) {
+				reportRepo.EXPECT().
+					FindFinishedByFingerprint(gomock.Any(), fingerprint, orgId).
+					Return(&reportData.Report{
+						ID:             sourceId,
+						TemplateID:     templateId,
+						OrganizationID: orgId,
+						Status:         constant.FinishedStatus,
+						Metadata:       map[string]any{constant.ReportSignatureMetadataKey: constant.SignatureCMS, constant.ReportFingerprintMetadataKey: fingerprint},
+					}, nil)
+
+				storage.EXPECT().Get(gomock.Any(), sourceObject).Return([]byte("id\n1\n"), nil)
+				storage.EXPECT().Get(gomock.Any(), sourceObject+".p7s").Return([]byte("signature"), nil)
+				storage.EXPECT().Put(gomock.Any(), targetObject, "text/csv", []byte("id\n1\n"), "").Return(nil)
+				storage.EXPECT().Put(gomock.Any(), targetObject+".p7s", constant.SignatureContentType, []byte("signature"), "").Return(nil)
+
+				reportRepo.EXPECT().
+					UpdateReportStatusById(gomock.Any(), constant.FinishedStatus, message.ReportID, gomock.Any(), map[string]any{
+						constant.ReportSignatureMetadataKey:   constant.SignatureCMS,
+						constant.ReportFingerprintMetadataKey: fingerprint,
+						constant.ReportReusedFromMetadataKey:  sourceId.String(),
+					}).
+					Return(nil)
+			},
+			wantReused: true,
+		},
+		{
+			name: "No finished report with the fingerprint",
+			mockSetup: func(reportRepo *reportData.MockRepository, _ *report.MockRepository) {
+				reportRepo.EXPECT().FindFinishedByFingerprint(gomock.Any(), fingerprint, orgId).Return(nil, mongo.ErrNoDocuments)
+			},
+		},
+		{
+			name: "Lookup failure falls back to generation",
+			mockSetup: func(reportRepo *reportData.MockRepository, _ *report.MockRepository) {
+				reportRepo.EXPECT().FindFinishedByFingerprint(gomock.Any(), fingerprint, orgId).Return(nil, errors.New("connection refused"))
+			},
+		},
+		{
+			name: "Output of the finished report no longer stored",
+			mockSetup: func(reportRepo *reportData.MockRepository, storage *report.MockRepository) {
+				reportRepo.EXPECT().
+					FindFinishedByFingerprint(gomock.Any(), fingerprint, orgId).
+					Return(&reportData.Report{ID: sourceId, TemplateID: templateId, OrganizationID: orgId, Status: constant.FinishedStatus}, nil)
+
+				storage.EXPECT().Get(gomock.Any(), sourceObject).Return(nil, errors.New("not found"))
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockReportDataRepo := reportData.NewMockRepository(ctrl)
+			mockStorage := report.NewMockRepository(ctrl)
+
+			tt.mockSetup(mockReportDataRepo, mockStorage)
+
+			uc := &UseCase{ReportDataRepo: mockReportDataRepo, ReportSeaweedFS: mockStorage}
+
+			_, tracer, _, _ := libCommons.NewTrackingFromContext(context.Background()) //nolint:dogsled // only tracer needed
+			_, span := tracer.Start(context.Background(), "test")
+
+			reused, err := uc.reuseReportOutput(context.Background(), message, fingerprint, &span, zap.InitializeLogger())
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantReused, reused)
+		})
+	}
+}
+
+func TestUseCase_FinishedMetadata(t *testing.T) {
+	t.Parallel()
+
+	message := GenerateReportMessage{OutputFormat: "csv"}
+
+	assert.Nil(t, (&UseCase{}).finishedMetadata(message, ""))
+	assert.Equal(t, map[string]any{constant.ReportFingerprintMetadataKey: "f1ee"}, (&UseCase{}).finishedMetadata(message, "f1ee"))
+}
+
+func TestUseCase_GenerateReport_ReusesOutput(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockTemplateRepo := template.NewMockRepository(ctrl)
+	mockReportRepo := report.NewMockRepository(ctrl)
+	mockPostgresRepo := postgres2.NewMockRepository(ctrl)
+	mockReportDataRepo := reportData.NewMockRepository(ctrl)
+
+	templateID := uuid.New()
+	reportID := uuid.New()
+	sourceID := uuid.New()
+
+	bodyBytes, _ := json.Marshal(GenerateReportMessage{
+		TemplateID:   templateID,
+		ReportID:     reportID,
+		OutputFormat: "txt",
+		DataQueries:  map[string]map[string][]string{"onboarding": {"organization": {"name"}}},
+		ReuseOutput:  true,
+	})
+
+	mockReportDataRepo.EXPECT().FindByID(gomock.Any(), reportID, gomock.Any()).Return(&reportData.Report{ID: reportID, Status: constant.ProcessingStatus}, nil)
+	mockTemplateRepo.EXPECT().Get(gomock.Any(), templateID.String()).Return([]byte("Hello {{ onboarding.organization.0.name }}"), nil)
+
+	mockReportDataRepo.EXPECT().
+		FindFinishedByFingerprint(gomock.Any(), gomock.Any(), uuid.Nil).
+		Return(&reportData.Report{ID: sourceID, TemplateID: templateID, Status: constant.FinishedStatus}, nil)
+
+	mockReportRepo.EXPECT().Get(gomock.Any(), templateID.String()+"/"+sourceID.String()+".txt").Return([]byte("Hello World"), nil)
+	mockReportRepo.EXPECT().Put(gomock.Any(), templateID.String()+"/"+reportID.String()+".txt", "text/plain", []byte("Hello World"), "").Return(nil)
+
+	// The datasource is never queried: the output of the finished report is reused
+	mockReportDataRepo.EXPECT().
+		UpdateReportStatusById(gomock.Any(), constant.FinishedStatus, reportID, gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, _ string, _ uuid.UUID, _ any, metadata map[string]any) error {
+			assert.Equal(t, sourceID.String(), metadata[constant.ReportReusedFromMetadataKey])
+			assert.NotEmpty(t, metadata[constant.ReportFingerprintMetadataKey])
+
+			return nil
+		})
+
+	logger, _, _, _ := libCommons.NewTrackingFromContext(context.Background())
+
+	useCase := &UseCase{
+		TemplateSeaweedFS:     mockTemplateRepo,
+		ReportSeaweedFS:       mockReportRepo,
+		ReportDataRepo:        mockReportDataRepo,
+		CircuitBreakerManager: pkg.NewCircuitBreakerManager(logger),
+		OutputCacheEnabled:    true,
+		ExternalDataSources: pkg.NewSafeDataSources(map[string]pkg.DataSource{
+			"onboarding": {
+				Initialized:        true,
+				DatabaseType:       "postgresql",
+				PostgresRepository: mockPostgresRepo,
+				DataVersion:        "2025-12",
+			},
+		}),
+	}
+
+	err := useCase.GenerateReport(context.Background(), bodyBytes)
+	require.NoError(t, err)
+}
@@ -0,0 +1,82 @@
+// Copyright (c) 2026 Lerian Studio. All rights reserved.
+// Use of this source code is governed by the Elastic License 2.0
+// that can be found in the LICENSE file.
+
+package services
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/LerianStudio/reporter/pkg/constant"
+
+	"github.com/LerianStudio/lib-commons/v2/commons/log"
+)
+
+// errReportCancelled is the cancellation cause of the generation context of a report cancelled through the manager.
+var errReportCancelled = errors.New("report cancelled")
+
+// watchCancellation returns the generation context of a report, cancelled with errReportCancelled as soon as
+// the report is found Cancelled. While the report is generated its status is polled every CancellationPollInterval,
+// which also interrupts in-flight datasource queries; a zero interval disables the polling.
+// The returned stop function must be called once the generation is over.
+func (uc *UseCase) watchCancellation(ctx context.Context, message GenerateReportMessage, logger log.Logger) (context.Context, func()) {
+	ctx, cancel := context.WithCancelCause(ctx)
+
+	if uc.CancellationPollInterval <= 0 {
+		return ctx, func() { cancel(nil) }
+	}
+
+	done := make(chan struct{})
+
+	var wg sync.WaitGroup
+
+	wg.Add(1)
+
+	go func() {
+		defer wg.Done()
+
+		ticker := time.NewTicker(uc.CancellationPollInterval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-done:
+				return
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				status, err := uc.checkReportStatus(ctx, message.ReportID, message.OrganizationID, logger)
+				if err == nil && status == constant.CancelledStatus {
+					logger.Infof("Report %s was cancelled, stopping its generation", message.ReportID)
+					cancel(errReportCancelled)
+
+					return
+				}
+			}
+		}
+	}()
+
+	return ctx, func() {
+		close(done)
+		wg.Wait()
+		cancel(nil)
+	}
+}
+
+// checkCancellation is a cooperative cancellation checkpoint of the report generation,
+// returning errReportCancelled once the report was cancelled.
+func checkCancellation(ctx context.Context) error {
+	if reportCancelled(ctx) {
+		return errReportCancelled
+	}
+
+	return nil
+}
+
+// reportCancelled reports whether the generation context was cancelled because the report was cancelled.
+func reportCancelled(ctx context.Context) bool {
+	return errors.Is(context.Cause(ctx), errReportCancelled)
+}
@@ -0,0 +1,191 @@
+// Copyright (c) 2026 Lerian Studio. All rights reserved.
+// Use of this source code is governed by the Elastic License 2.0
+// that can be found in the LICENSE file.
+
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/LerianStudio/reporter/pkg"
+	"github.com/LerianStudio/reporter/pkg/constant"
+	reportData "github.com/LerianStudio/reporter/pkg/mongodb/report"
+	postgres2 "github.com/LerianStudio/reporter/pkg/postgres"
+	"github.com/LerianStudio/reporter/pkg/seaweedfs/report"
+	"github.com/LerianStudio/reporter/pkg/seaweedfs/template"
+
+	libCommons "github.com/LerianStudio/lib-commons/v2/commons"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestUseCase_GenerateReport_CancelledWhileQuerying(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockTemplateRepo := template.NewMockRepository(ctrl)
+	mockReportRepo := report.NewMockRepository(ctrl)
+	mockPostgresRepo := postgres2.NewMockRepository(ctrl)
+	mockReportDataRepo := reportData.NewMockRepository(ctrl)
+
+	templateID := uuid.New()
+	reportID := uuid.New()
+
+	bodyBytes, _ := json.Marshal(GenerateReportMessage{
+		TemplateID:   templateID,
+		ReportID:     reportID,
+		OutputFormat: "txt",
+		DataQueries: map[string]map[string][]string{
+			"onboarding": {"organization": {"name"}},
+		},
+	})
+
+	gomock.InOrder(
+		mockReportDataRepo.
+			EXPECT().
+			FindByID(gomock.Any(), reportID, gomock.Any()).
+			Return(&reportData.Report{ID: reportID, Status: constant.ProcessingStatus}, nil),
+		mockReportDataRepo.
+			EXPECT().
+			FindByID(gomock.Any(), reportID, gomock.Any()).
+			Return(&reportData.Report{ID: reportID, Status: constant.CancelledStatus}, nil).
+			MinTimes(1),
+	)
+
+	mockTemplateRepo.
+		EXPECT().
+		Get(gomock.Any(), templateID.String()).
+		Return([]byte("Hello {{ onboarding.organization.0.name }}"), nil)
+
+	mockPostgresRepo.
+		EXPECT().
+		GetDatabaseSchema(gomock.Any(), gomock.Any()).
+		Return([]postgres2.TableSchema{
+			{
+				TableName: "organization",
+				Columns:   []postgres2.ColumnInformation{{Name: "name", DataType: "text"}},
+			},
+		}, nil)
+
+	// The query runs until the cancellation interrupts it; the report is neither saved nor marked as Error
+	mockPostgresRepo.
+		EXPECT().
+		Query(gomock.Any(), gomock.Any(), gomock.Any(), "organization", []string{"name"}, gomock.Any()).
+		DoAndReturn(func(ctx context.Context, _, _, _, _, _ any) ([]map[string]any, error) {
+			<-ctx.Done()
+
+			return nil, ctx.Err()
+		})
+
+	logger, _, _, _ := libCommons.NewTrackingFromContext(context.Background())
+
+	useCase := &UseCase{
+		TemplateSeaweedFS:     mockTemplateRepo,
+		ReportSeaweedFS:       mockReportRepo,
+		ReportDataRepo:        mockReportDataRepo,
+		CircuitBreakerManager: pkg.NewCircuitBreakerManager(logger),
+		ExternalDataSources: pkg.NewSafeDataSources(map[string]pkg.DataSource{
+			"onboarding": {
+				Initialized:        true,
+				DatabaseType:       "postgresql",
+				PostgresRepository: mockPostgresRepo,
+			},
+		}),
+		CancellationPollInterval: 5 * time.Millisecond,
+	}
+
+	err := useCase.GenerateReport(context.Background(), bodyBytes)
+	require.NoError(t, err)
+}
+
+func TestUseCase_WatchCancellation(t *testing.T) {
+	t.Parallel()
+
+	logger, _, _, _ := libCommons.NewTrackingFromContext(context.Background())
+	message := GenerateReportMessage{ReportID: uuid.New()}
+
+	t.Run("Polling disabled", func(t *testing.T) {
+		t.Parallel()
+
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		useCase := &UseCase{ReportDataRepo: reportData.NewMockRepository(ctrl)}
+
+		ctx, stop := useCase.watchCancellation(context.Background(), message, logger)
+		require.NoError(t, checkCancellation(ctx))
+
+		stop()
+
+		assert.Error(t, ctx.Err())
+		assert.NoError(t, checkCancellation(ctx))
+	})
+
+	t.Run("Report cancelled", func(t *testing.T) {
+		t.Parallel()
+
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockReportDataRepo := reportData.NewMockRepository(ctrl)
+		mockReportDataRepo.
+			EXPECT().
+			FindByID(gomock.Any(), message.ReportID, gomock.Any()).
+			Return(&reportData.Report{ID: message.ReportID, Status: constant.CancelledStatus}, nil)
+
+		useCase := &UseCase{ReportDataRepo: mockReportDataRepo, CancellationPollInterval: time.Millisecond}
+
+		ctx, stop := useCase.watchCancellation(context.Background(), message, logger)
+		defer stop()
+
+		<-ctx.Done()
+
+		assert.ErrorIs(t, checkCancellation(ctx), errReportCancelled)
+	})
+
+	t.Run("Status check failures are ignored", func(t *testing.T) {
+		t.Parallel()
+
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockReportDataRepo := reportData.NewMockRepository(ctrl)
+		mockReportDataRepo.
+			EXPECT().
+			FindByID(gomock.Any(), message.ReportID, gomock.Any()).
+			Return(nil, errors.New("database unavailable")).
+			AnyTimes()
+
+		useCase := &UseCase{ReportDataRepo: mockReportDataRepo, CancellationPollInterval: time.Millisecond}
+
+		ctx, stop := useCase.watchCancellation(context.Background(), message, logger)
+
+		time.Sleep(10 * time.Millisecond)
+		require.NoError(t, checkCancellation(ctx))
+
+		stop()
+	})
+}
+
+func TestUseCase_UpdateReportWithErrors_CancelledReport(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	// No status update is expected: a cancelled report keeps its status
+	useCase := &UseCase{ReportDataRepo: reportData.NewMockRepository(ctrl)}
+
+	ctx, cancel := context.WithCancelCause(context.Background())
+	cancel(errReportCancelled)
+
+	require.NoError(t, useCase.updateReportWithErrors(ctx, uuid.New(), "context canceled"))
+}
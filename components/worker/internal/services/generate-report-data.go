@@ -16,7 +16,6 @@ import (
 
 	libCommons "github.com/LerianStudio/lib-commons/v2/commons"
 	libConstants "github.com/LerianStudio/lib-commons/v2/commons/constants"
-	libCrypto "github.com/LerianStudio/lib-commons/v2/commons/crypto"
 	"github.com/LerianStudio/lib-commons/v2/commons/log"
 	libOtel "github.com/LerianStudio/lib-commons/v2/commons/opentelemetry"
 
@@ -161,6 +160,10 @@ func (uc *UseCase) queryPostgresDatabase(
 	resolver.RegisterDatabase(databaseName, schema)
 
 	for tableKey, fields := range tables {
+		if err := checkCancellation(ctx); err != nil {
+			return err
+		}
+
 		tableFilters := getTableFilters(databaseFilters, tableKey)
 
 		// Parse table key to extract explicit schema if present
@@ -256,6 +259,10 @@ func (uc *UseCase) queryMongoDatabase(
 	)
 
 	for collection, fields := range collections {
+		if err := checkCancellation(ctx); err != nil {
+			return err
+		}
+
 		collectionFilters := getTableFilters(databaseFilters, collection)
 
 		if err := uc.processMongoCollection(ctx, dataSource, databaseName, collection, fields, collectionFilters, result, logger); err != nil {
@@ -288,16 +295,16 @@ func (uc *UseCase) processMongoCollection(
 		attribute.String("app.request.collection", collection),
 	)
 
-	// Handle plugin_crm special cases
-	if databaseName == "plugin_crm" {
-		// Skip "organization" collection - it's not a real collection, just stores the organizationID for template context
-		if collection == "organization" {
-			logger.Debugf("Skipping organization collection for plugin_crm - it's a metadata field, not a queryable collection")
+	// Handle datasources with a field transformer (collection templating, encrypted fields, hashed search fields)
+	if transformer := uc.FieldTransformers.Get(databaseName); transformer != nil {
+		// Skipped collections are template metadata (e.g. plugin_crm "organization"), not queryable collections
+		if transformer.SkipsCollection(collection) {
+			logger.Debugf("Skipping %s collection for %s - it's a metadata field, not a queryable collection", collection, databaseName)
 			return nil
 		}
 
-		if err := uc.processPluginCRMCollection(ctx, dataSource, collection, fields, collectionFilters, result, logger); err != nil {
-			libOtel.HandleSpanError(&span, "Error processing plugin_crm collection", err)
+		if err := uc.processTransformedMongoCollection(ctx, dataSource, transformer, databaseName, collection, fields, collectionFilters, result, logger); err != nil {
+			libOtel.HandleSpanError(&span, "Error processing transformed MongoDB collection", err)
 			return err
 		}
 
@@ -313,11 +320,13 @@ func (uc *UseCase) processMongoCollection(
 	return nil
 }
 
-// processPluginCRMCollection handles plugin_crm specific collection processing
-func (uc *UseCase) processPluginCRMCollection(
+// processTransformedMongoCollection queries a collection through the datasource field transformer:
+// it resolves the physical collection name, rewrites filters to hashed search fields and decrypts the results.
+func (uc *UseCase) processTransformedMongoCollection(
 	ctx context.Context,
 	dataSource *pkg.DataSource,
-	collection string,
+	transformer *pkg.FieldTransformer,
+	databaseName, collection string,
 	fields []string,
 	collectionFilters map[string]model.FilterCondition,
 	result map[string]map[string][]map[string]any,
@@ -325,38 +334,38 @@ func (uc *UseCase) processPluginCRMCollection(
 ) error {
 	_, tracer, reqId, _ := libCommons.NewTrackingFromContext(ctx)
 
-	ctx, span := tracer.Start(ctx, "service.report.process_plugin_crm_collection")
+	ctx, span := tracer.Start(ctx, "service.report.process_transformed_mongo_collection")
 	defer span.End()
 
 	span.SetAttributes(
 		attribute.String("app.request.request_id", reqId),
+		attribute.String("app.request.database_name", databaseName),
 		attribute.String("app.request.collection", collection),
 	)
 
-	// Get Midaz organization ID from datasource configuration (DATASOURCE_CRM_MIDAZ_ORGANIZATION_ID)
-	if dataSource.MidazOrganizationID == "" {
-		logger.Errorf("Midaz Organization ID not configured for plugin_crm datasource. Set DATASOURCE_CRM_MIDAZ_ORGANIZATION_ID environment variable.")
-		return fmt.Errorf("plugin_crm datasource requires DATASOURCE_CRM_MIDAZ_ORGANIZATION_ID environment variable to be configured")
+	physicalCollection, err := transformer.CollectionName(collection, dataSource.MidazOrganizationID)
+	if err != nil {
+		logger.Errorf("Error resolving collection name for %s in %s: %s", collection, databaseName, err.Error())
+		return err
 	}
 
-	newCollection := collection + "_" + dataSource.MidazOrganizationID
+	transformedFilters, err := transformer.TransformFilters(collectionFilters, logger)
+	if err != nil {
+		return fmt.Errorf("error transforming advanced filters for collection %s: %w", physicalCollection, err)
+	}
 
-	// Query the collection
-	collectionResult, err := uc.queryMongoCollectionWithFilters(ctx, dataSource, newCollection, fields, collectionFilters, logger, "plugin_crm")
+	collectionResult, err := uc.queryMongoCollectionWithFilters(ctx, dataSource, physicalCollection, fields, transformedFilters, logger, databaseName)
 	if err != nil {
 		return err
 	}
 
-	result["plugin_crm"][collection] = collectionResult
-
-	// Decrypt data for plugin_crm
-	decryptedResult, err := uc.decryptPluginCRMData(logger, result["plugin_crm"][collection], fields)
+	decryptedResult, err := transformer.Decrypt(logger, collectionResult, fields)
 	if err != nil {
 		logger.Errorf("Error decrypting data for collection %s: %s", collection, err.Error())
 		return pkg.ValidateBusinessError(constant.ErrDecryptionData, "", err)
 	}
 
-	result["plugin_crm"][collection] = decryptedResult
+	result[databaseName][collection] = decryptedResult
 
 	return nil
 }
@@ -417,16 +426,6 @@ func (uc *UseCase) queryMongoCollectionWithFilters(
 	// Execute query with circuit breaker protection
 	queryResult, err := uc.CircuitBreakerManager.Execute(databaseName, func() (any, error) {
 		if len(collectionFilters) > 0 {
-			// Check if this is plugin_crm and needs filter transformation
-			if strings.Contains(collection, "_") && !strings.Contains(collection, "organization") {
-				transformedFilter, err := uc.transformPluginCRMAdvancedFilters(collectionFilters, logger)
-				if err != nil {
-					return nil, fmt.Errorf("error transforming advanced filters for collection %s: %w", collection, err)
-				}
-
-				collectionFilters = transformedFilter
-			}
-
 			return dataSource.MongoDBRepository.QueryWithAdvancedFilters(ctx, collection, fields, collectionFilters)
 		}
 
@@ -495,390 +494,3 @@ func getTableFilters(databaseFilters map[string]map[string]model.FilterCondition
 
 	return nil
 }
-
-// transformPluginCRMAdvancedFilters transforms advanced FilterCondition filters for plugin_crm to use search fields
-func (uc *UseCase) transformPluginCRMAdvancedFilters(filter map[string]model.FilterCondition, logger log.Logger) (map[string]model.FilterCondition, error) {
-	if filter == nil {
-		return nil, nil
-	}
-
-	if uc.CryptoHashSecretKeyPluginCRM == "" {
-		return nil, fmt.Errorf("CRYPTO_HASH_SECRET_KEY_PLUGIN_CRM not configured")
-	}
-
-	crypto := &libCrypto.Crypto{
-		HashSecretKey: uc.CryptoHashSecretKeyPluginCRM,
-		Logger:        logger,
-	}
-
-	transformedFilter := make(map[string]model.FilterCondition)
-
-	// Define field mappings: encrypted field -> search field
-	fieldMappings := map[string]string{
-		"document":                               "search.document",
-		"name":                                   "search.name",
-		"banking_details.account":                "search.banking_details_account",
-		"banking_details.iban":                   "search.banking_details_iban",
-		"contact.primary_email":                  "search.contact_primary_email",
-		"contact.secondary_email":                "search.contact_secondary_email",
-		"contact.mobile_phone":                   "search.contact_mobile_phone",
-		"contact.other_phone":                    "search.contact_other_phone",
-		"regulatory_fields.participant_document": "search.regulatory_fields_participant_document",
-		"related_parties.document":               "search.related_party_documents",
-	}
-
-	for fieldName, condition := range filter {
-		if searchField, exists := fieldMappings[fieldName]; exists {
-			// Transform the condition by hashing string values
-			transformedCondition := model.FilterCondition{}
-
-			// Transform Equals values
-			if len(condition.Equals) > 0 {
-				transformedCondition.Equals = uc.hashFilterValues(condition.Equals, crypto)
-			}
-
-			// Transform GreaterThan values
-			if len(condition.GreaterThan) > 0 {
-				transformedCondition.GreaterThan = uc.hashFilterValues(condition.GreaterThan, crypto)
-			}
-
-			// Transform GreaterOrEqual values
-			if len(condition.GreaterOrEqual) > 0 {
-				transformedCondition.GreaterOrEqual = uc.hashFilterValues(condition.GreaterOrEqual, crypto)
-			}
-
-			// Transform LessThan values
-			if len(condition.LessThan) > 0 {
-				transformedCondition.LessThan = uc.hashFilterValues(condition.LessThan, crypto)
-			}
-
-			// Transform LessOrEqual values
-			if len(condition.LessOrEqual) > 0 {
-				transformedCondition.LessOrEqual = uc.hashFilterValues(condition.LessOrEqual, crypto)
-			}
-
-			// Transform Between values
-			if len(condition.Between) > 0 {
-				transformedCondition.Between = uc.hashFilterValues(condition.Between, crypto)
-			}
-
-			// Transform In values
-			if len(condition.In) > 0 {
-				transformedCondition.In = uc.hashFilterValues(condition.In, crypto)
-			}
-
-			// Transform NotIn values
-			if len(condition.NotIn) > 0 {
-				transformedCondition.NotIn = uc.hashFilterValues(condition.NotIn, crypto)
-			}
-
-			transformedFilter[searchField] = transformedCondition
-
-			logger.Infof("Transformed advanced filter: %s -> %s", fieldName, searchField)
-		} else {
-			// Keep non-mapped fields as-is
-			transformedFilter[fieldName] = condition
-		}
-	}
-
-	return transformedFilter, nil
-}
-
-// hashFilterValues hashes string values in a filter condition array
-func (uc *UseCase) hashFilterValues(values []any, crypto *libCrypto.Crypto) []any {
-	hashedValues := make([]any, len(values))
-
-	for i, value := range values {
-		if strValue, ok := value.(string); ok && strValue != "" {
-			hash := crypto.GenerateHash(&strValue)
-			hashedValues[i] = hash
-		} else {
-			hashedValues[i] = value // Keep non-string values as-is
-		}
-	}
-
-	return hashedValues
-}
-
-// decryptPluginCRMData decrypts sensitive fields for plugin_crm database
-func (uc *UseCase) decryptPluginCRMData(logger log.Logger, collectionResult []map[string]any, fields []string) ([]map[string]any, error) {
-	// Check if we need to decrypt any fields
-	needsDecryption := false
-
-	for _, field := range fields {
-		// Check for top-level encrypted fields
-		if isEncryptedField(field) {
-			needsDecryption = true
-			break
-		}
-		// Check for nested fields that might need decryption
-		if strings.Contains(field, ".") {
-			needsDecryption = true
-			break
-		}
-	}
-
-	if !needsDecryption {
-		return collectionResult, nil
-	}
-
-	// Initialize crypto instance using centralized configuration
-	if uc.CryptoEncryptSecretKeyPluginCRM == "" {
-		return nil, fmt.Errorf("CRYPTO_ENCRYPT_SECRET_KEY_PLUGIN_CRM not configured")
-	}
-
-	if uc.CryptoHashSecretKeyPluginCRM == "" {
-		return nil, fmt.Errorf("CRYPTO_HASH_SECRET_KEY_PLUGIN_CRM not configured")
-	}
-
-	crypto := &libCrypto.Crypto{
-		HashSecretKey:    uc.CryptoHashSecretKeyPluginCRM,
-		EncryptSecretKey: uc.CryptoEncryptSecretKeyPluginCRM,
-		Logger:           logger,
-	}
-
-	err := crypto.InitializeCipher()
-	if err != nil {
-		return nil, fmt.Errorf("failed to initialize cipher: %w", err)
-	}
-
-	// Process each record in the collection
-	for i, record := range collectionResult {
-		decryptedRecord, err := uc.decryptRecord(record, crypto)
-		if err != nil {
-			return nil, fmt.Errorf("failed to decrypt record %d: %w", i, err)
-		}
-
-		collectionResult[i] = decryptedRecord
-	}
-
-	return collectionResult, nil
-}
-
-// isEncryptedField checks if a field is known to be encrypted in plugin_crm
-func isEncryptedField(field string) bool {
-	encryptedFields := map[string]bool{
-		"document": true,
-		"name":     true,
-	}
-
-	return encryptedFields[field]
-}
-
-// decryptRecord decrypts a single record's encrypted fields
-func (uc *UseCase) decryptRecord(record map[string]any, crypto *libCrypto.Crypto) (map[string]any, error) {
-	// Create a copy of the record to avoid modifying the original
-	decryptedRecord := make(map[string]any)
-	for k, v := range record {
-		decryptedRecord[k] = v
-	}
-
-	// Decrypt top-level fields
-	if err := uc.decryptTopLevelFields(decryptedRecord, crypto); err != nil {
-		return nil, err
-	}
-
-	// Decrypt nested fields
-	if err := uc.decryptNestedFields(decryptedRecord, crypto); err != nil {
-		return nil, err
-	}
-
-	return decryptedRecord, nil
-}
-
-// decryptTopLevelFields decrypts top-level encrypted fields
-func (uc *UseCase) decryptTopLevelFields(record map[string]any, crypto *libCrypto.Crypto) error {
-	for fieldName, fieldValue := range record {
-		if isEncryptedField(fieldName) && fieldValue != nil {
-			if err := uc.decryptFieldValue(record, fieldName, fieldValue, crypto); err != nil {
-				return fmt.Errorf("failed to decrypt field %s: %w", fieldName, err)
-			}
-		}
-	}
-
-	return nil
-}
-
-// decryptNestedFields decrypts nested encrypted fields in the record
-func (uc *UseCase) decryptNestedFields(record map[string]any, crypto *libCrypto.Crypto) error {
-	if err := uc.decryptContactFields(record, crypto); err != nil {
-		return err
-	}
-
-	if err := uc.decryptBankingDetailsFields(record, crypto); err != nil {
-		return err
-	}
-
-	if err := uc.decryptLegalPersonFields(record, crypto); err != nil {
-		return err
-	}
-
-	if err := uc.decryptNaturalPersonFields(record, crypto); err != nil {
-		return err
-	}
-
-	if err := uc.decryptRegulatoryFieldsFields(record, crypto); err != nil {
-		return err
-	}
-
-	if err := uc.decryptRelatedPartiesFields(record, crypto); err != nil {
-		return err
-	}
-
-	return nil
-}
-
-// decryptContactFields decrypts fields within the contact object
-func (uc *UseCase) decryptContactFields(record map[string]any, crypto *libCrypto.Crypto) error {
-	contact, ok := record["contact"].(map[string]any)
-	if !ok {
-		return nil
-	}
-
-	contactFields := []string{"primary_email", "secondary_email", "mobile_phone", "other_phone"}
-	for _, fieldName := range contactFields {
-		if fieldValue, exists := contact[fieldName]; exists && fieldValue != nil {
-			if err := uc.decryptFieldValue(contact, fieldName, fieldValue, crypto); err != nil {
-				return fmt.Errorf("failed to decrypt contact.%s: %w", fieldName, err)
-			}
-		}
-	}
-
-	record["contact"] = contact
-
-	return nil
-}
-
-// decryptBankingDetailsFields decrypts fields within the banking_details object
-func (uc *UseCase) decryptBankingDetailsFields(record map[string]any, crypto *libCrypto.Crypto) error {
-	bankingDetails, ok := record["banking_details"].(map[string]any)
-	if !ok {
-		return nil
-	}
-
-	bankingFields := []string{"account", "iban"}
-	for _, fieldName := range bankingFields {
-		if fieldValue, exists := bankingDetails[fieldName]; exists && fieldValue != nil {
-			if err := uc.decryptFieldValue(bankingDetails, fieldName, fieldValue, crypto); err != nil {
-				return fmt.Errorf("failed to decrypt banking_details.%s: %w", fieldName, err)
-			}
-		}
-	}
-
-	record["banking_details"] = bankingDetails
-
-	return nil
-}
-
-// decryptLegalPersonFields decrypts fields within the legal_person object
-func (uc *UseCase) decryptLegalPersonFields(record map[string]any, crypto *libCrypto.Crypto) error {
-	legalPerson, ok := record["legal_person"].(map[string]any)
-	if !ok {
-		return nil
-	}
-
-	representative, ok := legalPerson["representative"].(map[string]any)
-	if !ok {
-		return nil
-	}
-
-	representativeFields := []string{"name", "document", "email"}
-	for _, fieldName := range representativeFields {
-		if fieldValue, exists := representative[fieldName]; exists && fieldValue != nil {
-			if err := uc.decryptFieldValue(representative, fieldName, fieldValue, crypto); err != nil {
-				return fmt.Errorf("failed to decrypt legal_person.representative.%s: %w", fieldName, err)
-			}
-		}
-	}
-
-	legalPerson["representative"] = representative
-	record["legal_person"] = legalPerson
-
-	return nil
-}
-
-// decryptNaturalPersonFields decrypts fields within the natural_person object
-func (uc *UseCase) decryptNaturalPersonFields(record map[string]any, crypto *libCrypto.Crypto) error {
-	naturalPerson, ok := record["natural_person"].(map[string]any)
-	if !ok {
-		return nil
-	}
-
-	naturalPersonFields := []string{"mother_name", "father_name"}
-	for _, fieldName := range naturalPersonFields {
-		if fieldValue, exists := naturalPerson[fieldName]; exists && fieldValue != nil {
-			if err := uc.decryptFieldValue(naturalPerson, fieldName, fieldValue, crypto); err != nil {
-				return fmt.Errorf("failed to decrypt natural_person.%s: %w", fieldName, err)
-			}
-		}
-	}
-
-	record["natural_person"] = naturalPerson
-
-	return nil
-}
-
-// decryptRegulatoryFieldsFields decrypts fields within the regulatory_fields object
-func (uc *UseCase) decryptRegulatoryFieldsFields(record map[string]any, crypto *libCrypto.Crypto) error {
-	regulatoryFields, ok := record["regulatory_fields"].(map[string]any)
-	if !ok {
-		return nil
-	}
-
-	regulatoryFieldNames := []string{"participant_document"}
-	for _, fieldName := range regulatoryFieldNames {
-		if fieldValue, exists := regulatoryFields[fieldName]; exists && fieldValue != nil {
-			if err := uc.decryptFieldValue(regulatoryFields, fieldName, fieldValue, crypto); err != nil {
-				return fmt.Errorf("failed to decrypt regulatory_fields.%s: %w", fieldName, err)
-			}
-		}
-	}
-
-	record["regulatory_fields"] = regulatoryFields
-
-	return nil
-}
-
-// decryptRelatedPartiesFields decrypts the document field within each related_parties array item
-func (uc *UseCase) decryptRelatedPartiesFields(record map[string]any, crypto *libCrypto.Crypto) error {
-	relatedParties, ok := record["related_parties"].([]any)
-	if !ok {
-		return nil
-	}
-
-	for i, party := range relatedParties {
-		partyMap, ok := party.(map[string]any)
-		if !ok {
-			continue
-		}
-
-		if fieldValue, exists := partyMap["document"]; exists && fieldValue != nil {
-			if err := uc.decryptFieldValue(partyMap, "document", fieldValue, crypto); err != nil {
-				return fmt.Errorf("failed to decrypt related_parties[%d].document: %w", i, err)
-			}
-		}
-
-		relatedParties[i] = partyMap
-	}
-
-	record["related_parties"] = relatedParties
-
-	return nil
-}
-
-// decryptFieldValue decrypts a single field value if it's a non-empty string
-func (uc *UseCase) decryptFieldValue(container map[string]any, fieldName string, fieldValue any, crypto *libCrypto.Crypto) error {
-	strValue, ok := fieldValue.(string)
-	if !ok || strValue == "" {
-		return nil
-	}
-
-	decryptedValue, err := crypto.Decrypt(&strValue)
-	if err != nil {
-		return err
-	}
-
-	container[fieldName] = *decryptedValue
-
-	return nil
-}
@@ -513,1077 +513,7 @@ func TestUseCase_GetTableFilters(t *testing.T) {
 	}
 }
 
-func TestUseCase_TransformPluginCRMAdvancedFilters_NewFields(t *testing.T) {
-	t.Parallel()
-
-	hashKey := "0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef"
-
-	logger, _, _, _ := libCommons.NewTrackingFromContext(context.Background())
-	crypto := &libCrypto.Crypto{
-		HashSecretKey: hashKey,
-		Logger:        logger,
-	}
-
-	useCase := &UseCase{
-		CryptoHashSecretKeyPluginCRM: hashKey,
-	}
-
-	tests := []struct {
-		name          string
-		inputField    string
-		expectedField string
-		inputValue    string
-	}{
-		{
-			name:          "Success - transform regulatory_fields.participant_document",
-			inputField:    "regulatory_fields.participant_document",
-			expectedField: "search.regulatory_fields_participant_document",
-			inputValue:    "12345678901234",
-		},
-		{
-			name:          "Success - transform related_parties.document",
-			inputField:    "related_parties.document",
-			expectedField: "search.related_party_documents",
-			inputValue:    "11111111111",
-		},
-	}
-
-	for _, tt := range tests {
-		tt := tt
-		t.Run(tt.name, func(t *testing.T) {
-			t.Parallel()
-
-			filter := map[string]model.FilterCondition{
-				tt.inputField: {
-					Equals: []any{tt.inputValue},
-				},
-			}
-
-			transformedFilter, err := useCase.transformPluginCRMAdvancedFilters(filter, logger)
-			require.NoError(t, err)
-
-			// Verify the field was transformed
-			assert.Contains(t, transformedFilter, tt.expectedField, "expected field not found in transformed filter")
-
-			// Verify the original field was removed
-			assert.NotContains(t, transformedFilter, tt.inputField, "original field should not exist in transformed filter")
-
-			// Verify the value was hashed
-			expectedHash := crypto.GenerateHash(&tt.inputValue)
-			assert.Equal(t, expectedHash, transformedFilter[tt.expectedField].Equals[0], "expected hashed value")
-		})
-	}
-}
-
-func TestUseCase_TransformPluginCRMAdvancedFilters_EdgeCases(t *testing.T) {
-	// NOTE: Cannot use t.Parallel() because some subtests use t.Setenv which is incompatible with parallel execution.
-	t.Run("Success - Nil filter returns nil", func(t *testing.T) {
-		t.Parallel()
-
-		logger, _, _, _ := libCommons.NewTrackingFromContext(context.Background())
-		useCase := &UseCase{}
-
-		result, err := useCase.transformPluginCRMAdvancedFilters(nil, logger)
-		require.NoError(t, err)
-		assert.Nil(t, result, "expected nil result for nil input")
-	})
-
-	t.Run("Error - Missing env var", func(t *testing.T) {
-		// NOTE: t.Setenv is incompatible with t.Parallel()
-		t.Setenv("CRYPTO_HASH_SECRET_KEY_PLUGIN_CRM", "")
-
-		logger, _, _, _ := libCommons.NewTrackingFromContext(context.Background())
-		useCase := &UseCase{}
-
-		filter := map[string]model.FilterCondition{
-			"document": {
-				Equals: []any{"12345678901"},
-			},
-		}
-
-		_, err := useCase.transformPluginCRMAdvancedFilters(filter, logger)
-		require.Error(t, err, "expected error when env var is missing")
-		assert.Contains(t, err.Error(), "CRYPTO_HASH_SECRET_KEY_PLUGIN_CRM")
-	})
-
-	t.Run("Success - Non-mapped field preserved as-is", func(t *testing.T) {
-		t.Parallel()
-
-		hashKey := "0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef"
-
-		logger, _, _, _ := libCommons.NewTrackingFromContext(context.Background())
-		useCase := &UseCase{
-			CryptoHashSecretKeyPluginCRM: hashKey,
-		}
-
-		filter := map[string]model.FilterCondition{
-			"unmapped_field": {
-				Equals: []any{"value1"},
-			},
-		}
-
-		result, err := useCase.transformPluginCRMAdvancedFilters(filter, logger)
-		require.NoError(t, err)
-
-		// Non-mapped fields should be kept as-is
-		assert.Contains(t, result, "unmapped_field", "expected unmapped_field to be preserved")
-	})
-}
-
-func TestUseCase_TransformPluginCRMAdvancedFilters_AllFilterConditions(t *testing.T) {
-	t.Parallel()
-
-	hashKey := "0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef"
-
-	logger, _, _, _ := libCommons.NewTrackingFromContext(context.Background())
-	useCase := &UseCase{
-		CryptoHashSecretKeyPluginCRM: hashKey,
-	}
-
-	filter := map[string]model.FilterCondition{
-		"document": {
-			Equals:         []any{"value1"},
-			GreaterThan:    []any{"value2"},
-			GreaterOrEqual: []any{"value3"},
-			LessThan:       []any{"value4"},
-			LessOrEqual:    []any{"value5"},
-			Between:        []any{"value6", "value7"},
-			In:             []any{"value8"},
-			NotIn:          []any{"value9"},
-		},
-	}
-
-	result, err := useCase.transformPluginCRMAdvancedFilters(filter, logger)
-	require.NoError(t, err)
-
-	assert.Contains(t, result, "search.document", "expected search.document field in result")
-
-	// Verify all conditions were transformed
-	searchDoc := result["search.document"]
-	assert.NotEmpty(t, searchDoc.Equals, "expected Equals to be transformed")
-	assert.NotEmpty(t, searchDoc.GreaterThan, "expected GreaterThan to be transformed")
-	assert.NotEmpty(t, searchDoc.GreaterOrEqual, "expected GreaterOrEqual to be transformed")
-	assert.NotEmpty(t, searchDoc.LessThan, "expected LessThan to be transformed")
-	assert.NotEmpty(t, searchDoc.LessOrEqual, "expected LessOrEqual to be transformed")
-	assert.NotEmpty(t, searchDoc.Between, "expected Between to be transformed")
-	assert.NotEmpty(t, searchDoc.In, "expected In to be transformed")
-	assert.NotEmpty(t, searchDoc.NotIn, "expected NotIn to be transformed")
-}
-
-func TestUseCase_HashFilterValues(t *testing.T) {
-	t.Parallel()
-
-	hashKey := "0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef"
-
-	logger, _, _, _ := libCommons.NewTrackingFromContext(context.Background())
-	crypto := &libCrypto.Crypto{
-		HashSecretKey: hashKey,
-		Logger:        logger,
-	}
-
-	useCase := &UseCase{}
-
-	tests := []struct {
-		name   string
-		values []any
-	}{
-		{
-			name:   "Success - Hash string values",
-			values: []any{"value1", "value2"},
-		},
-		{
-			name:   "Success - Keep non-string values",
-			values: []any{123, 456.78, true},
-		},
-		{
-			name:   "Success - Mixed values",
-			values: []any{"string", 123, "another", nil},
-		},
-		{
-			name:   "Success - Empty string value",
-			values: []any{""},
-		},
-	}
-
-	for _, tt := range tests {
-		tt := tt
-		t.Run(tt.name, func(t *testing.T) {
-			t.Parallel()
-
-			result := useCase.hashFilterValues(tt.values, crypto)
-			require.Len(t, result, len(tt.values))
-
-			for i, v := range tt.values {
-				if strVal, ok := v.(string); ok && strVal != "" {
-					expectedHash := crypto.GenerateHash(&strVal)
-					assert.Equal(t, expectedHash, result[i], "value[%d]: expected hashed value", i)
-				} else {
-					assert.Equal(t, v, result[i], "value[%d]: expected unchanged value", i)
-				}
-			}
-		})
-	}
-}
-
-func TestUseCase_IsEncryptedField(t *testing.T) {
-	t.Parallel()
-
-	tests := []struct {
-		field    string
-		expected bool
-	}{
-		{"document", true},
-		{"name", true},
-		{"email", false},
-		{"id", false},
-		{"contact", false},
-		{"", false},
-	}
-
-	for _, tt := range tests {
-		tt := tt
-		t.Run(tt.field, func(t *testing.T) {
-			t.Parallel()
-
-			result := isEncryptedField(tt.field)
-			assert.Equal(t, tt.expected, result, "isEncryptedField(%q)", tt.field)
-		})
-	}
-}
-
-func TestUseCase_DecryptPluginCRMData(t *testing.T) {
-	// NOTE: Cannot use t.Parallel() because some subtests use t.Setenv which is incompatible with parallel execution.
-	t.Run("Error - Missing env vars", func(t *testing.T) {
-		// NOTE: t.Setenv is incompatible with t.Parallel()
-		t.Setenv("CRYPTO_HASH_SECRET_KEY_PLUGIN_CRM", "")
-		t.Setenv("CRYPTO_ENCRYPT_SECRET_KEY_PLUGIN_CRM", "")
-
-		logger, _, _, _ := libCommons.NewTrackingFromContext(context.Background())
-		useCase := &UseCase{}
-
-		collectionResult := []map[string]any{
-			{"document": "encrypted_value"},
-		}
-
-		_, err := useCase.decryptPluginCRMData(logger, collectionResult, []string{"document"})
-		require.Error(t, err, "expected error when env vars are missing")
-	})
-
-	t.Run("Success - No decryption needed", func(t *testing.T) {
-		t.Parallel()
-
-		logger, _, _, _ := libCommons.NewTrackingFromContext(context.Background())
-		useCase := &UseCase{}
-
-		collectionResult := []map[string]any{
-			{"id": "123", "status": "active"},
-		}
-
-		result, err := useCase.decryptPluginCRMData(logger, collectionResult, []string{"id", "status"})
-		require.NoError(t, err)
-		require.Len(t, result, 1)
-	})
-}
-
-func TestUseCase_DecryptRecord(t *testing.T) {
-	t.Parallel()
-
-	hashKey := "0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef"
-	encryptKey := "0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef"
-
-	logger, _, _, _ := libCommons.NewTrackingFromContext(context.Background())
-	crypto := &libCrypto.Crypto{
-		HashSecretKey:    hashKey,
-		EncryptSecretKey: encryptKey,
-		Logger:           logger,
-	}
-
-	err := crypto.InitializeCipher()
-	require.NoError(t, err, "Failed to initialize cipher")
-
-	useCase := &UseCase{}
-
-	tests := []struct {
-		name           string
-		record         map[string]any
-		expectedFields map[string]any
-	}{
-		{
-			name: "Success - Decrypt record with top-level encrypted fields",
-			record: func() map[string]any {
-				doc := "12345678901"
-				name := "John Doe"
-				encDoc, _ := crypto.Encrypt(&doc)
-				encName, _ := crypto.Encrypt(&name)
-				return map[string]any{
-					"document": *encDoc,
-					"name":     *encName,
-					"id":       "123",
-				}
-			}(),
-			expectedFields: map[string]any{
-				"document": "12345678901",
-				"name":     "John Doe",
-				"id":       "123",
-			},
-		},
-		{
-			name: "Success - Decrypt record with no encrypted fields",
-			record: map[string]any{
-				"id":     "123",
-				"status": "active",
-			},
-			expectedFields: map[string]any{
-				"id":     "123",
-				"status": "active",
-			},
-		},
-	}
-
-	for _, tt := range tests {
-		tt := tt
-		t.Run(tt.name, func(t *testing.T) {
-			t.Parallel()
-
-			result, err := useCase.decryptRecord(tt.record, crypto)
-			require.NoError(t, err)
-
-			for key, expected := range tt.expectedFields {
-				assert.Equal(t, expected, result[key], "field %s", key)
-			}
-		})
-	}
-}
-
-func TestUseCase_DecryptTopLevelFields(t *testing.T) {
-	t.Parallel()
-
-	hashKey := "0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef"
-	encryptKey := "0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef"
-
-	logger, _, _, _ := libCommons.NewTrackingFromContext(context.Background())
-	crypto := &libCrypto.Crypto{
-		HashSecretKey:    hashKey,
-		EncryptSecretKey: encryptKey,
-		Logger:           logger,
-	}
-
-	err := crypto.InitializeCipher()
-	require.NoError(t, err, "Failed to initialize cipher")
-
-	useCase := &UseCase{}
-
-	tests := []struct {
-		name           string
-		record         map[string]any
-		expectedDoc    string
-		expectNoChange bool
-	}{
-		{
-			name: "Success - Decrypt document and name fields",
-			record: func() map[string]any {
-				doc := "12345678901"
-				name := "John Doe"
-				encDoc, _ := crypto.Encrypt(&doc)
-				encName, _ := crypto.Encrypt(&name)
-				return map[string]any{
-					"document": *encDoc,
-					"name":     *encName,
-				}
-			}(),
-			expectedDoc: "12345678901",
-		},
-		{
-			name: "Success - No encrypted fields present",
-			record: map[string]any{
-				"id":     "123",
-				"status": "active",
-			},
-			expectNoChange: true,
-		},
-		{
-			name: "Success - Encrypted field with nil value",
-			record: map[string]any{
-				"document": nil,
-				"name":     nil,
-			},
-			expectNoChange: true,
-		},
-	}
-
-	for _, tt := range tests {
-		tt := tt
-		t.Run(tt.name, func(t *testing.T) {
-			t.Parallel()
-
-			err := useCase.decryptTopLevelFields(tt.record, crypto)
-			require.NoError(t, err)
-
-			if !tt.expectNoChange && tt.expectedDoc != "" {
-				assert.Equal(t, tt.expectedDoc, tt.record["document"])
-			}
-		})
-	}
-}
-
-func TestUseCase_DecryptNestedFields_AllTypes(t *testing.T) {
-	t.Parallel()
-
-	hashKey := "0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef"
-	encryptKey := "0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef"
-
-	logger, _, _, _ := libCommons.NewTrackingFromContext(context.Background())
-	crypto := &libCrypto.Crypto{
-		HashSecretKey:    hashKey,
-		EncryptSecretKey: encryptKey,
-		Logger:           logger,
-	}
-
-	err := crypto.InitializeCipher()
-	require.NoError(t, err, "Failed to initialize cipher")
-
-	useCase := &UseCase{}
-
-	// Create a record with all nested field types
-	email := "test@example.com"
-	account := "12345-6"
-	repName := "John Doe"
-	motherName := "Maria"
-	participantDoc := "12345678901234"
-	partyDoc := "11111111111"
-
-	encEmail, _ := crypto.Encrypt(&email)
-	encAccount, _ := crypto.Encrypt(&account)
-	encRepName, _ := crypto.Encrypt(&repName)
-	encMotherName, _ := crypto.Encrypt(&motherName)
-	encParticipantDoc, _ := crypto.Encrypt(&participantDoc)
-	encPartyDoc, _ := crypto.Encrypt(&partyDoc)
-
-	record := map[string]any{
-		"contact": map[string]any{
-			"primary_email": *encEmail,
-		},
-		"banking_details": map[string]any{
-			"account": *encAccount,
-		},
-		"legal_person": map[string]any{
-			"representative": map[string]any{
-				"name": *encRepName,
-			},
-		},
-		"natural_person": map[string]any{
-			"mother_name": *encMotherName,
-		},
-		"regulatory_fields": map[string]any{
-			"participant_document": *encParticipantDoc,
-		},
-		"related_parties": []any{
-			map[string]any{
-				"document": *encPartyDoc,
-			},
-		},
-	}
-
-	err = useCase.decryptNestedFields(record, crypto)
-	require.NoError(t, err)
-
-	// Verify all fields were decrypted
-	contact := record["contact"].(map[string]any)
-	assert.Equal(t, email, contact["primary_email"])
-
-	bankingDetails := record["banking_details"].(map[string]any)
-	assert.Equal(t, account, bankingDetails["account"])
-
-	legalPerson := record["legal_person"].(map[string]any)
-	representative := legalPerson["representative"].(map[string]any)
-	assert.Equal(t, repName, representative["name"])
-
-	naturalPerson := record["natural_person"].(map[string]any)
-	assert.Equal(t, motherName, naturalPerson["mother_name"])
-
-	regulatoryFields := record["regulatory_fields"].(map[string]any)
-	assert.Equal(t, participantDoc, regulatoryFields["participant_document"])
-
-	relatedParties := record["related_parties"].([]any)
-	party := relatedParties[0].(map[string]any)
-	assert.Equal(t, partyDoc, party["document"])
-}
-
-func TestUseCase_DecryptFieldValue(t *testing.T) {
-	t.Parallel()
-
-	hashKey := "0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef"
-	encryptKey := "0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef"
-
-	logger, _, _, _ := libCommons.NewTrackingFromContext(context.Background())
-	crypto := &libCrypto.Crypto{
-		HashSecretKey:    hashKey,
-		EncryptSecretKey: encryptKey,
-		Logger:           logger,
-	}
-
-	err := crypto.InitializeCipher()
-	require.NoError(t, err, "Failed to initialize cipher")
-
-	useCase := &UseCase{}
-
-	tests := []struct {
-		name        string
-		container   map[string]any
-		fieldName   string
-		fieldValue  any
-		expectError bool
-	}{
-		{
-			name:       "Success - Decrypt valid string",
-			container:  map[string]any{},
-			fieldName:  "test_field",
-			fieldValue: func() string { v := "test"; e, _ := crypto.Encrypt(&v); return *e }(),
-		},
-		{
-			name:       "Success - Skip non-string value",
-			container:  map[string]any{},
-			fieldName:  "test_field",
-			fieldValue: 123,
-		},
-		{
-			name:       "Success - Skip empty string",
-			container:  map[string]any{},
-			fieldName:  "test_field",
-			fieldValue: "",
-		},
-		{
-			name:        "Error - invalid encrypted value",
-			container:   map[string]any{},
-			fieldName:   "test_field",
-			fieldValue:  "not-encrypted-data",
-			expectError: true,
-		},
-	}
-
-	for _, tt := range tests {
-		tt := tt
-		t.Run(tt.name, func(t *testing.T) {
-			t.Parallel()
-
-			err := useCase.decryptFieldValue(tt.container, tt.fieldName, tt.fieldValue, crypto)
-			if tt.expectError {
-				require.Error(t, err)
-			} else {
-				require.NoError(t, err)
-			}
-		})
-	}
-}
-
-func TestUseCase_DecryptContactFields(t *testing.T) {
-	t.Parallel()
-
-	hashKey := "0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef"
-	encryptKey := "0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef"
-
-	logger, _, _, _ := libCommons.NewTrackingFromContext(context.Background())
-	crypto := &libCrypto.Crypto{
-		HashSecretKey:    hashKey,
-		EncryptSecretKey: encryptKey,
-		Logger:           logger,
-	}
-
-	err := crypto.InitializeCipher()
-	require.NoError(t, err, "Failed to initialize cipher")
-
-	useCase := &UseCase{}
-
-	tests := []struct {
-		name           string
-		record         map[string]any
-		expectedEmails []string
-		expectNoChange bool
-	}{
-		{
-			name: "Success - Decrypt contact fields",
-			record: func() map[string]any {
-				email := "test@example.com"
-				phone := "+1234567890"
-				encrypted1, _ := crypto.Encrypt(&email)
-				encrypted2, _ := crypto.Encrypt(&phone)
-				return map[string]any{
-					"contact": map[string]any{
-						"primary_email": *encrypted1,
-						"mobile_phone":  *encrypted2,
-					},
-				}
-			}(),
-			expectedEmails: []string{"test@example.com", "+1234567890"},
-		},
-		{
-			name: "Success - No contact field present",
-			record: map[string]any{
-				"id": "test-id",
-			},
-			expectNoChange: true,
-		},
-		{
-			name: "Success - Contact field is not a map",
-			record: map[string]any{
-				"contact": "not a map",
-			},
-			expectNoChange: true,
-		},
-	}
-
-	for _, tt := range tests {
-		tt := tt
-		t.Run(tt.name, func(t *testing.T) {
-			t.Parallel()
-
-			err := useCase.decryptContactFields(tt.record, crypto)
-			require.NoError(t, err)
-
-			if !tt.expectNoChange && len(tt.expectedEmails) > 0 {
-				contact, ok := tt.record["contact"].(map[string]any)
-				require.True(t, ok, "contact not found or wrong type")
-				assert.Equal(t, tt.expectedEmails[0], contact["primary_email"])
-			}
-		})
-	}
-}
-
-func TestUseCase_DecryptBankingDetailsFields(t *testing.T) {
-	t.Parallel()
-
-	hashKey := "0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef"
-	encryptKey := "0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef"
-
-	logger, _, _, _ := libCommons.NewTrackingFromContext(context.Background())
-	crypto := &libCrypto.Crypto{
-		HashSecretKey:    hashKey,
-		EncryptSecretKey: encryptKey,
-		Logger:           logger,
-	}
-
-	err := crypto.InitializeCipher()
-	require.NoError(t, err, "Failed to initialize cipher")
-
-	useCase := &UseCase{}
-
-	tests := []struct {
-		name            string
-		record          map[string]any
-		expectedAccount string
-		expectNoChange  bool
-	}{
-		{
-			name: "Success - Decrypt banking details fields",
-			record: func() map[string]any {
-				account := "12345-6"
-				iban := "BR1234567890"
-				encrypted1, _ := crypto.Encrypt(&account)
-				encrypted2, _ := crypto.Encrypt(&iban)
-				return map[string]any{
-					"banking_details": map[string]any{
-						"account": *encrypted1,
-						"iban":    *encrypted2,
-					},
-				}
-			}(),
-			expectedAccount: "12345-6",
-		},
-		{
-			name: "Success - No banking_details field present",
-			record: map[string]any{
-				"id": "test-id",
-			},
-			expectNoChange: true,
-		},
-		{
-			name: "Success - banking_details field is not a map",
-			record: map[string]any{
-				"banking_details": "not a map",
-			},
-			expectNoChange: true,
-		},
-	}
-
-	for _, tt := range tests {
-		tt := tt
-		t.Run(tt.name, func(t *testing.T) {
-			t.Parallel()
-
-			err := useCase.decryptBankingDetailsFields(tt.record, crypto)
-			require.NoError(t, err)
-
-			if !tt.expectNoChange && tt.expectedAccount != "" {
-				bankingDetails, ok := tt.record["banking_details"].(map[string]any)
-				require.True(t, ok, "banking_details not found or wrong type")
-				assert.Equal(t, tt.expectedAccount, bankingDetails["account"])
-			}
-		})
-	}
-}
-
-func TestUseCase_DecryptLegalPersonFields(t *testing.T) {
-	t.Parallel()
-
-	hashKey := "0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef"
-	encryptKey := "0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef"
-
-	logger, _, _, _ := libCommons.NewTrackingFromContext(context.Background())
-	crypto := &libCrypto.Crypto{
-		HashSecretKey:    hashKey,
-		EncryptSecretKey: encryptKey,
-		Logger:           logger,
-	}
-
-	err := crypto.InitializeCipher()
-	require.NoError(t, err, "Failed to initialize cipher")
-
-	useCase := &UseCase{}
-
-	tests := []struct {
-		name           string
-		record         map[string]any
-		expectedName   string
-		expectNoChange bool
-	}{
-		{
-			name: "Success - Decrypt legal person representative fields",
-			record: func() map[string]any {
-				name := "John Doe"
-				doc := "12345678901"
-				encrypted1, _ := crypto.Encrypt(&name)
-				encrypted2, _ := crypto.Encrypt(&doc)
-				return map[string]any{
-					"legal_person": map[string]any{
-						"representative": map[string]any{
-							"name":     *encrypted1,
-							"document": *encrypted2,
-						},
-					},
-				}
-			}(),
-			expectedName: "John Doe",
-		},
-		{
-			name: "Success - No legal_person field present",
-			record: map[string]any{
-				"id": "test-id",
-			},
-			expectNoChange: true,
-		},
-		{
-			name: "Success - legal_person without representative",
-			record: map[string]any{
-				"legal_person": map[string]any{
-					"company_name": "Test Company",
-				},
-			},
-			expectNoChange: true,
-		},
-	}
-
-	for _, tt := range tests {
-		tt := tt
-		t.Run(tt.name, func(t *testing.T) {
-			t.Parallel()
-
-			err := useCase.decryptLegalPersonFields(tt.record, crypto)
-			require.NoError(t, err)
-
-			if !tt.expectNoChange && tt.expectedName != "" {
-				legalPerson, ok := tt.record["legal_person"].(map[string]any)
-				require.True(t, ok, "legal_person not found or wrong type")
-				representative, ok := legalPerson["representative"].(map[string]any)
-				require.True(t, ok, "representative not found or wrong type")
-				assert.Equal(t, tt.expectedName, representative["name"])
-			}
-		})
-	}
-}
-
-func TestUseCase_DecryptNaturalPersonFields(t *testing.T) {
-	t.Parallel()
-
-	hashKey := "0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef"
-	encryptKey := "0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef"
-
-	logger, _, _, _ := libCommons.NewTrackingFromContext(context.Background())
-	crypto := &libCrypto.Crypto{
-		HashSecretKey:    hashKey,
-		EncryptSecretKey: encryptKey,
-		Logger:           logger,
-	}
-
-	err := crypto.InitializeCipher()
-	require.NoError(t, err, "Failed to initialize cipher")
-
-	useCase := &UseCase{}
-
-	tests := []struct {
-		name               string
-		record             map[string]any
-		expectedMotherName string
-		expectNoChange     bool
-	}{
-		{
-			name: "Success - Decrypt natural person fields",
-			record: func() map[string]any {
-				motherName := "Maria Silva"
-				fatherName := "Jose Silva"
-				encrypted1, _ := crypto.Encrypt(&motherName)
-				encrypted2, _ := crypto.Encrypt(&fatherName)
-				return map[string]any{
-					"natural_person": map[string]any{
-						"mother_name": *encrypted1,
-						"father_name": *encrypted2,
-					},
-				}
-			}(),
-			expectedMotherName: "Maria Silva",
-		},
-		{
-			name: "Success - No natural_person field present",
-			record: map[string]any{
-				"id": "test-id",
-			},
-			expectNoChange: true,
-		},
-		{
-			name: "Success - natural_person field is not a map",
-			record: map[string]any{
-				"natural_person": "not a map",
-			},
-			expectNoChange: true,
-		},
-	}
-
-	for _, tt := range tests {
-		tt := tt
-		t.Run(tt.name, func(t *testing.T) {
-			t.Parallel()
-
-			err := useCase.decryptNaturalPersonFields(tt.record, crypto)
-			require.NoError(t, err)
-
-			if !tt.expectNoChange && tt.expectedMotherName != "" {
-				naturalPerson, ok := tt.record["natural_person"].(map[string]any)
-				require.True(t, ok, "natural_person not found or wrong type")
-				assert.Equal(t, tt.expectedMotherName, naturalPerson["mother_name"])
-			}
-		})
-	}
-}
-
-func TestUseCase_DecryptRegulatoryFieldsFields(t *testing.T) {
-	t.Parallel()
-
-	hashKey := "0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef"
-	encryptKey := "0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef"
-
-	logger, _, _, _ := libCommons.NewTrackingFromContext(context.Background())
-	crypto := &libCrypto.Crypto{
-		HashSecretKey:    hashKey,
-		EncryptSecretKey: encryptKey,
-		Logger:           logger,
-	}
-
-	err := crypto.InitializeCipher()
-	require.NoError(t, err, "Failed to initialize cipher")
-
-	useCase := &UseCase{}
-
-	tests := []struct {
-		name           string
-		record         map[string]any
-		expectedDoc    string
-		expectNoChange bool
-	}{
-		{
-			name: "Success - decrypt regulatory_fields.participant_document",
-			record: func() map[string]any {
-				doc := "12345678901234"
-				encrypted, _ := crypto.Encrypt(&doc)
-				return map[string]any{
-					"regulatory_fields": map[string]any{
-						"participant_document": *encrypted,
-					},
-				}
-			}(),
-			expectedDoc: "12345678901234",
-		},
-		{
-			name: "Success - no regulatory_fields present",
-			record: map[string]any{
-				"id": "test-id",
-			},
-			expectNoChange: true,
-		},
-		{
-			name: "Success - regulatory_fields without participant_document",
-			record: map[string]any{
-				"regulatory_fields": map[string]any{
-					"other_field": "value",
-				},
-			},
-			expectNoChange: true,
-		},
-		{
-			name: "Success - regulatory_fields with nil participant_document",
-			record: map[string]any{
-				"regulatory_fields": map[string]any{
-					"participant_document": nil,
-				},
-			},
-			expectNoChange: true,
-		},
-	}
-
-	for _, tt := range tests {
-		tt := tt
-		t.Run(tt.name, func(t *testing.T) {
-			t.Parallel()
-
-			err := useCase.decryptRegulatoryFieldsFields(tt.record, crypto)
-			require.NoError(t, err)
-
-			if !tt.expectNoChange {
-				regulatoryFields, ok := tt.record["regulatory_fields"].(map[string]any)
-				require.True(t, ok, "regulatory_fields not found or wrong type")
-				assert.Equal(t, tt.expectedDoc, regulatoryFields["participant_document"])
-			}
-		})
-	}
-}
-
-func TestUseCase_DecryptRelatedPartiesFields(t *testing.T) {
-	t.Parallel()
-
-	hashKey := "0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef"
-	encryptKey := "0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef"
-
-	logger, _, _, _ := libCommons.NewTrackingFromContext(context.Background())
-	crypto := &libCrypto.Crypto{
-		HashSecretKey:    hashKey,
-		EncryptSecretKey: encryptKey,
-		Logger:           logger,
-	}
-
-	err := crypto.InitializeCipher()
-	require.NoError(t, err, "Failed to initialize cipher")
-
-	useCase := &UseCase{}
-
-	tests := []struct {
-		name           string
-		record         map[string]any
-		expectedDocs   []string
-		expectNoChange bool
-	}{
-		{
-			name: "Success - decrypt multiple related_parties documents",
-			record: func() map[string]any {
-				doc1 := "11111111111"
-				doc2 := "22222222222"
-				encrypted1, _ := crypto.Encrypt(&doc1)
-				encrypted2, _ := crypto.Encrypt(&doc2)
-				return map[string]any{
-					"related_parties": []any{
-						map[string]any{
-							"_id":      "party-1",
-							"document": *encrypted1,
-							"name":     "Party One",
-							"role":     "PRIMARY_HOLDER",
-						},
-						map[string]any{
-							"_id":      "party-2",
-							"document": *encrypted2,
-							"name":     "Party Two",
-							"role":     "LEGAL_REPRESENTATIVE",
-						},
-					},
-				}
-			}(),
-			expectedDocs: []string{"11111111111", "22222222222"},
-		},
-		{
-			name: "Success - no related_parties present",
-			record: map[string]any{
-				"id": "test-id",
-			},
-			expectNoChange: true,
-		},
-		{
-			name: "Success - empty related_parties array",
-			record: map[string]any{
-				"related_parties": []any{},
-			},
-			expectNoChange: true,
-		},
-		{
-			name: "Success - related_parties with nil document",
-			record: map[string]any{
-				"related_parties": []any{
-					map[string]any{
-						"_id":      "party-1",
-						"document": nil,
-						"name":     "Party One",
-					},
-				},
-			},
-			expectNoChange: true,
-		},
-		{
-			name: "Success - related_parties with mixed valid and nil documents",
-			record: func() map[string]any {
-				doc1 := "33333333333"
-				encrypted1, _ := crypto.Encrypt(&doc1)
-				return map[string]any{
-					"related_parties": []any{
-						map[string]any{
-							"_id":      "party-1",
-							"document": *encrypted1,
-							"name":     "Party One",
-						},
-						map[string]any{
-							"_id":      "party-2",
-							"document": nil,
-							"name":     "Party Two",
-						},
-					},
-				}
-			}(),
-			expectedDocs: []string{"33333333333"},
-		},
-	}
-
-	for _, tt := range tests {
-		tt := tt
-		t.Run(tt.name, func(t *testing.T) {
-			t.Parallel()
-
-			err := useCase.decryptRelatedPartiesFields(tt.record, crypto)
-			require.NoError(t, err)
-
-			if !tt.expectNoChange && len(tt.expectedDocs) > 0 {
-				relatedParties, ok := tt.record["related_parties"].([]any)
-				require.True(t, ok, "related_parties not found or wrong type")
-
-				docIndex := 0
-				for i, party := range relatedParties {
-					partyMap, ok := party.(map[string]any)
-					require.True(t, ok, "related_parties[%d] is not a map", i)
-
-					if partyMap["document"] != nil && docIndex < len(tt.expectedDocs) {
-						assert.Equal(t, tt.expectedDocs[docIndex], partyMap["document"], "related_parties[%d].document", i)
-						docIndex++
-					}
-				}
-			}
-		})
-	}
-}
-
-func TestUseCase_ProcessPluginCRMCollection(t *testing.T) {
+func TestUseCase_ProcessTransformedMongoCollection(t *testing.T) {
 	t.Parallel()
 
 	hashKey := "0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef"
@@ -1627,17 +557,17 @@ func TestUseCase_ProcessPluginCRMCollection(t *testing.T) {
 		}
 
 		useCase := &UseCase{
-			CircuitBreakerManager:           cbManager,
-			CryptoHashSecretKeyPluginCRM:    hashKey,
-			CryptoEncryptSecretKeyPluginCRM: encryptKey,
+			CircuitBreakerManager: cbManager,
 		}
 
 		result := make(map[string]map[string][]map[string]any)
 		result["plugin_crm"] = make(map[string][]map[string]any)
 
-		err = useCase.processPluginCRMCollection(
+		err = useCase.processTransformedMongoCollection(
 			context.Background(),
 			dataSource,
+			pkg.PluginCRMFieldTransformer(hashKey, encryptKey),
+			"plugin_crm",
 			"holders",
 			[]string{"name"},
 			nil,
@@ -1649,28 +579,95 @@ func TestUseCase_ProcessPluginCRMCollection(t *testing.T) {
 		assert.Equal(t, nameStr, result["plugin_crm"]["holders"][0]["name"])
 	})
 
-	t.Run("Error - missing MidazOrganizationID returns error", func(t *testing.T) {
+	t.Run("Success - configured datasource filters on hashed search fields", func(t *testing.T) {
 		t.Parallel()
 
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockMongoRepo := mongodb2.NewMockRepository(ctrl)
 		logger, _, _, _ := libCommons.NewTrackingFromContext(context.Background())
+		cbManager := pkg.NewCircuitBreakerManager(logger)
+
+		crypto := &libCrypto.Crypto{
+			HashSecretKey:    hashKey,
+			EncryptSecretKey: encryptKey,
+			Logger:           logger,
+		}
+		err := crypto.InitializeCipher()
+		require.NoError(t, err)
+
+		document := "12345678901"
+		encryptedDocument, _ := crypto.Encrypt(&document)
+
+		transformer := &pkg.FieldTransformer{
+			DataSource:         "plugin_fees",
+			CollectionTemplate: "{collection}_{midaz_organization_id}",
+			EncryptedFields:    []string{"payer.document"},
+			SearchFields:       map[string]string{"payer.document": "search.payer_document"},
+			EncryptSecretKey:   encryptKey,
+			HashSecretKey:      hashKey,
+		}
+
+		mockMongoRepo.EXPECT().
+			QueryWithAdvancedFilters(gomock.Any(), "charges_org-123", []string{"payer"}, map[string]model.FilterCondition{
+				"search.payer_document": {Equals: []any{crypto.GenerateHash(&document)}},
+			}).
+			Return([]map[string]any{
+				{"payer": map[string]any{"document": *encryptedDocument}},
+			}, nil)
 
 		dataSource := &pkg.DataSource{
 			Initialized:         true,
 			DatabaseType:        "mongodb",
-			MidazOrganizationID: "",
+			MongoDBRepository:   mockMongoRepo,
+			MidazOrganizationID: "org-123",
 		}
 
 		useCase := &UseCase{
-			CryptoHashSecretKeyPluginCRM:    hashKey,
-			CryptoEncryptSecretKeyPluginCRM: encryptKey,
+			CircuitBreakerManager: cbManager,
+			FieldTransformers:     pkg.NewFieldTransformerRegistry(transformer),
+		}
+
+		result := make(map[string]map[string][]map[string]any)
+		result["plugin_fees"] = make(map[string][]map[string]any)
+
+		err = useCase.processMongoCollection(
+			context.Background(),
+			dataSource,
+			"plugin_fees",
+			"charges",
+			[]string{"payer"},
+			map[string]model.FilterCondition{"payer.document": {Equals: []any{document}}},
+			result,
+			logger,
+		)
+		require.NoError(t, err)
+		require.Len(t, result["plugin_fees"]["charges"], 1)
+		assert.Equal(t, document, result["plugin_fees"]["charges"][0]["payer"].(map[string]any)["document"])
+	})
+
+	t.Run("Error - missing MidazOrganizationID returns error", func(t *testing.T) {
+		t.Parallel()
+
+		logger, _, _, _ := libCommons.NewTrackingFromContext(context.Background())
+
+		dataSource := &pkg.DataSource{
+			Initialized:         true,
+			DatabaseType:        "mongodb",
+			MidazOrganizationID: "",
 		}
 
+		useCase := &UseCase{}
+
 		result := make(map[string]map[string][]map[string]any)
 		result["plugin_crm"] = make(map[string][]map[string]any)
 
-		err := useCase.processPluginCRMCollection(
+		err := useCase.processTransformedMongoCollection(
 			context.Background(),
 			dataSource,
+			pkg.PluginCRMFieldTransformer(hashKey, encryptKey),
+			"plugin_crm",
 			"holders",
 			[]string{"name"},
 			nil,
@@ -1678,7 +675,38 @@ func TestUseCase_ProcessPluginCRMCollection(t *testing.T) {
 			logger,
 		)
 		require.Error(t, err)
-		assert.Contains(t, err.Error(), "DATASOURCE_CRM_MIDAZ_ORGANIZATION_ID")
+		assert.Contains(t, err.Error(), "MIDAZ_ORGANIZATION_ID")
+	})
+
+	t.Run("Error - filter transform error stops before querying", func(t *testing.T) {
+		t.Parallel()
+
+		logger, _, _, _ := libCommons.NewTrackingFromContext(context.Background())
+
+		dataSource := &pkg.DataSource{
+			Initialized:         true,
+			DatabaseType:        "mongodb",
+			MidazOrganizationID: "org-123",
+		}
+
+		useCase := &UseCase{}
+
+		result := make(map[string]map[string][]map[string]any)
+		result["plugin_crm"] = make(map[string][]map[string]any)
+
+		err := useCase.processTransformedMongoCollection(
+			context.Background(),
+			dataSource,
+			pkg.PluginCRMFieldTransformer("", encryptKey), // Empty hash key triggers transform error
+			"plugin_crm",
+			"holders",
+			[]string{"name"},
+			map[string]model.FilterCondition{"document": {Equals: []any{"12345678901"}}},
+			result,
+			logger,
+		)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "error transforming advanced filters")
 	})
 
 	t.Run("Error - MongoDB query failure propagates error", func(t *testing.T) {
@@ -1706,17 +734,17 @@ func TestUseCase_ProcessPluginCRMCollection(t *testing.T) {
 		}
 
 		useCase := &UseCase{
-			CircuitBreakerManager:           cbManager,
-			CryptoHashSecretKeyPluginCRM:    hashKey,
-			CryptoEncryptSecretKeyPluginCRM: encryptKey,
+			CircuitBreakerManager: cbManager,
 		}
 
 		result := make(map[string]map[string][]map[string]any)
 		result["plugin_crm"] = make(map[string][]map[string]any)
 
-		err := useCase.processPluginCRMCollection(
+		err := useCase.processTransformedMongoCollection(
 			context.Background(),
 			dataSource,
+			pkg.PluginCRMFieldTransformer(hashKey, encryptKey),
+			"plugin_crm",
 			"holders",
 			[]string{"name"},
 			nil,
@@ -1755,17 +783,17 @@ func TestUseCase_ProcessPluginCRMCollection(t *testing.T) {
 		}
 
 		useCase := &UseCase{
-			CircuitBreakerManager:           cbManager,
-			CryptoHashSecretKeyPluginCRM:    hashKey,
-			CryptoEncryptSecretKeyPluginCRM: encryptKey,
+			CircuitBreakerManager: cbManager,
 		}
 
 		result := make(map[string]map[string][]map[string]any)
 		result["plugin_crm"] = make(map[string][]map[string]any)
 
-		err := useCase.processPluginCRMCollection(
+		err := useCase.processTransformedMongoCollection(
 			context.Background(),
 			dataSource,
+			pkg.PluginCRMFieldTransformer(hashKey, encryptKey),
+			"plugin_crm",
 			"holders",
 			[]string{"document"},
 			nil,
@@ -1776,7 +804,7 @@ func TestUseCase_ProcessPluginCRMCollection(t *testing.T) {
 	})
 }
 
-func TestUseCase_ProcessMongoCollection_PluginCRMOrganizationSkip(t *testing.T) {
+func TestUseCase_ProcessMongoCollection_SkippedCollection(t *testing.T) {
 	t.Parallel()
 
 	logger, _, _, _ := libCommons.NewTrackingFromContext(context.Background())
@@ -1784,6 +812,7 @@ func TestUseCase_ProcessMongoCollection_PluginCRMOrganizationSkip(t *testing.T)
 
 	useCase := &UseCase{
 		CircuitBreakerManager: cbManager,
+		FieldTransformers:     pkg.NewFieldTransformerRegistry(pkg.PluginCRMFieldTransformer("", "")),
 	}
 
 	dataSource := &pkg.DataSource{
@@ -1885,55 +914,6 @@ func TestUseCase_QueryMongoCollectionWithFilters_ErrorPaths(t *testing.T) {
 		assert.Contains(t, err.Error(), "unexpected query result type")
 	})
 
-	t.Run("Error - filter transform error within query flow", func(t *testing.T) {
-		t.Parallel()
-
-		ctrl := gomock.NewController(t)
-		defer ctrl.Finish()
-
-		mockCB := pkg.NewMockCircuitBreakerExecutor(ctrl)
-		logger, _, _, _ := libCommons.NewTrackingFromContext(context.Background())
-
-		// The circuit breaker executes the function which internally calls
-		// transformPluginCRMAdvancedFilters. When CryptoHashSecretKeyPluginCRM is empty,
-		// the transform fails.
-		mockCB.EXPECT().
-			Execute("plugin_crm", gomock.Any()).
-			DoAndReturn(func(name string, fn func() (any, error)) (any, error) {
-				return fn()
-			})
-
-		dataSource := &pkg.DataSource{
-			Initialized:  true,
-			DatabaseType: "mongodb",
-		}
-
-		// Use a collection name that contains underscore (to trigger CRM filter transform path)
-		// and provide filters to activate the transform
-		collectionFilters := map[string]model.FilterCondition{
-			"document": {
-				Equals: []any{"12345678901"},
-			},
-		}
-
-		useCase := &UseCase{
-			CircuitBreakerManager:        mockCB,
-			CryptoHashSecretKeyPluginCRM: "", // Empty key triggers transform error
-		}
-
-		_, err := useCase.queryMongoCollectionWithFilters(
-			context.Background(),
-			dataSource,
-			"holders_org123", // Contains underscore, not "organization" -> triggers transform
-			[]string{"name"},
-			collectionFilters,
-			logger,
-			"plugin_crm",
-		)
-		require.Error(t, err)
-		assert.Contains(t, err.Error(), "error transforming advanced filters")
-	})
-
 	t.Run("Success - query with filters uses QueryWithAdvancedFilters", func(t *testing.T) {
 		t.Parallel()
 
@@ -2065,160 +1045,6 @@ func TestUseCase_QueryDatabase_DataSourceUnavailable(t *testing.T) {
 	require.Error(t, err)
 }
 
-func TestUseCase_DecryptNestedFields_ErrorPaths(t *testing.T) {
-	t.Parallel()
-
-	hashKey := "0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef"
-	encryptKey := "0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef"
-
-	logger, _, _, _ := libCommons.NewTrackingFromContext(context.Background())
-	crypto := &libCrypto.Crypto{
-		HashSecretKey:    hashKey,
-		EncryptSecretKey: encryptKey,
-		Logger:           logger,
-	}
-
-	err := crypto.InitializeCipher()
-	require.NoError(t, err, "Failed to initialize cipher")
-
-	useCase := &UseCase{}
-
-	tests := []struct {
-		name        string
-		record      map[string]any
-		errContains string
-	}{
-		{
-			name: "Error - contact field decryption failure",
-			record: map[string]any{
-				"contact": map[string]any{
-					"primary_email": "invalid-encrypted-data",
-				},
-			},
-			errContains: "contact.primary_email",
-		},
-		{
-			name: "Error - banking_details field decryption failure",
-			record: map[string]any{
-				"banking_details": map[string]any{
-					"account": "invalid-encrypted-data",
-				},
-			},
-			errContains: "banking_details.account",
-		},
-		{
-			name: "Error - legal_person representative decryption failure",
-			record: map[string]any{
-				"legal_person": map[string]any{
-					"representative": map[string]any{
-						"name": "invalid-encrypted-data",
-					},
-				},
-			},
-			errContains: "legal_person.representative.name",
-		},
-		{
-			name: "Error - natural_person field decryption failure",
-			record: map[string]any{
-				"natural_person": map[string]any{
-					"mother_name": "invalid-encrypted-data",
-				},
-			},
-			errContains: "natural_person.mother_name",
-		},
-		{
-			name: "Error - regulatory_fields decryption failure",
-			record: map[string]any{
-				"regulatory_fields": map[string]any{
-					"participant_document": "invalid-encrypted-data",
-				},
-			},
-			errContains: "regulatory_fields.participant_document",
-		},
-		{
-			name: "Error - related_parties document decryption failure",
-			record: map[string]any{
-				"related_parties": []any{
-					map[string]any{
-						"document": "invalid-encrypted-data",
-					},
-				},
-			},
-			errContains: "related_parties[0].document",
-		},
-	}
-
-	for _, tt := range tests {
-		tt := tt
-		t.Run(tt.name, func(t *testing.T) {
-			t.Parallel()
-
-			err := useCase.decryptNestedFields(tt.record, crypto)
-			require.Error(t, err)
-			assert.Contains(t, err.Error(), tt.errContains)
-		})
-	}
-}
-
-func TestUseCase_DecryptNestedFields_NoNestedFields(t *testing.T) {
-	t.Parallel()
-
-	hashKey := "0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef"
-	encryptKey := "0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef"
-
-	logger, _, _, _ := libCommons.NewTrackingFromContext(context.Background())
-	crypto := &libCrypto.Crypto{
-		HashSecretKey:    hashKey,
-		EncryptSecretKey: encryptKey,
-		Logger:           logger,
-	}
-
-	err := crypto.InitializeCipher()
-	require.NoError(t, err)
-
-	useCase := &UseCase{}
-
-	// Record with no nested fields at all - all decrypt functions should return nil
-	record := map[string]any{
-		"id":     "test-123",
-		"status": "active",
-	}
-
-	err = useCase.decryptNestedFields(record, crypto)
-	require.NoError(t, err)
-}
-
-func TestUseCase_DecryptRelatedPartiesFields_NonMapElement(t *testing.T) {
-	t.Parallel()
-
-	hashKey := "0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef"
-	encryptKey := "0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef"
-
-	logger, _, _, _ := libCommons.NewTrackingFromContext(context.Background())
-	crypto := &libCrypto.Crypto{
-		HashSecretKey:    hashKey,
-		EncryptSecretKey: encryptKey,
-		Logger:           logger,
-	}
-
-	err := crypto.InitializeCipher()
-	require.NoError(t, err)
-
-	useCase := &UseCase{}
-
-	// related_parties with a non-map element should be skipped via continue
-	record := map[string]any{
-		"related_parties": []any{
-			"not-a-map",
-			42,
-			nil,
-		},
-	}
-
-	err = useCase.decryptRelatedPartiesFields(record, crypto)
-	require.NoError(t, err)
-}
-
 func TestUseCase_QueryPostgresDatabase_ErrorPaths(t *testing.T) {
 	t.Parallel()
 
@@ -2583,7 +1409,7 @@ func TestUseCase_QueryPostgresDatabase_ErrorPaths(t *testing.T) {
 func TestUseCase_ProcessMongoCollection_ErrorPropagation(t *testing.T) {
 	t.Parallel()
 
-	t.Run("Error - processPluginCRMCollection error propagates", func(t *testing.T) {
+	t.Run("Error - processTransformedMongoCollection error propagates", func(t *testing.T) {
 		t.Parallel()
 
 		ctrl := gomock.NewController(t)
@@ -2605,9 +1431,11 @@ func TestUseCase_ProcessMongoCollection_ErrorPropagation(t *testing.T) {
 		}
 
 		useCase := &UseCase{
-			CircuitBreakerManager:           cbManager,
-			CryptoHashSecretKeyPluginCRM:    "0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef",
-			CryptoEncryptSecretKeyPluginCRM: "0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef",
+			CircuitBreakerManager: cbManager,
+			FieldTransformers: pkg.NewFieldTransformerRegistry(pkg.PluginCRMFieldTransformer(
+				"0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef",
+				"0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef",
+			)),
 		}
 
 		result := make(map[string]map[string][]map[string]any)
@@ -2705,85 +1533,3 @@ func TestUseCase_QueryMongoDatabase_ErrorPropagation(t *testing.T) {
 	)
 	require.Error(t, err)
 }
-
-func TestUseCase_DecryptPluginCRMData_CipherInitFailure(t *testing.T) {
-	t.Parallel()
-
-	logger, _, _, _ := libCommons.NewTrackingFromContext(context.Background())
-
-	useCase := &UseCase{
-		CryptoEncryptSecretKeyPluginCRM: "invalid-key-too-short",
-		CryptoHashSecretKeyPluginCRM:    "valid-hash-key",
-	}
-
-	collectionResult := []map[string]any{
-		{"document": "encrypted_value"},
-	}
-
-	_, err := useCase.decryptPluginCRMData(logger, collectionResult, []string{"document"})
-	require.Error(t, err)
-	assert.Contains(t, err.Error(), "initialize cipher")
-}
-
-func TestUseCase_DecryptPluginCRMData_MissingHashKey(t *testing.T) {
-	t.Parallel()
-
-	logger, _, _, _ := libCommons.NewTrackingFromContext(context.Background())
-
-	useCase := &UseCase{
-		CryptoEncryptSecretKeyPluginCRM: "0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef",
-		CryptoHashSecretKeyPluginCRM:    "",
-	}
-
-	collectionResult := []map[string]any{
-		{"document": "encrypted_value"},
-	}
-
-	_, err := useCase.decryptPluginCRMData(logger, collectionResult, []string{"document"})
-	require.Error(t, err)
-	assert.Contains(t, err.Error(), "CRYPTO_HASH_SECRET_KEY_PLUGIN_CRM")
-}
-
-func TestUseCase_DecryptPluginCRMData_RecordDecryptionFailure(t *testing.T) {
-	t.Parallel()
-
-	hashKey := "0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef"
-	encryptKey := "0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef"
-
-	logger, _, _, _ := libCommons.NewTrackingFromContext(context.Background())
-
-	useCase := &UseCase{
-		CryptoEncryptSecretKeyPluginCRM: encryptKey,
-		CryptoHashSecretKeyPluginCRM:    hashKey,
-	}
-
-	// Second record has invalid data, triggering mid-collection failure
-	collectionResult := []map[string]any{
-		{"id": "rec-1", "status": "active"},
-		{"id": "rec-2", "document": "invalid-encrypted-data"},
-	}
-
-	_, err := useCase.decryptPluginCRMData(logger, collectionResult, []string{"document"})
-	require.Error(t, err)
-	assert.Contains(t, err.Error(), "failed to decrypt record 1")
-}
-
-func TestUseCase_DecryptPluginCRMData_NestedFieldsTriggerDecryption(t *testing.T) {
-	t.Parallel()
-
-	logger, _, _, _ := libCommons.NewTrackingFromContext(context.Background())
-
-	useCase := &UseCase{
-		CryptoEncryptSecretKeyPluginCRM: "",
-		CryptoHashSecretKeyPluginCRM:    "some-key",
-	}
-
-	// Fields with dots trigger needsDecryption=true via the nested field check
-	collectionResult := []map[string]any{
-		{"contact.email": "test@example.com"},
-	}
-
-	_, err := useCase.decryptPluginCRMData(logger, collectionResult, []string{"contact.email"})
-	require.Error(t, err)
-	assert.Contains(t, err.Error(), "CRYPTO_ENCRYPT_SECRET_KEY_PLUGIN_CRM")
-}
@@ -0,0 +1,60 @@
+// Copyright (c) 2026 Lerian Studio. All rights reserved.
+// Use of this source code is governed by the Elastic License 2.0
+// that can be found in the LICENSE file.
+
+package services
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/LerianStudio/lib-commons/v2/commons/log"
+)
+
+// startHeartbeat records that the report is being generated, right away and then every HeartbeatInterval,
+// so the stuck-report reaper of the manager tells a report a worker is still generating from one whose
+// worker is gone. A zero interval disables the heartbeat. The returned stop function must be called once
+// the generation is over.
+func (uc *UseCase) startHeartbeat(ctx context.Context, message GenerateReportMessage, logger log.Logger) func() {
+	if uc.HeartbeatInterval <= 0 {
+		return func() {}
+	}
+
+	beat := func() {
+		if err := uc.ReportDataRepo.RecordHeartbeat(ctx, message.ReportID, message.OrganizationID, time.Now()); err != nil {
+			logger.Warnf("Failed to record heartbeat of report %s: %v", message.ReportID, err)
+		}
+	}
+
+	beat()
+
+	done := make(chan struct{})
+
+	var wg sync.WaitGroup
+
+	wg.Add(1)
+
+	go func() {
+		defer wg.Done()
+
+		ticker := time.NewTicker(uc.HeartbeatInterval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-done:
+				return
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				beat()
+			}
+		}
+	}()
+
+	return func() {
+		close(done)
+		wg.Wait()
+	}
+}
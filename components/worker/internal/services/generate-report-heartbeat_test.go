@@ -0,0 +1,62 @@
+// Copyright (c) 2026 Lerian Studio. All rights reserved.
+// Use of this source code is governed by the Elastic License 2.0
+// that can be found in the LICENSE file.
+
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	reportData "github.com/LerianStudio/reporter/pkg/mongodb/report"
+
+	"github.com/LerianStudio/lib-commons/v2/commons/zap"
+	"github.com/google/uuid"
+	"go.uber.org/mock/gomock"
+)
+
+func TestUseCase_StartHeartbeat(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockReportDataRepo := reportData.NewMockRepository(ctrl)
+
+	message := GenerateReportMessage{ReportID: uuid.New(), OrganizationID: uuid.New()}
+
+	// The first heartbeat is recorded right away, the next ones on every tick; failures are only logged
+	mockReportDataRepo.
+		EXPECT().
+		RecordHeartbeat(gomock.Any(), message.ReportID, message.OrganizationID, gomock.Any()).
+		Return(errors.New("connection refused"))
+	mockReportDataRepo.
+		EXPECT().
+		RecordHeartbeat(gomock.Any(), message.ReportID, message.OrganizationID, gomock.Any()).
+		Return(nil).
+		MinTimes(1)
+
+	uc := &UseCase{
+		ReportDataRepo:    mockReportDataRepo,
+		HeartbeatInterval: 10 * time.Millisecond,
+	}
+
+	stop := uc.startHeartbeat(context.Background(), message, zap.InitializeLogger())
+
+	time.Sleep(50 * time.Millisecond)
+	stop()
+}
+
+func TestUseCase_StartHeartbeat_Disabled(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	uc := &UseCase{ReportDataRepo: reportData.NewMockRepository(ctrl)}
+
+	stop := uc.startHeartbeat(context.Background(), GenerateReportMessage{ReportID: uuid.New()}, zap.InitializeLogger())
+	stop()
+}
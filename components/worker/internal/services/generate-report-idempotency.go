@@ -17,7 +17,7 @@ import (
 )
 
 // shouldSkipProcessing checks if report should be skipped due to idempotency.
-func (uc *UseCase) shouldSkipProcessing(ctx context.Context, reportID uuid.UUID, logger log.Logger) bool {
+func (uc *UseCase) shouldSkipProcessing(ctx context.Context, reportID, organizationID uuid.UUID, logger log.Logger) bool {
 	_, tracer, reqId, _ := libCommons.NewTrackingFromContext(ctx)
 
 	ctx, span := tracer.Start(ctx, "service.report.should_skip_processing")
@@ -28,7 +28,7 @@ func (uc *UseCase) shouldSkipProcessing(ctx context.Context, reportID uuid.UUID,
 		attribute.String("app.request.report_id", reportID.String()),
 	)
 
-	reportStatus, err := uc.checkReportStatus(ctx, reportID, logger)
+	reportStatus, err := uc.checkReportStatus(ctx, reportID, organizationID, logger)
 	if err == nil {
 		if reportStatus == constant.FinishedStatus {
 			logger.Infof("Report %s is already finished, skipping reprocessing", reportID)
@@ -39,13 +39,18 @@ func (uc *UseCase) shouldSkipProcessing(ctx context.Context, reportID uuid.UUID,
 			logger.Warnf("Report %s is in error state, skipping reprocessing", reportID)
 			return true
 		}
+
+		if reportStatus == constant.CancelledStatus {
+			logger.Infof("Report %s was cancelled, skipping processing", reportID)
+			return true
+		}
 	}
 
 	return false
 }
 
 // checkReportStatus checks the current status of a report to implement idempotency.
-func (uc *UseCase) checkReportStatus(ctx context.Context, reportID uuid.UUID, logger log.Logger) (string, error) {
+func (uc *UseCase) checkReportStatus(ctx context.Context, reportID, organizationID uuid.UUID, logger log.Logger) (string, error) {
 	_, tracer, reqId, _ := libCommons.NewTrackingFromContext(ctx)
 
 	ctx, span := tracer.Start(ctx, "service.report.check_report_status")
@@ -56,7 +61,7 @@ func (uc *UseCase) checkReportStatus(ctx context.Context, reportID uuid.UUID, lo
 		attribute.String("app.request.report_id", reportID.String()),
 	)
 
-	report, err := uc.ReportDataRepo.FindByID(ctx, reportID)
+	report, err := uc.ReportDataRepo.FindByID(ctx, reportID, organizationID)
 	if err != nil {
 		libOtel.HandleSpanError(&span, "Failed to check report status", err)
 
@@ -42,7 +42,7 @@ func TestUseCase_ShouldSkipProcessing(t *testing.T) {
 			reportID: uuid.New(),
 			mockSetup: func(reportID uuid.UUID) {
 				mockReportDataRepo.EXPECT().
-					FindByID(gomock.Any(), reportID).
+					FindByID(gomock.Any(), reportID, gomock.Any()).
 					Return(&reportData.Report{
 						ID:     reportID,
 						Status: "Finished",
@@ -55,7 +55,7 @@ func TestUseCase_ShouldSkipProcessing(t *testing.T) {
 			reportID: uuid.New(),
 			mockSetup: func(reportID uuid.UUID) {
 				mockReportDataRepo.EXPECT().
-					FindByID(gomock.Any(), reportID).
+					FindByID(gomock.Any(), reportID, gomock.Any()).
 					Return(&reportData.Report{
 						ID:     reportID,
 						Status: "Error",
@@ -63,12 +63,25 @@ func TestUseCase_ShouldSkipProcessing(t *testing.T) {
 			},
 			expectedSkip: true,
 		},
+		{
+			name:     "Success - Skip report cancelled",
+			reportID: uuid.New(),
+			mockSetup: func(reportID uuid.UUID) {
+				mockReportDataRepo.EXPECT().
+					FindByID(gomock.Any(), reportID, gomock.Any()).
+					Return(&reportData.Report{
+						ID:     reportID,
+						Status: "Cancelled",
+					}, nil)
+			},
+			expectedSkip: true,
+		},
 		{
 			name:     "Success - Don't skip report still processing",
 			reportID: uuid.New(),
 			mockSetup: func(reportID uuid.UUID) {
 				mockReportDataRepo.EXPECT().
-					FindByID(gomock.Any(), reportID).
+					FindByID(gomock.Any(), reportID, gomock.Any()).
 					Return(&reportData.Report{
 						ID:     reportID,
 						Status: "Processing",
@@ -81,7 +94,7 @@ func TestUseCase_ShouldSkipProcessing(t *testing.T) {
 			reportID: uuid.New(),
 			mockSetup: func(reportID uuid.UUID) {
 				mockReportDataRepo.EXPECT().
-					FindByID(gomock.Any(), reportID).
+					FindByID(gomock.Any(), reportID, gomock.Any()).
 					Return(nil, errors.New("not found"))
 			},
 			expectedSkip: false,
@@ -93,8 +106,8 @@ func TestUseCase_ShouldSkipProcessing(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			tt.mockSetup(tt.reportID)
 
-			result := useCase.shouldSkipProcessing(context.Background(), tt.reportID, logger)
-			assert.Equal(t, tt.expectedSkip, result, "shouldSkipProcessing()")
+			result := useCase.shouldSkipProcessing(context.Background(), tt.reportID, uuid.Nil, logger)
+			assert.Equal(t, tt.expectedSkip, result, "shouldSkipProcessing(uuid.Nil)")
 		})
 	}
 }
@@ -120,7 +133,7 @@ func TestUseCase_CheckReportStatus(t *testing.T) {
 			reportID: uuid.New(),
 			mockSetup: func(reportID uuid.UUID) {
 				mockReportDataRepo.EXPECT().
-					FindByID(gomock.Any(), reportID).
+					FindByID(gomock.Any(), reportID, gomock.Any()).
 					Return(&reportData.Report{
 						ID:     reportID,
 						Status: "Processing",
@@ -134,7 +147,7 @@ func TestUseCase_CheckReportStatus(t *testing.T) {
 			reportID: uuid.New(),
 			mockSetup: func(reportID uuid.UUID) {
 				mockReportDataRepo.EXPECT().
-					FindByID(gomock.Any(), reportID).
+					FindByID(gomock.Any(), reportID, gomock.Any()).
 					Return(nil, errors.New("not found"))
 			},
 			expectedStatus: "",
@@ -151,7 +164,7 @@ func TestUseCase_CheckReportStatus(t *testing.T) {
 				ReportDataRepo: mockReportDataRepo,
 			}
 
-			status, err := useCase.checkReportStatus(context.Background(), tt.reportID, logger)
+			status, err := useCase.checkReportStatus(context.Background(), tt.reportID, uuid.Nil, logger)
 			if tt.expectError {
 				require.Error(t, err)
 			} else {
@@ -10,6 +10,10 @@ import (
 	"os"
 	"strings"
 
+	"github.com/LerianStudio/reporter/pkg"
+	"github.com/LerianStudio/reporter/pkg/constant"
+	"github.com/LerianStudio/reporter/pkg/model"
+	"github.com/LerianStudio/reporter/pkg/pdf"
 	"github.com/LerianStudio/reporter/pkg/pongo"
 
 	libCommons "github.com/LerianStudio/lib-commons/v2/commons"
@@ -31,7 +35,7 @@ func (uc *UseCase) loadTemplate(ctx context.Context, message GenerateReportMessa
 
 	spanTemplate.SetAttributes(attribute.String("app.request.request_id", reqId))
 
-	fileBytes, err := uc.TemplateSeaweedFS.Get(ctx, message.TemplateID.String())
+	fileBytes, err := uc.TemplateSeaweedFS.Get(ctx, pkg.TenantObjectName(message.OrganizationID, message.TemplateID.String()))
 	if err != nil {
 		if errUpdate := uc.updateReportWithErrors(ctx, message.ReportID, err.Error()); errUpdate != nil {
 			libOtel.HandleSpanError(span, "Error to update report status with error.", errUpdate)
@@ -51,7 +55,10 @@ func (uc *UseCase) loadTemplate(ctx context.Context, message GenerateReportMessa
 	return fileBytes, nil
 }
 
-// renderTemplate renders the template with data from external sources.
+// renderTemplate renders the template with data from external sources. Partials referenced by
+// include, extends and import tags are loaded from the organization's template storage, and the
+// locale and timezone requested for the report override the template's. Fixed-width reports are
+// rendered with the layout declared by the template, and every line is checked against it.
 func (uc *UseCase) renderTemplate(ctx context.Context, templateBytes []byte, result map[string]map[string][]map[string]any, message GenerateReportMessage, span *trace.Span) (string, error) {
 	logger, tracer, reqId, _ := libCommons.NewTrackingFromContext(ctx)
 
@@ -60,9 +67,14 @@ func (uc *UseCase) renderTemplate(ctx context.Context, templateBytes []byte, res
 
 	spanRender.SetAttributes(attribute.String("app.request.request_id", reqId))
 
-	renderer := pongo.NewTemplateRenderer()
+	loader := pongo.NewStorageLoader(ctx, uc.TemplateSeaweedFS, func(name string) string {
+		return pkg.TenantPartialObjectName(message.OrganizationID, name)
+	})
+	renderer := pongo.NewTemplateRendererWithLoader(loader).
+		WithLocale(message.Locale).
+		WithTimezone(message.Timezone)
 
-	out, err := renderer.RenderFromBytes(ctx, templateBytes, result, logger)
+	out, err := renderWithLayout(ctx, renderer, templateBytes, result, message, logger)
 	if err != nil {
 		if errUpdate := uc.updateReportWithErrors(ctx, message.ReportID, err.Error()); errUpdate != nil {
 			libOtel.HandleSpanError(span, "Error to update report status with error.", errUpdate)
@@ -80,6 +92,36 @@ func (uc *UseCase) renderTemplate(ctx context.Context, templateBytes []byte, res
 	return out, nil
 }
 
+// renderWithLayout renders the template. A fixed-width template must declare its layout, which is
+// loaded once for the render and then used to validate the length of each emitted line.
+func renderWithLayout(ctx context.Context, renderer *pongo.TemplateRenderer, templateBytes []byte, result map[string]map[string][]map[string]any, message GenerateReportMessage, logger log.Logger) (string, error) {
+	if !strings.EqualFold(message.OutputFormat, constant.OutputFormatFixedWidth) {
+		return renderer.RenderFromBytes(ctx, templateBytes, result, logger)
+	}
+
+	layout, err := renderer.LoadFixedWidthLayout(templateBytes)
+	if err != nil {
+		return "", err
+	}
+
+	if layout == nil {
+		return "", fmt.Errorf("fixed-width template does not declare a layout with the fixed_width tag")
+	}
+
+	out, err := renderer.WithFixedWidthLayout(layout).RenderFromBytes(ctx, templateBytes, result, logger)
+	if err != nil {
+		return "", err
+	}
+
+	if errValidate := layout.ValidateOutput(out); errValidate != nil {
+		return "", errValidate
+	}
+
+	logger.Infof("Fixed-width output validated (%d bytes, record length %d)", len(out), layout.RecordLength)
+
+	return out, nil
+}
+
 // convertToPDFIfNeeded converts HTML to PDF if output format is PDF.
 func (uc *UseCase) convertToPDFIfNeeded(ctx context.Context, message GenerateReportMessage, htmlOutput string, span *trace.Span) (string, error) {
 	if strings.ToLower(message.OutputFormat) != "pdf" {
@@ -95,7 +137,12 @@ func (uc *UseCase) convertToPDFIfNeeded(ctx context.Context, message GenerateRep
 
 	logger.Infof("Converting HTML to PDF for report %s (HTML size: %d bytes)", message.ReportID, len(htmlOutput))
 
-	pdfBytes, err := uc.convertHTMLToPDF(htmlOutput, logger)
+	var pdfOptions *model.PDFOptions
+	if message.OutputOptions != nil {
+		pdfOptions = message.OutputOptions.PDF
+	}
+
+	pdfBytes, err := uc.convertHTMLToPDF(htmlOutput, pdfOptions, logger)
 	if err != nil {
 		if errUpdate := uc.updateReportWithErrors(ctx, message.ReportID, err.Error()); errUpdate != nil {
 			libOtel.HandleSpanError(span, "Error to update report status with error.", errUpdate)
@@ -115,8 +162,8 @@ func (uc *UseCase) convertToPDFIfNeeded(ctx context.Context, message GenerateRep
 	return string(pdfBytes), nil
 }
 
-// convertHTMLToPDF converts HTML content to PDF using Chrome headless via PDF pool.
-func (uc *UseCase) convertHTMLToPDF(htmlContent string, logger log.Logger) ([]byte, error) {
+// convertHTMLToPDF converts HTML content to PDF using Chrome headless via PDF pool, with the page setup of the options.
+func (uc *UseCase) convertHTMLToPDF(htmlContent string, options *model.PDFOptions, logger log.Logger) ([]byte, error) {
 	tmpFile, err := os.CreateTemp("", "pdf-*.pdf")
 	if err != nil {
 		logger.Errorf("Failed to create temporary PDF file: %v", err)
@@ -134,7 +181,7 @@ func (uc *UseCase) convertHTMLToPDF(htmlContent string, logger log.Logger) ([]by
 		}
 	}()
 
-	err = uc.PdfPool.Submit(htmlContent, tmpFileName)
+	err = uc.PdfPool.Submit(htmlContent, tmpFileName, options)
 	if err != nil {
 		logger.Errorf("Failed to generate PDF from HTML: %v", err)
 		return nil, fmt.Errorf("failed to generate PDF from HTML: %w", err)
@@ -150,3 +197,71 @@ func (uc *UseCase) convertHTMLToPDF(htmlContent string, logger log.Logger) ([]by
 
 	return pdfBytes, nil
 }
+
+// protectPDFIfNeeded encrypts a PDF report when the template asks for password protection, and returns
+// it with the user password that opens it. The passwords are rendered from their template expressions
+// with the report data and are never stored or logged.
+func (uc *UseCase) protectPDFIfNeeded(ctx context.Context, message GenerateReportMessage, pdfOutput string, result map[string]map[string][]map[string]any) (string, string, error) {
+	if strings.ToLower(message.OutputFormat) != "pdf" || message.OutputOptions == nil || message.OutputOptions.PDF == nil || message.OutputOptions.PDF.Protection == nil {
+		return pdfOutput, "", nil
+	}
+
+	logger, tracer, reqId, _ := libCommons.NewTrackingFromContext(ctx)
+
+	ctx, spanProtect := tracer.Start(ctx, "service.report.protect_pdf")
+	defer spanProtect.End()
+
+	spanProtect.SetAttributes(attribute.String("app.request.request_id", reqId))
+
+	protection := message.OutputOptions.PDF.Protection
+
+	userPassword, err := renderPDFPassword(ctx, protection.UserPassword, result, message, logger)
+	if err != nil {
+		libOtel.HandleSpanError(&spanProtect, "Error rendering PDF user password.", err)
+
+		return "", "", fmt.Errorf("failed to render the PDF user password: %w", err)
+	}
+
+	if userPassword == "" {
+		return "", "", fmt.Errorf("the PDF user password rendered empty for the report data")
+	}
+
+	ownerPassword, err := renderPDFPassword(ctx, protection.OwnerPassword, result, message, logger)
+	if err != nil {
+		libOtel.HandleSpanError(&spanProtect, "Error rendering PDF owner password.", err)
+
+		return "", "", fmt.Errorf("failed to render the PDF owner password: %w", err)
+	}
+
+	protected, err := pdf.Protect([]byte(pdfOutput), userPassword, ownerPassword, protection.Permissions)
+	if err != nil {
+		libOtel.HandleSpanError(&spanProtect, "Error encrypting PDF.", err)
+
+		return "", "", err
+	}
+
+	logger.Infof("PDF protected with a password (permissions: %v)", protection.Permissions)
+
+	return string(protected), userPassword, nil
+}
+
+// renderPDFPassword renders a password expression with the report data, without HTML escaping and
+// trimming surrounding whitespace. The password is kept as rendered: trailing zeros of values such
+// as "1234.50" are part of it. An empty expression renders an empty password.
+func renderPDFPassword(ctx context.Context, expression string, result map[string]map[string][]map[string]any, message GenerateReportMessage, logger log.Logger) (string, error) {
+	if expression == "" {
+		return "", nil
+	}
+
+	renderer := pongo.NewTemplateRenderer().
+		WithLocale(message.Locale).
+		WithTimezone(message.Timezone).
+		WithRawOutput()
+
+	password, err := renderer.RenderFromBytes(ctx, []byte("{% autoescape off %}"+expression+"{% endautoescape %}"), result, logger)
+	if err != nil {
+		return "", err
+	}
+
+	return strings.TrimSpace(password), nil
+}
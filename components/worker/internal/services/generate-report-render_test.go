@@ -9,10 +9,14 @@ import (
 	"errors"
 	"testing"
 
+	"github.com/LerianStudio/reporter/pkg"
+	"github.com/LerianStudio/reporter/pkg/model"
 	reportData "github.com/LerianStudio/reporter/pkg/mongodb/report"
+	"github.com/LerianStudio/reporter/pkg/pongo"
 	"github.com/LerianStudio/reporter/pkg/seaweedfs/template"
 
 	libCommons "github.com/LerianStudio/lib-commons/v2/commons"
+	"github.com/LerianStudio/lib-commons/v2/commons/zap"
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -122,6 +126,119 @@ func TestUseCase_RenderTemplate(t *testing.T) {
 		assert.Equal(t, "Hello World", result)
 	})
 
+	t.Run("Success - renders template extending a stored partial", func(t *testing.T) {
+		t.Parallel()
+
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		_, tracer, _, _ := libCommons.NewTrackingFromContext(context.Background()) //nolint:dogsled // only tracer needed
+		_, span := tracer.Start(context.Background(), "test")
+
+		organizationID := uuid.New()
+
+		mockTemplateRepo := template.NewMockRepository(ctrl)
+		mockTemplateRepo.EXPECT().
+			Get(gomock.Any(), pkg.TenantPartialObjectName(organizationID, "layouts/corporate")).
+			Return([]byte(`<header>ACME</header>{% block content %}{% endblock %}`), nil)
+
+		useCase := &UseCase{
+			TemplateSeaweedFS: mockTemplateRepo,
+		}
+
+		templateBytes := []byte(`{% extends "layouts/corporate" %}{% block content %}Hello {{ db.users.0.name }}{% endblock %}`)
+		data := map[string]map[string][]map[string]any{
+			"db": {
+				"users": {
+					{"name": "World"},
+				},
+			},
+		}
+
+		message := GenerateReportMessage{
+			TemplateID:     uuid.New(),
+			ReportID:       uuid.New(),
+			OrganizationID: organizationID,
+		}
+
+		result, err := useCase.renderTemplate(context.Background(), templateBytes, data, message, &span)
+		require.NoError(t, err)
+		assert.Equal(t, "<header>ACME</header>Hello World", result)
+	})
+
+	t.Run("Success - renders a fixed-width template with its stored layout", func(t *testing.T) {
+		t.Parallel()
+		require.NoError(t, pongo.RegisterAll())
+
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		_, tracer, _, _ := libCommons.NewTrackingFromContext(context.Background()) //nolint:dogsled // only tracer needed
+		_, span := tracer.Start(context.Background(), "test")
+
+		organizationID := uuid.New()
+
+		mockTemplateRepo := template.NewMockRepository(ctrl)
+		mockTemplateRepo.EXPECT().
+			Get(gomock.Any(), pkg.TenantPartialObjectName(organizationID, "layouts/simple")).
+			Return([]byte(`{"record_length": 8, "records": {"detail": {"fields": [
+				{"name": "name", "start": 1, "length": 5},
+				{"name": "seq", "start": 6, "length": 3, "type": "numeric", "source": "sequence"}]}}}`), nil)
+
+		useCase := &UseCase{
+			TemplateSeaweedFS: mockTemplateRepo,
+		}
+
+		templateBytes := []byte("{% fixed_width \"layouts/simple\" %}\n{% for u in db.users %}\n{% record \"detail\" name=u.name %}\n{% endfor %}\n")
+		data := map[string]map[string][]map[string]any{
+			"db": {
+				"users": {{"name": "Ann"}, {"name": "Bob"}},
+			},
+		}
+
+		message := GenerateReportMessage{
+			TemplateID:     uuid.New(),
+			ReportID:       uuid.New(),
+			OrganizationID: organizationID,
+			OutputFormat:   "fixed-width",
+		}
+
+		result, err := useCase.renderTemplate(context.Background(), templateBytes, data, message, &span)
+		require.NoError(t, err)
+		assert.Equal(t, "Ann  001\r\nBob  002\r\n", result)
+	})
+
+	t.Run("Error - fixed-width template without a layout", func(t *testing.T) {
+		t.Parallel()
+
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockReportDataRepo := reportData.NewMockRepository(ctrl)
+		_, tracer, _, _ := libCommons.NewTrackingFromContext(context.Background()) //nolint:dogsled // only tracer needed
+		_, span := tracer.Start(context.Background(), "test")
+
+		reportID := uuid.New()
+
+		mockReportDataRepo.EXPECT().
+			UpdateReportStatusById(gomock.Any(), "Error", reportID, gomock.Any(), gomock.Any()).
+			Return(nil)
+
+		useCase := &UseCase{
+			ReportDataRepo: mockReportDataRepo,
+		}
+
+		message := GenerateReportMessage{
+			TemplateID:   uuid.New(),
+			ReportID:     reportID,
+			OutputFormat: "fixed-width",
+		}
+
+		_, err := useCase.renderTemplate(context.Background(), []byte("plain text"), nil, message, &span)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "fixed_width")
+	})
+
 	t.Run("Error - template rendering fails and report update succeeds", func(t *testing.T) {
 		t.Parallel()
 
@@ -252,3 +369,82 @@ func TestUseCase_ConvertToPDFIfNeeded_NonPDFFormat(t *testing.T) {
 	require.NoError(t, err)
 	assert.Equal(t, htmlContent, result, "expected unchanged content for non-PDF format")
 }
+
+func TestUseCase_ProtectPDFIfNeeded(t *testing.T) {
+	t.Parallel()
+
+	result := map[string]map[string][]map[string]any{
+		"onboarding": {"holder": {{"document": "12345678900", "name": ""}}},
+	}
+
+	tests := []struct {
+		name    string
+		message GenerateReportMessage
+		wantErr string
+	}{
+		{
+			name:    "Non-PDF format is unchanged",
+			message: GenerateReportMessage{OutputFormat: "html", OutputOptions: &model.OutputOptions{PDF: &model.PDFOptions{Protection: &model.PDFProtection{UserPassword: "x"}}}},
+		},
+		{
+			name:    "PDF without protection is unchanged",
+			message: GenerateReportMessage{OutputFormat: "pdf", OutputOptions: &model.OutputOptions{PDF: &model.PDFOptions{PaperSize: "a4"}}},
+		},
+		{
+			name:    "Password rendered empty",
+			message: GenerateReportMessage{OutputFormat: "pdf", OutputOptions: &model.OutputOptions{PDF: &model.PDFOptions{Protection: &model.PDFProtection{UserPassword: "{{ onboarding.holder.0.name }}"}}}},
+			wantErr: "rendered empty",
+		},
+		{
+			name:    "Invalid password expression",
+			message: GenerateReportMessage{OutputFormat: "pdf", OutputOptions: &model.OutputOptions{PDF: &model.PDFOptions{Protection: &model.PDFProtection{UserPassword: "{{ onboarding.holder.0.document|"}}}},
+			wantErr: "failed to render the PDF user password",
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			useCase := &UseCase{}
+
+			out, password, err := useCase.protectPDFIfNeeded(context.Background(), tt.message, "content", result)
+			if tt.wantErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErr)
+
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, "content", out)
+			assert.Empty(t, password)
+		})
+	}
+}
+
+func TestRenderPDFPassword(t *testing.T) {
+	t.Parallel()
+
+	result := map[string]map[string][]map[string]any{
+		"onboarding": {"holder": {{"document": "12345678900", "code": "a&b", "branch": "1234.50"}}},
+	}
+	logger := zap.InitializeLogger()
+
+	password, err := renderPDFPassword(context.Background(), ` {{ onboarding.holder.0.document|slice:":5" }} `, result, GenerateReportMessage{}, logger)
+	require.NoError(t, err)
+	assert.Equal(t, "12345", password)
+
+	password, err = renderPDFPassword(context.Background(), `{{ onboarding.holder.0.code }}`, result, GenerateReportMessage{}, logger)
+	require.NoError(t, err)
+	assert.Equal(t, "a&b", password, "passwords are not HTML escaped")
+
+	password, err = renderPDFPassword(context.Background(), `{{ onboarding.holder.0.branch }}`, result, GenerateReportMessage{}, logger)
+	require.NoError(t, err)
+	assert.Equal(t, "1234.50", password, "trailing zeros are kept in passwords")
+
+	password, err = renderPDFPassword(context.Background(), "", result, GenerateReportMessage{}, logger)
+	require.NoError(t, err)
+	assert.Empty(t, password)
+}
@@ -0,0 +1,99 @@
+// Copyright (c) 2026 Lerian Studio. All rights reserved.
+// Use of this source code is governed by the Elastic License 2.0
+// that can be found in the LICENSE file.
+
+package services
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/LerianStudio/reporter/pkg"
+	"github.com/LerianStudio/reporter/pkg/constant"
+	"github.com/LerianStudio/reporter/pkg/pdf"
+	"github.com/LerianStudio/reporter/pkg/templateutils"
+
+	libCommons "github.com/LerianStudio/lib-commons/v2/commons"
+	libOtel "github.com/LerianStudio/lib-commons/v2/commons/opentelemetry"
+
+	// otel/attribute is used for span attribute types (no lib-commons wrapper available)
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// signReportIfNeeded signs a report when a signing certificate is configured. PDF reports get an embedded
+// PAdES signature, opened with the user password of protected reports. Other formats are returned
+// unchanged with their detached CMS signature, to be stored next to the report.
+func (uc *UseCase) signReportIfNeeded(ctx context.Context, message GenerateReportMessage, out, pdfPassword string) (string, []byte, error) {
+	if uc.Signer == nil {
+		return out, nil, nil
+	}
+
+	logger, tracer, reqId, _ := libCommons.NewTrackingFromContext(ctx)
+
+	_, spanSign := tracer.Start(ctx, "service.report.sign_report")
+	defer spanSign.End()
+
+	spanSign.SetAttributes(attribute.String("app.request.request_id", reqId))
+
+	if strings.ToLower(message.OutputFormat) == "pdf" {
+		signed, err := pdf.Sign([]byte(out), pdfPassword, uc.Signer)
+		if err != nil {
+			libOtel.HandleSpanError(&spanSign, "Error signing PDF report.", err)
+
+			return "", nil, err
+		}
+
+		logger.Infof("PDF report signed with certificate %s", uc.Signer.Certificate().Subject)
+
+		return string(signed), nil, nil
+	}
+
+	detached, err := uc.Signer.SignDetached([]byte(out))
+	if err != nil {
+		libOtel.HandleSpanError(&spanSign, "Error signing report.", err)
+
+		return "", nil, fmt.Errorf("failed to sign report: %w", err)
+	}
+
+	logger.Infof("Report signed with certificate %s", uc.Signer.Certificate().Subject)
+
+	return out, detached, nil
+}
+
+// saveReportSignature stores the detached signature of a report next to it, as <report file>.p7s,
+// with the same TTL as the report.
+func (uc *UseCase) saveReportSignature(ctx context.Context, message GenerateReportMessage, detached []byte) error {
+	logger, tracer, reqId, _ := libCommons.NewTrackingFromContext(ctx)
+
+	ctx, spanSave := tracer.Start(ctx, "service.report.save_report_signature")
+	defer spanSave.End()
+
+	spanSave.SetAttributes(attribute.String("app.request.request_id", reqId))
+
+	outputFormat := strings.ToLower(message.OutputFormat)
+	objectName := pkg.TenantObjectName(message.OrganizationID, message.TemplateID.String()+"/"+message.ReportID.String()+"."+templateutils.GetFileExtension(outputFormat)+"."+constant.SignatureFileExtension)
+
+	if err := uc.ReportSeaweedFS.Put(ctx, objectName, constant.SignatureContentType, detached, uc.ReportTTL); err != nil {
+		libOtel.HandleSpanError(&spanSave, "Error putting report signature file.", err)
+
+		logger.Errorf("Error putting report signature file: %s", err.Error())
+
+		return err
+	}
+
+	return nil
+}
+
+// signatureMetadata returns the report metadata recording how the report was signed, nil when signing is disabled.
+func (uc *UseCase) signatureMetadata(message GenerateReportMessage) map[string]any {
+	if uc.Signer == nil {
+		return nil
+	}
+
+	if strings.ToLower(message.OutputFormat) == "pdf" {
+		return map[string]any{constant.ReportSignatureMetadataKey: constant.SignaturePAdES}
+	}
+
+	return map[string]any{constant.ReportSignatureMetadataKey: constant.SignatureCMS}
+}
@@ -0,0 +1,186 @@
+// Copyright (c) 2026 Lerian Studio. All rights reserved.
+// Use of this source code is governed by the Elastic License 2.0
+// that can be found in the LICENSE file.
+
+package services
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"errors"
+	"math/big"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/LerianStudio/reporter/pkg/constant"
+	"github.com/LerianStudio/reporter/pkg/seaweedfs/report"
+	"github.com/LerianStudio/reporter/pkg/signature"
+
+	"github.com/google/uuid"
+	"github.com/hhrutter/pkcs7"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+// testSigner returns a signer with a self-signed ECDSA certificate.
+func testSigner(t *testing.T) *signature.Signer {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "Reporter"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(24 * time.Hour),
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, template, key.Public(), key)
+	require.NoError(t, err)
+
+	certificate, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+
+	signer, err := signature.NewSigner(certificate, key, nil)
+	require.NoError(t, err)
+
+	return signer
+}
+
+// blankPDF is a single blank page PDF with a valid cross-reference table.
+const blankPDF = "%PDF-1.7\n" +
+	"1 0 obj\n<< /Type /Catalog /Pages 2 0 R >>\nendobj\n" +
+	"2 0 obj\n<< /Type /Pages /Kids [3 0 R] /Count 1 >>\nendobj\n" +
+	"3 0 obj\n<< /Type /Page /Parent 2 0 R /MediaBox [0 0 612 792] >>\nendobj\n" +
+	"xref\n0 4\n0000000000 65535 f \n0000000009 00000 n \n0000000058 00000 n \n0000000115 00000 n \n" +
+	"trailer\n<< /Size 4 /Root 1 0 R >>\nstartxref\n186\n%%EOF\n"
+
+func TestUseCase_SignReportIfNeeded(t *testing.T) {
+	t.Parallel()
+
+	signer := testSigner(t)
+
+	tests := []struct {
+		name         string
+		signer       *signature.Signer
+		outputFormat string
+		output       string
+		wantSigned   bool
+		wantDetached bool
+		wantErr      bool
+	}{
+		{name: "Signing disabled", outputFormat: "csv", output: "id;amount\n1;10.00\n"},
+		{name: "CSV gets a detached signature", signer: signer, outputFormat: "csv", output: "id;amount\n1;10.00\n", wantDetached: true},
+		{name: "XML gets a detached signature", signer: signer, outputFormat: "xml", output: "<report/>", wantDetached: true},
+		{name: "PDF gets an embedded signature", signer: signer, outputFormat: "pdf", output: blankPDF, wantSigned: true},
+		{name: "Invalid PDF", signer: signer, outputFormat: "pdf", output: "<html></html>", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			useCase := &UseCase{Signer: tt.signer}
+			message := GenerateReportMessage{ReportID: uuid.New(), OutputFormat: tt.outputFormat}
+
+			out, detached, err := useCase.signReportIfNeeded(context.Background(), message, tt.output, "")
+			if tt.wantErr {
+				require.Error(t, err)
+
+				return
+			}
+
+			require.NoError(t, err)
+
+			if tt.wantSigned {
+				assert.True(t, strings.HasPrefix(out, tt.output))
+				assert.Contains(t, out, "/SubFilter /ETSI.CAdES.detached")
+			} else {
+				assert.Equal(t, tt.output, out)
+			}
+
+			if !tt.wantDetached {
+				assert.Nil(t, detached)
+
+				return
+			}
+
+			p7, err := pkcs7.Parse(detached)
+			require.NoError(t, err)
+
+			p7.Content = []byte(tt.output)
+			require.NoError(t, p7.Verify())
+		})
+	}
+}
+
+func TestUseCase_SaveReportSignature(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		putErr  error
+		wantErr bool
+	}{
+		{name: "Success - saves the signature next to the report"},
+		{name: "Error - Put fails", putErr: errors.New("failed to put file"), wantErr: true},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockReportRepo := report.NewMockRepository(ctrl)
+
+			useCase := &UseCase{
+				ReportSeaweedFS: mockReportRepo,
+				ReportTTL:       "7d",
+			}
+
+			message := GenerateReportMessage{
+				ReportID:       uuid.New(),
+				TemplateID:     uuid.New(),
+				OrganizationID: uuid.New(),
+				OutputFormat:   "CSV",
+			}
+
+			expectedObjectName := message.OrganizationID.String() + "/" + message.TemplateID.String() + "/" + message.ReportID.String() + ".csv.p7s"
+
+			mockReportRepo.
+				EXPECT().
+				Put(gomock.Any(), expectedObjectName, constant.SignatureContentType, []byte("cms"), "7d").
+				Return(tt.putErr)
+
+			err := useCase.saveReportSignature(context.Background(), message, []byte("cms"))
+			if tt.wantErr {
+				require.Error(t, err)
+
+				return
+			}
+
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestUseCase_SignatureMetadata(t *testing.T) {
+	t.Parallel()
+
+	signer := testSigner(t)
+
+	assert.Nil(t, (&UseCase{}).signatureMetadata(GenerateReportMessage{OutputFormat: "pdf"}))
+	assert.Equal(t, map[string]any{"signature": "pades"}, (&UseCase{Signer: signer}).signatureMetadata(GenerateReportMessage{OutputFormat: "PDF"}))
+	assert.Equal(t, map[string]any{"signature": "cms"}, (&UseCase{Signer: signer}).signatureMetadata(GenerateReportMessage{OutputFormat: "txt"}))
+}
@@ -6,7 +6,13 @@ package services
 
 import (
 	"context"
+	"fmt"
 	"strings"
+	"unicode/utf8"
+
+	"github.com/LerianStudio/reporter/pkg"
+	"github.com/LerianStudio/reporter/pkg/constant"
+	"github.com/LerianStudio/reporter/pkg/templateutils"
 
 	libCommons "github.com/LerianStudio/lib-commons/v2/commons"
 	libOtel "github.com/LerianStudio/lib-commons/v2/commons/opentelemetry"
@@ -36,8 +42,8 @@ func (uc *UseCase) saveReport(ctx context.Context, message GenerateReportMessage
 	spanSaveReport.SetAttributes(attribute.String("app.request.request_id", reqId))
 
 	outputFormat := strings.ToLower(message.OutputFormat)
-	contentType := getContentType(outputFormat)
-	objectName := message.TemplateID.String() + "/" + message.ReportID.String() + "." + outputFormat
+	contentType := templateutils.WithCharset(getContentType(outputFormat), message.OutputOptions)
+	objectName := pkg.TenantObjectName(message.OrganizationID, message.TemplateID.String()+"/"+message.ReportID.String()+"."+templateutils.GetFileExtension(outputFormat))
 
 	err := uc.ReportSeaweedFS.Put(ctx, objectName, contentType, []byte(out), uc.ReportTTL)
 	if err != nil {
@@ -55,6 +61,26 @@ func (uc *UseCase) saveReport(ctx context.Context, message GenerateReportMessage
 	return nil
 }
 
+// encodeOutput applies the output options of the template to a text report: line endings, trailing
+// newline, character encoding and byte order mark. PDF reports are returned unchanged. Fixed-width
+// positions count characters, so every character of a fixed-width report must be written in one byte.
+func encodeOutput(message GenerateReportMessage, out string) (string, error) {
+	if !templateutils.IsTextOutput(message.OutputFormat) {
+		return out, nil
+	}
+
+	encoded, err := templateutils.EncodeOutput(out, message.OutputOptions)
+	if err != nil {
+		return "", err
+	}
+
+	if strings.EqualFold(message.OutputFormat, constant.OutputFormatFixedWidth) && len(encoded) != utf8.RuneCountInString(out) {
+		return "", fmt.Errorf("fixed-width records no longer match the layout once encoded in %s: use a single-byte encoding or characters written in one byte", message.OutputOptions.Charset())
+	}
+
+	return string(encoded), nil
+}
+
 // getContentType returns the MIME type for a given file extension.
 // If the extension is not recognized, it returns "text/plain".
 func getContentType(ext string) string {
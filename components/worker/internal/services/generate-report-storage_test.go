@@ -9,6 +9,7 @@ import (
 	"errors"
 	"testing"
 
+	"github.com/LerianStudio/reporter/pkg/model"
 	"github.com/LerianStudio/reporter/pkg/seaweedfs/report"
 
 	"github.com/google/uuid"
@@ -53,12 +54,97 @@ func TestUseCase_GetContentType(t *testing.T) {
 	}
 }
 
+func TestEncodeOutput(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		message  GenerateReportMessage
+		output   string
+		expected string
+		wantErr  bool
+	}{
+		{
+			name:     "no output options",
+			message:  GenerateReportMessage{OutputFormat: "csv"},
+			output:   "nome\nJoão\n",
+			expected: "nome\nJoão\n",
+		},
+		{
+			name: "csv encoded in windows-1252 with crlf",
+			message: GenerateReportMessage{
+				OutputFormat:  "csv",
+				OutputOptions: &model.OutputOptions{Encoding: "windows-1252", LineEnding: "crlf"},
+			},
+			output:   "nome\nJoão\n",
+			expected: "nome\r\nJo\xE3o\r\n",
+		},
+		{
+			name: "pdf is not encoded",
+			message: GenerateReportMessage{
+				OutputFormat:  "pdf",
+				OutputOptions: &model.OutputOptions{Encoding: "iso-8859-1"},
+			},
+			output:   "%PDF-1.7 ő",
+			expected: "%PDF-1.7 ő",
+		},
+		{
+			name: "unmappable character fails",
+			message: GenerateReportMessage{
+				OutputFormat:  "txt",
+				OutputOptions: &model.OutputOptions{Encoding: "iso-8859-1", Unmappable: "fail"},
+			},
+			output:  "ő",
+			wantErr: true,
+		},
+		{
+			name: "fixed-width in a single-byte encoding",
+			message: GenerateReportMessage{
+				OutputFormat:  "fixed-width",
+				OutputOptions: &model.OutputOptions{Encoding: "iso-8859-1"},
+			},
+			output:   "João \r\n",
+			expected: "Jo\xE3o \r\n",
+		},
+		{
+			name:     "fixed-width in utf-8 with ascii characters",
+			message:  GenerateReportMessage{OutputFormat: "fixed-width"},
+			output:   "Joao \r\n",
+			expected: "Joao \r\n",
+		},
+		{
+			name:    "fixed-width in utf-8 with multibyte characters fails",
+			message: GenerateReportMessage{OutputFormat: "fixed-width"},
+			output:  "João \r\n",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			out, err := encodeOutput(tt.message, tt.output)
+			if tt.wantErr {
+				require.Error(t, err)
+
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, out)
+		})
+	}
+}
+
 func TestUseCase_SaveReport(t *testing.T) {
 	t.Parallel()
 
 	tests := []struct {
 		name           string
 		outputFormat   string
+		outputOptions  *model.OutputOptions
 		renderedOutput string
 		reportTTL      string
 		expectedType   string
@@ -74,6 +160,15 @@ func TestUseCase_SaveReport(t *testing.T) {
 			putErr:         nil,
 			expectError:    false,
 		},
+		{
+			name:           "Success - saves CSV report with the charset of the output options",
+			outputFormat:   "csv",
+			outputOptions:  &model.OutputOptions{Encoding: "iso-8859-1"},
+			renderedOutput: "id,name\n1,Jane",
+			expectedType:   "text/csv; charset=iso-8859-1",
+			putErr:         nil,
+			expectError:    false,
+		},
 		{
 			name:           "Error - Put fails",
 			outputFormat:   "html",
@@ -111,9 +206,10 @@ func TestUseCase_SaveReport(t *testing.T) {
 
 			reportID := uuid.New()
 			message := GenerateReportMessage{
-				ReportID:     reportID,
-				TemplateID:   uuid.New(),
-				OutputFormat: tt.outputFormat,
+				ReportID:      reportID,
+				TemplateID:    uuid.New(),
+				OutputFormat:  tt.outputFormat,
+				OutputOptions: tt.outputOptions,
 			}
 
 			mockReportRepo.
@@ -132,3 +228,33 @@ func TestUseCase_SaveReport(t *testing.T) {
 		})
 	}
 }
+
+func TestUseCase_SaveReport_OrganizationPrefix(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockReportRepo := report.NewMockRepository(ctrl)
+
+	useCase := &UseCase{
+		ReportSeaweedFS: mockReportRepo,
+	}
+
+	message := GenerateReportMessage{
+		ReportID:       uuid.New(),
+		TemplateID:     uuid.New(),
+		OrganizationID: uuid.New(),
+		OutputFormat:   "csv",
+	}
+
+	expectedObjectName := message.OrganizationID.String() + "/" + message.TemplateID.String() + "/" + message.ReportID.String() + ".csv"
+
+	mockReportRepo.
+		EXPECT().
+		Put(gomock.Any(), expectedObjectName, "text/csv", gomock.Any(), "").
+		Return(nil)
+
+	err := useCase.saveReport(context.Background(), message, "id,name")
+	require.NoError(t, err)
+}
@@ -44,11 +44,35 @@ type GenerateReportMessage struct {
 	// Format: map[databaseName]map[tableName]map[fieldName]model.FilterCondition
 	// Example: {"db": {"table": {"created_at": {"gte": ["2025-06-01"], "lte": ["2025-06-30"]}}}}
 	Filters map[string]map[string]map[string]model.FilterCondition `json:"filters"`
+
+	// OrganizationID is the tenant owning the report and its template. uuid.Nil is the default tenant.
+	OrganizationID uuid.UUID `json:"organizationId,omitempty"`
+
+	// RowLevelScope holds the auth claim values resolved by the manager when the report was requested.
+	// Format: map[claimName]value. Example: {"owner": "org-1"}
+	RowLevelScope map[string]string `json:"rowLevelScope,omitempty"`
+
+	// RowLevelSignature is the signature of the row-level scope, data queries and filters computed by the manager.
+	RowLevelSignature string `json:"rowLevelSignature,omitempty"`
+
+	// Locale is the locale of the format_* filters (e.g. "pt-BR"), overriding the template's {% locale %}.
+	Locale string `json:"locale,omitempty"`
+
+	// Timezone is the IANA timezone of the date_time tag and to_tz filter, overriding the template's {% timezone %}.
+	Timezone string `json:"timezone,omitempty"`
+
+	// OutputOptions are the character encoding and line ending options of the template, applied to text reports,
+	// and the page setup and protection of PDF reports.
+	OutputOptions *model.OutputOptions `json:"outputOptions,omitempty"`
+
+	// ReuseOutput is set when the request declared a closed period, allowing the output cache for the report.
+	ReuseOutput bool `json:"reuseOutput,omitempty"`
 }
 
 // GenerateReport handles a report generation request by loading a template file,
 // processing it, and storing the final report in the report repository.
-func (uc *UseCase) GenerateReport(ctx context.Context, body []byte) error {
+// A report cancelled while being generated is abandoned at the next checkpoint and its message acknowledged.
+func (uc *UseCase) GenerateReport(ctx context.Context, body []byte) (err error) {
 	logger, tracer, reqId, _ := libCommons.NewTrackingFromContext(ctx)
 
 	ctx, span := tracer.Start(ctx, "service.report.generate")
@@ -64,38 +88,103 @@ func (uc *UseCase) GenerateReport(ctx context.Context, body []byte) error {
 	span.SetAttributes(
 		attribute.String("app.request.report_id", message.ReportID.String()),
 		attribute.String("app.request.template_id", message.TemplateID.String()),
+		attribute.String("app.request.organization_id", message.OrganizationID.String()),
 	)
 
-	if skip := uc.shouldSkipProcessing(ctx, message.ReportID, logger); skip {
+	if skip := uc.shouldSkipProcessing(ctx, message.ReportID, message.OrganizationID, logger); skip {
 		return nil
 	}
 
+	stopHeartbeat := uc.startHeartbeat(ctx, message, logger)
+	defer stopHeartbeat()
+
+	ctx, stopWatching := uc.watchCancellation(ctx, message, logger)
+	defer func() {
+		stopWatching()
+
+		if err != nil && reportCancelled(ctx) {
+			logger.Infof("Generation of report %s stopped: the report was cancelled", message.ReportID)
+
+			err = nil
+		}
+	}()
+
+	// Re-verify the mandatory row-level filters so a tampered message can never read another tenant's rows
+	if err := uc.RowLevelPolicy.Verify(message.RowLevelScope, message.RowLevelSignature, message.DataQueries, message.Filters); err != nil {
+		return uc.handleErrorWithUpdate(ctx, message.ReportID, &span, "Row-level filter verification failed", err, logger)
+	}
+
 	templateBytes, err := uc.loadTemplate(ctx, message, &span)
 	if err != nil {
 		return err
 	}
 
+	if err := checkCancellation(ctx); err != nil {
+		return err
+	}
+
+	// A report with the same fingerprint as a finished one is finished with a copy of its output
+	fingerprint := uc.outputFingerprint(ctx, message, templateBytes)
+	if fingerprint != "" {
+		if reused, err := uc.reuseReportOutput(ctx, message, fingerprint, &span, logger); reused {
+			return err
+		}
+	}
+
 	result := make(map[string]map[string][]map[string]any)
 
 	if err := uc.queryExternalData(ctx, message, result); err != nil {
 		return uc.handleErrorWithUpdate(ctx, message.ReportID, &span, "Error querying external data", err, logger)
 	}
 
+	if err := checkCancellation(ctx); err != nil {
+		return err
+	}
+
 	renderedOutput, err := uc.renderTemplate(ctx, templateBytes, result, message, &span)
 	if err != nil {
 		return err
 	}
 
+	if err := checkCancellation(ctx); err != nil {
+		return err
+	}
+
 	finalOutput, err := uc.convertToPDFIfNeeded(ctx, message, renderedOutput, &span)
 	if err != nil {
 		return err
 	}
 
-	if err := uc.saveReport(ctx, message, finalOutput); err != nil {
+	finalOutput, pdfPassword, err := uc.protectPDFIfNeeded(ctx, message, finalOutput, result)
+	if err != nil {
+		return uc.handleErrorWithUpdate(ctx, message.ReportID, &span, "Error protecting PDF report", err, logger)
+	}
+
+	encodedOutput, err := encodeOutput(message, finalOutput)
+	if err != nil {
+		return uc.handleErrorWithUpdate(ctx, message.ReportID, &span, "Error encoding report output", err, logger)
+	}
+
+	signedOutput, detachedSignature, err := uc.signReportIfNeeded(ctx, message, encodedOutput, pdfPassword)
+	if err != nil {
+		return uc.handleErrorWithUpdate(ctx, message.ReportID, &span, "Error signing report", err, logger)
+	}
+
+	if err := checkCancellation(ctx); err != nil {
+		return err
+	}
+
+	if err := uc.saveReport(ctx, message, signedOutput); err != nil {
 		return uc.handleErrorWithUpdate(ctx, message.ReportID, &span, "Error saving report", err, logger)
 	}
 
-	if err := uc.markReportAsFinished(ctx, message.ReportID, &span, logger); err != nil {
+	if detachedSignature != nil {
+		if err := uc.saveReportSignature(ctx, message, detachedSignature); err != nil {
+			return uc.handleErrorWithUpdate(ctx, message.ReportID, &span, "Error saving report signature", err, logger)
+		}
+	}
+
+	if err := uc.markReportAsFinished(ctx, message.ReportID, uc.finishedMetadata(message, fingerprint), &span, logger); err != nil {
 		return err
 	}
 
@@ -124,9 +213,9 @@ func (uc *UseCase) parseMessage(ctx context.Context, body []byte, span *trace.Sp
 	return message, nil
 }
 
-// markReportAsFinished updates report status to finished.
-func (uc *UseCase) markReportAsFinished(ctx context.Context, reportID uuid.UUID, span *trace.Span, logger log.Logger) error {
-	err := uc.ReportDataRepo.UpdateReportStatusById(ctx, constant.FinishedStatus, reportID, time.Now(), nil)
+// markReportAsFinished updates report status to finished, recording the metadata of the report when not nil.
+func (uc *UseCase) markReportAsFinished(ctx context.Context, reportID uuid.UUID, metadata map[string]any, span *trace.Span, logger log.Logger) error {
+	err := uc.ReportDataRepo.UpdateReportStatusById(ctx, constant.FinishedStatus, reportID, time.Now(), metadata)
 	if err != nil {
 		if errUpdate := uc.updateReportWithErrors(ctx, reportID, err.Error()); errUpdate != nil {
 			libOtel.HandleSpanError(span, "Error to update report status with error.", errUpdate)
@@ -165,7 +254,12 @@ func (uc *UseCase) handleErrorWithUpdate(ctx context.Context, reportID uuid.UUID
 }
 
 // updateReportWithErrors updates the status of a report to "Error" with metadata containing the provided error message.
+// Cancelled reports keep their status.
 func (uc *UseCase) updateReportWithErrors(ctx context.Context, reportId uuid.UUID, errorMessage string) error {
+	if reportCancelled(ctx) {
+		return nil
+	}
+
 	_, tracer, reqId, _ := libCommons.NewTrackingFromContext(ctx)
 
 	ctx, span := tracer.Start(ctx, "service.report.update_report_with_errors")
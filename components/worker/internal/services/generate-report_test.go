@@ -11,6 +11,7 @@ import (
 	"testing"
 
 	"github.com/LerianStudio/reporter/pkg"
+	"github.com/LerianStudio/reporter/pkg/constant"
 	"github.com/LerianStudio/reporter/pkg/model"
 	mongodb2 "github.com/LerianStudio/reporter/pkg/mongodb"
 	reportData "github.com/LerianStudio/reporter/pkg/mongodb/report"
@@ -64,7 +65,7 @@ func TestUseCase_GenerateReport_Success(t *testing.T) {
 
 	mockReportDataRepo.
 		EXPECT().
-		FindByID(gomock.Any(), reportID).
+		FindByID(gomock.Any(), reportID, gomock.Any()).
 		Return(&reportData.Report{
 			ID:     reportID,
 			Status: "processing",
@@ -148,7 +149,7 @@ func TestUseCase_GenerateReport_ErrorAndSkipPaths(t *testing.T) {
 
 				mockReportDataRepo.
 					EXPECT().
-					FindByID(gomock.Any(), reportID).
+					FindByID(gomock.Any(), reportID, gomock.Any()).
 					Return(&reportData.Report{ID: reportID, Status: "processing"}, nil)
 
 				mockTemplateRepo.
@@ -176,7 +177,7 @@ func TestUseCase_GenerateReport_ErrorAndSkipPaths(t *testing.T) {
 
 				mockReportDataRepo.
 					EXPECT().
-					FindByID(gomock.Any(), reportID).
+					FindByID(gomock.Any(), reportID, gomock.Any()).
 					Return(&reportData.Report{ID: reportID, Status: "Finished"}, nil)
 
 				return &UseCase{
@@ -193,7 +194,7 @@ func TestUseCase_GenerateReport_ErrorAndSkipPaths(t *testing.T) {
 
 				mockReportDataRepo.
 					EXPECT().
-					FindByID(gomock.Any(), reportID).
+					FindByID(gomock.Any(), reportID, gomock.Any()).
 					Return(&reportData.Report{ID: reportID, Status: "Error"}, nil)
 
 				return &UseCase{
@@ -237,6 +238,68 @@ func TestUseCase_GenerateReport_ErrorAndSkipPaths(t *testing.T) {
 	}
 }
 
+func TestUseCase_GenerateReport_RowLevelFilterViolation(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	templateID := uuid.New()
+	reportID := uuid.New()
+
+	mockReportDataRepo := reportData.NewMockRepository(ctrl)
+
+	mockReportDataRepo.
+		EXPECT().
+		FindByID(gomock.Any(), reportID, gomock.Any()).
+		Return(&reportData.Report{ID: reportID, Status: "processing"}, nil)
+
+	mockReportDataRepo.EXPECT().
+		UpdateReportStatusById(gomock.Any(), constant.ErrorStatus, reportID, gomock.Any(), gomock.Any()).
+		Return(nil)
+
+	policy := pkg.NewRowLevelPolicy([]pkg.RowLevelRule{
+		{DataSource: "midaz_onboarding", Table: "account", Field: "organization_id", Claim: "owner"},
+	}).WithSigningKey("test-signing-key")
+
+	useCase := &UseCase{
+		ReportDataRepo:      mockReportDataRepo,
+		ExternalDataSources: pkg.NewSafeDataSources(map[string]pkg.DataSource{}),
+		RowLevelPolicy:      policy,
+	}
+
+	signedFilters := map[string]map[string]map[string]model.FilterCondition{
+		"midaz_onboarding": {"account": {"organization_id": {Equals: []any{"org-1"}}}},
+	}
+	signature, err := policy.Sign(map[string]string{"owner": "org-1"}, map[string]map[string][]string{
+		"midaz_onboarding": {"account": {"id"}},
+	}, signedFilters)
+	require.NoError(t, err)
+
+	// The filter was changed to another organization after the manager signed the message
+	body := GenerateReportMessage{
+		TemplateID:   templateID,
+		ReportID:     reportID,
+		OutputFormat: "txt",
+		DataQueries: map[string]map[string][]string{
+			"midaz_onboarding": {"account": {"id"}},
+		},
+		Filters: map[string]map[string]map[string]model.FilterCondition{
+			"midaz_onboarding": {"account": {"organization_id": {Equals: []any{"org-2"}}}},
+		},
+		RowLevelScope:     map[string]string{"owner": "org-1"},
+		RowLevelSignature: signature,
+	}
+	bodyBytes, _ := json.Marshal(body)
+
+	err = useCase.GenerateReport(context.Background(), bodyBytes)
+	require.Error(t, err)
+
+	var forbidden pkg.ForbiddenError
+	require.True(t, errors.As(err, &forbidden))
+	assert.Equal(t, constant.ErrInvalidRowLevelSignature.Error(), forbidden.Code)
+}
+
 // NOTE: Kept separate from table-driven TestUseCase_GenerateReport due to complex crypto setup requirements.
 // This test exercises the CRM plugin path with cipher initialization, hash generation, and encrypted
 // field decryption, which demands significantly different UseCase wiring (crypto keys, MongoDB mocks,
@@ -331,7 +394,7 @@ Conta Bancaria: {{ plugin_crm.holders.0.banking_details.account }}`
 
 	mockReportDataRepo.
 		EXPECT().
-		FindByID(gomock.Any(), reportID).
+		FindByID(gomock.Any(), reportID, gomock.Any()).
 		Return(&reportData.Report{
 			ID:     reportID,
 			Status: "processing",
@@ -379,12 +442,11 @@ Conta Bancaria: {{ plugin_crm.holders.0.banking_details.account }}`
 	circuitBreakerManager := pkg.NewCircuitBreakerManager(logger)
 
 	useCase := &UseCase{
-		TemplateSeaweedFS:               mockTemplateRepo,
-		ReportSeaweedFS:                 mockReportRepo,
-		ReportDataRepo:                  mockReportDataRepo,
-		CircuitBreakerManager:           circuitBreakerManager,
-		CryptoHashSecretKeyPluginCRM:    hashKey,
-		CryptoEncryptSecretKeyPluginCRM: encryptKey,
+		TemplateSeaweedFS:     mockTemplateRepo,
+		ReportSeaweedFS:       mockReportRepo,
+		ReportDataRepo:        mockReportDataRepo,
+		CircuitBreakerManager: circuitBreakerManager,
+		FieldTransformers:     pkg.NewFieldTransformerRegistry(pkg.PluginCRMFieldTransformer(hashKey, encryptKey)),
 		ExternalDataSources: pkg.NewSafeDataSources(map[string]pkg.DataSource{
 			"plugin_crm": {
 				Initialized:         true,
@@ -538,6 +600,7 @@ func TestUseCase_MarkReportAsFinished(t *testing.T) {
 	tests := []struct {
 		name        string
 		reportID    uuid.UUID
+		metadata    map[string]any
 		mockSetup   func(mockReportDataRepo *reportData.MockRepository, reportID uuid.UUID)
 		expectError bool
 		errContains string
@@ -552,6 +615,17 @@ func TestUseCase_MarkReportAsFinished(t *testing.T) {
 			},
 			expectError: false,
 		},
+		{
+			name:     "Success - Mark report as finished with its signature metadata",
+			reportID: uuid.New(),
+			metadata: map[string]any{"signature": "cms"},
+			mockSetup: func(mockReportDataRepo *reportData.MockRepository, reportID uuid.UUID) {
+				mockReportDataRepo.EXPECT().
+					UpdateReportStatusById(gomock.Any(), "Finished", reportID, gomock.Any(), map[string]any{"signature": "cms"}).
+					Return(nil)
+			},
+			expectError: false,
+		},
 		{
 			name:     "Error - Failed to mark as finished",
 			reportID: uuid.New(),
@@ -586,7 +660,7 @@ func TestUseCase_MarkReportAsFinished(t *testing.T) {
 				ReportDataRepo: mockReportDataRepo,
 			}
 
-			err := useCase.markReportAsFinished(context.Background(), tt.reportID, &span, logger)
+			err := useCase.markReportAsFinished(context.Background(), tt.reportID, tt.metadata, &span, logger)
 			if tt.expectError {
 				require.Error(t, err)
 				assert.Contains(t, err.Error(), tt.errContains)
@@ -729,7 +803,7 @@ func TestUseCase_MarkReportAsFinished_NestedErrorWhenUpdateAlsoFails(t *testing.
 		ReportDataRepo: mockReportDataRepo,
 	}
 
-	err := useCase.markReportAsFinished(context.Background(), reportID, &span, logger)
+	err := useCase.markReportAsFinished(context.Background(), reportID, nil, &span, logger)
 	require.Error(t, err)
 	assert.Contains(t, err.Error(), "second update also failed")
 }
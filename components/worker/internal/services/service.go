@@ -5,11 +5,14 @@
 package services
 
 import (
+	"time"
+
 	"github.com/LerianStudio/reporter/pkg"
 	reportData "github.com/LerianStudio/reporter/pkg/mongodb/report"
 	"github.com/LerianStudio/reporter/pkg/pdf"
 	reportSeaweedFS "github.com/LerianStudio/reporter/pkg/seaweedfs/report"
 	templateSeaweedFS "github.com/LerianStudio/reporter/pkg/seaweedfs/template"
+	"github.com/LerianStudio/reporter/pkg/signature"
 )
 
 // UseCase is a struct that coordinates the handling of template files, report storage, external data sources, and report data.
@@ -38,9 +41,24 @@ type UseCase struct {
 	// PdfPool provides PDF generation capabilities using Chrome headless
 	PdfPool pdf.PDFGenerator
 
-	// CryptoHashSecretKeyPluginCRM is the hash secret key for plugin_crm data operations.
-	CryptoHashSecretKeyPluginCRM string
+	// FieldTransformers holds the per-datasource field transformers used to read encrypted MongoDB datasources.
+	FieldTransformers *pkg.FieldTransformerRegistry
+
+	// RowLevelPolicy verifies that report filters carry the mandatory row-level predicates of the requester.
+	RowLevelPolicy *pkg.RowLevelPolicy
+
+	// Signer signs the reports with the configured certificate. Nil disables signing.
+	Signer *signature.Signer
+
+	// CancellationPollInterval is how often the status of a report being generated is checked for a cancellation.
+	// Zero disables the check.
+	CancellationPollInterval time.Duration
+
+	// HeartbeatInterval is how often a report being generated is recorded as alive for the stuck-report reaper.
+	// Zero disables the heartbeat.
+	HeartbeatInterval time.Duration
 
-	// CryptoEncryptSecretKeyPluginCRM is the encryption secret key for plugin_crm data operations.
-	CryptoEncryptSecretKeyPluginCRM string
+	// OutputCacheEnabled reuses the output of a finished report with the same fingerprint instead of generating
+	// a report again. Only reports requested with reuseOutput and querying datasources with a data version are reused.
+	OutputCacheEnabled bool
 }
@@ -56,4 +56,33 @@ var (
 	ErrObjectKeyRequired               = errors.New("TPL-0042")
 	ErrObjectNotFound                  = errors.New("TPL-0043")
 	ErrTTLNotSupported                 = errors.New("TPL-0044")
+	ErrMissingAuthClaim                = errors.New("TPL-0045")
+	ErrRowLevelFilterViolation         = errors.New("TPL-0046")
+	ErrMissingOrganizationID           = errors.New("TPL-0047")
+	ErrInvalidOrganizationID           = errors.New("TPL-0048")
+	ErrOrganizationMismatch            = errors.New("TPL-0049")
+	ErrInvalidPartialName              = errors.New("TPL-0050")
+	ErrPartialNotFound                 = errors.New("TPL-0051")
+	ErrPartialNameConflict             = errors.New("TPL-0052")
+	ErrPartialIncludeCycle             = errors.New("TPL-0053")
+	ErrPartialTemplateReport           = errors.New("TPL-0054")
+	ErrInvalidOutputOptions            = errors.New("TPL-0055")
+	ErrSignatureNotFound               = errors.New("TPL-0056")
+	ErrSignatureEmbedded               = errors.New("TPL-0057")
+	ErrReportNotCancellable            = errors.New("TPL-0058")
+	ErrReportNotRetryable              = errors.New("TPL-0059")
+	ErrInvalidRetrySelection           = errors.New("TPL-0060")
+	ErrInvalidDeadLetterSelection      = errors.New("TPL-0061")
+	ErrDeadLetterQueueNotConfigured    = errors.New("TPL-0062")
+	ErrInvalidReportBatchItems         = errors.New("TPL-0063")
+	ErrReportBatchNotFinished          = errors.New("TPL-0064")
+	ErrPresignNotSupported             = errors.New("TPL-0065")
+	ErrReportUnderLegalHold            = errors.New("TPL-0066")
+	ErrReportNotDeletable              = errors.New("TPL-0067")
+	ErrReportPurged                    = errors.New("TPL-0068")
+	ErrInvalidRowLevelSignature        = errors.New("TPL-0069")
+	ErrUntrustedOrganizationHeader     = errors.New("TPL-0070")
+	ErrPartialInUse                    = errors.New("TPL-0071")
+	ErrInvalidTemplatePriority         = errors.New("TPL-0072")
+	ErrInvalidTemplateRetention        = errors.New("TPL-0073")
 )
@@ -0,0 +1,39 @@
+// Copyright (c) 2026 Lerian Studio. All rights reserved.
+// Use of this source code is governed by the Elastic License 2.0
+// that can be found in the LICENSE file.
+
+package constant
+
+// Field transformer configuration
+const (
+	// CollectionTemplateEnvField is the DATASOURCE_{NAME}_* field holding the physical collection name template,
+	// e.g. "{collection}_{midaz_organization_id}".
+	CollectionTemplateEnvField = "COLLECTION_TEMPLATE"
+
+	// SkipCollectionsEnvField is the DATASOURCE_{NAME}_* field listing template collections that are never queried,
+	// separated by commas.
+	SkipCollectionsEnvField = "SKIP_COLLECTIONS"
+
+	// EncryptedFieldsEnvField is the DATASOURCE_{NAME}_* field listing the encrypted field paths, separated by commas.
+	// Nested fields use dot notation; arrays along the path are traversed, e.g. "contact.primary_email,related_parties.document".
+	EncryptedFieldsEnvField = "ENCRYPTED_FIELDS"
+
+	// SearchFieldsEnvField is the DATASOURCE_{NAME}_* field mapping filterable fields to their hashed search fields.
+	// Format: "field=search_field" entries separated by commas, e.g. "document=search.document".
+	SearchFieldsEnvField = "SEARCH_FIELDS"
+
+	// EncryptSecretKeyEnvField is the DATASOURCE_{NAME}_* field holding the key used to decrypt encrypted fields.
+	EncryptSecretKeyEnvField = "ENCRYPT_SECRET_KEY"
+
+	// HashSecretKeyEnvField is the DATASOURCE_{NAME}_* field holding the key used to hash search field values.
+	HashSecretKeyEnvField = "HASH_SECRET_KEY"
+
+	// CollectionPlaceholder is replaced by the collection name referenced in the template.
+	CollectionPlaceholder = "{collection}"
+
+	// MidazOrganizationIDPlaceholder is replaced by the datasource's DATASOURCE_{NAME}_MIDAZ_ORGANIZATION_ID.
+	MidazOrganizationIDPlaceholder = "{midaz_organization_id}"
+
+	// PluginCRMDataSourceID is the datasource ID of the Midaz CRM plugin.
+	PluginCRMDataSourceID = "plugin_crm"
+)
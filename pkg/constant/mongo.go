@@ -10,6 +10,8 @@ import "time"
 const (
 	MongoCollectionReport   = "report"
 	MongoCollectionTemplate = "template"
+	MongoCollectionOutbox   = "report_outbox"
+	MongoCollectionBatch    = "report_batch"
 )
 
 // MongoDB sampling and collection size thresholds for schema discovery.
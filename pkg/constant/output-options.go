@@ -0,0 +1,53 @@
+// Copyright (c) 2026 Lerian Studio. All rights reserved.
+// Use of this source code is governed by the Elastic License 2.0
+// that can be found in the LICENSE file.
+
+package constant
+
+// Character encodings of the output options of a template.
+const (
+	// EncodingUTF8 is the default encoding of generated reports.
+	EncodingUTF8 = "utf-8"
+
+	// EncodingISO88591 is ISO-8859-1 (Latin-1).
+	EncodingISO88591 = "iso-8859-1"
+
+	// EncodingWindows1252 is Windows-1252, Latin-1 plus typographic quotes, dashes and the euro sign.
+	EncodingWindows1252 = "windows-1252"
+)
+
+// Strategies for characters the target encoding cannot represent.
+const (
+	// UnmappableFail fails the report, naming the first character that cannot be encoded.
+	UnmappableFail = "fail"
+
+	// UnmappableTransliterate replaces each character with a similar one, e.g. "ő" with "o", or "?" when there is none.
+	UnmappableTransliterate = "transliterate"
+
+	// UnmappableReplace replaces each character with "?".
+	UnmappableReplace = "replace"
+
+	// UnmappableReplacement is the character written in place of characters that cannot be encoded.
+	UnmappableReplacement = '?'
+)
+
+// Line ending and trailing newline policies of the output options of a template.
+const (
+	// LineEndingKeep leaves the line endings written by the template unchanged.
+	LineEndingKeep = "keep"
+
+	// LineEndingLF normalizes every line ending to "\n".
+	LineEndingLF = "lf"
+
+	// LineEndingCRLF normalizes every line ending to "\r\n".
+	LineEndingCRLF = "crlf"
+
+	// TrailingNewlineKeep leaves the end of the output unchanged.
+	TrailingNewlineKeep = "keep"
+
+	// TrailingNewlineEnsure ends a non-empty output with a line ending.
+	TrailingNewlineEnsure = "ensure"
+
+	// TrailingNewlineStrip removes the line endings at the end of the output.
+	TrailingNewlineStrip = "strip"
+)
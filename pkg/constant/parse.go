@@ -32,7 +32,4 @@ const (
 
 	// MinByClauseArgs is the minimum number of arguments for an aggregation expression with a "by" clause.
 	MinByClauseArgs = 4
-
-	// PowerOperatorTokenLength is the number of characters consumed by the ** (power) operator.
-	PowerOperatorTokenLength = 2
 )
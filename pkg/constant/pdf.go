@@ -17,4 +17,64 @@ const (
 	PDFMarginInches          = 0.5
 	PDFFilePermissions       = 0o600
 	PDFChromeMaxOldSpaceSize = "512"
+	PDFEmptyHeaderFooter     = "<span></span>"
+)
+
+// Paper sizes, orientations and limits of the PDF options of a template. Dimensions are in inches.
+const (
+	// PDFPaperLetter is US Letter, 8.5 x 11 in, the default paper size.
+	PDFPaperLetter = "letter"
+
+	// PDFPaperLegal is US Legal, 8.5 x 14 in.
+	PDFPaperLegal = "legal"
+
+	// PDFPaperA3 is ISO A3, 297 x 420 mm.
+	PDFPaperA3 = "a3"
+
+	// PDFPaperA4 is ISO A4, 210 x 297 mm.
+	PDFPaperA4 = "a4"
+
+	// PDFPaperA5 is ISO A5, 148 x 210 mm.
+	PDFPaperA5 = "a5"
+
+	// PDFPaperCustom uses the width and height of the PDF options.
+	PDFPaperCustom = "custom"
+
+	PDFPaperLegalHeightInches = 14.0
+	PDFPaperA3WidthInches     = 11.69
+	PDFPaperA3HeightInches    = 16.54
+	PDFPaperA4WidthInches     = 8.27
+	PDFPaperA4HeightInches    = 11.69
+	PDFPaperA5WidthInches     = 5.83
+	PDFPaperA5HeightInches    = 8.27
+
+	// PDFOrientationPortrait is the default orientation.
+	PDFOrientationPortrait = "portrait"
+
+	// PDFOrientationLandscape prints the pages with their width and height swapped.
+	PDFOrientationLandscape = "landscape"
+
+	PDFMinScale = 0.1
+	PDFMaxScale = 2.0
+)
+
+// Permissions readers of a password-protected PDF can be given. Everything not listed is restricted.
+const (
+	// PDFPermissionPrint allows printing the document.
+	PDFPermissionPrint = "print"
+
+	// PDFPermissionCopy allows copying and extracting text and images.
+	PDFPermissionCopy = "copy"
+
+	// PDFPermissionAnnotate allows adding annotations and filling form fields.
+	PDFPermissionAnnotate = "annotate"
+
+	// PDFPermissionModify allows modifying and assembling the document.
+	PDFPermissionModify = "modify"
+
+	// PDFOwnerPasswordBytes is the size of the random owner password of documents without one.
+	PDFOwnerPasswordBytes = 32
+
+	// PDFEncryptionKeyLength is the AES key length, in bits, of password-protected documents.
+	PDFEncryptionKeyLength = 256
 )
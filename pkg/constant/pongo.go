@@ -12,10 +12,112 @@ const (
 	// DecimalPrecisionPercent is the number of decimal places for formatted percentage strings.
 	DecimalPrecisionPercent = 2
 
-	// RoundingPrecision is the multiplier/divisor used to round floating-point results
-	// to 10 decimal places, avoiding floating-point artifacts (e.g., ...0000000001).
-	RoundingPrecision = 1e10
+	// CalcDefaultScale is the number of decimal places kept by the calc tag when no scale is given.
+	CalcDefaultScale = 10
+
+	// CalcMaxScale is the largest scale accepted by the calc tag.
+	CalcMaxScale = 34
+
+	// CalcDivisionPrecision is the number of decimal places computed for divisions and
+	// fractional powers in calc expressions, before the result is rounded to its scale.
+	CalcDivisionPrecision = 34
 
 	// SliceFormatParts is the expected number of parts when parsing a "start:end" slice format.
 	SliceFormatParts = 2
 )
+
+// Rounding modes accepted by the calc tag.
+const (
+	// RoundingHalfUp rounds halves away from zero (e.g., 2.5 -> 3, -2.5 -> -3).
+	RoundingHalfUp = "half_up"
+
+	// RoundingHalfEven rounds halves to the nearest even digit (e.g., 2.5 -> 2, 3.5 -> 4).
+	RoundingHalfEven = "half_even"
+
+	// RoundingTruncate discards the digits beyond the scale (e.g., 2.59 -> 2.5 at scale 1).
+	RoundingTruncate = "truncate"
+)
+
+// Locales supported by the formatting filters (format_number, format_currency, format_percent and format_date).
+const (
+	// LocalePtBR formats numbers as 1.234.567,89 and amounts as R$ 1.234.567,89.
+	LocalePtBR = "pt-BR"
+
+	// LocaleEnUS formats numbers as 1,234,567.89 and amounts as $1,234,567.89.
+	LocaleEnUS = "en-US"
+
+	// LocaleES formats numbers as 1.234.567,89 and amounts as 1.234.567,89 €.
+	LocaleES = "es"
+
+	// DefaultLocale is used when neither the template nor the report request sets a locale.
+	DefaultLocale = LocaleEnUS
+
+	// DecimalPrecisionCurrency is the number of decimal places of formatted currency amounts.
+	DecimalPrecisionCurrency = 2
+)
+
+// Holiday calendars built into the business day filters.
+const (
+	// HolidayCalendarBR holds the Brazilian national holidays plus the Carnival and Corpus Christi bank holidays.
+	HolidayCalendarBR = "BR"
+
+	// HolidayCalendarNone has no holidays, so only weekends are skipped.
+	HolidayCalendarNone = "none"
+
+	// HolidayCalendarCustom is the configured calendar plus the extra holidays of HOLIDAY_CALENDAR_DATES.
+	HolidayCalendarCustom = "custom"
+
+	// HolidayDateLayout is the layout of the extra holiday dates.
+	HolidayDateLayout = "2006-01-02"
+
+	// BlackConsciousnessDayFirstYear is the first year November 20 is a Brazilian national holiday.
+	BlackConsciousnessDayFirstYear = 2024
+)
+
+// Fixed-width (positional) output, e.g. CNAB 240/400, DIMP and SPED files.
+const (
+	// OutputFormatFixedWidth is the output format of templates emitting positional records.
+	OutputFormatFixedWidth = "fixed-width"
+
+	// FixedWidthFileExtension is the extension of the files generated by fixed-width templates.
+	FixedWidthFileExtension = "txt"
+
+	// FixedWidthLineEnding ends every record of a fixed-width file.
+	FixedWidthLineEnding = "\r\n"
+
+	// FixedWidthTypeAlpha is a text field, left-aligned and padded with spaces by default.
+	FixedWidthTypeAlpha = "alpha"
+
+	// FixedWidthTypeNumeric is an unsigned number with implied decimals, right-aligned and zero-padded by default.
+	FixedWidthTypeNumeric = "numeric"
+
+	// FixedWidthTypeDate is a date written with the field format, e.g. "ddMMYYYY".
+	FixedWidthTypeDate = "date"
+
+	// FixedWidthSourceSequence fills a field with the number of the record in the file.
+	FixedWidthSourceSequence = "sequence"
+
+	// FixedWidthSourceCount fills a field with the number of records of the types listed in "of".
+	FixedWidthSourceCount = "count"
+
+	// FixedWidthSourceSum fills a field with the total of a numeric field, named "record.field" in "of".
+	FixedWidthSourceSum = "sum"
+)
+
+// CSV dialect of the csv_field filter, declared per template with the csv tag.
+const (
+	// CSVDefaultDelimiter separates the fields of a record.
+	CSVDefaultDelimiter = ","
+
+	// CSVDefaultQuote encloses fields holding the delimiter, the quote or a line break.
+	CSVDefaultQuote = "\""
+
+	// CSVDefaultDecimal is the decimal separator of numeric fields.
+	CSVDefaultDecimal = "."
+
+	// CSVFormulasKeep writes values starting with =, +, -, @, tab or carriage return as they are.
+	CSVFormulasKeep = "keep"
+
+	// CSVFormulasNeutralize prefixes such values with an apostrophe so spreadsheets do not evaluate them as formulas.
+	CSVFormulasNeutralize = "neutralize"
+)
@@ -4,8 +4,16 @@
 
 package constant
 
-// RabbitMQ Consumer Defaults
+// RabbitMQ Consumer Defaults. The prefetch count is per worker, so every worker of a queue has a message in hand.
 const (
 	DefaultWorkerCount   = 5
 	DefaultPrefetchCount = 1
 )
+
+// Report priorities. Each priority is generated from a queue of its own, so long exports of a lower
+// priority never hold back the reports of a higher one. Priorities without a queue use the normal one.
+const (
+	ReportPriorityHigh   = "high"
+	ReportPriorityNormal = "normal"
+	ReportPriorityLow    = "low"
+)
@@ -21,6 +21,12 @@ const (
 	// IdempotencyKeyCtx is the context key for client-provided Idempotency-Key header values.
 	IdempotencyKeyCtx = contextKey("idempotency_key")
 
+	// ReportReaperLeaderKey is the Redis key of the lease held by the manager instance running the stuck-report reaper.
+	ReportReaperLeaderKey = "report_reaper_leader"
+
+	// ReportPurgerLeaderKey is the Redis key of the lease held by the manager instance running the retention purge.
+	ReportPurgerLeaderKey = "report_purger_leader"
+
 	// IdempotencyReplayedCtx is the context key for signaling a replayed idempotent response
 	// from the service layer back to the handler.
 	IdempotencyReplayedCtx = contextKey("idempotency_replayed")
@@ -0,0 +1,15 @@
+// Copyright (c) 2026 Lerian Studio. All rights reserved.
+// Use of this source code is governed by the Elastic License 2.0
+// that can be found in the LICENSE file.
+
+package constant
+
+const (
+	// ReportFingerprintMetadataKey is the report metadata entry recording the fingerprint of the report output:
+	// the template revision, the resolved filters and the data versions of the datasources it was generated from.
+	ReportFingerprintMetadataKey = "fingerprint"
+
+	// ReportReusedFromMetadataKey is the report metadata entry recording the finished report whose output was
+	// reused instead of generating the report again.
+	ReportReusedFromMetadataKey = "reusedFrom"
+)
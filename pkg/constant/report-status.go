@@ -8,4 +8,36 @@ const (
 	ProcessingStatus = "Processing"
 	FinishedStatus   = "Finished"
 	ErrorStatus      = "Error"
+	CancelledStatus  = "Cancelled"
 )
+
+// MaxBulkRetryReports is the maximum number of reports retried by a single bulk retry request.
+const MaxBulkRetryReports = 500
+
+// MaxReapedReports is the maximum number of reports left in Processing handled by a single reaper sweep.
+const MaxReapedReports = 500
+
+// DefaultReportHeartbeatSeconds is how often a worker records that it is still generating a report when
+// REPORT_HEARTBEAT_SECONDS is not set.
+const DefaultReportHeartbeatSeconds = 30
+
+// MongoFieldHeartbeatAt is the report field holding the last time a worker generating it reported alive.
+const MongoFieldHeartbeatAt = "heartbeat_at"
+
+// MongoFieldPendingFiles is the field of a deleted report listing the storage files not deleted yet.
+const MongoFieldPendingFiles = "pending_files"
+
+// MaxDeadLetterMessages is the maximum number of dead letter messages listed or replayed by a single request.
+const MaxDeadLetterMessages = 500
+
+// MaxReportBatchItems is the maximum number of child reports created by a single report batch.
+const MaxReportBatchItems = 5000
+
+// ReportBatchInsertChunk is the number of child reports of a batch inserted together with their outbox entries.
+const ReportBatchInsertChunk = 100
+
+// MaxPurgedReports is the maximum number of expired reports deleted by a single purge sweep.
+const MaxPurgedReports = 500
+
+// MaxReportRetentionDays is the longest retention, in days, a template, tenant or deployment may set.
+const MaxReportRetentionDays = 36500
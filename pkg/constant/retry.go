@@ -51,3 +51,29 @@ const (
 	// ConnectionMonitorInterval is the period between background RabbitMQ health checks.
 	ConnectionMonitorInterval = 10 * time.Second
 )
+
+// Report Outbox Relay Configuration
+const (
+	// OutboxRelayInterval is the period between two runs of the outbox relay.
+	OutboxRelayInterval = 2 * time.Second
+
+	// OutboxClaimLease is how long a claimed outbox entry is reserved for its publisher.
+	// It outlasts the producer retries, so an entry is never published twice concurrently.
+	OutboxClaimLease = 1 * time.Minute
+
+	// OutboxDispatchBatchSize is the maximum number of entries published by a single relay run.
+	OutboxDispatchBatchSize = 100
+
+	// OutboxMaxAttempts is the number of failed publishes after which an entry is abandoned
+	// and its report marked as Error.
+	OutboxMaxAttempts = 10
+
+	// OutboxInitialBackoff is the delay before the first republish of a failed entry, doubled on every attempt.
+	OutboxInitialBackoff = 5 * time.Second
+
+	// OutboxMaxBackoff is the upper bound for the delay between two publishes of an entry.
+	OutboxMaxBackoff = 5 * time.Minute
+
+	// OutboxRetention is how long dispatched entries are kept before MongoDB removes them.
+	OutboxRetention = 7 * 24 * time.Hour
+)
@@ -0,0 +1,18 @@
+// Copyright (c) 2026 Lerian Studio. All rights reserved.
+// Use of this source code is governed by the Elastic License 2.0
+// that can be found in the LICENSE file.
+
+package constant
+
+// Row-level security configuration
+const (
+	// RowFiltersEnvField is the DATASOURCE_{NAME}_* field holding the row-level filter rules of a datasource.
+	// Format: "table:field=claim" entries separated by commas, e.g. "account:organization_id=owner,*:tenant_id=tenant".
+	RowFiltersEnvField = "ROW_FILTERS"
+
+	// RowFilterAnyTable is the table wildcard that applies a rule to every table queried on the datasource.
+	RowFilterAnyTable = "*"
+
+	// AuthClaimsCtx is the context key for the claims of the caller's access token.
+	AuthClaimsCtx = contextKey("auth_claims")
+)
@@ -16,3 +16,12 @@ const (
 	// SeaweedFSHTTPTimeout is the timeout for HTTP requests to the SeaweedFS server.
 	SeaweedFSHTTPTimeout = 30 * time.Second
 )
+
+// Report download configuration.
+const (
+	// DefaultReportDownloadURLExpiry is how long a presigned report download URL is valid when not configured.
+	DefaultReportDownloadURLExpiry = 5 * time.Minute
+
+	// MaxReportDownloadURLExpirySeconds is the longest validity of a presigned URL accepted by S3 (7 days).
+	MaxReportDownloadURLExpirySeconds = 7 * 24 * 60 * 60
+)
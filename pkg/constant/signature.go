@@ -0,0 +1,22 @@
+// Copyright (c) 2026 Lerian Studio. All rights reserved.
+// Use of this source code is governed by the Elastic License 2.0
+// that can be found in the LICENSE file.
+
+package constant
+
+const (
+	// ReportSignatureMetadataKey is the report metadata entry recording how the report was signed.
+	ReportSignatureMetadataKey = "signature"
+
+	// SignaturePAdES marks PDF reports carrying an embedded PAdES signature.
+	SignaturePAdES = "pades"
+
+	// SignatureCMS marks reports with a detached CMS signature stored next to them.
+	SignatureCMS = "cms"
+
+	// SignatureFileExtension is the extension of detached CMS signatures, appended to the report file name.
+	SignatureFileExtension = "p7s"
+
+	// SignatureContentType is the content type of detached CMS signatures.
+	SignatureContentType = "application/pkcs7-signature"
+)
@@ -0,0 +1,19 @@
+// Copyright (c) 2026 Lerian Studio. All rights reserved.
+// Use of this source code is governed by the Elastic License 2.0
+// that can be found in the LICENSE file.
+
+package constant
+
+// Template partial configuration
+const (
+	// PartialObjectPrefix is the storage prefix of partial templates, e.g. "partials/layouts/corporate.tpl".
+	PartialObjectPrefix = "partials/"
+	// MaxPartialNameLength is the maximum length of a partial name.
+	MaxPartialNameLength = 128
+	// MaxPartialIncludeDepth is the maximum nesting of include, extends and import tags across partials.
+	MaxPartialIncludeDepth = 10
+	// MongoFieldPartialName is the document field holding the name under which a partial template is referenced.
+	MongoFieldPartialName = "partial_name"
+	// MongoFieldPartials is the document field holding the partials a template references, directly or through other partials.
+	MongoFieldPartials = "partials"
+)
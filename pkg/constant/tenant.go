@@ -0,0 +1,21 @@
+// Copyright (c) 2026 Lerian Studio. All rights reserved.
+// Use of this source code is governed by the Elastic License 2.0
+// that can be found in the LICENSE file.
+
+package constant
+
+// Multi-tenant isolation configuration
+const (
+	// OrganizationIDHeader is the request header carrying the caller's organization (tenant) ID.
+	OrganizationIDHeader = "X-Organization-Id"
+	// OrganizationIDClaim is the access token claim carrying the caller's organization ID.
+	// When present it takes precedence over OrganizationIDHeader, which must then match it.
+	OrganizationIDClaim = "organization_id"
+	// TrustedServiceClaim is the access token claim marking a service allowed to act for any organization.
+	// Only such tokens may choose the organization through OrganizationIDHeader.
+	TrustedServiceClaim = "trusted_service"
+	// OrganizationIDLocal is the Fiber locals key holding the resolved organization ID.
+	OrganizationIDLocal = "organization_id"
+	// MongoFieldOrganizationID is the document field holding the owning organization of templates and reports.
+	MongoFieldOrganizationID = "organization_id"
+)
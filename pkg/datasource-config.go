@@ -96,6 +96,7 @@ type DataSourceConfig struct {
 	SSLCA               string
 	Options             string
 	MidazOrganizationID string // Used for CRM datasources to construct collection names
+	DataVersion         string // Marker of the data version, part of the fingerprint of reusable report outputs
 }
 
 // getDataSourceEnv reads an environment variable for a datasource field using the
@@ -178,6 +179,10 @@ type DataSource struct {
 	// MidazOrganizationID holds the Midaz organization ID for CRM datasources
 	// Used to construct collection names like "holder_{org_id}"
 	MidazOrganizationID string
+
+	// DataVersion is the marker of the version of the data, changed whenever historical data is corrected.
+	// Finished report outputs are reused only when every datasource they query has one. Empty disables reuse.
+	DataVersion string
 }
 
 // ConnectToDataSource establishes a connection to a data source if not already initialized.
@@ -476,6 +481,7 @@ func initMongoDataSource(dataSource DataSourceConfig, logger log.Logger) DataSou
 		LastAttempt:         time.Time{},
 		RetryCount:          0,
 		MidazOrganizationID: dataSource.MidazOrganizationID,
+		DataVersion:         dataSource.DataVersion,
 	}
 }
 
@@ -512,6 +518,7 @@ func initPostgresDataSource(dataSource DataSourceConfig, logger log.Logger, lazy
 		RetryCount:          0,
 		Schemas:             dataSource.GetSchemas(),
 		MidazOrganizationID: dataSource.MidazOrganizationID,
+		DataVersion:         dataSource.DataVersion,
 	}
 }
 
@@ -578,6 +585,7 @@ func buildDataSourceConfig(name string, logger log.Logger) (DataSourceConfig, bo
 		SSLCA:               getDataSourceEnv(name, "SSLCA"),                 // For MongoDB CA file
 		Options:             getDataSourceEnv(name, "OPTIONS"),               // For MongoDB URI options
 		MidazOrganizationID: getDataSourceEnv(name, "MIDAZ_ORGANIZATION_ID"), // For CRM collection names
+		DataVersion:         getDataSourceEnv(name, "DATA_VERSION"),          // For reusable report outputs
 	}
 
 	if dataSource.ConfigName == "" {
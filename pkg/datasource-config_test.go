@@ -320,6 +320,20 @@ func TestBuildDataSourceConfig(t *testing.T) {
 		assert.Equal(t, "org-123-456", config.MidazOrganizationID)
 	})
 
+	t.Run("Success - builds config with data version", func(t *testing.T) {
+		t.Setenv("DATASOURCE_LEDGER_DS_CONFIG_NAME", "ledger-ds")
+		t.Setenv("DATASOURCE_LEDGER_DS_HOST", "localhost")
+		t.Setenv("DATASOURCE_LEDGER_DS_TYPE", "postgresql")
+		t.Setenv("DATASOURCE_LEDGER_DS_DATA_VERSION", "2026-09")
+
+		logger, _, _, _ := libCommons.NewTrackingFromContext(context.Background())
+
+		config, isComplete := buildDataSourceConfig("ledger_ds", logger)
+
+		assert.True(t, isComplete)
+		assert.Equal(t, "2026-09", config.DataVersion)
+	})
+
 	t.Run("Fail - empty CONFIG_NAME is rejected", func(t *testing.T) {
 		t.Setenv("DATASOURCE_EMPTY_CFG_CONFIG_NAME", "")
 		t.Setenv("DATASOURCE_EMPTY_CFG_HOST", "localhost")
@@ -734,11 +748,13 @@ func TestInitMongoDataSource_MidazOrganizationID(t *testing.T) {
 		Password:            "orgpass",
 		Database:            "orgdb",
 		MidazOrganizationID: "org-123",
+		DataVersion:         "v7",
 	}
 
 	ds := initMongoDataSource(config, logger)
 
 	assert.Equal(t, "org-123", ds.MidazOrganizationID)
+	assert.Equal(t, "v7", ds.DataVersion)
 	assert.Equal(t, MongoDBType, ds.DatabaseType)
 	assert.Equal(t, "orgdb", ds.MongoDBName)
 }
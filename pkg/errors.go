@@ -101,6 +101,30 @@ func (e EntityConflictError) Unwrap() error {
 	return e.Err
 }
 
+// EntityGoneError records an error indicating an entity existed but was permanently removed,
+// such as a report purged by its retention policy.
+type EntityGoneError struct {
+	EntityType string `json:"entityType,omitempty"`
+	Title      string `json:"title,omitempty"`
+	Message    string `json:"message,omitempty"`
+	Code       string `json:"code,omitempty"`
+	Err        error  `json:"err,omitempty"`
+}
+
+// Error implements the error interface.
+func (e EntityGoneError) Error() string {
+	if e.Err != nil && strings.TrimSpace(e.Message) == "" {
+		return e.Err.Error()
+	}
+
+	return e.Message
+}
+
+// Unwrap implements the error interface introduced in Go 1.13 to unwrap the internal error.
+func (e EntityGoneError) Unwrap() error {
+	return e.Err
+}
+
 // UnauthorizedError indicates an operation that couldn't be performant because there's no user authenticated.
 type UnauthorizedError struct {
 	EntityType string `json:"entityType,omitempty"`
@@ -376,7 +400,7 @@ func ValidateBusinessError(err error, entityType string, args ...any) error {
 			EntityType: entityType,
 			Code:       constant.ErrInvalidOutputFormat.Error(),
 			Title:      "Invalid output format",
-			Message:    "The outputFormat field must be one of: html, csv, or xml.",
+			Message:    "The outputFormat field must be one of: html, pdf, csv, xml, txt or fixed-width.",
 		},
 		constant.ErrInvalidHeaderParameter: ValidationError{
 			EntityType: entityType,
@@ -534,6 +558,180 @@ func ValidateBusinessError(err error, entityType string, args ...any) error {
 			Title:      "TTL Not Supported",
 			Message:    "TTL parameter is not supported in S3 mode. Use bucket lifecycle policies instead.",
 		},
+		constant.ErrMissingAuthClaim: ForbiddenError{
+			EntityType: entityType,
+			Code:       constant.ErrMissingAuthClaim.Error(),
+			Title:      "Missing Authorization Claim",
+			Message:    fmt.Sprintf("The access token does not carry the '%v' claim required to query data source '%v'. Please check your credentials and try again.", args...),
+		},
+		constant.ErrRowLevelFilterViolation: ForbiddenError{
+			EntityType: entityType,
+			Code:       constant.ErrRowLevelFilterViolation.Error(),
+			Title:      "Row-Level Filter Violation",
+			Message:    fmt.Sprintf("The mandatory row-level filter on field '%v' of table '%v' in data source '%v' is missing or does not match the caller's scope.", args...),
+		},
+		constant.ErrInvalidRowLevelSignature: ForbiddenError{
+			EntityType: entityType,
+			Code:       constant.ErrInvalidRowLevelSignature.Error(),
+			Title:      "Invalid Row-Level Scope Signature",
+			Message:    "The row-level scope and filters of the report were not signed by the manager or were changed after the report was requested.",
+		},
+		constant.ErrMissingOrganizationID: ValidationError{
+			EntityType: entityType,
+			Code:       constant.ErrMissingOrganizationID.Error(),
+			Title:      "Missing Organization ID",
+			Message:    fmt.Sprintf("The organization ID is required. Please use an access token carrying the '%v' claim and try again.", args...),
+		},
+		constant.ErrInvalidOrganizationID: ValidationError{
+			EntityType: entityType,
+			Code:       constant.ErrInvalidOrganizationID.Error(),
+			Title:      "Invalid Organization ID",
+			Message:    fmt.Sprintf("The organization ID '%v' is not a valid UUID. Please check the value and try again.", args...),
+		},
+		constant.ErrOrganizationMismatch: ForbiddenError{
+			EntityType: entityType,
+			Code:       constant.ErrOrganizationMismatch.Error(),
+			Title:      "Organization Mismatch",
+			Message:    "The organization ID in the request does not match the organization of the access token.",
+		},
+		constant.ErrUntrustedOrganizationHeader: ForbiddenError{
+			EntityType: entityType,
+			Code:       constant.ErrUntrustedOrganizationHeader.Error(),
+			Title:      "Untrusted Organization Header",
+			Message:    fmt.Sprintf("The '%v' header is only accepted from trusted services. Use an access token carrying the organization of the request.", args...),
+		},
+		constant.ErrInvalidPartialName: ValidationError{
+			EntityType: entityType,
+			Code:       constant.ErrInvalidPartialName.Error(),
+			Title:      "Invalid Partial Name",
+			Message:    fmt.Sprintf("The partial name '%v' is invalid. Use up to 128 letters, digits, '-' or '_' in '/'-separated segments, e.g. layouts/corporate.", args...),
+		},
+		constant.ErrPartialNotFound: ValidationError{
+			EntityType: entityType,
+			Code:       constant.ErrPartialNotFound.Error(),
+			Title:      "Partial Not Found",
+			Message:    fmt.Sprintf("The partial '%v' referenced by the template was not found. Please upload it before referencing it.", args...),
+		},
+		constant.ErrPartialNameConflict: EntityConflictError{
+			EntityType: entityType,
+			Code:       constant.ErrPartialNameConflict.Error(),
+			Title:      "Partial Name Conflict",
+			Message:    fmt.Sprintf("A partial named '%v' already exists. Please update the existing partial or choose another name.", args...),
+		},
+		constant.ErrPartialInUse: EntityConflictError{
+			EntityType: entityType,
+			Code:       constant.ErrPartialInUse.Error(),
+			Title:      "Partial In Use",
+			Message:    fmt.Sprintf("The partial '%v' is still referenced by %v template(s). Please remove the references before deleting it.", args...),
+		},
+		constant.ErrInvalidTemplatePriority: ValidationError{
+			EntityType: entityType,
+			Code:       constant.ErrInvalidTemplatePriority.Error(),
+			Title:      "Invalid Template Priority",
+			Message:    fmt.Sprintf("The priority '%v' is invalid. Please use high, normal or low.", args...),
+		},
+		constant.ErrInvalidTemplateRetention: ValidationError{
+			EntityType: entityType,
+			Code:       constant.ErrInvalidTemplateRetention.Error(),
+			Title:      "Invalid Template Retention",
+			Message:    fmt.Sprintf("The retention days '%v' are invalid. Please use a whole number of days between 0 and %v.", args...),
+		},
+		constant.ErrPartialIncludeCycle: ValidationError{
+			EntityType: entityType,
+			Code:       constant.ErrPartialIncludeCycle.Error(),
+			Title:      "Partial Include Cycle",
+			Message:    fmt.Sprintf("The partial '%v' includes itself or exceeds the maximum include depth. Please check the include and extends tags.", args...),
+		},
+		constant.ErrPartialTemplateReport: ValidationError{
+			EntityType: entityType,
+			Code:       constant.ErrPartialTemplateReport.Error(),
+			Title:      "Partial Template Report",
+			Message:    "The template is a partial and can only be included or extended by other templates. Please select a regular template.",
+		},
+		constant.ErrInvalidOutputOptions: ValidationError{
+			EntityType: entityType,
+			Code:       constant.ErrInvalidOutputOptions.Error(),
+			Title:      "Invalid Output Options",
+			Message:    fmt.Sprintf("The output options are invalid: %v. Please check the encoding, unmappable, lineEnding, bom, trailingNewline and pdf fields.", args...),
+		},
+		constant.ErrSignatureNotFound: EntityNotFoundError{
+			EntityType: entityType,
+			Code:       constant.ErrSignatureNotFound.Error(),
+			Title:      "Signature Not Found",
+			Message:    "The Report has no detached signature. Reports are signed only when the worker has a signing certificate configured.",
+		},
+		constant.ErrSignatureEmbedded: ValidationError{
+			EntityType: entityType,
+			Code:       constant.ErrSignatureEmbedded.Error(),
+			Title:      "Signature Embedded in Report",
+			Message:    "The signature of PDF reports is embedded in the document. Please download the report to verify its signature.",
+		},
+		constant.ErrReportNotCancellable: EntityConflictError{
+			EntityType: entityType,
+			Code:       constant.ErrReportNotCancellable.Error(),
+			Title:      "Report Not Cancellable",
+			Message:    fmt.Sprintf("The Report is in the %v status and can no longer be cancelled. Only reports in the Processing status can be cancelled.", args...),
+		},
+		constant.ErrReportNotRetryable: EntityConflictError{
+			EntityType: entityType,
+			Code:       constant.ErrReportNotRetryable.Error(),
+			Title:      "Report Not Retryable",
+			Message:    fmt.Sprintf("The Report is in the %v status and can not be retried. Only reports in the Error or Cancelled status can be retried.", args...),
+		},
+		constant.ErrInvalidRetrySelection: ValidationError{
+			EntityType: entityType,
+			Code:       constant.ErrInvalidRetrySelection.Error(),
+			Title:      "Invalid Retry Selection",
+			Message:    fmt.Sprintf("The reports to retry are invalid: %v. Please provide a templateId, a createdFrom and createdTo window, or both.", args...),
+		},
+		constant.ErrInvalidDeadLetterSelection: ValidationError{
+			EntityType: entityType,
+			Code:       constant.ErrInvalidDeadLetterSelection.Error(),
+			Title:      "Invalid Dead Letter Selection",
+			Message:    fmt.Sprintf("The dead letter messages to replay are invalid: %v. Please provide reportIds or set all to true.", args...),
+		},
+		constant.ErrDeadLetterQueueNotConfigured: UnprocessableOperationError{
+			EntityType: entityType,
+			Code:       constant.ErrDeadLetterQueueNotConfigured.Error(),
+			Title:      "Dead Letter Queue Not Configured",
+			Message:    "The dead letter queue is not configured. Please set RABBITMQ_DLQ_QUEUE to manage its messages.",
+		},
+		constant.ErrInvalidReportBatchItems: ValidationError{
+			EntityType: entityType,
+			Code:       constant.ErrInvalidReportBatchItems.Error(),
+			Title:      "Invalid Report Batch Items",
+			Message:    fmt.Sprintf("The items of the report batch are invalid: %v. Please provide either items or itemsQuery, selecting between 1 and 5000 items.", args...),
+		},
+		constant.ErrReportBatchNotFinished: ValidationError{
+			EntityType: entityType,
+			Code:       constant.ErrReportBatchNotFinished.Error(),
+			Title:      "Report Batch Has No Finished Reports",
+			Message:    "The Report Batch has no finished reports to download yet. Please check the batch progress and try again later.",
+		},
+		constant.ErrPresignNotSupported: ValidationError{
+			EntityType: entityType,
+			Code:       constant.ErrPresignNotSupported.Error(),
+			Title:      "Presigned URL Not Supported",
+			Message:    "The storage backend does not support presigned URLs. Please download the file through the manager instead.",
+		},
+		constant.ErrReportUnderLegalHold: EntityConflictError{
+			EntityType: entityType,
+			Code:       constant.ErrReportUnderLegalHold.Error(),
+			Title:      "Report Under Legal Hold",
+			Message:    "The Report is under legal hold and cannot be deleted. Please release the legal hold and try again.",
+		},
+		constant.ErrReportNotDeletable: EntityConflictError{
+			EntityType: entityType,
+			Code:       constant.ErrReportNotDeletable.Error(),
+			Title:      "Report Not Deletable",
+			Message:    "The Report is still processing and cannot be deleted. Please cancel it or wait for it to complete and try again.",
+		},
+		constant.ErrReportPurged: EntityGoneError{
+			EntityType: entityType,
+			Code:       constant.ErrReportPurged.Error(),
+			Title:      "Report Purged",
+			Message:    "The Report was deleted or purged by its retention policy and its file is no longer available.",
+		},
 	}
 
 	if mappedError, found := errorMap[err]; found {
@@ -545,6 +545,35 @@ func TestValidateBusinessError_AllMappedErrors(t *testing.T) {
 		constant.ErrSchemaNotFound,
 		constant.ErrTableNotFoundInSchema,
 		constant.ErrDatabaseNotRegistered,
+		constant.ErrMissingAuthClaim,
+		constant.ErrRowLevelFilterViolation,
+		constant.ErrMissingOrganizationID,
+		constant.ErrInvalidOrganizationID,
+		constant.ErrOrganizationMismatch,
+		constant.ErrInvalidPartialName,
+		constant.ErrPartialNotFound,
+		constant.ErrPartialNameConflict,
+		constant.ErrPartialIncludeCycle,
+		constant.ErrPartialTemplateReport,
+		constant.ErrInvalidOutputOptions,
+		constant.ErrSignatureNotFound,
+		constant.ErrSignatureEmbedded,
+		constant.ErrReportNotCancellable,
+		constant.ErrReportNotRetryable,
+		constant.ErrInvalidRetrySelection,
+		constant.ErrInvalidDeadLetterSelection,
+		constant.ErrDeadLetterQueueNotConfigured,
+		constant.ErrInvalidReportBatchItems,
+		constant.ErrReportBatchNotFinished,
+		constant.ErrPresignNotSupported,
+		constant.ErrReportUnderLegalHold,
+		constant.ErrReportNotDeletable,
+		constant.ErrReportPurged,
+		constant.ErrInvalidRowLevelSignature,
+		constant.ErrUntrustedOrganizationHeader,
+		constant.ErrPartialInUse,
+		constant.ErrInvalidTemplatePriority,
+		constant.ErrInvalidTemplateRetention,
 	}
 
 	for _, err := range mappedErrors {
@@ -0,0 +1,408 @@
+// Copyright (c) 2026 Lerian Studio. All rights reserved.
+// Use of this source code is governed by the Elastic License 2.0
+// that can be found in the LICENSE file.
+
+package pkg
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/LerianStudio/reporter/pkg/constant"
+	"github.com/LerianStudio/reporter/pkg/model"
+
+	libCrypto "github.com/LerianStudio/lib-commons/v2/commons/crypto"
+	"github.com/LerianStudio/lib-commons/v2/commons/log"
+)
+
+// FieldTransformer describes how the documents of an encrypted MongoDB datasource are read:
+// which physical collection backs a template collection, which fields are stored encrypted
+// and which hashed search fields must be used to filter on them.
+type FieldTransformer struct {
+	// DataSource is the datasource ID (DATASOURCE_{NAME}_CONFIG_NAME) the transformer belongs to.
+	DataSource string
+
+	// CollectionTemplate builds the physical collection name from CollectionPlaceholder and
+	// MidazOrganizationIDPlaceholder. An empty template keeps the collection name unchanged.
+	CollectionTemplate string
+
+	// SkipCollections lists template collections that are not backed by a collection and are never queried.
+	SkipCollections []string
+
+	// EncryptedFields lists the encrypted field paths in dot notation. Arrays along a path are
+	// traversed, so "related_parties.document" decrypts the document of every related party.
+	EncryptedFields []string
+
+	// SearchFields maps a filterable field to the search field holding the hash of its value.
+	SearchFields map[string]string
+
+	// EncryptSecretKey is the key used to decrypt EncryptedFields.
+	EncryptSecretKey string
+
+	// HashSecretKey is the key used to hash filter values of SearchFields.
+	HashSecretKey string
+}
+
+// FieldTransformerRegistry holds the field transformer of every datasource.
+// A nil or empty registry transforms nothing.
+type FieldTransformerRegistry struct {
+	transformers map[string]*FieldTransformer
+}
+
+// NewFieldTransformerRegistry creates a FieldTransformerRegistry from the given transformers.
+func NewFieldTransformerRegistry(transformers ...*FieldTransformer) *FieldTransformerRegistry {
+	registry := &FieldTransformerRegistry{transformers: make(map[string]*FieldTransformer)}
+
+	for _, transformer := range transformers {
+		registry.transformers[transformer.DataSource] = transformer
+	}
+
+	return registry
+}
+
+// LoadFieldTransformerRegistry reads the field transformer environment variables of every configured
+// datasource (DATASOURCE_{NAME}_COLLECTION_TEMPLATE, _SKIP_COLLECTIONS, _ENCRYPTED_FIELDS, _SEARCH_FIELDS,
+// _ENCRYPT_SECRET_KEY and _HASH_SECRET_KEY) and builds the registry. A malformed entry is a fatal configuration error.
+func LoadFieldTransformerRegistry(logger log.Logger) (*FieldTransformerRegistry, error) {
+	registry := NewFieldTransformerRegistry()
+
+	for name := range collectDataSourceNames() {
+		collectionTemplate := getDataSourceEnv(name, constant.CollectionTemplateEnvField)
+		skipCollections := getDataSourceEnv(name, constant.SkipCollectionsEnvField)
+		encryptedFields := getDataSourceEnv(name, constant.EncryptedFieldsEnvField)
+		searchFields := getDataSourceEnv(name, constant.SearchFieldsEnvField)
+
+		if collectionTemplate == "" && skipCollections == "" && encryptedFields == "" && searchFields == "" {
+			continue
+		}
+
+		configName := getDataSourceEnv(name, "CONFIG_NAME")
+
+		searchFieldMappings, err := ParseSearchFields(configName, searchFields)
+		if err != nil {
+			return nil, err
+		}
+
+		transformer := &FieldTransformer{
+			DataSource:         configName,
+			CollectionTemplate: collectionTemplate,
+			SkipCollections:    splitFieldList(skipCollections),
+			EncryptedFields:    splitFieldList(encryptedFields),
+			SearchFields:       searchFieldMappings,
+			EncryptSecretKey:   getDataSourceEnv(name, constant.EncryptSecretKeyEnvField),
+			HashSecretKey:      getDataSourceEnv(name, constant.HashSecretKeyEnvField),
+		}
+
+		logger.Infof("Loaded field transformer for datasource '%s' (%d encrypted fields, %d search fields)",
+			configName, len(transformer.EncryptedFields), len(transformer.SearchFields))
+
+		registry.transformers[configName] = transformer
+	}
+
+	return registry, nil
+}
+
+// ParseSearchFields parses the "field=search_field" entries of a datasource, separated by commas.
+func ParseSearchFields(dataSource, raw string) (map[string]string, error) {
+	mappings := make(map[string]string)
+
+	for _, entry := range strings.Split(raw, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+
+		field, searchField, found := strings.Cut(entry, "=")
+		field = strings.TrimSpace(field)
+		searchField = strings.TrimSpace(searchField)
+
+		if !found || field == "" || searchField == "" {
+			return nil, fmt.Errorf("invalid search field %q for datasource %s: expected field=search_field", entry, dataSource)
+		}
+
+		mappings[field] = searchField
+	}
+
+	return mappings, nil
+}
+
+// PluginCRMFieldTransformer returns the transformer of the Midaz CRM plugin: collections suffixed with
+// the Midaz organization ID, encrypted holder and alias fields and their hashed search fields.
+func PluginCRMFieldTransformer(hashSecretKey, encryptSecretKey string) *FieldTransformer {
+	return &FieldTransformer{
+		DataSource:         constant.PluginCRMDataSourceID,
+		CollectionTemplate: constant.CollectionPlaceholder + "_" + constant.MidazOrganizationIDPlaceholder,
+		SkipCollections:    []string{"organization"},
+		EncryptedFields: []string{
+			"document",
+			"name",
+			"contact.primary_email",
+			"contact.secondary_email",
+			"contact.mobile_phone",
+			"contact.other_phone",
+			"banking_details.account",
+			"banking_details.iban",
+			"legal_person.representative.name",
+			"legal_person.representative.document",
+			"legal_person.representative.email",
+			"natural_person.mother_name",
+			"natural_person.father_name",
+			"regulatory_fields.participant_document",
+			"related_parties.document",
+		},
+		SearchFields: map[string]string{
+			"document":                               "search.document",
+			"name":                                   "search.name",
+			"banking_details.account":                "search.banking_details_account",
+			"banking_details.iban":                   "search.banking_details_iban",
+			"contact.primary_email":                  "search.contact_primary_email",
+			"contact.secondary_email":                "search.contact_secondary_email",
+			"contact.mobile_phone":                   "search.contact_mobile_phone",
+			"contact.other_phone":                    "search.contact_other_phone",
+			"regulatory_fields.participant_document": "search.regulatory_fields_participant_document",
+			"related_parties.document":               "search.related_party_documents",
+		},
+		EncryptSecretKey: encryptSecretKey,
+		HashSecretKey:    hashSecretKey,
+	}
+}
+
+// Get returns the transformer of a datasource, or nil when the datasource has none.
+func (r *FieldTransformerRegistry) Get(dataSource string) *FieldTransformer {
+	if r == nil {
+		return nil
+	}
+
+	return r.transformers[dataSource]
+}
+
+// RegisterDefault registers a built-in transformer unless the datasource already has one configured.
+func (r *FieldTransformerRegistry) RegisterDefault(transformer *FieldTransformer) {
+	if r.transformers == nil {
+		r.transformers = make(map[string]*FieldTransformer)
+	}
+
+	if _, exists := r.transformers[transformer.DataSource]; exists {
+		return
+	}
+
+	r.transformers[transformer.DataSource] = transformer
+}
+
+// SkipsCollection reports whether a template collection must not be queried.
+func (t *FieldTransformer) SkipsCollection(collection string) bool {
+	return t != nil && slices.Contains(t.SkipCollections, collection)
+}
+
+// CollectionName resolves the physical collection name of a template collection.
+func (t *FieldTransformer) CollectionName(collection, midazOrganizationID string) (string, error) {
+	if t == nil || t.CollectionTemplate == "" {
+		return collection, nil
+	}
+
+	if strings.Contains(t.CollectionTemplate, constant.MidazOrganizationIDPlaceholder) && midazOrganizationID == "" {
+		return "", fmt.Errorf("datasource %s requires the DATASOURCE_{NAME}_MIDAZ_ORGANIZATION_ID environment variable to be configured", t.DataSource)
+	}
+
+	return strings.NewReplacer(
+		constant.CollectionPlaceholder, collection,
+		constant.MidazOrganizationIDPlaceholder, midazOrganizationID,
+	).Replace(t.CollectionTemplate), nil
+}
+
+// NeedsDecryption reports whether any of the requested fields is, contains or is contained in an encrypted field.
+func (t *FieldTransformer) NeedsDecryption(fields []string) bool {
+	if t == nil {
+		return false
+	}
+
+	for _, field := range fields {
+		for _, path := range t.EncryptedFields {
+			if field == path || strings.HasPrefix(path, field+".") || strings.HasPrefix(field, path+".") {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// TransformFilters returns a copy of filter where every condition on a search-mapped field is moved
+// to its search field, with string values replaced by their hash. Other conditions are kept as-is.
+func (t *FieldTransformer) TransformFilters(filter map[string]model.FilterCondition, logger log.Logger) (map[string]model.FilterCondition, error) {
+	if filter == nil || t == nil || len(t.SearchFields) == 0 {
+		return filter, nil
+	}
+
+	crypto := &libCrypto.Crypto{
+		HashSecretKey: t.HashSecretKey,
+		Logger:        logger,
+	}
+
+	transformedFilter := make(map[string]model.FilterCondition, len(filter))
+
+	for fieldName, condition := range filter {
+		searchField, exists := t.SearchFields[fieldName]
+		if !exists {
+			transformedFilter[fieldName] = condition
+			continue
+		}
+
+		if t.HashSecretKey == "" {
+			return nil, fmt.Errorf("hash secret key not configured for datasource %s", t.DataSource)
+		}
+
+		transformedFilter[searchField] = model.FilterCondition{
+			Equals:         hashFilterValues(condition.Equals, crypto),
+			GreaterThan:    hashFilterValues(condition.GreaterThan, crypto),
+			GreaterOrEqual: hashFilterValues(condition.GreaterOrEqual, crypto),
+			LessThan:       hashFilterValues(condition.LessThan, crypto),
+			LessOrEqual:    hashFilterValues(condition.LessOrEqual, crypto),
+			Between:        hashFilterValues(condition.Between, crypto),
+			In:             hashFilterValues(condition.In, crypto),
+			NotIn:          hashFilterValues(condition.NotIn, crypto),
+		}
+
+		logger.Infof("Transformed advanced filter: %s -> %s", fieldName, searchField)
+	}
+
+	return transformedFilter, nil
+}
+
+// Decrypt returns copies of the records with every encrypted field decrypted.
+// Records are only decrypted when the requested fields reference an encrypted field.
+func (t *FieldTransformer) Decrypt(logger log.Logger, records []map[string]any, fields []string) ([]map[string]any, error) {
+	if !t.NeedsDecryption(fields) {
+		return records, nil
+	}
+
+	if t.EncryptSecretKey == "" {
+		return nil, fmt.Errorf("encrypt secret key not configured for datasource %s", t.DataSource)
+	}
+
+	if t.HashSecretKey == "" {
+		return nil, fmt.Errorf("hash secret key not configured for datasource %s", t.DataSource)
+	}
+
+	crypto := &libCrypto.Crypto{
+		HashSecretKey:    t.HashSecretKey,
+		EncryptSecretKey: t.EncryptSecretKey,
+		Logger:           logger,
+	}
+
+	if err := crypto.InitializeCipher(); err != nil {
+		return nil, fmt.Errorf("failed to initialize cipher: %w", err)
+	}
+
+	decrypted := make([]map[string]any, len(records))
+
+	for i, record := range records {
+		var current any = record
+
+		for _, path := range t.EncryptedFields {
+			value, err := decryptPath(current, strings.Split(path, "."), crypto)
+			if err != nil {
+				return nil, fmt.Errorf("failed to decrypt record %d: failed to decrypt %s: %w", i, path, err)
+			}
+
+			current = value
+		}
+
+		decrypted[i], _ = current.(map[string]any)
+	}
+
+	return decrypted, nil
+}
+
+// decryptPath decrypts the string at path inside value. Maps along the path are copied
+// instead of modified, so the caller's records are left untouched.
+func decryptPath(value any, path []string, crypto *libCrypto.Crypto) (any, error) {
+	switch typed := value.(type) {
+	case string:
+		if len(path) > 0 || typed == "" {
+			return typed, nil
+		}
+
+		decryptedValue, err := crypto.Decrypt(&typed)
+		if err != nil {
+			return nil, err
+		}
+
+		return *decryptedValue, nil
+	case map[string]any:
+		if len(path) == 0 {
+			return typed, nil
+		}
+
+		child, exists := typed[path[0]]
+		if !exists || child == nil {
+			return typed, nil
+		}
+
+		decryptedChild, err := decryptPath(child, path[1:], crypto)
+		if err != nil {
+			return nil, err
+		}
+
+		result := make(map[string]any, len(typed))
+		for k, v := range typed {
+			result[k] = v
+		}
+
+		result[path[0]] = decryptedChild
+
+		return result, nil
+	case []any:
+		if len(path) == 0 {
+			return typed, nil
+		}
+
+		result := make([]any, len(typed))
+
+		for i, item := range typed {
+			decryptedItem, err := decryptPath(item, path, crypto)
+			if err != nil {
+				return nil, err
+			}
+
+			result[i] = decryptedItem
+		}
+
+		return result, nil
+	default:
+		return value, nil
+	}
+}
+
+// hashFilterValues hashes the non-empty string values of a filter condition array.
+func hashFilterValues(values []any, crypto *libCrypto.Crypto) []any {
+	if len(values) == 0 {
+		return nil
+	}
+
+	hashedValues := make([]any, len(values))
+
+	for i, value := range values {
+		if strValue, ok := value.(string); ok && strValue != "" {
+			hashedValues[i] = crypto.GenerateHash(&strValue)
+		} else {
+			hashedValues[i] = value
+		}
+	}
+
+	return hashedValues
+}
+
+// splitFieldList splits a comma-separated list, trimming blanks and dropping empty entries.
+func splitFieldList(raw string) []string {
+	var items []string
+
+	for _, item := range strings.Split(raw, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+
+	return items
+}
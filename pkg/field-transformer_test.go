@@ -0,0 +1,458 @@
+// Copyright (c) 2026 Lerian Studio. All rights reserved.
+// Use of this source code is governed by the Elastic License 2.0
+// that can be found in the LICENSE file.
+
+package pkg
+
+import (
+	"context"
+	"testing"
+
+	"github.com/LerianStudio/reporter/pkg/constant"
+	"github.com/LerianStudio/reporter/pkg/model"
+
+	libCommons "github.com/LerianStudio/lib-commons/v2/commons"
+	libCrypto "github.com/LerianStudio/lib-commons/v2/commons/crypto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testCryptoKey = "0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef"
+
+// newTestCrypto returns an initialized cipher using the test keys.
+func newTestCrypto(t *testing.T) *libCrypto.Crypto {
+	t.Helper()
+
+	logger, _, _, _ := libCommons.NewTrackingFromContext(context.Background())
+
+	crypto := &libCrypto.Crypto{
+		HashSecretKey:    testCryptoKey,
+		EncryptSecretKey: testCryptoKey,
+		Logger:           logger,
+	}
+
+	require.NoError(t, crypto.InitializeCipher())
+
+	return crypto
+}
+
+// encryptForTest encrypts a value with the test cipher.
+func encryptForTest(t *testing.T, crypto *libCrypto.Crypto, value string) string {
+	t.Helper()
+
+	encrypted, err := crypto.Encrypt(&value)
+	require.NoError(t, err)
+
+	return *encrypted
+}
+
+func TestParseSearchFields(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		raw         string
+		expected    map[string]string
+		expectError bool
+	}{
+		{
+			name:     "multiple mappings with spaces",
+			raw:      " document=search.document , contact.email = search.contact_email ",
+			expected: map[string]string{"document": "search.document", "contact.email": "search.contact_email"},
+		},
+		{
+			name:     "empty value yields no mappings",
+			raw:      "",
+			expected: map[string]string{},
+		},
+		{
+			name:        "missing separator",
+			raw:         "document",
+			expectError: true,
+		},
+		{
+			name:        "empty search field",
+			raw:         "document=",
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			mappings, err := ParseSearchFields("plugin_fees", tt.raw)
+			if tt.expectError {
+				require.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, mappings)
+		})
+	}
+}
+
+func TestLoadFieldTransformerRegistry(t *testing.T) {
+	// Note: Cannot use t.Parallel() because t.Setenv is used
+	t.Setenv("DATASOURCE_FEES_CONFIG_NAME", "plugin_fees")
+	t.Setenv("DATASOURCE_FEES_COLLECTION_TEMPLATE", "{collection}_{midaz_organization_id}")
+	t.Setenv("DATASOURCE_FEES_SKIP_COLLECTIONS", "organization, metadata")
+	t.Setenv("DATASOURCE_FEES_ENCRYPTED_FIELDS", "payer.document,payer.name")
+	t.Setenv("DATASOURCE_FEES_SEARCH_FIELDS", "payer.document=search.payer_document")
+	t.Setenv("DATASOURCE_FEES_ENCRYPT_SECRET_KEY", "encrypt-key")
+	t.Setenv("DATASOURCE_FEES_HASH_SECRET_KEY", "hash-key")
+
+	logger, _, _, _ := libCommons.NewTrackingFromContext(context.Background())
+
+	registry, err := LoadFieldTransformerRegistry(logger)
+	require.NoError(t, err)
+
+	assert.Equal(t, &FieldTransformer{
+		DataSource:         "plugin_fees",
+		CollectionTemplate: "{collection}_{midaz_organization_id}",
+		SkipCollections:    []string{"organization", "metadata"},
+		EncryptedFields:    []string{"payer.document", "payer.name"},
+		SearchFields:       map[string]string{"payer.document": "search.payer_document"},
+		EncryptSecretKey:   "encrypt-key",
+		HashSecretKey:      "hash-key",
+	}, registry.Get("plugin_fees"))
+
+	t.Setenv("DATASOURCE_FEES_SEARCH_FIELDS", "payer.document")
+
+	_, err = LoadFieldTransformerRegistry(logger)
+	require.Error(t, err)
+}
+
+func TestFieldTransformerRegistry_RegisterDefault(t *testing.T) {
+	t.Parallel()
+
+	configured := &FieldTransformer{DataSource: constant.PluginCRMDataSourceID, EncryptedFields: []string{"document"}}
+
+	registry := NewFieldTransformerRegistry(configured)
+	registry.RegisterDefault(PluginCRMFieldTransformer(testCryptoKey, testCryptoKey))
+	assert.Same(t, configured, registry.Get(constant.PluginCRMDataSourceID), "configured transformer must not be replaced")
+
+	registry = NewFieldTransformerRegistry()
+	registry.RegisterDefault(PluginCRMFieldTransformer(testCryptoKey, testCryptoKey))
+	assert.NotNil(t, registry.Get(constant.PluginCRMDataSourceID))
+
+	var nilRegistry *FieldTransformerRegistry
+	assert.Nil(t, nilRegistry.Get(constant.PluginCRMDataSourceID))
+}
+
+func TestFieldTransformer_CollectionName(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name           string
+		transformer    *FieldTransformer
+		organizationID string
+		expected       string
+		expectError    bool
+	}{
+		{
+			name:           "plugin_crm appends the organization ID",
+			transformer:    PluginCRMFieldTransformer("", ""),
+			organizationID: "org-123",
+			expected:       "holders_org-123",
+		},
+		{
+			name:        "missing organization ID",
+			transformer: PluginCRMFieldTransformer("", ""),
+			expectError: true,
+		},
+		{
+			name:        "template without organization placeholder",
+			transformer: &FieldTransformer{CollectionTemplate: "archive_{collection}"},
+			expected:    "archive_holders",
+		},
+		{
+			name:        "empty template keeps the collection",
+			transformer: &FieldTransformer{},
+			expected:    "holders",
+		},
+		{
+			name:     "nil transformer keeps the collection",
+			expected: "holders",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			collection, err := tt.transformer.CollectionName("holders", tt.organizationID)
+			if tt.expectError {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), "MIDAZ_ORGANIZATION_ID")
+
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, collection)
+		})
+	}
+}
+
+func TestFieldTransformer_SkipsCollection(t *testing.T) {
+	t.Parallel()
+
+	transformer := PluginCRMFieldTransformer("", "")
+
+	assert.True(t, transformer.SkipsCollection("organization"))
+	assert.False(t, transformer.SkipsCollection("holders"))
+
+	var nilTransformer *FieldTransformer
+	assert.False(t, nilTransformer.SkipsCollection("organization"))
+}
+
+func TestFieldTransformer_NeedsDecryption(t *testing.T) {
+	t.Parallel()
+
+	transformer := PluginCRMFieldTransformer("", "")
+
+	tests := []struct {
+		fields   []string
+		expected bool
+	}{
+		{[]string{"document"}, true},
+		{[]string{"id", "name"}, true},
+		{[]string{"contact.primary_email"}, true},
+		{[]string{"contact"}, true},
+		{[]string{"related_parties"}, true},
+		{[]string{"id", "status"}, false},
+		{[]string{"contact.address"}, false},
+		{nil, false},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.expected, transformer.NeedsDecryption(tt.fields), "NeedsDecryption(%v)", tt.fields)
+	}
+}
+
+func TestFieldTransformer_TransformFilters(t *testing.T) {
+	t.Parallel()
+
+	logger, _, _, _ := libCommons.NewTrackingFromContext(context.Background())
+	crypto := newTestCrypto(t)
+	transformer := PluginCRMFieldTransformer(testCryptoKey, testCryptoKey)
+
+	hash := func(value string) string { return crypto.GenerateHash(&value) }
+
+	t.Run("Success - mapped fields move to hashed search fields", func(t *testing.T) {
+		t.Parallel()
+
+		filter := map[string]model.FilterCondition{
+			"regulatory_fields.participant_document": {Equals: []any{"12345678901234"}},
+			"related_parties.document":               {In: []any{"11111111111", 42, ""}},
+			"status":                                 {Equals: []any{"active"}},
+		}
+
+		result, err := transformer.TransformFilters(filter, logger)
+		require.NoError(t, err)
+
+		assert.Equal(t, map[string]model.FilterCondition{
+			"search.regulatory_fields_participant_document": {Equals: []any{hash("12345678901234")}},
+			"search.related_party_documents":                {In: []any{hash("11111111111"), 42, ""}},
+			"status":                                        {Equals: []any{"active"}},
+		}, result)
+	})
+
+	t.Run("Success - every condition is hashed", func(t *testing.T) {
+		t.Parallel()
+
+		filter := map[string]model.FilterCondition{
+			"document": {
+				Equals:         []any{"value1"},
+				GreaterThan:    []any{"value2"},
+				GreaterOrEqual: []any{"value3"},
+				LessThan:       []any{"value4"},
+				LessOrEqual:    []any{"value5"},
+				Between:        []any{"value6", "value7"},
+				In:             []any{"value8"},
+				NotIn:          []any{"value9"},
+			},
+		}
+
+		result, err := transformer.TransformFilters(filter, logger)
+		require.NoError(t, err)
+
+		assert.Equal(t, model.FilterCondition{
+			Equals:         []any{hash("value1")},
+			GreaterThan:    []any{hash("value2")},
+			GreaterOrEqual: []any{hash("value3")},
+			LessThan:       []any{hash("value4")},
+			LessOrEqual:    []any{hash("value5")},
+			Between:        []any{hash("value6"), hash("value7")},
+			In:             []any{hash("value8")},
+			NotIn:          []any{hash("value9")},
+		}, result["search.document"])
+	})
+
+	t.Run("Success - nil filter returns nil", func(t *testing.T) {
+		t.Parallel()
+
+		result, err := transformer.TransformFilters(nil, logger)
+		require.NoError(t, err)
+		assert.Nil(t, result)
+	})
+
+	t.Run("Error - missing hash key", func(t *testing.T) {
+		t.Parallel()
+
+		filter := map[string]model.FilterCondition{"document": {Equals: []any{"12345678901"}}}
+
+		_, err := PluginCRMFieldTransformer("", testCryptoKey).TransformFilters(filter, logger)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "hash secret key")
+	})
+}
+
+func TestFieldTransformer_Decrypt(t *testing.T) {
+	t.Parallel()
+
+	logger, _, _, _ := libCommons.NewTrackingFromContext(context.Background())
+	crypto := newTestCrypto(t)
+	transformer := PluginCRMFieldTransformer(testCryptoKey, testCryptoKey)
+
+	t.Run("Success - plugin_crm fields are decrypted at every depth", func(t *testing.T) {
+		t.Parallel()
+
+		record := map[string]any{
+			"id":       "rec-1",
+			"document": encryptForTest(t, crypto, "12345678901"),
+			"name":     encryptForTest(t, crypto, "John Doe"),
+			"contact": map[string]any{
+				"primary_email":   encryptForTest(t, crypto, "john@example.com"),
+				"secondary_email": nil,
+				"mobile_phone":    "",
+			},
+			"banking_details": map[string]any{
+				"account": encryptForTest(t, crypto, "12345-6"),
+				"iban":    encryptForTest(t, crypto, "BR1500000000000010932840814P2"),
+				"branch":  "0001",
+			},
+			"legal_person": map[string]any{
+				"representative": map[string]any{
+					"name":     encryptForTest(t, crypto, "Jane Roe"),
+					"document": encryptForTest(t, crypto, "98765432100"),
+					"email":    encryptForTest(t, crypto, "jane@example.com"),
+				},
+			},
+			"natural_person": map[string]any{
+				"mother_name": encryptForTest(t, crypto, "Maria"),
+				"father_name": encryptForTest(t, crypto, "José"),
+			},
+			"regulatory_fields": map[string]any{
+				"participant_document": encryptForTest(t, crypto, "12345678901234"),
+			},
+			"related_parties": []any{
+				map[string]any{"_id": "party-1", "document": encryptForTest(t, crypto, "11111111111"), "role": "PRIMARY_HOLDER"},
+				map[string]any{"_id": "party-2", "document": nil},
+				"not-a-map",
+				42,
+			},
+		}
+
+		result, err := transformer.Decrypt(logger, []map[string]any{record}, []string{"name"})
+		require.NoError(t, err)
+		require.Len(t, result, 1)
+
+		assert.Equal(t, map[string]any{
+			"id":       "rec-1",
+			"document": "12345678901",
+			"name":     "John Doe",
+			"contact": map[string]any{
+				"primary_email":   "john@example.com",
+				"secondary_email": nil,
+				"mobile_phone":    "",
+			},
+			"banking_details": map[string]any{
+				"account": "12345-6",
+				"iban":    "BR1500000000000010932840814P2",
+				"branch":  "0001",
+			},
+			"legal_person": map[string]any{
+				"representative": map[string]any{
+					"name":     "Jane Roe",
+					"document": "98765432100",
+					"email":    "jane@example.com",
+				},
+			},
+			"natural_person": map[string]any{
+				"mother_name": "Maria",
+				"father_name": "José",
+			},
+			"regulatory_fields": map[string]any{
+				"participant_document": "12345678901234",
+			},
+			"related_parties": []any{
+				map[string]any{"_id": "party-1", "document": "11111111111", "role": "PRIMARY_HOLDER"},
+				map[string]any{"_id": "party-2", "document": nil},
+				"not-a-map",
+				42,
+			},
+		}, result[0])
+
+		assert.NotEqual(t, "John Doe", record["name"], "input record must not be modified")
+		assert.NotEqual(t, "john@example.com", record["contact"].(map[string]any)["primary_email"], "nested input must not be modified")
+	})
+
+	t.Run("Success - no decryption needed", func(t *testing.T) {
+		t.Parallel()
+
+		records := []map[string]any{{"id": "123", "status": "active"}}
+
+		result, err := PluginCRMFieldTransformer("", "").Decrypt(logger, records, []string{"id", "status"})
+		require.NoError(t, err)
+		assert.Equal(t, records, result)
+	})
+
+	t.Run("Error - invalid encrypted values report the field path", func(t *testing.T) {
+		t.Parallel()
+
+		tests := []struct {
+			record      map[string]any
+			errContains string
+		}{
+			{map[string]any{"contact": map[string]any{"primary_email": "invalid-encrypted-data"}}, "contact.primary_email"},
+			{map[string]any{"legal_person": map[string]any{"representative": map[string]any{"name": "invalid-encrypted-data"}}}, "legal_person.representative.name"},
+			{map[string]any{"related_parties": []any{map[string]any{"document": "invalid-encrypted-data"}}}, "related_parties.document"},
+			{map[string]any{"id": "rec-1"}, ""},
+		}
+
+		for _, tt := range tests {
+			records := []map[string]any{{"id": "rec-0"}, tt.record}
+
+			_, err := transformer.Decrypt(logger, records, []string{"document"})
+			if tt.errContains == "" {
+				require.NoError(t, err)
+				continue
+			}
+
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), "failed to decrypt record 1")
+			assert.Contains(t, err.Error(), tt.errContains)
+		}
+	})
+
+	t.Run("Error - missing or invalid keys", func(t *testing.T) {
+		t.Parallel()
+
+		records := []map[string]any{{"document": "encrypted_value"}}
+
+		_, err := PluginCRMFieldTransformer(testCryptoKey, "").Decrypt(logger, records, []string{"document"})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "encrypt secret key")
+
+		_, err = PluginCRMFieldTransformer("", testCryptoKey).Decrypt(logger, records, []string{"document"})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "hash secret key")
+
+		_, err = PluginCRMFieldTransformer("valid-hash-key", "invalid-key-too-short").Decrypt(logger, records, []string{"document"})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "initialize cipher")
+	})
+}
@@ -0,0 +1,76 @@
+// Copyright (c) 2026 Lerian Studio. All rights reserved.
+// Use of this source code is governed by the Elastic License 2.0
+// that can be found in the LICENSE file.
+
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// DeadLetterMessage is a report generation message in the dead letter queue.
+//
+// swagger:model DeadLetterMessage
+//
+//	@Description	DeadLetterMessage is a report generation message the worker dead-lettered, with the reason.
+type DeadLetterMessage struct {
+	ReportID       uuid.UUID `json:"reportId" example:"00000000-0000-0000-0000-000000000000"`
+	TemplateID     uuid.UUID `json:"templateId" example:"00000000-0000-0000-0000-000000000000"`
+	OrganizationID uuid.UUID `json:"organizationId" example:"00000000-0000-0000-0000-000000000000"`
+
+	// RetryCount is the number of times the worker republished the message before dead-lettering it,
+	// and LastError the reason of its last failure. Messages rejected on their first failure have no LastError.
+	RetryCount int    `json:"retryCount" example:"3"`
+	LastError  string `json:"lastError,omitempty" example:"failed to query data source: connection refused"`
+
+	// Reason is why the broker dead-lettered the message (rejected, expired, maxlen or delivery_limit),
+	// Queue the queue it was dead-lettered from and DeadLetteredAt when.
+	Reason         string     `json:"reason,omitempty" example:"rejected"`
+	Queue          string     `json:"queue,omitempty" example:"reporter.generate-report.queue"`
+	DeadLetteredAt *time.Time `json:"deadLetteredAt,omitempty" example:"2026-01-01T00:00:00Z"`
+
+	// Message is the report message carried, nil when the body is not a readable report message.
+	Message *ReportMessage `json:"-"`
+} //	@name	DeadLetterMessage
+
+// DeadLetterMessagesOutput is a struct designed to encapsulate the dead letter listing response payload data.
+//
+// swagger:model DeadLetterMessagesOutput
+//
+//	@Description	DeadLetterMessagesOutput lists the first messages of the dead letter queue and its depth.
+type DeadLetterMessagesOutput struct {
+	Items []DeadLetterMessage `json:"items"`
+	Total int                 `json:"total" example:"12"`
+} //	@name	DeadLetterMessagesOutput
+
+// ReplayDeadLettersInput is a struct designed to encapsulate the dead letter replay request payload data.
+// It selects the messages of the given reports or, with All, every message.
+//
+// swagger:model ReplayDeadLettersInput
+//
+//	@Description	ReplayDeadLettersInput is the input payload to replay dead letter messages to the generation queue.
+type ReplayDeadLettersInput struct {
+	ReportIDs []string `json:"reportIds,omitempty" validate:"omitempty,max=500,dive,uuid" example:"00000000-0000-0000-0000-000000000000"`
+	All       bool     `json:"all,omitempty" example:"false"`
+} //	@name	ReplayDeadLettersInput
+
+// ReplayDeadLettersOutput is a struct designed to encapsulate the dead letter replay response payload data.
+//
+// swagger:model ReplayDeadLettersOutput
+//
+//	@Description	ReplayDeadLettersOutput lists the reports whose message was replayed and the ones that failed.
+type ReplayDeadLettersOutput struct {
+	Replayed []uuid.UUID          `json:"replayed"`
+	Failed   []RetryReportFailure `json:"failed"`
+} //	@name	ReplayDeadLettersOutput
+
+// PurgeDeadLettersOutput is a struct designed to encapsulate the dead letter purge response payload data.
+//
+// swagger:model PurgeDeadLettersOutput
+//
+//	@Description	PurgeDeadLettersOutput is the number of messages removed from the dead letter queue.
+type PurgeDeadLettersOutput struct {
+	Purged int `json:"purged" example:"12"`
+} //	@name	PurgeDeadLettersOutput
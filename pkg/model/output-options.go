@@ -0,0 +1,105 @@
+// Copyright (c) 2026 Lerian Studio. All rights reserved.
+// Use of this source code is governed by the Elastic License 2.0
+// that can be found in the LICENSE file.
+
+package model
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/LerianStudio/reporter/pkg/constant"
+)
+
+// OutputOptions defines how the rendered output of a template is written to the report file.
+// The encoding and line ending options apply to text formats only; the PDF options set the page of PDF reports.
+// Public fields are required for JSON binding and BSON persistence with the template.
+//
+// swagger:model OutputOptions
+//
+//	@Description	OutputOptions defines how the reports of a template are written: the character encoding and line endings of text reports and the page setup of PDF reports.
+type OutputOptions struct {
+	// Encoding is the character encoding of the file: utf-8 (default), iso-8859-1 or windows-1252.
+	Encoding string `json:"encoding,omitempty" bson:"encoding,omitempty" example:"windows-1252"`
+
+	// Unmappable is the strategy for characters the encoding cannot represent: fail (default), transliterate or replace.
+	Unmappable string `json:"unmappable,omitempty" bson:"unmappable,omitempty" example:"transliterate"`
+
+	// LineEnding normalizes the line endings: keep (default), lf or crlf.
+	LineEnding string `json:"lineEnding,omitempty" bson:"line_ending,omitempty" example:"crlf"`
+
+	// BOM writes a byte order mark at the start of the file. Only valid for utf-8.
+	BOM bool `json:"bom,omitempty" bson:"bom,omitempty" example:"false"`
+
+	// TrailingNewline is the policy for the end of the file: keep (default), ensure or strip.
+	TrailingNewline string `json:"trailingNewline,omitempty" bson:"trailing_newline,omitempty" example:"ensure"`
+
+	// PDF is the page setup of PDF reports. Nil prints Letter pages with 0.5 in margins and no header or footer.
+	PDF *PDFOptions `json:"pdf,omitempty" bson:"pdf,omitempty"`
+} //	@name	OutputOptions
+
+// Validate checks that every option holds a supported value.
+func (o *OutputOptions) Validate() error {
+	if o == nil {
+		return nil
+	}
+
+	if o.Encoding != "" && !slices.Contains([]string{constant.EncodingUTF8, constant.EncodingISO88591, constant.EncodingWindows1252}, o.Encoding) {
+		return fmt.Errorf("unsupported encoding '%s', use utf-8, iso-8859-1 or windows-1252", o.Encoding)
+	}
+
+	if o.Unmappable != "" && !slices.Contains([]string{constant.UnmappableFail, constant.UnmappableTransliterate, constant.UnmappableReplace}, o.Unmappable) {
+		return fmt.Errorf("unsupported unmappable strategy '%s', use fail, transliterate or replace", o.Unmappable)
+	}
+
+	if o.LineEnding != "" && !slices.Contains([]string{constant.LineEndingKeep, constant.LineEndingLF, constant.LineEndingCRLF}, o.LineEnding) {
+		return fmt.Errorf("unsupported line ending '%s', use keep, lf or crlf", o.LineEnding)
+	}
+
+	if o.TrailingNewline != "" && !slices.Contains([]string{constant.TrailingNewlineKeep, constant.TrailingNewlineEnsure, constant.TrailingNewlineStrip}, o.TrailingNewline) {
+		return fmt.Errorf("unsupported trailing newline policy '%s', use keep, ensure or strip", o.TrailingNewline)
+	}
+
+	if o.BOM && o.Charset() != constant.EncodingUTF8 {
+		return fmt.Errorf("a byte order mark can only be written in utf-8")
+	}
+
+	if err := o.PDF.Validate(); err != nil {
+		return fmt.Errorf("pdf: %w", err)
+	}
+
+	return nil
+}
+
+// ValidateForFormat checks the options against the output format of the template. Fixed-width records
+// are CRLF-terminated and measured from the first byte of the file, so the options must not change the
+// line endings, strip the last record's line ending or write a byte order mark.
+func (o *OutputOptions) ValidateForFormat(outputFormat string) error {
+	if o == nil || !strings.EqualFold(outputFormat, constant.OutputFormatFixedWidth) {
+		return nil
+	}
+
+	if o.LineEnding == constant.LineEndingLF {
+		return fmt.Errorf("fixed-width records end with crlf, use line ending keep or crlf")
+	}
+
+	if o.TrailingNewline == constant.TrailingNewlineStrip {
+		return fmt.Errorf("fixed-width records end with crlf, use trailing newline keep or ensure")
+	}
+
+	if o.BOM {
+		return fmt.Errorf("a byte order mark can not be written in a fixed-width file")
+	}
+
+	return nil
+}
+
+// Charset returns the character encoding of the file, utf-8 when none is set.
+func (o *OutputOptions) Charset() string {
+	if o == nil || o.Encoding == "" {
+		return constant.EncodingUTF8
+	}
+
+	return o.Encoding
+}
@@ -0,0 +1,106 @@
+// Copyright (c) 2026 Lerian Studio. All rights reserved.
+// Use of this source code is governed by the Elastic License 2.0
+// that can be found in the LICENSE file.
+
+package model
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOutputOptions_Validate(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		options *OutputOptions
+		wantErr string
+	}{
+		{name: "nil options", options: nil},
+		{name: "empty options", options: &OutputOptions{}},
+		{
+			name: "all options",
+			options: &OutputOptions{
+				Encoding:        "windows-1252",
+				Unmappable:      "transliterate",
+				LineEnding:      "crlf",
+				TrailingNewline: "ensure",
+			},
+		},
+		{name: "utf-8 with BOM", options: &OutputOptions{Encoding: "utf-8", BOM: true}},
+		{name: "unknown encoding", options: &OutputOptions{Encoding: "utf-16"}, wantErr: "encoding"},
+		{name: "unknown unmappable strategy", options: &OutputOptions{Unmappable: "drop"}, wantErr: "unmappable"},
+		{name: "unknown line ending", options: &OutputOptions{LineEnding: "cr"}, wantErr: "line ending"},
+		{name: "unknown trailing newline policy", options: &OutputOptions{TrailingNewline: "always"}, wantErr: "trailing newline"},
+		{name: "BOM outside utf-8", options: &OutputOptions{Encoding: "iso-8859-1", BOM: true}, wantErr: "byte order mark"},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			err := tt.options.Validate()
+			if tt.wantErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErr)
+
+				return
+			}
+
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestOutputOptions_ValidateForFormat(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name         string
+		outputFormat string
+		options      *OutputOptions
+		wantErr      string
+	}{
+		{name: "nil options", outputFormat: "fixed-width", options: nil},
+		{
+			name:         "fixed-width in windows-1252 with crlf",
+			outputFormat: "fixed-width",
+			options:      &OutputOptions{Encoding: "windows-1252", LineEnding: "crlf", TrailingNewline: "ensure"},
+		},
+		{name: "lf outside fixed-width", outputFormat: "csv", options: &OutputOptions{LineEnding: "lf", TrailingNewline: "strip", BOM: true}},
+		{name: "fixed-width with lf", outputFormat: "fixed-width", options: &OutputOptions{LineEnding: "lf"}, wantErr: "line ending"},
+		{name: "fixed-width stripping the newline", outputFormat: "Fixed-Width", options: &OutputOptions{TrailingNewline: "strip"}, wantErr: "trailing newline"},
+		{name: "fixed-width with BOM", outputFormat: "fixed-width", options: &OutputOptions{BOM: true}, wantErr: "byte order mark"},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			err := tt.options.ValidateForFormat(tt.outputFormat)
+			if tt.wantErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErr)
+
+				return
+			}
+
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestOutputOptions_Charset(t *testing.T) {
+	t.Parallel()
+
+	var options *OutputOptions
+
+	assert.Equal(t, "utf-8", options.Charset())
+	assert.Equal(t, "utf-8", (&OutputOptions{}).Charset())
+	assert.Equal(t, "windows-1252", (&OutputOptions{Encoding: "windows-1252"}).Charset())
+}
@@ -0,0 +1,222 @@
+// Copyright (c) 2026 Lerian Studio. All rights reserved.
+// Use of this source code is governed by the Elastic License 2.0
+// that can be found in the LICENSE file.
+
+package model
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+
+	"github.com/LerianStudio/reporter/pkg/constant"
+)
+
+// passwordExpressionPattern matches a template expression reading a variable, e.g. {{ holder.0.document }}.
+// Literal expressions such as {{ "1234" }} do not match, so a password is always derived from the report data.
+var passwordExpressionPattern = regexp.MustCompile(`{{-?\s*[A-Za-z_]`)
+
+// paperSizes maps the paper size presets of the PDF options to their portrait width and height in inches.
+var paperSizes = map[string][2]float64{
+	constant.PDFPaperLetter: {constant.PDFPaperWidthInches, constant.PDFPaperHeightInches},
+	constant.PDFPaperLegal:  {constant.PDFPaperWidthInches, constant.PDFPaperLegalHeightInches},
+	constant.PDFPaperA3:     {constant.PDFPaperA3WidthInches, constant.PDFPaperA3HeightInches},
+	constant.PDFPaperA4:     {constant.PDFPaperA4WidthInches, constant.PDFPaperA4HeightInches},
+	constant.PDFPaperA5:     {constant.PDFPaperA5WidthInches, constant.PDFPaperA5HeightInches},
+}
+
+// PDFOptions defines the page setup of the PDF reports of a template. Dimensions are in inches.
+// Public fields are required for JSON binding and BSON persistence with the template.
+//
+// swagger:model PDFOptions
+//
+//	@Description	PDFOptions defines the paper size, orientation, margins, scale, header and footer of PDF reports.
+type PDFOptions struct {
+	// PaperSize is a preset (letter (default), legal, a3, a4, a5) or custom, which uses Width and Height.
+	PaperSize string `json:"paperSize,omitempty" bson:"paper_size,omitempty" example:"a4"`
+
+	// Width is the paper width of a custom paper size, in inches.
+	Width float64 `json:"width,omitempty" bson:"width,omitempty" example:"8.5"`
+
+	// Height is the paper height of a custom paper size, in inches.
+	Height float64 `json:"height,omitempty" bson:"height,omitempty" example:"11"`
+
+	// Orientation is portrait (default) or landscape.
+	Orientation string `json:"orientation,omitempty" bson:"orientation,omitempty" example:"landscape"`
+
+	// Margins are the page margins. Sides not given keep the 0.5 in default.
+	Margins *PDFMargins `json:"margins,omitempty" bson:"margins,omitempty"`
+
+	// Scale is the scale of the page rendering, between 0.1 and 2. Defaults to 1.
+	Scale float64 `json:"scale,omitempty" bson:"scale,omitempty" example:"1"`
+
+	// HeaderTemplate is the HTML printed at the top of every page. Elements with the classes pageNumber,
+	// totalPages, date and title are filled with those values.
+	HeaderTemplate string `json:"headerTemplate,omitempty" bson:"header_template,omitempty" example:"<div style=\"font-size:9px\"><span class=\"title\"></span></div>"`
+
+	// FooterTemplate is the HTML printed at the bottom of every page, with the same classes as HeaderTemplate.
+	FooterTemplate string `json:"footerTemplate,omitempty" bson:"footer_template,omitempty" example:"<div style=\"font-size:9px\">Page <span class=\"pageNumber\"></span> of <span class=\"totalPages\"></span></div>"`
+
+	// Protection encrypts the reports with a password derived from the report data. Nil leaves them unprotected.
+	Protection *PDFProtection `json:"protection,omitempty" bson:"protection,omitempty"`
+} //	@name	PDFOptions
+
+// PDFProtection defines the password protection of PDF reports. Passwords are template expressions
+// rendered with the data of each report, so only the expressions are stored, never the passwords.
+//
+// swagger:model PDFProtection
+//
+//	@Description	PDFProtection defines the AES-256 password protection and permissions of PDF reports.
+type PDFProtection struct {
+	// UserPassword is the template expression of the password that opens the document.
+	UserPassword string `json:"userPassword" bson:"user_password" example:"{{ midaz_onboarding.holder.0.document|slice:\":5\" }}"`
+
+	// OwnerPassword is the template expression of the password that lifts the restrictions.
+	// When empty, a random password is used and the restrictions cannot be lifted.
+	OwnerPassword string `json:"ownerPassword,omitempty" bson:"owner_password,omitempty" example:""`
+
+	// Permissions lists what readers may do: print, copy, annotate and modify. Everything else is restricted.
+	Permissions []string `json:"permissions,omitempty" bson:"permissions,omitempty" example:"print"`
+} //	@name	PDFProtection
+
+// PDFMargins defines the page margins of PDF reports, in inches.
+//
+// swagger:model PDFMargins
+//
+//	@Description	PDFMargins defines the page margins of PDF reports, in inches.
+type PDFMargins struct {
+	Top    *float64 `json:"top,omitempty" bson:"top,omitempty" example:"0.5"`
+	Bottom *float64 `json:"bottom,omitempty" bson:"bottom,omitempty" example:"0.8"`
+	Left   *float64 `json:"left,omitempty" bson:"left,omitempty" example:"0.4"`
+	Right  *float64 `json:"right,omitempty" bson:"right,omitempty" example:"0.4"`
+} //	@name	PDFMargins
+
+// Validate checks the paper size, orientation, scale and protection, and that the margins leave room on the page.
+func (o *PDFOptions) Validate() error {
+	if o == nil {
+		return nil
+	}
+
+	switch {
+	case o.PaperSize == constant.PDFPaperCustom:
+		if o.Width <= 0 || o.Height <= 0 {
+			return fmt.Errorf("a custom paper size requires a positive width and height")
+		}
+	case o.Width != 0 || o.Height != 0:
+		return fmt.Errorf("width and height can only be set with the custom paper size")
+	case o.PaperSize != "":
+		if _, ok := paperSizes[o.PaperSize]; !ok {
+			return fmt.Errorf("unsupported paper size '%s', use letter, legal, a3, a4, a5 or custom", o.PaperSize)
+		}
+	}
+
+	if o.Orientation != "" && o.Orientation != constant.PDFOrientationPortrait && o.Orientation != constant.PDFOrientationLandscape {
+		return fmt.Errorf("unsupported orientation '%s', use portrait or landscape", o.Orientation)
+	}
+
+	if o.Scale != 0 && (o.Scale < constant.PDFMinScale || o.Scale > constant.PDFMaxScale) {
+		return fmt.Errorf("scale must be between %g and %g", constant.PDFMinScale, constant.PDFMaxScale)
+	}
+
+	if err := o.Protection.Validate(); err != nil {
+		return err
+	}
+
+	top, bottom, left, right := o.PageMargins()
+	if top < 0 || bottom < 0 || left < 0 || right < 0 {
+		return fmt.Errorf("margins cannot be negative")
+	}
+
+	width, height := o.PaperDimensions()
+	if o.Landscape() {
+		width, height = height, width
+	}
+
+	if left+right >= width || top+bottom >= height {
+		return fmt.Errorf("margins leave no room on the page")
+	}
+
+	return nil
+}
+
+// PaperDimensions returns the portrait width and height of the paper, in inches. Letter when none is set.
+func (o *PDFOptions) PaperDimensions() (width, height float64) {
+	if o != nil && o.PaperSize == constant.PDFPaperCustom {
+		return o.Width, o.Height
+	}
+
+	if o != nil {
+		if size, ok := paperSizes[o.PaperSize]; ok {
+			return size[0], size[1]
+		}
+	}
+
+	return constant.PDFPaperWidthInches, constant.PDFPaperHeightInches
+}
+
+// Landscape reports whether the pages are printed in landscape orientation.
+func (o *PDFOptions) Landscape() bool {
+	return o != nil && o.Orientation == constant.PDFOrientationLandscape
+}
+
+// PageMargins returns the top, bottom, left and right margins, in inches. Sides not set take the default.
+func (o *PDFOptions) PageMargins() (top, bottom, left, right float64) {
+	top, bottom, left, right = constant.PDFMarginInches, constant.PDFMarginInches, constant.PDFMarginInches, constant.PDFMarginInches
+
+	if o == nil || o.Margins == nil {
+		return top, bottom, left, right
+	}
+
+	for _, side := range []struct {
+		value  *float64
+		target *float64
+	}{{o.Margins.Top, &top}, {o.Margins.Bottom, &bottom}, {o.Margins.Left, &left}, {o.Margins.Right, &right}} {
+		if side.value != nil {
+			*side.target = *side.value
+		}
+	}
+
+	return top, bottom, left, right
+}
+
+// PageScale returns the scale of the page rendering, 1 when none is set.
+func (o *PDFOptions) PageScale() float64 {
+	if o == nil || o.Scale == 0 {
+		return 1
+	}
+
+	return o.Scale
+}
+
+// HasHeaderFooter reports whether a header or footer is printed on every page.
+func (o *PDFOptions) HasHeaderFooter() bool {
+	return o != nil && (o.HeaderTemplate != "" || o.FooterTemplate != "")
+}
+
+// Validate checks that the passwords are template expressions reading the report data and every permission is
+// supported. A literal password would be stored in plain text with the template and the messages of its reports.
+func (p *PDFProtection) Validate() error {
+	if p == nil {
+		return nil
+	}
+
+	if p.UserPassword == "" {
+		return fmt.Errorf("protection requires a user password")
+	}
+
+	if !passwordExpressionPattern.MatchString(p.UserPassword) {
+		return fmt.Errorf("the user password must be a template expression reading the report data, e.g. {{ holder.0.document }}")
+	}
+
+	if p.OwnerPassword != "" && !passwordExpressionPattern.MatchString(p.OwnerPassword) {
+		return fmt.Errorf("the owner password must be a template expression reading the report data, e.g. {{ holder.0.document }}")
+	}
+
+	for _, permission := range p.Permissions {
+		if !slices.Contains([]string{constant.PDFPermissionPrint, constant.PDFPermissionCopy, constant.PDFPermissionAnnotate, constant.PDFPermissionModify}, permission) {
+			return fmt.Errorf("unsupported permission '%s', use print, copy, annotate or modify", permission)
+		}
+	}
+
+	return nil
+}
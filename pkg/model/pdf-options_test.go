@@ -0,0 +1,89 @@
+// Copyright (c) 2026 Lerian Studio. All rights reserved.
+// Use of this source code is governed by the Elastic License 2.0
+// that can be found in the LICENSE file.
+
+package model
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPDFOptions_Validate(t *testing.T) {
+	t.Parallel()
+
+	zero, negative, wide := 0.0, -0.1, 4.5
+
+	tests := []struct {
+		name    string
+		options *PDFOptions
+		wantErr string
+	}{
+		{name: "nil options", options: nil},
+		{name: "empty options", options: &PDFOptions{}},
+		{name: "a4 landscape", options: &PDFOptions{PaperSize: "a4", Orientation: "landscape", Scale: 0.8}},
+		{name: "custom paper size", options: &PDFOptions{PaperSize: "custom", Width: 3.15, Height: 11}},
+		{name: "zero margins", options: &PDFOptions{Margins: &PDFMargins{Top: &zero, Bottom: &zero, Left: &zero, Right: &zero}}},
+		{name: "protection", options: &PDFOptions{Protection: &PDFProtection{UserPassword: "{{ holder.0.document }}", Permissions: []string{"print", "copy"}}}},
+		{name: "protection without user password", options: &PDFOptions{Protection: &PDFProtection{OwnerPassword: "x"}}, wantErr: "user password"},
+		{name: "unknown permission", options: &PDFOptions{Protection: &PDFProtection{UserPassword: "{{ holder.0.document }}", Permissions: []string{"sign"}}}, wantErr: "permission"},
+		{name: "literal user password", options: &PDFOptions{Protection: &PDFProtection{UserPassword: "1234"}}, wantErr: "user password must be a template expression"},
+		{name: "literal expression user password", options: &PDFOptions{Protection: &PDFProtection{UserPassword: `{{ "1234" }}`}}, wantErr: "user password must be a template expression"},
+		{name: "literal owner password", options: &PDFOptions{Protection: &PDFProtection{UserPassword: "{{- holder.0.document -}}", OwnerPassword: "admin"}}, wantErr: "owner password must be a template expression"},
+		{name: "owner password expression", options: &PDFOptions{Protection: &PDFProtection{UserPassword: "{{ holder.0.document }}", OwnerPassword: "{{ holder.0.id }}"}}},
+		{name: "unknown paper size", options: &PDFOptions{PaperSize: "b5"}, wantErr: "paper size"},
+		{name: "custom paper size without height", options: &PDFOptions{PaperSize: "custom", Width: 8}, wantErr: "custom paper size"},
+		{name: "dimensions of a preset", options: &PDFOptions{PaperSize: "a4", Width: 8}, wantErr: "custom paper size"},
+		{name: "unknown orientation", options: &PDFOptions{Orientation: "upside-down"}, wantErr: "orientation"},
+		{name: "scale too large", options: &PDFOptions{Scale: 3}, wantErr: "scale"},
+		{name: "negative margin", options: &PDFOptions{Margins: &PDFMargins{Top: &negative}}, wantErr: "negative"},
+		{name: "margins wider than the page", options: &PDFOptions{Margins: &PDFMargins{Left: &wide, Right: &wide}}, wantErr: "no room"},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			err := tt.options.Validate()
+			if tt.wantErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErr)
+
+				return
+			}
+
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestPDFOptions_PageSetup(t *testing.T) {
+	t.Parallel()
+
+	var options *PDFOptions
+
+	width, height := options.PaperDimensions()
+	assert.InDelta(t, 8.5, width, 0.001)
+	assert.InDelta(t, 11.0, height, 0.001)
+	assert.False(t, options.Landscape())
+	assert.False(t, options.HasHeaderFooter())
+	assert.InDelta(t, 1.0, options.PageScale(), 0.001)
+
+	right := 1.0
+	options = &PDFOptions{PaperSize: "legal", Orientation: "landscape", Margins: &PDFMargins{Right: &right}, HeaderTemplate: "<span class=\"title\"></span>"}
+
+	width, height = options.PaperDimensions()
+	assert.InDelta(t, 8.5, width, 0.001)
+	assert.InDelta(t, 14.0, height, 0.001)
+	assert.True(t, options.Landscape())
+	assert.True(t, options.HasHeaderFooter())
+
+	top, bottom, left, marginRight := options.PageMargins()
+	assert.InDelta(t, 0.5, top, 0.001)
+	assert.InDelta(t, 0.5, bottom, 0.001)
+	assert.InDelta(t, 0.5, left, 0.001)
+	assert.InDelta(t, 1.0, marginRight, 0.001)
+}
@@ -0,0 +1,60 @@
+// Copyright (c) 2026 Lerian Studio. All rights reserved.
+// Use of this source code is governed by the Elastic License 2.0
+// that can be found in the LICENSE file.
+
+package model
+
+// CreateReportBatchInput is a struct designed to encapsulate the report batch create payload data.
+// A batch generates one report of the template per item, filtered by the shared filters overridden
+// by the filters of the item. Items are either listed or selected by a datasource query.
+//
+// swagger:model CreateReportBatchInput
+//
+//	@Description	CreateReportBatchInput is the input payload to create a batch of reports of a template.
+type CreateReportBatchInput struct {
+	TemplateID string                                           `json:"templateId" validate:"required" example:"00000000-0000-0000-0000-000000000000"`
+	Filters    map[string]map[string]map[string]FilterCondition `json:"filters,omitempty"`
+
+	// Items are the per-report filter overrides. Exactly one of Items and ItemsQuery must be set.
+	Items []ReportBatchItem `json:"items,omitempty" validate:"omitempty,dive"`
+
+	// ItemsQuery selects the items from a datasource, one per distinct value of a field.
+	ItemsQuery *ReportBatchItemsQuery `json:"itemsQuery,omitempty"`
+
+	// Locale overrides the {% locale %} declared by the template for the format_* filters.
+	Locale string `json:"locale,omitempty" validate:"omitempty,oneof=pt-BR en-US es" example:"pt-BR"`
+
+	// Timezone overrides the {% timezone %} declared by the template for the date_time tag and to_tz filter.
+	Timezone string `json:"timezone,omitempty" validate:"omitempty,timezone" example:"America/Sao_Paulo"`
+
+	// Priority overrides the priority of the template: high, normal or low.
+	Priority string `json:"priority,omitempty" validate:"omitempty,oneof=high normal low" example:"low"`
+
+	// ReuseOutput declares that the filters of every report cover a closed, immutable period, allowing the
+	// output cache for the reports of the batch.
+	ReuseOutput bool `json:"reuseOutput,omitempty" example:"true"`
+} //	@name	CreateReportBatchInput
+
+// ReportBatchItem is a report of a batch. Its filters replace the shared filters of the batch
+// for the same datasource, table and field.
+type ReportBatchItem struct {
+	Filters map[string]map[string]map[string]FilterCondition `json:"filters" validate:"required"`
+} //	@name	ReportBatchItem
+
+// ReportBatchItemsQuery selects the items of a batch as the distinct values of Field in a datasource
+// table matching Filters. Each value becomes an item filtering Target, or Field itself when Target
+// is not set, equal to the value.
+type ReportBatchItemsQuery struct {
+	DataSource string                     `json:"dataSource" validate:"required" example:"midaz_onboarding"`
+	Table      string                     `json:"table" validate:"required" example:"account"`
+	Field      string                     `json:"field" validate:"required" example:"id"`
+	Filters    map[string]FilterCondition `json:"filters,omitempty"`
+	Target     *ReportBatchFilterTarget   `json:"target,omitempty"`
+} //	@name	ReportBatchItemsQuery
+
+// ReportBatchFilterTarget is the datasource field an item selected by a ReportBatchItemsQuery filters.
+type ReportBatchFilterTarget struct {
+	DataSource string `json:"dataSource" validate:"required" example:"midaz_transaction"`
+	Table      string `json:"table" validate:"required" example:"operation"`
+	Field      string `json:"field" validate:"required" example:"account_id"`
+} //	@name	ReportBatchFilterTarget
@@ -6,6 +6,7 @@ package model
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/google/uuid"
 )
@@ -65,6 +66,19 @@ type FilterCondition struct {
 type CreateReportInput struct {
 	TemplateID string                                           `json:"templateId" validate:"required" example:"00000000-0000-0000-0000-000000000000"`
 	Filters    map[string]map[string]map[string]FilterCondition `json:"filters" validate:"required"`
+
+	// Locale overrides the {% locale %} declared by the template for the format_* filters.
+	Locale string `json:"locale,omitempty" validate:"omitempty,oneof=pt-BR en-US es" example:"pt-BR"`
+
+	// Timezone overrides the {% timezone %} declared by the template for the date_time tag and to_tz filter.
+	Timezone string `json:"timezone,omitempty" validate:"omitempty,timezone" example:"America/Sao_Paulo"`
+
+	// Priority overrides the priority of the template: high, normal or low.
+	Priority string `json:"priority,omitempty" validate:"omitempty,oneof=high normal low" example:"high"`
+
+	// ReuseOutput declares that the filters cover a closed, immutable period, so the worker may finish the report
+	// with the output of a finished report with the same fingerprint, and this report's output may be reused later.
+	ReuseOutput bool `json:"reuseOutput,omitempty" example:"true"`
 } //	@name	CreateReportInput
 
 // NewCreateReportInput creates a new CreateReportInput with validation.
@@ -104,6 +118,32 @@ type ReportMessage struct {
 	OutputFormat string                                           `json:"outputFormat" example:"html"`
 	Filters      map[string]map[string]map[string]FilterCondition `json:"filters"`
 	MappedFields map[string]map[string][]string                   `json:"mappedFields"`
+
+	// OrganizationID is the tenant that owns the report. uuid.Nil is the default tenant.
+	OrganizationID uuid.UUID `json:"organizationId,omitempty" example:"00000000-0000-0000-0000-000000000000"`
+
+	// RowLevelScope holds the auth claim values the mandatory row-level filters were derived from,
+	// so the worker can verify the filters before querying any datasource.
+	RowLevelScope map[string]string `json:"rowLevelScope,omitempty"`
+
+	// RowLevelSignature is the signature of the row-level scope, mapped fields and filters, checked by the worker so a
+	// message changed on its way can not read the rows of another scope.
+	RowLevelSignature string `json:"rowLevelSignature,omitempty"`
+
+	// Locale is the locale requested for the format_* filters. Empty keeps the template's locale.
+	Locale string `json:"locale,omitempty" example:"pt-BR"`
+
+	// Timezone is the IANA timezone requested for the date_time tag and to_tz filter. Empty keeps the template's.
+	Timezone string `json:"timezone,omitempty" example:"America/Sao_Paulo"`
+
+	// OutputOptions are the encoding and line ending options of the template. Nil writes utf-8 as rendered.
+	OutputOptions *OutputOptions `json:"outputOptions,omitempty"`
+
+	// Priority is the generation priority of the report, which picks its queue. Empty is normal.
+	Priority string `json:"priority,omitempty" example:"normal"`
+
+	// ReuseOutput is set when the request declared a closed period, allowing the output cache for the report.
+	ReuseOutput bool `json:"reuseOutput,omitempty" example:"true"`
 } //	@name	ReportMessage
 
 // NewReportMessage creates a new ReportMessage with validation.
@@ -146,3 +186,98 @@ func NewReportMessage(
 		Filters:      filters,
 	}, nil
 }
+
+// RetryReportsInput is a struct designed to encapsulate the bulk retry request payload data.
+// It selects the reports in Error of a template, created in a time window, or both.
+//
+// swagger:model RetryReportsInput
+//
+//	@Description	RetryReportsInput is the input payload to retry the failed reports of a template or time window.
+type RetryReportsInput struct {
+	TemplateID string `json:"templateId,omitempty" validate:"omitempty,uuid" example:"00000000-0000-0000-0000-000000000000"`
+
+	// CreatedFrom and CreatedTo select the reports created in [CreatedFrom, CreatedTo).
+	CreatedFrom *time.Time `json:"createdFrom,omitempty" example:"2026-01-01T00:00:00Z"`
+	CreatedTo   *time.Time `json:"createdTo,omitempty" example:"2026-01-02T00:00:00Z"`
+
+	// RefreshTemplate republishes the reports with the current revision of their template
+	// instead of the one they were created with.
+	RefreshTemplate bool `json:"refreshTemplate,omitempty" example:"false"`
+} //	@name	RetryReportsInput
+
+// RetryReportsOutput is a struct designed to encapsulate the bulk retry response payload data.
+//
+// swagger:model RetryReportsOutput
+//
+//	@Description	RetryReportsOutput lists the reports requeued by a bulk retry and the ones that failed.
+type RetryReportsOutput struct {
+	Retried []uuid.UUID          `json:"retried"`
+	Failed  []RetryReportFailure `json:"failed"`
+} //	@name	RetryReportsOutput
+
+// RetryReportFailure is a report a bulk retry could not requeue, with the reason.
+type RetryReportFailure struct {
+	ReportID uuid.UUID `json:"reportId" example:"00000000-0000-0000-0000-000000000000"`
+	Error    string    `json:"error" example:"Failed to send report to queue"`
+}
+
+// ReportDownloadURLOutput is a struct designed to encapsulate the presigned download URL of a report.
+//
+// swagger:model ReportDownloadURLOutput
+//
+//	@Description	ReportDownloadURLOutput is a short-lived URL downloading the output of a report from the object storage.
+type ReportDownloadURLOutput struct {
+	URL       string    `json:"url" example:"https://storage.example.com/reporter-storage/reports/report.pdf?X-Amz-Signature=..."`
+	FileName  string    `json:"fileName" example:"00000000-0000-0000-0000-000000000000.pdf"`
+	ExpiresAt time.Time `json:"expiresAt" example:"2026-01-01T00:05:00Z"`
+} //	@name	ReportDownloadURLOutput
+
+// ReapReportsResult counts what the stuck-report reaper did with the reports left in Processing.
+type ReapReportsResult struct {
+	Requeued     int `json:"requeued"`
+	TimedOut     int `json:"timedOut"`
+	DeadLettered int `json:"deadLettered"`
+	Deferred     int `json:"deferred"`
+	Failed       int `json:"failed"`
+}
+
+// Add adds the counts of another result.
+func (r *ReapReportsResult) Add(other ReapReportsResult) {
+	r.Requeued += other.Requeued
+	r.TimedOut += other.TimedOut
+	r.DeadLettered += other.DeadLettered
+	r.Deferred += other.Deferred
+	r.Failed += other.Failed
+}
+
+// ReportReaperStats are the totals of the stuck-report reaper since the manager started,
+// exposed on the readiness endpoint.
+type ReportReaperStats struct {
+	ReapReportsResult
+	Leader    bool       `json:"leader"`
+	Sweeps    int        `json:"sweeps"`
+	LastRunAt *time.Time `json:"lastRunAt,omitempty"`
+	LastError string     `json:"lastError,omitempty"`
+}
+
+// PurgeReportsResult counts the expired reports deleted by the retention purge and the ones that failed.
+type PurgeReportsResult struct {
+	Purged int `json:"purged"`
+	Failed int `json:"failed"`
+}
+
+// Add adds the counts of another result.
+func (r *PurgeReportsResult) Add(other PurgeReportsResult) {
+	r.Purged += other.Purged
+	r.Failed += other.Failed
+}
+
+// ReportPurgerStats are the totals of the retention purge since the manager started,
+// exposed on the readiness endpoint.
+type ReportPurgerStats struct {
+	PurgeReportsResult
+	Leader    bool       `json:"leader"`
+	Sweeps    int        `json:"sweeps"`
+	LastRunAt *time.Time `json:"lastRunAt,omitempty"`
+	LastError string     `json:"lastError,omitempty"`
+}
@@ -0,0 +1,63 @@
+// Copyright (c) 2026 Lerian Studio. All rights reserved.
+// Use of this source code is governed by the Elastic License 2.0
+// that can be found in the LICENSE file.
+
+package model
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/LerianStudio/reporter/pkg/constant"
+
+	"github.com/google/uuid"
+)
+
+// RetentionPolicy sets how many days the reports are kept after completing before the purge job deletes them.
+// The retention days of a template, set in its output options, win over the ones of its tenant, which win over
+// the default. Zero days keep the reports forever.
+type RetentionPolicy struct {
+	// DefaultDays is the retention of the reports of the tenants and templates without a rule of their own.
+	DefaultDays int
+
+	// TenantDays is the retention of the reports of each organization. A zero keeps the reports of the
+	// organization forever, whatever the default.
+	TenantDays map[uuid.UUID]int
+}
+
+// ParseTenantRetentionDays parses per-tenant retention rules written as organizationID=days, comma separated,
+// e.g. "0190f7b2-0b5c-7cc4-9b8a-7d8d4a0f4d1e=30,0190f7b2-0b5c-7cc4-9b8a-7d8d4a0f4d1f=365".
+func ParseTenantRetentionDays(raw string) (map[uuid.UUID]int, error) {
+	tenantDays := make(map[uuid.UUID]int)
+
+	for _, rule := range strings.Split(raw, ",") {
+		rule = strings.TrimSpace(rule)
+		if rule == "" {
+			continue
+		}
+
+		organization, days, found := strings.Cut(rule, "=")
+		if !found {
+			return nil, fmt.Errorf("retention rule '%s' must be written as organizationID=days", rule)
+		}
+
+		organizationID, err := uuid.Parse(strings.TrimSpace(organization))
+		if err != nil {
+			return nil, fmt.Errorf("retention rule '%s' has an invalid organization ID: %w", rule, err)
+		}
+
+		retentionDays, err := strconv.Atoi(strings.TrimSpace(days))
+		if err != nil || retentionDays < 0 || retentionDays > constant.MaxReportRetentionDays {
+			return nil, fmt.Errorf("retention rule '%s' must set between 0 and %d days", rule, constant.MaxReportRetentionDays)
+		}
+
+		if _, duplicated := tenantDays[organizationID]; duplicated {
+			return nil, fmt.Errorf("retention rule of organization %s is set more than once", organizationID)
+		}
+
+		tenantDays[organizationID] = retentionDays
+	}
+
+	return tenantDays, nil
+}
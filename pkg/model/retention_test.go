@@ -0,0 +1,57 @@
+// Copyright (c) 2026 Lerian Studio. All rights reserved.
+// Use of this source code is governed by the Elastic License 2.0
+// that can be found in the LICENSE file.
+
+package model
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseTenantRetentionDays(t *testing.T) {
+	t.Parallel()
+
+	first := uuid.MustParse("0190f7b2-0b5c-7cc4-9b8a-7d8d4a0f4d1e")
+	second := uuid.MustParse("0190f7b2-0b5c-7cc4-9b8a-7d8d4a0f4d1f")
+
+	tests := []struct {
+		name    string
+		raw     string
+		want    map[uuid.UUID]int
+		wantErr string
+	}{
+		{name: "empty", raw: "", want: map[uuid.UUID]int{}},
+		{
+			name: "several tenants",
+			raw:  first.String() + "=30, " + second.String() + " = 0,",
+			want: map[uuid.UUID]int{first: 30, second: 0},
+		},
+		{name: "missing days", raw: first.String(), wantErr: "organizationID=days"},
+		{name: "invalid organization", raw: "acme=30", wantErr: "invalid organization ID"},
+		{name: "negative days", raw: first.String() + "=-1", wantErr: "between 0 and"},
+		{name: "days not a number", raw: first.String() + "=month", wantErr: "between 0 and"},
+		{name: "duplicated tenant", raw: first.String() + "=30," + first.String() + "=60", wantErr: "more than once"},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := ParseTenantRetentionDays(tt.raw)
+			if tt.wantErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErr)
+
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -0,0 +1,135 @@
+// Copyright (c) 2026 Lerian Studio. All rights reserved.
+// Use of this source code is governed by the Elastic License 2.0
+// that can be found in the LICENSE file.
+
+package batch
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/LerianStudio/reporter/pkg/constant"
+	"github.com/LerianStudio/reporter/pkg/model"
+
+	"github.com/google/uuid"
+)
+
+// Batch represents the entity model for a report batch, the parent of the reports generated
+// from a template for a list of filter overrides.
+// Public fields are required for JSON serialization (json tags) and Swagger documentation.
+type Batch struct {
+	ID             uuid.UUID                                              `json:"id" example:"00000000-0000-0000-0000-000000000000"`
+	TemplateID     uuid.UUID                                              `json:"templateId" example:"00000000-0000-0000-0000-000000000000"`
+	OrganizationID uuid.UUID                                              `json:"organizationId" example:"00000000-0000-0000-0000-000000000000"`
+	Filters        map[string]map[string]map[string]model.FilterCondition `json:"filters"`
+	CreatedAt      time.Time                                              `json:"createdAt"`
+	UpdatedAt      time.Time                                              `json:"updatedAt"`
+	DeletedAt      *time.Time                                             `json:"deletedAt"`
+
+	// FailedAt is set when the batch could not store all of its reports; those stored were cancelled.
+	FailedAt      *time.Time `json:"failedAt,omitempty"`
+	FailureReason string     `json:"failureReason,omitempty" example:"failed to store the reports of the batch"`
+
+	// Progress aggregates the statuses of the child reports. It is not stored with the batch.
+	Progress
+}
+
+// Progress aggregates the statuses of the child reports of a batch. Status is Processing while
+// any report is processing, then Finished when every report finished and Error otherwise.
+type Progress struct {
+	Status     string `json:"status" example:"Processing"`
+	Total      int    `json:"total" example:"3000"`
+	Processing int    `json:"processing" example:"1200"`
+	Finished   int    `json:"finished" example:"1790"`
+	Failed     int    `json:"failed" example:"10"`
+	Cancelled  int    `json:"cancelled" example:"0"`
+}
+
+// NewProgress aggregates the number of child reports in each status.
+func NewProgress(counts map[string]int) Progress {
+	p := Progress{
+		Processing: counts[constant.ProcessingStatus],
+		Finished:   counts[constant.FinishedStatus],
+		Failed:     counts[constant.ErrorStatus],
+		Cancelled:  counts[constant.CancelledStatus],
+	}
+
+	p.Total = p.Processing + p.Finished + p.Failed + p.Cancelled
+
+	switch {
+	case p.Processing > 0:
+		p.Status = constant.ProcessingStatus
+	case p.Finished == p.Total:
+		p.Status = constant.FinishedStatus
+	default:
+		p.Status = constant.ErrorStatus
+	}
+
+	return p
+}
+
+// NewBatch creates a new Batch entity with invariant validation.
+func NewBatch(
+	id, templateID, organizationID uuid.UUID,
+	filters map[string]map[string]map[string]model.FilterCondition,
+) (*Batch, error) {
+	if id == uuid.Nil {
+		return nil, fmt.Errorf("batch id must not be nil: %w", constant.ErrMissingRequiredFields)
+	}
+
+	if templateID == uuid.Nil {
+		return nil, fmt.Errorf("batch templateID must not be nil: %w", constant.ErrMissingRequiredFields)
+	}
+
+	now := time.Now()
+
+	return &Batch{
+		ID:             id,
+		TemplateID:     templateID,
+		OrganizationID: organizationID,
+		Filters:        filters,
+		CreatedAt:      now,
+		UpdatedAt:      now,
+	}, nil
+}
+
+// BatchMongoDBModel represents the MongoDB model for a report batch.
+type BatchMongoDBModel struct {
+	ID             uuid.UUID                                              `bson:"_id"`
+	TemplateID     uuid.UUID                                              `bson:"template_id"`
+	OrganizationID uuid.UUID                                              `bson:"organization_id"`
+	Filters        map[string]map[string]map[string]model.FilterCondition `bson:"filters"`
+	CreatedAt      time.Time                                              `bson:"created_at"`
+	UpdatedAt      time.Time                                              `bson:"updated_at"`
+	DeletedAt      *time.Time                                             `bson:"deleted_at"`
+	FailedAt       *time.Time                                             `bson:"failed_at,omitempty"`
+	FailureReason  string                                                 `bson:"failure_reason,omitempty"`
+}
+
+// FromEntity converts Batch to BatchMongoDBModel.
+func (bm *BatchMongoDBModel) FromEntity(b *Batch) {
+	bm.ID = b.ID
+	bm.TemplateID = b.TemplateID
+	bm.OrganizationID = b.OrganizationID
+	bm.Filters = b.Filters
+	bm.CreatedAt = b.CreatedAt
+	bm.UpdatedAt = b.UpdatedAt
+	bm.DeletedAt = b.DeletedAt
+	bm.FailedAt = b.FailedAt
+	bm.FailureReason = b.FailureReason
+}
+
+// ToEntity converts BatchMongoDBModel to Batch.
+func (bm *BatchMongoDBModel) ToEntity() *Batch {
+	return &Batch{
+		ID:             bm.ID,
+		TemplateID:     bm.TemplateID,
+		OrganizationID: bm.OrganizationID,
+		Filters:        bm.Filters,
+		CreatedAt:      bm.CreatedAt,
+		UpdatedAt:      bm.UpdatedAt,
+		DeletedAt:      bm.DeletedAt,
+		FailedAt:       bm.FailedAt,
+		FailureReason:  bm.FailureReason,
+	}
+}
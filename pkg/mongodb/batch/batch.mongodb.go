@@ -0,0 +1,159 @@
+// Copyright (c) 2026 Lerian Studio. All rights reserved.
+// Use of this source code is governed by the Elastic License 2.0
+// that can be found in the LICENSE file.
+
+package batch
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/LerianStudio/reporter/pkg/constant"
+	"github.com/LerianStudio/reporter/pkg/mongodb"
+
+	"github.com/LerianStudio/lib-commons/v2/commons"
+	libMongo "github.com/LerianStudio/lib-commons/v2/commons/mongo"
+	libOpentelemetry "github.com/LerianStudio/lib-commons/v2/commons/opentelemetry"
+	"github.com/google/uuid"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// Repository provides an interface for operations related to the report batch collection in MongoDB.
+// The child reports of a batch are stored by the report repository.
+//
+//go:generate mockgen --destination=batch.mongodb.mock.go --package=batch --copyright_file=../../../COPYRIGHT . Repository
+type Repository interface {
+	Create(ctx context.Context, record *Batch) (*Batch, error)
+	FindByID(ctx context.Context, id, organizationID uuid.UUID) (*Batch, error)
+	MarkFailed(ctx context.Context, id, organizationID uuid.UUID, reason string, failedAt time.Time) error
+}
+
+// BatchMongoDBRepository is a MongoDB-specific implementation of the batch Repository.
+type BatchMongoDBRepository struct {
+	connection *libMongo.MongoConnection
+	Database   string
+}
+
+// Compile-time interface satisfaction check.
+var _ Repository = (*BatchMongoDBRepository)(nil)
+
+// NewBatchMongoDBRepository returns a new instance of BatchMongoDBRepository using the given MongoDB connection.
+func NewBatchMongoDBRepository(mc *libMongo.MongoConnection) (*BatchMongoDBRepository, error) {
+	r := &BatchMongoDBRepository{
+		connection: mc,
+		Database:   mc.Database,
+	}
+	if _, err := r.connection.GetDB(context.Background()); err != nil {
+		return nil, fmt.Errorf("failed to connect to mongodb for report batches: %w", err)
+	}
+
+	return r, nil
+}
+
+// Create inserts a new report batch into mongo.
+func (bm *BatchMongoDBRepository) Create(ctx context.Context, batch *Batch) (*Batch, error) {
+	_, tracer, reqId, _ := commons.NewTrackingFromContext(ctx)
+
+	ctx, span := tracer.Start(ctx, "repository.batch.create")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("app.request.request_id", reqId),
+		attribute.String("app.request.batch_id", batch.ID.String()),
+	)
+
+	db, err := bm.connection.GetDB(ctx)
+	if err != nil {
+		libOpentelemetry.HandleSpanError(&span, "Failed to get database", err)
+		return nil, err
+	}
+
+	coll := db.Database(strings.ToLower(bm.Database)).Collection(strings.ToLower(constant.MongoCollectionBatch))
+
+	record := &BatchMongoDBModel{}
+	record.FromEntity(batch)
+
+	if _, err := coll.InsertOne(ctx, record); err != nil {
+		libOpentelemetry.HandleSpanError(&span, "Failed to insert report batch", err)
+		return nil, err
+	}
+
+	return record.ToEntity(), nil
+}
+
+// FindByID retrieves a report batch of the given organization. It returns mongo.ErrNoDocuments when no such batch exists.
+func (bm *BatchMongoDBRepository) FindByID(ctx context.Context, id, organizationID uuid.UUID) (*Batch, error) {
+	_, tracer, reqId, _ := commons.NewTrackingFromContext(ctx)
+
+	ctx, span := tracer.Start(ctx, "repository.batch.find_by_id")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("app.request.request_id", reqId),
+		attribute.String("app.request.batch_id", id.String()),
+	)
+
+	db, err := bm.connection.GetDB(ctx)
+	if err != nil {
+		libOpentelemetry.HandleSpanError(&span, "Failed to get database", err)
+		return nil, err
+	}
+
+	coll := db.Database(strings.ToLower(bm.Database)).Collection(strings.ToLower(constant.MongoCollectionBatch))
+
+	filter := bson.M{
+		"_id":                             id,
+		constant.MongoFieldOrganizationID: mongodb.OrganizationFilter(organizationID),
+		"deleted_at":                      bson.D{{Key: "$eq", Value: nil}},
+	}
+
+	var record BatchMongoDBModel
+	if err := coll.FindOne(ctx, filter).Decode(&record); err != nil {
+		libOpentelemetry.HandleSpanError(&span, "Failed to find report batch by id", err)
+		return nil, err
+	}
+
+	return record.ToEntity(), nil
+}
+
+// MarkFailed records that a report batch of the organization failed at failedAt for the given reason.
+func (bm *BatchMongoDBRepository) MarkFailed(ctx context.Context, id, organizationID uuid.UUID, reason string, failedAt time.Time) error {
+	_, tracer, reqId, _ := commons.NewTrackingFromContext(ctx)
+
+	ctx, span := tracer.Start(ctx, "repository.batch.mark_failed")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("app.request.request_id", reqId),
+		attribute.String("app.request.batch_id", id.String()),
+	)
+
+	db, err := bm.connection.GetDB(ctx)
+	if err != nil {
+		libOpentelemetry.HandleSpanError(&span, "Failed to get database", err)
+		return err
+	}
+
+	coll := db.Database(strings.ToLower(bm.Database)).Collection(strings.ToLower(constant.MongoCollectionBatch))
+
+	filter := bson.M{
+		"_id":                             id,
+		constant.MongoFieldOrganizationID: mongodb.OrganizationFilter(organizationID),
+	}
+
+	update := bson.M{"$set": bson.M{
+		"failed_at":      failedAt,
+		"failure_reason": reason,
+		"updated_at":     failedAt,
+	}}
+
+	if _, err := coll.UpdateOne(ctx, filter, update); err != nil {
+		libOpentelemetry.HandleSpanError(&span, "Failed to mark report batch as failed", err)
+		return err
+	}
+
+	return nil
+}
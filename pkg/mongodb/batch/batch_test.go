@@ -0,0 +1,85 @@
+// Copyright (c) 2026 Lerian Studio. All rights reserved.
+// Use of this source code is governed by the Elastic License 2.0
+// that can be found in the LICENSE file.
+
+package batch
+
+import (
+	"testing"
+
+	"github.com/LerianStudio/reporter/pkg/constant"
+	"github.com/LerianStudio/reporter/pkg/model"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewBatch(t *testing.T) {
+	t.Parallel()
+
+	id := uuid.New()
+	templateID := uuid.New()
+	filters := map[string]map[string]map[string]model.FilterCondition{
+		"db": {"table": {"status": {Equals: []any{"approved"}}}},
+	}
+
+	b, err := NewBatch(id, templateID, uuid.Nil, filters)
+	require.NoError(t, err)
+	assert.Equal(t, id, b.ID)
+	assert.Equal(t, templateID, b.TemplateID)
+	assert.Equal(t, filters, b.Filters)
+	assert.False(t, b.CreatedAt.IsZero())
+
+	_, err = NewBatch(uuid.Nil, templateID, uuid.Nil, nil)
+	require.ErrorIs(t, err, constant.ErrMissingRequiredFields)
+
+	_, err = NewBatch(id, uuid.Nil, uuid.Nil, nil)
+	require.ErrorIs(t, err, constant.ErrMissingRequiredFields)
+}
+
+func TestNewProgress(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		counts   map[string]int
+		expected Progress
+	}{
+		{
+			name:     "Processing while any report is processing",
+			counts:   map[string]int{constant.ProcessingStatus: 1, constant.FinishedStatus: 3, constant.ErrorStatus: 1},
+			expected: Progress{Status: constant.ProcessingStatus, Total: 5, Processing: 1, Finished: 3, Failed: 1},
+		},
+		{
+			name:     "Finished when every report finished",
+			counts:   map[string]int{constant.FinishedStatus: 4},
+			expected: Progress{Status: constant.FinishedStatus, Total: 4, Finished: 4},
+		},
+		{
+			name:     "Error when any report failed or was cancelled",
+			counts:   map[string]int{constant.FinishedStatus: 2, constant.CancelledStatus: 1},
+			expected: Progress{Status: constant.ErrorStatus, Total: 3, Finished: 2, Cancelled: 1},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, tt.expected, NewProgress(tt.counts))
+		})
+	}
+}
+
+func TestBatchMongoDBModel_RoundTrip(t *testing.T) {
+	t.Parallel()
+
+	b, err := NewBatch(uuid.New(), uuid.New(), uuid.New(), nil)
+	require.NoError(t, err)
+
+	record := &BatchMongoDBModel{}
+	record.FromEntity(b)
+
+	assert.Equal(t, b, record.ToEntity())
+}
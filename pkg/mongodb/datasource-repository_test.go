@@ -606,6 +606,50 @@ func TestBuildFindOptions(t *testing.T) {
 	}
 }
 
+func TestBuildDistinctValuesPipeline(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		filter    bson.M
+		wantMatch bson.M
+	}{
+		{
+			name:      "null values are left out",
+			filter:    bson.M{},
+			wantMatch: bson.M{"id": bson.M{"$ne": nil}},
+		},
+		{
+			name:      "filters on other fields are kept",
+			filter:    bson.M{"status": "active"},
+			wantMatch: bson.M{"status": "active", "id": bson.M{"$ne": nil}},
+		},
+		{
+			name:   "a filter on the field is kept next to the null check",
+			filter: bson.M{"id": bson.M{"$in": []any{"a", "b"}}},
+			wantMatch: bson.M{"$and": bson.A{
+				bson.M{"id": bson.M{"$in": []any{"a", "b"}}},
+				bson.M{"id": bson.M{"$ne": nil}},
+			}},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			pipeline := buildDistinctValuesPipeline("id", tt.filter, 1001)
+
+			require.Len(t, pipeline, 4)
+			assert.Equal(t, bson.E{Key: "$match", Value: tt.wantMatch}, pipeline[0][0])
+			assert.Equal(t, bson.E{Key: "$group", Value: bson.M{"_id": "$id"}}, pipeline[1][0])
+			assert.Equal(t, bson.E{Key: "$sort", Value: bson.M{"_id": 1}}, pipeline[2][0])
+			assert.Equal(t, bson.E{Key: "$limit", Value: int64(1001)}, pipeline[3][0])
+		})
+	}
+}
+
 func TestConvertBsonValue_BsonD(t *testing.T) {
 	t.Parallel()
 
@@ -33,6 +33,7 @@ import (
 type Repository interface {
 	Query(ctx context.Context, collection string, fields []string, filter map[string][]any) ([]map[string]any, error)
 	QueryWithAdvancedFilters(ctx context.Context, collection string, fields []string, filter map[string]model.FilterCondition) ([]map[string]any, error)
+	QueryDistinctValues(ctx context.Context, collection string, field string, filter map[string]model.FilterCondition, limit int) ([]any, error)
 	GetDatabaseSchema(ctx context.Context) ([]CollectionSchema, error)
 	GetDatabaseSchemaForOrganization(ctx context.Context, organizationID string) ([]CollectionSchema, error)
 	CloseConnection(ctx context.Context) error
@@ -718,6 +719,88 @@ func (ds *ExternalDataSource) QueryWithAdvancedFilters(ctx context.Context, coll
 	return ds.processQueryResults(queryCtx, cursor, collection, logger)
 }
 
+// QueryDistinctValues returns the non-null values of a field of the documents matching the advanced filters,
+// in ascending order and at most limit of them. The values are grouped by the database, so no document is read
+// by the caller.
+func (ds *ExternalDataSource) QueryDistinctValues(ctx context.Context, collection string, field string, filter map[string]model.FilterCondition, limit int) ([]any, error) {
+	logger, tracer, reqId, _ := libCommons.NewTrackingFromContext(ctx)
+
+	logger.Infof("Querying distinct values of %s in %s collection", field, collection)
+
+	ctx, span := tracer.Start(ctx, "repository.datasource.query_distinct_values")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("app.request.request_id", reqId),
+	)
+
+	err := libOpentelemetry.SetSpanAttributesFromStruct(&span, "app.request.repository_filter", map[string]any{
+		"collection": collection,
+		"field":      field,
+		"filter":     filter,
+		"limit":      limit,
+	})
+	if err != nil {
+		libOpentelemetry.HandleSpanError(&span, "Failed to convert repository filter to JSON string", err)
+	}
+
+	client, err := ds.connection.GetDB(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	mongoFilter, err := ds.buildMongoFilter(filter)
+	if err != nil {
+		return nil, err
+	}
+
+	queryCtx, cancel := context.WithTimeout(ctx, constant.QueryTimeoutSlow)
+	defer cancel()
+
+	cursor, err := client.Database(ds.Database).Collection(collection).Aggregate(queryCtx, buildDistinctValuesPipeline(field, mongoFilter, limit))
+	if err != nil {
+		return nil, wrapQueryError(queryCtx, constant.QueryTimeoutSlow, collection, "mongodb distinct values query timeout after %v for collection %s: %w", err)
+	}
+
+	defer cursor.Close(queryCtx)
+
+	values := make([]any, 0)
+
+	for _, result := range decodeCursorResults(queryCtx, cursor, logger) {
+		values = append(values, result["_id"])
+	}
+
+	if err := cursor.Err(); err != nil {
+		return nil, wrapQueryError(queryCtx, constant.QueryTimeoutSlow, collection, "mongodb distinct values result iteration timeout after %v for collection %s: %w", err)
+	}
+
+	return values, nil
+}
+
+// buildDistinctValuesPipeline builds the aggregation of QueryDistinctValues. Documents without the field are
+// left out, so they never count against the limit.
+func buildDistinctValuesPipeline(field string, mongoFilter bson.M, limit int) mongo.Pipeline {
+	match := bson.M{}
+	for key, value := range mongoFilter {
+		match[key] = value
+	}
+
+	// A filter on the field itself is kept next to the null check
+	if condition, ok := match[field]; ok {
+		match["$and"] = bson.A{bson.M{field: condition}, bson.M{field: bson.M{"$ne": nil}}}
+		delete(match, field)
+	} else {
+		match[field] = bson.M{"$ne": nil}
+	}
+
+	return mongo.Pipeline{
+		{{Key: "$match", Value: match}},
+		{{Key: "$group", Value: bson.M{"_id": "$" + field}}},
+		{{Key: "$sort", Value: bson.M{"_id": 1}}},
+		{{Key: "$limit", Value: int64(limit)}},
+	}
+}
+
 // buildMongoFilter converts FilterCondition map to MongoDB filter format
 func (ds *ExternalDataSource) buildMongoFilter(filter map[string]model.FilterCondition) (bson.M, error) {
 	mongoFilter := bson.M{}
@@ -100,6 +100,21 @@ func (mr *MockRepositoryMockRecorder) Query(ctx, collection, fields, filter any)
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Query", reflect.TypeOf((*MockRepository)(nil).Query), ctx, collection, fields, filter)
 }
 
+// QueryDistinctValues mocks base method.
+func (m *MockRepository) QueryDistinctValues(ctx context.Context, collection, field string, filter map[string]model.FilterCondition, limit int) ([]any, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "QueryDistinctValues", ctx, collection, field, filter, limit)
+	ret0, _ := ret[0].([]any)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// QueryDistinctValues indicates an expected call of QueryDistinctValues.
+func (mr *MockRepositoryMockRecorder) QueryDistinctValues(ctx, collection, field, filter, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "QueryDistinctValues", reflect.TypeOf((*MockRepository)(nil).QueryDistinctValues), ctx, collection, field, filter, limit)
+}
+
 // QueryWithAdvancedFilters mocks base method.
 func (m *MockRepository) QueryWithAdvancedFilters(ctx context.Context, collection string, fields []string, filter map[string]model.FilterCondition) ([]map[string]any, error) {
 	m.ctrl.T.Helper()
@@ -0,0 +1,89 @@
+// Copyright (c) 2026 Lerian Studio. All rights reserved.
+// Use of this source code is governed by the Elastic License 2.0
+// that can be found in the LICENSE file.
+
+package outbox
+
+import (
+	"context"
+	"strings"
+
+	"github.com/LerianStudio/reporter/pkg/constant"
+
+	"github.com/LerianStudio/lib-commons/v2/commons"
+	libOpentelemetry "github.com/LerianStudio/lib-commons/v2/commons/opentelemetry"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// EnsureIndexes creates all indexes for the report outbox collection.
+func (om *OutboxMongoDBRepository) EnsureIndexes(ctx context.Context) error {
+	logger, tracer, reqId, _ := commons.NewTrackingFromContext(ctx)
+
+	ctx, span := tracer.Start(ctx, "repository.outbox.ensure_indexes")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("app.request.request_id", reqId),
+		attribute.String("app.request.collection", constant.MongoCollectionOutbox),
+	)
+
+	logger.Infof("Creating indexes for %s collection", constant.MongoCollectionOutbox)
+
+	db, err := om.connection.GetDB(ctx)
+	if err != nil {
+		libOpentelemetry.HandleSpanError(&span, "Failed to get database", err)
+		return err
+	}
+
+	coll := db.Database(strings.ToLower(om.Database)).Collection(strings.ToLower(constant.MongoCollectionOutbox))
+
+	indexes := []mongo.IndexModel{
+		{
+			Keys: bson.D{
+				{Key: "next_attempt_at", Value: 1},
+			},
+			Options: options.Index().
+				SetName("idx_outbox_pending").
+				SetPartialFilterExpression(bson.D{
+					{Key: "dispatched_at", Value: nil},
+					{Key: "abandoned_at", Value: nil},
+				}),
+		},
+
+		// Dispatched entries expire after OutboxRetention; pending and abandoned entries are kept
+		{
+			Keys: bson.D{
+				{Key: "dispatched_at", Value: 1},
+			},
+			Options: options.Index().
+				SetName("idx_outbox_dispatched_ttl").
+				SetExpireAfterSeconds(int32(constant.OutboxRetention.Seconds())),
+		},
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, constant.MongoIndexCreateTimeout)
+	defer cancel()
+
+	indexNames, err := coll.Indexes().CreateMany(ctx, indexes)
+	if err != nil {
+		// Check if error is due to indexes already existing
+		if strings.Contains(err.Error(), "IndexOptionsConflict") ||
+			strings.Contains(err.Error(), "already exists") {
+			logger.Infof("Indexes for %s already exist (detected during creation)", constant.MongoCollectionOutbox)
+			return nil
+		}
+
+		libOpentelemetry.HandleSpanError(&span, "Failed to create indexes", err)
+		logger.Errorf("Failed to create indexes for %s: %v", constant.MongoCollectionOutbox, err)
+
+		return err
+	}
+
+	logger.Infof("Successfully created %d indexes for %s collection: %v",
+		len(indexNames), constant.MongoCollectionOutbox, indexNames)
+
+	return nil
+}
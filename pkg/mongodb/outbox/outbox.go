@@ -0,0 +1,105 @@
+// Copyright (c) 2026 Lerian Studio. All rights reserved.
+// Use of this source code is governed by the Elastic License 2.0
+// that can be found in the LICENSE file.
+
+package outbox
+
+import (
+	"time"
+
+	"github.com/LerianStudio/reporter/pkg/model"
+
+	"github.com/LerianStudio/lib-commons/v2/commons"
+	"github.com/google/uuid"
+)
+
+// Entry is a report generation message waiting to be published to the broker. It is written in the
+// same transaction as its report, so a report is never stored without the message that generates it.
+type Entry struct {
+	ID         uuid.UUID
+	ReportID   uuid.UUID
+	Exchange   string
+	RoutingKey string
+	Message    model.ReportMessage
+
+	// Attempts is the number of failed publishes and LastError the reason of the last one.
+	Attempts  int
+	LastError string
+
+	CreatedAt     time.Time
+	NextAttemptAt time.Time
+
+	// LockedUntil reserves the entry for the publisher that claimed it.
+	LockedUntil *time.Time
+
+	// DispatchedAt is set once the message is published, AbandonedAt once OutboxMaxAttempts publishes failed.
+	DispatchedAt *time.Time
+	AbandonedAt  *time.Time
+}
+
+// NewEntry creates the outbox entry of a report message, claimed by its creator for lease
+// so it can be published right away without the relay publishing it concurrently.
+func NewEntry(message model.ReportMessage, exchange, routingKey string, now time.Time, lease time.Duration) *Entry {
+	lockedUntil := now.Add(lease)
+
+	return &Entry{
+		ID:            commons.GenerateUUIDv7(),
+		ReportID:      message.ReportID,
+		Exchange:      exchange,
+		RoutingKey:    routingKey,
+		Message:       message,
+		CreatedAt:     now,
+		NextAttemptAt: now,
+		LockedUntil:   &lockedUntil,
+	}
+}
+
+// EntryMongoDBModel represents the MongoDB model for an outbox entry.
+type EntryMongoDBModel struct {
+	ID            uuid.UUID           `bson:"_id"`
+	ReportID      uuid.UUID           `bson:"report_id"`
+	Exchange      string              `bson:"exchange"`
+	RoutingKey    string              `bson:"routing_key"`
+	Message       model.ReportMessage `bson:"message"`
+	Attempts      int                 `bson:"attempts"`
+	LastError     string              `bson:"last_error,omitempty"`
+	CreatedAt     time.Time           `bson:"created_at"`
+	NextAttemptAt time.Time           `bson:"next_attempt_at"`
+	LockedUntil   *time.Time          `bson:"locked_until"`
+	DispatchedAt  *time.Time          `bson:"dispatched_at"`
+	AbandonedAt   *time.Time          `bson:"abandoned_at"`
+}
+
+// FromEntity converts an Entry to EntryMongoDBModel.
+func (em *EntryMongoDBModel) FromEntity(e *Entry) {
+	em.ID = e.ID
+	em.ReportID = e.ReportID
+	em.Exchange = e.Exchange
+	em.RoutingKey = e.RoutingKey
+	em.Message = e.Message
+	em.Attempts = e.Attempts
+	em.LastError = e.LastError
+	em.CreatedAt = e.CreatedAt
+	em.NextAttemptAt = e.NextAttemptAt
+	em.LockedUntil = e.LockedUntil
+	em.DispatchedAt = e.DispatchedAt
+	em.AbandonedAt = e.AbandonedAt
+}
+
+// ToEntity converts EntryMongoDBModel to Entry.
+func (em *EntryMongoDBModel) ToEntity() *Entry {
+	return &Entry{
+		ID:            em.ID,
+		ReportID:      em.ReportID,
+		Exchange:      em.Exchange,
+		RoutingKey:    em.RoutingKey,
+		Message:       em.Message,
+		Attempts:      em.Attempts,
+		LastError:     em.LastError,
+		CreatedAt:     em.CreatedAt,
+		NextAttemptAt: em.NextAttemptAt,
+		LockedUntil:   em.LockedUntil,
+		DispatchedAt:  em.DispatchedAt,
+		AbandonedAt:   em.AbandonedAt,
+	}
+}
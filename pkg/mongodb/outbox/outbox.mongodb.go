@@ -0,0 +1,162 @@
+// Copyright (c) 2026 Lerian Studio. All rights reserved.
+// Use of this source code is governed by the Elastic License 2.0
+// that can be found in the LICENSE file.
+
+package outbox
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/LerianStudio/reporter/pkg/constant"
+
+	"github.com/LerianStudio/lib-commons/v2/commons"
+	libMongo "github.com/LerianStudio/lib-commons/v2/commons/mongo"
+	libOpentelemetry "github.com/LerianStudio/lib-commons/v2/commons/opentelemetry"
+	"github.com/google/uuid"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// Repository provides an interface for operations related to the report outbox collection in MongoDB.
+// Entries are created together with their report by the report repository.
+//
+//go:generate mockgen --destination=outbox.mongodb.mock.go --package=outbox --copyright_file=../../../COPYRIGHT . Repository
+type Repository interface {
+	Claim(ctx context.Context, now time.Time, lease time.Duration) (*Entry, error)
+	MarkDispatched(ctx context.Context, id uuid.UUID, dispatchedAt time.Time) error
+	MarkFailed(ctx context.Context, id uuid.UUID, lastError string, nextAttemptAt time.Time) error
+	MarkAbandoned(ctx context.Context, id uuid.UUID, lastError string, abandonedAt time.Time) error
+}
+
+// OutboxMongoDBRepository is a MongoDB-specific implementation of the outbox Repository.
+type OutboxMongoDBRepository struct {
+	connection *libMongo.MongoConnection
+	Database   string
+}
+
+// Compile-time interface satisfaction check.
+var _ Repository = (*OutboxMongoDBRepository)(nil)
+
+// NewOutboxMongoDBRepository returns a new instance of OutboxMongoDBRepository using the given MongoDB connection.
+func NewOutboxMongoDBRepository(mc *libMongo.MongoConnection) (*OutboxMongoDBRepository, error) {
+	r := &OutboxMongoDBRepository{
+		connection: mc,
+		Database:   mc.Database,
+	}
+	if _, err := r.connection.GetDB(context.Background()); err != nil {
+		return nil, fmt.Errorf("failed to connect to mongodb for report outbox: %w", err)
+	}
+
+	return r, nil
+}
+
+// Claim reserves for lease the pending entry due the longest, neither dispatched nor abandoned
+// and not claimed by another publisher. It returns mongo.ErrNoDocuments when no entry is due.
+func (om *OutboxMongoDBRepository) Claim(ctx context.Context, now time.Time, lease time.Duration) (*Entry, error) {
+	_, tracer, reqId, _ := commons.NewTrackingFromContext(ctx)
+
+	ctx, span := tracer.Start(ctx, "repository.outbox.claim")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("app.request.request_id", reqId),
+	)
+
+	db, err := om.connection.GetDB(ctx)
+	if err != nil {
+		libOpentelemetry.HandleSpanError(&span, "Failed to get database", err)
+		return nil, err
+	}
+
+	coll := db.Database(strings.ToLower(om.Database)).Collection(strings.ToLower(constant.MongoCollectionOutbox))
+
+	filter := bson.M{
+		"dispatched_at":   nil,
+		"abandoned_at":    nil,
+		"next_attempt_at": bson.M{"$lte": now},
+		"$or": bson.A{
+			bson.M{"locked_until": nil},
+			bson.M{"locked_until": bson.M{"$lte": now}},
+		},
+	}
+
+	update := bson.M{"$set": bson.M{"locked_until": now.Add(lease)}}
+
+	opts := options.FindOneAndUpdate().
+		SetSort(bson.D{{Key: "next_attempt_at", Value: 1}}).
+		SetReturnDocument(options.After)
+
+	var record EntryMongoDBModel
+
+	if err := coll.FindOneAndUpdate(ctx, filter, update, opts).Decode(&record); err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, err
+		}
+
+		libOpentelemetry.HandleSpanError(&span, "Failed to claim outbox entry", err)
+
+		return nil, err
+	}
+
+	return record.ToEntity(), nil
+}
+
+// MarkDispatched records that the message of an entry was published and releases it.
+func (om *OutboxMongoDBRepository) MarkDispatched(ctx context.Context, id uuid.UUID, dispatchedAt time.Time) error {
+	return om.update(ctx, "repository.outbox.mark_dispatched", id, bson.M{
+		"$set":   bson.M{"dispatched_at": dispatchedAt},
+		"$unset": bson.M{"locked_until": ""},
+	})
+}
+
+// MarkFailed records a failed publish of an entry and releases it until nextAttemptAt.
+func (om *OutboxMongoDBRepository) MarkFailed(ctx context.Context, id uuid.UUID, lastError string, nextAttemptAt time.Time) error {
+	return om.update(ctx, "repository.outbox.mark_failed", id, bson.M{
+		"$set":   bson.M{"last_error": lastError, "next_attempt_at": nextAttemptAt},
+		"$inc":   bson.M{"attempts": 1},
+		"$unset": bson.M{"locked_until": ""},
+	})
+}
+
+// MarkAbandoned records the last failed publish of an entry, which is no longer published.
+func (om *OutboxMongoDBRepository) MarkAbandoned(ctx context.Context, id uuid.UUID, lastError string, abandonedAt time.Time) error {
+	return om.update(ctx, "repository.outbox.mark_abandoned", id, bson.M{
+		"$set":   bson.M{"last_error": lastError, "abandoned_at": abandonedAt},
+		"$inc":   bson.M{"attempts": 1},
+		"$unset": bson.M{"locked_until": ""},
+	})
+}
+
+// update applies an update to a single outbox entry.
+func (om *OutboxMongoDBRepository) update(ctx context.Context, spanName string, id uuid.UUID, update bson.M) error {
+	_, tracer, reqId, _ := commons.NewTrackingFromContext(ctx)
+
+	ctx, span := tracer.Start(ctx, spanName)
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("app.request.request_id", reqId),
+		attribute.String("app.request.outbox_id", id.String()),
+	)
+
+	db, err := om.connection.GetDB(ctx)
+	if err != nil {
+		libOpentelemetry.HandleSpanError(&span, "Failed to get database", err)
+		return err
+	}
+
+	coll := db.Database(strings.ToLower(om.Database)).Collection(strings.ToLower(constant.MongoCollectionOutbox))
+
+	if _, err := coll.UpdateOne(ctx, bson.M{"_id": id}, update); err != nil {
+		libOpentelemetry.HandleSpanError(&span, "Failed to update outbox entry", err)
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,105 @@
+// // Copyright (c) 2026 Lerian Studio. All rights reserved.
+// // Use of this source code is governed by the Elastic License 2.0
+// // that can be found in the LICENSE file.
+//
+
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/LerianStudio/reporter/pkg/mongodb/outbox (interfaces: Repository)
+//
+// Generated by this command:
+//
+//	mockgen --destination=outbox.mongodb.mock.go --package=outbox --copyright_file=../../../COPYRIGHT . Repository
+//
+
+// Package outbox is a generated GoMock package.
+package outbox
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	uuid "github.com/google/uuid"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockRepository is a mock of Repository interface.
+type MockRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockRepositoryMockRecorder is the mock recorder for MockRepository.
+type MockRepositoryMockRecorder struct {
+	mock *MockRepository
+}
+
+// NewMockRepository creates a new mock instance.
+func NewMockRepository(ctrl *gomock.Controller) *MockRepository {
+	mock := &MockRepository{ctrl: ctrl}
+	mock.recorder = &MockRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRepository) EXPECT() *MockRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Claim mocks base method.
+func (m *MockRepository) Claim(ctx context.Context, now time.Time, lease time.Duration) (*Entry, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Claim", ctx, now, lease)
+	ret0, _ := ret[0].(*Entry)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Claim indicates an expected call of Claim.
+func (mr *MockRepositoryMockRecorder) Claim(ctx, now, lease any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Claim", reflect.TypeOf((*MockRepository)(nil).Claim), ctx, now, lease)
+}
+
+// MarkAbandoned mocks base method.
+func (m *MockRepository) MarkAbandoned(ctx context.Context, id uuid.UUID, lastError string, abandonedAt time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkAbandoned", ctx, id, lastError, abandonedAt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MarkAbandoned indicates an expected call of MarkAbandoned.
+func (mr *MockRepositoryMockRecorder) MarkAbandoned(ctx, id, lastError, abandonedAt any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkAbandoned", reflect.TypeOf((*MockRepository)(nil).MarkAbandoned), ctx, id, lastError, abandonedAt)
+}
+
+// MarkDispatched mocks base method.
+func (m *MockRepository) MarkDispatched(ctx context.Context, id uuid.UUID, dispatchedAt time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkDispatched", ctx, id, dispatchedAt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MarkDispatched indicates an expected call of MarkDispatched.
+func (mr *MockRepositoryMockRecorder) MarkDispatched(ctx, id, dispatchedAt any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkDispatched", reflect.TypeOf((*MockRepository)(nil).MarkDispatched), ctx, id, dispatchedAt)
+}
+
+// MarkFailed mocks base method.
+func (m *MockRepository) MarkFailed(ctx context.Context, id uuid.UUID, lastError string, nextAttemptAt time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkFailed", ctx, id, lastError, nextAttemptAt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MarkFailed indicates an expected call of MarkFailed.
+func (mr *MockRepositoryMockRecorder) MarkFailed(ctx, id, lastError, nextAttemptAt any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkFailed", reflect.TypeOf((*MockRepository)(nil).MarkFailed), ctx, id, lastError, nextAttemptAt)
+}
@@ -0,0 +1,52 @@
+// Copyright (c) 2026 Lerian Studio. All rights reserved.
+// Use of this source code is governed by the Elastic License 2.0
+// that can be found in the LICENSE file.
+
+package outbox
+
+import (
+	"testing"
+	"time"
+
+	"github.com/LerianStudio/reporter/pkg/model"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewEntry(t *testing.T) {
+	t.Parallel()
+
+	now := time.Now()
+	message := model.ReportMessage{ReportID: uuid.New(), TemplateID: uuid.New(), OutputFormat: "csv"}
+
+	entry := NewEntry(message, "reporter.generate-report.exchange", "reporter.generate-report.key", now, time.Minute)
+
+	assert.NotEqual(t, uuid.Nil, entry.ID)
+	assert.Equal(t, message.ReportID, entry.ReportID)
+	assert.Equal(t, message, entry.Message)
+	assert.Equal(t, "reporter.generate-report.exchange", entry.Exchange)
+	assert.Equal(t, "reporter.generate-report.key", entry.RoutingKey)
+	assert.Equal(t, now, entry.NextAttemptAt)
+	require.NotNil(t, entry.LockedUntil)
+	assert.Equal(t, now.Add(time.Minute), *entry.LockedUntil)
+	assert.Nil(t, entry.DispatchedAt)
+	assert.Nil(t, entry.AbandonedAt)
+}
+
+func TestRoundTrip_FromEntity_ToEntity(t *testing.T) {
+	t.Parallel()
+
+	now := time.Now()
+
+	entry := NewEntry(model.ReportMessage{ReportID: uuid.New()}, "exchange", "key", now, time.Minute)
+	entry.Attempts = 3
+	entry.LastError = "channel closed"
+	entry.DispatchedAt = &now
+
+	record := &EntryMongoDBModel{}
+	record.FromEntity(entry)
+
+	assert.Equal(t, entry, record.ToEntity())
+}
@@ -101,6 +101,81 @@ func (rm *ReportMongoDBRepository) EnsureIndexes(ctx context.Context) error {
 					{Key: "deleted_at", Value: nil},
 				}),
 		},
+
+		{
+			Keys: bson.D{
+				{Key: constant.MongoFieldOrganizationID, Value: 1},
+				{Key: "deleted_at", Value: 1},
+				{Key: "created_at", Value: -1},
+			},
+			Options: options.Index().
+				SetName("idx_report_org_list").
+				SetPartialFilterExpression(bson.D{
+					{Key: "deleted_at", Value: nil},
+				}),
+		},
+
+		{
+			Keys: bson.D{
+				{Key: constant.MongoFieldOrganizationID, Value: 1},
+				{Key: "status", Value: 1},
+				{Key: "template_id", Value: 1},
+				{Key: "created_at", Value: -1},
+			},
+			Options: options.Index().
+				SetName("idx_report_org_complete").
+				SetPartialFilterExpression(bson.D{
+					{Key: "deleted_at", Value: nil},
+				}),
+		},
+
+		{
+			Keys: bson.D{
+				{Key: "batch_id", Value: 1},
+				{Key: "status", Value: 1},
+			},
+			Options: options.Index().
+				SetName("idx_report_batch").
+				SetPartialFilterExpression(bson.D{
+					{Key: "batch_id", Value: bson.D{{Key: "$exists", Value: true}}},
+				}),
+		},
+
+		{
+			Keys: bson.D{
+				{Key: "status", Value: 1},
+				{Key: "completed_at", Value: 1},
+			},
+			Options: options.Index().
+				SetName("idx_report_retention").
+				SetPartialFilterExpression(bson.D{
+					{Key: "deleted_at", Value: nil},
+				}),
+		},
+
+		{
+			Keys: bson.D{
+				{Key: "metadata." + constant.ReportFingerprintMetadataKey, Value: 1},
+				{Key: constant.MongoFieldOrganizationID, Value: 1},
+				{Key: "completed_at", Value: -1},
+			},
+			Options: options.Index().
+				SetName("idx_report_fingerprint").
+				SetPartialFilterExpression(bson.D{
+					{Key: "metadata." + constant.ReportFingerprintMetadataKey, Value: bson.D{{Key: "$exists", Value: true}}},
+				}),
+		},
+
+		{
+			Keys: bson.D{
+				{Key: "deleted_at", Value: 1},
+			},
+			Options: options.Index().
+				SetName("idx_report_pending_files").
+				SetPartialFilterExpression(bson.D{
+					{Key: constant.MongoFieldPendingFiles, Value: bson.D{{Key: "$exists", Value: true}}},
+				}),
+		},
 	}
 
 	ctx, cancel := context.WithTimeout(ctx, constant.MongoIndexCreateTimeout)
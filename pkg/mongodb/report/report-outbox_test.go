@@ -0,0 +1,65 @@
+// Copyright (c) 2026 Lerian Studio. All rights reserved.
+// Use of this source code is governed by the Elastic License 2.0
+// that can be found in the LICENSE file.
+
+package report
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/LerianStudio/reporter/pkg/constant"
+	"github.com/LerianStudio/reporter/pkg/model"
+	"github.com/LerianStudio/reporter/pkg/mongodb/outbox"
+
+	libMongo "github.com/LerianStudio/lib-commons/v2/commons/mongo"
+	"github.com/LerianStudio/lib-commons/v2/commons/zap"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/mongo/integration/mtest"
+)
+
+func TestReportMongoDBRepository_CreateWithOutbox_StandaloneFallback(t *testing.T) {
+	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
+
+	mt.Run("inserts without a transaction once the server rejects transactions", func(mt *mtest.T) {
+		repo := &ReportMongoDBRepository{
+			connection: &libMongo.MongoConnection{
+				DB:       mt.Client,
+				Database: mt.DB.Name(),
+				Logger:   zap.InitializeLogger(),
+			},
+			Database: mt.DB.Name(),
+		}
+
+		reportModel, err := NewReport(uuid.New(), uuid.New(), uuid.Nil, constant.ProcessingStatus, nil)
+		require.NoError(mt, err)
+
+		entry := outbox.NewEntry(model.ReportMessage{ReportID: reportModel.ID}, "exchange", "key", time.Now(), time.Minute)
+
+		mt.AddMockResponses(
+			mtest.CreateCommandErrorResponse(mtest.CommandError{
+				Code:    20,
+				Name:    "IllegalOperation",
+				Message: "Transaction numbers are only allowed on a replica set member or mongos",
+			}),
+			// abortTransaction
+			mtest.CreateSuccessResponse(),
+			mtest.CreateSuccessResponse(),
+			mtest.CreateSuccessResponse(),
+		)
+
+		created, err := repo.CreateWithOutbox(context.Background(), reportModel, entry)
+		require.NoError(mt, err)
+		assert.Equal(mt, reportModel.ID, created.ID)
+		assert.True(mt, repo.transactionsUnsupported.Load())
+
+		// Later reports skip the transaction attempt
+		mt.AddMockResponses(mtest.CreateSuccessResponse(), mtest.CreateSuccessResponse())
+
+		_, err = repo.CreateWithOutbox(context.Background(), reportModel, entry)
+		require.NoError(mt, err)
+	})
+}
@@ -59,7 +59,7 @@ func TestRepository_FindByID(t *testing.T) {
 			id:   reportID,
 			setupMock: func(m *MockRepository) {
 				m.EXPECT().
-					FindByID(gomock.Any(), reportID).
+					FindByID(gomock.Any(), reportID, gomock.Any()).
 					Return(validReport, nil).
 					Times(1)
 			},
@@ -71,7 +71,7 @@ func TestRepository_FindByID(t *testing.T) {
 			id:   uuid.New(),
 			setupMock: func(m *MockRepository) {
 				m.EXPECT().
-					FindByID(gomock.Any(), gomock.Any()).
+					FindByID(gomock.Any(), gomock.Any(), gomock.Any()).
 					Return(nil, errNotFound).
 					Times(1)
 			},
@@ -84,7 +84,7 @@ func TestRepository_FindByID(t *testing.T) {
 			id:   reportID,
 			setupMock: func(m *MockRepository) {
 				m.EXPECT().
-					FindByID(gomock.Any(), reportID).
+					FindByID(gomock.Any(), reportID, gomock.Any()).
 					Return(nil, errGeneric).
 					Times(1)
 			},
@@ -103,7 +103,7 @@ func TestRepository_FindByID(t *testing.T) {
 			mockRepo := NewMockRepository(ctrl)
 			tt.setupMock(mockRepo)
 
-			got, err := mockRepo.FindByID(context.Background(), tt.id)
+			got, err := mockRepo.FindByID(context.Background(), tt.id, uuid.Nil)
 
 			if tt.wantErr {
 				require.Error(t, err)
@@ -162,7 +162,7 @@ func TestRepository_FindList(t *testing.T) {
 			},
 			setupMock: func(m *MockRepository) {
 				m.EXPECT().
-					FindList(gomock.Any(), http.QueryHeader{Limit: 10, Page: 1}).
+					FindList(gomock.Any(), http.QueryHeader{Limit: 10, Page: 1}, gomock.Any()).
 					Return([]*Report{report1, report2}, nil).
 					Times(1)
 			},
@@ -178,7 +178,7 @@ func TestRepository_FindList(t *testing.T) {
 			},
 			setupMock: func(m *MockRepository) {
 				m.EXPECT().
-					FindList(gomock.Any(), http.QueryHeader{Limit: 10, Page: 1, Status: "nonexistent"}).
+					FindList(gomock.Any(), http.QueryHeader{Limit: 10, Page: 1, Status: "nonexistent"}, gomock.Any()).
 					Return([]*Report{}, nil).
 					Times(1)
 			},
@@ -194,7 +194,7 @@ func TestRepository_FindList(t *testing.T) {
 			},
 			setupMock: func(m *MockRepository) {
 				m.EXPECT().
-					FindList(gomock.Any(), http.QueryHeader{Limit: 10, Page: 1, Status: constant.FinishedStatus}).
+					FindList(gomock.Any(), http.QueryHeader{Limit: 10, Page: 1, Status: constant.FinishedStatus}, gomock.Any()).
 					Return([]*Report{report1}, nil).
 					Times(1)
 			},
@@ -210,7 +210,7 @@ func TestRepository_FindList(t *testing.T) {
 			},
 			setupMock: func(m *MockRepository) {
 				m.EXPECT().
-					FindList(gomock.Any(), http.QueryHeader{Limit: 10, Page: 1, TemplateID: templateID}).
+					FindList(gomock.Any(), http.QueryHeader{Limit: 10, Page: 1, TemplateID: templateID}, gomock.Any()).
 					Return([]*Report{report1, report2}, nil).
 					Times(1)
 			},
@@ -226,7 +226,7 @@ func TestRepository_FindList(t *testing.T) {
 			},
 			setupMock: func(m *MockRepository) {
 				m.EXPECT().
-					FindList(gomock.Any(), gomock.Any()).
+					FindList(gomock.Any(), gomock.Any(), gomock.Any()).
 					Return([]*Report{report1}, nil).
 					Times(1)
 			},
@@ -241,7 +241,7 @@ func TestRepository_FindList(t *testing.T) {
 			},
 			setupMock: func(m *MockRepository) {
 				m.EXPECT().
-					FindList(gomock.Any(), http.QueryHeader{Limit: 10, Page: 1}).
+					FindList(gomock.Any(), http.QueryHeader{Limit: 10, Page: 1}, gomock.Any()).
 					Return(nil, errGeneric).
 					Times(1)
 			},
@@ -260,7 +260,7 @@ func TestRepository_FindList(t *testing.T) {
 			mockRepo := NewMockRepository(ctrl)
 			tt.setupMock(mockRepo)
 
-			got, err := mockRepo.FindList(context.Background(), tt.filters)
+			got, err := mockRepo.FindList(context.Background(), tt.filters, uuid.Nil)
 
 			if tt.wantErr {
 				require.Error(t, err)
@@ -557,6 +557,73 @@ func TestRepository_UpdateReportStatusById(t *testing.T) {
 	}
 }
 
+func TestRepository_TransitionReportStatus(t *testing.T) {
+	t.Parallel()
+
+	now := time.Now().Truncate(time.Millisecond)
+	reportID := uuid.New()
+	from := []string{constant.ProcessingStatus}
+
+	tests := []struct {
+		name      string
+		setupMock func(m *MockRepository)
+		want      bool
+		wantErr   bool
+	}{
+		{
+			name: "success - report transitioned",
+			setupMock: func(m *MockRepository) {
+				m.EXPECT().
+					TransitionReportStatus(gomock.Any(), reportID, from, constant.CancelledStatus, now, nil).
+					Return(true, nil).
+					Times(1)
+			},
+			want: true,
+		},
+		{
+			name: "success - report no longer in a source status",
+			setupMock: func(m *MockRepository) {
+				m.EXPECT().
+					TransitionReportStatus(gomock.Any(), reportID, from, constant.CancelledStatus, now, nil).
+					Return(false, nil).
+					Times(1)
+			},
+			want: false,
+		},
+		{
+			name: "error - database failure",
+			setupMock: func(m *MockRepository) {
+				m.EXPECT().
+					TransitionReportStatus(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(false, errors.New("connection refused")).
+					Times(1)
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			mockRepo := NewMockRepository(ctrl)
+			tt.setupMock(mockRepo)
+
+			got, err := mockRepo.TransitionReportStatus(context.Background(), reportID, from, constant.CancelledStatus, now, nil)
+			if tt.wantErr {
+				require.Error(t, err)
+
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
 // ---------------------------------------------------------------------------
 // Domain entity tests: NewReport constructor
 // ---------------------------------------------------------------------------
@@ -633,7 +700,7 @@ func TestNewReport_Validation(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			t.Parallel()
 
-			got, err := NewReport(tt.id, tt.templateID, tt.status, tt.filters)
+			got, err := NewReport(tt.id, tt.templateID, uuid.Nil, tt.status, tt.filters)
 
 			if tt.wantErr {
 				require.Error(t, err)
@@ -718,7 +785,7 @@ func TestReconstructReport_BypassesValidation(t *testing.T) {
 			t.Parallel()
 
 			got := ReconstructReport(
-				tt.id, tt.templateID, tt.status, tt.filters,
+				tt.id, tt.templateID, uuid.Nil, tt.status, tt.filters,
 				tt.metadata, tt.completedAt, tt.createdAt, tt.updatedAt, tt.deletedAt,
 			)
 
@@ -947,6 +1014,11 @@ func TestRoundTrip_FromEntity_ToEntityFindByID(t *testing.T) {
 		Status:     constant.ProcessingStatus,
 		Filters:    filters,
 		Metadata:   map[string]any{"source": "api"},
+		Attempts: []ReportAttempt{
+			{Status: constant.ErrorStatus, Metadata: map[string]any{"error": "timeout"}, RetriedAt: time.Now()},
+		},
+		Message:   &model.ReportMessage{TemplateID: templateID, ReportID: id, OutputFormat: "csv", Locale: "pt-BR"},
+		LegalHold: true,
 	}
 
 	// Step 1: Convert entity to MongoDB model
@@ -964,6 +1036,9 @@ func TestRoundTrip_FromEntity_ToEntityFindByID(t *testing.T) {
 	assert.Equal(t, original.Status, roundTripped.Status, "Status must survive round-trip")
 	assert.Equal(t, original.Filters, roundTripped.Filters, "Filters must survive round-trip")
 	assert.Equal(t, original.Metadata, roundTripped.Metadata, "Metadata must survive round-trip")
+	assert.Equal(t, original.Attempts, roundTripped.Attempts, "Attempts must survive round-trip")
+	assert.Equal(t, original.Message, roundTripped.Message, "Message must survive round-trip")
+	assert.True(t, roundTripped.LegalHold, "LegalHold must survive round-trip")
 
 	// Timestamps are reset by FromEntity, so we only check they are non-zero
 	assert.False(t, roundTripped.CreatedAt.IsZero(), "CreatedAt must be set after round-trip")
@@ -18,15 +18,74 @@ import (
 // Public fields are required for JSON serialization (json tags) and Swagger documentation.
 // This is a documented deviation from Ring's private-field pattern; use NewReport() for programmatic creation.
 type Report struct {
-	ID          uuid.UUID                                              `json:"id" example:"00000000-0000-0000-0000-000000000000"`
-	TemplateID  uuid.UUID                                              `json:"templateId" example:"00000000-0000-0000-0000-000000000000"`
-	Filters     map[string]map[string]map[string]model.FilterCondition `json:"filters"`
-	Status      string                                                 `json:"status" example:"processing"`
-	Metadata    map[string]any                                         `json:"metadata"`
-	CompletedAt *time.Time                                             `json:"completedAt"`
-	CreatedAt   time.Time                                              `json:"createdAt"`
-	UpdatedAt   time.Time                                              `json:"updatedAt"`
-	DeletedAt   *time.Time                                             `json:"deletedAt"`
+	ID             uuid.UUID                                              `json:"id" example:"00000000-0000-0000-0000-000000000000"`
+	TemplateID     uuid.UUID                                              `json:"templateId" example:"00000000-0000-0000-0000-000000000000"`
+	OrganizationID uuid.UUID                                              `json:"organizationId" example:"00000000-0000-0000-0000-000000000000"`
+	Filters        map[string]map[string]map[string]model.FilterCondition `json:"filters"`
+	Status         string                                                 `json:"status" example:"processing"`
+	Metadata       map[string]any                                         `json:"metadata"`
+	CompletedAt    *time.Time                                             `json:"completedAt"`
+	CreatedAt      time.Time                                              `json:"createdAt"`
+	UpdatedAt      time.Time                                              `json:"updatedAt"`
+	DeletedAt      *time.Time                                             `json:"deletedAt"`
+
+	// BatchID is the report batch the report was created by. uuid.Nil for reports created on their own.
+	BatchID uuid.UUID `json:"batchId,omitempty" example:"00000000-0000-0000-0000-000000000000"`
+
+	// Attempts are the previous generation attempts of a retried report, oldest first.
+	Attempts []ReportAttempt `json:"attempts,omitempty"`
+
+	// Message is the message the report was queued with, republished when the report is retried.
+	Message *model.ReportMessage `json:"-"`
+
+	// LegalHold exempts the report from deletion, by request or by its retention policy, until it is released.
+	LegalHold bool `json:"legalHold" example:"false"`
+
+	// HeartbeatAt is the last time a worker generating the report reported it was still alive.
+	// Nil until a worker picks the report up, and cleared when the report is requeued.
+	HeartbeatAt *time.Time `json:"heartbeatAt,omitempty"`
+
+	// PendingFiles are the storage files of a deleted report not deleted yet, retried by the retention purge.
+	PendingFiles []string `json:"-"`
+}
+
+// ReportAttempt records how a previous generation attempt of a retried report ended.
+type ReportAttempt struct {
+	Status      string         `json:"status" bson:"status" example:"Error"`
+	Metadata    map[string]any `json:"metadata,omitempty" bson:"metadata"`
+	CompletedAt *time.Time     `json:"completedAt" bson:"completed_at"`
+	RetriedAt   time.Time      `json:"retriedAt" bson:"retried_at"`
+}
+
+// StatusQuery selects the reports of an organization in the given statuses, optionally of a single
+// template, created in a time window and last updated before a given time. Zero values match any template,
+// creation or update time. StaleBefore selects the reports whose last worker heartbeat, or last update when
+// no worker reported one, is before it. AllOrganizations ignores OrganizationID and selects the reports of every tenant.
+// A BatchID selects only the reports of that report batch.
+type StatusQuery struct {
+	OrganizationID   uuid.UUID
+	AllOrganizations bool
+	Statuses         []string
+	TemplateID       uuid.UUID
+	BatchID          uuid.UUID
+	CreatedFrom      time.Time
+	CreatedTo        time.Time
+	UpdatedBefore    time.Time
+	StaleBefore      time.Time
+	Limit            int64
+}
+
+// RetentionQuery selects the reports past their retention: reports Finished, in Error or Cancelled that completed
+// before CompletedBefore and are neither deleted nor under legal hold. TemplateIDs and OrganizationIDs restrict the
+// reports to those templates and tenants when not empty; the exclusions leave out the templates and tenants
+// governed by a more specific retention rule.
+type RetentionQuery struct {
+	TemplateIDs            []uuid.UUID
+	OrganizationIDs        []uuid.UUID
+	ExcludeTemplateIDs     []uuid.UUID
+	ExcludeOrganizationIDs []uuid.UUID
+	CompletedBefore        time.Time
+	Limit                  int64
 }
 
 // NewReport creates a new Report entity with invariant validation.
@@ -35,6 +94,7 @@ type Report struct {
 // Parameters:
 //   - id: The report UUID (must not be uuid.Nil)
 //   - templateID: The template UUID (must not be uuid.Nil)
+//   - organizationID: The owning organization UUID (uuid.Nil is the default tenant)
 //   - status: The report status (must not be empty)
 //   - filters: Optional filter conditions for report generation (can be nil)
 //
@@ -42,7 +102,7 @@ type Report struct {
 //   - *Report: A validated Report entity
 //   - error: Wrapped ErrMissingRequiredFields if any invariant is violated
 func NewReport(
-	id, templateID uuid.UUID,
+	id, templateID, organizationID uuid.UUID,
 	status string,
 	filters map[string]map[string]map[string]model.FilterCondition,
 ) (*Report, error) {
@@ -61,19 +121,20 @@ func NewReport(
 	now := time.Now()
 
 	return &Report{
-		ID:         id,
-		TemplateID: templateID,
-		Status:     status,
-		Filters:    filters,
-		CreatedAt:  now,
-		UpdatedAt:  now,
+		ID:             id,
+		TemplateID:     templateID,
+		OrganizationID: organizationID,
+		Status:         status,
+		Filters:        filters,
+		CreatedAt:      now,
+		UpdatedAt:      now,
 	}, nil
 }
 
 // ReconstructReport creates a Report from persisted data without validation.
 // Used only for database hydration where data integrity is already ensured.
 func ReconstructReport(
-	id, templateID uuid.UUID,
+	id, templateID, organizationID uuid.UUID,
 	status string,
 	filters map[string]map[string]map[string]model.FilterCondition,
 	metadata map[string]any,
@@ -82,39 +143,61 @@ func ReconstructReport(
 	deletedAt *time.Time,
 ) *Report {
 	return &Report{
-		ID:          id,
-		TemplateID:  templateID,
-		Status:      status,
-		Filters:     filters,
-		Metadata:    metadata,
-		CompletedAt: completedAt,
-		CreatedAt:   createdAt,
-		UpdatedAt:   updatedAt,
-		DeletedAt:   deletedAt,
+		ID:             id,
+		TemplateID:     templateID,
+		OrganizationID: organizationID,
+		Status:         status,
+		Filters:        filters,
+		Metadata:       metadata,
+		CompletedAt:    completedAt,
+		CreatedAt:      createdAt,
+		UpdatedAt:      updatedAt,
+		DeletedAt:      deletedAt,
 	}
 }
 
 // ReportMongoDBModel represents the MongoDB model for a report
 type ReportMongoDBModel struct {
-	ID          uuid.UUID                                              `bson:"_id"`
-	TemplateID  uuid.UUID                                              `bson:"template_id"`
-	Status      string                                                 `bson:"status"`
-	Filters     map[string]map[string]map[string]model.FilterCondition `bson:"filters"`
-	Metadata    map[string]any                                         `bson:"metadata"`
-	CompletedAt *time.Time                                             `bson:"completed_at"`
-	CreatedAt   time.Time                                              `bson:"created_at"`
-	UpdatedAt   time.Time                                              `bson:"updated_at"`
-	DeletedAt   *time.Time                                             `bson:"deleted_at"`
+	ID             uuid.UUID                                              `bson:"_id"`
+	TemplateID     uuid.UUID                                              `bson:"template_id"`
+	OrganizationID uuid.UUID                                              `bson:"organization_id"`
+	Status         string                                                 `bson:"status"`
+	Filters        map[string]map[string]map[string]model.FilterCondition `bson:"filters"`
+	Metadata       map[string]any                                         `bson:"metadata"`
+	CompletedAt    *time.Time                                             `bson:"completed_at"`
+	CreatedAt      time.Time                                              `bson:"created_at"`
+	UpdatedAt      time.Time                                              `bson:"updated_at"`
+	DeletedAt      *time.Time                                             `bson:"deleted_at"`
+	BatchID        *uuid.UUID                                             `bson:"batch_id,omitempty"`
+	Attempts       []ReportAttempt                                        `bson:"attempts,omitempty"`
+	Message        *model.ReportMessage                                   `bson:"message,omitempty"`
+	LegalHold      bool                                                   `bson:"legal_hold,omitempty"`
+	HeartbeatAt    *time.Time                                             `bson:"heartbeat_at,omitempty"`
+	PendingFiles   []string                                               `bson:"pending_files,omitempty"`
 }
 
 // ToEntity converts ReportMongoDBModel to Report using ReconstructReport.
 func (rm *ReportMongoDBModel) ToEntity(filters map[string]map[string]map[string]model.FilterCondition) *Report {
-	return ReconstructReport(rm.ID, rm.TemplateID, rm.Status, filters, nil, rm.CompletedAt, rm.CreatedAt, rm.UpdatedAt, rm.DeletedAt)
+	report := ReconstructReport(rm.ID, rm.TemplateID, rm.OrganizationID, rm.Status, filters, nil, rm.CompletedAt, rm.CreatedAt, rm.UpdatedAt, rm.DeletedAt)
+	report.LegalHold = rm.LegalHold
+
+	return report
 }
 
 // ToEntityFindByID converts ReportMongoDBModel to Report using ReconstructReport.
 func (rm *ReportMongoDBModel) ToEntityFindByID() *Report {
-	return ReconstructReport(rm.ID, rm.TemplateID, rm.Status, rm.Filters, rm.Metadata, rm.CompletedAt, rm.CreatedAt, rm.UpdatedAt, rm.DeletedAt)
+	report := ReconstructReport(rm.ID, rm.TemplateID, rm.OrganizationID, rm.Status, rm.Filters, rm.Metadata, rm.CompletedAt, rm.CreatedAt, rm.UpdatedAt, rm.DeletedAt)
+	report.Attempts = rm.Attempts
+	report.Message = rm.Message
+	report.LegalHold = rm.LegalHold
+	report.HeartbeatAt = rm.HeartbeatAt
+	report.PendingFiles = rm.PendingFiles
+
+	if rm.BatchID != nil {
+		report.BatchID = *rm.BatchID
+	}
+
+	return report
 }
 
 // FromEntity converts Report to ReportMongoDBModel
@@ -122,9 +205,20 @@ func (rm *ReportMongoDBModel) FromEntity(r *Report) error {
 	dateNow := time.Now()
 	rm.ID = r.ID
 	rm.TemplateID = r.TemplateID
+	rm.OrganizationID = r.OrganizationID
 	rm.Metadata = r.Metadata
 	rm.Status = r.Status
 	rm.Filters = r.Filters
+	rm.Attempts = r.Attempts
+	rm.Message = r.Message
+	rm.LegalHold = r.LegalHold
+	rm.BatchID = nil
+
+	if r.BatchID != uuid.Nil {
+		batchID := r.BatchID
+		rm.BatchID = &batchID
+	}
+
 	rm.CompletedAt = r.CompletedAt
 	rm.CreatedAt = dateNow
 	rm.UpdatedAt = dateNow
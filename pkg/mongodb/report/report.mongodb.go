@@ -6,11 +6,16 @@ package report
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"strings"
+	"sync/atomic"
 	"time"
 
 	"github.com/LerianStudio/reporter/pkg/constant"
+	"github.com/LerianStudio/reporter/pkg/model"
+	"github.com/LerianStudio/reporter/pkg/mongodb"
+	"github.com/LerianStudio/reporter/pkg/mongodb/outbox"
 	"github.com/LerianStudio/reporter/pkg/net/http"
 
 	"github.com/LerianStudio/lib-commons/v2/commons"
@@ -28,15 +33,34 @@ import (
 //go:generate mockgen --destination=report.mongodb.mock.go --package=report --copyright_file=../../../COPYRIGHT . Repository
 type Repository interface {
 	UpdateReportStatusById(ctx context.Context, status string, id uuid.UUID, completedAt time.Time, metadata map[string]any) error
+	TransitionReportStatus(ctx context.Context, id uuid.UUID, from []string, to string, completedAt time.Time, metadata map[string]any) (bool, error)
+	ResetForRetry(ctx context.Context, id, organizationID uuid.UUID, from []string, message *model.ReportMessage, retriedAt time.Time) (*Report, error)
+	ResetForRetryWithOutbox(ctx context.Context, id, organizationID uuid.UUID, from []string, message *model.ReportMessage, retriedAt time.Time, entry *outbox.Entry) (*Report, error)
+	RecordHeartbeat(ctx context.Context, id, organizationID uuid.UUID, at time.Time) error
+	FindByStatus(ctx context.Context, query StatusQuery) ([]*Report, error)
 	Create(ctx context.Context, record *Report) (*Report, error)
-	FindByID(ctx context.Context, id uuid.UUID) (*Report, error)
-	FindList(ctx context.Context, filters http.QueryHeader) ([]*Report, error)
+	CreateWithOutbox(ctx context.Context, record *Report, entry *outbox.Entry) (*Report, error)
+	CreateManyWithOutbox(ctx context.Context, records []*Report, entries []*outbox.Entry) error
+	CountByBatch(ctx context.Context, batchID, organizationID uuid.UUID) (map[string]int, error)
+	CancelByBatch(ctx context.Context, batchID, organizationID uuid.UUID, cancelledAt time.Time) (int64, error)
+	FindByID(ctx context.Context, id, organizationID uuid.UUID) (*Report, error)
+	FindDeletedByID(ctx context.Context, id, organizationID uuid.UUID) (*Report, error)
+	FindFinishedByFingerprint(ctx context.Context, fingerprint string, organizationID uuid.UUID) (*Report, error)
+	FindExpired(ctx context.Context, query RetentionQuery) ([]*Report, error)
+	SoftDelete(ctx context.Context, id, organizationID uuid.UUID, deletedAt time.Time, pendingFiles []string) (bool, error)
+	FindPendingFiles(ctx context.Context, limit int64) ([]*Report, error)
+	ClearPendingFiles(ctx context.Context, id, organizationID uuid.UUID) error
+	SetLegalHold(ctx context.Context, id, organizationID uuid.UUID, legalHold bool) (*Report, error)
+	FindList(ctx context.Context, filters http.QueryHeader, organizationID uuid.UUID) ([]*Report, error)
 }
 
 // ReportMongoDBRepository is a MongoDB-specific implementation of the ReportRepository.
 type ReportMongoDBRepository struct {
 	connection *libMongo.MongoConnection
 	Database   string
+
+	// transactionsUnsupported is set once the deployment rejected a transaction, i.e. a standalone server.
+	transactionsUnsupported atomic.Bool
 }
 
 // Compile-time interface satisfaction check.
@@ -56,6 +80,7 @@ func NewReportMongoDBRepository(mc *libMongo.MongoConnection) (*ReportMongoDBRep
 }
 
 // UpdateReportStatusById updates only the status, completedAt and metadata fields of a report document by UUID.
+// Cancelled reports are left untouched, so a worker finishing late never overwrites a cancellation.
 func (rm *ReportMongoDBRepository) UpdateReportStatusById(
 	ctx context.Context,
 	status string,
@@ -86,7 +111,10 @@ func (rm *ReportMongoDBRepository) UpdateReportStatusById(
 	coll := db.Database(strings.ToLower(rm.Database)).Collection(strings.ToLower(constant.MongoCollectionReport))
 
 	// Create a filter using the UUID directly for matching the _id field stored as BinData
-	filter := bson.M{"_id": id}
+	filter := bson.M{
+		"_id":    id,
+		"status": bson.M{"$ne": constant.CancelledStatus},
+	}
 
 	ctx, spanUpdate := tracer.Start(ctx, "repository.report.update_status_exec")
 	defer spanUpdate.End()
@@ -133,114 +161,473 @@ func (rm *ReportMongoDBRepository) UpdateReportStatusById(
 	return nil
 }
 
-// Create inserts a new report entity into mongo.
-func (rm *ReportMongoDBRepository) Create(ctx context.Context, report *Report) (*Report, error) {
+// TransitionReportStatus atomically moves a report to the status to, only if its current status is one of from.
+// It sets completedAt when not zero, replaces the metadata when not nil and reports whether the report was transitioned.
+func (rm *ReportMongoDBRepository) TransitionReportStatus(
+	ctx context.Context,
+	id uuid.UUID,
+	from []string,
+	to string,
+	completedAt time.Time,
+	metadata map[string]any,
+) (bool, error) {
 	_, tracer, reqId, _ := commons.NewTrackingFromContext(ctx)
 
-	ctx, span := tracer.Start(ctx, "repository.report.create")
+	ctx, span := tracer.Start(ctx, "repository.report.transition_status")
 	defer span.End()
 
-	attributes := []attribute.KeyValue{
+	span.SetAttributes(
 		attribute.String("app.request.request_id", reqId),
+		attribute.String("app.request.report_id", id.String()),
+		attribute.StringSlice("app.request.from_status", from),
+		attribute.String("app.request.status", to),
+	)
+
+	db, err := rm.connection.GetDB(ctx)
+	if err != nil {
+		libOpentelemetry.HandleSpanError(&span, "Failed to get database", err)
+		return false, err
 	}
 
-	span.SetAttributes(attributes...)
+	coll := db.Database(strings.ToLower(rm.Database)).Collection(strings.ToLower(constant.MongoCollectionReport))
 
-	err := libOpentelemetry.SetSpanAttributesFromStruct(&span, "app.request.payload", report)
+	filter := bson.M{
+		"_id":        id,
+		"status":     bson.M{"$in": from},
+		"deleted_at": bson.D{{Key: "$eq", Value: nil}},
+	}
+
+	updateFields := bson.M{
+		"status":     to,
+		"updated_at": time.Now(),
+	}
+
+	if !completedAt.IsZero() {
+		updateFields["completed_at"] = completedAt
+	}
+
+	if metadata != nil {
+		updateFields["metadata"] = metadata
+	}
+
+	result, err := coll.UpdateOne(ctx, filter, bson.M{"$set": updateFields})
 	if err != nil {
-		libOpentelemetry.HandleSpanError(&span, "Failed to convert report record to JSON string", err)
+		libOpentelemetry.HandleSpanError(&span, "Failed to transition report status", err)
+		return false, err
 	}
 
+	return result.ModifiedCount > 0, nil
+}
+
+// ResetForRetry atomically moves a report of the organization whose status is one of from back to Processing.
+// The previous status, metadata and completion time are appended to the report attempts, and message replaces
+// the message stored with the report. It returns mongo.ErrNoDocuments when no such report exists.
+func (rm *ReportMongoDBRepository) ResetForRetry(
+	ctx context.Context,
+	id, organizationID uuid.UUID,
+	from []string,
+	message *model.ReportMessage,
+	retriedAt time.Time,
+) (*Report, error) {
+	_, tracer, reqId, _ := commons.NewTrackingFromContext(ctx)
+
+	ctx, span := tracer.Start(ctx, "repository.report.reset_for_retry")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("app.request.request_id", reqId),
+		attribute.String("app.request.report_id", id.String()),
+		attribute.StringSlice("app.request.from_status", from),
+	)
+
 	db, err := rm.connection.GetDB(ctx)
 	if err != nil {
 		libOpentelemetry.HandleSpanError(&span, "Failed to get database", err)
-
 		return nil, err
 	}
 
 	coll := db.Database(strings.ToLower(rm.Database)).Collection(strings.ToLower(constant.MongoCollectionReport))
-	record := &ReportMongoDBModel{}
 
-	if err := record.FromEntity(report); err != nil {
-		libOpentelemetry.HandleSpanError(&span, "Failed to convert report to model", err)
+	record, err := resetForRetry(ctx, coll, id, organizationID, from, message, retriedAt)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, err
+		}
+
+		libOpentelemetry.HandleSpanError(&span, "Failed to reset report for retry", err)
 
 		return nil, err
 	}
 
-	ctx, spanInsert := tracer.Start(ctx, "repository.report.create_exec")
+	return record.ToEntityFindByID(), nil
+}
 
-	spanInsert.SetAttributes(attributes...)
+// ResetForRetryWithOutbox resets a report for a new attempt like ResetForRetry and inserts the outbox entry of its
+// message in a single transaction, so a report is never back in Processing without the message that generates it.
+// On a standalone server the entry is inserted before the report is reset and removed when the reset fails; an entry
+// left behind by a failed removal is published for a report that is not Processing, which the worker skips.
+func (rm *ReportMongoDBRepository) ResetForRetryWithOutbox(
+	ctx context.Context,
+	id, organizationID uuid.UUID,
+	from []string,
+	message *model.ReportMessage,
+	retriedAt time.Time,
+	entry *outbox.Entry,
+) (*Report, error) {
+	logger, tracer, reqId, _ := commons.NewTrackingFromContext(ctx)
+
+	ctx, span := tracer.Start(ctx, "repository.report.reset_for_retry_with_outbox")
+	defer span.End()
 
-	err = libOpentelemetry.SetSpanAttributesFromStruct(&spanInsert, "app.request.repository_input", record)
+	span.SetAttributes(
+		attribute.String("app.request.request_id", reqId),
+		attribute.String("app.request.report_id", id.String()),
+		attribute.String("app.request.outbox_id", entry.ID.String()),
+		attribute.StringSlice("app.request.from_status", from),
+	)
+
+	db, err := rm.connection.GetDB(ctx)
 	if err != nil {
-		libOpentelemetry.HandleSpanError(&spanInsert, "Failed to convert report record to JSON string", err)
+		libOpentelemetry.HandleSpanError(&span, "Failed to get database", err)
+
+		return nil, err
 	}
 
-	_, err = coll.InsertOne(ctx, record)
+	database := db.Database(strings.ToLower(rm.Database))
+	reports := database.Collection(strings.ToLower(constant.MongoCollectionReport))
+	entries := database.Collection(strings.ToLower(constant.MongoCollectionOutbox))
+
+	entryRecord := &outbox.EntryMongoDBModel{}
+	entryRecord.FromEntity(entry)
+
+	var record *ReportMongoDBModel
+
+	reset := func(ctx context.Context) error {
+		var errReset error
+
+		record, errReset = resetForRetry(ctx, reports, id, organizationID, from, message, retriedAt)
+		if errReset != nil {
+			return errReset
+		}
+
+		_, errReset = entries.InsertOne(ctx, entryRecord)
+
+		return errReset
+	}
+
+	if !rm.transactionsUnsupported.Load() {
+		err = rm.withTransaction(ctx, db, reset)
+		if err == nil {
+			return record.ToEntityFindByID(), nil
+		}
+
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, err
+		}
+
+		if !mongodb.IsTransactionUnsupported(err) {
+			libOpentelemetry.HandleSpanError(&span, "Failed to reset report for retry with outbox entry", err)
+
+			return nil, err
+		}
+
+		rm.transactionsUnsupported.Store(true)
+
+		logger.Warn("MongoDB does not support transactions (standalone server); reports and outbox entries are written without a transaction")
+	}
+
+	if _, err := entries.InsertOne(ctx, entryRecord); err != nil {
+		libOpentelemetry.HandleSpanError(&span, "Failed to insert outbox entry", err)
+
+		return nil, err
+	}
+
+	record, err = resetForRetry(ctx, reports, id, organizationID, from, message, retriedAt)
 	if err != nil {
-		libOpentelemetry.HandleSpanError(&spanInsert, "Failed to insert report", err)
+		if _, errDelete := entries.DeleteOne(ctx, bson.M{"_id": entry.ID}); errDelete != nil {
+			logger.Errorf("Failed to remove the outbox entry of report %s that was not reset: %v", id, errDelete)
+		}
+
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, err
+		}
+
+		libOpentelemetry.HandleSpanError(&span, "Failed to reset report for retry", err)
 
 		return nil, err
 	}
 
-	spanInsert.End()
+	return record.ToEntityFindByID(), nil
+}
 
-	return record.ToEntity(report.Filters), nil
+// resetForRetry moves a report of the organization whose status is one of from back to Processing, recording the
+// previous attempt, and returns the report reset. It returns mongo.ErrNoDocuments when no such report exists.
+func resetForRetry(
+	ctx context.Context,
+	coll *mongo.Collection,
+	id, organizationID uuid.UUID,
+	from []string,
+	message *model.ReportMessage,
+	retriedAt time.Time,
+) (*ReportMongoDBModel, error) {
+	filter := bson.M{
+		"_id":                             id,
+		constant.MongoFieldOrganizationID: mongodb.OrganizationFilter(organizationID),
+		"status":                          bson.M{"$in": from},
+		"deleted_at":                      bson.D{{Key: "$eq", Value: nil}},
+	}
+
+	// An update pipeline reads the previous attempt from the document being updated,
+	// so recording it and resetting the report happen in a single atomic write
+	update := mongo.Pipeline{
+		{{Key: "$set", Value: bson.M{
+			"attempts": bson.M{"$concatArrays": bson.A{
+				bson.M{"$ifNull": bson.A{"$attempts", bson.A{}}},
+				bson.A{bson.M{
+					"status":       "$status",
+					"metadata":     "$metadata",
+					"completed_at": "$completed_at",
+					"retried_at":   retriedAt,
+				}},
+			}},
+			"status":       constant.ProcessingStatus,
+			"metadata":     nil,
+			"completed_at": nil,
+			"updated_at":   retriedAt,
+			// The next attempt has no heartbeat until a worker picks it up
+			constant.MongoFieldHeartbeatAt: "$$REMOVE",
+			// $literal keeps filter values starting with $ from being read as field paths
+			"message": bson.M{"$literal": message},
+		}}},
+	}
+
+	var record ReportMongoDBModel
+
+	if err := coll.
+		FindOneAndUpdate(ctx, filter, update, options.FindOneAndUpdate().SetReturnDocument(options.After)).
+		Decode(&record); err != nil {
+		return nil, err
+	}
+
+	return &record, nil
 }
 
-// FindByID retrieves a report from the mongodb using the provided entity_id.
-func (rm *ReportMongoDBRepository) FindByID(ctx context.Context, id uuid.UUID) (*Report, error) {
+// RecordHeartbeat records that a worker is still generating a report of the organization in Processing.
+// Reports in any other status are left untouched.
+func (rm *ReportMongoDBRepository) RecordHeartbeat(ctx context.Context, id, organizationID uuid.UUID, at time.Time) error {
 	_, tracer, reqId, _ := commons.NewTrackingFromContext(ctx)
 
-	ctx, span := tracer.Start(ctx, "repository.report.find_by_id")
+	ctx, span := tracer.Start(ctx, "repository.report.record_heartbeat")
 	defer span.End()
 
-	attributes := []attribute.KeyValue{
+	span.SetAttributes(
 		attribute.String("app.request.request_id", reqId),
 		attribute.String("app.request.report_id", id.String()),
+	)
+
+	db, err := rm.connection.GetDB(ctx)
+	if err != nil {
+		libOpentelemetry.HandleSpanError(&span, "Failed to get database", err)
+		return err
 	}
 
-	span.SetAttributes(attributes...)
+	coll := db.Database(strings.ToLower(rm.Database)).Collection(strings.ToLower(constant.MongoCollectionReport))
+
+	filter := bson.M{
+		"_id":                             id,
+		constant.MongoFieldOrganizationID: mongodb.OrganizationFilter(organizationID),
+		"status":                          constant.ProcessingStatus,
+		"deleted_at":                      bson.D{{Key: "$eq", Value: nil}},
+	}
+
+	if _, err := coll.UpdateOne(ctx, filter, bson.M{"$set": bson.M{constant.MongoFieldHeartbeatAt: at}}); err != nil {
+		libOpentelemetry.HandleSpanError(&span, "Failed to record report heartbeat", err)
+		return err
+	}
+
+	return nil
+}
+
+// FindByStatus retrieves the reports selected by the query, oldest first.
+func (rm *ReportMongoDBRepository) FindByStatus(ctx context.Context, query StatusQuery) ([]*Report, error) {
+	_, tracer, reqId, _ := commons.NewTrackingFromContext(ctx)
+
+	ctx, span := tracer.Start(ctx, "repository.report.find_by_status")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("app.request.request_id", reqId),
+		attribute.StringSlice("app.request.status", query.Statuses),
+	)
 
 	db, err := rm.connection.GetDB(ctx)
 	if err != nil {
 		libOpentelemetry.HandleSpanError(&span, "Failed to get database", err)
-
 		return nil, err
 	}
 
 	coll := db.Database(strings.ToLower(rm.Database)).Collection(strings.ToLower(constant.MongoCollectionReport))
 
-	var record *ReportMongoDBModel
+	filter := bson.M{
+		"status":     bson.M{"$in": query.Statuses},
+		"deleted_at": bson.D{{Key: "$eq", Value: nil}},
+	}
 
-	ctx, spanFindOne := tracer.Start(ctx, "repository.report.find_by_id_exec")
+	if !query.AllOrganizations {
+		filter[constant.MongoFieldOrganizationID] = mongodb.OrganizationFilter(query.OrganizationID)
+	}
 
-	spanFindOne.SetAttributes(attributes...)
+	if !query.UpdatedBefore.IsZero() {
+		filter["updated_at"] = bson.M{"$lt": query.UpdatedBefore}
+	}
 
-	filter := bson.M{"_id": id, "deleted_at": bson.D{{Key: "$eq", Value: nil}}}
+	if !query.StaleBefore.IsZero() {
+		filter["$or"] = bson.A{
+			bson.M{constant.MongoFieldHeartbeatAt: bson.M{"$lt": query.StaleBefore}},
+			bson.M{
+				constant.MongoFieldHeartbeatAt: bson.M{"$exists": false},
+				"updated_at":                   bson.M{"$lt": query.StaleBefore},
+			},
+		}
+	}
 
-	if err = coll.
-		FindOne(ctx, filter).
-		Decode(&record); err != nil {
-		libOpentelemetry.HandleSpanError(&spanFindOne, "Failed to find report by entity", err)
+	if query.TemplateID != uuid.Nil {
+		filter["template_id"] = query.TemplateID
+	}
+
+	if query.BatchID != uuid.Nil {
+		filter["batch_id"] = query.BatchID
+	}
+
+	createdAt := bson.M{}
+
+	if !query.CreatedFrom.IsZero() {
+		createdAt["$gte"] = query.CreatedFrom
+	}
+
+	if !query.CreatedTo.IsZero() {
+		createdAt["$lt"] = query.CreatedTo
+	}
+
+	if len(createdAt) > 0 {
+		filter["created_at"] = createdAt
+	}
+
+	opts := options.Find().SetSort(bson.D{{Key: "created_at", Value: 1}})
+	if query.Limit > 0 {
+		opts.SetLimit(query.Limit)
+	}
+
+	cur, err := coll.Find(ctx, filter, opts)
+	if err != nil {
+		libOpentelemetry.HandleSpanError(&span, "Failed to find reports by status", err)
 		return nil, err
 	}
 
-	if nil == record {
+	var records []ReportMongoDBModel
+	if err := cur.All(ctx, &records); err != nil {
+		libOpentelemetry.HandleSpanError(&span, "Failed to decode reports", err)
+		return nil, err
+	}
+
+	reports := make([]*Report, 0, len(records))
+	for i := range records {
+		reports = append(reports, records[i].ToEntityFindByID())
+	}
+
+	return reports, nil
+}
+
+// FindExpired retrieves the reports selected by the retention query, oldest completion first.
+func (rm *ReportMongoDBRepository) FindExpired(ctx context.Context, query RetentionQuery) ([]*Report, error) {
+	_, tracer, reqId, _ := commons.NewTrackingFromContext(ctx)
+
+	ctx, span := tracer.Start(ctx, "repository.report.find_expired")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("app.request.request_id", reqId),
+		attribute.String("app.request.completed_before", query.CompletedBefore.String()),
+	)
+
+	db, err := rm.connection.GetDB(ctx)
+	if err != nil {
 		libOpentelemetry.HandleSpanError(&span, "Failed to get database", err)
-		return nil, mongo.ErrNoDocuments
+		return nil, err
 	}
 
-	spanFindOne.End()
+	coll := db.Database(strings.ToLower(rm.Database)).Collection(strings.ToLower(constant.MongoCollectionReport))
 
-	return record.ToEntityFindByID(), nil
+	opts := options.Find().SetSort(bson.D{{Key: "completed_at", Value: 1}})
+	if query.Limit > 0 {
+		opts.SetLimit(query.Limit)
+	}
+
+	cur, err := coll.Find(ctx, retentionFilter(query), opts)
+	if err != nil {
+		libOpentelemetry.HandleSpanError(&span, "Failed to find expired reports", err)
+		return nil, err
+	}
+
+	var records []ReportMongoDBModel
+	if err := cur.All(ctx, &records); err != nil {
+		libOpentelemetry.HandleSpanError(&span, "Failed to decode reports", err)
+		return nil, err
+	}
+
+	reports := make([]*Report, 0, len(records))
+	for i := range records {
+		reports = append(reports, records[i].ToEntityFindByID())
+	}
+
+	return reports, nil
+}
+
+// retentionFilter builds the query of the reports selected by a retention query.
+func retentionFilter(query RetentionQuery) bson.M {
+	filter := bson.M{
+		"status":       bson.M{"$in": bson.A{constant.FinishedStatus, constant.ErrorStatus, constant.CancelledStatus}},
+		"completed_at": bson.M{"$lt": query.CompletedBefore},
+		"deleted_at":   bson.D{{Key: "$eq", Value: nil}},
+		"legal_hold":   bson.M{"$ne": true},
+	}
+
+	templateID := bson.M{}
+
+	if len(query.TemplateIDs) > 0 {
+		templateID["$in"] = query.TemplateIDs
+	}
+
+	if len(query.ExcludeTemplateIDs) > 0 {
+		templateID["$nin"] = query.ExcludeTemplateIDs
+	}
+
+	if len(templateID) > 0 {
+		filter["template_id"] = templateID
+	}
+
+	organizationID := bson.M{}
+
+	if len(query.OrganizationIDs) > 0 {
+		organizationID["$in"] = mongodb.OrganizationValues(query.OrganizationIDs)
+	}
+
+	if len(query.ExcludeOrganizationIDs) > 0 {
+		organizationID["$nin"] = mongodb.OrganizationValues(query.ExcludeOrganizationIDs)
+	}
+
+	if len(organizationID) > 0 {
+		filter[constant.MongoFieldOrganizationID] = organizationID
+	}
+
+	return filter
 }
 
-// FindList retrieves all reports from the mongodb with filtering and pagination support.
-func (rm *ReportMongoDBRepository) FindList(ctx context.Context, filters http.QueryHeader) ([]*Report, error) {
+// Create inserts a new report entity into mongo.
+func (rm *ReportMongoDBRepository) Create(ctx context.Context, report *Report) (*Report, error) {
 	_, tracer, reqId, _ := commons.NewTrackingFromContext(ctx)
 
-	ctx, span := tracer.Start(ctx, "repository.report.find_list")
+	ctx, span := tracer.Start(ctx, "repository.report.create")
 	defer span.End()
 
 	attributes := []attribute.KeyValue{
@@ -249,20 +636,731 @@ func (rm *ReportMongoDBRepository) FindList(ctx context.Context, filters http.Qu
 
 	span.SetAttributes(attributes...)
 
-	err := libOpentelemetry.SetSpanAttributesFromStruct(&span, "app.request.payload", filters)
+	err := libOpentelemetry.SetSpanAttributesFromStruct(&span, "app.request.payload", report)
 	if err != nil {
-		libOpentelemetry.HandleSpanError(&span, "Failed to convert filters to JSON string", err)
+		libOpentelemetry.HandleSpanError(&span, "Failed to convert report record to JSON string", err)
 	}
 
 	db, err := rm.connection.GetDB(ctx)
 	if err != nil {
 		libOpentelemetry.HandleSpanError(&span, "Failed to get database", err)
+
 		return nil, err
 	}
 
 	coll := db.Database(strings.ToLower(rm.Database)).Collection(strings.ToLower(constant.MongoCollectionReport))
+	record := &ReportMongoDBModel{}
+
+	if err := record.FromEntity(report); err != nil {
+		libOpentelemetry.HandleSpanError(&span, "Failed to convert report to model", err)
+
+		return nil, err
+	}
+
+	ctx, spanInsert := tracer.Start(ctx, "repository.report.create_exec")
+
+	spanInsert.SetAttributes(attributes...)
+
+	err = libOpentelemetry.SetSpanAttributesFromStruct(&spanInsert, "app.request.repository_input", record)
+	if err != nil {
+		libOpentelemetry.HandleSpanError(&spanInsert, "Failed to convert report record to JSON string", err)
+	}
+
+	_, err = coll.InsertOne(ctx, record)
+	if err != nil {
+		libOpentelemetry.HandleSpanError(&spanInsert, "Failed to insert report", err)
+
+		return nil, err
+	}
+
+	spanInsert.End()
+
+	return record.ToEntity(report.Filters), nil
+}
+
+// CreateWithOutbox inserts a report and the outbox entry of its message in a single transaction, so the report
+// is never stored without the message that generates it. Transactions require a replica set or a sharded cluster;
+// on a standalone server the entry is inserted before the report, see insertOutboxFirst.
+func (rm *ReportMongoDBRepository) CreateWithOutbox(ctx context.Context, report *Report, entry *outbox.Entry) (*Report, error) {
+	logger, tracer, reqId, _ := commons.NewTrackingFromContext(ctx)
+
+	ctx, span := tracer.Start(ctx, "repository.report.create_with_outbox")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("app.request.request_id", reqId),
+		attribute.String("app.request.report_id", report.ID.String()),
+		attribute.String("app.request.outbox_id", entry.ID.String()),
+	)
 
-	queryFilter := bson.M{}
+	db, err := rm.connection.GetDB(ctx)
+	if err != nil {
+		libOpentelemetry.HandleSpanError(&span, "Failed to get database", err)
+
+		return nil, err
+	}
+
+	database := db.Database(strings.ToLower(rm.Database))
+	reports := database.Collection(strings.ToLower(constant.MongoCollectionReport))
+	entries := database.Collection(strings.ToLower(constant.MongoCollectionOutbox))
+
+	record := &ReportMongoDBModel{}
+	if err := record.FromEntity(report); err != nil {
+		libOpentelemetry.HandleSpanError(&span, "Failed to convert report to model", err)
+
+		return nil, err
+	}
+
+	entryRecord := &outbox.EntryMongoDBModel{}
+	entryRecord.FromEntity(entry)
+
+	insert := func(ctx context.Context) error {
+		if _, err := reports.InsertOne(ctx, record); err != nil {
+			return err
+		}
+
+		_, err := entries.InsertOne(ctx, entryRecord)
+
+		return err
+	}
+
+	if !rm.transactionsUnsupported.Load() {
+		err = rm.withTransaction(ctx, db, insert)
+		if err == nil {
+			return record.ToEntity(report.Filters), nil
+		}
+
+		if !mongodb.IsTransactionUnsupported(err) {
+			libOpentelemetry.HandleSpanError(&span, "Failed to insert report with outbox entry", err)
+
+			return nil, err
+		}
+
+		rm.transactionsUnsupported.Store(true)
+
+		logger.Warn("MongoDB does not support transactions (standalone server); reports and outbox entries are inserted without a transaction")
+	}
+
+	if err := rm.insertOutboxFirst(ctx, reports, entries, []any{record}, []*outbox.EntryMongoDBModel{entryRecord}, []uuid.UUID{report.ID}); err != nil {
+		libOpentelemetry.HandleSpanError(&span, "Failed to insert report with outbox entry", err)
+
+		return nil, err
+	}
+
+	return record.ToEntity(report.Filters), nil
+}
+
+// CreateManyWithOutbox inserts reports and the outbox entries of their messages in a single transaction,
+// falling back to inserting the entries before the reports on a standalone server like CreateWithOutbox.
+func (rm *ReportMongoDBRepository) CreateManyWithOutbox(ctx context.Context, reports []*Report, entries []*outbox.Entry) error {
+	logger, tracer, reqId, _ := commons.NewTrackingFromContext(ctx)
+
+	ctx, span := tracer.Start(ctx, "repository.report.create_many_with_outbox")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("app.request.request_id", reqId),
+		attribute.Int("app.request.reports", len(reports)),
+	)
+
+	db, err := rm.connection.GetDB(ctx)
+	if err != nil {
+		libOpentelemetry.HandleSpanError(&span, "Failed to get database", err)
+
+		return err
+	}
+
+	database := db.Database(strings.ToLower(rm.Database))
+	reportsColl := database.Collection(strings.ToLower(constant.MongoCollectionReport))
+	entriesColl := database.Collection(strings.ToLower(constant.MongoCollectionOutbox))
+
+	records := make([]any, 0, len(reports))
+
+	for _, report := range reports {
+		record := &ReportMongoDBModel{}
+		if err := record.FromEntity(report); err != nil {
+			libOpentelemetry.HandleSpanError(&span, "Failed to convert report to model", err)
+
+			return err
+		}
+
+		records = append(records, record)
+	}
+
+	entryRecords := make([]*outbox.EntryMongoDBModel, 0, len(entries))
+	entryDocuments := make([]any, 0, len(entries))
+
+	for _, entry := range entries {
+		entryRecord := &outbox.EntryMongoDBModel{}
+		entryRecord.FromEntity(entry)
+
+		entryRecords = append(entryRecords, entryRecord)
+		entryDocuments = append(entryDocuments, entryRecord)
+	}
+
+	insert := func(ctx context.Context) error {
+		if _, err := reportsColl.InsertMany(ctx, records); err != nil {
+			return err
+		}
+
+		_, err := entriesColl.InsertMany(ctx, entryDocuments)
+
+		return err
+	}
+
+	if !rm.transactionsUnsupported.Load() {
+		err = rm.withTransaction(ctx, db, insert)
+		if err == nil {
+			return nil
+		}
+
+		if !mongodb.IsTransactionUnsupported(err) {
+			libOpentelemetry.HandleSpanError(&span, "Failed to insert reports with outbox entries", err)
+
+			return err
+		}
+
+		rm.transactionsUnsupported.Store(true)
+
+		logger.Warn("MongoDB does not support transactions (standalone server); reports and outbox entries are inserted without a transaction")
+	}
+
+	reportIDs := make([]uuid.UUID, 0, len(reports))
+	for _, report := range reports {
+		reportIDs = append(reportIDs, report.ID)
+	}
+
+	if err := rm.insertOutboxFirst(ctx, reportsColl, entriesColl, records, entryRecords, reportIDs); err != nil {
+		libOpentelemetry.HandleSpanError(&span, "Failed to insert reports with outbox entries", err)
+
+		return err
+	}
+
+	return nil
+}
+
+// insertOutboxFirst inserts reports and the outbox entries of their messages without a transaction. The entries
+// are inserted first, so a report is never stored without the message that generates it, and claimed, so the relay
+// does not publish them before their reports exist. The entries their creator did not claim are released once the
+// reports are inserted. When the reports can not be inserted, the entries and the reports inserted before the
+// failure are removed.
+func (rm *ReportMongoDBRepository) insertOutboxFirst(ctx context.Context, reportsColl, entriesColl *mongo.Collection, records []any, entryRecords []*outbox.EntryMongoDBModel, reportIDs []uuid.UUID) error {
+	logger, _, _, _ := commons.NewTrackingFromContext(ctx) //nolint:dogsled // only logger needed from tracking context
+
+	now := time.Now()
+	claimedUntil := now.Add(constant.OutboxClaimLease)
+
+	entryDocuments := make([]any, 0, len(entryRecords))
+	entryIDs := make([]uuid.UUID, 0, len(entryRecords))
+	unclaimedIDs := make([]uuid.UUID, 0, len(entryRecords))
+
+	for _, entryRecord := range entryRecords {
+		document := *entryRecord
+		if document.LockedUntil == nil || !document.LockedUntil.After(now) {
+			document.LockedUntil = &claimedUntil
+			unclaimedIDs = append(unclaimedIDs, document.ID)
+		}
+
+		entryDocuments = append(entryDocuments, &document)
+		entryIDs = append(entryIDs, document.ID)
+	}
+
+	if _, err := entriesColl.InsertMany(ctx, entryDocuments); err != nil {
+		return err
+	}
+
+	if _, err := reportsColl.InsertMany(ctx, records); err != nil {
+		if _, errDelete := entriesColl.DeleteMany(ctx, bson.M{"_id": bson.M{"$in": entryIDs}}); errDelete != nil {
+			logger.Errorf("Failed to remove the outbox entries of reports that were not inserted: %v", errDelete)
+		}
+
+		if _, errDelete := reportsColl.DeleteMany(ctx, bson.M{"_id": bson.M{"$in": reportIDs}}); errDelete != nil {
+			logger.Errorf("Failed to remove the reports inserted before the failure: %v", errDelete)
+		}
+
+		return err
+	}
+
+	if len(unclaimedIDs) == 0 {
+		return nil
+	}
+
+	// An entry left claimed by a failed release is published by the relay once its claim expires
+	if _, err := entriesColl.UpdateMany(ctx,
+		bson.M{"_id": bson.M{"$in": unclaimedIDs}, "locked_until": claimedUntil},
+		bson.M{"$set": bson.M{"locked_until": nil}},
+	); err != nil {
+		logger.Warnf("Failed to release the outbox entries of the reports inserted, they are published once their claim expires: %v", err)
+	}
+
+	return nil
+}
+
+// CountByBatch counts the reports of a report batch of the given organization in each status.
+func (rm *ReportMongoDBRepository) CountByBatch(ctx context.Context, batchID, organizationID uuid.UUID) (map[string]int, error) {
+	_, tracer, reqId, _ := commons.NewTrackingFromContext(ctx)
+
+	ctx, span := tracer.Start(ctx, "repository.report.count_by_batch")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("app.request.request_id", reqId),
+		attribute.String("app.request.batch_id", batchID.String()),
+	)
+
+	db, err := rm.connection.GetDB(ctx)
+	if err != nil {
+		libOpentelemetry.HandleSpanError(&span, "Failed to get database", err)
+		return nil, err
+	}
+
+	coll := db.Database(strings.ToLower(rm.Database)).Collection(strings.ToLower(constant.MongoCollectionReport))
+
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: bson.M{
+			"batch_id":                        batchID,
+			constant.MongoFieldOrganizationID: mongodb.OrganizationFilter(organizationID),
+			"deleted_at":                      bson.D{{Key: "$eq", Value: nil}},
+		}}},
+		{{Key: "$group", Value: bson.M{
+			"_id":   "$status",
+			"count": bson.M{"$sum": 1},
+		}}},
+	}
+
+	cur, err := coll.Aggregate(ctx, pipeline)
+	if err != nil {
+		libOpentelemetry.HandleSpanError(&span, "Failed to count reports by batch", err)
+		return nil, err
+	}
+
+	var groups []struct {
+		Status string `bson:"_id"`
+		Count  int    `bson:"count"`
+	}
+
+	if err := cur.All(ctx, &groups); err != nil {
+		libOpentelemetry.HandleSpanError(&span, "Failed to decode report counts", err)
+		return nil, err
+	}
+
+	counts := make(map[string]int, len(groups))
+	for _, group := range groups {
+		counts[group.Status] = group.Count
+	}
+
+	return counts, nil
+}
+
+// CancelByBatch moves the reports of a batch of the organization still in Processing to Cancelled at cancelledAt,
+// so workers skip their messages, and returns how many were cancelled.
+func (rm *ReportMongoDBRepository) CancelByBatch(ctx context.Context, batchID, organizationID uuid.UUID, cancelledAt time.Time) (int64, error) {
+	_, tracer, reqId, _ := commons.NewTrackingFromContext(ctx)
+
+	ctx, span := tracer.Start(ctx, "repository.report.cancel_by_batch")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("app.request.request_id", reqId),
+		attribute.String("app.request.batch_id", batchID.String()),
+	)
+
+	db, err := rm.connection.GetDB(ctx)
+	if err != nil {
+		libOpentelemetry.HandleSpanError(&span, "Failed to get database", err)
+		return 0, err
+	}
+
+	coll := db.Database(strings.ToLower(rm.Database)).Collection(strings.ToLower(constant.MongoCollectionReport))
+
+	filter := bson.M{
+		"batch_id":                        batchID,
+		constant.MongoFieldOrganizationID: mongodb.OrganizationFilter(organizationID),
+		"status":                          constant.ProcessingStatus,
+		"deleted_at":                      bson.D{{Key: "$eq", Value: nil}},
+	}
+
+	update := bson.M{"$set": bson.M{
+		"status":       constant.CancelledStatus,
+		"completed_at": cancelledAt,
+		"updated_at":   cancelledAt,
+	}}
+
+	result, err := coll.UpdateMany(ctx, filter, update)
+	if err != nil {
+		libOpentelemetry.HandleSpanError(&span, "Failed to cancel reports by batch", err)
+		return 0, err
+	}
+
+	return result.ModifiedCount, nil
+}
+
+// withTransaction runs fn in a transaction, retried by the driver on transient errors.
+func (rm *ReportMongoDBRepository) withTransaction(ctx context.Context, db *mongo.Client, fn func(ctx context.Context) error) error {
+	session, err := db.StartSession()
+	if err != nil {
+		return err
+	}
+
+	defer session.EndSession(ctx)
+
+	_, err = session.WithTransaction(ctx, func(sc mongo.SessionContext) (any, error) {
+		return nil, fn(sc)
+	})
+
+	return err
+}
+
+// FindByID retrieves a report of the given organization from the mongodb using the provided entity_id.
+func (rm *ReportMongoDBRepository) FindByID(ctx context.Context, id, organizationID uuid.UUID) (*Report, error) {
+	_, tracer, reqId, _ := commons.NewTrackingFromContext(ctx)
+
+	ctx, span := tracer.Start(ctx, "repository.report.find_by_id")
+	defer span.End()
+
+	attributes := []attribute.KeyValue{
+		attribute.String("app.request.request_id", reqId),
+		attribute.String("app.request.report_id", id.String()),
+	}
+
+	span.SetAttributes(attributes...)
+
+	db, err := rm.connection.GetDB(ctx)
+	if err != nil {
+		libOpentelemetry.HandleSpanError(&span, "Failed to get database", err)
+
+		return nil, err
+	}
+
+	coll := db.Database(strings.ToLower(rm.Database)).Collection(strings.ToLower(constant.MongoCollectionReport))
+
+	var record *ReportMongoDBModel
+
+	ctx, spanFindOne := tracer.Start(ctx, "repository.report.find_by_id_exec")
+
+	spanFindOne.SetAttributes(attributes...)
+
+	filter := bson.M{
+		"_id":                             id,
+		constant.MongoFieldOrganizationID: mongodb.OrganizationFilter(organizationID),
+		"deleted_at":                      bson.D{{Key: "$eq", Value: nil}},
+	}
+
+	if err = coll.
+		FindOne(ctx, filter).
+		Decode(&record); err != nil {
+		libOpentelemetry.HandleSpanError(&spanFindOne, "Failed to find report by entity", err)
+		return nil, err
+	}
+
+	if nil == record {
+		libOpentelemetry.HandleSpanError(&span, "Failed to get database", err)
+		return nil, mongo.ErrNoDocuments
+	}
+
+	spanFindOne.End()
+
+	return record.ToEntityFindByID(), nil
+}
+
+// FindDeletedByID retrieves a deleted report of the given organization, such as one purged by its retention policy.
+// It returns mongo.ErrNoDocuments when the report does not exist or was not deleted.
+func (rm *ReportMongoDBRepository) FindDeletedByID(ctx context.Context, id, organizationID uuid.UUID) (*Report, error) {
+	_, tracer, reqId, _ := commons.NewTrackingFromContext(ctx)
+
+	ctx, span := tracer.Start(ctx, "repository.report.find_deleted_by_id")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("app.request.request_id", reqId),
+		attribute.String("app.request.report_id", id.String()),
+	)
+
+	db, err := rm.connection.GetDB(ctx)
+	if err != nil {
+		libOpentelemetry.HandleSpanError(&span, "Failed to get database", err)
+		return nil, err
+	}
+
+	coll := db.Database(strings.ToLower(rm.Database)).Collection(strings.ToLower(constant.MongoCollectionReport))
+
+	filter := bson.M{
+		"_id":                             id,
+		constant.MongoFieldOrganizationID: mongodb.OrganizationFilter(organizationID),
+		"deleted_at":                      bson.M{"$ne": nil},
+	}
+
+	var record ReportMongoDBModel
+
+	if err := coll.FindOne(ctx, filter).Decode(&record); err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, err
+		}
+
+		libOpentelemetry.HandleSpanError(&span, "Failed to find deleted report", err)
+
+		return nil, err
+	}
+
+	return record.ToEntityFindByID(), nil
+}
+
+// FindFinishedByFingerprint retrieves the latest finished report of the given organization generated with the
+// output fingerprint. It returns mongo.ErrNoDocuments when no such report exists.
+func (rm *ReportMongoDBRepository) FindFinishedByFingerprint(ctx context.Context, fingerprint string, organizationID uuid.UUID) (*Report, error) {
+	_, tracer, reqId, _ := commons.NewTrackingFromContext(ctx)
+
+	ctx, span := tracer.Start(ctx, "repository.report.find_finished_by_fingerprint")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("app.request.request_id", reqId),
+		attribute.String("app.request.fingerprint", fingerprint),
+	)
+
+	db, err := rm.connection.GetDB(ctx)
+	if err != nil {
+		libOpentelemetry.HandleSpanError(&span, "Failed to get database", err)
+		return nil, err
+	}
+
+	coll := db.Database(strings.ToLower(rm.Database)).Collection(strings.ToLower(constant.MongoCollectionReport))
+
+	opts := options.FindOne().SetSort(bson.D{{Key: "completed_at", Value: -1}})
+
+	var record ReportMongoDBModel
+
+	if err := coll.FindOne(ctx, fingerprintFilter(fingerprint, organizationID), opts).Decode(&record); err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, err
+		}
+
+		libOpentelemetry.HandleSpanError(&span, "Failed to find report by fingerprint", err)
+
+		return nil, err
+	}
+
+	return record.ToEntityFindByID(), nil
+}
+
+// fingerprintFilter builds the query of the finished reports of the organization with the output fingerprint.
+func fingerprintFilter(fingerprint string, organizationID uuid.UUID) bson.M {
+	return bson.M{
+		"metadata." + constant.ReportFingerprintMetadataKey: fingerprint,
+		constant.MongoFieldOrganizationID:                   mongodb.OrganizationFilter(organizationID),
+		"status":                                            constant.FinishedStatus,
+		"deleted_at":                                        bson.D{{Key: "$eq", Value: nil}},
+	}
+}
+
+// SoftDelete marks a report of the organization as deleted at deletedAt, recording its storage files as pending
+// until ClearPendingFiles is called once they are deleted. Reports still in Processing, under legal hold or already
+// deleted are left untouched; it reports whether the report was deleted.
+func (rm *ReportMongoDBRepository) SoftDelete(ctx context.Context, id, organizationID uuid.UUID, deletedAt time.Time, pendingFiles []string) (bool, error) {
+	_, tracer, reqId, _ := commons.NewTrackingFromContext(ctx)
+
+	ctx, span := tracer.Start(ctx, "repository.report.soft_delete")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("app.request.request_id", reqId),
+		attribute.String("app.request.report_id", id.String()),
+	)
+
+	db, err := rm.connection.GetDB(ctx)
+	if err != nil {
+		libOpentelemetry.HandleSpanError(&span, "Failed to get database", err)
+		return false, err
+	}
+
+	coll := db.Database(strings.ToLower(rm.Database)).Collection(strings.ToLower(constant.MongoCollectionReport))
+
+	filter := bson.M{
+		"_id":                             id,
+		constant.MongoFieldOrganizationID: mongodb.OrganizationFilter(organizationID),
+		"status":                          bson.M{"$ne": constant.ProcessingStatus},
+		"legal_hold":                      bson.M{"$ne": true},
+		"deleted_at":                      bson.D{{Key: "$eq", Value: nil}},
+	}
+
+	setFields := bson.M{
+		"deleted_at": deletedAt,
+		"updated_at": deletedAt,
+	}
+
+	if len(pendingFiles) > 0 {
+		setFields[constant.MongoFieldPendingFiles] = pendingFiles
+	}
+
+	result, err := coll.UpdateOne(ctx, filter, bson.M{"$set": setFields})
+	if err != nil {
+		libOpentelemetry.HandleSpanError(&span, "Failed to soft delete report", err)
+		return false, err
+	}
+
+	return result.ModifiedCount > 0, nil
+}
+
+// FindPendingFiles returns up to limit deleted reports of every organization whose storage files are still
+// pending deletion, oldest deletion first.
+func (rm *ReportMongoDBRepository) FindPendingFiles(ctx context.Context, limit int64) ([]*Report, error) {
+	_, tracer, reqId, _ := commons.NewTrackingFromContext(ctx)
+
+	ctx, span := tracer.Start(ctx, "repository.report.find_pending_files")
+	defer span.End()
+
+	span.SetAttributes(attribute.String("app.request.request_id", reqId))
+
+	db, err := rm.connection.GetDB(ctx)
+	if err != nil {
+		libOpentelemetry.HandleSpanError(&span, "Failed to get database", err)
+		return nil, err
+	}
+
+	coll := db.Database(strings.ToLower(rm.Database)).Collection(strings.ToLower(constant.MongoCollectionReport))
+
+	filter := bson.M{
+		constant.MongoFieldPendingFiles: bson.M{"$exists": true, "$ne": bson.A{}},
+		"deleted_at":                    bson.M{"$ne": nil},
+	}
+
+	opts := options.Find().SetSort(bson.D{{Key: "deleted_at", Value: 1}})
+	if limit > 0 {
+		opts.SetLimit(limit)
+	}
+
+	cur, err := coll.Find(ctx, filter, opts)
+	if err != nil {
+		libOpentelemetry.HandleSpanError(&span, "Failed to find reports with pending files", err)
+		return nil, err
+	}
+
+	var records []ReportMongoDBModel
+	if err := cur.All(ctx, &records); err != nil {
+		libOpentelemetry.HandleSpanError(&span, "Failed to decode reports", err)
+		return nil, err
+	}
+
+	reports := make([]*Report, 0, len(records))
+	for i := range records {
+		reports = append(reports, records[i].ToEntityFindByID())
+	}
+
+	return reports, nil
+}
+
+// ClearPendingFiles records that the storage files of a deleted report of the organization were deleted.
+func (rm *ReportMongoDBRepository) ClearPendingFiles(ctx context.Context, id, organizationID uuid.UUID) error {
+	_, tracer, reqId, _ := commons.NewTrackingFromContext(ctx)
+
+	ctx, span := tracer.Start(ctx, "repository.report.clear_pending_files")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("app.request.request_id", reqId),
+		attribute.String("app.request.report_id", id.String()),
+	)
+
+	db, err := rm.connection.GetDB(ctx)
+	if err != nil {
+		libOpentelemetry.HandleSpanError(&span, "Failed to get database", err)
+		return err
+	}
+
+	coll := db.Database(strings.ToLower(rm.Database)).Collection(strings.ToLower(constant.MongoCollectionReport))
+
+	filter := bson.M{
+		"_id":                             id,
+		constant.MongoFieldOrganizationID: mongodb.OrganizationFilter(organizationID),
+	}
+
+	if _, err := coll.UpdateOne(ctx, filter, bson.M{"$unset": bson.M{constant.MongoFieldPendingFiles: ""}}); err != nil {
+		libOpentelemetry.HandleSpanError(&span, "Failed to clear pending files of report", err)
+		return err
+	}
+
+	return nil
+}
+
+// SetLegalHold places or releases the legal hold of a report of the organization and returns the updated report.
+// It returns mongo.ErrNoDocuments when no such report exists.
+func (rm *ReportMongoDBRepository) SetLegalHold(ctx context.Context, id, organizationID uuid.UUID, legalHold bool) (*Report, error) {
+	_, tracer, reqId, _ := commons.NewTrackingFromContext(ctx)
+
+	ctx, span := tracer.Start(ctx, "repository.report.set_legal_hold")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("app.request.request_id", reqId),
+		attribute.String("app.request.report_id", id.String()),
+		attribute.Bool("app.request.legal_hold", legalHold),
+	)
+
+	db, err := rm.connection.GetDB(ctx)
+	if err != nil {
+		libOpentelemetry.HandleSpanError(&span, "Failed to get database", err)
+		return nil, err
+	}
+
+	coll := db.Database(strings.ToLower(rm.Database)).Collection(strings.ToLower(constant.MongoCollectionReport))
+
+	filter := bson.M{
+		"_id":                             id,
+		constant.MongoFieldOrganizationID: mongodb.OrganizationFilter(organizationID),
+		"deleted_at":                      bson.D{{Key: "$eq", Value: nil}},
+	}
+
+	update := bson.M{"$set": bson.M{
+		"legal_hold": legalHold,
+		"updated_at": time.Now(),
+	}}
+
+	var record ReportMongoDBModel
+
+	if err := coll.
+		FindOneAndUpdate(ctx, filter, update, options.FindOneAndUpdate().SetReturnDocument(options.After)).
+		Decode(&record); err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, err
+		}
+
+		libOpentelemetry.HandleSpanError(&span, "Failed to set report legal hold", err)
+
+		return nil, err
+	}
+
+	return record.ToEntityFindByID(), nil
+}
+
+// FindList retrieves all reports of the given organization from the mongodb with filtering and pagination support.
+func (rm *ReportMongoDBRepository) FindList(ctx context.Context, filters http.QueryHeader, organizationID uuid.UUID) ([]*Report, error) {
+	_, tracer, reqId, _ := commons.NewTrackingFromContext(ctx)
+
+	ctx, span := tracer.Start(ctx, "repository.report.find_list")
+	defer span.End()
+
+	attributes := []attribute.KeyValue{
+		attribute.String("app.request.request_id", reqId),
+	}
+
+	span.SetAttributes(attributes...)
+
+	err := libOpentelemetry.SetSpanAttributesFromStruct(&span, "app.request.payload", filters)
+	if err != nil {
+		libOpentelemetry.HandleSpanError(&span, "Failed to convert filters to JSON string", err)
+	}
+
+	db, err := rm.connection.GetDB(ctx)
+	if err != nil {
+		libOpentelemetry.HandleSpanError(&span, "Failed to get database", err)
+		return nil, err
+	}
+
+	coll := db.Database(strings.ToLower(rm.Database)).Collection(strings.ToLower(constant.MongoCollectionReport))
+
+	queryFilter := bson.M{
+		constant.MongoFieldOrganizationID: mongodb.OrganizationFilter(organizationID),
+	}
 
 	// Filter by status
 	if !commons.IsNilOrEmpty(&filters.Status) {
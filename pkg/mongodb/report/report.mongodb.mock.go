@@ -14,6 +14,8 @@ import (
 	reflect "reflect"
 	time "time"
 
+	model "github.com/LerianStudio/reporter/pkg/model"
+	outbox "github.com/LerianStudio/reporter/pkg/mongodb/outbox"
 	http "github.com/LerianStudio/reporter/pkg/net/http"
 	uuid "github.com/google/uuid"
 	gomock "go.uber.org/mock/gomock"
@@ -43,6 +45,50 @@ func (m *MockRepository) EXPECT() *MockRepositoryMockRecorder {
 	return m.recorder
 }
 
+// CancelByBatch mocks base method.
+func (m *MockRepository) CancelByBatch(ctx context.Context, batchID, organizationID uuid.UUID, cancelledAt time.Time) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CancelByBatch", ctx, batchID, organizationID, cancelledAt)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CancelByBatch indicates an expected call of CancelByBatch.
+func (mr *MockRepositoryMockRecorder) CancelByBatch(ctx, batchID, organizationID, cancelledAt any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CancelByBatch", reflect.TypeOf((*MockRepository)(nil).CancelByBatch), ctx, batchID, organizationID, cancelledAt)
+}
+
+// ClearPendingFiles mocks base method.
+func (m *MockRepository) ClearPendingFiles(ctx context.Context, id, organizationID uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ClearPendingFiles", ctx, id, organizationID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ClearPendingFiles indicates an expected call of ClearPendingFiles.
+func (mr *MockRepositoryMockRecorder) ClearPendingFiles(ctx, id, organizationID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClearPendingFiles", reflect.TypeOf((*MockRepository)(nil).ClearPendingFiles), ctx, id, organizationID)
+}
+
+// CountByBatch mocks base method.
+func (m *MockRepository) CountByBatch(ctx context.Context, batchID, organizationID uuid.UUID) (map[string]int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountByBatch", ctx, batchID, organizationID)
+	ret0, _ := ret[0].(map[string]int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountByBatch indicates an expected call of CountByBatch.
+func (mr *MockRepositoryMockRecorder) CountByBatch(ctx, batchID, organizationID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountByBatch", reflect.TypeOf((*MockRepository)(nil).CountByBatch), ctx, batchID, organizationID)
+}
+
 // Create mocks base method.
 func (m *MockRepository) Create(ctx context.Context, record *Report) (*Report, error) {
 	m.ctrl.T.Helper()
@@ -58,34 +104,227 @@ func (mr *MockRepositoryMockRecorder) Create(ctx, record any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockRepository)(nil).Create), ctx, record)
 }
 
+// CreateManyWithOutbox mocks base method.
+func (m *MockRepository) CreateManyWithOutbox(ctx context.Context, records []*Report, entries []*outbox.Entry) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateManyWithOutbox", ctx, records, entries)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateManyWithOutbox indicates an expected call of CreateManyWithOutbox.
+func (mr *MockRepositoryMockRecorder) CreateManyWithOutbox(ctx, records, entries any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateManyWithOutbox", reflect.TypeOf((*MockRepository)(nil).CreateManyWithOutbox), ctx, records, entries)
+}
+
+// CreateWithOutbox mocks base method.
+func (m *MockRepository) CreateWithOutbox(ctx context.Context, record *Report, entry *outbox.Entry) (*Report, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateWithOutbox", ctx, record, entry)
+	ret0, _ := ret[0].(*Report)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateWithOutbox indicates an expected call of CreateWithOutbox.
+func (mr *MockRepositoryMockRecorder) CreateWithOutbox(ctx, record, entry any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateWithOutbox", reflect.TypeOf((*MockRepository)(nil).CreateWithOutbox), ctx, record, entry)
+}
+
 // FindByID mocks base method.
-func (m *MockRepository) FindByID(ctx context.Context, id uuid.UUID) (*Report, error) {
+func (m *MockRepository) FindByID(ctx context.Context, id, organizationID uuid.UUID) (*Report, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "FindByID", ctx, id)
+	ret := m.ctrl.Call(m, "FindByID", ctx, id, organizationID)
 	ret0, _ := ret[0].(*Report)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // FindByID indicates an expected call of FindByID.
-func (mr *MockRepositoryMockRecorder) FindByID(ctx, id any) *gomock.Call {
+func (mr *MockRepositoryMockRecorder) FindByID(ctx, id, organizationID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByID", reflect.TypeOf((*MockRepository)(nil).FindByID), ctx, id, organizationID)
+}
+
+// FindByStatus mocks base method.
+func (m *MockRepository) FindByStatus(ctx context.Context, query StatusQuery) ([]*Report, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByStatus", ctx, query)
+	ret0, _ := ret[0].([]*Report)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByStatus indicates an expected call of FindByStatus.
+func (mr *MockRepositoryMockRecorder) FindByStatus(ctx, query any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByStatus", reflect.TypeOf((*MockRepository)(nil).FindByStatus), ctx, query)
+}
+
+// FindDeletedByID mocks base method.
+func (m *MockRepository) FindDeletedByID(ctx context.Context, id, organizationID uuid.UUID) (*Report, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindDeletedByID", ctx, id, organizationID)
+	ret0, _ := ret[0].(*Report)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindDeletedByID indicates an expected call of FindDeletedByID.
+func (mr *MockRepositoryMockRecorder) FindDeletedByID(ctx, id, organizationID any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByID", reflect.TypeOf((*MockRepository)(nil).FindByID), ctx, id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindDeletedByID", reflect.TypeOf((*MockRepository)(nil).FindDeletedByID), ctx, id, organizationID)
+}
+
+// FindExpired mocks base method.
+func (m *MockRepository) FindExpired(ctx context.Context, query RetentionQuery) ([]*Report, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindExpired", ctx, query)
+	ret0, _ := ret[0].([]*Report)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindExpired indicates an expected call of FindExpired.
+func (mr *MockRepositoryMockRecorder) FindExpired(ctx, query any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindExpired", reflect.TypeOf((*MockRepository)(nil).FindExpired), ctx, query)
+}
+
+// FindFinishedByFingerprint mocks base method.
+func (m *MockRepository) FindFinishedByFingerprint(ctx context.Context, fingerprint string, organizationID uuid.UUID) (*Report, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindFinishedByFingerprint", ctx, fingerprint, organizationID)
+	ret0, _ := ret[0].(*Report)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindFinishedByFingerprint indicates an expected call of FindFinishedByFingerprint.
+func (mr *MockRepositoryMockRecorder) FindFinishedByFingerprint(ctx, fingerprint, organizationID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindFinishedByFingerprint", reflect.TypeOf((*MockRepository)(nil).FindFinishedByFingerprint), ctx, fingerprint, organizationID)
 }
 
 // FindList mocks base method.
-func (m *MockRepository) FindList(ctx context.Context, filters http.QueryHeader) ([]*Report, error) {
+func (m *MockRepository) FindList(ctx context.Context, filters http.QueryHeader, organizationID uuid.UUID) ([]*Report, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "FindList", ctx, filters)
+	ret := m.ctrl.Call(m, "FindList", ctx, filters, organizationID)
 	ret0, _ := ret[0].([]*Report)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // FindList indicates an expected call of FindList.
-func (mr *MockRepositoryMockRecorder) FindList(ctx, filters any) *gomock.Call {
+func (mr *MockRepositoryMockRecorder) FindList(ctx, filters, organizationID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindList", reflect.TypeOf((*MockRepository)(nil).FindList), ctx, filters, organizationID)
+}
+
+// FindPendingFiles mocks base method.
+func (m *MockRepository) FindPendingFiles(ctx context.Context, limit int64) ([]*Report, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindPendingFiles", ctx, limit)
+	ret0, _ := ret[0].([]*Report)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindPendingFiles indicates an expected call of FindPendingFiles.
+func (mr *MockRepositoryMockRecorder) FindPendingFiles(ctx, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindPendingFiles", reflect.TypeOf((*MockRepository)(nil).FindPendingFiles), ctx, limit)
+}
+
+// RecordHeartbeat mocks base method.
+func (m *MockRepository) RecordHeartbeat(ctx context.Context, id, organizationID uuid.UUID, at time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RecordHeartbeat", ctx, id, organizationID, at)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RecordHeartbeat indicates an expected call of RecordHeartbeat.
+func (mr *MockRepositoryMockRecorder) RecordHeartbeat(ctx, id, organizationID, at any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecordHeartbeat", reflect.TypeOf((*MockRepository)(nil).RecordHeartbeat), ctx, id, organizationID, at)
+}
+
+// ResetForRetry mocks base method.
+func (m *MockRepository) ResetForRetry(ctx context.Context, id, organizationID uuid.UUID, from []string, message *model.ReportMessage, retriedAt time.Time) (*Report, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResetForRetry", ctx, id, organizationID, from, message, retriedAt)
+	ret0, _ := ret[0].(*Report)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ResetForRetry indicates an expected call of ResetForRetry.
+func (mr *MockRepositoryMockRecorder) ResetForRetry(ctx, id, organizationID, from, message, retriedAt any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResetForRetry", reflect.TypeOf((*MockRepository)(nil).ResetForRetry), ctx, id, organizationID, from, message, retriedAt)
+}
+
+// ResetForRetryWithOutbox mocks base method.
+func (m *MockRepository) ResetForRetryWithOutbox(ctx context.Context, id, organizationID uuid.UUID, from []string, message *model.ReportMessage, retriedAt time.Time, entry *outbox.Entry) (*Report, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResetForRetryWithOutbox", ctx, id, organizationID, from, message, retriedAt, entry)
+	ret0, _ := ret[0].(*Report)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ResetForRetryWithOutbox indicates an expected call of ResetForRetryWithOutbox.
+func (mr *MockRepositoryMockRecorder) ResetForRetryWithOutbox(ctx, id, organizationID, from, message, retriedAt, entry any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResetForRetryWithOutbox", reflect.TypeOf((*MockRepository)(nil).ResetForRetryWithOutbox), ctx, id, organizationID, from, message, retriedAt, entry)
+}
+
+// SetLegalHold mocks base method.
+func (m *MockRepository) SetLegalHold(ctx context.Context, id, organizationID uuid.UUID, legalHold bool) (*Report, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetLegalHold", ctx, id, organizationID, legalHold)
+	ret0, _ := ret[0].(*Report)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetLegalHold indicates an expected call of SetLegalHold.
+func (mr *MockRepositoryMockRecorder) SetLegalHold(ctx, id, organizationID, legalHold any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetLegalHold", reflect.TypeOf((*MockRepository)(nil).SetLegalHold), ctx, id, organizationID, legalHold)
+}
+
+// SoftDelete mocks base method.
+func (m *MockRepository) SoftDelete(ctx context.Context, id, organizationID uuid.UUID, deletedAt time.Time, pendingFiles []string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SoftDelete", ctx, id, organizationID, deletedAt, pendingFiles)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SoftDelete indicates an expected call of SoftDelete.
+func (mr *MockRepositoryMockRecorder) SoftDelete(ctx, id, organizationID, deletedAt, pendingFiles any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SoftDelete", reflect.TypeOf((*MockRepository)(nil).SoftDelete), ctx, id, organizationID, deletedAt, pendingFiles)
+}
+
+// TransitionReportStatus mocks base method.
+func (m *MockRepository) TransitionReportStatus(ctx context.Context, id uuid.UUID, from []string, to string, completedAt time.Time, metadata map[string]any) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TransitionReportStatus", ctx, id, from, to, completedAt, metadata)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// TransitionReportStatus indicates an expected call of TransitionReportStatus.
+func (mr *MockRepositoryMockRecorder) TransitionReportStatus(ctx, id, from, to, completedAt, metadata any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindList", reflect.TypeOf((*MockRepository)(nil).FindList), ctx, filters)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TransitionReportStatus", reflect.TypeOf((*MockRepository)(nil).TransitionReportStatus), ctx, id, from, to, completedAt, metadata)
 }
 
 // UpdateReportStatusById mocks base method.
@@ -11,9 +11,11 @@ import (
 
 	"github.com/LerianStudio/reporter/pkg/constant"
 	"github.com/LerianStudio/reporter/pkg/model"
+	"github.com/LerianStudio/reporter/pkg/mongodb"
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson"
 )
 
 func TestReportMongoDBModel_ToEntity(t *testing.T) {
@@ -449,7 +451,7 @@ func TestNewReport(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			t.Parallel()
 
-			got, err := NewReport(tt.id, tt.templateID, tt.status, tt.filters)
+			got, err := NewReport(tt.id, tt.templateID, uuid.Nil, tt.status, tt.filters)
 
 			if tt.wantErr {
 				require.Error(t, err)
@@ -549,7 +551,7 @@ func TestReconstructReport(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			t.Parallel()
 
-			got := ReconstructReport(tt.id, tt.templateID, tt.status, tt.filters, tt.metadata, tt.completedAt, tt.createdAt, tt.updatedAt, tt.deletedAt)
+			got := ReconstructReport(tt.id, tt.templateID, uuid.Nil, tt.status, tt.filters, tt.metadata, tt.completedAt, tt.createdAt, tt.updatedAt, tt.deletedAt)
 
 			require.NotNil(t, got)
 			assert.Equal(t, tt.id, got.ID)
@@ -599,7 +601,7 @@ func TestReconstructReport_MatchesToEntityFindByID(t *testing.T) {
 	}
 
 	fromToEntity := mongoModel.ToEntityFindByID()
-	fromReconstruct := ReconstructReport(id, templateID, "completed", filters, metadata, &completedAt, now, now, &deletedAt)
+	fromReconstruct := ReconstructReport(id, templateID, uuid.Nil, "completed", filters, metadata, &completedAt, now, now, &deletedAt)
 
 	assert.Equal(t, fromToEntity.ID, fromReconstruct.ID)
 	assert.Equal(t, fromToEntity.TemplateID, fromReconstruct.TemplateID)
@@ -687,3 +689,53 @@ func TestFilterCondition_AllOperators(t *testing.T) {
 		})
 	}
 }
+
+func TestRetentionFilter(t *testing.T) {
+	t.Parallel()
+
+	cutoff := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	templateID := uuid.New()
+	organizationID := uuid.New()
+
+	t.Run("global rule excludes templates and tenants with their own rules", func(t *testing.T) {
+		t.Parallel()
+
+		filter := retentionFilter(RetentionQuery{
+			ExcludeTemplateIDs:     []uuid.UUID{templateID},
+			ExcludeOrganizationIDs: []uuid.UUID{uuid.Nil, organizationID},
+			CompletedBefore:        cutoff,
+		})
+
+		assert.Equal(t, bson.M{"$in": bson.A{constant.FinishedStatus, constant.ErrorStatus, constant.CancelledStatus}}, filter["status"])
+		assert.Equal(t, bson.M{"$lt": cutoff}, filter["completed_at"])
+		assert.Equal(t, bson.M{"$ne": true}, filter["legal_hold"])
+		assert.Equal(t, bson.D{{Key: "$eq", Value: nil}}, filter["deleted_at"])
+		assert.Equal(t, bson.M{"$nin": []uuid.UUID{templateID}}, filter["template_id"])
+		assert.Equal(t, bson.M{"$nin": bson.A{uuid.Nil, nil, organizationID}}, filter[constant.MongoFieldOrganizationID])
+	})
+
+	t.Run("template rule selects only its template", func(t *testing.T) {
+		t.Parallel()
+
+		filter := retentionFilter(RetentionQuery{
+			TemplateIDs:     []uuid.UUID{templateID},
+			CompletedBefore: cutoff,
+		})
+
+		assert.Equal(t, bson.M{"$in": []uuid.UUID{templateID}}, filter["template_id"])
+		assert.NotContains(t, filter, constant.MongoFieldOrganizationID)
+	})
+}
+
+func TestFingerprintFilter(t *testing.T) {
+	t.Parallel()
+
+	organizationID := uuid.New()
+
+	filter := fingerprintFilter("abc123", organizationID)
+
+	assert.Equal(t, "abc123", filter["metadata.fingerprint"])
+	assert.Equal(t, constant.FinishedStatus, filter["status"])
+	assert.Equal(t, bson.D{{Key: "$eq", Value: nil}}, filter["deleted_at"])
+	assert.Equal(t, mongodb.OrganizationFilter(organizationID), filter[constant.MongoFieldOrganizationID])
+}
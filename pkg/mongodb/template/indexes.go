@@ -75,6 +75,58 @@ func (tm *TemplateMongoDBRepository) EnsureIndexes(ctx context.Context) error {
 				}),
 		},
 
+		{
+			Keys: bson.D{
+				{Key: constant.MongoFieldOrganizationID, Value: 1},
+				{Key: "deleted_at", Value: 1},
+				{Key: "created_at", Value: -1},
+			},
+			Options: options.Index().
+				SetName("idx_template_org_list").
+				SetPartialFilterExpression(bson.D{
+					{Key: "deleted_at", Value: nil},
+				}),
+		},
+
+		{
+			Keys: bson.D{
+				{Key: constant.MongoFieldOrganizationID, Value: 1},
+				{Key: "output_format", Value: 1},
+				{Key: "created_at", Value: -1},
+			},
+			Options: options.Index().
+				SetName("idx_template_org_format").
+				SetPartialFilterExpression(bson.D{
+					{Key: "deleted_at", Value: nil},
+				}),
+		},
+
+		{
+			Keys: bson.D{
+				{Key: constant.MongoFieldOrganizationID, Value: 1},
+				{Key: constant.MongoFieldPartialName, Value: 1},
+			},
+			Options: options.Index().
+				SetName("idx_template_org_partial_name").
+				SetPartialFilterExpression(bson.D{
+					{Key: "deleted_at", Value: nil},
+					{Key: constant.MongoFieldPartialName, Value: bson.D{{Key: "$exists", Value: true}}},
+				}),
+		},
+
+		{
+			Keys: bson.D{
+				{Key: constant.MongoFieldOrganizationID, Value: 1},
+				{Key: constant.MongoFieldPartials, Value: 1},
+			},
+			Options: options.Index().
+				SetName("idx_template_org_partials").
+				SetPartialFilterExpression(bson.D{
+					{Key: "deleted_at", Value: nil},
+					{Key: constant.MongoFieldPartials, Value: bson.D{{Key: "$exists", Value: true}}},
+				}),
+		},
+
 		{
 			Keys: bson.D{
 				{Key: "description", Value: "text"},
@@ -47,7 +47,7 @@ func TestRepository_FindByID(t *testing.T) {
 					UpdatedAt:    time.Date(2026, 1, 15, 10, 0, 0, 0, time.UTC),
 				}
 				mockRepo.EXPECT().
-					FindByID(gomock.Any(), gomock.Any()).
+					FindByID(gomock.Any(), gomock.Any(), gomock.Any()).
 					Return(expected, nil)
 			},
 			wantErr: false,
@@ -65,7 +65,7 @@ func TestRepository_FindByID(t *testing.T) {
 			id:   uuid.MustParse("00000000-0000-0000-0000-000000000002"),
 			setupMock: func(mockRepo *MockRepository) {
 				mockRepo.EXPECT().
-					FindByID(gomock.Any(), gomock.Any()).
+					FindByID(gomock.Any(), gomock.Any(), gomock.Any()).
 					Return(nil, errors.New("mongo: no documents in result"))
 			},
 			wantErr:     true,
@@ -76,7 +76,7 @@ func TestRepository_FindByID(t *testing.T) {
 			id:   uuid.MustParse("00000000-0000-0000-0000-000000000003"),
 			setupMock: func(mockRepo *MockRepository) {
 				mockRepo.EXPECT().
-					FindByID(gomock.Any(), gomock.Any()).
+					FindByID(gomock.Any(), gomock.Any(), gomock.Any()).
 					Return(nil, errors.New("failed to connect to mongodb"))
 			},
 			wantErr:     true,
@@ -95,7 +95,7 @@ func TestRepository_FindByID(t *testing.T) {
 					UpdatedAt:    time.Date(2026, 2, 1, 8, 0, 0, 0, time.UTC),
 				}
 				mockRepo.EXPECT().
-					FindByID(gomock.Any(), gomock.Any()).
+					FindByID(gomock.Any(), gomock.Any(), gomock.Any()).
 					Return(expected, nil)
 			},
 			wantErr: false,
@@ -120,7 +120,7 @@ func TestRepository_FindByID(t *testing.T) {
 			mockRepo := NewMockRepository(ctrl)
 			tt.setupMock(mockRepo)
 
-			result, err := mockRepo.FindByID(context.Background(), tt.id)
+			result, err := mockRepo.FindByID(context.Background(), tt.id, uuid.Nil)
 
 			if tt.wantErr {
 				require.Error(t, err)
@@ -174,7 +174,7 @@ func TestRepository_FindList(t *testing.T) {
 					},
 				}
 				mockRepo.EXPECT().
-					FindList(gomock.Any(), gomock.Any()).
+					FindList(gomock.Any(), gomock.Any(), gomock.Any()).
 					Return(templates, nil)
 			},
 			wantErr: false,
@@ -194,7 +194,7 @@ func TestRepository_FindList(t *testing.T) {
 			},
 			setupMock: func(mockRepo *MockRepository) {
 				mockRepo.EXPECT().
-					FindList(gomock.Any(), gomock.Any()).
+					FindList(gomock.Any(), gomock.Any(), gomock.Any()).
 					Return([]*Template{}, nil)
 			},
 			wantErr: false,
@@ -223,7 +223,7 @@ func TestRepository_FindList(t *testing.T) {
 					},
 				}
 				mockRepo.EXPECT().
-					FindList(gomock.Any(), gomock.Any()).
+					FindList(gomock.Any(), gomock.Any(), gomock.Any()).
 					Return(templates, nil)
 			},
 			wantErr: false,
@@ -253,7 +253,7 @@ func TestRepository_FindList(t *testing.T) {
 					},
 				}
 				mockRepo.EXPECT().
-					FindList(gomock.Any(), gomock.Any()).
+					FindList(gomock.Any(), gomock.Any(), gomock.Any()).
 					Return(templates, nil)
 			},
 			wantErr: false,
@@ -283,7 +283,7 @@ func TestRepository_FindList(t *testing.T) {
 					},
 				}
 				mockRepo.EXPECT().
-					FindList(gomock.Any(), gomock.Any()).
+					FindList(gomock.Any(), gomock.Any(), gomock.Any()).
 					Return(templates, nil)
 			},
 			wantErr: false,
@@ -301,7 +301,7 @@ func TestRepository_FindList(t *testing.T) {
 			},
 			setupMock: func(mockRepo *MockRepository) {
 				mockRepo.EXPECT().
-					FindList(gomock.Any(), gomock.Any()).
+					FindList(gomock.Any(), gomock.Any(), gomock.Any()).
 					Return(nil, errors.New("failed to get database"))
 			},
 			wantErr:     true,
@@ -315,7 +315,7 @@ func TestRepository_FindList(t *testing.T) {
 			},
 			setupMock: func(mockRepo *MockRepository) {
 				mockRepo.EXPECT().
-					FindList(gomock.Any(), gomock.Any()).
+					FindList(gomock.Any(), gomock.Any(), gomock.Any()).
 					Return(nil, errors.New("failed to iterate templates"))
 			},
 			wantErr:     true,
@@ -334,7 +334,7 @@ func TestRepository_FindList(t *testing.T) {
 			mockRepo := NewMockRepository(ctrl)
 			tt.setupMock(mockRepo)
 
-			result, err := mockRepo.FindList(context.Background(), tt.filters)
+			result, err := mockRepo.FindList(context.Background(), tt.filters, uuid.Nil)
 
 			if tt.wantErr {
 				require.Error(t, err)
@@ -516,7 +516,7 @@ func TestRepository_Update(t *testing.T) {
 			},
 			setupMock: func(mockRepo *MockRepository) {
 				mockRepo.EXPECT().
-					Update(gomock.Any(), gomock.Any(), gomock.Any()).
+					Update(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
 					Return(nil)
 			},
 			wantErr: false,
@@ -532,7 +532,7 @@ func TestRepository_Update(t *testing.T) {
 			},
 			setupMock: func(mockRepo *MockRepository) {
 				mockRepo.EXPECT().
-					Update(gomock.Any(), gomock.Any(), gomock.Any()).
+					Update(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
 					Return(nil)
 			},
 			wantErr: false,
@@ -550,7 +550,7 @@ func TestRepository_Update(t *testing.T) {
 			},
 			setupMock: func(mockRepo *MockRepository) {
 				mockRepo.EXPECT().
-					Update(gomock.Any(), gomock.Any(), gomock.Any()).
+					Update(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
 					Return(nil)
 			},
 			wantErr: false,
@@ -563,7 +563,7 @@ func TestRepository_Update(t *testing.T) {
 			},
 			setupMock: func(mockRepo *MockRepository) {
 				mockRepo.EXPECT().
-					Update(gomock.Any(), gomock.Any(), gomock.Any()).
+					Update(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
 					Return(errors.New("failed to get database"))
 			},
 			wantErr:     true,
@@ -577,7 +577,7 @@ func TestRepository_Update(t *testing.T) {
 			},
 			setupMock: func(mockRepo *MockRepository) {
 				mockRepo.EXPECT().
-					Update(gomock.Any(), gomock.Any(), gomock.Any()).
+					Update(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
 					Return(errors.New("failed to update template"))
 			},
 			wantErr:     true,
@@ -596,7 +596,7 @@ func TestRepository_Update(t *testing.T) {
 			mockRepo := NewMockRepository(ctrl)
 			tt.setupMock(mockRepo)
 
-			err := mockRepo.Update(context.Background(), tt.id, tt.updateFields)
+			err := mockRepo.Update(context.Background(), tt.id, tt.updateFields, uuid.Nil)
 
 			if tt.wantErr {
 				require.Error(t, err)
@@ -625,7 +625,7 @@ func TestRepository_Delete(t *testing.T) {
 			hardDelete: false,
 			setupMock: func(mockRepo *MockRepository) {
 				mockRepo.EXPECT().
-					Delete(gomock.Any(), gomock.Any(), gomock.Eq(false)).
+					Delete(gomock.Any(), gomock.Any(), gomock.Eq(false), gomock.Any()).
 					Return(nil)
 			},
 			wantErr: false,
@@ -636,7 +636,7 @@ func TestRepository_Delete(t *testing.T) {
 			hardDelete: true,
 			setupMock: func(mockRepo *MockRepository) {
 				mockRepo.EXPECT().
-					Delete(gomock.Any(), gomock.Any(), gomock.Eq(true)).
+					Delete(gomock.Any(), gomock.Any(), gomock.Eq(true), gomock.Any()).
 					Return(nil)
 			},
 			wantErr: false,
@@ -647,7 +647,7 @@ func TestRepository_Delete(t *testing.T) {
 			hardDelete: false,
 			setupMock: func(mockRepo *MockRepository) {
 				mockRepo.EXPECT().
-					Delete(gomock.Any(), gomock.Any(), gomock.Eq(false)).
+					Delete(gomock.Any(), gomock.Any(), gomock.Eq(false), gomock.Any()).
 					Return(errors.New("TPL-0011"))
 			},
 			wantErr:     true,
@@ -659,7 +659,7 @@ func TestRepository_Delete(t *testing.T) {
 			hardDelete: true,
 			setupMock: func(mockRepo *MockRepository) {
 				mockRepo.EXPECT().
-					Delete(gomock.Any(), gomock.Any(), gomock.Eq(true)).
+					Delete(gomock.Any(), gomock.Any(), gomock.Eq(true), gomock.Any()).
 					Return(errors.New("TPL-0011"))
 			},
 			wantErr:     true,
@@ -671,7 +671,7 @@ func TestRepository_Delete(t *testing.T) {
 			hardDelete: false,
 			setupMock: func(mockRepo *MockRepository) {
 				mockRepo.EXPECT().
-					Delete(gomock.Any(), gomock.Any(), gomock.Eq(false)).
+					Delete(gomock.Any(), gomock.Any(), gomock.Eq(false), gomock.Any()).
 					Return(errors.New("failed to get database"))
 			},
 			wantErr:     true,
@@ -690,7 +690,7 @@ func TestRepository_Delete(t *testing.T) {
 			mockRepo := NewMockRepository(ctrl)
 			tt.setupMock(mockRepo)
 
-			err := mockRepo.Delete(context.Background(), tt.id, tt.hardDelete)
+			err := mockRepo.Delete(context.Background(), tt.id, tt.hardDelete, uuid.Nil)
 
 			if tt.wantErr {
 				require.Error(t, err)
@@ -719,7 +719,7 @@ func TestRepository_FindOutputFormatByID(t *testing.T) {
 			setupMock: func(mockRepo *MockRepository) {
 				format := "PDF"
 				mockRepo.EXPECT().
-					FindOutputFormatByID(gomock.Any(), gomock.Any()).
+					FindOutputFormatByID(gomock.Any(), gomock.Any(), gomock.Any()).
 					Return(&format, nil)
 			},
 			wantErr:        false,
@@ -731,7 +731,7 @@ func TestRepository_FindOutputFormatByID(t *testing.T) {
 			setupMock: func(mockRepo *MockRepository) {
 				format := "HTML"
 				mockRepo.EXPECT().
-					FindOutputFormatByID(gomock.Any(), gomock.Any()).
+					FindOutputFormatByID(gomock.Any(), gomock.Any(), gomock.Any()).
 					Return(&format, nil)
 			},
 			wantErr:        false,
@@ -743,7 +743,7 @@ func TestRepository_FindOutputFormatByID(t *testing.T) {
 			setupMock: func(mockRepo *MockRepository) {
 				format := "CSV"
 				mockRepo.EXPECT().
-					FindOutputFormatByID(gomock.Any(), gomock.Any()).
+					FindOutputFormatByID(gomock.Any(), gomock.Any(), gomock.Any()).
 					Return(&format, nil)
 			},
 			wantErr:        false,
@@ -754,7 +754,7 @@ func TestRepository_FindOutputFormatByID(t *testing.T) {
 			id:   uuid.MustParse("00000000-0000-0000-0000-000000000004"),
 			setupMock: func(mockRepo *MockRepository) {
 				mockRepo.EXPECT().
-					FindOutputFormatByID(gomock.Any(), gomock.Any()).
+					FindOutputFormatByID(gomock.Any(), gomock.Any(), gomock.Any()).
 					Return(nil, errors.New("mongo: no documents in result"))
 			},
 			wantErr:     true,
@@ -765,7 +765,7 @@ func TestRepository_FindOutputFormatByID(t *testing.T) {
 			id:   uuid.MustParse("00000000-0000-0000-0000-000000000005"),
 			setupMock: func(mockRepo *MockRepository) {
 				mockRepo.EXPECT().
-					FindOutputFormatByID(gomock.Any(), gomock.Any()).
+					FindOutputFormatByID(gomock.Any(), gomock.Any(), gomock.Any()).
 					Return(nil, errors.New("failed to get database"))
 			},
 			wantErr:     true,
@@ -784,7 +784,7 @@ func TestRepository_FindOutputFormatByID(t *testing.T) {
 			mockRepo := NewMockRepository(ctrl)
 			tt.setupMock(mockRepo)
 
-			result, err := mockRepo.FindOutputFormatByID(context.Background(), tt.id)
+			result, err := mockRepo.FindOutputFormatByID(context.Background(), tt.id, uuid.Nil)
 
 			if tt.wantErr {
 				require.Error(t, err)
@@ -822,8 +822,8 @@ func TestRepository_FindMappedFieldsAndOutputFormatByID(t *testing.T) {
 					},
 				}
 				mockRepo.EXPECT().
-					FindMappedFieldsAndOutputFormatByID(gomock.Any(), gomock.Any()).
-					Return(&format, mappedFields, nil)
+					FindMappedFieldsAndOutputFormatByID(gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(&format, mappedFields, nil, "", nil)
 			},
 			wantErr:            false,
 			expectedFormat:     "PDF",
@@ -844,8 +844,8 @@ func TestRepository_FindMappedFieldsAndOutputFormatByID(t *testing.T) {
 					},
 				}
 				mockRepo.EXPECT().
-					FindMappedFieldsAndOutputFormatByID(gomock.Any(), gomock.Any()).
-					Return(&format, mappedFields, nil)
+					FindMappedFieldsAndOutputFormatByID(gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(&format, mappedFields, nil, "", nil)
 			},
 			wantErr:            false,
 			expectedFormat:     "HTML",
@@ -857,8 +857,8 @@ func TestRepository_FindMappedFieldsAndOutputFormatByID(t *testing.T) {
 			setupMock: func(mockRepo *MockRepository) {
 				format := "CSV"
 				mockRepo.EXPECT().
-					FindMappedFieldsAndOutputFormatByID(gomock.Any(), gomock.Any()).
-					Return(&format, nil, nil)
+					FindMappedFieldsAndOutputFormatByID(gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(&format, nil, nil, "", nil)
 			},
 			wantErr:            false,
 			expectedFormat:     "CSV",
@@ -869,8 +869,8 @@ func TestRepository_FindMappedFieldsAndOutputFormatByID(t *testing.T) {
 			id:   uuid.MustParse("00000000-0000-0000-0000-000000000004"),
 			setupMock: func(mockRepo *MockRepository) {
 				mockRepo.EXPECT().
-					FindMappedFieldsAndOutputFormatByID(gomock.Any(), gomock.Any()).
-					Return(nil, nil, errors.New("mongo: no documents in result"))
+					FindMappedFieldsAndOutputFormatByID(gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(nil, nil, nil, "", errors.New("mongo: no documents in result"))
 			},
 			wantErr:     true,
 			expectedErr: "mongo: no documents in result",
@@ -880,8 +880,8 @@ func TestRepository_FindMappedFieldsAndOutputFormatByID(t *testing.T) {
 			id:   uuid.MustParse("00000000-0000-0000-0000-000000000005"),
 			setupMock: func(mockRepo *MockRepository) {
 				mockRepo.EXPECT().
-					FindMappedFieldsAndOutputFormatByID(gomock.Any(), gomock.Any()).
-					Return(nil, nil, errors.New("failed to get database"))
+					FindMappedFieldsAndOutputFormatByID(gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(nil, nil, nil, "", errors.New("failed to get database"))
 			},
 			wantErr:     true,
 			expectedErr: "failed to get database",
@@ -899,7 +899,7 @@ func TestRepository_FindMappedFieldsAndOutputFormatByID(t *testing.T) {
 			mockRepo := NewMockRepository(ctrl)
 			tt.setupMock(mockRepo)
 
-			format, mappedFields, err := mockRepo.FindMappedFieldsAndOutputFormatByID(context.Background(), tt.id)
+			format, mappedFields, _, _, err := mockRepo.FindMappedFieldsAndOutputFormatByID(context.Background(), tt.id, uuid.Nil)
 
 			if tt.wantErr {
 				require.Error(t, err)
@@ -1089,7 +1089,7 @@ func TestTemplateMongoDBModel_RoundTrip(t *testing.T) {
 			t.Parallel()
 
 			// Step 1: Create a Template entity via NewTemplate
-			original, err := NewTemplate(tt.id, tt.outputFormat, tt.description, tt.fileName)
+			original, err := NewTemplate(tt.id, uuid.Nil, tt.outputFormat, tt.description, tt.fileName)
 			require.NoError(t, err)
 			require.NotNil(t, original)
 
@@ -1152,7 +1152,7 @@ func TestRepository_FindList_PaginationEdgeCases(t *testing.T) {
 					},
 				}
 				mockRepo.EXPECT().
-					FindList(gomock.Any(), gomock.Any()).
+					FindList(gomock.Any(), gomock.Any(), gomock.Any()).
 					Return(templates, nil)
 			},
 			validate: func(t *testing.T, result []*Template) {
@@ -1169,7 +1169,7 @@ func TestRepository_FindList_PaginationEdgeCases(t *testing.T) {
 			},
 			setupMock: func(mockRepo *MockRepository) {
 				mockRepo.EXPECT().
-					FindList(gomock.Any(), gomock.Any()).
+					FindList(gomock.Any(), gomock.Any(), gomock.Any()).
 					Return([]*Template{}, nil)
 			},
 			validate: func(t *testing.T, result []*Template) {
@@ -1198,7 +1198,7 @@ func TestRepository_FindList_PaginationEdgeCases(t *testing.T) {
 				}
 
 				mockRepo.EXPECT().
-					FindList(gomock.Any(), gomock.Any()).
+					FindList(gomock.Any(), gomock.Any(), gomock.Any()).
 					Return(templates, nil)
 			},
 			validate: func(t *testing.T, result []*Template) {
@@ -1220,7 +1220,7 @@ func TestRepository_FindList_PaginationEdgeCases(t *testing.T) {
 			mockRepo := NewMockRepository(ctrl)
 			tt.setupMock(mockRepo)
 
-			result, err := mockRepo.FindList(context.Background(), tt.filters)
+			result, err := mockRepo.FindList(context.Background(), tt.filters, uuid.Nil)
 			require.NoError(t, err)
 			require.NotNil(t, result)
 			tt.validate(t, result)
@@ -1284,10 +1284,10 @@ func TestRepository_Delete_VerifiesHardDeleteFlag(t *testing.T) {
 
 	// Verify that soft delete passes hardDelete=false
 	mockRepo.EXPECT().
-		Delete(gomock.Any(), gomock.Eq(id), gomock.Eq(false)).
+		Delete(gomock.Any(), gomock.Eq(id), gomock.Eq(false), gomock.Any()).
 		Return(nil)
 
-	err := mockRepo.Delete(context.Background(), id, false)
+	err := mockRepo.Delete(context.Background(), id, false, uuid.Nil)
 	require.NoError(t, err)
 }
 
@@ -1309,10 +1309,10 @@ func TestRepository_Update_VerifiesExactFields(t *testing.T) {
 
 	// Verify the exact update fields are passed to the repository
 	mockRepo.EXPECT().
-		Update(gomock.Any(), gomock.Eq(id), gomock.Eq(updateFields)).
+		Update(gomock.Any(), gomock.Eq(id), gomock.Eq(updateFields), gomock.Any()).
 		Return(nil)
 
-	err := mockRepo.Update(context.Background(), id, updateFields)
+	err := mockRepo.Update(context.Background(), id, updateFields, uuid.Nil)
 	require.NoError(t, err)
 }
 
@@ -1394,10 +1394,10 @@ func TestRepository_ContextCancellation(t *testing.T) {
 		cancel()
 
 		mockRepo.EXPECT().
-			FindByID(gomock.Any(), gomock.Eq(id)).
+			FindByID(gomock.Any(), gomock.Eq(id), gomock.Any()).
 			Return(nil, context.Canceled)
 
-		result, err := mockRepo.FindByID(ctx, id)
+		result, err := mockRepo.FindByID(ctx, id, uuid.Nil)
 		require.Error(t, err)
 		assert.Nil(t, result)
 		assert.ErrorIs(t, err, context.Canceled)
@@ -1417,10 +1417,10 @@ func TestRepository_ContextCancellation(t *testing.T) {
 		filters := http.QueryHeader{Limit: 10, Page: 1}
 
 		mockRepo.EXPECT().
-			FindList(gomock.Any(), gomock.Any()).
+			FindList(gomock.Any(), gomock.Any(), gomock.Any()).
 			Return(nil, context.Canceled)
 
-		result, err := mockRepo.FindList(ctx, filters)
+		result, err := mockRepo.FindList(ctx, filters, uuid.Nil)
 		require.Error(t, err)
 		assert.Nil(t, result)
 		assert.ErrorIs(t, err, context.Canceled)
@@ -1471,10 +1471,10 @@ func TestRepository_ContextCancellation(t *testing.T) {
 		updateFields := &bson.M{"$set": bson.M{"description": "test"}}
 
 		mockRepo.EXPECT().
-			Update(gomock.Any(), gomock.Any(), gomock.Any()).
+			Update(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
 			Return(context.Canceled)
 
-		err := mockRepo.Update(ctx, id, updateFields)
+		err := mockRepo.Update(ctx, id, updateFields, uuid.Nil)
 		require.Error(t, err)
 		assert.ErrorIs(t, err, context.Canceled)
 	})
@@ -1492,10 +1492,10 @@ func TestRepository_ContextCancellation(t *testing.T) {
 		cancel()
 
 		mockRepo.EXPECT().
-			Delete(gomock.Any(), gomock.Any(), gomock.Any()).
+			Delete(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
 			Return(context.Canceled)
 
-		err := mockRepo.Delete(ctx, id, false)
+		err := mockRepo.Delete(ctx, id, false, uuid.Nil)
 		require.Error(t, err)
 		assert.ErrorIs(t, err, context.Canceled)
 	})
@@ -9,6 +9,7 @@ import (
 	"time"
 
 	"github.com/LerianStudio/reporter/pkg/constant"
+	"github.com/LerianStudio/reporter/pkg/model"
 
 	"github.com/google/uuid"
 )
@@ -17,12 +18,25 @@ import (
 // Public fields are required for JSON serialization (json tags) and Swagger documentation.
 // This is a documented deviation from Ring's private-field pattern; use NewTemplate() for programmatic creation.
 type Template struct {
-	ID           uuid.UUID `json:"id" example:"00000000-0000-0000-0000-000000000000"`
-	OutputFormat string    `json:"outputFormat" example:"HTML"`
-	Description  string    `json:"description" example:"Template Financeiro"`
-	FileName     string    `json:"fileName" example:"0196159b-4f26-7300-b3d9-f4f68a7c85f3_1744119295.tpl"`
-	CreatedAt    time.Time `json:"createdAt" example:"2021-01-01T00:00:00Z"`
-	UpdatedAt    time.Time `json:"updatedAt" example:"2021-01-01T00:00:00Z"`
+	ID             uuid.UUID `json:"id" example:"00000000-0000-0000-0000-000000000000"`
+	OrganizationID uuid.UUID `json:"organizationId" example:"00000000-0000-0000-0000-000000000000"`
+	OutputFormat   string    `json:"outputFormat" example:"HTML"`
+	Priority       string    `json:"priority,omitempty" example:"low"`
+	RetentionDays  int       `json:"retentionDays,omitempty" example:"90"`
+	Description    string    `json:"description" example:"Template Financeiro"`
+	FileName       string    `json:"fileName" example:"0196159b-4f26-7300-b3d9-f4f68a7c85f3_1744119295.tpl"`
+	PartialName    string    `json:"partialName,omitempty" example:"layouts/corporate"`
+	CreatedAt      time.Time `json:"createdAt" example:"2021-01-01T00:00:00Z"`
+	UpdatedAt      time.Time `json:"updatedAt" example:"2021-01-01T00:00:00Z"`
+
+	// OutputOptions sets the character encoding and line endings of the reports. Nil writes utf-8 as rendered.
+	OutputOptions *model.OutputOptions `json:"outputOptions,omitempty"`
+}
+
+// IsPartial reports whether the template is a partial, referenced by name from other templates
+// through {% include %}, {% extends %} or {% import %} instead of generating reports itself.
+func (t *Template) IsPartial() bool {
+	return t.PartialName != ""
 }
 
 // NewTemplate creates a new Template entity with invariant validation.
@@ -30,6 +44,7 @@ type Template struct {
 //
 // Parameters:
 //   - id: The template UUID (must not be uuid.Nil)
+//   - organizationID: The owning organization UUID (uuid.Nil is the default tenant)
 //   - outputFormat: The output format (must not be empty)
 //   - description: Optional description (can be empty)
 //   - fileName: The template file name (must not be empty)
@@ -37,7 +52,7 @@ type Template struct {
 // Returns:
 //   - *Template: A validated Template entity
 //   - error: Wrapped ErrMissingRequiredFields if any invariant is violated
-func NewTemplate(id uuid.UUID, outputFormat, description, fileName string) (*Template, error) {
+func NewTemplate(id, organizationID uuid.UUID, outputFormat, description, fileName string) (*Template, error) {
 	if id == uuid.Nil {
 		return nil, fmt.Errorf("template id must not be nil: %w", constant.ErrMissingRequiredFields)
 	}
@@ -53,43 +68,57 @@ func NewTemplate(id uuid.UUID, outputFormat, description, fileName string) (*Tem
 	now := time.Now()
 
 	return &Template{
-		ID:           id,
-		OutputFormat: outputFormat,
-		Description:  description,
-		FileName:     fileName,
-		CreatedAt:    now,
-		UpdatedAt:    now,
+		ID:             id,
+		OrganizationID: organizationID,
+		OutputFormat:   outputFormat,
+		Description:    description,
+		FileName:       fileName,
+		CreatedAt:      now,
+		UpdatedAt:      now,
 	}, nil
 }
 
 // ReconstructTemplate creates a Template from persisted data without validation.
 // Used only for database hydration where data integrity is already ensured.
-func ReconstructTemplate(id uuid.UUID, outputFormat, description, fileName string, createdAt, updatedAt time.Time) *Template {
+func ReconstructTemplate(id, organizationID uuid.UUID, outputFormat, description, fileName string, createdAt, updatedAt time.Time) *Template {
 	return &Template{
-		ID:           id,
-		OutputFormat: outputFormat,
-		Description:  description,
-		FileName:     fileName,
-		CreatedAt:    createdAt,
-		UpdatedAt:    updatedAt,
+		ID:             id,
+		OrganizationID: organizationID,
+		OutputFormat:   outputFormat,
+		Description:    description,
+		FileName:       fileName,
+		CreatedAt:      createdAt,
+		UpdatedAt:      updatedAt,
 	}
 }
 
 // TemplateMongoDBModel represents the MongoDB model for a template
 type TemplateMongoDBModel struct {
-	ID           uuid.UUID                      `bson:"_id"`
-	OutputFormat string                         `bson:"output_format"`
-	Description  string                         `bson:"description"`
-	FileName     string                         `bson:"filename"`
-	MappedFields map[string]map[string][]string `bson:"mapped_fields"`
-	CreatedAt    time.Time                      `bson:"created_at"`
-	UpdatedAt    time.Time                      `bson:"updated_at"`
-	DeletedAt    *time.Time                     `bson:"deleted_at"`
+	ID             uuid.UUID                      `bson:"_id"`
+	OrganizationID uuid.UUID                      `bson:"organization_id"`
+	OutputFormat   string                         `bson:"output_format"`
+	Priority       string                         `bson:"priority,omitempty"`
+	RetentionDays  int                            `bson:"retention_days,omitempty"`
+	Description    string                         `bson:"description"`
+	FileName       string                         `bson:"filename"`
+	PartialName    string                         `bson:"partial_name,omitempty"`
+	OutputOptions  *model.OutputOptions           `bson:"output_options,omitempty"`
+	MappedFields   map[string]map[string][]string `bson:"mapped_fields"`
+	Partials       []string                       `bson:"partials,omitempty"`
+	CreatedAt      time.Time                      `bson:"created_at"`
+	UpdatedAt      time.Time                      `bson:"updated_at"`
+	DeletedAt      *time.Time                     `bson:"deleted_at"`
 }
 
 // ToEntity converts TemplateMongoDBModel to Template using ReconstructTemplate.
 func (tm *TemplateMongoDBModel) ToEntity() *Template {
-	return ReconstructTemplate(tm.ID, tm.OutputFormat, tm.Description, tm.FileName, tm.CreatedAt, tm.UpdatedAt)
+	entity := ReconstructTemplate(tm.ID, tm.OrganizationID, tm.OutputFormat, tm.Description, tm.FileName, tm.CreatedAt, tm.UpdatedAt)
+	entity.Priority = tm.Priority
+	entity.RetentionDays = tm.RetentionDays
+	entity.PartialName = tm.PartialName
+	entity.OutputOptions = tm.OutputOptions
+
+	return entity
 }
 
 // FromEntity populates TemplateMongoDBModel fields from a Template entity.
@@ -97,9 +126,14 @@ func (tm *TemplateMongoDBModel) ToEntity() *Template {
 // MongoDB-only concerns not present on the domain entity.
 func (tm *TemplateMongoDBModel) FromEntity(t *Template) {
 	tm.ID = t.ID
+	tm.OrganizationID = t.OrganizationID
 	tm.OutputFormat = t.OutputFormat
+	tm.Priority = t.Priority
+	tm.RetentionDays = t.RetentionDays
 	tm.Description = t.Description
 	tm.FileName = t.FileName
+	tm.PartialName = t.PartialName
+	tm.OutputOptions = t.OutputOptions
 	tm.CreatedAt = t.CreatedAt
 	tm.UpdatedAt = t.UpdatedAt
 }
@@ -109,12 +143,17 @@ func (tm *TemplateMongoDBModel) FromEntity(t *Template) {
 // This is the preferred way to build a complete model for persistence.
 func FromTemplateEntity(t *Template, mappedFields map[string]map[string][]string) *TemplateMongoDBModel {
 	return &TemplateMongoDBModel{
-		ID:           t.ID,
-		OutputFormat: t.OutputFormat,
-		Description:  t.Description,
-		FileName:     t.FileName,
-		MappedFields: mappedFields,
-		CreatedAt:    t.CreatedAt,
-		UpdatedAt:    t.UpdatedAt,
+		ID:             t.ID,
+		OrganizationID: t.OrganizationID,
+		OutputFormat:   t.OutputFormat,
+		Priority:       t.Priority,
+		RetentionDays:  t.RetentionDays,
+		Description:    t.Description,
+		FileName:       t.FileName,
+		PartialName:    t.PartialName,
+		OutputOptions:  t.OutputOptions,
+		MappedFields:   mappedFields,
+		CreatedAt:      t.CreatedAt,
+		UpdatedAt:      t.UpdatedAt,
 	}
 }
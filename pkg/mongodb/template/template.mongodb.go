@@ -13,6 +13,8 @@ import (
 
 	"github.com/LerianStudio/reporter/pkg"
 	"github.com/LerianStudio/reporter/pkg/constant"
+	"github.com/LerianStudio/reporter/pkg/model"
+	"github.com/LerianStudio/reporter/pkg/mongodb"
 	"github.com/LerianStudio/reporter/pkg/net/http"
 
 	"github.com/LerianStudio/lib-commons/v2/commons"
@@ -29,13 +31,16 @@ import (
 //
 //go:generate mockgen --destination=template.mongodb.mock.go --package=template --copyright_file=../../../COPYRIGHT . Repository
 type Repository interface {
-	FindByID(ctx context.Context, id uuid.UUID) (*Template, error)
-	FindList(ctx context.Context, filters http.QueryHeader) ([]*Template, error)
+	FindByID(ctx context.Context, id, organizationID uuid.UUID) (*Template, error)
+	FindByPartialName(ctx context.Context, partialName string, organizationID uuid.UUID) (*Template, error)
+	FindByPartialReference(ctx context.Context, partialName string, organizationID uuid.UUID) ([]*Template, error)
+	FindList(ctx context.Context, filters http.QueryHeader, organizationID uuid.UUID) ([]*Template, error)
 	Create(ctx context.Context, record *TemplateMongoDBModel) (*Template, error)
-	Update(ctx context.Context, id uuid.UUID, updateFields *bson.M) error
-	Delete(ctx context.Context, id uuid.UUID, hardDelete bool) error
-	FindOutputFormatByID(ctx context.Context, id uuid.UUID) (*string, error)
-	FindMappedFieldsAndOutputFormatByID(ctx context.Context, id uuid.UUID) (*string, map[string]map[string][]string, error)
+	Update(ctx context.Context, id uuid.UUID, updateFields *bson.M, organizationID uuid.UUID) error
+	Delete(ctx context.Context, id uuid.UUID, hardDelete bool, organizationID uuid.UUID) error
+	FindOutputFormatByID(ctx context.Context, id, organizationID uuid.UUID) (*string, error)
+	FindRetentionDays(ctx context.Context) (map[uuid.UUID]int, error)
+	FindMappedFieldsAndOutputFormatByID(ctx context.Context, id, organizationID uuid.UUID) (*string, map[string]map[string][]string, *model.OutputOptions, string, error)
 }
 
 // TemplateMongoDBRepository is a MongoDD-specific implementation of the PackageRepository.
@@ -60,8 +65,8 @@ func NewTemplateMongoDBRepository(mc *libMongo.MongoConnection) (*TemplateMongoD
 	return r, nil
 }
 
-// FindByID retrieves a template from the mongodb using the provided entity_id.
-func (tm *TemplateMongoDBRepository) FindByID(ctx context.Context, id uuid.UUID) (*Template, error) {
+// FindByID retrieves a template of the given organization from the mongodb using the provided entity_id.
+func (tm *TemplateMongoDBRepository) FindByID(ctx context.Context, id, organizationID uuid.UUID) (*Template, error) {
 	_, tracer, reqId, _ := commons.NewTrackingFromContext(ctx)
 
 	ctx, span := tracer.Start(ctx, "repository.template.find_by_id")
@@ -89,7 +94,11 @@ func (tm *TemplateMongoDBRepository) FindByID(ctx context.Context, id uuid.UUID)
 
 	spanFindOne.SetAttributes(attributes...)
 
-	filter := bson.M{"_id": id, "deleted_at": bson.D{{Key: "$eq", Value: nil}}}
+	filter := bson.M{
+		"_id":                             id,
+		constant.MongoFieldOrganizationID: mongodb.OrganizationFilter(organizationID),
+		"deleted_at":                      bson.D{{Key: "$eq", Value: nil}},
+	}
 
 	if err = coll.
 		FindOne(ctx, filter).
@@ -108,8 +117,97 @@ func (tm *TemplateMongoDBRepository) FindByID(ctx context.Context, id uuid.UUID)
 	return record.ToEntity(), nil
 }
 
-// FindList retrieves all templates from the mongodb using the provided filters.
-func (tm *TemplateMongoDBRepository) FindList(ctx context.Context, filters http.QueryHeader) ([]*Template, error) {
+// FindByPartialName retrieves the partial template of the given organization referenced by partialName.
+func (tm *TemplateMongoDBRepository) FindByPartialName(ctx context.Context, partialName string, organizationID uuid.UUID) (*Template, error) {
+	_, tracer, reqId, _ := commons.NewTrackingFromContext(ctx)
+
+	ctx, span := tracer.Start(ctx, "repository.template.find_by_partial_name")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("app.request.request_id", reqId),
+		attribute.String("app.request.partial_name", partialName),
+	)
+
+	db, err := tm.connection.GetDB(ctx)
+	if err != nil {
+		libOpentelemetry.HandleSpanError(&span, "Failed to get database", err)
+
+		return nil, err
+	}
+
+	coll := db.Database(strings.ToLower(tm.Database)).Collection(strings.ToLower(constant.MongoCollectionTemplate))
+
+	filter := bson.M{
+		constant.MongoFieldPartialName:    partialName,
+		constant.MongoFieldOrganizationID: mongodb.OrganizationFilter(organizationID),
+		"deleted_at":                      bson.D{{Key: "$eq", Value: nil}},
+	}
+
+	var record TemplateMongoDBModel
+
+	if err = coll.FindOne(ctx, filter).Decode(&record); err != nil {
+		libOpentelemetry.HandleSpanError(&span, "Failed to find template by partial name", err)
+
+		return nil, err
+	}
+
+	return record.ToEntity(), nil
+}
+
+// FindByPartialReference retrieves the templates of the given organization, partials included, that
+// reference the partial named partialName directly or through other partials.
+func (tm *TemplateMongoDBRepository) FindByPartialReference(ctx context.Context, partialName string, organizationID uuid.UUID) ([]*Template, error) {
+	_, tracer, reqId, _ := commons.NewTrackingFromContext(ctx)
+
+	ctx, span := tracer.Start(ctx, "repository.template.find_by_partial_reference")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("app.request.request_id", reqId),
+		attribute.String("app.request.partial_name", partialName),
+	)
+
+	db, err := tm.connection.GetDB(ctx)
+	if err != nil {
+		libOpentelemetry.HandleSpanError(&span, "Failed to get database", err)
+
+		return nil, err
+	}
+
+	coll := db.Database(strings.ToLower(tm.Database)).Collection(strings.ToLower(constant.MongoCollectionTemplate))
+
+	filter := bson.M{
+		constant.MongoFieldPartials:       partialName,
+		constant.MongoFieldOrganizationID: mongodb.OrganizationFilter(organizationID),
+		"deleted_at":                      bson.D{{Key: "$eq", Value: nil}},
+	}
+
+	cur, err := coll.Find(ctx, filter)
+	if err != nil {
+		libOpentelemetry.HandleSpanError(&span, "Failed to find templates by partial reference", err)
+
+		return nil, err
+	}
+
+	var records []TemplateMongoDBModel
+
+	if err := cur.All(ctx, &records); err != nil {
+		libOpentelemetry.HandleSpanError(&span, "Failed to decode templates by partial reference", err)
+
+		return nil, err
+	}
+
+	templates := make([]*Template, 0, len(records))
+	for i := range records {
+		templates = append(templates, records[i].ToEntity())
+	}
+
+	return templates, nil
+}
+
+// FindList retrieves all templates of the given organization from the mongodb using the provided filters.
+func (tm *TemplateMongoDBRepository) FindList(ctx context.Context, filters http.QueryHeader, organizationID uuid.UUID) ([]*Template, error) {
 	_, tracer, reqId, _ := commons.NewTrackingFromContext(ctx)
 
 	ctx, span := tracer.Start(ctx, "repository.template.find_list")
@@ -134,7 +232,9 @@ func (tm *TemplateMongoDBRepository) FindList(ctx context.Context, filters http.
 
 	coll := db.Database(strings.ToLower(tm.Database)).Collection(strings.ToLower(constant.MongoCollectionTemplate))
 
-	queryFilter := bson.M{}
+	queryFilter := bson.M{
+		constant.MongoFieldOrganizationID: mongodb.OrganizationFilter(organizationID),
+	}
 
 	if !commons.IsNilOrEmpty(&filters.OutputFormat) {
 		queryFilter["output_format"] = filters.OutputFormat
@@ -208,8 +308,8 @@ func (tm *TemplateMongoDBRepository) FindList(ctx context.Context, filters http.
 	return templates, nil
 }
 
-// FindOutputFormatByID retrieves outputFormat of a template provided entity_id.
-func (tm *TemplateMongoDBRepository) FindOutputFormatByID(ctx context.Context, id uuid.UUID) (*string, error) {
+// FindOutputFormatByID retrieves outputFormat of a template of the given organization provided entity_id.
+func (tm *TemplateMongoDBRepository) FindOutputFormatByID(ctx context.Context, id, organizationID uuid.UUID) (*string, error) {
 	_, tracer, reqId, _ := commons.NewTrackingFromContext(ctx)
 
 	ctx, span := tracer.Start(ctx, "repository.template.find_output_format_by_id")
@@ -238,7 +338,11 @@ func (tm *TemplateMongoDBRepository) FindOutputFormatByID(ctx context.Context, i
 		"_id":           0,
 	})
 
-	filter := bson.M{"_id": id, "deleted_at": bson.D{{Key: "$eq", Value: nil}}}
+	filter := bson.M{
+		"_id":                             id,
+		constant.MongoFieldOrganizationID: mongodb.OrganizationFilter(organizationID),
+		"deleted_at":                      bson.D{{Key: "$eq", Value: nil}},
+	}
 
 	if err = coll.
 		FindOne(ctx, filter, opts).
@@ -250,6 +354,56 @@ func (tm *TemplateMongoDBRepository) FindOutputFormatByID(ctx context.Context, i
 	return &record.OutputFormat, nil
 }
 
+// FindRetentionDays retrieves the retention days of every template of every organization that sets its own,
+// keyed by template ID. Deleted templates are included, since their reports remain governed by their rule.
+func (tm *TemplateMongoDBRepository) FindRetentionDays(ctx context.Context) (map[uuid.UUID]int, error) {
+	_, tracer, reqId, _ := commons.NewTrackingFromContext(ctx)
+
+	ctx, span := tracer.Start(ctx, "repository.template.find_retention_days")
+	defer span.End()
+
+	span.SetAttributes(attribute.String("app.request.request_id", reqId))
+
+	db, err := tm.connection.GetDB(ctx)
+	if err != nil {
+		libOpentelemetry.HandleSpanError(&span, "Failed to get database", err)
+
+		return nil, err
+	}
+
+	coll := db.Database(strings.ToLower(tm.Database)).Collection(strings.ToLower(constant.MongoCollectionTemplate))
+
+	opts := options.Find().SetProjection(bson.M{
+		"_id":            1,
+		"retention_days": 1,
+	})
+
+	cur, err := coll.Find(ctx, bson.M{"retention_days": bson.M{"$gt": 0}}, opts)
+	if err != nil {
+		libOpentelemetry.HandleSpanError(&span, "Failed to find template retention days", err)
+
+		return nil, err
+	}
+
+	var records []struct {
+		ID            uuid.UUID `bson:"_id"`
+		RetentionDays int       `bson:"retention_days"`
+	}
+
+	if err := cur.All(ctx, &records); err != nil {
+		libOpentelemetry.HandleSpanError(&span, "Failed to decode template retention days", err)
+
+		return nil, err
+	}
+
+	retentionDays := make(map[uuid.UUID]int, len(records))
+	for _, record := range records {
+		retentionDays[record.ID] = record.RetentionDays
+	}
+
+	return retentionDays, nil
+}
+
 // Create inserts a new package entity into mongo.
 func (tm *TemplateMongoDBRepository) Create(ctx context.Context, record *TemplateMongoDBModel) (*Template, error) {
 	_, tracer, reqId, _ := commons.NewTrackingFromContext(ctx)
@@ -298,8 +452,8 @@ func (tm *TemplateMongoDBRepository) Create(ctx context.Context, record *Templat
 	return record.ToEntity(), nil
 }
 
-// Update a template entity into mongodb.
-func (tm *TemplateMongoDBRepository) Update(ctx context.Context, id uuid.UUID, updateFields *bson.M) error {
+// Update a template entity of the given organization into mongodb.
+func (tm *TemplateMongoDBRepository) Update(ctx context.Context, id uuid.UUID, updateFields *bson.M, organizationID uuid.UUID) error {
 	_, tracer, reqId, _ := commons.NewTrackingFromContext(ctx)
 
 	ctx, span := tracer.Start(ctx, "repository.template.update")
@@ -334,7 +488,10 @@ func (tm *TemplateMongoDBRepository) Update(ctx context.Context, id uuid.UUID, u
 		libOpentelemetry.HandleSpanError(&spanUpdate, "Failed to convert template record from entity to JSON string", err)
 	}
 
-	filter := bson.M{"_id": id}
+	filter := bson.M{
+		"_id":                             id,
+		constant.MongoFieldOrganizationID: mongodb.OrganizationFilter(organizationID),
+	}
 
 	_, err = coll.UpdateOne(ctx, filter, updateFields, opts)
 	if err != nil {
@@ -347,8 +504,8 @@ func (tm *TemplateMongoDBRepository) Update(ctx context.Context, id uuid.UUID, u
 	return nil
 }
 
-// Delete a template entity into mongodb with soft delete or not.
-func (tm *TemplateMongoDBRepository) Delete(ctx context.Context, id uuid.UUID, hardDelete bool) error {
+// Delete a template entity of the given organization into mongodb with soft delete or not.
+func (tm *TemplateMongoDBRepository) Delete(ctx context.Context, id uuid.UUID, hardDelete bool, organizationID uuid.UUID) error {
 	logger, tracer, reqId, _ := commons.NewTrackingFromContext(ctx)
 
 	ctx, span := tracer.Start(ctx, "repository.template.delete")
@@ -378,6 +535,7 @@ func (tm *TemplateMongoDBRepository) Delete(ctx context.Context, id uuid.UUID, h
 
 	filter := bson.D{
 		{Key: "_id", Value: id},
+		{Key: constant.MongoFieldOrganizationID, Value: mongodb.OrganizationFilter(organizationID)},
 		{Key: "deleted_at", Value: nil},
 	}
 
@@ -420,8 +578,9 @@ func (tm *TemplateMongoDBRepository) Delete(ctx context.Context, id uuid.UUID, h
 	return nil
 }
 
-// FindMappedFieldsAndOutputFormatByID find mapped fields of template and output format.
-func (tm *TemplateMongoDBRepository) FindMappedFieldsAndOutputFormatByID(ctx context.Context, id uuid.UUID) (*string, map[string]map[string][]string, error) {
+// FindMappedFieldsAndOutputFormatByID find mapped fields, output format, output options and priority of a template of the given organization.
+// Partials cannot generate reports on their own, so they return constant.ErrPartialTemplateReport.
+func (tm *TemplateMongoDBRepository) FindMappedFieldsAndOutputFormatByID(ctx context.Context, id, organizationID uuid.UUID) (*string, map[string]map[string][]string, *model.OutputOptions, string, error) {
 	_, tracer, reqId, _ := commons.NewTrackingFromContext(ctx)
 
 	ctx, span := tracer.Start(ctx, "repository.template.find_mapped_fields_and_output_format_by_id")
@@ -436,30 +595,45 @@ func (tm *TemplateMongoDBRepository) FindMappedFieldsAndOutputFormatByID(ctx con
 	if err != nil {
 		libOpentelemetry.HandleSpanError(&span, "Failed to get database", err)
 
-		return nil, nil, err
+		return nil, nil, nil, "", err
 	}
 
 	coll := db.Database(strings.ToLower(tm.Database)).Collection(strings.ToLower(constant.MongoCollectionTemplate))
 
 	var record struct {
-		OutputFormat string                         `bson:"output_format"`
-		MappedFields map[string]map[string][]string `bson:"mapped_fields"`
+		OutputFormat  string                         `bson:"output_format"`
+		Priority      string                         `bson:"priority"`
+		MappedFields  map[string]map[string][]string `bson:"mapped_fields"`
+		PartialName   string                         `bson:"partial_name"`
+		OutputOptions *model.OutputOptions           `bson:"output_options"`
 	}
 
 	opts := options.FindOne().SetProjection(bson.M{
-		"output_format": 1,
-		"mapped_fields": 1,
-		"_id":           0,
+		"output_format":                1,
+		"priority":                     1,
+		"mapped_fields":                1,
+		constant.MongoFieldPartialName: 1,
+		"output_options":               1,
+		"_id":                          0,
 	})
 
-	filter := bson.M{"_id": id, "deleted_at": bson.D{{Key: "$eq", Value: nil}}}
+	filter := bson.M{
+		"_id":                             id,
+		constant.MongoFieldOrganizationID: mongodb.OrganizationFilter(organizationID),
+		"deleted_at":                      bson.D{{Key: "$eq", Value: nil}},
+	}
 
 	if err = coll.
 		FindOne(ctx, filter, opts).
 		Decode(&record); err != nil {
 		libOpentelemetry.HandleSpanError(&span, "Failed to find template output_format and mapped_fields by entity ID", err)
-		return nil, nil, err
+		return nil, nil, nil, "", err
+	}
+
+	// Partials only render as part of other templates
+	if record.PartialName != "" {
+		return nil, nil, nil, "", constant.ErrPartialTemplateReport
 	}
 
-	return &record.OutputFormat, record.MappedFields, nil
+	return &record.OutputFormat, record.MappedFields, record.OutputOptions, record.Priority, nil
 }
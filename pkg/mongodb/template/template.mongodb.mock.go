@@ -13,6 +13,7 @@ import (
 	context "context"
 	reflect "reflect"
 
+	model "github.com/LerianStudio/reporter/pkg/model"
 	http "github.com/LerianStudio/reporter/pkg/net/http"
 	uuid "github.com/google/uuid"
 	bson "go.mongodb.org/mongo-driver/bson"
@@ -59,90 +60,137 @@ func (mr *MockRepositoryMockRecorder) Create(ctx, record any) *gomock.Call {
 }
 
 // Delete mocks base method.
-func (m *MockRepository) Delete(ctx context.Context, id uuid.UUID, hardDelete bool) error {
+func (m *MockRepository) Delete(ctx context.Context, id uuid.UUID, hardDelete bool, organizationID uuid.UUID) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Delete", ctx, id, hardDelete)
+	ret := m.ctrl.Call(m, "Delete", ctx, id, hardDelete, organizationID)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // Delete indicates an expected call of Delete.
-func (mr *MockRepositoryMockRecorder) Delete(ctx, id, hardDelete any) *gomock.Call {
+func (mr *MockRepositoryMockRecorder) Delete(ctx, id, hardDelete, organizationID any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockRepository)(nil).Delete), ctx, id, hardDelete)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockRepository)(nil).Delete), ctx, id, hardDelete, organizationID)
 }
 
 // FindByID mocks base method.
-func (m *MockRepository) FindByID(ctx context.Context, id uuid.UUID) (*Template, error) {
+func (m *MockRepository) FindByID(ctx context.Context, id, organizationID uuid.UUID) (*Template, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "FindByID", ctx, id)
+	ret := m.ctrl.Call(m, "FindByID", ctx, id, organizationID)
 	ret0, _ := ret[0].(*Template)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // FindByID indicates an expected call of FindByID.
-func (mr *MockRepositoryMockRecorder) FindByID(ctx, id any) *gomock.Call {
+func (mr *MockRepositoryMockRecorder) FindByID(ctx, id, organizationID any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByID", reflect.TypeOf((*MockRepository)(nil).FindByID), ctx, id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByID", reflect.TypeOf((*MockRepository)(nil).FindByID), ctx, id, organizationID)
+}
+
+// FindByPartialName mocks base method.
+func (m *MockRepository) FindByPartialName(ctx context.Context, partialName string, organizationID uuid.UUID) (*Template, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByPartialName", ctx, partialName, organizationID)
+	ret0, _ := ret[0].(*Template)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByPartialName indicates an expected call of FindByPartialName.
+func (mr *MockRepositoryMockRecorder) FindByPartialName(ctx, partialName, organizationID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByPartialName", reflect.TypeOf((*MockRepository)(nil).FindByPartialName), ctx, partialName, organizationID)
+}
+
+// FindByPartialReference mocks base method.
+func (m *MockRepository) FindByPartialReference(ctx context.Context, partialName string, organizationID uuid.UUID) ([]*Template, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByPartialReference", ctx, partialName, organizationID)
+	ret0, _ := ret[0].([]*Template)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByPartialReference indicates an expected call of FindByPartialReference.
+func (mr *MockRepositoryMockRecorder) FindByPartialReference(ctx, partialName, organizationID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByPartialReference", reflect.TypeOf((*MockRepository)(nil).FindByPartialReference), ctx, partialName, organizationID)
 }
 
 // FindList mocks base method.
-func (m *MockRepository) FindList(ctx context.Context, filters http.QueryHeader) ([]*Template, error) {
+func (m *MockRepository) FindList(ctx context.Context, filters http.QueryHeader, organizationID uuid.UUID) ([]*Template, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "FindList", ctx, filters)
+	ret := m.ctrl.Call(m, "FindList", ctx, filters, organizationID)
 	ret0, _ := ret[0].([]*Template)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // FindList indicates an expected call of FindList.
-func (mr *MockRepositoryMockRecorder) FindList(ctx, filters any) *gomock.Call {
+func (mr *MockRepositoryMockRecorder) FindList(ctx, filters, organizationID any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindList", reflect.TypeOf((*MockRepository)(nil).FindList), ctx, filters)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindList", reflect.TypeOf((*MockRepository)(nil).FindList), ctx, filters, organizationID)
 }
 
 // FindMappedFieldsAndOutputFormatByID mocks base method.
-func (m *MockRepository) FindMappedFieldsAndOutputFormatByID(ctx context.Context, id uuid.UUID) (*string, map[string]map[string][]string, error) {
+func (m *MockRepository) FindMappedFieldsAndOutputFormatByID(ctx context.Context, id, organizationID uuid.UUID) (*string, map[string]map[string][]string, *model.OutputOptions, string, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "FindMappedFieldsAndOutputFormatByID", ctx, id)
+	ret := m.ctrl.Call(m, "FindMappedFieldsAndOutputFormatByID", ctx, id, organizationID)
 	ret0, _ := ret[0].(*string)
 	ret1, _ := ret[1].(map[string]map[string][]string)
-	ret2, _ := ret[2].(error)
-	return ret0, ret1, ret2
+	ret2, _ := ret[2].(*model.OutputOptions)
+	ret3, _ := ret[3].(string)
+	ret4, _ := ret[4].(error)
+	return ret0, ret1, ret2, ret3, ret4
 }
 
 // FindMappedFieldsAndOutputFormatByID indicates an expected call of FindMappedFieldsAndOutputFormatByID.
-func (mr *MockRepositoryMockRecorder) FindMappedFieldsAndOutputFormatByID(ctx, id any) *gomock.Call {
+func (mr *MockRepositoryMockRecorder) FindMappedFieldsAndOutputFormatByID(ctx, id, organizationID any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindMappedFieldsAndOutputFormatByID", reflect.TypeOf((*MockRepository)(nil).FindMappedFieldsAndOutputFormatByID), ctx, id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindMappedFieldsAndOutputFormatByID", reflect.TypeOf((*MockRepository)(nil).FindMappedFieldsAndOutputFormatByID), ctx, id, organizationID)
 }
 
 // FindOutputFormatByID mocks base method.
-func (m *MockRepository) FindOutputFormatByID(ctx context.Context, id uuid.UUID) (*string, error) {
+func (m *MockRepository) FindOutputFormatByID(ctx context.Context, id, organizationID uuid.UUID) (*string, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "FindOutputFormatByID", ctx, id)
+	ret := m.ctrl.Call(m, "FindOutputFormatByID", ctx, id, organizationID)
 	ret0, _ := ret[0].(*string)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // FindOutputFormatByID indicates an expected call of FindOutputFormatByID.
-func (mr *MockRepositoryMockRecorder) FindOutputFormatByID(ctx, id any) *gomock.Call {
+func (mr *MockRepositoryMockRecorder) FindOutputFormatByID(ctx, id, organizationID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindOutputFormatByID", reflect.TypeOf((*MockRepository)(nil).FindOutputFormatByID), ctx, id, organizationID)
+}
+
+// FindRetentionDays mocks base method.
+func (m *MockRepository) FindRetentionDays(ctx context.Context) (map[uuid.UUID]int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindRetentionDays", ctx)
+	ret0, _ := ret[0].(map[uuid.UUID]int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindRetentionDays indicates an expected call of FindRetentionDays.
+func (mr *MockRepositoryMockRecorder) FindRetentionDays(ctx any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindOutputFormatByID", reflect.TypeOf((*MockRepository)(nil).FindOutputFormatByID), ctx, id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindRetentionDays", reflect.TypeOf((*MockRepository)(nil).FindRetentionDays), ctx)
 }
 
 // Update mocks base method.
-func (m *MockRepository) Update(ctx context.Context, id uuid.UUID, updateFields *bson.M) error {
+func (m *MockRepository) Update(ctx context.Context, id uuid.UUID, updateFields *bson.M, organizationID uuid.UUID) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Update", ctx, id, updateFields)
+	ret := m.ctrl.Call(m, "Update", ctx, id, updateFields, organizationID)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // Update indicates an expected call of Update.
-func (mr *MockRepositoryMockRecorder) Update(ctx, id, updateFields any) *gomock.Call {
+func (mr *MockRepositoryMockRecorder) Update(ctx, id, updateFields, organizationID any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockRepository)(nil).Update), ctx, id, updateFields)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockRepository)(nil).Update), ctx, id, updateFields, organizationID)
 }
@@ -331,7 +331,7 @@ func TestNewTemplate(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			t.Parallel()
 
-			got, err := NewTemplate(tt.id, tt.outputFormat, tt.description, tt.fileName)
+			got, err := NewTemplate(tt.id, uuid.Nil, tt.outputFormat, tt.description, tt.fileName)
 
 			if tt.wantErr {
 				require.Error(t, err)
@@ -403,7 +403,7 @@ func TestReconstructTemplate(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			t.Parallel()
 
-			got := ReconstructTemplate(tt.id, tt.outputFormat, tt.description, tt.fileName, tt.createdAt, tt.updatedAt)
+			got := ReconstructTemplate(tt.id, uuid.Nil, tt.outputFormat, tt.description, tt.fileName, tt.createdAt, tt.updatedAt)
 
 			require.NotNil(t, got)
 			assert.Equal(t, tt.id, got.ID)
@@ -432,7 +432,7 @@ func TestReconstructTemplate_MatchesToEntity(t *testing.T) {
 	}
 
 	fromToEntity := mongoModel.ToEntity()
-	fromReconstruct := ReconstructTemplate(id, "PDF", "Financial Report Template", "0196159b-4f26-7300-b3d9-f4f68a7c85f3_1744119295.tpl", now, now)
+	fromReconstruct := ReconstructTemplate(id, uuid.Nil, "PDF", "Financial Report Template", "0196159b-4f26-7300-b3d9-f4f68a7c85f3_1744119295.tpl", now, now)
 
 	assert.Equal(t, fromToEntity.ID, fromReconstruct.ID)
 	assert.Equal(t, fromToEntity.OutputFormat, fromReconstruct.OutputFormat)
@@ -525,7 +525,7 @@ func TestFromTemplateEntity_RoundTrip(t *testing.T) {
 	t.Parallel()
 
 	id := uuid.New()
-	entity, err := NewTemplate(id, "pdf", "Financial Report", "template_123.tpl")
+	entity, err := NewTemplate(id, uuid.Nil, "pdf", "Financial Report", "template_123.tpl")
 	require.NoError(t, err)
 
 	mappedFields := map[string]map[string][]string{
@@ -602,3 +602,21 @@ func TestTemplateMongoDBModel_BSONTags(t *testing.T) {
 	assert.NotNil(t, mongoModel.MappedFields)
 	assert.Equal(t, &deletedAt, mongoModel.DeletedAt)
 }
+
+func TestTemplateMongoDBModel_PartialName(t *testing.T) {
+	t.Parallel()
+
+	entity, err := NewTemplate(uuid.New(), uuid.New(), "html", "Corporate layout", "partials/layouts/corporate.tpl")
+	require.NoError(t, err)
+	assert.False(t, entity.IsPartial())
+
+	entity.PartialName = "layouts/corporate"
+	assert.True(t, entity.IsPartial())
+
+	model := FromTemplateEntity(entity, nil)
+	assert.Equal(t, "layouts/corporate", model.PartialName)
+
+	restored := model.ToEntity()
+	assert.Equal(t, "layouts/corporate", restored.PartialName)
+	assert.True(t, restored.IsPartial())
+}
@@ -0,0 +1,37 @@
+// Copyright (c) 2026 Lerian Studio. All rights reserved.
+// Use of this source code is governed by the Elastic License 2.0
+// that can be found in the LICENSE file.
+
+package mongodb
+
+import (
+	"github.com/google/uuid"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// OrganizationFilter returns the query value matching documents owned by the given organization.
+// uuid.Nil is the default tenant of single-tenant deployments and also matches documents created
+// before tenancy existed, which have no organization_id field.
+func OrganizationFilter(organizationID uuid.UUID) any {
+	if organizationID == uuid.Nil {
+		return bson.M{"$in": bson.A{uuid.Nil, nil}}
+	}
+
+	return organizationID
+}
+
+// OrganizationValues returns the organization_id values of the given organizations for $in and $nin queries.
+// Like OrganizationFilter, uuid.Nil also matches documents with no organization_id field.
+func OrganizationValues(organizationIDs []uuid.UUID) bson.A {
+	values := make(bson.A, 0, len(organizationIDs)+1)
+
+	for _, organizationID := range organizationIDs {
+		values = append(values, organizationID)
+
+		if organizationID == uuid.Nil {
+			values = append(values, nil)
+		}
+	}
+
+	return values
+}
@@ -0,0 +1,34 @@
+// Copyright (c) 2026 Lerian Studio. All rights reserved.
+// Use of this source code is governed by the Elastic License 2.0
+// that can be found in the LICENSE file.
+
+package mongodb
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+func TestOrganizationFilter(t *testing.T) {
+	t.Parallel()
+
+	organizationID := uuid.New()
+
+	assert.Equal(t, organizationID, OrganizationFilter(organizationID))
+	assert.Equal(t, bson.M{"$in": bson.A{uuid.Nil, nil}}, OrganizationFilter(uuid.Nil),
+		"default tenant must also match documents created before tenancy existed")
+}
+
+func TestOrganizationValues(t *testing.T) {
+	t.Parallel()
+
+	organizationID := uuid.New()
+
+	assert.Equal(t, bson.A{organizationID}, OrganizationValues([]uuid.UUID{organizationID}))
+	assert.Equal(t, bson.A{uuid.Nil, nil, organizationID}, OrganizationValues([]uuid.UUID{uuid.Nil, organizationID}),
+		"default tenant must also match documents created before tenancy existed")
+	assert.Empty(t, OrganizationValues(nil))
+}
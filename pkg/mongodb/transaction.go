@@ -0,0 +1,25 @@
+// Copyright (c) 2026 Lerian Studio. All rights reserved.
+// Use of this source code is governed by the Elastic License 2.0
+// that can be found in the LICENSE file.
+
+package mongodb
+
+import (
+	"errors"
+
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// illegalOperationCode is the server error code returned when a transaction is started on a standalone server.
+const illegalOperationCode = 20
+
+// IsTransactionUnsupported reports whether err means the deployment does not support transactions,
+// which require a replica set or a sharded cluster.
+func IsTransactionUnsupported(err error) bool {
+	var serverErr mongo.ServerError
+	if !errors.As(err, &serverErr) {
+		return false
+	}
+
+	return serverErr.HasErrorCode(illegalOperationCode)
+}
@@ -0,0 +1,45 @@
+// Copyright (c) 2026 Lerian Studio. All rights reserved.
+// Use of this source code is governed by the Elastic License 2.0
+// that can be found in the LICENSE file.
+
+package mongodb
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+func TestIsTransactionUnsupported(t *testing.T) {
+	t.Parallel()
+
+	standalone := mongo.CommandError{
+		Code:    20,
+		Name:    "IllegalOperation",
+		Message: "Transaction numbers are only allowed on a replica set member or mongos",
+	}
+
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{name: "Standalone server", err: standalone, want: true},
+		{name: "Wrapped standalone server error", err: fmt.Errorf("insert report: %w", standalone), want: true},
+		{name: "Other server error", err: mongo.CommandError{Code: 11000, Name: "DuplicateKey"}},
+		{name: "Not a server error", err: errors.New("connection refused")},
+		{name: "Nil error"},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, tt.want, IsTransactionUnsupported(tt.err))
+		})
+	}
+}
@@ -26,6 +26,11 @@ func IsBusinessError(err error) bool {
 		return true
 	}
 
+	var goneErr pkg.EntityGoneError
+	if errors.As(err, &goneErr) {
+		return true
+	}
+
 	var validationKnownFieldsErr pkg.ValidationKnownFieldsError
 	if errors.As(err, &validationKnownFieldsErr) {
 		return true
@@ -70,6 +75,11 @@ func WithError(c *fiber.Ctx, err error) error {
 		return Conflict(c, conflictErr.Code, conflictErr.Title, conflictErr.Message)
 	}
 
+	var goneErr pkg.EntityGoneError
+	if errors.As(err, &goneErr) {
+		return Gone(c, goneErr.Code, goneErr.Title, goneErr.Message)
+	}
+
 	var validationKnownFieldsErr pkg.ValidationKnownFieldsError
 	if errors.As(err, &validationKnownFieldsErr) {
 		return BadRequest(c, validationKnownFieldsErr)
@@ -162,6 +162,11 @@ func TestIsBusinessError(t *testing.T) {
 			err:      pkg.EntityConflictError{Code: "E002", Title: "Conflict", Message: "conflict"},
 			expected: true,
 		},
+		{
+			name:     "EntityGoneError is business error",
+			err:      pkg.EntityGoneError{Code: "E009", Title: "Gone", Message: "gone"},
+			expected: true,
+		},
 		{
 			name:     "ValidationKnownFieldsError is business error",
 			err:      pkg.ValidationKnownFieldsError{Code: "E003", Title: "Validation", Message: "bad fields"},
@@ -248,6 +253,15 @@ func TestWithError_UnwrappedErrors(t *testing.T) {
 			},
 			expectedStatusCode: http.StatusConflict,
 		},
+		{
+			name: "direct EntityGoneError returns 410",
+			err: pkg.EntityGoneError{
+				Code:    "TPL-0068",
+				Title:   "Report Purged",
+				Message: "report purged",
+			},
+			expectedStatusCode: http.StatusGone,
+		},
 		{
 			name: "direct ValidationError returns 400",
 			err: pkg.ValidationError{
@@ -5,8 +5,11 @@
 package http
 
 import (
+	"net/http"
+
 	"github.com/LerianStudio/reporter/pkg"
 
+	"github.com/LerianStudio/lib-commons/v2/commons"
 	commonsHTTP "github.com/LerianStudio/lib-commons/v2/commons/net/http"
 	"github.com/gofiber/fiber/v2"
 )
@@ -41,6 +44,16 @@ func Conflict(c *fiber.Ctx, code, title, message string) error {
 	return commonsHTTP.Conflict(c, code, title, message)
 }
 
+// Gone sends an HTTP 410 Gone response with a custom code, title and message.
+// lib-commons has no helper for this status, so the response body mirrors commonsHTTP.Conflict.
+func Gone(c *fiber.Ctx, code, title, message string) error {
+	return c.Status(http.StatusGone).JSON(commons.Response{
+		Code:    code,
+		Title:   title,
+		Message: message,
+	})
+}
+
 // UnprocessableEntity sends an HTTP 422 Unprocessable Entity response with a custom code, title and message.
 // Delegates to lib-commons commonsHTTP.UnprocessableEntity for consistency.
 func UnprocessableEntity(c *fiber.Ctx, code, title, message string) error {
@@ -263,6 +263,53 @@ func TestConflict(t *testing.T) {
 	}
 }
 
+func TestGone(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		code    string
+		title   string
+		message string
+	}{
+		{
+			name:    "Success - returns 410 with custom fields",
+			code:    "GONE_001",
+			title:   "Gone",
+			message: "Entity was purged",
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			app := fiber.New()
+			app.Get("/test", func(c *fiber.Ctx) error {
+				return Gone(c, tt.code, tt.title, tt.message)
+			})
+
+			req := httptest.NewRequest(http.MethodGet, "/test", nil)
+			resp, err := app.Test(req)
+			require.NoError(t, err)
+
+			defer resp.Body.Close()
+
+			assert.Equal(t, http.StatusGone, resp.StatusCode)
+
+			body, err := io.ReadAll(resp.Body)
+			require.NoError(t, err)
+
+			var result map[string]string
+			require.NoError(t, json.Unmarshal(body, &result))
+			assert.Equal(t, tt.code, result["code"])
+			assert.Equal(t, tt.title, result["title"])
+			assert.Equal(t, tt.message, result["message"])
+		})
+	}
+}
+
 func TestUnprocessableEntity(t *testing.T) {
 	t.Parallel()
 
@@ -15,6 +15,7 @@ import (
 	"time"
 
 	cn "github.com/LerianStudio/reporter/pkg/constant"
+	"github.com/LerianStudio/reporter/pkg/model"
 
 	"github.com/LerianStudio/lib-commons/v2/commons/log"
 	"github.com/chromedp/cdproto/network"
@@ -29,14 +30,16 @@ var _ PDFGenerator = (*WorkerPool)(nil)
 
 // PDFGenerator defines the interface for submitting PDF generation tasks.
 type PDFGenerator interface {
-	// Submit sends an HTML string to the pool for PDF generation and blocks until completion.
-	Submit(html, filename string) error
+	// Submit sends an HTML string to the pool for PDF generation with the page setup of the options
+	// and blocks until completion. Nil options print Letter pages with the default margins.
+	Submit(html, filename string, options *model.PDFOptions) error
 }
 
 // Task represents a task to generate a PDF.
 type Task struct {
 	HTML     string
 	Filename string
+	Options  *model.PDFOptions
 	Result   chan error
 }
 
@@ -132,7 +135,7 @@ func (wp *WorkerPool) processTask(allocCtx context.Context, task Task) {
 		return
 	}
 
-	pdfBuf, err := wp.generatePDFFromFile(ctxTimeout, tmpFileName)
+	pdfBuf, err := wp.generatePDFFromFile(ctxTimeout, tmpFileName, task.Options)
 
 	err = wp.processPDFResult(pdfBuf, task.Filename, err)
 
@@ -167,7 +170,7 @@ func (wp *WorkerPool) createTempHTMLFile(html string) (string, error) {
 }
 
 // generatePDFFromFile generates a PDF from an HTML file using Chrome.
-func (wp *WorkerPool) generatePDFFromFile(ctx context.Context, htmlFilePath string) ([]byte, error) {
+func (wp *WorkerPool) generatePDFFromFile(ctx context.Context, htmlFilePath string, options *model.PDFOptions) ([]byte, error) {
 	fileURL := "file://" + filepath.ToSlash(htmlFilePath)
 	wp.logger.Infof("Navigating to file URL: %s", fileURL)
 
@@ -184,16 +187,7 @@ func (wp *WorkerPool) generatePDFFromFile(ctx context.Context, htmlFilePath stri
 		chromedp.ActionFunc(func(ctx context.Context) error {
 			var err error
 
-			pdfBuf, _, err = page.PrintToPDF().
-				WithPrintBackground(true).
-				WithPaperWidth(cn.PDFPaperWidthInches).
-				WithPaperHeight(cn.PDFPaperHeightInches).
-				WithMarginTop(cn.PDFMarginInches).
-				WithMarginBottom(cn.PDFMarginInches).
-				WithMarginLeft(cn.PDFMarginInches).
-				WithMarginRight(cn.PDFMarginInches).
-				WithDisplayHeaderFooter(false).
-				Do(ctx)
+			pdfBuf, _, err = printParams(options).Do(ctx)
 
 			return err
 		}),
@@ -206,6 +200,40 @@ func (wp *WorkerPool) generatePDFFromFile(ctx context.Context, htmlFilePath stri
 	return pdfBuf, nil
 }
 
+// printParams returns the print parameters of the page setup of the options.
+func printParams(options *model.PDFOptions) *page.PrintToPDFParams {
+	width, height := options.PaperDimensions()
+	top, bottom, left, right := options.PageMargins()
+
+	params := page.PrintToPDF().
+		WithPrintBackground(true).
+		WithPaperWidth(width).
+		WithPaperHeight(height).
+		WithLandscape(options.Landscape()).
+		WithScale(options.PageScale()).
+		WithMarginTop(top).
+		WithMarginBottom(bottom).
+		WithMarginLeft(left).
+		WithMarginRight(right).
+		WithDisplayHeaderFooter(options.HasHeaderFooter())
+
+	if !options.HasHeaderFooter() {
+		return params
+	}
+
+	// Chrome prints its default date and title header, or URL and page footer, when a template is empty
+	header, footer := options.HeaderTemplate, options.FooterTemplate
+	if header == "" {
+		header = cn.PDFEmptyHeaderFooter
+	}
+
+	if footer == "" {
+		footer = cn.PDFEmptyHeaderFooter
+	}
+
+	return params.WithHeaderTemplate(header).WithFooterTemplate(footer)
+}
+
 // processPDFResult validates and writes the generated PDF to disk.
 func (wp *WorkerPool) processPDFResult(pdfBuf []byte, filename string, err error) error {
 	if err != nil {
@@ -254,9 +282,9 @@ func (wp *WorkerPool) logPDFGenerationError(ctx context.Context, err error) {
 }
 
 // Submit sends a task to the pool and blocks until it is completed.
-func (wp *WorkerPool) Submit(html, filename string) error {
+func (wp *WorkerPool) Submit(html, filename string, options *model.PDFOptions) error {
 	res := make(chan error, 1)
-	wp.tasks <- Task{HTML: html, Filename: filename, Result: res}
+	wp.tasks <- Task{HTML: html, Filename: filename, Options: options, Result: res}
 
 	return <-res
 }
@@ -12,6 +12,7 @@ package pdf
 import (
 	reflect "reflect"
 
+	model "github.com/LerianStudio/reporter/pkg/model"
 	gomock "go.uber.org/mock/gomock"
 )
 
@@ -40,15 +41,15 @@ func (m *MockPDFGenerator) EXPECT() *MockPDFGeneratorMockRecorder {
 }
 
 // Submit mocks base method.
-func (m *MockPDFGenerator) Submit(html, filename string) error {
+func (m *MockPDFGenerator) Submit(html, filename string, options *model.PDFOptions) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Submit", html, filename)
+	ret := m.ctrl.Call(m, "Submit", html, filename, options)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // Submit indicates an expected call of Submit.
-func (mr *MockPDFGeneratorMockRecorder) Submit(html, filename any) *gomock.Call {
+func (mr *MockPDFGeneratorMockRecorder) Submit(html, filename, options any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Submit", reflect.TypeOf((*MockPDFGenerator)(nil).Submit), html, filename)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Submit", reflect.TypeOf((*MockPDFGenerator)(nil).Submit), html, filename, options)
 }
@@ -13,6 +13,7 @@ import (
 	"time"
 
 	"github.com/LerianStudio/lib-commons/v2/commons/zap"
+	"github.com/LerianStudio/reporter/pkg/model"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -532,3 +533,45 @@ func TestLogPDFGenerationError_Generic(t *testing.T) {
 		wp.logPDFGenerationError(ctx, errors.New("generic error"))
 	})
 }
+
+// --- printParams tests ---
+
+func TestPrintParams_Defaults(t *testing.T) {
+	t.Parallel()
+
+	params := printParams(nil)
+
+	assert.True(t, params.PrintBackground)
+	assert.InDelta(t, 8.5, params.PaperWidth, 0.001)
+	assert.InDelta(t, 11.0, params.PaperHeight, 0.001)
+	assert.InDelta(t, 0.5, params.MarginTop, 0.001)
+	assert.InDelta(t, 0.5, params.MarginRight, 0.001)
+	assert.InDelta(t, 1.0, params.Scale, 0.001)
+	assert.False(t, params.Landscape)
+	assert.False(t, params.DisplayHeaderFooter)
+}
+
+func TestPrintParams_PageSetup(t *testing.T) {
+	t.Parallel()
+
+	bottom := 0.8
+	options := &model.PDFOptions{
+		PaperSize:      "a4",
+		Orientation:    "landscape",
+		Scale:          0.9,
+		Margins:        &model.PDFMargins{Bottom: &bottom},
+		FooterTemplate: `<div>Page <span class="pageNumber"></span> of <span class="totalPages"></span></div>`,
+	}
+
+	params := printParams(options)
+
+	assert.InDelta(t, 8.27, params.PaperWidth, 0.001)
+	assert.InDelta(t, 11.69, params.PaperHeight, 0.001)
+	assert.True(t, params.Landscape)
+	assert.InDelta(t, 0.9, params.Scale, 0.001)
+	assert.InDelta(t, 0.5, params.MarginTop, 0.001)
+	assert.InDelta(t, 0.8, params.MarginBottom, 0.001)
+	assert.True(t, params.DisplayHeaderFooter)
+	assert.Equal(t, "<span></span>", params.HeaderTemplate)
+	assert.Equal(t, options.FooterTemplate, params.FooterTemplate)
+}
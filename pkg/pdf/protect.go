@@ -0,0 +1,70 @@
+// Copyright (c) 2026 Lerian Studio. All rights reserved.
+// Use of this source code is governed by the Elastic License 2.0
+// that can be found in the LICENSE file.
+
+package pdf
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"sync"
+
+	cn "github.com/LerianStudio/reporter/pkg/constant"
+
+	"github.com/pdfcpu/pdfcpu/pkg/api"
+	pdfcpuModel "github.com/pdfcpu/pdfcpu/pkg/pdfcpu/model"
+)
+
+// disableConfigDir keeps pdfcpu from installing its configuration in the user's config directory.
+var disableConfigDir sync.Once
+
+// permissionFlags maps the permissions of the PDF options to the access flags they grant.
+var permissionFlags = map[string]pdfcpuModel.PermissionFlags{
+	cn.PDFPermissionPrint:    pdfcpuModel.PermissionPrintRev2 | pdfcpuModel.PermissionPrintRev3,
+	cn.PDFPermissionCopy:     pdfcpuModel.PermissionExtract | pdfcpuModel.PermissionExtractRev3,
+	cn.PDFPermissionAnnotate: pdfcpuModel.PermissionModAnnFillForm | pdfcpuModel.PermissionFillRev3,
+	cn.PDFPermissionModify:   pdfcpuModel.PermissionModify | pdfcpuModel.PermissionAssembleRev3,
+}
+
+// Protect encrypts a PDF with AES-256. Readers open it with the user password and are restricted to
+// the given permissions. Without an owner password a random one is used, so the restrictions cannot be lifted.
+func Protect(pdf []byte, userPassword, ownerPassword string, permissions []string) ([]byte, error) {
+	if userPassword == "" {
+		return nil, errors.New("a user password is required to protect the PDF")
+	}
+
+	if ownerPassword == "" {
+		random := make([]byte, cn.PDFOwnerPasswordBytes)
+		if _, err := rand.Read(random); err != nil {
+			return nil, fmt.Errorf("failed to generate the owner password: %w", err)
+		}
+
+		ownerPassword = hex.EncodeToString(random)
+	}
+
+	flags := pdfcpuModel.PermissionsNone
+
+	for _, permission := range permissions {
+		flag, ok := permissionFlags[permission]
+		if !ok {
+			return nil, fmt.Errorf("unsupported PDF permission '%s'", permission)
+		}
+
+		flags |= flag
+	}
+
+	disableConfigDir.Do(api.DisableConfigDir)
+
+	conf := pdfcpuModel.NewAESConfiguration(userPassword, ownerPassword, cn.PDFEncryptionKeyLength)
+	conf.Permissions = flags
+
+	var out bytes.Buffer
+	if err := api.Encrypt(bytes.NewReader(pdf), &out, conf); err != nil {
+		return nil, fmt.Errorf("failed to encrypt PDF: %w", err)
+	}
+
+	return out.Bytes(), nil
+}
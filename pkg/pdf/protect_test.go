@@ -0,0 +1,112 @@
+// Copyright (c) 2026 Lerian Studio. All rights reserved.
+// Use of this source code is governed by the Elastic License 2.0
+// that can be found in the LICENSE file.
+
+package pdf
+
+import (
+	"bytes"
+	"fmt"
+	"testing"
+
+	"github.com/pdfcpu/pdfcpu/pkg/api"
+	pdfcpuModel "github.com/pdfcpu/pdfcpu/pkg/pdfcpu/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// minimalPDF returns a single blank page PDF with a valid cross-reference table.
+func minimalPDF() []byte {
+	objects := []string{
+		"<< /Type /Catalog /Pages 2 0 R >>",
+		"<< /Type /Pages /Kids [3 0 R] /Count 1 >>",
+		"<< /Type /Page /Parent 2 0 R /MediaBox [0 0 612 792] >>",
+	}
+
+	var buf bytes.Buffer
+
+	buf.WriteString("%PDF-1.7\n")
+
+	offsets := make([]int, len(objects))
+	for i, object := range objects {
+		offsets[i] = buf.Len()
+		fmt.Fprintf(&buf, "%d 0 obj\n%s\nendobj\n", i+1, object)
+	}
+
+	xref := buf.Len()
+	fmt.Fprintf(&buf, "xref\n0 %d\n0000000000 65535 f \n", len(objects)+1)
+
+	for _, offset := range offsets {
+		fmt.Fprintf(&buf, "%010d 00000 n \n", offset)
+	}
+
+	fmt.Fprintf(&buf, "trailer\n<< /Size %d /Root 1 0 R >>\nstartxref\n%d\n%%%%EOF\n", len(objects)+1, xref)
+
+	return buf.Bytes()
+}
+
+func TestProtect(t *testing.T) {
+	t.Parallel()
+
+	protected, err := Protect(minimalPDF(), "12345", "owner", []string{"print"})
+	require.NoError(t, err)
+	assert.Contains(t, string(protected), "/Encrypt")
+
+	// The user password opens the document
+	var decrypted bytes.Buffer
+	require.NoError(t, api.Decrypt(bytes.NewReader(protected), &decrypted, pdfcpuModel.NewAESConfiguration("12345", "", 256)))
+
+	// Any other password does not
+	err = api.Decrypt(bytes.NewReader(protected), &bytes.Buffer{}, pdfcpuModel.NewAESConfiguration("00000", "", 256))
+	require.Error(t, err)
+
+	permissions, err := api.GetPermissions(bytes.NewReader(protected), pdfcpuModel.NewAESConfiguration("12345", "owner", 256))
+	require.NoError(t, err)
+	require.NotNil(t, permissions)
+
+	flags := pdfcpuModel.PermissionFlags(uint16(*permissions))
+	assert.NotZero(t, flags&pdfcpuModel.PermissionPrintRev3)
+	assert.Zero(t, flags&pdfcpuModel.PermissionExtract)
+	assert.Zero(t, flags&pdfcpuModel.PermissionModify)
+}
+
+func TestProtect_RandomOwnerPassword(t *testing.T) {
+	t.Parallel()
+
+	protected, err := Protect(minimalPDF(), "12345", "", nil)
+	require.NoError(t, err)
+
+	// Without the owner password the restrictions cannot be lifted
+	conf := pdfcpuModel.NewAESConfiguration("12345", "", 256)
+	conf.Permissions = pdfcpuModel.PermissionsAll
+
+	err = api.SetPermissions(bytes.NewReader(protected), &bytes.Buffer{}, conf)
+	require.Error(t, err)
+}
+
+func TestProtect_Errors(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		pdf         []byte
+		password    string
+		permissions []string
+		wantErr     string
+	}{
+		{name: "missing user password", pdf: minimalPDF(), wantErr: "user password"},
+		{name: "unsupported permission", pdf: minimalPDF(), password: "x", permissions: []string{"sign"}, wantErr: "unsupported PDF permission"},
+		{name: "not a PDF", pdf: []byte("<html></html>"), password: "x", wantErr: "failed to encrypt PDF"},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := Protect(tt.pdf, tt.password, "", tt.permissions)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
@@ -0,0 +1,325 @@
+// Copyright (c) 2026 Lerian Studio. All rights reserved.
+// Use of this source code is governed by the Elastic License 2.0
+// that can be found in the LICENSE file.
+
+package pdf
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/LerianStudio/reporter/pkg/signature"
+
+	"github.com/pdfcpu/pdfcpu/pkg/api"
+	pdfcpuModel "github.com/pdfcpu/pdfcpu/pkg/pdfcpu/model"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/types"
+)
+
+const (
+	// signatureFieldName is the name of the signature field added to the document form.
+	signatureFieldName = "Signature1"
+
+	// byteRangePlaceholder reserves room for the byte ranges, filled in once the document is written.
+	byteRangePlaceholder = "[0 0000000000 0000000000 0000000000]"
+
+	// aes256Version is the version of the standard security handler of AES-256 encryption, whose
+	// revisions 5 and 6 encrypt strings with the file key itself.
+	aes256Version = 5
+)
+
+// Sign adds an invisible PAdES signature (ETSI.CAdES.detached) to a PDF as an incremental update, so
+// the bytes of the original document are kept. Documents protected with a password are opened with it.
+func Sign(pdf []byte, password string, signer *signature.Signer) ([]byte, error) {
+	if signer == nil {
+		return nil, errors.New("a signer is required to sign the PDF")
+	}
+
+	disableConfigDir.Do(api.DisableConfigDir)
+
+	conf := pdfcpuModel.NewDefaultConfiguration()
+	conf.UserPW = password
+
+	ctx, err := api.ReadContext(bytes.NewReader(pdf), conf)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read PDF: %w", err)
+	}
+
+	var key []byte
+
+	if ctx.Encrypt != nil {
+		if ctx.E == nil || ctx.E.V != aes256Version || !ctx.AES4Strings {
+			return nil, errors.New("only PDFs protected with AES-256 can be signed")
+		}
+
+		key = ctx.EncKey
+	}
+
+	catalog, err := ctx.Catalog()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the PDF catalog: %w", err)
+	}
+
+	if _, ok := catalog.Find("AcroForm"); ok {
+		return nil, errors.New("PDFs with form fields cannot be signed")
+	}
+
+	prevXRef, xrefStream, err := lastXRef(pdf)
+	if err != nil {
+		return nil, err
+	}
+
+	if ctx.Size == nil || ctx.Root == nil {
+		return nil, errors.New("failed to read the PDF trailer")
+	}
+
+	sigNr, fieldNr := *ctx.Size, *ctx.Size+1
+	rootNr, rootGen := ctx.Root.ObjectNumber.Value(), ctx.Root.GenerationNumber.Value()
+
+	var buf bytes.Buffer
+
+	buf.Write(pdf)
+
+	if !bytes.HasSuffix(pdf, []byte("\n")) {
+		buf.WriteString("\n")
+	}
+
+	offsets := map[int]int{}
+
+	signingTime, err := encryptString(key, "D:"+time.Now().UTC().Format("20060102150405")+"Z")
+	if err != nil {
+		return nil, err
+	}
+
+	// The signature contents are never encrypted, so they can be read without the password
+	offsets[sigNr] = buf.Len()
+	fmt.Fprintf(&buf, "%d 0 obj\n<</Type /Sig /Filter /Adobe.PPKLite /SubFilter /ETSI.CAdES.detached /M %s /ByteRange ", sigNr, signingTime.PDFString())
+
+	byteRangeAt := buf.Len()
+	buf.WriteString(byteRangePlaceholder + " /Contents ")
+
+	contentsAt := buf.Len()
+	buf.WriteString("<" + strings.Repeat("0", 2*signer.SignatureSize()) + ">")
+	contentsEnd := buf.Len()
+
+	buf.WriteString(">>\nendobj\n")
+
+	fieldName, err := encryptString(key, signatureFieldName)
+	if err != nil {
+		return nil, err
+	}
+
+	field := types.Dict{
+		"FT":      types.Name("Sig"),
+		"Type":    types.Name("Annot"),
+		"Subtype": types.Name("Widget"),
+		"Rect":    types.NewIntegerArray(0, 0, 0, 0),
+		"F":       types.Integer(132), // Hidden and locked
+		"T":       fieldName,
+		"V":       *types.NewIndirectRef(sigNr, 0),
+	}
+
+	offsets[fieldNr] = buf.Len()
+	fmt.Fprintf(&buf, "%d 0 obj\n%s\nendobj\n", fieldNr, field.PDFString())
+
+	root, err := encryptStrings(key, catalog.Clone())
+	if err != nil {
+		return nil, err
+	}
+
+	rootDict, _ := root.(types.Dict)
+	rootDict["AcroForm"] = types.Dict{
+		"Fields":   types.Array{*types.NewIndirectRef(fieldNr, 0)},
+		"SigFlags": types.Integer(3), // Signatures exist and the document is append only
+	}
+
+	offsets[rootNr] = buf.Len()
+	fmt.Fprintf(&buf, "%d %d obj\n%s\nendobj\n", rootNr, rootGen, rootDict.PDFString())
+
+	trailer := types.Dict{
+		"Root": *ctx.Root,
+		"Prev": types.Integer(prevXRef),
+	}
+
+	if ctx.Info != nil {
+		trailer["Info"] = *ctx.Info
+	}
+
+	if ctx.ID != nil {
+		trailer["ID"] = ctx.ID
+	}
+
+	if ctx.Encrypt != nil {
+		trailer["Encrypt"] = *ctx.Encrypt
+	}
+
+	if xrefStream {
+		writeXRefStream(&buf, trailer, offsets, rootNr, rootGen, sigNr)
+	} else {
+		writeXRefTable(&buf, trailer, offsets, rootNr, rootGen, sigNr)
+	}
+
+	out := buf.Bytes()
+
+	byteRange := fmt.Sprintf("[0 %d %d %d]", contentsAt, contentsEnd, len(out)-contentsEnd)
+	copy(out[byteRangeAt:], byteRange+strings.Repeat(" ", len(byteRangePlaceholder)-len(byteRange)))
+
+	signed := make([]byte, 0, len(out)-(contentsEnd-contentsAt))
+	signed = append(append(signed, out[:contentsAt]...), out[contentsEnd:]...)
+
+	cms, err := signer.SignPAdES(signed)
+	if err != nil {
+		return nil, fmt.Errorf("failed to sign PDF: %w", err)
+	}
+
+	if 2*len(cms) > contentsEnd-contentsAt-2 {
+		return nil, errors.New("the PDF signature is larger than the room reserved for it")
+	}
+
+	hex.Encode(out[contentsAt+1:], cms)
+
+	return out, nil
+}
+
+// lastXRef returns the offset of the last cross-reference section of a PDF and whether it is a cross-reference stream.
+func lastXRef(pdf []byte) (int, bool, error) {
+	at := bytes.LastIndex(pdf, []byte("startxref"))
+	if at < 0 {
+		return 0, false, errors.New("failed to find the PDF cross-reference table")
+	}
+
+	fields := bytes.Fields(pdf[at+len("startxref"):])
+	if len(fields) == 0 {
+		return 0, false, errors.New("failed to find the PDF cross-reference table")
+	}
+
+	offset, err := strconv.Atoi(string(fields[0]))
+	if err != nil || offset < 0 || offset >= len(pdf) {
+		return 0, false, errors.New("invalid PDF cross-reference table offset")
+	}
+
+	return offset, !bytes.HasPrefix(pdf[offset:], []byte("xref")), nil
+}
+
+// writeXRefTable appends the cross-reference table and trailer of the updated objects.
+func writeXRefTable(buf *bytes.Buffer, trailer types.Dict, offsets map[int]int, rootNr, rootGen, sigNr int) {
+	xrefAt := buf.Len()
+
+	trailer["Size"] = types.Integer(sigNr + 2)
+
+	fmt.Fprintf(buf, "xref\n%d 1\n%010d %05d n \n", rootNr, offsets[rootNr], rootGen)
+	fmt.Fprintf(buf, "%d 2\n%010d 00000 n \n%010d 00000 n \n", sigNr, offsets[sigNr], offsets[sigNr+1])
+	fmt.Fprintf(buf, "trailer\n%s\nstartxref\n%d\n%%%%EOF\n", trailer.PDFString(), xrefAt)
+}
+
+// writeXRefStream appends an uncompressed cross-reference stream of the updated objects, for documents
+// whose cross-reference sections are streams.
+func writeXRefStream(buf *bytes.Buffer, trailer types.Dict, offsets map[int]int, rootNr, rootGen, sigNr int) {
+	xrefNr := sigNr + 2
+	offsets[xrefNr] = buf.Len()
+
+	var data bytes.Buffer
+
+	entry := func(offset, generation int) {
+		data.WriteByte(1)
+		_ = binary.Write(&data, binary.BigEndian, uint32(offset))
+		_ = binary.Write(&data, binary.BigEndian, uint16(generation))
+	}
+
+	entry(offsets[rootNr], rootGen)
+	entry(offsets[sigNr], 0)
+	entry(offsets[sigNr+1], 0)
+	entry(offsets[xrefNr], 0)
+
+	trailer["Type"] = types.Name("XRef")
+	trailer["Size"] = types.Integer(xrefNr + 1)
+	trailer["W"] = types.NewIntegerArray(1, 4, 2)
+	trailer["Index"] = types.NewIntegerArray(rootNr, 1, sigNr, 3)
+	trailer["Length"] = types.Integer(data.Len())
+
+	fmt.Fprintf(buf, "%d 0 obj\n%s\nstream\n", xrefNr, trailer.PDFString())
+	buf.Write(data.Bytes())
+	fmt.Fprintf(buf, "\nendstream\nendobj\nstartxref\n%d\n%%%%EOF\n", offsets[xrefNr])
+}
+
+// encryptStrings returns the object with its strings encrypted with the file key of an AES-256
+// protected document. Without a key the object is returned unchanged.
+func encryptStrings(key []byte, object types.Object) (types.Object, error) {
+	if key == nil {
+		return object, nil
+	}
+
+	switch o := object.(type) {
+	case types.Dict:
+		for name, value := range o {
+			encrypted, err := encryptStrings(key, value)
+			if err != nil {
+				return nil, err
+			}
+
+			o[name] = encrypted
+		}
+
+		return o, nil
+	case types.Array:
+		for i, value := range o {
+			encrypted, err := encryptStrings(key, value)
+			if err != nil {
+				return nil, err
+			}
+
+			o[i] = encrypted
+		}
+
+		return o, nil
+	case types.StringLiteral:
+		plain, err := types.Unescape(o.Value())
+		if err != nil {
+			return nil, fmt.Errorf("failed to read PDF string: %w", err)
+		}
+
+		return encryptString(key, string(plain))
+	case types.HexLiteral:
+		plain, err := o.Bytes()
+		if err != nil {
+			return nil, fmt.Errorf("failed to read PDF string: %w", err)
+		}
+
+		return encryptString(key, string(plain))
+	default:
+		return object, nil
+	}
+}
+
+// encryptString returns a PDF string encrypted with AES-256-CBC and a random IV, as the AES-256
+// standard security handler expects. Without a key the string is returned as is.
+func encryptString(key []byte, value string) (types.Object, error) {
+	if key == nil {
+		return types.NewHexLiteral([]byte(value)), nil
+	}
+
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encrypt PDF string: %w", err)
+	}
+
+	padding := aes.BlockSize - len(value)%aes.BlockSize
+	plain := append([]byte(value), bytes.Repeat([]byte{byte(padding)}, padding)...)
+
+	encrypted := make([]byte, aes.BlockSize+len(plain))
+	if _, randErr := rand.Read(encrypted[:aes.BlockSize]); randErr != nil {
+		return nil, fmt.Errorf("failed to encrypt PDF string: %w", randErr)
+	}
+
+	cipher.NewCBCEncrypter(block, encrypted[:aes.BlockSize]).CryptBlocks(encrypted[aes.BlockSize:], plain)
+
+	return types.NewHexLiteral(encrypted), nil
+}
@@ -0,0 +1,213 @@
+// Copyright (c) 2026 Lerian Studio. All rights reserved.
+// Use of this source code is governed by the Elastic License 2.0
+// that can be found in the LICENSE file.
+
+package pdf
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"encoding/hex"
+	"math/big"
+	"regexp"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/LerianStudio/reporter/pkg/signature"
+
+	"github.com/hhrutter/pkcs7"
+	"github.com/pdfcpu/pdfcpu/pkg/api"
+	pdfcpuModel "github.com/pdfcpu/pdfcpu/pkg/pdfcpu/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testSigner returns a signer with a self-signed ECDSA certificate.
+func testSigner(t *testing.T) *signature.Signer {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(7),
+		Subject:      pkix.Name{CommonName: "Reporter"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(24 * time.Hour),
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, template, key.Public(), key)
+	require.NoError(t, err)
+
+	certificate, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+
+	signer, err := signature.NewSigner(certificate, key, nil)
+	require.NoError(t, err)
+
+	return signer
+}
+
+// verifySignature checks the byte ranges of the last signature of a PDF and verifies its CMS signature over them.
+func verifySignature(t *testing.T, signed []byte) {
+	t.Helper()
+
+	matches := regexp.MustCompile(`/ByteRange \[0 (\d+) (\d+) (\d+)\] */Contents <([0-9a-f]+)>`).FindAllSubmatch(signed, -1)
+	require.NotEmpty(t, matches)
+
+	match := matches[len(matches)-1]
+	contentsAt, _ := strconv.Atoi(string(match[1]))
+	contentsEnd, _ := strconv.Atoi(string(match[2]))
+	tail, _ := strconv.Atoi(string(match[3]))
+
+	// The ranges cover the whole document but the signature contents
+	require.Equal(t, len(signed), contentsEnd+tail)
+	require.Equal(t, byte('<'), signed[contentsAt])
+	require.Equal(t, byte('>'), signed[contentsEnd-1])
+
+	contents, err := hex.DecodeString(string(match[4]))
+	require.NoError(t, err)
+
+	// The contents are padded with zeros after the DER-encoded signature
+	var cms asn1.RawValue
+
+	_, err = asn1.Unmarshal(contents, &cms)
+	require.NoError(t, err)
+
+	p7, err := pkcs7.Parse(cms.FullBytes)
+	require.NoError(t, err)
+
+	p7.Content = append(append([]byte{}, signed[:contentsAt]...), signed[contentsEnd:]...)
+	require.NoError(t, p7.Verify())
+}
+
+func TestSign(t *testing.T) {
+	t.Parallel()
+
+	original := minimalPDF()
+
+	signed, err := Sign(original, "", testSigner(t))
+	require.NoError(t, err)
+
+	// The signature is an incremental update of the original document
+	assert.True(t, bytes.HasPrefix(signed, original))
+	assert.Contains(t, string(signed), "/SubFilter /ETSI.CAdES.detached")
+
+	verifySignature(t, signed)
+
+	ctx, err := api.ReadContext(bytes.NewReader(signed), pdfcpuModel.NewDefaultConfiguration())
+	require.NoError(t, err)
+	require.NoError(t, api.ValidateContext(ctx))
+
+	catalog, err := ctx.Catalog()
+	require.NoError(t, err)
+
+	form := catalog.DictEntry("AcroForm")
+	require.NotNil(t, form)
+	assert.Equal(t, 3, *form.IntEntry("SigFlags"))
+}
+
+func TestSign_ProtectedPDF(t *testing.T) {
+	t.Parallel()
+
+	protected, err := Protect(minimalPDF(), "12345", "owner", []string{"print"})
+	require.NoError(t, err)
+
+	signed, err := Sign(protected, "12345", testSigner(t))
+	require.NoError(t, err)
+	assert.True(t, bytes.HasPrefix(signed, protected))
+
+	verifySignature(t, signed)
+
+	// The signed document still opens with the user password, and only with it
+	var decrypted bytes.Buffer
+	require.NoError(t, api.Decrypt(bytes.NewReader(signed), &decrypted, pdfcpuModel.NewAESConfiguration("12345", "", 256)))
+
+	err = api.Decrypt(bytes.NewReader(signed), &bytes.Buffer{}, pdfcpuModel.NewAESConfiguration("00000", "", 256))
+	require.Error(t, err)
+
+	conf := pdfcpuModel.NewDefaultConfiguration()
+	conf.UserPW = "12345"
+
+	ctx, err := api.ReadContext(bytes.NewReader(signed), conf)
+	require.NoError(t, err)
+
+	// The strings of the new objects are encrypted with the document key
+	catalog, err := ctx.Catalog()
+	require.NoError(t, err)
+
+	fields := catalog.DictEntry("AcroForm").ArrayEntry("Fields")
+	require.Len(t, fields, 1)
+
+	field, err := ctx.DereferenceDict(fields[0])
+	require.NoError(t, err)
+
+	name, err := field.StringEntryBytes("T")
+	require.NoError(t, err)
+	assert.Equal(t, "Signature1", string(name))
+	assert.NotContains(t, string(signed), hex.EncodeToString([]byte("Signature1")))
+
+	require.NoError(t, api.ValidateContext(ctx))
+}
+
+func TestSign_XRefStream(t *testing.T) {
+	t.Parallel()
+
+	conf := pdfcpuModel.NewDefaultConfiguration()
+	conf.WriteXRefStream = true
+	conf.WriteObjectStream = true
+
+	var optimized bytes.Buffer
+	require.NoError(t, api.Optimize(bytes.NewReader(minimalPDF()), &optimized, conf))
+
+	offset, xrefStream, err := lastXRef(optimized.Bytes())
+	require.NoError(t, err)
+	require.True(t, xrefStream, "the fixture must end with a cross-reference stream at %d", offset)
+
+	signed, err := Sign(optimized.Bytes(), "", testSigner(t))
+	require.NoError(t, err)
+
+	verifySignature(t, signed)
+
+	ctx, err := api.ReadContext(bytes.NewReader(signed), pdfcpuModel.NewDefaultConfiguration())
+	require.NoError(t, err)
+	require.NoError(t, api.ValidateContext(ctx))
+}
+
+func TestSign_Errors(t *testing.T) {
+	t.Parallel()
+
+	protected, err := Protect(minimalPDF(), "12345", "", nil)
+	require.NoError(t, err)
+
+	signer := testSigner(t)
+
+	tests := []struct {
+		name     string
+		pdf      []byte
+		password string
+		signer   *signature.Signer
+		wantErr  string
+	}{
+		{name: "missing signer", pdf: minimalPDF(), wantErr: "signer is required"},
+		{name: "not a PDF", pdf: []byte("id;amount\n"), signer: signer, wantErr: "failed to read PDF"},
+		{name: "wrong password", pdf: protected, password: "00000", signer: signer, wantErr: "failed to read PDF"},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := Sign(tt.pdf, tt.password, tt.signer)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
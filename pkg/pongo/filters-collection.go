@@ -0,0 +1,339 @@
+// Copyright (c) 2026 Lerian Studio. All rights reserved.
+// Use of this source code is governed by the Elastic License 2.0
+// that can be found in the LICENSE file.
+
+package pongo
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/flosch/pongo2/v6"
+)
+
+// whereConditionPattern matches a where condition written with an operator, e.g. "amount>=100" or
+// "type in PIX,TED", capturing the field, the operator and the value.
+var whereConditionPattern = regexp.MustCompile(`^\s*([\w.]+)\s*(==|!=|>=|<=|>|<|\bnot in\b|\bin\b)\s*(.*?)\s*$`)
+
+// whereCondition is a parsed where parameter. The ":" operator is the original "field:value" syntax,
+// which compares the value as a string.
+type whereCondition struct {
+	field    string
+	operator string
+	value    string
+}
+
+// parseWhereCondition parses "field:value" or "field<operator>value".
+func parseWhereCondition(param string) (whereCondition, bool) {
+	if match := whereConditionPattern.FindStringSubmatch(param); match != nil {
+		return whereCondition{field: match[1], operator: match[2], value: match[3]}, true
+	}
+
+	field, value, ok := strings.Cut(param, ":")
+	if !ok {
+		return whereCondition{}, false
+	}
+
+	return whereCondition{field: field, operator: ":", value: value}, true
+}
+
+// matches reports whether the item satisfies the condition. Items without the field never match.
+func (c whereCondition) matches(item map[string]any) bool {
+	val, ok := getNestedField(item, c.field)
+	if !ok {
+		return false
+	}
+
+	switch c.operator {
+	case ":":
+		return fmt.Sprintf("%v", val) == c.value
+	case "==":
+		return compareValues(val, c.value) == 0
+	case "!=":
+		return compareValues(val, c.value) != 0
+	case ">":
+		return compareValues(val, c.value) > 0
+	case ">=":
+		return compareValues(val, c.value) >= 0
+	case "<":
+		return compareValues(val, c.value) < 0
+	case "<=":
+		return compareValues(val, c.value) <= 0
+	case "in", "not in":
+		found := slices.ContainsFunc(strings.Split(c.value, ","), func(option string) bool {
+			return compareValues(val, strings.TrimSpace(option)) == 0
+		})
+
+		return found == (c.operator == "in")
+	default:
+		return false
+	}
+}
+
+// compareValues compares two values as decimals when both are numeric, as instants when both are
+// dates and as strings otherwise.
+func compareValues(a, b any) int {
+	if decA, ok := toDecimal(a); ok {
+		if decB, ok := toDecimal(b); ok {
+			return decA.Cmp(decB)
+		}
+	}
+
+	if timeA, ok := parseTimeValue(a); ok {
+		if timeB, ok := parseTimeValue(b); ok {
+			return timeA.Compare(timeB)
+		}
+	}
+
+	return strings.Compare(fmt.Sprintf("%v", a), fmt.Sprintf("%v", b))
+}
+
+// sortKey is a field of a sort_by parameter.
+type sortKey struct {
+	field      string
+	descending bool
+}
+
+// compare orders two items by the key. Items without the field come last in both directions.
+func (k sortKey) compare(a, b map[string]any) int {
+	valA, okA := getNestedField(a, k.field)
+	valB, okB := getNestedField(b, k.field)
+	okA = okA && valA != nil
+	okB = okB && valB != nil
+
+	switch {
+	case !okA && !okB:
+		return 0
+	case !okA:
+		return 1
+	case !okB:
+		return -1
+	}
+
+	if k.descending {
+		return compareValues(valB, valA)
+	}
+
+	return compareValues(valA, valB)
+}
+
+// sortByFilter sorts an array of maps by one or more fields. A field prefixed with "-" is sorted in
+// descending order. Items comparing equal keep their original order.
+// Syntax: {{ array|sort_by:"field,-other" }}
+// Examples:
+//   - {{ transactions|sort_by:"created_at" }} → oldest first
+//   - {{ transactions|sort_by:"account_id,-amount.value" }} → by account, largest amount first
+func sortByFilter(in *pongo2.Value, param *pongo2.Value) (*pongo2.Value, *pongo2.Error) {
+	list, ok := toMapSlice(in.Interface())
+	if !ok {
+		return nil, &pongo2.Error{
+			Sender:    "sort_by",
+			OrigError: fmt.Errorf("expected array of maps, got %T", in.Interface()),
+		}
+	}
+
+	var keys []sortKey
+
+	for _, field := range strings.Split(param.String(), ",") {
+		field = strings.TrimSpace(field)
+		descending := strings.HasPrefix(field, "-")
+		field = strings.TrimSpace(strings.TrimPrefix(field, "-"))
+
+		if field == "" {
+			return nil, &pongo2.Error{
+				Sender:    "sort_by",
+				OrigError: fmt.Errorf("invalid format, expected 'field,-other', got '%s'", param.String()),
+			}
+		}
+
+		keys = append(keys, sortKey{field: field, descending: descending})
+	}
+
+	sorted := slices.Clone(list)
+
+	slices.SortStableFunc(sorted, func(a, b map[string]any) int {
+		for _, key := range keys {
+			if c := key.compare(a, b); c != 0 {
+				return c
+			}
+		}
+
+		return 0
+	})
+
+	return pongo2.AsValue(sorted), nil
+}
+
+// groupByFilter groups an array of maps by a field, in the order the groups first appear.
+// Each group is a map with the "key" shared by its items and the "items" themselves.
+// Syntax: {{ array|group_by:"field" }}
+// Example:
+//
+//	{% for group in transactions|group_by:"account_id" %}
+//	  {{ group.key }}: {% for t in group.items %}{{ t.amount }} {% endfor %}
+//	{% endfor %}
+func groupByFilter(in *pongo2.Value, param *pongo2.Value) (*pongo2.Value, *pongo2.Error) {
+	list, ok := toMapSlice(in.Interface())
+	if !ok {
+		return nil, &pongo2.Error{
+			Sender:    "group_by",
+			OrigError: fmt.Errorf("expected array of maps, got %T", in.Interface()),
+		}
+	}
+
+	field := strings.TrimSpace(param.String())
+	if field == "" {
+		return nil, &pongo2.Error{
+			Sender:    "group_by",
+			OrigError: fmt.Errorf("a field to group by is required"),
+		}
+	}
+
+	groups := []map[string]any{}
+	positions := map[string]int{}
+
+	for _, item := range list {
+		key, _ := getNestedField(item, field)
+		id := fmt.Sprintf("%v", key)
+
+		position, found := positions[id]
+		if !found {
+			position = len(groups)
+			positions[id] = position
+			groups = append(groups, map[string]any{"key": key, "items": []map[string]any{}})
+		}
+
+		groups[position]["items"] = append(groups[position]["items"].([]map[string]any), item)
+	}
+
+	return pongo2.AsValue(groups), nil
+}
+
+// uniqueFilter removes duplicates from an array, keeping the first occurrence. With a field, items of
+// an array of maps are compared by that field; without one, the values themselves are compared.
+// Syntax: {{ array|unique:"field" }} or {{ values|unique }}
+// Examples:
+//   - {{ transactions|unique:"account_id" }} → the first transaction of each account
+//   - {{ transactions|pluck:"currency"|unique }} → the distinct currencies
+func uniqueFilter(in *pongo2.Value, param *pongo2.Value) (*pongo2.Value, *pongo2.Error) {
+	field := strings.TrimSpace(param.String())
+	seen := map[string]bool{}
+
+	if field == "" {
+		if !in.CanSlice() || in.IsString() {
+			return nil, &pongo2.Error{
+				Sender:    "unique",
+				OrigError: fmt.Errorf("expected array, got %T", in.Interface()),
+			}
+		}
+
+		result := []any{}
+
+		for i := range in.Len() {
+			val := in.Index(i).Interface()
+			if id := fmt.Sprintf("%v", val); !seen[id] {
+				seen[id] = true
+				result = append(result, val)
+			}
+		}
+
+		return pongo2.AsValue(result), nil
+	}
+
+	list, ok := toMapSlice(in.Interface())
+	if !ok {
+		return nil, &pongo2.Error{
+			Sender:    "unique",
+			OrigError: fmt.Errorf("expected array of maps, got %T", in.Interface()),
+		}
+	}
+
+	result := []map[string]any{}
+
+	for _, item := range list {
+		val, _ := getNestedField(item, field)
+		if id := fmt.Sprintf("%v", val); !seen[id] {
+			seen[id] = true
+			result = append(result, item)
+		}
+	}
+
+	return pongo2.AsValue(result), nil
+}
+
+// pluckFilter extracts the values of a field from an array of maps. Items without the field are skipped.
+// Syntax: {{ array|pluck:"field" }}
+// Example: {{ holders|pluck:"document"|join:", " }} → "123, 456"
+func pluckFilter(in *pongo2.Value, param *pongo2.Value) (*pongo2.Value, *pongo2.Error) {
+	list, ok := toMapSlice(in.Interface())
+	if !ok {
+		return nil, &pongo2.Error{
+			Sender:    "pluck",
+			OrigError: fmt.Errorf("expected array of maps, got %T", in.Interface()),
+		}
+	}
+
+	field := strings.TrimSpace(param.String())
+	if field == "" {
+		return nil, &pongo2.Error{
+			Sender:    "pluck",
+			OrigError: fmt.Errorf("a field to pluck is required"),
+		}
+	}
+
+	result := []any{}
+
+	for _, item := range list {
+		if val, ok := getNestedField(item, field); ok {
+			result = append(result, val)
+		}
+	}
+
+	return pongo2.AsValue(result), nil
+}
+
+// firstNFilter returns the first N elements of an array, or all of them when it is shorter.
+// Syntax: {{ array|first_n:5 }}
+func firstNFilter(in *pongo2.Value, param *pongo2.Value) (*pongo2.Value, *pongo2.Error) {
+	n, err := countParam("first_n", in, param)
+	if err != nil {
+		return nil, err
+	}
+
+	return in.Slice(0, n), nil
+}
+
+// lastNFilter returns the last N elements of an array, or all of them when it is shorter.
+// Syntax: {{ array|last_n:5 }}
+func lastNFilter(in *pongo2.Value, param *pongo2.Value) (*pongo2.Value, *pongo2.Error) {
+	n, err := countParam("last_n", in, param)
+	if err != nil {
+		return nil, err
+	}
+
+	return in.Slice(in.Len()-n, in.Len()), nil
+}
+
+// countParam validates the input and the element count of first_n and last_n, capping the count
+// at the length of the input.
+func countParam(sender string, in *pongo2.Value, param *pongo2.Value) (int, *pongo2.Error) {
+	if !in.CanSlice() || in.IsString() {
+		return 0, &pongo2.Error{
+			Sender:    sender,
+			OrigError: fmt.Errorf("expected array, got %T", in.Interface()),
+		}
+	}
+
+	n, errConv := strconv.Atoi(strings.TrimSpace(param.String()))
+	if errConv != nil || n < 0 {
+		return 0, &pongo2.Error{
+			Sender:    sender,
+			OrigError: fmt.Errorf("expected a non-negative number of elements, got '%s'", param.String()),
+		}
+	}
+
+	return min(n, in.Len()), nil
+}
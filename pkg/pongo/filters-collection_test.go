@@ -0,0 +1,132 @@
+// Copyright (c) 2026 Lerian Studio. All rights reserved.
+// Use of this source code is governed by the Elastic License 2.0
+// that can be found in the LICENSE file.
+
+package pongo
+
+import (
+	"testing"
+	"time"
+
+	"github.com/flosch/pongo2/v6"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func collectionFixture() []any {
+	return []any{
+		map[string]any{"id": "t1", "account": "A", "amount": 100, "type": "PIX", "date": "2025-03-02"},
+		map[string]any{"id": "t2", "account": "B", "amount": "25.50", "type": "TED", "date": "2025-03-01"},
+		map[string]any{"id": "t3", "account": "A", "amount": decimal.RequireFromString("1000"), "type": "BOLETO", "date": "2025-03-03"},
+		map[string]any{"id": "t4", "account": "C", "amount": 9.5, "type": "PIX", "date": "2025-03-01"},
+		map[string]any{"id": "t5", "account": "B", "type": "TED"},
+	}
+}
+
+func TestCollectionFilters(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name     string
+		template string
+		expected string
+	}{
+		{"where greater than", `{% for t in items|where:"amount>50" %}{{ t.id }} {% endfor %}`, "t1 t3 "},
+		{"where less or equal", `{% for t in items|where:"amount<=25.5" %}{{ t.id }} {% endfor %}`, "t2 t4 "},
+		{"where not equal", `{% for t in items|where:"type!=PIX" %}{{ t.id }} {% endfor %}`, "t2 t3 t5 "},
+		{"where numeric equality", `{% for t in items|where:"amount==100.00" %}{{ t.id }} {% endfor %}`, "t1 "},
+		{"where in", `{% for t in items|where:"type in TED, BOLETO" %}{{ t.id }} {% endfor %}`, "t2 t3 t5 "},
+		{"where not in", `{% for t in items|where:"account not in A,B" %}{{ t.id }} {% endfor %}`, "t4 "},
+		{"where date", `{% for t in items|where:"date>=2025-03-02" %}{{ t.id }} {% endfor %}`, "t1 t3 "},
+		{"where legacy equality", `{% for t in items|where:"type:PIX" %}{{ t.id }} {% endfor %}`, "t1 t4 "},
+		{"sort_by numeric", `{% for t in items|sort_by:"amount" %}{{ t.id }} {% endfor %}`, "t4 t2 t1 t3 t5 "},
+		{"sort_by descending", `{% for t in items|sort_by:"-amount" %}{{ t.id }} {% endfor %}`, "t3 t1 t2 t4 t5 "},
+		{"sort_by several fields", `{% for t in items|sort_by:"account,-date" %}{{ t.id }} {% endfor %}`, "t3 t1 t2 t5 t4 "},
+		{"sort_by is stable", `{% for t in items|sort_by:"type" %}{{ t.id }} {% endfor %}`, "t3 t1 t4 t2 t5 "},
+		{
+			"group_by",
+			`{% for g in items|group_by:"account" %}{{ g.key }}:{% for t in g.items %}{{ t.id }},{% endfor %} {% endfor %}`,
+			"A:t1,t3, B:t2,t5, C:t4, ",
+		},
+		{"group_by sorted", `{% for g in items|sort_by:"date"|group_by:"date" %}{{ g.items|length }}{% endfor %}`, "2111"},
+		{"unique by field", `{% for t in items|unique:"account" %}{{ t.id }} {% endfor %}`, "t1 t2 t4 "},
+		{"pluck", `{{ items|pluck:"type"|join:"," }}`, "PIX,TED,BOLETO,PIX,TED"},
+		{"pluck unique", `{{ items|pluck:"type"|unique|join:"," }}`, "PIX,TED,BOLETO"},
+		{"first_n", `{% for t in items|first_n:2 %}{{ t.id }} {% endfor %}`, "t1 t2 "},
+		{"last_n", `{% for t in items|last_n:"2" %}{{ t.id }} {% endfor %}`, "t4 t5 "},
+		{"first_n longer than the array", `{{ items|first_n:10|length }}`, "5"},
+		{"last_n zero", `{{ items|last_n:0|length }}`, "0"},
+		{"chained", `{{ items|where:"type in PIX,TED"|sort_by:"-date"|pluck:"id"|first_n:2|join:"," }}`, "t1,t2"},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			tpl, err := SafeFromString(tt.template)
+			require.NoError(t, err)
+
+			out, err := tpl.Execute(pongo2.Context{"items": collectionFixture()})
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, out)
+		})
+	}
+}
+
+func TestCollectionFilters_Errors(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name     string
+		template string
+	}{
+		{"sort_by without field", `{{ items|sort_by:"amount,,id" }}`},
+		{"sort_by not an array", `{{ "abc"|sort_by:"id" }}`},
+		{"group_by without field", `{{ items|group_by:"" }}`},
+		{"pluck without field", `{{ items|pluck:"" }}`},
+		{"unique not an array", `{{ "abc"|unique }}`},
+		{"first_n negative", `{{ items|first_n:"-1" }}`},
+		{"last_n not a number", `{{ items|last_n:"x" }}`},
+		{"where without operator", `{{ items|where:"amount" }}`},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			tpl, err := SafeFromString(tt.template)
+			require.NoError(t, err)
+
+			_, err = tpl.Execute(pongo2.Context{"items": collectionFixture()})
+			require.Error(t, err)
+		})
+	}
+}
+
+func TestParseWhereCondition(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		param    string
+		expected whereCondition
+	}{
+		{"state:SP", whereCondition{field: "state", operator: ":", value: "SP"}},
+		{"note:a>b", whereCondition{field: "note", operator: ":", value: "a>b"}},
+		{"amount.value >= 10", whereCondition{field: "amount.value", operator: ">=", value: "10"}},
+		{"created_at<2025-01-01T10:00:00Z", whereCondition{field: "created_at", operator: "<", value: "2025-01-01T10:00:00Z"}},
+		{"type in PIX,TED", whereCondition{field: "type", operator: "in", value: "PIX,TED"}},
+		{"type not in PIX", whereCondition{field: "type", operator: "not in", value: "PIX"}},
+	}
+
+	for _, tt := range tests {
+		condition, ok := parseWhereCondition(tt.param)
+		require.True(t, ok, tt.param)
+		assert.Equal(t, tt.expected, condition, tt.param)
+	}
+}
+
+func TestCompareValues(t *testing.T) {
+	t.Parallel()
+	assert.Equal(t, 0, compareValues(100, "100.00"))
+	assert.Equal(t, -1, compareValues("9.5", decimal.NewFromInt(10)))
+	assert.Equal(t, 1, compareValues(time.Date(2025, 3, 2, 0, 0, 0, 0, time.UTC), "2025-03-01"))
+	assert.Equal(t, -1, compareValues("PIX", "TED"))
+}
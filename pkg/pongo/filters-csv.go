@@ -0,0 +1,136 @@
+// Copyright (c) 2026 Lerian Studio. All rights reserved.
+// Use of this source code is governed by the Elastic License 2.0
+// that can be found in the LICENSE file.
+
+package pongo
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/LerianStudio/reporter/pkg/constant"
+
+	"github.com/flosch/pongo2/v6"
+	"github.com/shopspring/decimal"
+)
+
+// Fields written by the csv_field filter are enclosed in these private-use characters, after a kind
+// character (n for numbers, s for text), and escaped with the dialect of the render once it is complete.
+const (
+	csvFieldStart = "\uE004"
+	csvFieldEnd   = "\uE005"
+	csvKindNumber = "n"
+	csvKindText   = "s"
+)
+
+// csvFieldPattern matches a field written by the csv_field filter, capturing its kind and text.
+var csvFieldPattern = regexp.MustCompile(csvFieldStart + `([ns])([^` + csvFieldEnd + `]*)` + csvFieldEnd)
+
+// plainNumberPattern matches text that is a plain signed number, which is never a formula.
+var plainNumberPattern = regexp.MustCompile(`^[+-]?\d+([.,]\d+)?$`)
+
+// CSVContextKey is the key used to store the CSV dialect of a render in the pongo2 context
+const CSVContextKey = "_csv_dialect"
+
+// CSVDialect defines how the csv_field filter writes fields.
+type CSVDialect struct {
+	Delimiter string
+	Quote     string
+	Decimal   string
+	Formulas  string
+}
+
+// DefaultCSVDialect returns the RFC 4180 dialect: comma-separated, double-quoted, with a decimal point.
+func DefaultCSVDialect() *CSVDialect {
+	return &CSVDialect{
+		Delimiter: constant.CSVDefaultDelimiter,
+		Quote:     constant.CSVDefaultQuote,
+		Decimal:   constant.CSVDefaultDecimal,
+		Formulas:  constant.CSVFormulasKeep,
+	}
+}
+
+// escape writes a field: numbers take the decimal separator of the dialect, text that a spreadsheet
+// would evaluate as a formula is neutralized when asked, and fields holding the delimiter, the quote
+// or a line break are quoted, doubling their quotes.
+func (d *CSVDialect) escape(kind, text string) string {
+	if kind == csvKindNumber {
+		text = strings.Replace(text, ".", d.Decimal, 1)
+	} else if d.Formulas == constant.CSVFormulasNeutralize && isFormula(text) {
+		text = "'" + text
+	}
+
+	if !strings.Contains(text, d.Delimiter) && !strings.Contains(text, d.Quote) && !strings.ContainsAny(text, "\r\n") {
+		return text
+	}
+
+	return d.Quote + strings.ReplaceAll(text, d.Quote, d.Quote+d.Quote) + d.Quote
+}
+
+// isFormula reports whether a spreadsheet would evaluate the text as a formula. Plain numbers, such as
+// negative amounts, are not formulas.
+func isFormula(text string) bool {
+	if text == "" || !strings.ContainsAny(text[:1], "=+-@\t\r") {
+		return false
+	}
+
+	return !plainNumberPattern.MatchString(text)
+}
+
+// writeCSVFields escapes the fields written by the csv_field filter with the dialect of the render.
+func writeCSVFields(output string, dialect *CSVDialect) string {
+	if !strings.Contains(output, csvFieldStart) {
+		return output
+	}
+
+	if dialect == nil {
+		dialect = DefaultCSVDialect()
+	}
+
+	return csvFieldPattern.ReplaceAllStringFunc(output, func(match string) string {
+		parts := csvFieldPattern.FindStringSubmatch(match)
+
+		return dialect.escape(parts[1], parts[2])
+	})
+}
+
+// stripCSVFields writes the fields written by the csv_field filter as their plain text, for the outputs
+// that are not CSV, e.g. fixed-width records and passwords.
+func stripCSVFields(output string) string {
+	if !strings.Contains(output, csvFieldStart) {
+		return output
+	}
+
+	return csvFieldPattern.ReplaceAllString(output, "$2")
+}
+
+// csvFieldFilter writes a value as a field of a CSV record, escaped with the dialect declared by the
+// template's {% csv %} tag, or the RFC 4180 dialect when there is none.
+// Examples ({% csv delimiter=";" decimal="," %}):
+//   - {{ "Silva; Ana"|csv_field }} → "Silva; Ana" (quoted)
+//   - {{ 1234.5|csv_field }} → 1234,5
+//   - {{ "say \"hi\""|csv_field }} → "say ""hi"""
+func csvFieldFilter(in *pongo2.Value, _ *pongo2.Value) (*pongo2.Value, *pongo2.Error) {
+	if in.IsNil() {
+		return pongo2.AsValue(""), nil
+	}
+
+	kind, text := csvKindText, in.String()
+
+	switch v := in.Interface().(type) {
+	case decimal.Decimal:
+		kind, text = csvKindNumber, v.String()
+	case float32, float64:
+		kind, text = csvKindNumber, decimal.NewFromFloat(in.Float()).String()
+	default:
+		if in.IsInteger() {
+			kind = csvKindNumber
+		}
+	}
+
+	// A value already written by the csv_field filter is written as a single field
+	text = strings.NewReplacer(csvFieldStart, "", csvFieldEnd, "").Replace(text)
+
+	// The field is safe: CSV escaping replaces the HTML autoescaping of the output
+	return pongo2.AsSafeValue(csvFieldStart + kind + text + csvFieldEnd), nil
+}
@@ -0,0 +1,154 @@
+// Copyright (c) 2026 Lerian Studio. All rights reserved.
+// Use of this source code is governed by the Elastic License 2.0
+// that can be found in the LICENSE file.
+
+package pongo
+
+import (
+	"context"
+	"testing"
+
+	"github.com/LerianStudio/lib-commons/v2/commons/zap"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRenderFromBytes_CSVField(t *testing.T) {
+	t.Parallel()
+
+	logger := zap.InitializeLogger()
+	data := map[string]map[string][]map[string]any{
+		"db": {
+			"customers": {
+				{"name": "Silva, Ana", "note": `say "hi"`, "balance": decimal.RequireFromString("1234.50"), "count": 3},
+				{"name": "=HYPERLINK(\"x\")", "note": "line1\nline2", "balance": -10.25, "count": -2},
+				{"name": "@SUM(A1)", "note": nil, "balance": "+1", "count": 0},
+			},
+		},
+	}
+
+	tests := []struct {
+		name     string
+		template string
+		expected string
+	}{
+		{
+			name:     "default dialect",
+			template: `{% for c in db.customers %}{{ c.name|csv_field }},{{ c.note|csv_field }},{{ c.balance|csv_field }}` + "\n" + `{% endfor %}`,
+			expected: "\"Silva, Ana\",\"say \"\"hi\"\"\",1234.5\n" +
+				"\"=HYPERLINK(\"\"x\"\")\",\"line1\nline2\",-10.25\n" +
+				"@SUM(A1),,+1\n",
+		},
+		{
+			name:     "semicolon delimiter with decimal comma",
+			template: `{% csv delimiter=";" decimal="," %}{% for c in db.customers %}{{ c.name|csv_field }};{{ c.balance|csv_field }};{{ c.count|csv_field }}` + "\n" + `{% endfor %}`,
+			expected: "Silva, Ana;1234,5;3\n" +
+				"\"=HYPERLINK(\"\"x\"\")\";-10,25;-2\n" +
+				"@SUM(A1);+1;0\n",
+		},
+		{
+			name:     "formulas neutralized",
+			template: `{% csv formulas="neutralize" %}{% for c in db.customers %}{{ c.name|csv_field }},{{ c.balance|csv_field }}` + "\n" + `{% endfor %}`,
+			expected: "\"Silva, Ana\",1234.5\n" +
+				"\"'=HYPERLINK(\"\"x\"\")\",-10.25\n" +
+				"'@SUM(A1),+1\n",
+		},
+		{
+			name:     "custom quote",
+			template: `{% csv quote="'" %}{{ db.customers.0.note|csv_field }},{{ "it's"|csv_field }}`,
+			expected: `say "hi",'it''s'`,
+		},
+		{
+			name:     "escaped quote option",
+			template: `{% csv delimiter="\\" quote="\"" %}{{ "a\\b"|csv_field }}`,
+			expected: `"a\b"`,
+		},
+		{
+			name:     "localized values are escaped as shown",
+			template: `{% locale "pt-BR" %}{% csv delimiter="," %}{{ db.customers.0.balance|format_number:2|csv_field }},{{ db.customers.0.count|csv_field }}`,
+			expected: `"1.234,50",3`,
+		},
+		{
+			name:     "html is not autoescaped",
+			template: `{{ "<b>&</b>"|csv_field }}`,
+			expected: "<b>&</b>",
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			out, err := NewTemplateRenderer().RenderFromBytes(context.Background(), []byte(tt.template), data, logger)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, out)
+		})
+	}
+}
+
+func TestCSVTag_ParseErrors(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		template string
+	}{
+		{"unknown option", `{% csv separator=";" %}`},
+		{"option without value", `{% csv delimiter %}`},
+		{"variable value", `{% csv delimiter=sep %}`},
+		{"multi-character delimiter", `{% csv delimiter=";;" %}`},
+		{"line break quote", `{% csv quote="\n" %}`},
+		{"delimiter equal to quote", `{% csv delimiter="\"" %}`},
+		{"unsupported decimal", `{% csv decimal="_" %}`},
+		{"unsupported formulas policy", `{% csv formulas="strip" %}`},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			_, err := SafeFromString(tt.template)
+			require.Error(t, err)
+		})
+	}
+}
+
+func TestIsFormula(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		text     string
+		expected bool
+	}{
+		{"", false},
+		{"plain", false},
+		{"=1+2", true},
+		{"+cmd", true},
+		{"-2+3", true},
+		{"@SUM(A1)", true},
+		{"\tx", true},
+		{"-10.25", false},
+		{"+1", false},
+		{"-1,5", false},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.text, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tt.expected, isFormula(tt.text))
+		})
+	}
+}
+
+func TestWriteCSVFields(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, "a,b", writeCSVFields("a,b", nil))
+	assert.Equal(t, `"a,b",1.5`, writeCSVFields(csvFieldStart+"sa,b"+csvFieldEnd+","+csvFieldStart+"n1.5"+csvFieldEnd, nil))
+
+	dialect := &CSVDialect{Delimiter: "|", Quote: `"`, Decimal: ",", Formulas: "neutralize"}
+	assert.Equal(t, `a,b|1,5|'=x`, writeCSVFields(csvFieldStart+"sa,b"+csvFieldEnd+"|"+csvFieldStart+"n1.5"+csvFieldEnd+"|"+csvFieldStart+"s=x"+csvFieldEnd, dialect))
+}
@@ -0,0 +1,164 @@
+// Copyright (c) 2026 Lerian Studio. All rights reserved.
+// Use of this source code is governed by the Elastic License 2.0
+// that can be found in the LICENSE file.
+
+package pongo
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/flosch/pongo2/v6"
+)
+
+// toTzFilter converts a date to the given IANA timezone, e.g. a UTC timestamp from Postgres to local time.
+// Without a parameter the report or template timezone is used.
+// Syntax: {{ created_at|to_tz:"America/Sao_Paulo"|date:"02/01/2006 15:04" }}
+func toTzFilter(in *pongo2.Value, param *pongo2.Value) (*pongo2.Value, *pongo2.Error) {
+	t, empty, err := dateFilterInput("to_tz", in)
+	if err != nil || empty {
+		return pongo2.AsValue(""), err
+	}
+
+	if param.String() == "" {
+		return nil, &pongo2.Error{
+			Sender:    "to_tz",
+			OrigError: fmt.Errorf("no timezone given and none set for the template or report"),
+		}
+	}
+
+	loc, errLoad := time.LoadLocation(param.String())
+	if errLoad != nil {
+		return nil, &pongo2.Error{
+			Sender:    "to_tz",
+			OrigError: fmt.Errorf("invalid timezone '%s': %w", param.String(), errLoad),
+		}
+	}
+
+	return pongo2.AsValue(t.In(loc)), nil
+}
+
+// addDaysFilter adds a number of calendar days to a date; negative numbers go back.
+// Syntax: {{ due_date|add_days:"30" }}
+func addDaysFilter(in *pongo2.Value, param *pongo2.Value) (*pongo2.Value, *pongo2.Error) {
+	return shiftDate("add_days", in, param, func(t time.Time, n int) time.Time {
+		return t.AddDate(0, 0, n)
+	})
+}
+
+// addMonthsFilter adds a number of months to a date; negative numbers go back.
+// Days past the end of the resulting month roll over as in time.AddDate (e.g. Jan 31 + 1 month = Mar 3).
+// Syntax: {{ reference_date|add_months:"-1" }}
+func addMonthsFilter(in *pongo2.Value, param *pongo2.Value) (*pongo2.Value, *pongo2.Error) {
+	return shiftDate("add_months", in, param, func(t time.Time, n int) time.Time {
+		return t.AddDate(0, n, 0)
+	})
+}
+
+// startOfMonthFilter returns midnight of the first day of the date's month, in the date's timezone.
+// Syntax: {{ created_at|to_tz:"America/Sao_Paulo"|start_of_month }}
+func startOfMonthFilter(in *pongo2.Value, _ *pongo2.Value) (*pongo2.Value, *pongo2.Error) {
+	t, empty, err := dateFilterInput("start_of_month", in)
+	if err != nil || empty {
+		return pongo2.AsValue(""), err
+	}
+
+	return pongo2.AsValue(startOfMonth(t)), nil
+}
+
+// endOfMonthFilter returns the last instant of the date's month, in the date's timezone.
+// Syntax: {{ reference_date|end_of_month|date:"02/01/2006" }}
+func endOfMonthFilter(in *pongo2.Value, _ *pongo2.Value) (*pongo2.Value, *pongo2.Error) {
+	t, empty, err := dateFilterInput("end_of_month", in)
+	if err != nil || empty {
+		return pongo2.AsValue(""), err
+	}
+
+	return pongo2.AsValue(startOfMonth(t).AddDate(0, 1, 0).Add(-time.Nanosecond)), nil
+}
+
+// businessDaysBetweenFilter counts the business days after the earlier of the two dates up to and including
+// the later one, skipping weekends and the holidays of the default holiday calendar (Brazilian national
+// holidays unless configured otherwise). The count is negative when the parameter date is the earlier one.
+// Syntax: {{ settlement_date|business_days_between:maturity_date }}
+func businessDaysBetweenFilter(in *pongo2.Value, param *pongo2.Value) (*pongo2.Value, *pongo2.Error) {
+	from, empty, err := dateFilterInput("business_days_between", in)
+	if err != nil || empty {
+		return pongo2.AsValue(""), err
+	}
+
+	to, empty, err := dateFilterInput("business_days_between", param)
+	if err != nil || empty {
+		return pongo2.AsValue(""), err
+	}
+
+	calendar := currentHolidayCalendar()
+
+	start, end, sign := calendarDate(from), calendarDate(to), 1
+	if end.Before(start) {
+		start, end, sign = end, start, -1
+	}
+
+	count := 0
+
+	for day := start.AddDate(0, 0, 1); !day.After(end); day = day.AddDate(0, 0, 1) {
+		if isBusinessDay(day, calendar) {
+			count++
+		}
+	}
+
+	return pongo2.AsValue(sign * count), nil
+}
+
+// shiftDate applies a date shift whose amount is the filter parameter.
+func shiftDate(sender string, in, param *pongo2.Value, shift func(time.Time, int) time.Time) (*pongo2.Value, *pongo2.Error) {
+	t, empty, err := dateFilterInput(sender, in)
+	if err != nil || empty {
+		return pongo2.AsValue(""), err
+	}
+
+	n, errConv := strconv.Atoi(strings.TrimSpace(param.String()))
+	if errConv != nil {
+		return nil, &pongo2.Error{
+			Sender:    sender,
+			OrigError: fmt.Errorf("expected an integer, got '%s'", param.String()),
+		}
+	}
+
+	return pongo2.AsValue(shift(t, n)), nil
+}
+
+// dateFilterInput converts the value of a date filter to time.Time.
+// Nil and empty values are reported as empty so missing data renders as an empty string.
+func dateFilterInput(sender string, in *pongo2.Value) (time.Time, bool, *pongo2.Error) {
+	value := in.Interface()
+	if v, ok := value.(localizedValue); ok {
+		value = v.value
+	}
+
+	if value == nil || value == "" {
+		return time.Time{}, true, nil
+	}
+
+	t, ok := parseTimeValue(value)
+	if !ok {
+		return time.Time{}, false, &pongo2.Error{
+			Sender:    sender,
+			OrigError: fmt.Errorf("invalid date '%v'", value),
+		}
+	}
+
+	return t, false, nil
+}
+
+// startOfMonth returns midnight of the first day of the month of t, in the timezone of t.
+func startOfMonth(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, t.Location())
+}
+
+// calendarDate returns the calendar day of t, in its own timezone, as midnight UTC.
+func calendarDate(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
@@ -0,0 +1,103 @@
+// Copyright (c) 2026 Lerian Studio. All rights reserved.
+// Use of this source code is governed by the Elastic License 2.0
+// that can be found in the LICENSE file.
+
+package pongo
+
+import (
+	"testing"
+	"time"
+
+	"github.com/flosch/pongo2/v6"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDateFilters(t *testing.T) {
+	t.Parallel()
+	created := time.Date(2025, 3, 1, 2, 30, 0, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		template string
+		context  pongo2.Context
+		expected string
+	}{
+		{"to_tz", `{{ d|to_tz:"America/Sao_Paulo"|date:"2006-01-02 15:04 MST" }}`, pongo2.Context{"d": created}, "2025-02-28 23:30 -03"},
+		{"to_tz from postgres string", `{{ d|to_tz:"America/Sao_Paulo"|date:"02/01 15:04" }}`, pongo2.Context{"d": "2025-03-01 02:30:00"}, "28/02 23:30"},
+		{"add_days", `{{ d|add_days:"30"|date:"2006-01-02" }}`, pongo2.Context{"d": "2025-03-01"}, "2025-03-31"},
+		{"add_days negative", `{{ d|add_days:"-7"|date:"2006-01-02" }}`, pongo2.Context{"d": "2025-03-01"}, "2025-02-22"},
+		{"add_months", `{{ d|add_months:"-1"|date:"2006-01-02" }}`, pongo2.Context{"d": "2025-03-15"}, "2025-02-15"},
+		{"start_of_month in timezone", `{{ d|to_tz:"America/Sao_Paulo"|start_of_month|date:"2006-01-02 15:04" }}`, pongo2.Context{"d": created}, "2025-02-01 00:00"},
+		{"end_of_month", `{{ d|end_of_month|date:"2006-01-02 15:04:05" }}`, pongo2.Context{"d": "2024-02-10"}, "2024-02-29 23:59:59"},
+		{"missing date", `[{{ d|add_days:"1" }}]`, pongo2.Context{}, "[]"},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			tpl, err := SafeFromString(tt.template)
+			require.NoError(t, err)
+
+			out, err := tpl.Execute(tt.context)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, out)
+		})
+	}
+}
+
+func TestBusinessDaysBetweenFilter(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name     string
+		from     any
+		to       any
+		expected int
+	}{
+		{"same day", "2025-03-10", "2025-03-10", 0},
+		{"over a weekend", "2025-03-07", "2025-03-10", 1},
+		{"full week", "2025-03-10", "2025-03-17", 5},
+		{"over carnival", "2025-02-28", "2025-03-06", 2},
+		{"over good friday and tiradentes", "2025-04-17", "2025-04-22", 1},
+		{"backwards", "2025-03-10", "2025-03-07", -1},
+		{"timestamps use their calendar day", time.Date(2025, 3, 7, 23, 0, 0, 0, time.UTC), "2025-03-11T01:00:00Z", 2},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			out, err := businessDaysBetweenFilter(pongo2.AsValue(tt.from), pongo2.AsValue(tt.to))
+			require.Nil(t, err)
+			assert.Equal(t, tt.expected, out.Integer())
+		})
+	}
+}
+
+func TestDateFilters_Errors(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name     string
+		template string
+		context  pongo2.Context
+	}{
+		{"to_tz without timezone", `{{ d|to_tz }}`, pongo2.Context{"d": "2025-03-01"}},
+		{"to_tz invalid timezone", `{{ d|to_tz:"Mars/Olympus" }}`, pongo2.Context{"d": "2025-03-01"}},
+		{"add_days non-integer", `{{ d|add_days:"x" }}`, pongo2.Context{"d": "2025-03-01"}},
+		{"invalid date", `{{ d|start_of_month }}`, pongo2.Context{"d": "March"}},
+		{"invalid end date", `{{ d|business_days_between:e }}`, pongo2.Context{"d": "2025-03-01", "e": "soon"}},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			tpl, err := SafeFromString(tt.template)
+			require.NoError(t, err)
+
+			_, err = tpl.Execute(tt.context)
+			require.Error(t, err)
+		})
+	}
+}
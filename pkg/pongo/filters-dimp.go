@@ -42,11 +42,15 @@ func replaceFilter(in *pongo2.Value, param *pongo2.Value) (*pongo2.Value, *pongo
 }
 
 // whereFilter filters an array of maps by a field condition.
-// Syntax: {{ array|where:"field:value" }}
+// Syntax: {{ array|where:"field:value" }} or {{ array|where:"field<operator>value" }}
 // Supports nested fields using dot notation: {{ array|where:"address.state:SP" }}
+// "field:value" compares the value as a string. The ==, !=, >, >=, < and <= operators compare
+// numbers as decimals and dates as instants; "in" and "not in" take a comma-separated list.
 // Examples:
 //   - {{ holders|where:"state:SP" }} → holders where state == "SP"
 //   - {{ holders|where:"address.uf:SP" }} → holders where address.uf == "SP"
+//   - {{ operations|where:"amount>1000" }} → operations above 1000
+//   - {{ operations|where:"type in PIX,TED" }} → PIX and TED operations
 func whereFilter(in *pongo2.Value, param *pongo2.Value) (*pongo2.Value, *pongo2.Error) {
 	// Try to get as []map[string]any first
 	list, ok := toMapSlice(in.Interface())
@@ -57,28 +61,21 @@ func whereFilter(in *pongo2.Value, param *pongo2.Value) (*pongo2.Value, *pongo2.
 		}
 	}
 
-	// Parse parameter: "field:value"
-	paramStr := param.String()
-
-	colonIndex := strings.Index(paramStr, ":")
-	if colonIndex == -1 {
+	// Parse parameter: "field:value" or "field<operator>value"
+	condition, ok := parseWhereCondition(param.String())
+	if !ok {
 		return nil, &pongo2.Error{
 			Sender:    "where",
-			OrigError: fmt.Errorf("invalid format, expected 'field:value', got '%s'", paramStr),
+			OrigError: fmt.Errorf("invalid format, expected 'field:value' or 'field<operator>value', got '%s'", param.String()),
 		}
 	}
 
-	field := paramStr[:colonIndex]
-	expectedValue := paramStr[colonIndex+1:]
-
 	// Filter
 	var result []map[string]any
 
 	for _, item := range list {
-		if val, ok := getNestedField(item, field); ok {
-			if fmt.Sprintf("%v", val) == expectedValue {
-				result = append(result, item)
-			}
+		if condition.matches(item) {
+			result = append(result, item)
 		}
 	}
 
@@ -145,20 +142,28 @@ func sumFilter(in *pongo2.Value, param *pongo2.Value) (*pongo2.Value, *pongo2.Er
 	return pongo2.AsValue(total.String()), nil
 }
 
-// toDecimal converts various numeric types to decimal.Decimal
+// toDecimal converts various numeric types to decimal.Decimal.
+// Values implementing fmt.Stringer (e.g., json.Number, BSON Decimal128) are parsed from their string form.
 func toDecimal(v any) (decimal.Decimal, bool) {
 	switch t := v.(type) {
 	case int:
 		return decimal.NewFromInt(int64(t)), true
+	case int32:
+		return decimal.NewFromInt32(t), true
 	case int64:
 		return decimal.NewFromInt(t), true
+	case float32:
+		return decimal.NewFromFloat32(t), true
 	case float64:
 		return decimal.NewFromFloat(t), true
 	case string:
-		d, err := decimal.NewFromString(t)
+		d, err := decimal.NewFromString(strings.TrimSpace(t))
 		return d, err == nil
 	case decimal.Decimal:
 		return t, true
+	case fmt.Stringer:
+		d, err := decimal.NewFromString(t.String())
+		return d, err == nil
 	default:
 		return decimal.Zero, false
 	}
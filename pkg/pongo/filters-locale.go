@@ -0,0 +1,346 @@
+// Copyright (c) 2026 Lerian Studio. All rights reserved.
+// Use of this source code is governed by the Elastic License 2.0
+// that can be found in the LICENSE file.
+
+package pongo
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/LerianStudio/reporter/pkg/constant"
+
+	"github.com/flosch/pongo2/v6"
+	"github.com/go-playground/locales"
+	"github.com/go-playground/locales/en_US"
+	"github.com/go-playground/locales/es"
+	"github.com/go-playground/locales/pt_BR"
+	"github.com/shopspring/decimal"
+)
+
+// localeFormat holds how numbers, amounts and dates are written in a locale.
+// Separators and the percent pattern are taken from the CLDR data of go-playground/locales;
+// amounts are formatted here because the library formats through float64 and lacks some symbols (e.g. R$ for pt_BR).
+type localeFormat struct {
+	translator      locales.Translator
+	decimal         string            // Decimal separator
+	group           string            // Thousands separator
+	percentPattern  string            // Percent pattern where "#" is replaced by the number, e.g. "#%"
+	currencyPattern string            // Currency pattern where "¤" is the symbol and "#" the amount, spaced by a no-break space as in CLDR
+	currency        string            // Currency used when format_currency has no parameter
+	symbols         map[string]string // Locale-specific currency symbols overriding currencySymbols
+}
+
+// currencySymbols maps the ISO 4217 codes accepted by format_currency to their symbols.
+var currencySymbols = map[string]string{
+	"BRL": "R$",
+	"USD": "US$",
+	"EUR": "€",
+	"GBP": "£",
+	"ARS": "ARS",
+	"MXN": "MX$",
+}
+
+// localeFormats holds the supported locales, keyed by their canonical tag.
+var localeFormats = map[string]*localeFormat{
+	constant.LocalePtBR: newLocaleFormat(pt_BR.New(), "¤\u00a0#", "BRL", nil),
+	constant.LocaleEnUS: newLocaleFormat(en_US.New(), "¤#", "USD", map[string]string{"USD": "$"}),
+	constant.LocaleES:   newLocaleFormat(es.New(), "#\u00a0¤", "EUR", nil),
+}
+
+// newLocaleFormat derives the separators and percent pattern of a locale from its translator.
+func newLocaleFormat(translator locales.Translator, currencyPattern, currency string, symbols map[string]string) *localeFormat {
+	var separators []string
+
+	for _, r := range translator.FmtNumber(1234.5, 1) {
+		if !unicode.IsDigit(r) {
+			separators = append(separators, string(r))
+		}
+	}
+
+	return &localeFormat{
+		translator:      translator,
+		group:           separators[0],
+		decimal:         separators[len(separators)-1],
+		percentPattern:  strings.Replace(translator.FmtPercent(1, 0), "1", "#", 1),
+		currencyPattern: currencyPattern,
+		currency:        currency,
+		symbols:         symbols,
+	}
+}
+
+// NormalizeLocale returns the canonical tag of a supported locale, accepting any letter case
+// and "_" as separator (e.g. "pt_br" -> "pt-BR"). It reports false for unsupported locales.
+func NormalizeLocale(locale string) (string, bool) {
+	wanted := strings.ReplaceAll(strings.TrimSpace(locale), "_", "-")
+
+	for tag := range localeFormats {
+		if strings.EqualFold(tag, wanted) {
+			return tag, true
+		}
+	}
+
+	return "", false
+}
+
+// LocaleContextKey is the key used to store the locale of a render in the pongo2 context
+const LocaleContextKey = "_locale"
+
+// localizedValue is a value bound to the locale it must be formatted in by the format_* filters.
+type localizedValue struct {
+	value  any
+	locale string
+}
+
+// String renders the wrapped value unchanged, so a localized value printed without a format filter looks as before.
+func (v localizedValue) String() string {
+	return pongo2.AsValue(v.value).String()
+}
+
+// unwrapLocalized returns the value to format and its locale, falling back to the default locale.
+func unwrapLocalized(in *pongo2.Value) (any, *localeFormat) {
+	if v, ok := in.Interface().(localizedValue); ok {
+		return v.value, localeFormats[v.locale]
+	}
+
+	return in.Interface(), localeFormats[constant.DefaultLocale]
+}
+
+// localizeFilter binds a value to a locale for the format_* filters that follow it.
+// A value that is already localized keeps its locale, so the closest localize to the value wins.
+// Syntax: {{ value|localize:"pt-BR"|format_number }}
+func localizeFilter(in *pongo2.Value, param *pongo2.Value) (*pongo2.Value, *pongo2.Error) {
+	locale, ok := NormalizeLocale(param.String())
+	if !ok {
+		return nil, &pongo2.Error{
+			Sender:    "localize",
+			OrigError: fmt.Errorf("unsupported locale '%s'", param.String()),
+		}
+	}
+
+	if _, localized := in.Interface().(localizedValue); localized {
+		return in, nil
+	}
+
+	return pongo2.AsValue(localizedValue{value: in.Interface(), locale: locale}), nil
+}
+
+// formatNumberFilter formats a number with the locale's decimal and thousands separators.
+// The parameter is the number of decimal places (rounded half-up); without it the value keeps its own decimal places.
+// Examples (pt-BR):
+//   - {{ 1234567.891|format_number:"2" }} → "1.234.567,89"
+//   - {{ 1234|format_number }} → "1.234"
+func formatNumberFilter(in *pongo2.Value, param *pongo2.Value) (*pongo2.Value, *pongo2.Error) {
+	value, lf := unwrapLocalized(in)
+
+	dec, empty, err := localizedDecimal("format_number", value)
+	if err != nil || empty {
+		return pongo2.AsValue(""), err
+	}
+
+	places := max(-dec.Exponent(), 0)
+
+	if param.String() != "" {
+		places, err = decimalPlacesParam("format_number", param.String())
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return pongo2.AsValue(lf.formatDecimal(dec, places)), nil
+}
+
+// formatCurrencyFilter formats an amount with two decimal places and the currency symbol placed as the locale does.
+// The parameter is the ISO 4217 currency code; without it the locale's own currency is used.
+// Examples:
+//   - {{ 1234567.89|localize:"pt-BR"|format_currency }} → "R$ 1.234.567,89"
+//   - {{ 1234.5|localize:"es"|format_currency:"USD" }} → "1.234,50 US$"
+func formatCurrencyFilter(in *pongo2.Value, param *pongo2.Value) (*pongo2.Value, *pongo2.Error) {
+	value, lf := unwrapLocalized(in)
+
+	dec, empty, err := localizedDecimal("format_currency", value)
+	if err != nil || empty {
+		return pongo2.AsValue(""), err
+	}
+
+	code := strings.ToUpper(strings.TrimSpace(param.String()))
+	if code == "" {
+		code = lf.currency
+	}
+
+	symbol, ok := lf.symbols[code]
+	if !ok {
+		symbol, ok = currencySymbols[code]
+	}
+
+	if !ok {
+		return nil, &pongo2.Error{
+			Sender:    "format_currency",
+			OrigError: fmt.Errorf("unsupported currency '%s'", code),
+		}
+	}
+
+	amount := roundDecimal(dec, constant.DecimalPrecisionCurrency, constant.RoundingHalfUp)
+	formatted := strings.NewReplacer("¤", symbol, "#", lf.formatDecimal(amount.Abs(), constant.DecimalPrecisionCurrency)).Replace(lf.currencyPattern)
+
+	if amount.IsNegative() {
+		formatted = "-" + formatted
+	}
+
+	return pongo2.AsValue(formatted), nil
+}
+
+// formatPercentFilter formats a value that is already a percentage (e.g. 12.5 for 12.5%) with the locale's percent sign.
+// The parameter is the number of decimal places, two by default.
+// Examples:
+//   - {{ 12.5|localize:"pt-BR"|format_percent }} → "12,50%"
+//   - {{ 12.5|localize:"en-US"|format_percent:"1" }} → "12.5%"
+func formatPercentFilter(in *pongo2.Value, param *pongo2.Value) (*pongo2.Value, *pongo2.Error) {
+	value, lf := unwrapLocalized(in)
+
+	dec, empty, err := localizedDecimal("format_percent", value)
+	if err != nil || empty {
+		return pongo2.AsValue(""), err
+	}
+
+	places := int32(constant.DecimalPrecisionPercent)
+
+	if param.String() != "" {
+		places, err = decimalPlacesParam("format_percent", param.String())
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return pongo2.AsValue(strings.Replace(lf.percentPattern, "#", lf.formatDecimal(dec, places), 1)), nil
+}
+
+// formatDateFilter formats a time.Time or a date string (RFC 3339, "YYYY-MM-dd" or "YYYY-MM-dd HH:mm:ss")
+// in one of the locale's styles: short (default), medium, long or full. Any other parameter is a custom
+// layout in the date_time tag syntax, e.g. "dd/MM/YYYY HH:mm".
+// Examples (pt-BR, 2025-03-07):
+//   - {{ created_at|format_date }} → "07/03/2025"
+//   - {{ created_at|format_date:"long" }} → "7 de março de 2025"
+func formatDateFilter(in *pongo2.Value, param *pongo2.Value) (*pongo2.Value, *pongo2.Error) {
+	value, lf := unwrapLocalized(in)
+
+	if value == nil || value == "" {
+		return pongo2.AsValue(""), nil
+	}
+
+	t, ok := parseTimeValue(value)
+	if !ok {
+		return nil, &pongo2.Error{
+			Sender:    "format_date",
+			OrigError: fmt.Errorf("invalid date '%v'", value),
+		}
+	}
+
+	switch style := param.String(); style {
+	case "", "short":
+		return pongo2.AsValue(lf.translator.FmtDateShort(t)), nil
+	case "medium":
+		return pongo2.AsValue(lf.translator.FmtDateMedium(t)), nil
+	case "long":
+		return pongo2.AsValue(lf.translator.FmtDateLong(t)), nil
+	case "full":
+		return pongo2.AsValue(lf.translator.FmtDateFull(t)), nil
+	default:
+		return pongo2.AsValue(t.Format(convertToGoDateLayout(style))), nil
+	}
+}
+
+// localizedDecimal converts the value of a format_* filter to a decimal.
+// Nil and empty values are reported as empty so missing data renders as an empty string.
+func localizedDecimal(sender string, value any) (decimal.Decimal, bool, *pongo2.Error) {
+	if value == nil || value == "" {
+		return decimal.Zero, true, nil
+	}
+
+	dec, ok := toDecimal(value)
+	if !ok {
+		return decimal.Zero, false, &pongo2.Error{
+			Sender:    sender,
+			OrigError: fmt.Errorf("expected a number, got '%v'", value),
+		}
+	}
+
+	return dec, false, nil
+}
+
+// decimalPlacesParam parses the number of decimal places given to a format_* filter.
+func decimalPlacesParam(sender, param string) (int32, *pongo2.Error) {
+	places, err := strconv.Atoi(strings.TrimSpace(param))
+	if err != nil || places < 0 || places > constant.CalcMaxScale {
+		return 0, &pongo2.Error{
+			Sender:    sender,
+			OrigError: fmt.Errorf("invalid number of decimal places '%s'", param),
+		}
+	}
+
+	return int32(places), nil
+}
+
+// formatDecimal writes the value rounded half-up to the given places, grouping the integer part in thousands.
+func (lf *localeFormat) formatDecimal(value decimal.Decimal, places int32) string {
+	digits := roundDecimal(value, places, constant.RoundingHalfUp).StringFixed(places)
+
+	sign := ""
+	if strings.HasPrefix(digits, "-") {
+		sign, digits = "-", digits[1:]
+	}
+
+	integer, fraction, _ := strings.Cut(digits, ".")
+
+	var b strings.Builder
+
+	b.WriteString(sign)
+
+	for i, r := range integer {
+		if i > 0 && (len(integer)-i)%3 == 0 {
+			b.WriteString(lf.group)
+		}
+
+		b.WriteRune(r)
+	}
+
+	if fraction != "" {
+		b.WriteString(lf.decimal)
+		b.WriteString(fraction)
+	}
+
+	return b.String()
+}
+
+// parseTimeValue converts a time.Time or a date string as returned by the datasources to time.Time.
+func parseTimeValue(val any) (time.Time, bool) {
+	switch v := val.(type) {
+	case time.Time:
+		return v, true
+	case *time.Time:
+		if v != nil {
+			return *v, true
+		}
+	case string:
+		for _, layout := range dateValueLayouts {
+			if t, err := time.Parse(layout, strings.TrimSpace(v)); err == nil {
+				return t, true
+			}
+		}
+	}
+
+	return time.Time{}, false
+}
+
+// dateValueLayouts are the string date layouts accepted by parseTimeValue, tried in order.
+var dateValueLayouts = []string{
+	time.RFC3339,
+	"2006-01-02",
+	time.RFC3339Nano,
+	"2006-01-02 15:04:05",
+	"2006-01-02T15:04:05",
+	"2006-01-02 15:04:05.999999999-07:00",
+}
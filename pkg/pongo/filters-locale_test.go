@@ -0,0 +1,120 @@
+// Copyright (c) 2026 Lerian Studio. All rights reserved.
+// Use of this source code is governed by the Elastic License 2.0
+// that can be found in the LICENSE file.
+
+package pongo
+
+import (
+	"testing"
+	"time"
+
+	"github.com/flosch/pongo2/v6"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// renderLocalized executes a template.
+func renderLocalized(t *testing.T, template string, ctx pongo2.Context) (string, error) {
+	t.Helper()
+
+	tpl, err := SafeFromString(template)
+	require.NoError(t, err)
+
+	return tpl.Execute(ctx)
+}
+
+func TestNormalizeLocale(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		input    string
+		expected string
+		ok       bool
+	}{
+		{"pt-BR", "pt-BR", true},
+		{"pt_br", "pt-BR", true},
+		{" EN-us ", "en-US", true},
+		{"ES", "es", true},
+		{"fr-FR", "", false},
+		{"", "", false},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.input, func(t *testing.T) {
+			t.Parallel()
+			locale, ok := NormalizeLocale(tt.input)
+			assert.Equal(t, tt.ok, ok)
+			assert.Equal(t, tt.expected, locale)
+		})
+	}
+}
+
+func TestFormatFilters(t *testing.T) {
+	t.Parallel()
+	created := time.Date(2025, 3, 7, 14, 5, 0, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		template string
+		context  pongo2.Context
+		expected string
+	}{
+		{"number pt-BR", `{{ v|localize:"pt-BR"|format_number:"2" }}`, pongo2.Context{"v": "1234567.891"}, "1.234.567,89"},
+		{"number en-US", `{{ v|localize:"en-US"|format_number:"2" }}`, pongo2.Context{"v": "1234567.891"}, "1,234,567.89"},
+		{"number es", `{{ v|localize:"es"|format_number:"2" }}`, pongo2.Context{"v": "-1234567.895"}, "-1.234.567,90"},
+		{"number keeps own places", `{{ v|localize:"pt-BR"|format_number }}`, pongo2.Context{"v": decimal.RequireFromString("1230.5")}, "1.230,5"},
+		{"number integer", `{{ v|localize:"pt-BR"|format_number }}`, pongo2.Context{"v": 1230}, "1.230"},
+		{"number default locale", `{{ v|format_number:"1" }}`, pongo2.Context{"v": 1234.56}, "1,234.6"},
+		{"number beyond float precision", `{{ v|localize:"pt-BR"|format_number }}`, pongo2.Context{"v": "12345678901234567.89"}, "12.345.678.901.234.567,89"},
+		{"currency pt-BR", `{{ v|localize:"pt-BR"|format_currency }}`, pongo2.Context{"v": "1234567.89"}, "R$\u00a01.234.567,89"},
+		{"currency en-US negative", `{{ v|localize:"en-US"|format_currency }}`, pongo2.Context{"v": -1234.5}, "-$1,234.50"},
+		{"currency es", `{{ v|localize:"es"|format_currency }}`, pongo2.Context{"v": 1234.5}, "1.234,50\u00a0€"},
+		{"currency code", `{{ v|localize:"pt-BR"|format_currency:"usd" }}`, pongo2.Context{"v": 10}, "US$\u00a010,00"},
+		{"percent pt-BR", `{{ v|localize:"pt-BR"|format_percent }}`, pongo2.Context{"v": 12.5}, "12,50%"},
+		{"percent es", `{{ v|localize:"es"|format_percent:"1" }}`, pongo2.Context{"v": "12.55"}, "12,6\u00a0%"},
+		{"date short", `{{ d|localize:"pt-BR"|format_date }}`, pongo2.Context{"d": created}, "07/03/2025"},
+		{"date long", `{{ d|localize:"pt-BR"|format_date:"long" }}`, pongo2.Context{"d": created}, "7 de março de 2025"},
+		{"date full es", `{{ d|localize:"es"|format_date:"full" }}`, pongo2.Context{"d": created}, "viernes, 7 de marzo de 2025"},
+		{"date from string", `{{ d|localize:"en-US"|format_date:"medium" }}`, pongo2.Context{"d": "2025-03-07T14:05:00Z"}, "Mar 7, 2025"},
+		{"date from date-only string", `{{ d|localize:"pt-BR"|format_date }}`, pongo2.Context{"d": "2025-03-07"}, "07/03/2025"},
+		{"date custom layout", `{{ d|format_date:"dd/MM/YYYY HH:mm" }}`, pongo2.Context{"d": created}, "07/03/2025 14:05"},
+		{"closest localize wins", `{{ v|localize:"es"|localize:"en-US"|format_number }}`, pongo2.Context{"v": 1234.5}, "1.234,5"},
+		{"missing value", `[{{ v|localize:"pt-BR"|format_currency }}]`, pongo2.Context{}, "[]"},
+		{"localized value without format", `{{ v|localize:"pt-BR" }}`, pongo2.Context{"v": "1234.5"}, "1234.5"},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			out, err := renderLocalized(t, tt.template, tt.context)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, out)
+		})
+	}
+}
+
+func TestFormatFilters_Errors(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name     string
+		template string
+		context  pongo2.Context
+	}{
+		{"unsupported locale", `{{ v|localize:"fr-FR"|format_number }}`, pongo2.Context{"v": 1}},
+		{"non-numeric value", `{{ v|format_number }}`, pongo2.Context{"v": "abc"}},
+		{"invalid decimal places", `{{ v|format_number:"x" }}`, pongo2.Context{"v": 1}},
+		{"unsupported currency", `{{ v|format_currency:"XYZ" }}`, pongo2.Context{"v": 1}},
+		{"invalid date", `{{ v|format_date }}`, pongo2.Context{"v": "not a date"}},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			_, err := renderLocalized(t, tt.template, tt.context)
+			require.Error(t, err)
+		})
+	}
+}
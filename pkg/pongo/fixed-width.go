@@ -0,0 +1,378 @@
+// Copyright (c) 2026 Lerian Studio. All rights reserved.
+// Use of this source code is governed by the Elastic License 2.0
+// that can be found in the LICENSE file.
+
+package pongo
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/LerianStudio/reporter/pkg/constant"
+	"github.com/shopspring/decimal"
+)
+
+// FixedWidthLayout describes the records of a fixed-width (positional) file. Every record type covers
+// all RecordLength positions with contiguous fields.
+//
+// Example:
+//
+//	{
+//	  "record_length": 20,
+//	  "records": {
+//	    "header":  {"fields": [{"name": "type", "start": 1, "length": 1, "value": "0"},
+//	                           {"name": "company", "start": 2, "length": 19}]},
+//	    "detail":  {"fields": [{"name": "type", "start": 1, "length": 1, "value": "1"},
+//	                           {"name": "amount", "start": 2, "length": 13, "type": "numeric", "decimals": 2},
+//	                           {"name": "seq", "start": 15, "length": 6, "type": "numeric", "source": "sequence"}]},
+//	    "trailer": {"fields": [{"name": "type", "start": 1, "length": 1, "value": "9"},
+//	                           {"name": "count", "start": 2, "length": 6, "type": "numeric", "source": "count", "of": "detail"},
+//	                           {"name": "total", "start": 8, "length": 13, "type": "numeric", "decimals": 2, "source": "sum", "of": "detail.amount"}]}
+//	  }
+//	}
+type FixedWidthLayout struct {
+	RecordLength int                         `json:"record_length"`
+	Records      map[string]FixedWidthRecord `json:"records"`
+}
+
+// FixedWidthRecord is a record type of a fixed-width layout.
+type FixedWidthRecord struct {
+	Fields []FixedWidthField `json:"fields"`
+}
+
+// FixedWidthField is a field of a record, at 1-based position Start.
+type FixedWidthField struct {
+	Name   string `json:"name"`
+	Start  int    `json:"start"`
+	Length int    `json:"length"`
+
+	// Type is alpha (default), numeric or date.
+	Type string `json:"type,omitempty"`
+
+	// Align is left or right. Alpha fields are left-aligned and the others right-aligned by default.
+	Align string `json:"align,omitempty"`
+
+	// Pad is the padding character: a space for alpha fields and "0" for the others by default.
+	Pad string `json:"pad,omitempty"`
+
+	// Decimals is the number of implied decimal places of a numeric field, e.g. 2 writes 12.5 as 1250.
+	Decimals int32 `json:"decimals,omitempty"`
+
+	// Format is the layout of a date field, e.g. "ddMMYYYY".
+	Format string `json:"format,omitempty"`
+
+	// Value is written when the record tag gives no value for the field.
+	Value string `json:"value,omitempty"`
+
+	// Source fills the field with the record sequence, a count of records or the sum of a field.
+	Source string `json:"source,omitempty"`
+
+	// Of names the record types counted, comma-separated (all when empty), or the "record.field" summed.
+	Of string `json:"of,omitempty"`
+}
+
+// ParseFixedWidthLayout parses and validates a JSON fixed-width layout.
+func ParseFixedWidthLayout(data []byte) (*FixedWidthLayout, error) {
+	var layout FixedWidthLayout
+
+	if err := json.Unmarshal(data, &layout); err != nil {
+		return nil, fmt.Errorf("invalid fixed-width layout: %w", err)
+	}
+
+	if err := layout.validate(); err != nil {
+		return nil, fmt.Errorf("invalid fixed-width layout: %w", err)
+	}
+
+	return &layout, nil
+}
+
+// validate checks that every record type covers the record length with contiguous, well-formed fields.
+func (l *FixedWidthLayout) validate() error {
+	if l.RecordLength <= 0 {
+		return fmt.Errorf("record_length must be positive")
+	}
+
+	if len(l.Records) == 0 {
+		return fmt.Errorf("no record types defined")
+	}
+
+	for name, record := range l.Records {
+		position := 1
+		names := map[string]bool{}
+
+		for i := range record.Fields {
+			field := &record.Fields[i]
+
+			if field.Start != position || field.Length <= 0 {
+				return fmt.Errorf("record '%s': field '%s' must start at position %d with a positive length", name, field.Name, position)
+			}
+
+			if field.Name != "" && names[field.Name] {
+				return fmt.Errorf("record '%s': duplicate field '%s'", name, field.Name)
+			}
+
+			names[field.Name] = true
+			position += field.Length
+
+			if err := l.validateField(field); err != nil {
+				return fmt.Errorf("record '%s': field '%s': %w", name, field.Name, err)
+			}
+		}
+
+		if position-1 != l.RecordLength {
+			return fmt.Errorf("record '%s' has %d positions, expected %d", name, position-1, l.RecordLength)
+		}
+	}
+
+	return nil
+}
+
+// validateField checks the type, alignment, padding and source of a field.
+func (l *FixedWidthLayout) validateField(field *FixedWidthField) error {
+	switch field.Type {
+	case "", constant.FixedWidthTypeAlpha, constant.FixedWidthTypeNumeric:
+	case constant.FixedWidthTypeDate:
+		if field.Format == "" {
+			return fmt.Errorf("date fields require a format")
+		}
+	default:
+		return fmt.Errorf("unknown type '%s'", field.Type)
+	}
+
+	if field.Align != "" && field.Align != "left" && field.Align != "right" {
+		return fmt.Errorf("unknown alignment '%s'", field.Align)
+	}
+
+	if field.Pad != "" && utf8.RuneCountInString(field.Pad) != 1 {
+		return fmt.Errorf("pad must be a single character")
+	}
+
+	switch field.Source {
+	case "", constant.FixedWidthSourceSequence:
+	case constant.FixedWidthSourceCount:
+		for _, recordType := range splitList(field.Of) {
+			if _, ok := l.Records[recordType]; !ok {
+				return fmt.Errorf("counts unknown record '%s'", recordType)
+			}
+		}
+	case constant.FixedWidthSourceSum:
+		recordType, fieldName, _ := strings.Cut(field.Of, ".")
+
+		record, ok := l.Records[recordType]
+		if !ok || !slices.ContainsFunc(record.Fields, func(f FixedWidthField) bool {
+			return f.Name == fieldName && f.Type == constant.FixedWidthTypeNumeric
+		}) {
+			return fmt.Errorf("sums '%s', which is not a numeric field", field.Of)
+		}
+	default:
+		return fmt.Errorf("unknown source '%s'", field.Source)
+	}
+
+	return nil
+}
+
+// ValidateOutput checks that the output is made of CRLF-terminated records of the layout's length.
+func (l *FixedWidthLayout) ValidateOutput(output string) error {
+	if output == "" {
+		return fmt.Errorf("fixed-width output has no records")
+	}
+
+	if !strings.HasSuffix(output, constant.FixedWidthLineEnding) {
+		return fmt.Errorf("fixed-width output must end with CRLF")
+	}
+
+	lines := strings.Split(strings.TrimSuffix(output, constant.FixedWidthLineEnding), constant.FixedWidthLineEnding)
+
+	for i, line := range lines {
+		if strings.ContainsAny(line, "\r\n") {
+			return fmt.Errorf("line %d of the fixed-width output has a line break that is not CRLF", i+1)
+		}
+
+		if length := utf8.RuneCountInString(line); length != l.RecordLength {
+			return fmt.Errorf("line %d of the fixed-width output has %d characters, expected %d", i+1, length, l.RecordLength)
+		}
+	}
+
+	return nil
+}
+
+// format writes a value in the positions of the field. A nil value leaves the field blank.
+func (f *FixedWidthField) format(value any) (string, error) {
+	var text string
+
+	switch f.Type {
+	case constant.FixedWidthTypeNumeric:
+		if value != nil {
+			dec, ok := toDecimal(value)
+			if !ok {
+				return "", fmt.Errorf("'%v' is not a number", value)
+			}
+
+			if dec.IsNegative() {
+				return "", fmt.Errorf("%s is negative", dec.String())
+			}
+
+			text = dec.Shift(f.Decimals).Round(0).String()
+		}
+	case constant.FixedWidthTypeDate:
+		if value != nil && value != "" {
+			t, ok := parseTimeValue(value)
+			if !ok {
+				return "", fmt.Errorf("'%v' is not a date", value)
+			}
+
+			text = t.Format(convertToGoDateLayout(f.Format))
+		}
+	default:
+		if value != nil {
+			text = strings.Map(func(r rune) rune {
+				if r == '\r' || r == '\n' || r == '\t' {
+					return ' '
+				}
+
+				return r
+			}, fmt.Sprintf("%v", value))
+		}
+
+		if runes := []rune(text); len(runes) > f.Length {
+			text = string(runes[:f.Length])
+		}
+	}
+
+	if length := utf8.RuneCountInString(text); length > f.Length {
+		return "", fmt.Errorf("'%s' does not fit in %d positions", text, f.Length)
+	}
+
+	return f.pad(text), nil
+}
+
+// pad fills the field up to its length, using the field alignment and padding character or the
+// defaults of its type.
+func (f *FixedWidthField) pad(text string) string {
+	alpha := f.Type == "" || f.Type == constant.FixedWidthTypeAlpha
+
+	padding := f.Pad
+	if padding == "" {
+		padding = "0"
+		if alpha {
+			padding = " "
+		}
+	}
+
+	fill := strings.Repeat(padding, f.Length-utf8.RuneCountInString(text))
+
+	if f.Align == "left" || (f.Align == "" && alpha) {
+		return text + fill
+	}
+
+	return fill + text
+}
+
+// fixedWidthState holds the records written by a render, for the sequence, count and sum sources.
+type fixedWidthState struct {
+	layout *FixedWidthLayout
+	lines  int
+	counts map[string]int
+	sums   map[string]decimal.Decimal
+}
+
+// newFixedWidthState creates the record state of a render.
+func newFixedWidthState(layout *FixedWidthLayout) *fixedWidthState {
+	return &fixedWidthState{
+		layout: layout,
+		counts: map[string]int{},
+		sums:   map[string]decimal.Decimal{},
+	}
+}
+
+// writeRecord formats a record of the given type from the values given by the record tag. Fields
+// without a value are filled from their source or constant value, or left blank.
+func (s *fixedWidthState) writeRecord(recordType string, values map[string]any) (string, error) {
+	record, ok := s.layout.Records[recordType]
+	if !ok {
+		return "", fmt.Errorf("unknown record type '%s'", recordType)
+	}
+
+	for name := range values {
+		if !slices.ContainsFunc(record.Fields, func(f FixedWidthField) bool { return f.Name == name }) {
+			return "", fmt.Errorf("record '%s' has no field '%s'", recordType, name)
+		}
+	}
+
+	s.lines++
+	s.counts[recordType]++
+
+	var line strings.Builder
+
+	for i := range record.Fields {
+		field := &record.Fields[i]
+
+		value, given := values[field.Name]
+		if !given {
+			value = s.sourceValue(field)
+		}
+
+		text, err := field.format(value)
+		if err != nil {
+			return "", fmt.Errorf("record '%s': field '%s': %w", recordType, field.Name, err)
+		}
+
+		line.WriteString(text)
+	}
+
+	for _, field := range record.Fields {
+		if value, given := values[field.Name]; given && field.Type == constant.FixedWidthTypeNumeric {
+			if dec, ok := toDecimal(value); ok {
+				key := recordType + "." + field.Name
+				s.sums[key] = s.sums[key].Add(dec)
+			}
+		}
+	}
+
+	return line.String(), nil
+}
+
+// sourceValue returns the value of a field not given by the record tag.
+func (s *fixedWidthState) sourceValue(field *FixedWidthField) any {
+	switch field.Source {
+	case constant.FixedWidthSourceSequence:
+		return s.lines
+	case constant.FixedWidthSourceCount:
+		recordTypes := splitList(field.Of)
+		if len(recordTypes) == 0 {
+			return s.lines
+		}
+
+		count := 0
+
+		for _, recordType := range recordTypes {
+			count += s.counts[recordType]
+		}
+
+		return count
+	case constant.FixedWidthSourceSum:
+		return s.sums[field.Of]
+	}
+
+	if field.Value != "" {
+		return field.Value
+	}
+
+	return nil
+}
+
+// splitList splits a comma-separated list, dropping empty items.
+func splitList(list string) []string {
+	var items []string
+
+	for _, item := range strings.Split(list, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+
+	return items
+}
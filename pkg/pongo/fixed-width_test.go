@@ -0,0 +1,132 @@
+// Copyright (c) 2026 Lerian Studio. All rights reserved.
+// Use of this source code is governed by the Elastic License 2.0
+// that can be found in the LICENSE file.
+
+package pongo
+
+import (
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testFixedWidthLayout is a 20-position layout with a header, detail lines and a trailer.
+const testFixedWidthLayout = `{
+  "record_length": 20,
+  "records": {
+    "header": {"fields": [
+      {"name": "type", "start": 1, "length": 1, "value": "0"},
+      {"name": "company", "start": 2, "length": 11},
+      {"name": "date", "start": 13, "length": 8, "type": "date", "format": "ddMMYYYY"}
+    ]},
+    "detail": {"fields": [
+      {"name": "type", "start": 1, "length": 1, "value": "1"},
+      {"name": "name", "start": 2, "length": 6},
+      {"name": "amount", "start": 8, "length": 9, "type": "numeric", "decimals": 2},
+      {"name": "seq", "start": 17, "length": 4, "type": "numeric", "source": "sequence"}
+    ]},
+    "trailer": {"fields": [
+      {"name": "type", "start": 1, "length": 1, "value": "9"},
+      {"name": "count", "start": 2, "length": 4, "type": "numeric", "source": "count", "of": "detail"},
+      {"name": "total", "start": 6, "length": 11, "type": "numeric", "decimals": 2, "source": "sum", "of": "detail.amount"},
+      {"name": "lines", "start": 17, "length": 4, "type": "numeric", "source": "count"}
+    ]}
+  }
+}`
+
+func TestParseFixedWidthLayout(t *testing.T) {
+	t.Parallel()
+
+	layout, err := ParseFixedWidthLayout([]byte(testFixedWidthLayout))
+	require.NoError(t, err)
+	assert.Equal(t, 20, layout.RecordLength)
+	assert.Len(t, layout.Records, 3)
+}
+
+func TestParseFixedWidthLayout_Errors(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name   string
+		layout string
+	}{
+		{"invalid json", `{`},
+		{"no record length", `{"records": {"a": {"fields": [{"name": "x", "start": 1, "length": 1}]}}}`},
+		{"no records", `{"record_length": 1}`},
+		{"gap between fields", `{"record_length": 3, "records": {"a": {"fields": [{"name": "x", "start": 1, "length": 1}, {"name": "y", "start": 3, "length": 1}]}}}`},
+		{"short record", `{"record_length": 3, "records": {"a": {"fields": [{"name": "x", "start": 1, "length": 2}]}}}`},
+		{"duplicate field", `{"record_length": 2, "records": {"a": {"fields": [{"name": "x", "start": 1, "length": 1}, {"name": "x", "start": 2, "length": 1}]}}}`},
+		{"unknown type", `{"record_length": 1, "records": {"a": {"fields": [{"name": "x", "start": 1, "length": 1, "type": "money"}]}}}`},
+		{"date without format", `{"record_length": 8, "records": {"a": {"fields": [{"name": "x", "start": 1, "length": 8, "type": "date"}]}}}`},
+		{"long pad", `{"record_length": 1, "records": {"a": {"fields": [{"name": "x", "start": 1, "length": 1, "pad": "ab"}]}}}`},
+		{"count of unknown record", `{"record_length": 1, "records": {"a": {"fields": [{"name": "x", "start": 1, "length": 1, "source": "count", "of": "b"}]}}}`},
+		{"sum of alpha field", `{"record_length": 2, "records": {"a": {"fields": [{"name": "x", "start": 1, "length": 1}, {"name": "y", "start": 2, "length": 1, "source": "sum", "of": "a.x"}]}}}`},
+		{"unknown source", `{"record_length": 1, "records": {"a": {"fields": [{"name": "x", "start": 1, "length": 1, "source": "random"}]}}}`},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			_, err := ParseFixedWidthLayout([]byte(tt.layout))
+			require.Error(t, err)
+		})
+	}
+}
+
+func TestFixedWidthField_Format(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name     string
+		field    FixedWidthField
+		value    any
+		expected string
+		wantErr  bool
+	}{
+		{"alpha padded", FixedWidthField{Length: 5}, "AB", "AB   ", false},
+		{"alpha truncated", FixedWidthField{Length: 3}, "ABCDEF", "ABC", false},
+		{"alpha line breaks", FixedWidthField{Length: 5}, "A\r\nB", "A  B ", false},
+		{"alpha right aligned", FixedWidthField{Length: 4, Align: "right", Pad: "*"}, "7", "***7", false},
+		{"alpha blank", FixedWidthField{Length: 3}, nil, "   ", false},
+		{"numeric implied decimals", FixedWidthField{Length: 8, Type: "numeric", Decimals: 2}, "1234.5", "00123450", false},
+		{"numeric decimal", FixedWidthField{Length: 4, Type: "numeric"}, decimal.NewFromInt(42), "0042", false},
+		{"numeric rounded", FixedWidthField{Length: 4, Type: "numeric", Decimals: 1}, 1.26, "0013", false},
+		{"numeric blank", FixedWidthField{Length: 3, Type: "numeric"}, nil, "000", false},
+		{"numeric overflow", FixedWidthField{Length: 3, Type: "numeric"}, 1000, "", true},
+		{"numeric negative", FixedWidthField{Length: 3, Type: "numeric"}, -1, "", true},
+		{"numeric invalid", FixedWidthField{Length: 3, Type: "numeric"}, "abc", "", true},
+		{"date", FixedWidthField{Length: 8, Type: "date", Format: "ddMMYYYY"}, time.Date(2025, 3, 7, 0, 0, 0, 0, time.UTC), "07032025", false},
+		{"date string", FixedWidthField{Length: 6, Type: "date", Format: "YYMMdd"}, "2025-03-07", "250307", false},
+		{"date blank", FixedWidthField{Length: 8, Type: "date", Format: "ddMMYYYY"}, "", "00000000", false},
+		{"date invalid", FixedWidthField{Length: 8, Type: "date", Format: "ddMMYYYY"}, "soon", "", true},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			out, err := tt.field.format(tt.value)
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, out)
+		})
+	}
+}
+
+func TestFixedWidthLayout_ValidateOutput(t *testing.T) {
+	t.Parallel()
+	layout := &FixedWidthLayout{RecordLength: 3}
+
+	require.NoError(t, layout.ValidateOutput("abc\r\ndef\r\n"))
+	require.NoError(t, layout.ValidateOutput("ção\r\n"))
+
+	for _, output := range []string{"", "abc", "abc\ndef\r\n", "abcd\r\n", "ab\r\n"} {
+		assert.Error(t, layout.ValidateOutput(output), "%q", output)
+	}
+}
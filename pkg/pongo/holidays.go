@@ -0,0 +1,159 @@
+// Copyright (c) 2026 Lerian Studio. All rights reserved.
+// Use of this source code is governed by the Elastic License 2.0
+// that can be found in the LICENSE file.
+
+package pongo
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/LerianStudio/reporter/pkg/constant"
+)
+
+// HolidayCalendar tells which dates are holidays for the business day filters.
+// Only the year, month and day of the date are meaningful.
+type HolidayCalendar interface {
+	IsHoliday(date time.Time) bool
+}
+
+// HolidayCalendarFunc adapts a function to the HolidayCalendar interface.
+type HolidayCalendarFunc func(date time.Time) bool
+
+// IsHoliday calls f(date).
+func (f HolidayCalendarFunc) IsHoliday(date time.Time) bool {
+	return f(date)
+}
+
+var (
+	holidayCalendarsMu sync.RWMutex
+	holidayCalendars   = map[string]HolidayCalendar{
+		constant.HolidayCalendarBR:   HolidayCalendarFunc(isBrazilianHoliday),
+		constant.HolidayCalendarNone: HolidayCalendarFunc(func(time.Time) bool { return false }),
+	}
+	defaultHolidayCalendar = constant.HolidayCalendarBR
+)
+
+// RegisterHolidayCalendar makes a holiday calendar available under the given name,
+// replacing any calendar previously registered with it.
+func RegisterHolidayCalendar(name string, calendar HolidayCalendar) {
+	holidayCalendarsMu.Lock()
+	defer holidayCalendarsMu.Unlock()
+
+	holidayCalendars[name] = calendar
+}
+
+// SetDefaultHolidayCalendar selects the registered calendar used by business_days_between.
+func SetDefaultHolidayCalendar(name string) error {
+	holidayCalendarsMu.Lock()
+	defer holidayCalendarsMu.Unlock()
+
+	if _, ok := holidayCalendars[name]; !ok {
+		return fmt.Errorf("holiday calendar '%s' is not registered", name)
+	}
+
+	defaultHolidayCalendar = name
+
+	return nil
+}
+
+// ConfigureHolidayCalendar selects the default holiday calendar by name, BR when empty. Extra holidays,
+// given as YYYY-MM-DD dates, are added on top of it as the custom calendar.
+func ConfigureHolidayCalendar(name string, extraDates []string) error {
+	if name == "" {
+		name = constant.HolidayCalendarBR
+	}
+
+	if len(extraDates) == 0 {
+		return SetDefaultHolidayCalendar(name)
+	}
+
+	holidays := make(map[string]bool, len(extraDates))
+
+	for _, raw := range extraDates {
+		date, err := time.Parse(constant.HolidayDateLayout, strings.TrimSpace(raw))
+		if err != nil {
+			return fmt.Errorf("invalid holiday date '%s': expected YYYY-MM-DD", raw)
+		}
+
+		holidays[date.Format(constant.HolidayDateLayout)] = true
+	}
+
+	holidayCalendarsMu.RLock()
+	base, ok := holidayCalendars[name]
+	holidayCalendarsMu.RUnlock()
+
+	if !ok {
+		return fmt.Errorf("holiday calendar '%s' is not registered", name)
+	}
+
+	RegisterHolidayCalendar(constant.HolidayCalendarCustom, HolidayCalendarFunc(func(date time.Time) bool {
+		return holidays[date.Format(constant.HolidayDateLayout)] || base.IsHoliday(date)
+	}))
+
+	return SetDefaultHolidayCalendar(constant.HolidayCalendarCustom)
+}
+
+// currentHolidayCalendar returns the default holiday calendar.
+//
+//nolint:ireturn
+func currentHolidayCalendar() HolidayCalendar {
+	holidayCalendarsMu.RLock()
+	defer holidayCalendarsMu.RUnlock()
+
+	return holidayCalendars[defaultHolidayCalendar]
+}
+
+// isBusinessDay reports whether the date is a weekday that is not a holiday.
+func isBusinessDay(date time.Time, calendar HolidayCalendar) bool {
+	if weekday := date.Weekday(); weekday == time.Saturday || weekday == time.Sunday {
+		return false
+	}
+
+	return !calendar.IsHoliday(date)
+}
+
+// isBrazilianHoliday reports whether the date is a Brazilian national holiday or one of the
+// Carnival and Corpus Christi bank holidays observed by the financial market (ANBIMA calendar).
+func isBrazilianHoliday(date time.Time) bool {
+	year, month, day := date.Date()
+
+	switch {
+	case month == time.January && day == 1, // Confraternização Universal
+		month == time.April && day == 21,    // Tiradentes
+		month == time.May && day == 1,       // Dia do Trabalho
+		month == time.September && day == 7, // Independência
+		month == time.October && day == 12,  // Nossa Senhora Aparecida
+		month == time.November && day == 2,  // Finados
+		month == time.November && day == 15, // Proclamação da República
+		month == time.December && day == 25: // Natal
+		return true
+	case month == time.November && day == 20: // Consciência Negra, national since 2024
+		return year >= constant.BlackConsciousnessDayFirstYear
+	}
+
+	easter := easterSunday(year)
+	target := time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+
+	for _, offset := range []int{-48, -47, -2, 60} { // Carnival Monday and Tuesday, Good Friday, Corpus Christi
+		if easter.AddDate(0, 0, offset).Equal(target) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// easterSunday returns the date of Easter Sunday in the Gregorian calendar (anonymous Gregorian algorithm).
+func easterSunday(year int) time.Time {
+	a := year % 19
+	b := year / 100
+	c := year % 100
+	d := (19*a + b - b/4 - (b-(b+8)/25+1)/3 + 15) % 30
+	e := (32 + 2*(b%4) + 2*(c/4) - d - c%4) % 7
+	f := d + e - 7*((a+11*d+22*e)/451) + 114
+
+	return time.Date(year, time.Month(f/31), f%31+1, 0, 0, 0, 0, time.UTC)
+}
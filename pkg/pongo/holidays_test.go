@@ -0,0 +1,98 @@
+// Copyright (c) 2026 Lerian Studio. All rights reserved.
+// Use of this source code is governed by the Elastic License 2.0
+// that can be found in the LICENSE file.
+
+package pongo
+
+import (
+	"testing"
+	"time"
+
+	"github.com/LerianStudio/reporter/pkg/constant"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEasterSunday(t *testing.T) {
+	t.Parallel()
+	expected := map[int]string{
+		2024: "2024-03-31",
+		2025: "2025-04-20",
+		2026: "2026-04-05",
+		2038: "2038-04-25",
+	}
+
+	for year, date := range expected {
+		assert.Equal(t, date, easterSunday(year).Format("2006-01-02"))
+	}
+}
+
+func TestIsBrazilianHoliday(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		date     string
+		expected bool
+	}{
+		{"2025-01-01", true},
+		{"2025-03-03", true},  // Carnival Monday
+		{"2025-03-04", true},  // Carnival Tuesday
+		{"2025-03-05", false}, // Ash Wednesday
+		{"2025-04-18", true},  // Good Friday
+		{"2025-04-21", true},
+		{"2025-06-19", true}, // Corpus Christi
+		{"2025-09-07", true},
+		{"2025-11-20", true},
+		{"2023-11-20", false}, // before Consciência Negra became national
+		{"2025-12-24", false},
+		{"2025-12-25", true},
+	}
+
+	for _, tt := range tests {
+		date, err := time.Parse("2006-01-02", tt.date)
+		require.NoError(t, err)
+		assert.Equal(t, tt.expected, isBrazilianHoliday(date), tt.date)
+	}
+}
+
+func TestHolidayCalendarRegistry(t *testing.T) {
+	t.Parallel()
+
+	RegisterHolidayCalendar("test-company", HolidayCalendarFunc(func(date time.Time) bool {
+		return date.Month() == time.March && date.Day() == 10
+	}))
+
+	require.Error(t, SetDefaultHolidayCalendar("unknown"))
+
+	holidayCalendarsMu.RLock()
+	calendar := holidayCalendars["test-company"]
+	holidayCalendarsMu.RUnlock()
+
+	assert.False(t, isBusinessDay(time.Date(2025, 3, 10, 0, 0, 0, 0, time.UTC), calendar))
+	assert.True(t, isBusinessDay(time.Date(2025, 3, 11, 0, 0, 0, 0, time.UTC), calendar))
+	assert.False(t, isBusinessDay(time.Date(2025, 3, 8, 0, 0, 0, 0, time.UTC), calendar))
+}
+
+// TestConfigureHolidayCalendar is not parallel: it changes the default calendar and restores it.
+func TestConfigureHolidayCalendar(t *testing.T) {
+	t.Cleanup(func() {
+		require.NoError(t, SetDefaultHolidayCalendar(constant.HolidayCalendarBR))
+	})
+
+	christmasEve := time.Date(2026, 12, 24, 0, 0, 0, 0, time.UTC)
+	christmas := time.Date(2026, 12, 25, 0, 0, 0, 0, time.UTC)
+
+	require.NoError(t, ConfigureHolidayCalendar("", nil))
+	assert.True(t, currentHolidayCalendar().IsHoliday(christmas))
+	assert.False(t, currentHolidayCalendar().IsHoliday(christmasEve))
+
+	require.NoError(t, ConfigureHolidayCalendar(constant.HolidayCalendarNone, nil))
+	assert.False(t, currentHolidayCalendar().IsHoliday(christmas))
+
+	require.NoError(t, ConfigureHolidayCalendar(constant.HolidayCalendarBR, []string{"2026-12-24"}))
+	assert.True(t, currentHolidayCalendar().IsHoliday(christmas))
+	assert.True(t, currentHolidayCalendar().IsHoliday(christmasEve))
+
+	require.Error(t, ConfigureHolidayCalendar("unknown", nil))
+	require.Error(t, ConfigureHolidayCalendar("unknown", []string{"2026-12-24"}))
+	require.Error(t, ConfigureHolidayCalendar(constant.HolidayCalendarBR, []string{"24/12/2026"}))
+}
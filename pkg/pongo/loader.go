@@ -0,0 +1,63 @@
+// Copyright (c) 2026 Lerian Studio. All rights reserved.
+// Use of this source code is governed by the Elastic License 2.0
+// that can be found in the LICENSE file.
+
+package pongo
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/LerianStudio/reporter/pkg/templateutils"
+
+	"github.com/flosch/pongo2/v6"
+)
+
+// TemplateSource fetches stored template files by object name, e.g. the template storage repository.
+type TemplateSource interface {
+	Get(ctx context.Context, objectName string) ([]byte, error)
+}
+
+// StorageLoader is a pongo2.TemplateLoader that resolves the partials referenced by
+// {% include %}, {% extends %} and {% import %} from template storage.
+type StorageLoader struct {
+	ctx        context.Context
+	source     TemplateSource
+	objectName func(partialName string) string
+}
+
+// Compile-time interface satisfaction check.
+var _ pongo2.TemplateLoader = (*StorageLoader)(nil)
+
+// NewStorageLoader creates a StorageLoader reading partials from source. objectName maps a partial
+// name to its storage object name, scoping the lookup to the organization that owns the template.
+func NewStorageLoader(ctx context.Context, source TemplateSource, objectName func(partialName string) string) *StorageLoader {
+	return &StorageLoader{
+		ctx:        ctx,
+		source:     source,
+		objectName: objectName,
+	}
+}
+
+// Abs resolves a partial name relative to the partial that references it.
+func (l *StorageLoader) Abs(base, name string) string {
+	return templateutils.ResolvePartialName(base, name)
+}
+
+// Get downloads a partial and applies the same schema preprocessing as the main template.
+func (l *StorageLoader) Get(path string) (io.Reader, error) {
+	name := templateutils.ResolvePartialName("", path)
+
+	if err := templateutils.ValidatePartialName(name); err != nil {
+		return nil, fmt.Errorf("invalid partial name %q: %w", path, err)
+	}
+
+	data, err := l.source.Get(l.ctx, l.objectName(name))
+	if err != nil {
+		return nil, fmt.Errorf("failed to load partial %q: %w", name, err)
+	}
+
+	return bytes.NewBufferString(preprocessSchemaReferences(string(data))), nil
+}
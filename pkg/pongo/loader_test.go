@@ -0,0 +1,112 @@
+// Copyright (c) 2026 Lerian Studio. All rights reserved.
+// Use of this source code is governed by the Elastic License 2.0
+// that can be found in the LICENSE file.
+
+package pongo
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/LerianStudio/lib-commons/v2/commons/zap"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// mapTemplateSource serves partials from memory, keyed by object name.
+type mapTemplateSource map[string]string
+
+func (m mapTemplateSource) Get(_ context.Context, objectName string) ([]byte, error) {
+	content, ok := m[objectName]
+	if !ok {
+		return nil, errors.New("object not found")
+	}
+
+	return []byte(content), nil
+}
+
+func partialObjectName(name string) string {
+	return "org-a/partials/" + name + ".tpl"
+}
+
+func TestRenderFromBytes_StorageLoader(t *testing.T) {
+	t.Parallel()
+
+	logger := zap.InitializeLogger()
+
+	source := mapTemplateSource{
+		"org-a/partials/layouts/corporate.tpl": `<header>{% include "./header" %}</header><main>{% block content %}{% endblock %}</main>`,
+		"org-a/partials/layouts/header.tpl":    `ACME`,
+		"org-a/partials/rows/holder.tpl":       `[{{ holder.name }}]`,
+		"org-a/partials/rows/schema.tpl":       `{{ midaz_onboarding:public.account.0.name }}`,
+	}
+
+	data := map[string]map[string][]map[string]any{
+		"midaz_onboarding": {
+			"public__account": {{"name": "Checking"}},
+		},
+		"plugin_crm": {
+			"holders": {{"name": "Ann"}, {"name": "Bob"}},
+		},
+	}
+
+	tests := []struct {
+		name     string
+		template string
+		expected string
+	}{
+		{
+			name:     "extends a stored layout with a nested relative include",
+			template: `{% extends "layouts/corporate" %}{% block content %}body{% endblock %}`,
+			expected: `<header>ACME</header><main>body</main>`,
+		},
+		{
+			name:     "include inside a loop sees the loop variable",
+			template: `{% for holder in plugin_crm.holders %}{% include "rows/holder" %}{% endfor %}`,
+			expected: `[Ann][Bob]`,
+		},
+		{
+			name:     "partials get schema references preprocessed",
+			template: `{% include "rows/schema.tpl" %}`,
+			expected: `Checking`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			renderer := NewTemplateRendererWithLoader(NewStorageLoader(context.Background(), source, partialObjectName))
+
+			out, err := renderer.RenderFromBytes(context.Background(), []byte(tt.template), data, logger)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, out)
+		})
+	}
+}
+
+func TestRenderFromBytes_StorageLoaderErrors(t *testing.T) {
+	t.Parallel()
+
+	logger := zap.InitializeLogger()
+	renderer := NewTemplateRendererWithLoader(NewStorageLoader(context.Background(), mapTemplateSource{}, partialObjectName))
+
+	for _, template := range []string{
+		`{% include "layouts/missing" %}`,
+		`{% include "../../etc/passwd" %}`,
+	} {
+		_, err := renderer.RenderFromBytes(context.Background(), []byte(template), nil, logger)
+		require.Error(t, err, template)
+	}
+}
+
+func TestStorageLoader_Abs(t *testing.T) {
+	t.Parallel()
+
+	loader := NewStorageLoader(context.Background(), mapTemplateSource{}, partialObjectName)
+
+	assert.Equal(t, "layouts/header", loader.Abs("layouts/corporate", "./header"))
+	assert.Equal(t, "shared/footer", loader.Abs("layouts/corporate", "../shared/footer"))
+	assert.Equal(t, "shared/footer", loader.Abs("layouts/corporate", "shared/footer.tpl"))
+}
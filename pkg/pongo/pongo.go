@@ -55,6 +55,36 @@ func doRegisterAll() error {
 		return fmt.Errorf("failed to register count filter: %w", err)
 	}
 
+	filters := []struct {
+		name   string
+		filter pongo2.FilterFunction
+	}{
+		{"localize", localizeFilter},
+		{"format_number", formatNumberFilter},
+		{"format_currency", formatCurrencyFilter},
+		{"format_percent", formatPercentFilter},
+		{"format_date", formatDateFilter},
+		{"to_tz", toTzFilter},
+		{"add_days", addDaysFilter},
+		{"add_months", addMonthsFilter},
+		{"start_of_month", startOfMonthFilter},
+		{"end_of_month", endOfMonthFilter},
+		{"business_days_between", businessDaysBetweenFilter},
+		{"sort_by", sortByFilter},
+		{"group_by", groupByFilter},
+		{"unique", uniqueFilter},
+		{"pluck", pluckFilter},
+		{"first_n", firstNFilter},
+		{"last_n", lastNFilter},
+		{"csv_field", csvFieldFilter},
+	}
+
+	for _, f := range filters {
+		if err := pongo2.RegisterFilter(f.name, f.filter); err != nil {
+			return fmt.Errorf("failed to register %s filter: %w", f.name, err)
+		}
+	}
+
 	tags := []struct {
 		name string
 		op   string
@@ -89,6 +119,26 @@ func doRegisterAll() error {
 		return fmt.Errorf("failed to register last_item_by_group tag: %w", err)
 	}
 
+	if err := pongo2.RegisterTag("locale", makeLocaleTag); err != nil {
+		return fmt.Errorf("failed to register locale tag: %w", err)
+	}
+
+	if err := pongo2.RegisterTag("timezone", makeTimezoneTag); err != nil {
+		return fmt.Errorf("failed to register timezone tag: %w", err)
+	}
+
+	if err := pongo2.RegisterTag("fixed_width", makeFixedWidthTag); err != nil {
+		return fmt.Errorf("failed to register fixed_width tag: %w", err)
+	}
+
+	if err := pongo2.RegisterTag("record", makeRecordTag); err != nil {
+		return fmt.Errorf("failed to register record tag: %w", err)
+	}
+
+	if err := pongo2.RegisterTag("csv", makeCSVTag); err != nil {
+		return fmt.Errorf("failed to register csv tag: %w", err)
+	}
+
 	// Register counter tags for counting blocks during rendering
 	if err := pongo2.RegisterTag("counter", makeCounterTag()); err != nil {
 		return fmt.Errorf("failed to register counter tag: %w", err)
@@ -7,7 +7,9 @@ package pongo
 import (
 	"context"
 	"fmt"
+	"io"
 	"regexp"
+	"sort"
 	"strings"
 
 	"github.com/LerianStudio/lib-commons/v2/commons/log"
@@ -17,11 +19,86 @@ import (
 )
 
 // TemplateRenderer handles rendering templates using pongo2
-type TemplateRenderer struct{}
+type TemplateRenderer struct {
+	loader           pongo2.TemplateLoader
+	locale           string
+	timezone         string
+	fixedWidthLayout *FixedWidthLayout
+	rawOutput        bool
+}
 
 // NewTemplateRenderer creates a new TemplateRenderer
 func NewTemplateRenderer() *TemplateRenderer {
-	return &TemplateRenderer{}
+	return &TemplateRenderer{loader: pongo2.DefaultLoader}
+}
+
+// NewTemplateRendererWithLoader creates a TemplateRenderer that resolves {% include %},
+// {% extends %} and {% import %} through the given loader, e.g. a StorageLoader.
+func NewTemplateRendererWithLoader(loader pongo2.TemplateLoader) *TemplateRenderer {
+	return &TemplateRenderer{loader: loader}
+}
+
+// WithLocale returns a copy of the renderer whose format_* filters use the given locale (e.g. "pt-BR"),
+// overriding the {% locale %} declared by the template. An empty locale keeps the template's.
+func (r *TemplateRenderer) WithLocale(locale string) *TemplateRenderer {
+	renderer := *r
+	renderer.locale = locale
+
+	return &renderer
+}
+
+// WithTimezone returns a copy of the renderer whose date_time tags and to_tz filters use the given
+// IANA timezone (e.g. "America/Sao_Paulo"), overriding the {% timezone %} declared by the template.
+// An empty timezone keeps the template's.
+func (r *TemplateRenderer) WithTimezone(timezone string) *TemplateRenderer {
+	renderer := *r
+	renderer.timezone = timezone
+
+	return &renderer
+}
+
+// WithFixedWidthLayout returns a copy of the renderer that writes the records of the given layout,
+// instead of loading the layout declared by the template's {% fixed_width %} tag.
+func (r *TemplateRenderer) WithFixedWidthLayout(layout *FixedWidthLayout) *TemplateRenderer {
+	renderer := *r
+	renderer.fixedWidthLayout = layout
+
+	return &renderer
+}
+
+// WithRawOutput returns a copy of the renderer that returns the output exactly as the template wrote it,
+// without removing trailing zeros from numbers, for values that are not report text, e.g. passwords.
+func (r *TemplateRenderer) WithRawOutput() *TemplateRenderer {
+	renderer := *r
+	renderer.rawOutput = true
+
+	return &renderer
+}
+
+// LoadFixedWidthLayout loads the layout declared by the {% fixed_width %} tag of the template through
+// the renderer's loader. It returns nil when the template declares no layout.
+func (r *TemplateRenderer) LoadFixedWidthLayout(templateBytes []byte) (*FixedWidthLayout, error) {
+	match := fixedWidthTagPattern.FindSubmatch(templateBytes)
+	if match == nil {
+		return nil, nil
+	}
+
+	loader := r.loader
+	if loader == nil {
+		loader = pongo2.DefaultLoader
+	}
+
+	reader, err := loader.Get(loader.Abs("", string(match[1])))
+	if err != nil {
+		return nil, fmt.Errorf("failed to load fixed-width layout: %w", err)
+	}
+
+	data, err := io.ReadAll(reader)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read fixed-width layout: %w", err)
+	}
+
+	return ParseFixedWidthLayout(data)
 }
 
 // RenderFromBytes renders a template from bytes using the provided data context
@@ -29,13 +106,41 @@ func (r *TemplateRenderer) RenderFromBytes(ctx context.Context, templateBytes []
 	// Pre-process template to convert schema syntax (database:schema.table) to Pongo2 compatible syntax
 	processedTemplate := preprocessSchemaReferences(string(templateBytes))
 
+	// Bind the format_* filters to the report or template locale and mark their output
+	locale := resolveLocale(processedTemplate, r.locale)
+	processedTemplate = markLocalizedOutput(applyLocale(processedTemplate))
+
+	// Give the date_time tags and to_tz filters the report or template timezone
+	timezone, err := resolveTimezone(processedTemplate, r.timezone)
+	if err != nil {
+		logger.Errorf("Error resolving template timezone: %s", err.Error())
+		return "", err
+	}
+
+	processedTemplate = applyTimezone(processedTemplate, timezone)
+
+	// Load the layout of the record tags of a fixed-width template
+	layout := r.fixedWidthLayout
+	if layout == nil {
+		layout, err = r.LoadFixedWidthLayout([]byte(processedTemplate))
+		if err != nil {
+			logger.Errorf("Error loading fixed-width layout: %s", err.Error())
+			return "", err
+		}
+	}
+
 	// Create a per-call TemplateSet to avoid a race condition on pongo2's
 	// shared DefaultSet.  TemplateSet.FromString() writes to the unsynchronized
 	// field firstTemplateCreated, so concurrent renders through the global
 	// DefaultSet trigger the Go race detector.  A fresh set per render is cheap
 	// (no caching benefit is lost because each template string is unique) and
 	// eliminates the shared mutable state entirely.
-	ts := pongo2.NewSet("render", pongo2.DefaultLoader)
+	loader := r.loader
+	if loader == nil {
+		loader = pongo2.DefaultLoader
+	}
+
+	ts := pongo2.NewSet("render", localeLoader{TemplateLoader: loader})
 
 	tpl, err := ts.FromString(processedTemplate)
 	if err != nil {
@@ -69,6 +174,20 @@ func (r *TemplateRenderer) RenderFromBytes(ctx context.Context, templateBytes []
 			return strings.Contains(s1, s2)
 		},
 	}
+	pongoCtx[LocaleContextKey] = locale
+
+	if timezone != nil {
+		pongoCtx[TimezoneContextKey] = timezone
+	}
+
+	if layout != nil {
+		pongoCtx[FixedWidthContextKey] = newFixedWidthState(layout)
+	}
+
+	// The {% csv %} tag sets the dialect the csv_field values are escaped with
+	csvDialect := DefaultCSVDialect()
+	pongoCtx[CSVContextKey] = csvDialect
+
 	for k, v := range data {
 		pongoCtx[k] = v
 	}
@@ -79,9 +198,18 @@ func (r *TemplateRenderer) RenderFromBytes(ctx context.Context, templateBytes []
 		return "", err
 	}
 
+	// Fixed-width files are made of the records only, written exactly as the layout defines them
+	if layout != nil {
+		return fixedWidthRecords(out), nil
+	}
+
+	if r.rawOutput {
+		return stripLocalizedOutput(stripCSVFields(out)), nil
+	}
+
 	cleaned := cleanNumericOutput(out)
 
-	return cleaned, nil
+	return writeCSVFields(cleaned, csvDialect), nil
 }
 
 // preprocessSchemaReferences converts explicit schema syntax (database:schema.table) to Pongo2 dot notation.
@@ -98,8 +226,47 @@ func preprocessSchemaReferences(template string) string {
 	return schemaPattern.ReplaceAllString(template, `${1}.${2}__${3}`)
 }
 
-// cleanNumericOutput removes trailing zeros from numeric values in the output
+// numericCleanupPattern matches the numeric values whose trailing zeros cleanNumericText removes.
+var numericCleanupPattern = regexp.MustCompile(`\b\d+\.\d*0+\b`)
+
+// cleanNumericOutput removes trailing zeros from numeric values in the output. The output of the format_*
+// filters and the csv_field filter is left as written, since their separators would be taken for decimal
+// points, and the markers of the format_* output are removed.
 func cleanNumericOutput(output string) string {
+	spans := append(csvFieldPattern.FindAllStringIndex(output, -1), localizedOutputPattern.FindAllStringIndex(output, -1)...)
+	if len(spans) == 0 {
+		return cleanNumericText(output)
+	}
+
+	sort.Slice(spans, func(i, j int) bool { return spans[i][0] < spans[j][0] })
+
+	var b strings.Builder
+
+	last := 0
+
+	for _, span := range spans {
+		if span[0] < last {
+			if span[1] > last {
+				b.WriteString(output[last:span[1]])
+				last = span[1]
+			}
+
+			continue
+		}
+
+		b.WriteString(cleanNumericText(output[last:span[0]]))
+		b.WriteString(output[span[0]:span[1]])
+
+		last = span[1]
+	}
+
+	b.WriteString(cleanNumericText(output[last:]))
+
+	return stripLocalizedOutput(b.String())
+}
+
+// cleanNumericText removes trailing zeros from numeric values in a piece of output
+func cleanNumericText(output string) string {
 	// First, protect XML declarations from being modified
 	xmlDeclarationRegex := regexp.MustCompile(`<\?xml[^>]*version="[^"]*"[^>]*\?>`)
 	xmlDeclarations := xmlDeclarationRegex.FindAllString(output, -1)
@@ -111,8 +278,7 @@ func cleanNumericOutput(output string) string {
 		protectedOutput = strings.Replace(protectedOutput, declaration, placeholder, 1)
 	}
 
-	re := regexp.MustCompile(`\b\d+\.\d*0+\b`)
-	cleaned := re.ReplaceAllStringFunc(protectedOutput, func(match string) string {
+	cleaned := numericCleanupPattern.ReplaceAllStringFunc(protectedOutput, func(match string) string {
 		return cleanNumericString(match)
 	})
 
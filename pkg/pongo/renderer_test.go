@@ -8,6 +8,7 @@ import (
 	"context"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/LerianStudio/lib-commons/v2/commons/zap"
 	"github.com/stretchr/testify/assert"
@@ -74,6 +75,28 @@ Calculation: {% calc (100 + 200) * 1.2 %}`)
 	assert.Contains(t, out, "Calculation: 360")
 }
 
+func TestRender_WithRawOutput(t *testing.T) {
+	t.Parallel()
+	logger := zap.InitializeLogger()
+	tpl := []byte(`{{ account.holder.0.branch }}`)
+
+	data := map[string]map[string][]map[string]any{
+		"account": {"holder": {{"branch": "1234.50"}}},
+	}
+
+	out, err := NewTemplateRenderer().RenderFromBytes(context.Background(), tpl, data, logger)
+	require.NoError(t, err)
+	assert.Equal(t, "1234.5", out)
+
+	out, err = NewTemplateRenderer().WithRawOutput().RenderFromBytes(context.Background(), tpl, data, logger)
+	require.NoError(t, err)
+	assert.Equal(t, "1234.50", out)
+
+	out, err = NewTemplateRenderer().WithRawOutput().RenderFromBytes(context.Background(), []byte(`{{ "a,b"|csv_field }}`), data, logger)
+	require.NoError(t, err)
+	assert.Equal(t, "a,b", out)
+}
+
 func TestRender_ArithmeticExpressionWithVariables(t *testing.T) {
 	t.Parallel()
 	r := NewTemplateRenderer()
@@ -582,6 +605,103 @@ func TestCleanNumericOutput_MultipleXMLDeclarations(t *testing.T) {
 	assert.NotContains(t, result, "200.300")
 }
 
+func TestCleanNumericOutput_KeepsLocalizedValues(t *testing.T) {
+	t.Parallel()
+
+	input := "<td>10.500</td><td>" + localizedOutputStart + "1.230" + localizedOutputEnd + "</td>" +
+		"<td>" + localizedOutputStart + "1,230.50" + localizedOutputEnd + "</td><td>1.230</td>"
+	result := cleanNumericOutput(input)
+	assert.Equal(t, "<td>10.5</td><td>1.230</td><td>1,230.50</td><td>1.23</td>", result)
+}
+
+func TestRender_WithLocale(t *testing.T) {
+	t.Parallel()
+	logger := zap.InitializeLogger()
+	tpl := []byte(`{% locale "en-US" %}{% for balance in midaz_transaction.balance %}{{ balance.available|format_currency }};{% endfor %}`)
+
+	data := map[string]map[string][]map[string]any{
+		"midaz_transaction": {
+			"balance": {
+				{"available": "1230.50"},
+				{"available": "1234567.8"},
+			},
+		},
+	}
+
+	out, err := NewTemplateRenderer().RenderFromBytes(context.Background(), tpl, data, logger)
+	require.NoError(t, err)
+	assert.Equal(t, "$1,230.50;$1,234,567.80;", out)
+
+	out, err = NewTemplateRenderer().WithLocale("pt-BR").RenderFromBytes(context.Background(), tpl, data, logger)
+	require.NoError(t, err)
+	assert.Equal(t, "R$\u00a01.230,50;R$\u00a01.234.567,80;", out)
+}
+
+func TestRender_LocalizedValuesAreText(t *testing.T) {
+	t.Parallel()
+	logger := zap.InitializeLogger()
+	tpl := []byte(`{% locale "pt-BR" %}{% for balance in midaz_transaction.balance %}` +
+		`[{{ balance.available|format_number|ljust:"8" }}]` +
+		`{% if balance.available|format_number == "1.230" %}same{% endif %};{{ balance.limit }};` +
+		`{% endfor %}`)
+
+	data := map[string]map[string][]map[string]any{
+		"midaz_transaction": {
+			"balance": {
+				{"available": "1230", "limit": 10.5},
+			},
+		},
+	}
+
+	out, err := NewTemplateRenderer().RenderFromBytes(context.Background(), tpl, data, logger)
+	require.NoError(t, err)
+	assert.Equal(t, "[1.230   ]same;10.5;", out)
+}
+
+func TestRender_LocalizedValuesKeptByPosition(t *testing.T) {
+	t.Parallel()
+	logger := zap.InitializeLogger()
+	tpl := []byte(`{% locale "pt-BR" %}[ {{- db.v.0.amount|format_number -}} ] {{ db.v.0.limit }}`)
+
+	data := map[string]map[string][]map[string]any{
+		"db": {"v": {{"amount": "1230", "limit": "1.230"}}},
+	}
+
+	// Only the formatted value is kept as written: the plain number showing the same text is cleaned up
+	out, err := NewTemplateRenderer().RenderFromBytes(context.Background(), tpl, data, logger)
+	require.NoError(t, err)
+	assert.Equal(t, "[1.230] 1.23", out)
+
+	out, err = NewTemplateRenderer().WithRawOutput().RenderFromBytes(context.Background(), tpl, data, logger)
+	require.NoError(t, err)
+	assert.Equal(t, "[1.230] 1.230", out)
+}
+
+func TestRender_WithTimezone(t *testing.T) {
+	t.Parallel()
+	logger := zap.InitializeLogger()
+	tpl := []byte(`{% timezone "America/Sao_Paulo" %}{% for op in midaz_transaction.operation %}{{ op.created_at|to_tz|date:"02/01/2006 15:04" }};{% endfor %}`)
+
+	data := map[string]map[string][]map[string]any{
+		"midaz_transaction": {
+			"operation": {
+				{"created_at": time.Date(2025, 3, 1, 2, 30, 0, 0, time.UTC)},
+			},
+		},
+	}
+
+	out, err := NewTemplateRenderer().RenderFromBytes(context.Background(), tpl, data, logger)
+	require.NoError(t, err)
+	assert.Equal(t, "28/02/2025 23:30;", out)
+
+	out, err = NewTemplateRenderer().WithTimezone("Asia/Tokyo").RenderFromBytes(context.Background(), tpl, data, logger)
+	require.NoError(t, err)
+	assert.Equal(t, "01/03/2025 11:30;", out)
+
+	_, err = NewTemplateRenderer().WithTimezone("Mars/Olympus").RenderFromBytes(context.Background(), tpl, data, logger)
+	require.Error(t, err)
+}
+
 func TestCleanNumericString_DecimalParseFallback(t *testing.T) {
 	t.Parallel()
 
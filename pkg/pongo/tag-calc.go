@@ -0,0 +1,571 @@
+// Copyright (c) 2026 Lerian Studio. All rights reserved.
+// Use of this source code is governed by the Elastic License 2.0
+// that can be found in the LICENSE file.
+
+package pongo
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/LerianStudio/reporter/pkg/constant"
+
+	"github.com/flosch/pongo2/v6"
+	"github.com/shopspring/decimal"
+)
+
+// errDivisionByZero is returned when a calc expression divides by zero.
+var errDivisionByZero = errors.New("division by zero")
+
+// calcTagNode represents a calc tag that evaluates an arithmetic expression with exact decimal arithmetic.
+// Syntax: {% calc <expression> [scale <n>] [rounding half_even|half_up|truncate] [as <result_var>] %}
+type calcTagNode struct {
+	expression    calcExpr // Parsed arithmetic expression
+	scale         int32    // Number of decimal places kept in the result
+	rounding      string   // Rounding mode applied to the result and by round()
+	resultVarName string   // Optional variable name to store the result instead of writing it
+}
+
+// calcExpr is a node of a parsed calc expression.
+type calcExpr interface {
+	evaluate(ctx *pongo2.ExecutionContext, node *calcTagNode) (decimal.Decimal, error)
+}
+
+// calcNumber is a numeric literal.
+type calcNumber struct {
+	value decimal.Decimal
+}
+
+// calcVariable is a variable path such as balance.available or midaz_transaction.balance.0.amount.
+type calcVariable struct {
+	path []string
+}
+
+// calcUnary is a negated or unary-plus operand.
+type calcUnary struct {
+	op      string
+	operand calcExpr
+}
+
+// calcBinary is a binary arithmetic operation.
+type calcBinary struct {
+	op          string
+	left, right calcExpr
+}
+
+// calcCall is a call to one of the calc functions: abs, round, min and max.
+type calcCall struct {
+	name string
+	args []calcExpr
+}
+
+// calcFunctionArity holds the minimum and maximum number of arguments of each calc function.
+// A maximum of -1 means the function is variadic.
+var calcFunctionArity = map[string][2]int{
+	"abs":   {1, 1},
+	"round": {1, 2},
+	"min":   {1, -1},
+	"max":   {1, -1},
+}
+
+// makeCalcTag parses the calc tag.
+func makeCalcTag(_ *pongo2.Parser, start *pongo2.Token, arguments *pongo2.Parser) (pongo2.INodeTag, *pongo2.Error) {
+	p := &calcParser{tokens: calcTokens(arguments), start: start, args: arguments}
+
+	expression, err := p.parseExpression()
+	if err != nil {
+		return nil, err
+	}
+
+	node := &calcTagNode{
+		expression: expression,
+		scale:      constant.CalcDefaultScale,
+		rounding:   constant.RoundingHalfUp,
+	}
+
+	if err := p.parseOptions(node); err != nil {
+		return nil, err
+	}
+
+	return node, nil
+}
+
+// Execute evaluates the expression, rounds the result and writes it or stores it in the result variable.
+func (node *calcTagNode) Execute(ctx *pongo2.ExecutionContext, writer pongo2.TemplateWriter) *pongo2.Error {
+	result, err := node.expression.evaluate(ctx, node)
+	if err != nil {
+		return &pongo2.Error{
+			Sender:    "calc",
+			OrigError: err,
+		}
+	}
+
+	out := roundDecimal(result, node.scale, node.rounding).String()
+
+	if node.resultVarName != "" {
+		ctx.Private[node.resultVarName] = out
+		return nil
+	}
+
+	if _, err := writer.WriteString(out); err != nil {
+		return ctx.Error("Error writing output", nil)
+	}
+
+	return nil
+}
+
+// roundDecimal rounds value to scale decimal places using the given rounding mode.
+// half_up rounds halves away from zero, half_even rounds halves to the nearest even digit
+// and truncate discards the extra digits.
+func roundDecimal(value decimal.Decimal, scale int32, mode string) decimal.Decimal {
+	switch mode {
+	case constant.RoundingHalfEven:
+		return value.RoundBank(scale)
+	case constant.RoundingTruncate:
+		return value.Truncate(scale)
+	default:
+		return value.Round(scale)
+	}
+}
+
+func (n calcNumber) evaluate(_ *pongo2.ExecutionContext, _ *calcTagNode) (decimal.Decimal, error) {
+	return n.value, nil
+}
+
+// evaluate resolves the variable from the private context first, then from the public one.
+// Missing, empty and non-numeric values evaluate to zero.
+func (v calcVariable) evaluate(ctx *pongo2.ExecutionContext, _ *calcTagNode) (decimal.Decimal, error) {
+	value, ok := lookupCalcVariable(ctx.Private, v.path)
+	if !ok {
+		value, ok = lookupCalcVariable(ctx.Public, v.path)
+	}
+
+	if !ok {
+		return decimal.Zero, nil
+	}
+
+	if dec, ok := toDecimal(value); ok {
+		return dec, nil
+	}
+
+	return decimal.Zero, nil
+}
+
+func (u calcUnary) evaluate(ctx *pongo2.ExecutionContext, node *calcTagNode) (decimal.Decimal, error) {
+	value, err := u.operand.evaluate(ctx, node)
+	if err != nil {
+		return decimal.Zero, err
+	}
+
+	if u.op == "-" {
+		return value.Neg(), nil
+	}
+
+	return value, nil
+}
+
+func (b calcBinary) evaluate(ctx *pongo2.ExecutionContext, node *calcTagNode) (decimal.Decimal, error) {
+	left, err := b.left.evaluate(ctx, node)
+	if err != nil {
+		return decimal.Zero, err
+	}
+
+	right, err := b.right.evaluate(ctx, node)
+	if err != nil {
+		return decimal.Zero, err
+	}
+
+	switch b.op {
+	case "+":
+		return left.Add(right), nil
+	case "-":
+		return left.Sub(right), nil
+	case "*":
+		return left.Mul(right), nil
+	case "/":
+		if right.IsZero() {
+			return decimal.Zero, errDivisionByZero
+		}
+
+		return left.DivRound(right, constant.CalcDivisionPrecision), nil
+	default:
+		if left.IsZero() && right.IsNegative() {
+			return decimal.Zero, errDivisionByZero
+		}
+
+		return left.PowWithPrecision(right, constant.CalcDivisionPrecision)
+	}
+}
+
+func (c calcCall) evaluate(ctx *pongo2.ExecutionContext, node *calcTagNode) (decimal.Decimal, error) {
+	args := make([]decimal.Decimal, 0, len(c.args))
+
+	for _, arg := range c.args {
+		value, err := arg.evaluate(ctx, node)
+		if err != nil {
+			return decimal.Zero, err
+		}
+
+		args = append(args, value)
+	}
+
+	switch c.name {
+	case "abs":
+		return args[0].Abs(), nil
+	case "round":
+		places := int32(0)
+		if len(args) > 1 {
+			places = int32(args[1].IntPart())
+		}
+
+		return roundDecimal(args[0], places, node.rounding), nil
+	case "min":
+		return decimal.Min(args[0], args[1:]...), nil
+	default:
+		return decimal.Max(args[0], args[1:]...), nil
+	}
+}
+
+// lookupCalcVariable walks a dotted path through maps, slices and structs starting at the root variable.
+// Loop variables are stored by pongo2 as *pongo2.Value and are unwrapped before walking.
+func lookupCalcVariable(context pongo2.Context, path []string) (any, bool) {
+	root, ok := context[path[0]]
+	if !ok {
+		return nil, false
+	}
+
+	if wrapped, isValue := root.(*pongo2.Value); isValue {
+		root = wrapped.Interface()
+	}
+
+	current := reflect.ValueOf(root)
+
+	for _, part := range path[1:] {
+		for current.IsValid() && (current.Kind() == reflect.Interface || current.Kind() == reflect.Pointer) {
+			current = current.Elem()
+		}
+
+		switch current.Kind() {
+		case reflect.Map:
+			if current.Type().Key().Kind() != reflect.String {
+				return nil, false
+			}
+
+			current = current.MapIndex(reflect.ValueOf(part).Convert(current.Type().Key()))
+		case reflect.Slice, reflect.Array:
+			index, err := strconv.Atoi(part)
+			if err != nil || index < 0 || index >= current.Len() {
+				return nil, false
+			}
+
+			current = current.Index(index)
+		case reflect.Struct:
+			current = current.FieldByName(part)
+		default:
+			return nil, false
+		}
+
+		if !current.IsValid() {
+			return nil, false
+		}
+	}
+
+	if !current.CanInterface() {
+		return nil, false
+	}
+
+	return current.Interface(), true
+}
+
+// calcToken is a token of a calc expression.
+type calcToken struct {
+	typ pongo2.TokenType
+	val string
+	src *pongo2.Token
+}
+
+// calcTokens consumes the tag arguments and merges the "*" "*" pair into the "**" power operator,
+// since the pongo2 lexer has no such symbol.
+func calcTokens(arguments *pongo2.Parser) []calcToken {
+	var tokens []calcToken
+
+	for arguments.Remaining() > 0 {
+		tok := arguments.Current()
+		arguments.Consume()
+
+		if tok.Typ == pongo2.TokenSymbol && tok.Val == "*" && len(tokens) > 0 {
+			last := &tokens[len(tokens)-1]
+			if last.typ == pongo2.TokenSymbol && last.val == "*" {
+				last.val = "**"
+				continue
+			}
+		}
+
+		tokens = append(tokens, calcToken{typ: tok.Typ, val: tok.Val, src: tok})
+	}
+
+	return tokens
+}
+
+// calcParser is a recursive descent parser for calc expressions. Operators by increasing precedence:
+// "+" and "-", "*" and "/", "**" and "^" (right associative), then unary "-" and "+".
+type calcParser struct {
+	tokens []calcToken
+	pos    int
+	start  *pongo2.Token
+	args   *pongo2.Parser
+}
+
+func (p *calcParser) peek(typ pongo2.TokenType, values ...string) bool {
+	if p.pos >= len(p.tokens) || p.tokens[p.pos].typ != typ {
+		return false
+	}
+
+	if len(values) == 0 {
+		return true
+	}
+
+	for _, v := range values {
+		if p.tokens[p.pos].val == v {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (p *calcParser) next() calcToken {
+	tok := p.tokens[p.pos]
+	p.pos++
+
+	return tok
+}
+
+// fail returns a parse error located at the current token.
+func (p *calcParser) fail(msg string) *pongo2.Error {
+	tok := p.start
+	if p.pos < len(p.tokens) {
+		tok = p.tokens[p.pos].src
+	}
+
+	return p.args.Error(msg, tok)
+}
+
+func (p *calcParser) parseExpression() (calcExpr, *pongo2.Error) {
+	left, err := p.parseTerm()
+	if err != nil {
+		return nil, err
+	}
+
+	for p.peek(pongo2.TokenSymbol, "+", "-") {
+		op := p.next().val
+
+		right, err := p.parseTerm()
+		if err != nil {
+			return nil, err
+		}
+
+		left = calcBinary{op: op, left: left, right: right}
+	}
+
+	return left, nil
+}
+
+func (p *calcParser) parseTerm() (calcExpr, *pongo2.Error) {
+	left, err := p.parsePower()
+	if err != nil {
+		return nil, err
+	}
+
+	for p.peek(pongo2.TokenSymbol, "*", "/") {
+		op := p.next().val
+
+		right, err := p.parsePower()
+		if err != nil {
+			return nil, err
+		}
+
+		left = calcBinary{op: op, left: left, right: right}
+	}
+
+	return left, nil
+}
+
+func (p *calcParser) parsePower() (calcExpr, *pongo2.Error) {
+	base, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+
+	if !p.peek(pongo2.TokenSymbol, "**", "^") {
+		return base, nil
+	}
+
+	p.next()
+
+	exponent, err := p.parsePower()
+	if err != nil {
+		return nil, err
+	}
+
+	return calcBinary{op: "**", left: base, right: exponent}, nil
+}
+
+func (p *calcParser) parseUnary() (calcExpr, *pongo2.Error) {
+	if p.peek(pongo2.TokenSymbol, "-", "+") {
+		op := p.next().val
+
+		operand, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+
+		return calcUnary{op: op, operand: operand}, nil
+	}
+
+	return p.parsePrimary()
+}
+
+func (p *calcParser) parsePrimary() (calcExpr, *pongo2.Error) {
+	switch {
+	case p.peek(pongo2.TokenNumber):
+		return p.parseNumber()
+	case p.peek(pongo2.TokenSymbol, "("):
+		p.next()
+
+		inner, err := p.parseExpression()
+		if err != nil {
+			return nil, err
+		}
+
+		if !p.peek(pongo2.TokenSymbol, ")") {
+			return nil, p.fail("unmatched parentheses in calc expression")
+		}
+
+		p.next()
+
+		return inner, nil
+	case p.peek(pongo2.TokenIdentifier):
+		name := p.next().val
+
+		if _, ok := calcFunctionArity[name]; ok && p.peek(pongo2.TokenSymbol, "(") {
+			return p.parseCall(name)
+		}
+
+		return p.parseVariable(name), nil
+	}
+
+	return nil, p.fail("expected a number, variable or function in calc expression")
+}
+
+// parseNumber parses a numeric literal. The pongo2 lexer splits "1.5" into "1", "." and "5".
+func (p *calcParser) parseNumber() (calcExpr, *pongo2.Error) {
+	literal := p.next().val
+
+	if p.peek(pongo2.TokenSymbol, ".") && p.pos+1 < len(p.tokens) && p.tokens[p.pos+1].typ == pongo2.TokenNumber {
+		p.pos++
+		literal += "." + p.next().val
+	}
+
+	value, err := decimal.NewFromString(literal)
+	if err != nil {
+		return nil, p.fail(fmt.Sprintf("invalid number %q in calc expression", literal))
+	}
+
+	return calcNumber{value: value}, nil
+}
+
+// parseVariable parses the attributes and indexes following the root of a variable.
+func (p *calcParser) parseVariable(root string) calcExpr {
+	path := []string{root}
+
+	for p.peek(pongo2.TokenSymbol, ".") && p.pos+1 < len(p.tokens) &&
+		(p.tokens[p.pos+1].typ == pongo2.TokenIdentifier || p.tokens[p.pos+1].typ == pongo2.TokenNumber) {
+		p.pos++
+		path = append(path, p.next().val)
+	}
+
+	return calcVariable{path: path}
+}
+
+// parseCall parses the arguments of a calc function and checks their number.
+func (p *calcParser) parseCall(name string) (calcExpr, *pongo2.Error) {
+	p.next()
+
+	call := calcCall{name: name}
+
+	for !p.peek(pongo2.TokenSymbol, ")") {
+		if len(call.args) > 0 {
+			if !p.peek(pongo2.TokenSymbol, ",") {
+				return nil, p.fail(fmt.Sprintf("expected ',' or ')' in %s()", name))
+			}
+
+			p.next()
+		}
+
+		arg, err := p.parseExpression()
+		if err != nil {
+			return nil, err
+		}
+
+		call.args = append(call.args, arg)
+	}
+
+	p.next()
+
+	arity := calcFunctionArity[name]
+	if len(call.args) < arity[0] || (arity[1] >= 0 && len(call.args) > arity[1]) {
+		return nil, p.fail(fmt.Sprintf("wrong number of arguments for %s()", name))
+	}
+
+	return call, nil
+}
+
+// parseOptions parses the scale, rounding and as clauses following the expression.
+func (p *calcParser) parseOptions(node *calcTagNode) *pongo2.Error {
+	for p.pos < len(p.tokens) {
+		switch {
+		case p.peek(pongo2.TokenIdentifier, "scale"):
+			p.next()
+
+			if !p.peek(pongo2.TokenNumber) {
+				return p.fail("expected a number after 'scale'")
+			}
+
+			scale, err := strconv.Atoi(p.next().val)
+			if err != nil || scale > constant.CalcMaxScale {
+				return p.fail(fmt.Sprintf("scale must be between 0 and %d", constant.CalcMaxScale))
+			}
+
+			node.scale = int32(scale)
+		case p.peek(pongo2.TokenIdentifier, "rounding"):
+			p.next()
+
+			if !p.peek(pongo2.TokenIdentifier) && !p.peek(pongo2.TokenString) {
+				return p.fail("expected a rounding mode after 'rounding'")
+			}
+
+			mode := strings.ReplaceAll(strings.ToLower(p.next().val), "-", "_")
+			if mode != constant.RoundingHalfUp && mode != constant.RoundingHalfEven && mode != constant.RoundingTruncate {
+				return p.fail(fmt.Sprintf("unknown rounding mode %q, expected half_even, half_up or truncate", mode))
+			}
+
+			node.rounding = mode
+		case p.peek(pongo2.TokenKeyword, "as"):
+			p.next()
+
+			if !p.peek(pongo2.TokenIdentifier) {
+				return p.fail("expected variable name after 'as'")
+			}
+
+			node.resultVarName = p.next().val
+		default:
+			return p.fail("unexpected token in calc expression")
+		}
+	}
+
+	return nil
+}
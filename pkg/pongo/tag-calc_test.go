@@ -0,0 +1,172 @@
+// Copyright (c) 2026 Lerian Studio. All rights reserved.
+// Use of this source code is governed by the Elastic License 2.0
+// that can be found in the LICENSE file.
+
+package pongo
+
+import (
+	"testing"
+
+	"github.com/flosch/pongo2/v6"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCalcTag_ExactDecimalArithmetic(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name     string
+		template string
+		context  pongo2.Context
+		expected string
+	}{
+		{"binary fractions", `{% calc 0.1 + 0.2 %}`, nil, "0.3"},
+		{"repeated subtraction", `{% calc 1 - 0.9 - 0.1 %}`, nil, "0"},
+		{"large amounts", `{% calc 9007199254740993 + 1 %}`, nil, "9007199254740994"},
+		{"division keeps default scale", `{% calc 1 / 3 %}`, nil, "0.3333333333"},
+		{"right associative power", `{% calc 2 ** 3 ** 2 %}`, nil, "512"},
+		{"caret power", `{% calc 2 ^ 10 %}`, nil, "1024"},
+		{"precedence", `{% calc 2 + 3 * 4 - 6 / 2 %}`, nil, "11"},
+		{"decimal string variable", `{% calc amount * 3 %}`, pongo2.Context{"amount": "0.1"}, "0.3"},
+		{"decimal variable", `{% calc amount + 0.01 %}`, pongo2.Context{"amount": decimal.RequireFromString("100.005")}, "100.015"},
+		{"int32 variable", `{% calc amount / 4 %}`, pongo2.Context{"amount": int32(10)}, "2.5"},
+		{"nested variable", `{% calc data.items.1.value * 2 %}`, pongo2.Context{
+			"data": map[string]any{"items": []map[string]any{{"value": 1}, {"value": 2.25}}},
+		}, "4.5"},
+		{"missing variable is zero", `{% calc missing + 1 %}`, nil, "1"},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			tpl, err := SafeFromString(tt.template)
+			require.NoError(t, err)
+
+			out, err := tpl.Execute(tt.context)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, out)
+		})
+	}
+}
+
+func TestCalcTag_ScaleAndRounding(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name     string
+		template string
+		expected string
+	}{
+		{"default rounding is half up", `{% calc 2.345 scale 2 %}`, "2.35"},
+		{"half up negative", `{% calc -2.345 scale 2 %}`, "-2.35"},
+		{"half even rounds to even", `{% calc 2.345 scale 2 rounding half_even %}`, "2.34"},
+		{"half even rounds up when odd", `{% calc 2.355 scale 2 rounding half_even %}`, "2.36"},
+		{"truncate", `{% calc 2.349 scale 2 rounding truncate %}`, "2.34"},
+		{"truncate negative", `{% calc -2.349 scale 2 rounding truncate %}`, "-2.34"},
+		{"rounding as string", `{% calc 2.345 scale 2 rounding "half-even" %}`, "2.34"},
+		{"scale zero", `{% calc 10 / 4 scale 0 %}`, "3"},
+		{"trailing zeros are trimmed", `{% calc 1.5 * 2 scale 4 %}`, "3"},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			tpl, err := SafeFromString(tt.template)
+			require.NoError(t, err)
+
+			out, err := tpl.Execute(pongo2.Context{})
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, out)
+		})
+	}
+}
+
+func TestCalcTag_Functions(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name     string
+		template string
+		expected string
+	}{
+		{"abs", `{% calc abs(3 - 10) %}`, "7"},
+		{"round to integer", `{% calc round(2.5) %}`, "3"},
+		{"round to places", `{% calc round(1.23456, 3) %}`, "1.235"},
+		{"round uses tag rounding", `{% calc round(2.5) rounding half_even %}`, "2"},
+		{"min", `{% calc min(3, -1.5, 2) %}`, "-1.5"},
+		{"max", `{% calc max(3, -1.5, 2) %}`, "3"},
+		{"nested calls", `{% calc max(abs(-4), min(10, 5)) * 2 %}`, "10"},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			tpl, err := SafeFromString(tt.template)
+			require.NoError(t, err)
+
+			out, err := tpl.Execute(pongo2.Context{})
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, out)
+		})
+	}
+}
+
+func TestCalcTag_AsClause(t *testing.T) {
+	t.Parallel()
+	tpl, err := SafeFromString(`{% calc price * qty scale 2 as total %}[{{ total }}]{% calc total / 2 %}`)
+	require.NoError(t, err)
+
+	out, err := tpl.Execute(pongo2.Context{"price": "19.99", "qty": 3})
+	require.NoError(t, err)
+	assert.Equal(t, "[59.97]29.985", out)
+}
+
+func TestCalcTag_LoopVariable(t *testing.T) {
+	t.Parallel()
+	tpl, err := SafeFromString(`{% for item in items %}{% calc item.amount * 1.1 %};{% endfor %}`)
+	require.NoError(t, err)
+
+	out, err := tpl.Execute(pongo2.Context{"items": []map[string]any{{"amount": "10"}, {"amount": 0.3}}})
+	require.NoError(t, err)
+	assert.Equal(t, "11;0.33;", out)
+}
+
+func TestCalcTag_ParseErrors(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name     string
+		template string
+	}{
+		{"empty expression", `{% calc %}`},
+		{"unbalanced parentheses", `{% calc (1 + 2 %}`},
+		{"trailing operator", `{% calc 1 + %}`},
+		{"unknown function", `{% calc sqrt(4) %}`},
+		{"abs arity", `{% calc abs(1, 2) %}`},
+		{"round arity", `{% calc round() %}`},
+		{"negative scale", `{% calc 1 scale -1 %}`},
+		{"scale too large", `{% calc 1 scale 99 %}`},
+		{"unknown rounding", `{% calc 1 rounding ceiling %}`},
+		{"missing as name", `{% calc 1 as %}`},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			_, err := SafeFromString(tt.template)
+			require.Error(t, err)
+		})
+	}
+}
+
+func TestCalcTag_ZeroToNegativePower(t *testing.T) {
+	t.Parallel()
+	tpl, err := SafeFromString(`{% calc 0 ** -1 %}`)
+	require.NoError(t, err)
+
+	_, err = tpl.Execute(pongo2.Context{})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "division by zero")
+}
@@ -0,0 +1,91 @@
+// Copyright (c) 2026 Lerian Studio. All rights reserved.
+// Use of this source code is governed by the Elastic License 2.0
+// that can be found in the LICENSE file.
+
+package pongo
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/LerianStudio/reporter/pkg/constant"
+
+	"github.com/flosch/pongo2/v6"
+)
+
+// csvTagNode represents a csv tag, which declares the dialect the csv_field filter writes the fields
+// of the template with. Options not given keep the RFC 4180 defaults.
+// Syntax: {% csv delimiter=";" quote="\"" decimal="," formulas="neutralize" %}
+type csvTagNode struct {
+	dialect CSVDialect
+}
+
+// makeCSVTag parses the csv tag: name="value" options with string literals.
+func makeCSVTag(_ *pongo2.Parser, start *pongo2.Token, arguments *pongo2.Parser) (pongo2.INodeTag, *pongo2.Error) {
+	node := &csvTagNode{dialect: *DefaultCSVDialect()}
+
+	for arguments.Remaining() > 0 {
+		nameToken := arguments.MatchType(pongo2.TokenIdentifier)
+		if nameToken == nil || arguments.Match(pongo2.TokenSymbol, "=") == nil {
+			return nil, arguments.Error("csv options must be given as name=\"value\", e.g. {% csv delimiter=\";\" %}", start)
+		}
+
+		valueToken := arguments.MatchType(pongo2.TokenString)
+		if valueToken == nil {
+			return nil, arguments.Error("csv option '"+nameToken.Val+"' requires a string value", nameToken)
+		}
+
+		// The lexer keeps the escapes of string literals, e.g. quote="\""
+		value := strings.NewReplacer(`\"`, `"`, `\\`, `\`).Replace(valueToken.Val)
+
+		switch nameToken.Val {
+		case "delimiter":
+			node.dialect.Delimiter = value
+		case "quote":
+			node.dialect.Quote = value
+		case "decimal":
+			node.dialect.Decimal = value
+		case "formulas":
+			node.dialect.Formulas = value
+		default:
+			return nil, arguments.Error("unknown csv option '"+nameToken.Val+"', use delimiter, quote, decimal or formulas", nameToken)
+		}
+	}
+
+	if err := node.dialect.validate(); err != nil {
+		return nil, arguments.Error(err.Error(), start)
+	}
+
+	return node, nil
+}
+
+// validate checks that the delimiter and quote are distinct single characters and the other options are supported.
+func (d *CSVDialect) validate() error {
+	for _, option := range []struct{ name, value string }{{"delimiter", d.Delimiter}, {"quote", d.Quote}} {
+		if utf8.RuneCountInString(option.value) != 1 || strings.ContainsAny(option.value, "\r\n") {
+			return fmt.Errorf("csv %s must be a single character other than a line break", option.name)
+		}
+	}
+
+	switch {
+	case d.Delimiter == d.Quote:
+		return fmt.Errorf("csv delimiter and quote must differ")
+	case d.Decimal != "." && d.Decimal != ",":
+		return fmt.Errorf("csv decimal must be \".\" or \",\"")
+	case d.Formulas != constant.CSVFormulasKeep && d.Formulas != constant.CSVFormulasNeutralize:
+		return fmt.Errorf("csv formulas must be \"keep\" or \"neutralize\"")
+	}
+
+	return nil
+}
+
+// Execute sets the dialect of the render, which escapes the csv_field values once the output is
+// complete. The tag renders nothing.
+func (node *csvTagNode) Execute(ctx *pongo2.ExecutionContext, _ pongo2.TemplateWriter) *pongo2.Error {
+	if dialect, ok := ctx.Public[CSVContextKey].(*CSVDialect); ok {
+		*dialect = node.dialect
+	}
+
+	return nil
+}
@@ -0,0 +1,154 @@
+// Copyright (c) 2026 Lerian Studio. All rights reserved.
+// Use of this source code is governed by the Elastic License 2.0
+// that can be found in the LICENSE file.
+
+package pongo
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/LerianStudio/reporter/pkg/constant"
+	"github.com/LerianStudio/reporter/pkg/templateutils"
+
+	"github.com/flosch/pongo2/v6"
+)
+
+// FixedWidthContextKey is the key used to store the fixed-width record state in the pongo2 context
+const FixedWidthContextKey = "_fixed_width"
+
+// Markers wrapping the lines written by the record tag. When a template declares a fixed-width
+// layout, only the marked lines are kept, so the text between record tags is not part of the file.
+const (
+	fixedWidthLineStart = "\uE002"
+	fixedWidthLineEnd   = "\uE003"
+)
+
+// fixedWidthTagPattern matches the fixed_width declaration, capturing the layout name.
+var fixedWidthTagPattern = regexp.MustCompile(`{%-?\s*fixed_width\s+["']([^"']*)["']\s*-?%}`)
+
+// fixedWidthLinePattern matches a line written by the record tag, capturing it without the markers.
+var fixedWidthLinePattern = regexp.MustCompile(fixedWidthLineStart + `([^` + fixedWidthLineEnd + `]*)` + fixedWidthLineEnd)
+
+// fixedWidthTagNode represents a fixed_width tag, which declares the stored layout of a fixed-width
+// template. The layout is loaded by the renderer, so the tag renders nothing.
+// Syntax: {% fixed_width "layouts/cnab240" %}
+type fixedWidthTagNode struct{}
+
+// makeFixedWidthTag parses the fixed_width tag, accepting only a string literal naming a stored layout.
+func makeFixedWidthTag(_ *pongo2.Parser, start *pongo2.Token, arguments *pongo2.Parser) (pongo2.INodeTag, *pongo2.Error) {
+	nameToken := arguments.MatchType(pongo2.TokenString)
+	if nameToken == nil {
+		return nil, arguments.Error("fixed_width tag requires a layout name, e.g. {% fixed_width \"layouts/cnab240\" %}", start)
+	}
+
+	if err := templateutils.ValidatePartialName(templateutils.ResolvePartialName("", nameToken.Val)); err != nil {
+		return nil, arguments.Error("invalid layout name '"+nameToken.Val+"'", nameToken)
+	}
+
+	if arguments.Remaining() > 0 {
+		return nil, arguments.Error("fixed_width tag takes a single layout name", nil)
+	}
+
+	return &fixedWidthTagNode{}, nil
+}
+
+// Execute renders nothing; the layout is loaded when the template is prepared for rendering.
+func (node *fixedWidthTagNode) Execute(_ *pongo2.ExecutionContext, _ pongo2.TemplateWriter) *pongo2.Error {
+	return nil
+}
+
+// recordField is a field value given to the record tag.
+type recordField struct {
+	name string
+	expr pongo2.IEvaluator
+}
+
+// recordNode represents a record tag, which writes a CRLF-terminated record of the fixed-width layout.
+// Fields that are not given are filled from their source or constant value in the layout.
+// Syntax: {% record "detail" amount=op.amount name=op.holder_name %}
+type recordNode struct {
+	recordType string
+	fields     []recordField
+}
+
+// makeRecordTag parses the record tag: a record type followed by name=value pairs.
+func makeRecordTag(_ *pongo2.Parser, start *pongo2.Token, arguments *pongo2.Parser) (pongo2.INodeTag, *pongo2.Error) {
+	typeToken := arguments.MatchType(pongo2.TokenString)
+	if typeToken == nil {
+		return nil, arguments.Error("record tag requires a record type, e.g. {% record \"detail\" amount=op.amount %}", start)
+	}
+
+	node := &recordNode{recordType: typeToken.Val}
+
+	for arguments.Remaining() > 0 {
+		nameToken := arguments.MatchType(pongo2.TokenIdentifier)
+		if nameToken == nil || arguments.Match(pongo2.TokenSymbol, "=") == nil {
+			return nil, arguments.Error("record fields must be given as name=value", nil)
+		}
+
+		expr, err := arguments.ParseExpression()
+		if err != nil {
+			return nil, err
+		}
+
+		node.fields = append(node.fields, recordField{name: nameToken.Val, expr: expr})
+	}
+
+	return node, nil
+}
+
+// Execute formats the record with the layout of the render and writes it.
+func (node *recordNode) Execute(ctx *pongo2.ExecutionContext, writer pongo2.TemplateWriter) *pongo2.Error {
+	state, ok := ctx.Public[FixedWidthContextKey].(*fixedWidthState)
+	if !ok {
+		return ctx.Error("record tag requires a {% fixed_width %} layout", nil)
+	}
+
+	values := make(map[string]any, len(node.fields))
+
+	for _, field := range node.fields {
+		value, err := field.expr.Evaluate(ctx)
+		if err != nil {
+			return err
+		}
+
+		values[field.name] = recordValue(value)
+	}
+
+	line, err := state.writeRecord(node.recordType, values)
+	if err != nil {
+		return ctx.Error(err.Error(), nil)
+	}
+
+	if _, errWrite := writer.WriteString(fixedWidthLineStart + line + constant.FixedWidthLineEnding + fixedWidthLineEnd); errWrite != nil {
+		return ctx.Error(errWrite.Error(), nil)
+	}
+
+	return nil
+}
+
+// recordValue unwraps the value given to a record field. Values written by the csv_field filter are
+// given as their plain text, so the markers are not counted in the field width.
+func recordValue(value *pongo2.Value) any {
+	if value.IsNil() {
+		return nil
+	}
+
+	if text, ok := value.Interface().(string); ok {
+		return stripCSVFields(text)
+	}
+
+	return value.Interface()
+}
+
+// fixedWidthRecords returns the records written by the record tags, dropping any other output.
+func fixedWidthRecords(output string) string {
+	var b strings.Builder
+
+	for _, match := range fixedWidthLinePattern.FindAllStringSubmatch(output, -1) {
+		b.WriteString(stripCSVFields(match[1]))
+	}
+
+	return b.String()
+}
@@ -0,0 +1,115 @@
+// Copyright (c) 2026 Lerian Studio. All rights reserved.
+// Use of this source code is governed by the Elastic License 2.0
+// that can be found in the LICENSE file.
+
+package pongo
+
+import (
+	"context"
+	"testing"
+
+	"github.com/LerianStudio/lib-commons/v2/commons/zap"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRenderFromBytes_FixedWidth(t *testing.T) {
+	t.Parallel()
+
+	logger := zap.InitializeLogger()
+	source := mapTemplateSource{partialObjectName("layouts/test"): testFixedWidthLayout}
+	renderer := NewTemplateRendererWithLoader(NewStorageLoader(context.Background(), source, partialObjectName))
+
+	data := map[string]map[string][]map[string]any{
+		"db": {
+			"company": {{"name": "ACME", "date": "2025-03-07"}},
+			"transfers": {
+				{"name": "Ann", "amount": "1234.5"},
+				{"name": "Bartholomew", "amount": 10},
+			},
+		},
+	}
+
+	template := `{% fixed_width "layouts/test" %}
+{% record "header" company=db.company.0.name date=db.company.0.date %}
+{% for t in db.transfers %}
+  {% record "detail" name=t.name amount=t.amount %}
+{% endfor %}
+{% record "trailer" %}
+`
+
+	out, err := renderer.RenderFromBytes(context.Background(), []byte(template), data, logger)
+	require.NoError(t, err)
+
+	expected := "0ACME       07032025\r\n" +
+		"1Ann   0001234500002\r\n" +
+		"1Bartho0000010000003\r\n" +
+		"90002000001244500004\r\n"
+	assert.Equal(t, expected, out)
+
+	layout, err := renderer.LoadFixedWidthLayout([]byte(template))
+	require.NoError(t, err)
+	require.NoError(t, layout.ValidateOutput(out))
+}
+
+func TestRenderFromBytes_FixedWidthCSVField(t *testing.T) {
+	t.Parallel()
+
+	logger := zap.InitializeLogger()
+	source := mapTemplateSource{partialObjectName("layouts/test"): testFixedWidthLayout}
+	renderer := NewTemplateRendererWithLoader(NewStorageLoader(context.Background(), source, partialObjectName))
+
+	data := map[string]map[string][]map[string]any{
+		"db": {"transfers": {{"name": "Ann", "amount": "1234.5"}}},
+	}
+
+	template := `{% fixed_width "layouts/test" %}
+{% for t in db.transfers %}{% record "detail" name=t.name|csv_field amount=t.amount %}{{ t.name|csv_field }}{% endfor %}
+`
+
+	out, err := renderer.RenderFromBytes(context.Background(), []byte(template), data, logger)
+	require.NoError(t, err)
+	assert.Equal(t, "1Ann   0001234500001\r\n", out)
+}
+
+func TestRenderFromBytes_FixedWidthErrors(t *testing.T) {
+	t.Parallel()
+
+	logger := zap.InitializeLogger()
+	source := mapTemplateSource{
+		partialObjectName("layouts/test"):    testFixedWidthLayout,
+		partialObjectName("layouts/invalid"): `{"record_length": 0}`,
+	}
+	renderer := NewTemplateRendererWithLoader(NewStorageLoader(context.Background(), source, partialObjectName))
+
+	tests := []struct {
+		name     string
+		template string
+	}{
+		{"missing layout", `{% fixed_width "layouts/missing" %}`},
+		{"invalid layout", `{% fixed_width "layouts/invalid" %}`},
+		{"record without layout", `{% record "detail" %}`},
+		{"unknown record type", `{% fixed_width "layouts/test" %}{% record "footer" %}`},
+		{"unknown field", `{% fixed_width "layouts/test" %}{% record "detail" iban="x" %}`},
+		{"value does not fit", `{% fixed_width "layouts/test" %}{% record "detail" amount=10000000 %}`},
+		{"malformed field", `{% fixed_width "layouts/test" %}{% record "detail" amount %}`},
+		{"layout name is not a string", `{% fixed_width layout %}`},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			_, err := renderer.RenderFromBytes(context.Background(), []byte(tt.template), nil, logger)
+			require.Error(t, err)
+		})
+	}
+}
+
+func TestLoadFixedWidthLayout_NoLayout(t *testing.T) {
+	t.Parallel()
+
+	layout, err := NewTemplateRenderer().LoadFixedWidthLayout([]byte(`{{ x }}`))
+	require.NoError(t, err)
+	assert.Nil(t, layout)
+}
@@ -250,26 +250,10 @@ func (node *lastItemByGroupNode) getLastByDate(items []map[string]any, dateField
 	return latest
 }
 
-// parseTime attempts to parse a value as time.Time.
+// parseTime attempts to parse a value as time.Time, returning the zero time when it is not a date.
 // This is a package-level function shared across tags.
 func parseTime(val any) time.Time {
-	switch v := val.(type) {
-	case time.Time:
-		return v
-	case string:
-		// Try RFC3339 first
-		if t, err := time.Parse(time.RFC3339, v); err == nil {
-			return t
-		}
-		// Try date only
-		if t, err := time.Parse("2006-01-02", v); err == nil {
-			return t
-		}
-		// Try RFC3339Nano
-		if t, err := time.Parse(time.RFC3339Nano, v); err == nil {
-			return t
-		}
-	}
+	t, _ := parseTimeValue(val)
 
-	return time.Time{}
+	return t
 }
@@ -0,0 +1,134 @@
+// Copyright (c) 2026 Lerian Studio. All rights reserved.
+// Use of this source code is governed by the Elastic License 2.0
+// that can be found in the LICENSE file.
+
+package pongo
+
+import (
+	"io"
+	"regexp"
+	"strings"
+
+	"github.com/LerianStudio/reporter/pkg/constant"
+
+	"github.com/flosch/pongo2/v6"
+)
+
+// localeTagPattern matches the template-level locale declaration, capturing the locale.
+var localeTagPattern = regexp.MustCompile(`{%-?\s*locale\s+["']([^"']*)["']\s*-?%}`)
+
+// formatFilterPattern matches the locale-aware format filters in a template.
+var formatFilterPattern = regexp.MustCompile(`\|\s*(format_number|format_currency|format_percent|format_date)\b`)
+
+// variableTagPattern matches a variable tag, capturing its whitespace control and expression.
+var variableTagPattern = regexp.MustCompile(`{{(-?)((?:[^}]|}[^}])*?)(-?)}}`)
+
+// The output of the variable tags printing a format_* filter is enclosed in these private-use characters,
+// so the renderer leaves it out of its numeric clean-up: its separators would be taken for decimal points
+// (e.g. "1.230" in pt-BR). The characters are removed once the clean-up is done.
+const (
+	localizedOutputStart = "\uE006"
+	localizedOutputEnd   = "\uE007"
+)
+
+// localizedOutputPattern matches the output of a variable tag printing a format_* filter.
+var localizedOutputPattern = regexp.MustCompile(localizedOutputStart + `[^` + localizedOutputEnd + `]*` + localizedOutputEnd)
+
+// localeTagNode represents a locale tag, which declares the default locale of the format_* filters of a template.
+// The declaration is applied by the renderer before parsing, so the tag itself renders nothing.
+// Syntax: {% locale "pt-BR" %}
+type localeTagNode struct{}
+
+// makeLocaleTag parses the locale tag, accepting only a string literal naming a supported locale.
+func makeLocaleTag(_ *pongo2.Parser, start *pongo2.Token, arguments *pongo2.Parser) (pongo2.INodeTag, *pongo2.Error) {
+	localeToken := arguments.MatchType(pongo2.TokenString)
+	if localeToken == nil {
+		return nil, arguments.Error("locale tag requires a locale string, e.g. {% locale \"pt-BR\" %}", start)
+	}
+
+	if _, ok := NormalizeLocale(localeToken.Val); !ok {
+		return nil, arguments.Error("unsupported locale '"+localeToken.Val+"'", localeToken)
+	}
+
+	if arguments.Remaining() > 0 {
+		return nil, arguments.Error("locale tag takes a single locale string", nil)
+	}
+
+	return &localeTagNode{}, nil
+}
+
+// Execute renders nothing; the locale is applied when the template is prepared for rendering.
+func (node *localeTagNode) Execute(_ *pongo2.ExecutionContext, _ pongo2.TemplateWriter) *pongo2.Error {
+	return nil
+}
+
+// resolveLocale returns the locale of a render: the report locale, falling back to the locale declared
+// by the template and then to the default locale.
+func resolveLocale(template, reportLocale string) string {
+	if locale, ok := NormalizeLocale(reportLocale); ok {
+		return locale
+	}
+
+	if match := localeTagPattern.FindStringSubmatch(template); match != nil {
+		if locale, ok := NormalizeLocale(match[1]); ok {
+			return locale
+		}
+	}
+
+	return constant.DefaultLocale
+}
+
+// applyLocale binds every format_* filter of the template to the render locale stored under
+// LocaleContextKey. Values already bound with the localize filter keep their locale.
+func applyLocale(template string) string {
+	return formatFilterPattern.ReplaceAllString(template, `|localize:`+LocaleContextKey+`|${1}`)
+}
+
+// markLocalizedOutput encloses the variable tags of the template that print a format_* filter in the
+// localized output markers. The whitespace control of a tag is kept by empty tags outside the markers.
+func markLocalizedOutput(template string) string {
+	return variableTagPattern.ReplaceAllStringFunc(template, func(tag string) string {
+		parts := variableTagPattern.FindStringSubmatch(tag)
+		if !formatFilterPattern.MatchString(parts[2]) {
+			return tag
+		}
+
+		marked := localizedOutputStart + "{{" + parts[2] + "}}" + localizedOutputEnd
+
+		if parts[1] != "" {
+			marked = `{{- "" }}` + marked
+		}
+
+		if parts[3] != "" {
+			marked += `{{ "" -}}`
+		}
+
+		return marked
+	})
+}
+
+// stripLocalizedOutput removes the localized output markers from the output of a render.
+func stripLocalizedOutput(output string) string {
+	return strings.NewReplacer(localizedOutputStart, "", localizedOutputEnd, "").Replace(output)
+}
+
+// localeLoader binds the format_* filters of the templates included, extended or imported during a
+// render to the render locale and marks their output, as the renderer does for the rendered template.
+type localeLoader struct {
+	pongo2.TemplateLoader
+}
+
+// Get reads the template at path, binding its format_* filters and marking their output.
+func (l localeLoader) Get(path string) (io.Reader, error) {
+	reader, err := l.TemplateLoader.Get(path)
+	if err != nil {
+		return nil, err
+	}
+
+	content, err := io.ReadAll(reader)
+	if err != nil {
+		return nil, err
+	}
+
+	return strings.NewReader(markLocalizedOutput(applyLocale(string(content)))), nil
+}
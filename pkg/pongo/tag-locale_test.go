@@ -0,0 +1,143 @@
+// Copyright (c) 2026 Lerian Studio. All rights reserved.
+// Use of this source code is governed by the Elastic License 2.0
+// that can be found in the LICENSE file.
+
+package pongo
+
+import (
+	"testing"
+
+	"github.com/LerianStudio/reporter/pkg/constant"
+
+	"github.com/flosch/pongo2/v6"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLocaleTag(t *testing.T) {
+	t.Parallel()
+	tpl, err := SafeFromString(`{% locale "pt-BR" %}ok`)
+	require.NoError(t, err)
+
+	out, err := tpl.Execute(pongo2.Context{})
+	require.NoError(t, err)
+	assert.Equal(t, "ok", out)
+}
+
+func TestLocaleTag_ParseErrors(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name     string
+		template string
+	}{
+		{"missing locale", `{% locale %}`},
+		{"variable instead of string", `{% locale lang %}`},
+		{"unsupported locale", `{% locale "fr-FR" %}`},
+		{"extra arguments", `{% locale "pt-BR" "es" %}`},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			_, err := SafeFromString(tt.template)
+			require.Error(t, err)
+		})
+	}
+}
+
+func TestResolveLocale(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name         string
+		template     string
+		reportLocale string
+		expected     string
+	}{
+		{
+			name:     "no locale uses the default locale",
+			template: `{{ v|format_number }}`,
+			expected: constant.DefaultLocale,
+		},
+		{
+			name:     "template locale",
+			template: `{% locale "pt_br" %}{{ v|format_number:"2" }}`,
+			expected: "pt-BR",
+		},
+		{
+			name:         "report locale overrides the template",
+			template:     `{% locale "pt-BR" %}{{ v|format_currency }}`,
+			reportLocale: "es",
+			expected:     "es",
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tt.expected, resolveLocale(tt.template, tt.reportLocale))
+		})
+	}
+}
+
+func TestMarkLocalizedOutput(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name     string
+		template string
+		expected string
+	}{
+		{
+			name:     "format filter output is marked",
+			template: `<td>{{ v|format_number:"2"|ljust:"8" }}</td>`,
+			expected: "<td>" + localizedOutputStart + `{{ v|format_number:"2"|ljust:"8" }}` + localizedOutputEnd + "</td>",
+		},
+		{
+			name:     "whitespace control is kept outside the markers",
+			template: `[ {{- v|format_date -}} ]`,
+			expected: `[ {{- "" }}` + localizedOutputStart + `{{ v|format_date }}` + localizedOutputEnd + `{{ "" -}} ]`,
+		},
+		{
+			name:     "other variables and tags are untouched",
+			template: `{{ v }}{% if v|format_number == "1" %}{{ w|upper }}{% endif %}`,
+			expected: `{{ v }}{% if v|format_number == "1" %}{{ w|upper }}{% endif %}`,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tt.expected, markLocalizedOutput(tt.template))
+		})
+	}
+}
+
+func TestApplyLocale(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name     string
+		template string
+		expected string
+	}{
+		{
+			name:     "format filters are bound to the render locale",
+			template: `{{ v|format_number:"2" }}{{ d | format_date }}`,
+			expected: `{{ v|localize:_locale|format_number:"2" }}{{ d |localize:_locale|format_date }}`,
+		},
+		{
+			name:     "other filters are untouched",
+			template: `{{ v|format_numbers }}{{ v|strip_zeros }}`,
+			expected: `{{ v|format_numbers }}{{ v|strip_zeros }}`,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tt.expected, applyLocale(tt.template))
+		})
+	}
+}
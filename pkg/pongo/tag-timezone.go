@@ -0,0 +1,88 @@
+// Copyright (c) 2026 Lerian Studio. All rights reserved.
+// Use of this source code is governed by the Elastic License 2.0
+// that can be found in the LICENSE file.
+
+package pongo
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/flosch/pongo2/v6"
+)
+
+// TimezoneContextKey is the key used to store the template or report timezone in the pongo2 context
+const TimezoneContextKey = "_timezone"
+
+// timezoneTagPattern matches the template-level timezone declaration, capturing the zone.
+var timezoneTagPattern = regexp.MustCompile(`{%-?\s*timezone\s+["']([^"']*)["']\s*-?%}`)
+
+// toTzFilterPattern matches the to_tz filter, with the colon of its parameter when it has one.
+var toTzFilterPattern = regexp.MustCompile(`\|\s*to_tz\b(\s*:)?`)
+
+// timezoneTagNode represents a timezone tag, which declares the default timezone of the date_time tag
+// and the to_tz filter of a template. The declaration is applied by the renderer, so the tag renders nothing.
+// Syntax: {% timezone "America/Sao_Paulo" %}
+type timezoneTagNode struct{}
+
+// makeTimezoneTag parses the timezone tag, accepting only a string literal naming an IANA timezone.
+func makeTimezoneTag(_ *pongo2.Parser, start *pongo2.Token, arguments *pongo2.Parser) (pongo2.INodeTag, *pongo2.Error) {
+	zoneToken := arguments.MatchType(pongo2.TokenString)
+	if zoneToken == nil {
+		return nil, arguments.Error("timezone tag requires a timezone string, e.g. {% timezone \"America/Sao_Paulo\" %}", start)
+	}
+
+	if _, err := time.LoadLocation(zoneToken.Val); err != nil {
+		return nil, arguments.Error("invalid timezone '"+zoneToken.Val+"'", zoneToken)
+	}
+
+	if arguments.Remaining() > 0 {
+		return nil, arguments.Error("timezone tag takes a single timezone string", nil)
+	}
+
+	return &timezoneTagNode{}, nil
+}
+
+// Execute renders nothing; the timezone is applied when the template is prepared for rendering.
+func (node *timezoneTagNode) Execute(_ *pongo2.ExecutionContext, _ pongo2.TemplateWriter) *pongo2.Error {
+	return nil
+}
+
+// resolveTimezone returns the report timezone, falling back to the one declared by the template.
+// It returns nil when neither is set.
+func resolveTimezone(template, reportTimezone string) (*time.Location, error) {
+	zone := reportTimezone
+
+	if zone == "" {
+		match := timezoneTagPattern.FindStringSubmatch(template)
+		if match == nil {
+			return nil, nil
+		}
+
+		zone = match[1]
+	}
+
+	loc, err := time.LoadLocation(zone)
+	if err != nil {
+		return nil, fmt.Errorf("invalid timezone '%s': %w", zone, err)
+	}
+
+	return loc, nil
+}
+
+// applyTimezone gives the timezone to every to_tz filter of the template that has no zone of its own.
+func applyTimezone(template string, loc *time.Location) string {
+	if loc == nil {
+		return template
+	}
+
+	return toTzFilterPattern.ReplaceAllStringFunc(template, func(match string) string {
+		if strings.HasSuffix(match, ":") {
+			return match
+		}
+
+		return match + `:"` + loc.String() + `"`
+	})
+}
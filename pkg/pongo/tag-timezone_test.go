@@ -0,0 +1,80 @@
+// Copyright (c) 2026 Lerian Studio. All rights reserved.
+// Use of this source code is governed by the Elastic License 2.0
+// that can be found in the LICENSE file.
+
+package pongo
+
+import (
+	"testing"
+	"time"
+
+	"github.com/flosch/pongo2/v6"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTimezoneTag(t *testing.T) {
+	t.Parallel()
+	tpl, err := SafeFromString(`{% timezone "America/Sao_Paulo" %}ok`)
+	require.NoError(t, err)
+
+	out, err := tpl.Execute(pongo2.Context{})
+	require.NoError(t, err)
+	assert.Equal(t, "ok", out)
+
+	for _, template := range []string{`{% timezone %}`, `{% timezone zone %}`, `{% timezone "Mars/Olympus" %}`, `{% timezone "UTC" "UTC" %}`} {
+		_, err := SafeFromString(template)
+		require.Error(t, err, template)
+	}
+}
+
+func TestResolveTimezone(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name           string
+		template       string
+		reportTimezone string
+		expected       string
+		wantErr        bool
+	}{
+		{"none", `{{ x }}`, "", "", false},
+		{"template", `{% timezone "America/Sao_Paulo" %}`, "", "America/Sao_Paulo", false},
+		{"report overrides template", `{% timezone "America/Sao_Paulo" %}`, "Europe/Madrid", "Europe/Madrid", false},
+		{"invalid report timezone", `{{ x }}`, "Mars/Olympus", "", true},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			loc, err := resolveTimezone(tt.template, tt.reportTimezone)
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+
+			if tt.expected == "" {
+				assert.Nil(t, loc)
+				return
+			}
+
+			require.NotNil(t, loc)
+			assert.Equal(t, tt.expected, loc.String())
+		})
+	}
+}
+
+func TestApplyTimezone(t *testing.T) {
+	t.Parallel()
+	loc, err := time.LoadLocation("America/Sao_Paulo")
+	require.NoError(t, err)
+
+	template := `{{ a|to_tz }}{{ b | to_tz|date:"15:04" }}{{ c|to_tz:"UTC" }}{{ d|to_tz :"UTC" }}`
+
+	assert.Equal(t,
+		`{{ a|to_tz:"America/Sao_Paulo" }}{{ b | to_tz:"America/Sao_Paulo"|date:"15:04" }}{{ c|to_tz:"UTC" }}{{ d|to_tz :"UTC" }}`,
+		applyTimezone(template, loc))
+	assert.Equal(t, template, applyTimezone(template, nil))
+}
@@ -6,14 +6,10 @@ package pongo
 
 import (
 	"fmt"
-	"math"
-	"regexp"
 	"strconv"
 	"strings"
 	"time"
 
-	"github.com/LerianStudio/reporter/pkg/constant"
-
 	"github.com/flosch/pongo2/v6"
 	"github.com/shopspring/decimal"
 )
@@ -28,14 +24,12 @@ type aggregateTagNode struct {
 }
 
 // dateNowNode represents a data structure to hold information for the dateNow template tag.
-// It includes the expression representing the date format (e.g., "YYYY-MM-dd").
+// It includes the expression representing the date format (e.g., "YYYY-MM-dd") and the optional zone and offset.
+// Syntax: {% date_time "dd/MM/YYYY HH:mm" [tz "America/Sao_Paulo"] [offset "-1d"] %}
 type dateNowNode struct {
 	formatExpr pongo2.IEvaluator // Expression representing the date format (e.g., "YYYY-MM-dd")
-}
-
-// calcTagNode represents a calc tag that evaluates arithmetic expressions
-type calcTagNode struct {
-	expression string
+	tzExpr     pongo2.IEvaluator // Optional IANA timezone, overriding the template or report timezone
+	offsetExpr pongo2.IEvaluator // Optional offset added to the current time (e.g., "-1d", "3h", "-90m")
 }
 
 const aggregateOpCount = "count"
@@ -86,22 +80,74 @@ func makeDateNowTag() pongo2.TagParser {
 			return nil, err
 		}
 
-		return &dateNowNode{
-			formatExpr: formatExpr,
-		}, nil
+		node := &dateNowNode{formatExpr: formatExpr}
+
+		for args.Remaining() > 0 {
+			option := args.MatchType(pongo2.TokenIdentifier)
+
+			switch {
+			case option != nil && option.Val == "tz" && node.tzExpr == nil:
+				node.tzExpr, err = args.ParseExpression()
+			case option != nil && option.Val == "offset" && node.offsetExpr == nil:
+				node.offsetExpr, err = args.ParseExpression()
+			default:
+				return nil, args.Error("date_time tag only accepts the 'tz' and 'offset' options, once each", nil)
+			}
+
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		return node, nil
 	}
 }
 
 // Execute renders the current date in a specified format and writes it using the provided template writer.
+// The date is taken in the tag's timezone, else the template or report timezone, else the worker's local zone.
 func (node *dateNowNode) Execute(ctx *pongo2.ExecutionContext, writer pongo2.TemplateWriter) *pongo2.Error {
 	formatVal, err := node.formatExpr.Evaluate(ctx)
 	if err != nil {
 		return err
 	}
 
+	now := time.Now()
+
+	if loc, ok := ctx.Public[TimezoneContextKey].(*time.Location); ok {
+		now = now.In(loc)
+	}
+
+	if node.tzExpr != nil {
+		tzVal, errTz := node.tzExpr.Evaluate(ctx)
+		if errTz != nil {
+			return errTz
+		}
+
+		loc, errLoad := time.LoadLocation(tzVal.String())
+		if errLoad != nil {
+			return ctx.Error(fmt.Sprintf("invalid timezone '%s'", tzVal.String()), nil)
+		}
+
+		now = now.In(loc)
+	}
+
+	if node.offsetExpr != nil {
+		offsetVal, errOffset := node.offsetExpr.Evaluate(ctx)
+		if errOffset != nil {
+			return errOffset
+		}
+
+		shifted, ok := applyDateOffset(now, offsetVal.String())
+		if !ok {
+			return ctx.Error(fmt.Sprintf("invalid offset '%s', expected e.g. \"-1d\" or \"3h\"", offsetVal.String()), nil)
+		}
+
+		now = shifted
+	}
+
 	format := formatVal.String()
 	goLayout := convertToGoDateLayout(format)
-	output := time.Now().Format(goLayout)
+	output := now.Format(goLayout)
 
 	_, err2 := writer.WriteString(output)
 	if err2 != nil {
@@ -111,6 +157,28 @@ func (node *dateNowNode) Execute(ctx *pongo2.ExecutionContext, writer pongo2.Tem
 	return nil
 }
 
+// applyDateOffset shifts t by an offset in days ("-1d") or in Go duration syntax ("3h", "-90m").
+// Days are added on the calendar, so "1d" keeps the wall clock time across daylight saving changes.
+func applyDateOffset(t time.Time, offset string) (time.Time, bool) {
+	offset = strings.TrimSpace(offset)
+
+	if days, found := strings.CutSuffix(offset, "d"); found {
+		n, err := strconv.Atoi(days)
+		if err != nil {
+			return t, false
+		}
+
+		return t.AddDate(0, 0, n), true
+	}
+
+	duration, err := time.ParseDuration(offset)
+	if err != nil {
+		return t, false
+	}
+
+	return t.Add(duration), true
+}
+
 // Execute processes a template tag by evaluating a collection and performing aggregation, then writes the result to the output.
 func (node *aggregateTagNode) Execute(ctx *pongo2.ExecutionContext, writer pongo2.TemplateWriter) *pongo2.Error {
 	list, err := evaluateCollection(ctx, node.collectionExpr)
@@ -305,6 +373,7 @@ func extractDecimalValue(ctx *pongo2.ExecutionContext, item map[string]any, fiel
 func convertToGoDateLayout(layout string) string {
 	replacer := strings.NewReplacer(
 		"YYYY", "2006",
+		"YY", "06",
 		"MM", "01",
 		"dd", "02",
 		"HH", "15",
@@ -314,128 +383,3 @@ func convertToGoDateLayout(layout string) string {
 
 	return replacer.Replace(layout)
 }
-
-func makeCalcTag(_ *pongo2.Parser, _ *pongo2.Token, arguments *pongo2.Parser) (pongo2.INodeTag, *pongo2.Error) {
-	calcNode := &calcTagNode{}
-
-	// Get the raw expression as string
-	// We need to collect all remaining tokens to build the full expression
-	var tokens []string
-
-	for arguments.Remaining() > 0 {
-		token := arguments.Current()
-		tokens = append(tokens, token.Val)
-
-		arguments.Consume()
-	}
-
-	expression := strings.Join(tokens, " ")
-
-	// Remove spaces around dots to fix variable paths
-	expression = strings.ReplaceAll(expression, " . ", ".")
-	calcNode.expression = expression
-
-	return calcNode, nil
-}
-
-func (node *calcTagNode) Execute(ctx *pongo2.ExecutionContext, writer pongo2.TemplateWriter) *pongo2.Error {
-	context := ctx.Public
-
-	expression := node.replaceVariables(node.expression, context, ctx.Private)
-
-	result, err := evaluateArithmeticExpression(expression)
-	if err != nil {
-		return &pongo2.Error{
-			Sender:    "calc",
-			OrigError: err,
-		}
-	}
-
-	// Round to 10 decimal places to avoid artifacts (e.g., ...0000000001)
-	rounded := math.Round(result*constant.RoundingPrecision) / constant.RoundingPrecision
-	out := formatNumber(rounded)
-	out = strings.TrimRight(out, "0")
-	out = strings.TrimRight(out, ".")
-
-	if _, err := writer.WriteString(out); err != nil {
-		return ctx.Error("Error writing output", nil)
-	}
-
-	return nil
-}
-
-// shouldSkipMatch determines if a match should be skipped (operators, parentheses, numbers)
-func shouldSkipMatch(match string) bool {
-	// Skip operators and parentheses
-	operators := []string{"+", "-", "*", "/", "**", "(", ")", "[", "]", "{", "}"}
-	for _, op := range operators {
-		if match == op {
-			return true
-		}
-	}
-
-	// Skip if it's a number (but only if it doesn't contain dots)
-	if !strings.Contains(match, ".") {
-		if _, err := strconv.ParseFloat(match, 64); err == nil {
-			return true
-		}
-	}
-
-	return false
-}
-
-// resolveVariableFromContext attempts to resolve a variable from a given context
-func resolveVariableFromContext(match string, context pongo2.Context) (string, bool) {
-	templateStr := fmt.Sprintf("{{ %s }}", match)
-
-	// Use a per-call TemplateSet to avoid a race on pongo2's shared DefaultSet.
-	// See renderer.go for the detailed explanation.
-	ts := pongo2.NewSet("resolve", pongo2.DefaultLoader)
-
-	template, err := ts.FromString(templateStr)
-	if err != nil {
-		return "", false
-	}
-
-	result, err := template.ExecuteBytes(context)
-	if err != nil {
-		return "", false
-	}
-
-	value := strings.TrimSpace(string(result))
-	if value == "" {
-		return "", false
-	}
-
-	// Try to parse as number to validate it's a valid numeric value
-	if _, err := strconv.ParseFloat(value, 64); err != nil {
-		return "", false
-	}
-
-	return value, true
-}
-
-// replaceVariables replaces variables in the expression with their values
-func (node *calcTagNode) replaceVariables(expression string, context pongo2.Context, privateContext pongo2.Context) string {
-	// Find all variable patterns in the expression (words with dots)
-	// This regex matches patterns like: balance.available, midaz_transaction.balance.0.initial_balance, etc.
-	re := regexp.MustCompile(`\b[a-zA-Z_][a-zA-Z0-9_]*(\.[a-zA-Z0-9_]+)*\b`)
-
-	expression = re.ReplaceAllStringFunc(expression, func(match string) string {
-		if shouldSkipMatch(match) {
-			return match
-		}
-
-		if value, ok := resolveVariableFromContext(match, privateContext); ok {
-			return value
-		}
-
-		if value, ok := resolveVariableFromContext(match, context); ok {
-			return value
-		}
-
-		return "0"
-	})
-
-	return expression
-}
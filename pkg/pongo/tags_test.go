@@ -6,6 +6,7 @@ package pongo
 
 import (
 	"testing"
+	"time"
 
 	"github.com/flosch/pongo2/v6"
 	"github.com/shopspring/decimal"
@@ -362,6 +363,11 @@ func TestConvertToGoDateLayout(t *testing.T) {
 			input:    "YYYY-MM-ddTHH:mm:ss",
 			expected: "2006-01-02T15:04:05",
 		},
+		{
+			name:     "two-digit year ddMMYY",
+			input:    "ddMMYY",
+			expected: "020106",
+		},
 		{
 			name:     "no recognized tokens returns input unchanged",
 			input:    "hello-world",
@@ -384,115 +390,6 @@ func TestConvertToGoDateLayout(t *testing.T) {
 	}
 }
 
-func TestShouldSkipMatch(t *testing.T) {
-	t.Parallel()
-
-	tests := []struct {
-		name     string
-		match    string
-		expected bool
-	}{
-		// Operators and parentheses - should skip
-		{name: "plus operator", match: "+", expected: true},
-		{name: "minus operator", match: "-", expected: true},
-		{name: "multiply operator", match: "*", expected: true},
-		{name: "divide operator", match: "/", expected: true},
-		{name: "power operator", match: "**", expected: true},
-		{name: "open parenthesis", match: "(", expected: true},
-		{name: "close parenthesis", match: ")", expected: true},
-		{name: "open bracket", match: "[", expected: true},
-		{name: "close bracket", match: "]", expected: true},
-		{name: "open brace", match: "{", expected: true},
-		{name: "close brace", match: "}", expected: true},
-		// Numbers without dots - should skip
-		{name: "integer 42", match: "42", expected: true},
-		{name: "integer 0", match: "0", expected: true},
-		{name: "negative-looking integer 100", match: "100", expected: true},
-		// Variable paths with dots - should NOT skip (contain dots, ParseFloat is not called)
-		{name: "variable path with dot", match: "variable.path", expected: false},
-		{name: "nested variable path", match: "data.nested.field", expected: false},
-		// Plain identifiers - should NOT skip (not operators, not numbers)
-		{name: "plain identifier", match: "variable", expected: false},
-		{name: "identifier with underscore", match: "my_var", expected: false},
-		// Float-like strings with dots are NOT skipped because they contain dots
-		{name: "float 3.14 not skipped due to dot", match: "3.14", expected: false},
-	}
-
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			t.Parallel()
-
-			result := shouldSkipMatch(tt.match)
-			assert.Equal(t, tt.expected, result)
-		})
-	}
-}
-
-func TestResolveVariableFromContext(t *testing.T) {
-	t.Parallel()
-
-	tests := []struct {
-		name      string
-		match     string
-		context   pongo2.Context
-		wantValue string
-		wantOK    bool
-	}{
-		{
-			name:      "resolves integer variable",
-			match:     "amount",
-			context:   pongo2.Context{"amount": 42},
-			wantValue: "42",
-			wantOK:    true,
-		},
-		{
-			name:      "resolves float variable",
-			match:     "price",
-			context:   pongo2.Context{"price": 3.14},
-			wantValue: "3.140000",
-			wantOK:    true,
-		},
-		{
-			name:      "resolves nested map variable",
-			match:     "data.amount",
-			context:   pongo2.Context{"data": map[string]any{"amount": 100}},
-			wantValue: "100",
-			wantOK:    true,
-		},
-		{
-			name:    "missing variable returns false",
-			match:   "nonexistent",
-			context: pongo2.Context{},
-			wantOK:  false,
-		},
-		{
-			name:    "non-numeric value returns false",
-			match:   "name",
-			context: pongo2.Context{"name": "hello"},
-			wantOK:  false,
-		},
-		{
-			name:    "empty string value returns false",
-			match:   "empty",
-			context: pongo2.Context{"empty": ""},
-			wantOK:  false,
-		},
-	}
-
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			t.Parallel()
-
-			value, ok := resolveVariableFromContext(tt.match, tt.context)
-			assert.Equal(t, tt.wantOK, ok)
-
-			if tt.wantOK {
-				assert.Equal(t, tt.wantValue, value)
-			}
-		})
-	}
-}
-
 func TestExtractDecimalValue_ThroughTemplate(t *testing.T) {
 	t.Parallel()
 
@@ -741,6 +638,124 @@ func TestDateTimeTag(t *testing.T) {
 	}
 }
 
+func TestDateTimeTag_TimezoneAndOffset(t *testing.T) {
+	t.Parallel()
+
+	saoPaulo, err := time.LoadLocation("America/Sao_Paulo")
+	require.NoError(t, err)
+
+	tokyo, err := time.LoadLocation("Asia/Tokyo")
+	require.NoError(t, err)
+
+	tests := []struct {
+		name     string
+		template string
+		context  pongo2.Context
+		expected func(now time.Time) string
+	}{
+		{
+			name:     "tag timezone",
+			template: `{% date_time "YYYY-MM-dd HH" tz "Asia/Tokyo" %}`,
+			expected: func(now time.Time) string { return now.In(tokyo).Format("2006-01-02 15") },
+		},
+		{
+			name:     "render timezone",
+			template: `{% date_time "YYYY-MM-dd HH" %}`,
+			context:  pongo2.Context{TimezoneContextKey: saoPaulo},
+			expected: func(now time.Time) string { return now.In(saoPaulo).Format("2006-01-02 15") },
+		},
+		{
+			name:     "tag timezone overrides render timezone",
+			template: `{% date_time "YYYY-MM-dd HH" tz zone %}`,
+			context:  pongo2.Context{TimezoneContextKey: saoPaulo, "zone": "Asia/Tokyo"},
+			expected: func(now time.Time) string { return now.In(tokyo).Format("2006-01-02 15") },
+		},
+		{
+			name:     "day offset",
+			template: `{% date_time "YYYY-MM-dd" tz "America/Sao_Paulo" offset "-1d" %}`,
+			expected: func(now time.Time) string { return now.In(saoPaulo).AddDate(0, 0, -1).Format("2006-01-02") },
+		},
+		{
+			name:     "duration offset",
+			template: `{% date_time "YYYY-MM-dd HH" offset "3h" tz "UTC" %}`,
+			expected: func(now time.Time) string { return now.UTC().Add(3 * time.Hour).Format("2006-01-02 15") },
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			tpl, err := SafeFromString(tt.template)
+			require.NoError(t, err)
+
+			before := time.Now()
+
+			out, err := tpl.Execute(tt.context)
+			require.NoError(t, err)
+
+			// The hour may turn between computing the expectation and rendering
+			assert.Contains(t, []string{tt.expected(before), tt.expected(time.Now())}, out)
+		})
+	}
+}
+
+func TestDateTimeTag_Errors(t *testing.T) {
+	t.Parallel()
+
+	parseErrors := []string{
+		`{% date_time "YYYY" zone "UTC" %}`,
+		`{% date_time "YYYY" tz "UTC" tz "UTC" %}`,
+		`{% date_time "YYYY" offset %}`,
+	}
+
+	for _, template := range parseErrors {
+		_, err := SafeFromString(template)
+		require.Error(t, err, template)
+	}
+
+	executionErrors := []string{
+		`{% date_time "YYYY" tz "Mars/Olympus" %}`,
+		`{% date_time "YYYY" offset "tomorrow" %}`,
+	}
+
+	for _, template := range executionErrors {
+		tpl, err := SafeFromString(template)
+		require.NoError(t, err, template)
+
+		_, err = tpl.Execute(pongo2.Context{})
+		require.Error(t, err, template)
+	}
+}
+
+func TestApplyDateOffset(t *testing.T) {
+	t.Parallel()
+
+	saoPaulo, err := time.LoadLocation("America/Sao_Paulo")
+	require.NoError(t, err)
+
+	base := time.Date(2025, 3, 31, 10, 0, 0, 0, saoPaulo)
+
+	tests := []struct {
+		offset   string
+		expected time.Time
+		ok       bool
+	}{
+		{"-1d", time.Date(2025, 3, 30, 10, 0, 0, 0, saoPaulo), true},
+		{"+2d", time.Date(2025, 4, 2, 10, 0, 0, 0, saoPaulo), true},
+		{"-90m", time.Date(2025, 3, 31, 8, 30, 0, 0, saoPaulo), true},
+		{" 1h30m ", time.Date(2025, 3, 31, 11, 30, 0, 0, saoPaulo), true},
+		{"1w", base, false},
+		{"xd", base, false},
+	}
+
+	for _, tt := range tests {
+		shifted, ok := applyDateOffset(base, tt.offset)
+		assert.Equal(t, tt.ok, ok, tt.offset)
+		assert.True(t, tt.expected.Equal(shifted), tt.offset)
+	}
+}
+
 func TestPassesFilter(t *testing.T) {
 	t.Parallel()
 
@@ -713,6 +713,61 @@ func TestBuildDynamicFilters(t *testing.T) {
 	}
 }
 
+// --------------------------------------------------------------------------
+// Pure function tests: buildDistinctValuesQuery
+// --------------------------------------------------------------------------
+
+func TestBuildDistinctValuesQuery(t *testing.T) {
+	t.Parallel()
+
+	ds := &ExternalDataSource{}
+
+	schema := []TableSchema{
+		{
+			SchemaName: "public",
+			TableName:  "accounts",
+			Columns: []ColumnInformation{
+				{Name: "id", DataType: "varchar"},
+				{Name: "status", DataType: "varchar"},
+			},
+		},
+	}
+
+	tests := []struct {
+		name      string
+		filter    map[string]model.FilterCondition
+		wantQuery string
+		wantArgs  []any
+	}{
+		{
+			name:      "without_filters",
+			wantQuery: "SELECT DISTINCT id FROM \"public\".\"accounts\" WHERE id IS NOT NULL ORDER BY id LIMIT 1001",
+		},
+		{
+			name:      "with_filters",
+			filter:    map[string]model.FilterCondition{"status": {Equals: []any{"active"}}},
+			wantQuery: "SELECT DISTINCT id FROM \"public\".\"accounts\" WHERE id IS NOT NULL AND status = $1 ORDER BY id LIMIT 1001",
+			wantArgs:  []any{"active"},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			builder, err := ds.buildDistinctValuesQuery(schema, qualifyTableName("public", "accounts"), "accounts", "id", tt.filter, 1001)
+			require.NoError(t, err)
+
+			query, args, err := builder.ToSql()
+			require.NoError(t, err)
+
+			assert.Equal(t, tt.wantQuery, query)
+			assert.Equal(t, tt.wantArgs, args)
+		})
+	}
+}
+
 // --------------------------------------------------------------------------
 // Pure function tests: applyFilter
 // --------------------------------------------------------------------------
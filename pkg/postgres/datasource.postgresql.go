@@ -29,6 +29,7 @@ import (
 type Repository interface {
 	Query(ctx context.Context, schema []TableSchema, schemaName string, table string, fields []string, filter map[string][]any) ([]map[string]any, error)
 	QueryWithAdvancedFilters(ctx context.Context, schema []TableSchema, schemaName string, table string, fields []string, filter map[string]model.FilterCondition) ([]map[string]any, error)
+	QueryDistinctValues(ctx context.Context, schema []TableSchema, schemaName string, table string, field string, filter map[string]model.FilterCondition, limit int) ([]any, error)
 	GetDatabaseSchema(ctx context.Context, schemas []string) ([]TableSchema, error)
 	CloseConnection() error
 }
@@ -676,6 +677,92 @@ func (ds *ExternalDataSource) QueryWithAdvancedFilters(ctx context.Context, sche
 	return scanRows(rows, logger)
 }
 
+// QueryDistinctValues executes a SELECT DISTINCT SQL query returning the non-null values of a field that match
+// the advanced filters, in ascending order and at most limit of them. Nested JSONB paths are not supported.
+func (ds *ExternalDataSource) QueryDistinctValues(ctx context.Context, schema []TableSchema, schemaName string, table string, field string, filter map[string]model.FilterCondition, limit int) ([]any, error) {
+	logger, tracer, reqId, _ := libCommons.NewTrackingFromContext(ctx)
+
+	ctx, span := tracer.Start(ctx, "repository.datasource.query_distinct_values")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("app.request.request_id", reqId),
+	)
+
+	err := libOpentelemetry.SetSpanAttributesFromStruct(&span, "app.request.repository_filter", map[string]any{
+		"schema": schemaName,
+		"table":  table,
+		"field":  field,
+		"filter": filter,
+		"limit":  limit,
+	})
+	if err != nil {
+		libOpentelemetry.HandleSpanError(&span, "Failed to convert repository filter to JSON string", err)
+	}
+
+	qualifiedTable := qualifyTableName(schemaName, table)
+	logger.Infof("Querying distinct values of %s in %s table", field, qualifiedTable)
+
+	if _, err := ds.ValidateTableAndFields(ctx, table, []string{field}, schema); err != nil {
+		return nil, err
+	}
+
+	if extractRootColumn(field) != field {
+		return nil, fmt.Errorf("distinct values of the nested field '%s' are not supported", field)
+	}
+
+	queryBuilder, err := ds.buildDistinctValuesQuery(schema, qualifiedTable, table, field, filter, limit)
+	if err != nil {
+		return nil, fmt.Errorf("error building advanced filters: %w", err)
+	}
+
+	query, args, err := queryBuilder.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("error generating SQL: %w", err)
+	}
+
+	logger.Infof("Executing SQL: %s with args: %v", query, args)
+
+	queryCtx, cancel := context.WithTimeout(ctx, constant.QueryTimeoutSlow)
+	defer cancel()
+
+	rows, err := ds.connection.ConnectionDB.QueryContext(queryCtx, query, args...)
+	if err != nil {
+		if queryCtx.Err() == context.DeadlineExceeded {
+			return nil, fmt.Errorf("distinct values query timeout after %v: %w", constant.QueryTimeoutSlow, err)
+		}
+
+		return nil, fmt.Errorf("error executing query: %w", err)
+	}
+	defer rows.Close()
+
+	results, err := scanRows(rows, logger)
+	if err != nil {
+		return nil, err
+	}
+
+	values := make([]any, 0, len(results))
+	for _, row := range results {
+		values = append(values, row[field])
+	}
+
+	return values, nil
+}
+
+// buildDistinctValuesQuery builds the SELECT DISTINCT query of QueryDistinctValues. Null values are left out,
+// so they never count against the limit.
+func (ds *ExternalDataSource) buildDistinctValuesQuery(schema []TableSchema, qualifiedTable, table, column string, filter map[string]model.FilterCondition, limit int) (squirrel.SelectBuilder, error) {
+	psql := squirrel.StatementBuilder.PlaceholderFormat(squirrel.Dollar)
+	queryBuilder := psql.Select(column).Distinct().From(qualifiedTable).Where(squirrel.NotEq{column: nil})
+
+	queryBuilder, err := ds.buildAdvancedFilters(queryBuilder, schema, table, filter)
+	if err != nil {
+		return queryBuilder, err
+	}
+
+	return queryBuilder.OrderBy(column).Limit(uint64(limit)), nil
+}
+
 // buildAdvancedFilters applies FilterCondition criteria to the query builder
 func (ds *ExternalDataSource) buildAdvancedFilters(queryBuilder squirrel.SelectBuilder, schema []TableSchema, table string, filter map[string]model.FilterCondition) (squirrel.SelectBuilder, error) {
 	var tableColumns []ColumnInformation
@@ -85,6 +85,21 @@ func (mr *MockRepositoryMockRecorder) Query(ctx, schema, schemaName, table, fiel
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Query", reflect.TypeOf((*MockRepository)(nil).Query), ctx, schema, schemaName, table, fields, filter)
 }
 
+// QueryDistinctValues mocks base method.
+func (m *MockRepository) QueryDistinctValues(ctx context.Context, schema []TableSchema, schemaName, table, field string, filter map[string]model.FilterCondition, limit int) ([]any, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "QueryDistinctValues", ctx, schema, schemaName, table, field, filter, limit)
+	ret0, _ := ret[0].([]any)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// QueryDistinctValues indicates an expected call of QueryDistinctValues.
+func (mr *MockRepositoryMockRecorder) QueryDistinctValues(ctx, schema, schemaName, table, field, filter, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "QueryDistinctValues", reflect.TypeOf((*MockRepository)(nil).QueryDistinctValues), ctx, schema, schemaName, table, field, filter, limit)
+}
+
 // QueryWithAdvancedFilters mocks base method.
 func (m *MockRepository) QueryWithAdvancedFilters(ctx context.Context, schema []TableSchema, schemaName, table string, fields []string, filter map[string]model.FilterCondition) ([]map[string]any, error) {
 	m.ctrl.T.Helper()
@@ -0,0 +1,27 @@
+// Copyright (c) 2026 Lerian Studio. All rights reserved.
+// Use of this source code is governed by the Elastic License 2.0
+// that can be found in the LICENSE file.
+
+package rabbitmq
+
+import (
+	"context"
+
+	"github.com/LerianStudio/reporter/pkg/model"
+
+	"github.com/google/uuid"
+)
+
+// DeadLetterRepository manages the report generation messages in the dead letter queue.
+//
+//go:generate mockgen --destination=dead-letter.mock.go --package=rabbitmq --copyright_file=../../COPYRIGHT . DeadLetterRepository
+type DeadLetterRepository interface {
+	// Peek returns up to count messages of the dead letter queue, leaving them in the queue, and its depth.
+	Peek(ctx context.Context, count int) ([]model.DeadLetterMessage, int, error)
+	// Replay publishes back to the generation exchange the message of each report found among the first
+	// count messages, removes it from the dead letter queue and returns the reports replayed.
+	// Further messages of a replayed report are removed without being published.
+	Replay(ctx context.Context, reportIDs []uuid.UUID, count int) ([]uuid.UUID, error)
+	// Purge removes every message of the dead letter queue and returns how many were removed.
+	Purge(ctx context.Context) (int, error)
+}
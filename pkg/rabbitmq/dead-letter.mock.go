@@ -0,0 +1,94 @@
+// // Copyright (c) 2026 Lerian Studio. All rights reserved.
+// // Use of this source code is governed by the Elastic License 2.0
+// // that can be found in the LICENSE file.
+//
+
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/LerianStudio/reporter/pkg/rabbitmq (interfaces: DeadLetterRepository)
+//
+// Generated by this command:
+//
+//	mockgen --destination=dead-letter.mock.go --package=rabbitmq --copyright_file=../../COPYRIGHT . DeadLetterRepository
+//
+
+// Package rabbitmq is a generated GoMock package.
+package rabbitmq
+
+import (
+	context "context"
+	reflect "reflect"
+
+	model "github.com/LerianStudio/reporter/pkg/model"
+	uuid "github.com/google/uuid"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockDeadLetterRepository is a mock of DeadLetterRepository interface.
+type MockDeadLetterRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockDeadLetterRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockDeadLetterRepositoryMockRecorder is the mock recorder for MockDeadLetterRepository.
+type MockDeadLetterRepositoryMockRecorder struct {
+	mock *MockDeadLetterRepository
+}
+
+// NewMockDeadLetterRepository creates a new mock instance.
+func NewMockDeadLetterRepository(ctrl *gomock.Controller) *MockDeadLetterRepository {
+	mock := &MockDeadLetterRepository{ctrl: ctrl}
+	mock.recorder = &MockDeadLetterRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockDeadLetterRepository) EXPECT() *MockDeadLetterRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Peek mocks base method.
+func (m *MockDeadLetterRepository) Peek(ctx context.Context, count int) ([]model.DeadLetterMessage, int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Peek", ctx, count)
+	ret0, _ := ret[0].([]model.DeadLetterMessage)
+	ret1, _ := ret[1].(int)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// Peek indicates an expected call of Peek.
+func (mr *MockDeadLetterRepositoryMockRecorder) Peek(ctx, count any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Peek", reflect.TypeOf((*MockDeadLetterRepository)(nil).Peek), ctx, count)
+}
+
+// Purge mocks base method.
+func (m *MockDeadLetterRepository) Purge(ctx context.Context) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Purge", ctx)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Purge indicates an expected call of Purge.
+func (mr *MockDeadLetterRepositoryMockRecorder) Purge(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Purge", reflect.TypeOf((*MockDeadLetterRepository)(nil).Purge), ctx)
+}
+
+// Replay mocks base method.
+func (m *MockDeadLetterRepository) Replay(ctx context.Context, reportIDs []uuid.UUID, count int) ([]uuid.UUID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Replay", ctx, reportIDs, count)
+	ret0, _ := ret[0].([]uuid.UUID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Replay indicates an expected call of Replay.
+func (mr *MockDeadLetterRepositoryMockRecorder) Replay(ctx, reportIDs, count any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Replay", reflect.TypeOf((*MockDeadLetterRepository)(nil).Replay), ctx, reportIDs, count)
+}
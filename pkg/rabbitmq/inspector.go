@@ -0,0 +1,17 @@
+// Copyright (c) 2026 Lerian Studio. All rights reserved.
+// Use of this source code is governed by the Elastic License 2.0
+// that can be found in the LICENSE file.
+
+package rabbitmq
+
+import "context"
+
+// QueueInspector provides a read-only view of the queues of the broker.
+//
+//go:generate mockgen --destination=inspector.mock.go --package=rabbitmq --copyright_file=../../COPYRIGHT . QueueInspector
+type QueueInspector interface {
+	// QueueDepth returns the number of messages of a queue, counting both ready and unacknowledged ones.
+	QueueDepth(ctx context.Context, queue string) (int, error)
+	// PeekMessages returns the bodies of up to count messages of a queue, leaving them in the queue.
+	PeekMessages(ctx context.Context, queue string, count int) ([][]byte, error)
+}
@@ -0,0 +1,76 @@
+// // Copyright (c) 2026 Lerian Studio. All rights reserved.
+// // Use of this source code is governed by the Elastic License 2.0
+// // that can be found in the LICENSE file.
+//
+
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/LerianStudio/reporter/pkg/rabbitmq (interfaces: QueueInspector)
+//
+// Generated by this command:
+//
+//	mockgen --destination=inspector.mock.go --package=rabbitmq --copyright_file=../../COPYRIGHT . QueueInspector
+//
+
+// Package rabbitmq is a generated GoMock package.
+package rabbitmq
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockQueueInspector is a mock of QueueInspector interface.
+type MockQueueInspector struct {
+	ctrl     *gomock.Controller
+	recorder *MockQueueInspectorMockRecorder
+	isgomock struct{}
+}
+
+// MockQueueInspectorMockRecorder is the mock recorder for MockQueueInspector.
+type MockQueueInspectorMockRecorder struct {
+	mock *MockQueueInspector
+}
+
+// NewMockQueueInspector creates a new mock instance.
+func NewMockQueueInspector(ctrl *gomock.Controller) *MockQueueInspector {
+	mock := &MockQueueInspector{ctrl: ctrl}
+	mock.recorder = &MockQueueInspectorMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockQueueInspector) EXPECT() *MockQueueInspectorMockRecorder {
+	return m.recorder
+}
+
+// PeekMessages mocks base method.
+func (m *MockQueueInspector) PeekMessages(ctx context.Context, queue string, count int) ([][]byte, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PeekMessages", ctx, queue, count)
+	ret0, _ := ret[0].([][]byte)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PeekMessages indicates an expected call of PeekMessages.
+func (mr *MockQueueInspectorMockRecorder) PeekMessages(ctx, queue, count any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PeekMessages", reflect.TypeOf((*MockQueueInspector)(nil).PeekMessages), ctx, queue, count)
+}
+
+// QueueDepth mocks base method.
+func (m *MockQueueInspector) QueueDepth(ctx context.Context, queue string) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "QueueDepth", ctx, queue)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// QueueDepth indicates an expected call of QueueDepth.
+func (mr *MockQueueInspectorMockRecorder) QueueDepth(ctx, queue any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "QueueDepth", reflect.TypeOf((*MockQueueInspector)(nil).QueueDepth), ctx, queue)
+}
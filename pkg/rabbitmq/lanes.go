@@ -0,0 +1,33 @@
+// Copyright (c) 2026 Lerian Studio. All rights reserved.
+// Use of this source code is governed by the Elastic License 2.0
+// that can be found in the LICENSE file.
+
+package rabbitmq
+
+// GenerationLane is the queue report generation messages of a priority are routed to, and its routing key.
+type GenerationLane struct {
+	Queue      string
+	RoutingKey string
+}
+
+// GenerationLanes maps report priorities to the lanes configured for them.
+// Priorities without a lane are routed to the generation queue.
+type GenerationLanes map[string]GenerationLane
+
+// RoutingKey returns the routing key of the lane of a priority, or defaultKey when it has no lane.
+func (l GenerationLanes) RoutingKey(priority, defaultKey string) string {
+	if lane, ok := l[priority]; ok {
+		return lane.RoutingKey
+	}
+
+	return defaultKey
+}
+
+// Queue returns the queue of the lane of a priority, or defaultQueue when it has no lane.
+func (l GenerationLanes) Queue(priority, defaultQueue string) string {
+	if lane, ok := l[priority]; ok {
+		return lane.Queue
+	}
+
+	return defaultQueue
+}
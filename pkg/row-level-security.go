@@ -0,0 +1,419 @@
+// Copyright (c) 2026 Lerian Studio. All rights reserved.
+// Use of this source code is governed by the Elastic License 2.0
+// that can be found in the LICENSE file.
+
+package pkg
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/LerianStudio/reporter/pkg/constant"
+	"github.com/LerianStudio/reporter/pkg/model"
+
+	"github.com/LerianStudio/lib-commons/v2/commons/log"
+)
+
+// RowLevelRule maps an auth claim to a mandatory equality predicate on a table field.
+// Every report that queries the table is restricted to rows where Field equals the claim value.
+type RowLevelRule struct {
+	// DataSource is the datasource ID (DATASOURCE_{NAME}_CONFIG_NAME) the rule belongs to.
+	DataSource string
+
+	// Table is the table or collection the rule applies to, optionally schema qualified.
+	// RowFilterAnyTable applies the rule to every table queried on the datasource.
+	Table string
+
+	// Field is the column or document field compared against the claim value.
+	Field string
+
+	// Claim is the access token claim holding the caller's value, e.g. "owner".
+	// Nested claims are addressed with dot notation, e.g. "tenant.id".
+	Claim string
+}
+
+// RowLevelPolicy holds the row-level rules of every datasource.
+// A nil or empty policy enforces nothing.
+type RowLevelPolicy struct {
+	rules map[string][]RowLevelRule
+
+	// signingKey is the key shared by the manager and the worker to sign the scope and filters of a report.
+	signingKey []byte
+}
+
+// NewRowLevelPolicy creates a RowLevelPolicy from the given rules.
+func NewRowLevelPolicy(rules []RowLevelRule) *RowLevelPolicy {
+	policy := &RowLevelPolicy{rules: make(map[string][]RowLevelRule)}
+
+	for _, rule := range rules {
+		policy.rules[rule.DataSource] = append(policy.rules[rule.DataSource], rule)
+	}
+
+	return policy
+}
+
+// WithSigningKey sets the key the scope and filters of a report are signed with, shared by the manager
+// and the worker, and returns the policy.
+func (p *RowLevelPolicy) WithSigningKey(key string) *RowLevelPolicy {
+	p.signingKey = []byte(key)
+
+	return p
+}
+
+// LoadRowLevelPolicy reads the DATASOURCE_{NAME}_ROW_FILTERS environment variables of every
+// configured datasource and builds the policy. A malformed rule is a fatal configuration error.
+func LoadRowLevelPolicy(logger log.Logger) (*RowLevelPolicy, error) {
+	var rules []RowLevelRule
+
+	for name := range collectDataSourceNames() {
+		raw := getDataSourceEnv(name, constant.RowFiltersEnvField)
+		if raw == "" {
+			continue
+		}
+
+		configName := getDataSourceEnv(name, "CONFIG_NAME")
+
+		dataSourceRules, err := ParseRowLevelRules(configName, raw)
+		if err != nil {
+			return nil, err
+		}
+
+		logger.Infof("Loaded %d row-level filter rules for datasource '%s'", len(dataSourceRules), configName)
+
+		rules = append(rules, dataSourceRules...)
+	}
+
+	return NewRowLevelPolicy(rules), nil
+}
+
+// ParseRowLevelRules parses the "table:field=claim" entries of a datasource, separated by commas.
+func ParseRowLevelRules(dataSource, raw string) ([]RowLevelRule, error) {
+	var rules []RowLevelRule
+
+	for _, entry := range strings.Split(raw, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+
+		target, claim, found := strings.Cut(entry, "=")
+		if !found {
+			return nil, fmt.Errorf("invalid row filter %q for datasource %s: expected table:field=claim", entry, dataSource)
+		}
+
+		table, field, found := strings.Cut(target, ":")
+		if !found {
+			return nil, fmt.Errorf("invalid row filter %q for datasource %s: expected table:field=claim", entry, dataSource)
+		}
+
+		rule := RowLevelRule{
+			DataSource: dataSource,
+			Table:      strings.TrimSpace(table),
+			Field:      strings.TrimSpace(field),
+			Claim:      strings.TrimSpace(claim),
+		}
+
+		if rule.Table == "" || rule.Field == "" || rule.Claim == "" {
+			return nil, fmt.Errorf("invalid row filter %q for datasource %s: table, field and claim must not be empty", entry, dataSource)
+		}
+
+		rules = append(rules, rule)
+	}
+
+	return rules, nil
+}
+
+// Enabled reports whether the policy has at least one rule.
+func (p *RowLevelPolicy) Enabled() bool {
+	return p != nil && len(p.rules) > 0
+}
+
+// ResolveScope extracts the values of every claim referenced by the policy from the token claims.
+// Claims that are absent are left out of the scope; Apply reports them when a rule needs them.
+func (p *RowLevelPolicy) ResolveScope(claims map[string]any) map[string]string {
+	if !p.Enabled() {
+		return nil
+	}
+
+	scope := make(map[string]string)
+
+	for _, rules := range p.rules {
+		for _, rule := range rules {
+			if value, ok := lookupClaim(claims, rule.Claim); ok {
+				scope[rule.Claim] = value
+			}
+		}
+	}
+
+	return scope
+}
+
+// Apply returns a copy of filters with the mandatory predicate of every rule matching a table in
+// mappedFields. A mandatory predicate replaces any condition the caller sent for the same field,
+// so a request can never widen the rows it is allowed to read. Filters sent under another key naming
+// a scoped table, e.g. "public.account" for "account", are moved to the key of mappedFields first.
+func (p *RowLevelPolicy) Apply(
+	scope map[string]string,
+	mappedFields map[string]map[string][]string,
+	filters map[string]map[string]map[string]model.FilterCondition,
+) (map[string]map[string]map[string]model.FilterCondition, error) {
+	result := copyFilters(filters)
+
+	if !p.Enabled() {
+		return result, nil
+	}
+
+	for _, dataSource := range sortedKeys(mappedFields) {
+		for _, tableKey := range sortedKeys(mappedFields[dataSource]) {
+			rules := p.rulesFor(dataSource, tableKey)
+			if len(rules) == 0 {
+				continue
+			}
+
+			if result == nil {
+				result = make(map[string]map[string]map[string]model.FilterCondition)
+			}
+
+			if result[dataSource] == nil {
+				result[dataSource] = make(map[string]map[string]model.FilterCondition)
+			}
+
+			fields := mergeTableFilters(result[dataSource], mappedFields[dataSource], tableKey)
+
+			for _, rule := range rules {
+				value, ok := scope[rule.Claim]
+				if !ok || value == "" {
+					return nil, ValidateBusinessError(constant.ErrMissingAuthClaim, constant.MongoCollectionReport, rule.Claim, dataSource)
+				}
+
+				fields[rule.Field] = model.FilterCondition{Equals: []any{value}}
+			}
+		}
+	}
+
+	return result, nil
+}
+
+// Sign returns the signature of the scope, mapped fields and filters of a report, sent along with them so
+// the worker can check they are the ones the manager resolved. It is empty when the policy enforces nothing.
+func (p *RowLevelPolicy) Sign(
+	scope map[string]string,
+	mappedFields map[string]map[string][]string,
+	filters map[string]map[string]map[string]model.FilterCondition,
+) (string, error) {
+	if !p.Enabled() {
+		return "", nil
+	}
+
+	payload, err := json.Marshal(struct {
+		Scope        map[string]string                                      `json:"scope"`
+		MappedFields map[string]map[string][]string                         `json:"mappedFields"`
+		Filters      map[string]map[string]map[string]model.FilterCondition `json:"filters"`
+	}{scope, mappedFields, filters})
+	if err != nil {
+		return "", fmt.Errorf("failed to encode row-level scope: %w", err)
+	}
+
+	mac := hmac.New(sha256.New, p.signingKey)
+	mac.Write(payload)
+
+	return hex.EncodeToString(mac.Sum(nil)), nil
+}
+
+// Verify checks that the scope, mapped fields and filters carry the signature of the manager and that filters
+// carry the mandatory predicate of every rule matching a table in mappedFields, using the scope resolved when
+// the report was requested.
+func (p *RowLevelPolicy) Verify(
+	scope map[string]string,
+	signature string,
+	mappedFields map[string]map[string][]string,
+	filters map[string]map[string]map[string]model.FilterCondition,
+) error {
+	if !p.Enabled() {
+		return nil
+	}
+
+	expected, err := p.Sign(scope, mappedFields, filters)
+	if err != nil {
+		return err
+	}
+
+	if !hmac.Equal([]byte(expected), []byte(signature)) {
+		return ValidateBusinessError(constant.ErrInvalidRowLevelSignature, constant.MongoCollectionReport)
+	}
+
+	for _, dataSource := range sortedKeys(mappedFields) {
+		for _, tableKey := range sortedKeys(mappedFields[dataSource]) {
+			for _, rule := range p.rulesFor(dataSource, tableKey) {
+				value, ok := scope[rule.Claim]
+				if !ok || value == "" {
+					return ValidateBusinessError(constant.ErrMissingAuthClaim, constant.MongoCollectionReport, rule.Claim, dataSource)
+				}
+
+				condition, exists := filters[dataSource][tableKey][rule.Field]
+				if !exists || !isMandatoryCondition(condition, value) {
+					return ValidateBusinessError(constant.ErrRowLevelFilterViolation, constant.MongoCollectionReport, rule.Field, tableKey, dataSource)
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+// rulesFor returns the rules of a datasource that apply to the given table key.
+func (p *RowLevelPolicy) rulesFor(dataSource, tableKey string) []RowLevelRule {
+	var matched []RowLevelRule
+
+	for _, rule := range p.rules[dataSource] {
+		if rowLevelTableMatches(rule.Table, tableKey) {
+			matched = append(matched, rule)
+		}
+	}
+
+	return matched
+}
+
+// rowLevelTableMatches compares a rule table with a mapped table key.
+func rowLevelTableMatches(ruleTable, tableKey string) bool {
+	return ruleTable == constant.RowFilterAnyTable || sameTable(ruleTable, tableKey)
+}
+
+// sameTable reports whether two table keys name the same table.
+// Both accept the "schema.table" and "schema__table" formats; when either side omits the
+// schema only the table names are compared, so a rule errs on the side of filtering more.
+func sameTable(a, b string) bool {
+	schemaA, nameA := splitQualifiedTable(a)
+	schemaB, nameB := splitQualifiedTable(b)
+
+	if nameA != nameB {
+		return false
+	}
+
+	return schemaA == "" || schemaB == "" || schemaA == schemaB
+}
+
+// mergeTableFilters moves the filters of a datasource sent under another key naming the same table as
+// tableKey to tableKey and returns them. Keys of other mapped tables are left in place. The conditions
+// sent under tableKey win over the ones sent under the other keys.
+func mergeTableFilters(
+	tables map[string]map[string]model.FilterCondition,
+	mappedTables map[string][]string,
+	tableKey string,
+) map[string]model.FilterCondition {
+	fields := make(map[string]model.FilterCondition)
+
+	for _, key := range sortedKeys(tables) {
+		if _, mapped := mappedTables[key]; mapped || !sameTable(key, tableKey) {
+			continue
+		}
+
+		for field, condition := range tables[key] {
+			fields[field] = condition
+		}
+
+		delete(tables, key)
+	}
+
+	for field, condition := range tables[tableKey] {
+		fields[field] = condition
+	}
+
+	tables[tableKey] = fields
+
+	return fields
+}
+
+// splitQualifiedTable splits "schema.table" or "schema__table" into its schema and table parts.
+func splitQualifiedTable(table string) (string, string) {
+	if schema, name, found := strings.Cut(table, "__"); found {
+		return schema, name
+	}
+
+	if schema, name, found := strings.Cut(table, "."); found {
+		return schema, name
+	}
+
+	return "", table
+}
+
+// isMandatoryCondition reports whether condition is exactly the equality predicate on value.
+func isMandatoryCondition(condition model.FilterCondition, value string) bool {
+	if len(condition.Equals) != 1 || fmt.Sprint(condition.Equals[0]) != value {
+		return false
+	}
+
+	return len(condition.GreaterThan) == 0 &&
+		len(condition.GreaterOrEqual) == 0 &&
+		len(condition.LessThan) == 0 &&
+		len(condition.LessOrEqual) == 0 &&
+		len(condition.Between) == 0 &&
+		len(condition.In) == 0 &&
+		len(condition.NotIn) == 0
+}
+
+// lookupClaim resolves a claim by name, following dot notation into nested objects.
+func lookupClaim(claims map[string]any, name string) (string, bool) {
+	var current any = claims
+
+	for _, part := range strings.Split(name, ".") {
+		object, ok := current.(map[string]any)
+		if !ok {
+			return "", false
+		}
+
+		current, ok = object[part]
+		if !ok || current == nil {
+			return "", false
+		}
+	}
+
+	switch value := current.(type) {
+	case string:
+		return value, value != ""
+	case map[string]any, []any:
+		return "", false
+	default:
+		return fmt.Sprint(value), true
+	}
+}
+
+// copyFilters returns a deep copy of the filter map so the caller's input is never mutated.
+func copyFilters(filters map[string]map[string]map[string]model.FilterCondition) map[string]map[string]map[string]model.FilterCondition {
+	if filters == nil {
+		return nil
+	}
+
+	result := make(map[string]map[string]map[string]model.FilterCondition, len(filters))
+
+	for dataSource, tables := range filters {
+		result[dataSource] = make(map[string]map[string]model.FilterCondition, len(tables))
+
+		for table, fields := range tables {
+			result[dataSource][table] = make(map[string]model.FilterCondition, len(fields))
+
+			for field, condition := range fields {
+				result[dataSource][table][field] = condition
+			}
+		}
+	}
+
+	return result
+}
+
+// sortedKeys returns the keys of a map in lexical order so rule evaluation is deterministic.
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+
+	sort.Strings(keys)
+
+	return keys
+}
@@ -0,0 +1,345 @@
+// Copyright (c) 2026 Lerian Studio. All rights reserved.
+// Use of this source code is governed by the Elastic License 2.0
+// that can be found in the LICENSE file.
+
+package pkg
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/LerianStudio/reporter/pkg/constant"
+	"github.com/LerianStudio/reporter/pkg/model"
+
+	libCommons "github.com/LerianStudio/lib-commons/v2/commons"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseRowLevelRules(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		raw         string
+		expected    []RowLevelRule
+		expectError bool
+	}{
+		{
+			name: "single rule",
+			raw:  "account:organization_id=owner",
+			expected: []RowLevelRule{
+				{DataSource: "midaz_onboarding", Table: "account", Field: "organization_id", Claim: "owner"},
+			},
+		},
+		{
+			name: "multiple rules with wildcard and spaces",
+			raw:  " account:organization_id=owner , *:tenant_id=tenant.id ",
+			expected: []RowLevelRule{
+				{DataSource: "midaz_onboarding", Table: "account", Field: "organization_id", Claim: "owner"},
+				{DataSource: "midaz_onboarding", Table: "*", Field: "tenant_id", Claim: "tenant.id"},
+			},
+		},
+		{
+			name:     "empty value yields no rules",
+			raw:      "",
+			expected: nil,
+		},
+		{
+			name:        "missing claim separator",
+			raw:         "account:organization_id",
+			expectError: true,
+		},
+		{
+			name:        "missing field separator",
+			raw:         "account=owner",
+			expectError: true,
+		},
+		{
+			name:        "empty field",
+			raw:         "account:=owner",
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			rules, err := ParseRowLevelRules("midaz_onboarding", tt.raw)
+			if tt.expectError {
+				require.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, rules)
+		})
+	}
+}
+
+func TestLoadRowLevelPolicy(t *testing.T) {
+	// Note: Cannot use t.Parallel() because t.Setenv is used
+	t.Setenv("DATASOURCE_ONBOARDING_CONFIG_NAME", "midaz_onboarding")
+	t.Setenv("DATASOURCE_ONBOARDING_ROW_FILTERS", "account:organization_id=owner")
+
+	logger, _, _, _ := libCommons.NewTrackingFromContext(context.Background())
+
+	policy, err := LoadRowLevelPolicy(logger)
+	require.NoError(t, err)
+	assert.True(t, policy.Enabled())
+
+	t.Setenv("DATASOURCE_ONBOARDING_ROW_FILTERS", "account")
+
+	_, err = LoadRowLevelPolicy(logger)
+	require.Error(t, err)
+}
+
+func TestRowLevelPolicy_ResolveScope(t *testing.T) {
+	t.Parallel()
+
+	policy := NewRowLevelPolicy([]RowLevelRule{
+		{DataSource: "ds", Table: "*", Field: "organization_id", Claim: "owner"},
+		{DataSource: "ds", Table: "*", Field: "tenant_id", Claim: "tenant.id"},
+		{DataSource: "ds", Table: "*", Field: "region", Claim: "region"},
+	})
+
+	scope := policy.ResolveScope(map[string]any{
+		"owner":  "org-1",
+		"tenant": map[string]any{"id": float64(42)},
+	})
+
+	assert.Equal(t, map[string]string{"owner": "org-1", "tenant.id": "42"}, scope)
+
+	var disabled *RowLevelPolicy
+	assert.Nil(t, disabled.ResolveScope(map[string]any{"owner": "org-1"}))
+}
+
+func TestRowLevelPolicy_Apply(t *testing.T) {
+	t.Parallel()
+
+	policy := NewRowLevelPolicy([]RowLevelRule{
+		{DataSource: "midaz_onboarding", Table: "account", Field: "organization_id", Claim: "owner"},
+	})
+
+	mappedFields := map[string]map[string][]string{
+		"midaz_onboarding": {
+			"public__account":      {"id", "name"},
+			"public__organization": {"id"},
+		},
+	}
+
+	t.Run("injects predicate when caller sent no filters", func(t *testing.T) {
+		t.Parallel()
+
+		filters, err := policy.Apply(map[string]string{"owner": "org-1"}, mappedFields, nil)
+		require.NoError(t, err)
+
+		assert.Equal(t, map[string]map[string]map[string]model.FilterCondition{
+			"midaz_onboarding": {
+				"public__account": {"organization_id": {Equals: []any{"org-1"}}},
+			},
+		}, filters)
+	})
+
+	t.Run("replaces a caller condition on the scoped field", func(t *testing.T) {
+		t.Parallel()
+
+		input := map[string]map[string]map[string]model.FilterCondition{
+			"midaz_onboarding": {
+				"public__account": {
+					"organization_id": {In: []any{"org-1", "org-2"}},
+					"status":          {Equals: []any{"active"}},
+				},
+			},
+		}
+
+		filters, err := policy.Apply(map[string]string{"owner": "org-1"}, mappedFields, input)
+		require.NoError(t, err)
+
+		assert.Equal(t, model.FilterCondition{Equals: []any{"org-1"}}, filters["midaz_onboarding"]["public__account"]["organization_id"])
+		assert.Equal(t, model.FilterCondition{Equals: []any{"active"}}, filters["midaz_onboarding"]["public__account"]["status"])
+		assert.Equal(t, model.FilterCondition{In: []any{"org-1", "org-2"}}, input["midaz_onboarding"]["public__account"]["organization_id"],
+			"input filters must not be mutated")
+	})
+
+	t.Run("constrains caller filters sent under a schema-qualified key", func(t *testing.T) {
+		t.Parallel()
+
+		scoped := map[string]map[string][]string{
+			"midaz_onboarding": {"account": {"id"}, "public__organization": {"id"}},
+		}
+		input := map[string]map[string]map[string]model.FilterCondition{
+			"midaz_onboarding": {
+				"public.account": {
+					"organization_id": {In: []any{"org-1", "org-2"}},
+					"status":          {Equals: []any{"active"}},
+				},
+				"public__organization": {"id": {Equals: []any{"org-1"}}},
+			},
+		}
+
+		filters, err := policy.Apply(map[string]string{"owner": "org-1"}, scoped, input)
+		require.NoError(t, err)
+
+		assert.Equal(t, map[string]map[string]map[string]model.FilterCondition{
+			"midaz_onboarding": {
+				"account": {
+					"organization_id": {Equals: []any{"org-1"}},
+					"status":          {Equals: []any{"active"}},
+				},
+				"public__organization": {"id": {Equals: []any{"org-1"}}},
+			},
+		}, filters)
+		assert.Contains(t, input["midaz_onboarding"], "public.account", "input filters must not be mutated")
+	})
+
+	t.Run("missing claim is rejected", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := policy.Apply(map[string]string{}, mappedFields, nil)
+		require.Error(t, err)
+
+		var forbidden ForbiddenError
+		require.True(t, errors.As(err, &forbidden))
+		assert.Equal(t, constant.ErrMissingAuthClaim.Error(), forbidden.Code)
+	})
+
+	t.Run("datasource without rules is untouched", func(t *testing.T) {
+		t.Parallel()
+
+		filters, err := policy.Apply(nil, map[string]map[string][]string{"other": {"account": {"id"}}}, nil)
+		require.NoError(t, err)
+		assert.Nil(t, filters)
+	})
+}
+
+func TestRowLevelPolicy_Verify(t *testing.T) {
+	t.Parallel()
+
+	policy := NewRowLevelPolicy([]RowLevelRule{
+		{DataSource: "midaz_onboarding", Table: "public.account", Field: "organization_id", Claim: "owner"},
+	}).WithSigningKey("test-signing-key")
+
+	mappedFields := map[string]map[string][]string{
+		"midaz_onboarding": {"account": {"id"}},
+	}
+	scope := map[string]string{"owner": "org-1"}
+
+	tests := []struct {
+		name               string
+		scope              map[string]string
+		filters            map[string]map[string]map[string]model.FilterCondition
+		signedScope        map[string]string
+		signedMappedFields map[string]map[string][]string
+		unsigned           bool
+		expectedCode       string
+	}{
+		{
+			name:  "mandatory predicate present",
+			scope: scope,
+			filters: map[string]map[string]map[string]model.FilterCondition{
+				"midaz_onboarding": {"account": {"organization_id": {Equals: []any{"org-1"}}}},
+			},
+		},
+		{
+			name:         "predicate missing",
+			scope:        scope,
+			filters:      nil,
+			expectedCode: constant.ErrRowLevelFilterViolation.Error(),
+		},
+		{
+			name:  "predicate widened with another operator",
+			scope: scope,
+			filters: map[string]map[string]map[string]model.FilterCondition{
+				"midaz_onboarding": {"account": {"organization_id": {Equals: []any{"org-1"}, NotIn: []any{"org-3"}}}},
+			},
+			expectedCode: constant.ErrRowLevelFilterViolation.Error(),
+		},
+		{
+			name:  "predicate for another tenant",
+			scope: scope,
+			filters: map[string]map[string]map[string]model.FilterCondition{
+				"midaz_onboarding": {"account": {"organization_id": {Equals: []any{"org-2"}}}},
+			},
+			expectedCode: constant.ErrRowLevelFilterViolation.Error(),
+		},
+		{
+			name:  "scope changed after signing",
+			scope: map[string]string{"owner": "org-2"},
+			filters: map[string]map[string]map[string]model.FilterCondition{
+				"midaz_onboarding": {"account": {"organization_id": {Equals: []any{"org-2"}}}},
+			},
+			signedScope:  scope,
+			expectedCode: constant.ErrInvalidRowLevelSignature.Error(),
+		},
+		{
+			name:  "mapped fields changed after signing",
+			scope: scope,
+			filters: map[string]map[string]map[string]model.FilterCondition{
+				"midaz_onboarding": {"account": {"organization_id": {Equals: []any{"org-1"}}}},
+			},
+			signedMappedFields: map[string]map[string][]string{"midaz_onboarding": {"account": {"name"}}},
+			expectedCode:       constant.ErrInvalidRowLevelSignature.Error(),
+		},
+		{
+			name:  "signature missing",
+			scope: scope,
+			filters: map[string]map[string]map[string]model.FilterCondition{
+				"midaz_onboarding": {"account": {"organization_id": {Equals: []any{"org-1"}}}},
+			},
+			unsigned:     true,
+			expectedCode: constant.ErrInvalidRowLevelSignature.Error(),
+		},
+		{
+			name:         "scope missing",
+			scope:        nil,
+			expectedCode: constant.ErrMissingAuthClaim.Error(),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			signedScope := tt.scope
+			if tt.signedScope != nil {
+				signedScope = tt.signedScope
+			}
+
+			signedMappedFields := mappedFields
+			if tt.signedMappedFields != nil {
+				signedMappedFields = tt.signedMappedFields
+			}
+
+			signature, err := policy.Sign(signedScope, signedMappedFields, tt.filters)
+			require.NoError(t, err)
+
+			if tt.unsigned {
+				signature = ""
+			}
+
+			err = policy.Verify(tt.scope, signature, mappedFields, tt.filters)
+			if tt.expectedCode == "" {
+				require.NoError(t, err)
+				return
+			}
+
+			var forbidden ForbiddenError
+			require.True(t, errors.As(err, &forbidden))
+			assert.Equal(t, tt.expectedCode, forbidden.Code)
+		})
+	}
+}
+
+func TestRowLevelTableMatches(t *testing.T) {
+	t.Parallel()
+
+	assert.True(t, rowLevelTableMatches("*", "public__account"))
+	assert.True(t, rowLevelTableMatches("account", "public__account"))
+	assert.True(t, rowLevelTableMatches("public.account", "public__account"))
+	assert.True(t, rowLevelTableMatches("public__account", "account"))
+	assert.False(t, rowLevelTableMatches("audit.account", "public__account"))
+	assert.False(t, rowLevelTableMatches("account", "public__account_history"))
+}
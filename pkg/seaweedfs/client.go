@@ -91,6 +91,68 @@ func (c *SeaweedFSClient) DownloadFile(ctx context.Context, path string) ([]byte
 	return data, nil
 }
 
+// FileSize returns the size in bytes of a file in SeaweedFS
+func (c *SeaweedFSClient) FileSize(ctx context.Context, path string) (int64, error) {
+	url := fmt.Sprintf("%s%s", c.baseURL, path)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodHead, url, nil)
+	if err != nil {
+		return 0, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return 0, fmt.Errorf("failed to read file size: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return 0, fmt.Errorf("size check failed with status %d", resp.StatusCode)
+	}
+
+	if resp.ContentLength < 0 {
+		return 0, fmt.Errorf("size check returned no content length")
+	}
+
+	return resp.ContentLength, nil
+}
+
+// DownloadFileRange streams length bytes of a file from SeaweedFS, starting at offset.
+// The caller must close the returned ReadCloser.
+func (c *SeaweedFSClient) DownloadFileRange(ctx context.Context, path string, offset, length int64) (io.ReadCloser, error) {
+	url := fmt.Sprintf("%s%s", c.baseURL, path)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	req.Header.Set("Range", fmt.Sprintf("bytes=%d-%d", offset, offset+length-1))
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to download file: %w", err)
+	}
+
+	// A server ignoring the range answers the whole file, which is only usable from its start
+	if resp.StatusCode == http.StatusOK && offset == 0 {
+		return struct {
+			io.Reader
+			io.Closer
+		}{io.LimitReader(resp.Body, length), resp.Body}, nil
+	}
+
+	if resp.StatusCode != http.StatusPartialContent {
+		defer resp.Body.Close()
+
+		body, _ := io.ReadAll(resp.Body)
+
+		return nil, fmt.Errorf("download failed with status %d: %s", resp.StatusCode, string(body))
+	}
+
+	return resp.Body, nil
+}
+
 // DeleteFile deletes a file from SeaweedFS
 func (c *SeaweedFSClient) DeleteFile(ctx context.Context, path string) error {
 	url := fmt.Sprintf("%s%s", c.baseURL, path)
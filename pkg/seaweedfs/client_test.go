@@ -6,6 +6,7 @@ package seaweedfs
 
 import (
 	"context"
+	"fmt"
 	"io"
 	"net/http"
 	"net/http/httptest"
@@ -211,6 +212,90 @@ func TestSeaweedFSClient_DownloadFile_Error(t *testing.T) {
 	assert.Contains(t, err.Error(), "download failed")
 }
 
+func TestSeaweedFSClient_FileSize(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodHead, r.Method)
+
+		if r.URL.Path == "/bucket/missing.txt" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		w.Header().Set("Content-Length", "23")
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	client := NewSeaweedFSClient(server.URL)
+
+	size, err := client.FileSize(context.Background(), "/bucket/file.txt")
+	require.NoError(t, err)
+	assert.Equal(t, int64(23), size)
+
+	_, err = client.FileSize(context.Background(), "/bucket/missing.txt")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "status 404")
+}
+
+func TestSeaweedFSClient_DownloadFileRange(t *testing.T) {
+	t.Parallel()
+
+	content := "downloaded file content"
+
+	tests := []struct {
+		name         string
+		offset       int64
+		length       int64
+		ignoreRange  bool
+		expectedBody string
+		expectErr    bool
+	}{
+		{name: "Partial content", offset: 11, length: 4, expectedBody: "file"},
+		{name: "Whole file from a server ignoring the range", offset: 0, length: 10, ignoreRange: true, expectedBody: "downloaded"},
+		{name: "Whole file from a server ignoring a range past the start", offset: 11, length: 4, ignoreRange: true, expectErr: true},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if tt.ignoreRange {
+					w.WriteHeader(http.StatusOK)
+					w.Write([]byte(content))
+
+					return
+				}
+
+				assert.Equal(t, fmt.Sprintf("bytes=%d-%d", tt.offset, tt.offset+tt.length-1), r.Header.Get("Range"))
+				w.WriteHeader(http.StatusPartialContent)
+				w.Write([]byte(content[tt.offset : tt.offset+tt.length]))
+			}))
+			defer server.Close()
+
+			client := NewSeaweedFSClient(server.URL)
+
+			reader, err := client.DownloadFileRange(context.Background(), "/bucket/file.txt", tt.offset, tt.length)
+			if tt.expectErr {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), "download failed")
+
+				return
+			}
+
+			require.NoError(t, err)
+			defer reader.Close()
+
+			data, err := io.ReadAll(reader)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedBody, string(data))
+		})
+	}
+}
+
 func TestSeaweedFSClient_DeleteFile(t *testing.T) {
 	t.Parallel()
 
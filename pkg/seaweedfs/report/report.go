@@ -7,8 +7,10 @@ package report
 import (
 	"bytes"
 	"context"
+	"errors"
 	"fmt"
 	"io"
+	"time"
 
 	"github.com/LerianStudio/reporter/pkg"
 	"github.com/LerianStudio/reporter/pkg/constant"
@@ -25,6 +27,10 @@ import (
 type Repository interface {
 	Put(ctx context.Context, objectName string, contentType string, data []byte, ttl string) error
 	Get(ctx context.Context, objectName string) ([]byte, error)
+	PresignedURL(ctx context.Context, objectName, fileName string, expiry time.Duration) (string, error)
+	Size(ctx context.Context, objectName string) (int64, error)
+	GetRange(ctx context.Context, objectName string, offset, length int64) (io.ReadCloser, error)
+	Delete(ctx context.Context, objectName string) error
 }
 
 // StorageRepository provides access to object storage for report operations.
@@ -99,3 +105,104 @@ func (repo *StorageRepository) Get(ctx context.Context, objectName string) ([]by
 
 	return data, nil
 }
+
+// PresignedURL creates a download URL of the given object name, valid for expiry, that serves it as an
+// attachment named fileName. It returns constant.ErrPresignNotSupported when the storage cannot sign URLs.
+func (repo *StorageRepository) PresignedURL(ctx context.Context, objectName, fileName string, expiry time.Duration) (string, error) {
+	logger, tracer, reqId, _ := commons.NewTrackingFromContext(ctx)
+
+	ctx, span := tracer.Start(ctx, "repository.report_storage.presigned_url")
+	defer span.End()
+
+	span.SetAttributes(attribute.String("app.request.request_id", reqId))
+
+	// Add reports prefix
+	key := fmt.Sprintf("reports/%s", objectName)
+
+	logger.Infof("Presigning report download from storage: %s", key)
+
+	url, err := repo.storage.GeneratePresignedDownloadURL(ctx, key, fileName, expiry)
+	if err != nil {
+		if errors.Is(err, constant.ErrPresignNotSupported) {
+			return "", err
+		}
+
+		libOpentelemetry.HandleSpanError(&span, "Failed to presign report download", err)
+
+		return "", pkg.ValidateBusinessError(constant.ErrCommunicateSeaweedFS, "")
+	}
+
+	return url, nil
+}
+
+// Size returns the size in bytes of the given object name
+func (repo *StorageRepository) Size(ctx context.Context, objectName string) (int64, error) {
+	logger, tracer, reqId, _ := commons.NewTrackingFromContext(ctx)
+
+	ctx, span := tracer.Start(ctx, "repository.report_storage.size")
+	defer span.End()
+
+	span.SetAttributes(attribute.String("app.request.request_id", reqId))
+
+	// Add reports prefix
+	key := fmt.Sprintf("reports/%s", objectName)
+
+	logger.Infof("Reading report size from storage: %s", key)
+
+	size, err := repo.storage.Size(ctx, key)
+	if err != nil {
+		libOpentelemetry.HandleSpanError(&span, "Failed to read report size", err)
+
+		return 0, pkg.ValidateBusinessError(constant.ErrCommunicateSeaweedFS, "")
+	}
+
+	return size, nil
+}
+
+// GetRange streams length bytes of the given object name from storage, starting at offset.
+// The caller must close the returned ReadCloser.
+func (repo *StorageRepository) GetRange(ctx context.Context, objectName string, offset, length int64) (io.ReadCloser, error) {
+	logger, tracer, reqId, _ := commons.NewTrackingFromContext(ctx)
+
+	ctx, span := tracer.Start(ctx, "repository.report_storage.get_range")
+	defer span.End()
+
+	span.SetAttributes(attribute.String("app.request.request_id", reqId))
+
+	// Add reports prefix
+	key := fmt.Sprintf("reports/%s", objectName)
+
+	logger.Infof("Streaming report from storage: %s (offset: %d, length: %d)", key, offset, length)
+
+	reader, err := repo.storage.DownloadRange(ctx, key, offset, length)
+	if err != nil {
+		libOpentelemetry.HandleSpanError(&span, "Failed to stream report from storage", err)
+
+		return nil, pkg.ValidateBusinessError(constant.ErrCommunicateSeaweedFS, "")
+	}
+
+	return reader, nil
+}
+
+// Delete removes the given object name from storage. Deleting a missing object succeeds.
+func (repo *StorageRepository) Delete(ctx context.Context, objectName string) error {
+	logger, tracer, reqId, _ := commons.NewTrackingFromContext(ctx)
+
+	ctx, span := tracer.Start(ctx, "repository.report_storage.delete")
+	defer span.End()
+
+	span.SetAttributes(attribute.String("app.request.request_id", reqId))
+
+	// Add reports prefix
+	key := fmt.Sprintf("reports/%s", objectName)
+
+	logger.Infof("Deleting report from storage: %s", key)
+
+	if err := repo.storage.Delete(ctx, key); err != nil {
+		libOpentelemetry.HandleSpanError(&span, "Failed to delete report from storage", err)
+
+		return pkg.ValidateBusinessError(constant.ErrCommunicateSeaweedFS, "")
+	}
+
+	return nil
+}
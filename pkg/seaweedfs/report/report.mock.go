@@ -11,7 +11,9 @@ package report
 
 import (
 	context "context"
+	io "io"
 	reflect "reflect"
+	time "time"
 
 	gomock "go.uber.org/mock/gomock"
 )
@@ -40,6 +42,20 @@ func (m *MockRepository) EXPECT() *MockRepositoryMockRecorder {
 	return m.recorder
 }
 
+// Delete mocks base method.
+func (m *MockRepository) Delete(ctx context.Context, objectName string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, objectName)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockRepositoryMockRecorder) Delete(ctx, objectName any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockRepository)(nil).Delete), ctx, objectName)
+}
+
 // Get mocks base method.
 func (m *MockRepository) Get(ctx context.Context, objectName string) ([]byte, error) {
 	m.ctrl.T.Helper()
@@ -55,6 +71,36 @@ func (mr *MockRepositoryMockRecorder) Get(ctx, objectName any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockRepository)(nil).Get), ctx, objectName)
 }
 
+// GetRange mocks base method.
+func (m *MockRepository) GetRange(ctx context.Context, objectName string, offset, length int64) (io.ReadCloser, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRange", ctx, objectName, offset, length)
+	ret0, _ := ret[0].(io.ReadCloser)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRange indicates an expected call of GetRange.
+func (mr *MockRepositoryMockRecorder) GetRange(ctx, objectName, offset, length any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRange", reflect.TypeOf((*MockRepository)(nil).GetRange), ctx, objectName, offset, length)
+}
+
+// PresignedURL mocks base method.
+func (m *MockRepository) PresignedURL(ctx context.Context, objectName, fileName string, expiry time.Duration) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PresignedURL", ctx, objectName, fileName, expiry)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PresignedURL indicates an expected call of PresignedURL.
+func (mr *MockRepositoryMockRecorder) PresignedURL(ctx, objectName, fileName, expiry any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PresignedURL", reflect.TypeOf((*MockRepository)(nil).PresignedURL), ctx, objectName, fileName, expiry)
+}
+
 // Put mocks base method.
 func (m *MockRepository) Put(ctx context.Context, objectName, contentType string, data []byte, ttl string) error {
 	m.ctrl.T.Helper()
@@ -68,3 +114,18 @@ func (mr *MockRepositoryMockRecorder) Put(ctx, objectName, contentType, data, tt
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Put", reflect.TypeOf((*MockRepository)(nil).Put), ctx, objectName, contentType, data, ttl)
 }
+
+// Size mocks base method.
+func (m *MockRepository) Size(ctx context.Context, objectName string) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Size", ctx, objectName)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Size indicates an expected call of Size.
+func (mr *MockRepositoryMockRecorder) Size(ctx, objectName any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Size", reflect.TypeOf((*MockRepository)(nil).Size), ctx, objectName)
+}
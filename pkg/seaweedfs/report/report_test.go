@@ -10,7 +10,9 @@ import (
 	"errors"
 	"io"
 	"testing"
+	"time"
 
+	"github.com/LerianStudio/reporter/pkg/constant"
 	"github.com/LerianStudio/reporter/pkg/storage"
 
 	"github.com/stretchr/testify/assert"
@@ -111,3 +113,119 @@ func TestStorageRepository_Get_Error(t *testing.T) {
 	_, err := repo.Get(context.Background(), "obj.txt")
 	require.Error(t, err)
 }
+
+func TestStorageRepository_PresignedURL_Success(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStorage := storage.NewMockObjectStorage(ctrl)
+	repo := NewStorageRepository(mockStorage)
+
+	mockStorage.EXPECT().
+		GeneratePresignedDownloadURL(gomock.Any(), "reports/obj.pdf", "report.pdf", time.Minute).
+		Return("https://storage/reports/obj.pdf?sig=1", nil)
+
+	url, err := repo.PresignedURL(context.Background(), "obj.pdf", "report.pdf", time.Minute)
+	require.NoError(t, err)
+	assert.Equal(t, "https://storage/reports/obj.pdf?sig=1", url)
+}
+
+func TestStorageRepository_PresignedURL_NotSupported(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStorage := storage.NewMockObjectStorage(ctrl)
+	repo := NewStorageRepository(mockStorage)
+
+	mockStorage.EXPECT().
+		GeneratePresignedDownloadURL(gomock.Any(), "reports/obj.pdf", "report.pdf", time.Minute).
+		Return("", storage.ErrPresignNotSupported)
+
+	_, err := repo.PresignedURL(context.Background(), "obj.pdf", "report.pdf", time.Minute)
+	assert.ErrorIs(t, err, constant.ErrPresignNotSupported)
+}
+
+func TestStorageRepository_PresignedURL_Error(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStorage := storage.NewMockObjectStorage(ctrl)
+	repo := NewStorageRepository(mockStorage)
+
+	mockStorage.EXPECT().
+		GeneratePresignedDownloadURL(gomock.Any(), "reports/obj.pdf", "report.pdf", time.Minute).
+		Return("", errors.New("presign failed"))
+
+	_, err := repo.PresignedURL(context.Background(), "obj.pdf", "report.pdf", time.Minute)
+	require.Error(t, err)
+	assert.NotErrorIs(t, err, constant.ErrPresignNotSupported)
+}
+
+func TestStorageRepository_Size(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStorage := storage.NewMockObjectStorage(ctrl)
+	repo := NewStorageRepository(mockStorage)
+
+	mockStorage.EXPECT().Size(gomock.Any(), "reports/obj.pdf").Return(int64(42), nil)
+	mockStorage.EXPECT().Size(gomock.Any(), "reports/missing.pdf").Return(int64(0), errors.New("size failed"))
+
+	size, err := repo.Size(context.Background(), "obj.pdf")
+	require.NoError(t, err)
+	assert.Equal(t, int64(42), size)
+
+	_, err = repo.Size(context.Background(), "missing.pdf")
+	require.Error(t, err)
+}
+
+func TestStorageRepository_GetRange(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStorage := storage.NewMockObjectStorage(ctrl)
+	repo := NewStorageRepository(mockStorage)
+
+	mockStorage.EXPECT().
+		DownloadRange(gomock.Any(), "reports/obj.pdf", int64(2), int64(3)).
+		Return(io.NopCloser(bytes.NewReader([]byte("rld"))), nil)
+	mockStorage.EXPECT().
+		DownloadRange(gomock.Any(), "reports/missing.pdf", int64(0), int64(3)).
+		Return(nil, errors.New("download failed"))
+
+	reader, err := repo.GetRange(context.Background(), "obj.pdf", 2, 3)
+	require.NoError(t, err)
+
+	data, err := io.ReadAll(reader)
+	require.NoError(t, err)
+	assert.Equal(t, "rld", string(data))
+
+	_, err = repo.GetRange(context.Background(), "missing.pdf", 0, 3)
+	require.Error(t, err)
+}
+
+func TestStorageRepository_Delete(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStorage := storage.NewMockObjectStorage(ctrl)
+	repo := NewStorageRepository(mockStorage)
+
+	mockStorage.EXPECT().Delete(gomock.Any(), "reports/obj.pdf").Return(nil)
+	mockStorage.EXPECT().Delete(gomock.Any(), "reports/broken.pdf").Return(errors.New("delete failed"))
+
+	require.NoError(t, repo.Delete(context.Background(), "obj.pdf"))
+	require.Error(t, repo.Delete(context.Background(), "broken.pdf"))
+}
@@ -0,0 +1,118 @@
+// Copyright (c) 2026 Lerian Studio. All rights reserved.
+// Use of this source code is governed by the Elastic License 2.0
+// that can be found in the LICENSE file.
+
+package signature
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"software.sslmate.com/src/go-pkcs12"
+)
+
+// Signer signs report outputs with a certificate and its private key. CMS signatures carry the
+// certificate and its chain, so they can be verified without access to the signer's configuration.
+type Signer struct {
+	certificate *x509.Certificate
+	chain       []*x509.Certificate
+	key         crypto.Signer
+}
+
+// NewSigner creates a Signer from a certificate, its RSA or ECDSA private key and the certificates of its chain.
+func NewSigner(certificate *x509.Certificate, key crypto.PrivateKey, chain []*x509.Certificate) (*Signer, error) {
+	if certificate == nil {
+		return nil, errors.New("a signing certificate is required")
+	}
+
+	switch key.(type) {
+	case *rsa.PrivateKey, *ecdsa.PrivateKey:
+	default:
+		return nil, fmt.Errorf("unsupported signing key type %T, use an RSA or ECDSA key", key)
+	}
+
+	signer := key.(crypto.Signer)
+
+	publicKey, ok := certificate.PublicKey.(interface{ Equal(crypto.PublicKey) bool })
+	if !ok || !publicKey.Equal(signer.Public()) {
+		return nil, errors.New("the signing key does not match the certificate")
+	}
+
+	return &Signer{certificate: certificate, chain: chain, key: signer}, nil
+}
+
+// LoadPKCS12 creates a Signer from a PKCS#12 (.p12/.pfx) file holding the certificate, its key and chain.
+func LoadPKCS12(data []byte, password string) (*Signer, error) {
+	key, certificate, chain, err := pkcs12.DecodeChain(data, password)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode the PKCS#12 signing certificate: %w", err)
+	}
+
+	return NewSigner(certificate, key, chain)
+}
+
+// LoadSigner loads the signing certificate configured for the worker, from a PKCS#12 file path or
+// from its base64 content, as injected by secret providers. Without either, signing is disabled and
+// no Signer is returned.
+func LoadSigner(path, content, password string) (*Signer, error) {
+	var data []byte
+
+	switch {
+	case path != "" && content != "":
+		return nil, errors.New("set either the signing certificate path or its content, not both")
+	case path != "":
+		fileData, err := os.ReadFile(filepath.Clean(path))
+		if err != nil {
+			return nil, fmt.Errorf("failed to read the signing certificate: %w", err)
+		}
+
+		data = fileData
+	case content != "":
+		decoded, err := base64.StdEncoding.DecodeString(string(bytes.TrimSpace([]byte(content))))
+		if err != nil {
+			return nil, fmt.Errorf("the signing certificate content is not valid base64: %w", err)
+		}
+
+		data = decoded
+	default:
+		return nil, nil
+	}
+
+	return LoadPKCS12(data, password)
+}
+
+// Certificate returns the signing certificate.
+func (s *Signer) Certificate() *x509.Certificate {
+	return s.certificate
+}
+
+// SignDetached returns a detached CMS (PKCS#7) signature of the content, as stored in .p7s files.
+// The signed attributes include the signing time and the signing certificate (CAdES-BES).
+func (s *Signer) SignDetached(content []byte) ([]byte, error) {
+	return s.signCMS(content, true)
+}
+
+// SignPAdES returns the CMS signature embedded in a PDF signature dictionary for the signed byte
+// ranges of the document. PAdES signatures carry the signing time in the dictionary instead.
+func (s *Signer) SignPAdES(content []byte) ([]byte, error) {
+	return s.signCMS(content, false)
+}
+
+// SignatureSize returns an upper bound of the size of the CMS signatures of the signer, used to
+// reserve room for the signature in PDF documents.
+func (s *Signer) SignatureSize() int {
+	size := len(s.certificate.Raw)
+	for _, certificate := range s.chain {
+		size += len(certificate.Raw)
+	}
+
+	return size + cmsOverheadBytes
+}
@@ -0,0 +1,191 @@
+// Copyright (c) 2026 Lerian Studio. All rights reserved.
+// Use of this source code is governed by the Elastic License 2.0
+// that can be found in the LICENSE file.
+
+package signature
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/hhrutter/pkcs7"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"software.sslmate.com/src/go-pkcs12"
+)
+
+// selfSigned returns a self-signed certificate of the key, valid for a day.
+func selfSigned(t *testing.T, key crypto.Signer) *x509.Certificate {
+	t.Helper()
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(42),
+		Subject:      pkix.Name{CommonName: "Reporter", Organization: []string{"Lerian Studio"}},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(24 * time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, template, key.Public(), key)
+	require.NoError(t, err)
+
+	certificate, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+
+	return certificate
+}
+
+func TestLoadSigner(t *testing.T) {
+	t.Parallel()
+
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	certificate := selfSigned(t, key)
+
+	p12, err := pkcs12.Modern.Encode(key, certificate, nil, "secret")
+	require.NoError(t, err)
+
+	path := filepath.Join(t.TempDir(), "signing.p12")
+	require.NoError(t, os.WriteFile(path, p12, 0o600))
+
+	content := base64.StdEncoding.EncodeToString(p12)
+
+	tests := []struct {
+		name       string
+		path       string
+		content    string
+		password   string
+		wantSigner bool
+		wantErr    string
+	}{
+		{name: "signing disabled"},
+		{name: "from file", path: path, password: "secret", wantSigner: true},
+		{name: "from base64 content", content: content + "\n", password: "secret", wantSigner: true},
+		{name: "path and content", path: path, content: content, wantErr: "not both"},
+		{name: "missing file", path: filepath.Join(t.TempDir(), "missing.p12"), wantErr: "failed to read"},
+		{name: "invalid base64", content: "not base64!", wantErr: "not valid base64"},
+		{name: "wrong password", path: path, password: "wrong", wantErr: "failed to decode"},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			signer, err := LoadSigner(tt.path, tt.content, tt.password)
+			if tt.wantErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErr)
+
+				return
+			}
+
+			require.NoError(t, err)
+
+			if !tt.wantSigner {
+				assert.Nil(t, signer)
+
+				return
+			}
+
+			require.NotNil(t, signer)
+			assert.Equal(t, certificate.Raw, signer.Certificate().Raw)
+		})
+	}
+}
+
+func TestNewSigner_Errors(t *testing.T) {
+	t.Parallel()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	other, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	_, edKey, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	certificate := selfSigned(t, key)
+
+	tests := []struct {
+		name        string
+		certificate *x509.Certificate
+		key         crypto.PrivateKey
+		wantErr     string
+	}{
+		{name: "missing certificate", key: key, wantErr: "certificate is required"},
+		{name: "unsupported key", certificate: certificate, key: edKey, wantErr: "unsupported signing key"},
+		{name: "key of another certificate", certificate: certificate, key: other, wantErr: "does not match"},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := NewSigner(tt.certificate, tt.key, nil)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
+
+func TestSigner_SignDetached(t *testing.T) {
+	t.Parallel()
+
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	ecdsaKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name string
+		key  crypto.Signer
+	}{
+		{name: "rsa", key: rsaKey},
+		{name: "ecdsa", key: ecdsaKey},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			signer, err := NewSigner(selfSigned(t, tt.key), tt.key, nil)
+			require.NoError(t, err)
+
+			content := []byte("id;amount\n1;10.00\n")
+
+			cms, err := signer.SignDetached(content)
+			require.NoError(t, err)
+			assert.LessOrEqual(t, len(cms), signer.SignatureSize())
+
+			p7, err := pkcs7.Parse(cms)
+			require.NoError(t, err)
+			require.Len(t, p7.Signers, 1)
+			assert.Equal(t, signer.Certificate().Raw, p7.GetOnlySigner().Raw)
+
+			p7.Content = content
+			require.NoError(t, p7.Verify())
+
+			// The signature does not verify other content
+			p7.Content = []byte("id;amount\n1;99.00\n")
+			require.Error(t, p7.Verify())
+		})
+	}
+}
@@ -38,4 +38,15 @@ type ObjectStorage interface {
 	// GeneratePresignedURL creates a time-limited download URL.
 	// Note: Not all storage backends support presigned URLs (e.g., SeaweedFS HTTP mode)
 	GeneratePresignedURL(ctx context.Context, key string, expiry time.Duration) (string, error)
+
+	// GeneratePresignedDownloadURL creates a time-limited download URL that serves the object as an attachment
+	// named fileName. Backends that cannot sign URLs return ErrPresignNotSupported.
+	GeneratePresignedDownloadURL(ctx context.Context, key, fileName string, expiry time.Duration) (string, error)
+
+	// Size returns the size in bytes of the object at the given key.
+	Size(ctx context.Context, key string) (int64, error)
+
+	// DownloadRange retrieves length bytes of the object at the given key, starting at offset.
+	// The caller must close the returned ReadCloser.
+	DownloadRange(ctx context.Context, key string, offset, length int64) (io.ReadCloser, error)
 }
@@ -71,6 +71,21 @@ func (mr *MockObjectStorageMockRecorder) Download(ctx, key any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Download", reflect.TypeOf((*MockObjectStorage)(nil).Download), ctx, key)
 }
 
+// DownloadRange mocks base method.
+func (m *MockObjectStorage) DownloadRange(ctx context.Context, key string, offset, length int64) (io.ReadCloser, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DownloadRange", ctx, key, offset, length)
+	ret0, _ := ret[0].(io.ReadCloser)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DownloadRange indicates an expected call of DownloadRange.
+func (mr *MockObjectStorageMockRecorder) DownloadRange(ctx, key, offset, length any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DownloadRange", reflect.TypeOf((*MockObjectStorage)(nil).DownloadRange), ctx, key, offset, length)
+}
+
 // Exists mocks base method.
 func (m *MockObjectStorage) Exists(ctx context.Context, key string) (bool, error) {
 	m.ctrl.T.Helper()
@@ -86,6 +101,21 @@ func (mr *MockObjectStorageMockRecorder) Exists(ctx, key any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Exists", reflect.TypeOf((*MockObjectStorage)(nil).Exists), ctx, key)
 }
 
+// GeneratePresignedDownloadURL mocks base method.
+func (m *MockObjectStorage) GeneratePresignedDownloadURL(ctx context.Context, key, fileName string, expiry time.Duration) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GeneratePresignedDownloadURL", ctx, key, fileName, expiry)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GeneratePresignedDownloadURL indicates an expected call of GeneratePresignedDownloadURL.
+func (mr *MockObjectStorageMockRecorder) GeneratePresignedDownloadURL(ctx, key, fileName, expiry any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GeneratePresignedDownloadURL", reflect.TypeOf((*MockObjectStorage)(nil).GeneratePresignedDownloadURL), ctx, key, fileName, expiry)
+}
+
 // GeneratePresignedURL mocks base method.
 func (m *MockObjectStorage) GeneratePresignedURL(ctx context.Context, key string, expiry time.Duration) (string, error) {
 	m.ctrl.T.Helper()
@@ -101,6 +131,21 @@ func (mr *MockObjectStorageMockRecorder) GeneratePresignedURL(ctx, key, expiry a
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GeneratePresignedURL", reflect.TypeOf((*MockObjectStorage)(nil).GeneratePresignedURL), ctx, key, expiry)
 }
 
+// Size mocks base method.
+func (m *MockObjectStorage) Size(ctx context.Context, key string) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Size", ctx, key)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Size indicates an expected call of Size.
+func (mr *MockObjectStorageMockRecorder) Size(ctx, key any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Size", reflect.TypeOf((*MockObjectStorage)(nil).Size), ctx, key)
+}
+
 // Upload mocks base method.
 func (m *MockObjectStorage) Upload(ctx context.Context, key string, reader io.Reader, contentType string) (string, error) {
 	m.ctrl.T.Helper()
@@ -11,6 +11,7 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"mime"
 	"time"
 
 	libCommons "github.com/LerianStudio/lib-commons/v2/commons"
@@ -61,6 +62,10 @@ var (
 	ErrObjectNotFound = constant.ErrObjectNotFound
 	// ErrTTLNotSupported indicates TTL is not supported by S3 (use lifecycle policies instead).
 	ErrTTLNotSupported = constant.ErrTTLNotSupported
+	// ErrPresignNotSupported indicates the backend cannot create presigned URLs.
+	ErrPresignNotSupported = constant.ErrPresignNotSupported
+	// ErrInvalidRange indicates a byte range outside of an object.
+	ErrInvalidRange = errors.New("invalid byte range")
 )
 
 // NewS3Client creates a new S3 client with the given configuration.
@@ -262,6 +267,120 @@ func (client *S3Client) GeneratePresignedURL(ctx context.Context, key string, ex
 	return result.URL, nil
 }
 
+// GeneratePresignedDownloadURL creates a time-limited download URL that serves the object as an attachment
+// named fileName.
+func (client *S3Client) GeneratePresignedDownloadURL(ctx context.Context, key, fileName string, expiry time.Duration) (string, error) {
+	logger, tracer, _, _ := libCommons.NewTrackingFromContext(ctx)
+	ctx, span := tracer.Start(ctx, "repository.storage.generate_presigned_download_url")
+
+	defer span.End()
+
+	if key == "" {
+		return "", ErrKeyRequired
+	}
+
+	presigner := s3.NewPresignClient(client.s3)
+
+	input := &s3.GetObjectInput{
+		Bucket:                     aws.String(client.bucket),
+		Key:                        aws.String(key),
+		ResponseContentDisposition: aws.String(mime.FormatMediaType("attachment", map[string]string{"filename": fileName})),
+	}
+
+	result, err := presigner.PresignGetObject(ctx, input, s3.WithPresignExpires(expiry))
+	if err != nil {
+		libOpentelemetry.HandleSpanError(&span, "failed to generate presigned download url", err)
+
+		if logger != nil {
+			logger.Errorf("failed to generate presigned download url for %s: %v", key, err)
+		}
+
+		return "", fmt.Errorf("generating presigned download url: %w", err)
+	}
+
+	return result.URL, nil
+}
+
+// Size returns the size in bytes of the object at the given key.
+func (client *S3Client) Size(ctx context.Context, key string) (int64, error) {
+	logger, tracer, _, _ := libCommons.NewTrackingFromContext(ctx)
+	ctx, span := tracer.Start(ctx, "repository.storage.size")
+
+	defer span.End()
+
+	if key == "" {
+		return 0, ErrKeyRequired
+	}
+
+	input := &s3.HeadObjectInput{
+		Bucket: aws.String(client.bucket),
+		Key:    aws.String(key),
+	}
+
+	result, err := client.s3.HeadObject(ctx, input)
+	if err != nil {
+		var nsk *types.NoSuchKey
+		if errors.As(err, &nsk) {
+			return 0, ErrObjectNotFound
+		}
+
+		var notFound *types.NotFound
+		if errors.As(err, &notFound) {
+			return 0, ErrObjectNotFound
+		}
+
+		libOpentelemetry.HandleSpanError(&span, "failed to read object size", err)
+
+		if logger != nil {
+			logger.Errorf("failed to read size of %s: %v", key, err)
+		}
+
+		return 0, fmt.Errorf("reading object size: %w", err)
+	}
+
+	return aws.ToInt64(result.ContentLength), nil
+}
+
+// DownloadRange retrieves length bytes of the object at the given key, starting at offset.
+func (client *S3Client) DownloadRange(ctx context.Context, key string, offset, length int64) (io.ReadCloser, error) {
+	logger, tracer, _, _ := libCommons.NewTrackingFromContext(ctx)
+	ctx, span := tracer.Start(ctx, "repository.storage.download_range")
+
+	defer span.End()
+
+	if key == "" {
+		return nil, ErrKeyRequired
+	}
+
+	if offset < 0 || length <= 0 {
+		return nil, ErrInvalidRange
+	}
+
+	input := &s3.GetObjectInput{
+		Bucket: aws.String(client.bucket),
+		Key:    aws.String(key),
+		Range:  aws.String(fmt.Sprintf("bytes=%d-%d", offset, offset+length-1)),
+	}
+
+	result, err := client.s3.GetObject(ctx, input)
+	if err != nil {
+		var nsk *types.NoSuchKey
+		if errors.As(err, &nsk) {
+			return nil, ErrObjectNotFound
+		}
+
+		libOpentelemetry.HandleSpanError(&span, "failed to download object range", err)
+
+		if logger != nil {
+			logger.Errorf("failed to download range of object %s: %v", key, err)
+		}
+
+		return nil, fmt.Errorf("downloading object range: %w", err)
+	}
+
+	return result.Body, nil
+}
+
 // Exists checks if an object exists at the given key.
 func (client *S3Client) Exists(ctx context.Context, key string) (bool, error) {
 	logger, tracer, _, _ := libCommons.NewTrackingFromContext(ctx)
@@ -183,6 +183,92 @@ func TestS3Client_GeneratePresignedURL_Success(t *testing.T) {
 	assert.Contains(t, url, "test-bucket")
 }
 
+func TestS3Client_GeneratePresignedDownloadURL_Success(t *testing.T) {
+	t.Parallel()
+
+	client := createTestClient(t)
+
+	url, err := client.GeneratePresignedDownloadURL(context.Background(), "reports/test-key.pdf", "report.pdf", 5*time.Minute)
+	require.NoError(t, err)
+	assert.Contains(t, url, "test-bucket/reports/test-key.pdf")
+	assert.Contains(t, url, "response-content-disposition=attachment%3B%20filename%3Dreport.pdf")
+	assert.Contains(t, url, "X-Amz-Expires=300")
+}
+
+func TestS3Client_GeneratePresignedDownloadURLRequiresKey(t *testing.T) {
+	t.Parallel()
+
+	client := createTestClient(t)
+
+	url, err := client.GeneratePresignedDownloadURL(context.Background(), "", "report.pdf", time.Hour)
+	assert.Empty(t, url)
+	assert.Equal(t, ErrKeyRequired, err)
+}
+
+func TestS3Client_Size(t *testing.T) {
+	t.Parallel()
+
+	client, server := createTestClientWithServer(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodHead, r.Method)
+
+		if strings.HasSuffix(r.URL.Path, "/missing.pdf") {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		w.Header().Set("Content-Length", "1234")
+		w.WriteHeader(http.StatusOK)
+	})
+	defer server.Close()
+
+	size, err := client.Size(context.Background(), "report.pdf")
+	require.NoError(t, err)
+	assert.Equal(t, int64(1234), size)
+
+	_, err = client.Size(context.Background(), "missing.pdf")
+	assert.Equal(t, ErrObjectNotFound, err)
+
+	_, err = client.Size(context.Background(), "")
+	assert.Equal(t, ErrKeyRequired, err)
+}
+
+func TestS3Client_DownloadRange(t *testing.T) {
+	t.Parallel()
+
+	client, server := createTestClientWithServer(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodGet, r.Method)
+		assert.Equal(t, "bytes=4-10", r.Header.Get("Range"))
+
+		w.Header().Set("Content-Range", "bytes 4-10/16")
+		w.WriteHeader(http.StatusPartialContent)
+		_, _ = w.Write([]byte("content"))
+	})
+	defer server.Close()
+
+	reader, err := client.DownloadRange(context.Background(), "report.pdf", 4, 7)
+	require.NoError(t, err)
+	defer reader.Close()
+
+	data, err := io.ReadAll(reader)
+	require.NoError(t, err)
+	assert.Equal(t, "content", string(data))
+}
+
+func TestS3Client_DownloadRange_InvalidRange(t *testing.T) {
+	t.Parallel()
+
+	client := createTestClient(t)
+
+	_, err := client.DownloadRange(context.Background(), "report.pdf", -1, 10)
+	assert.Equal(t, ErrInvalidRange, err)
+
+	_, err = client.DownloadRange(context.Background(), "report.pdf", 0, 0)
+	assert.Equal(t, ErrInvalidRange, err)
+
+	_, err = client.DownloadRange(context.Background(), "", 0, 10)
+	assert.Equal(t, ErrKeyRequired, err)
+}
+
 func TestS3Config_WithAllOptions(t *testing.T) {
 	t.Parallel()
 
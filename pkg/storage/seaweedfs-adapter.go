@@ -76,8 +76,12 @@ func (a *SeaweedFSAdapter) Delete(ctx context.Context, key string) error {
 	// Build the full path: /bucket/key
 	path := fmt.Sprintf("/%s/%s", a.bucket, key)
 
-	// Delete from SeaweedFS
-	return a.client.DeleteFile(ctx, path)
+	// Delete from SeaweedFS; a missing file is already deleted, as with S3
+	if err := a.client.DeleteFile(ctx, path); err != nil && !strings.Contains(err.Error(), "status 404") {
+		return err
+	}
+
+	return nil
 }
 
 // Exists checks if an object exists at the given key.
@@ -109,5 +113,31 @@ func (a *SeaweedFSAdapter) GeneratePresignedURL(ctx context.Context, key string,
 	return url, nil
 }
 
+// GeneratePresignedDownloadURL returns ErrPresignNotSupported, as SeaweedFS HTTP mode can neither
+// expire a URL nor set the name of the downloaded file.
+func (a *SeaweedFSAdapter) GeneratePresignedDownloadURL(ctx context.Context, key, fileName string, expiry time.Duration) (string, error) {
+	return "", ErrPresignNotSupported
+}
+
+// Size returns the size in bytes of the object at the given key.
+func (a *SeaweedFSAdapter) Size(ctx context.Context, key string) (int64, error) {
+	// Build the full path: /bucket/key
+	path := fmt.Sprintf("/%s/%s", a.bucket, key)
+
+	return a.client.FileSize(ctx, path)
+}
+
+// DownloadRange retrieves length bytes of the object at the given key, starting at offset.
+func (a *SeaweedFSAdapter) DownloadRange(ctx context.Context, key string, offset, length int64) (io.ReadCloser, error) {
+	if offset < 0 || length <= 0 {
+		return nil, ErrInvalidRange
+	}
+
+	// Build the full path: /bucket/key
+	path := fmt.Sprintf("/%s/%s", a.bucket, key)
+
+	return a.client.DownloadFileRange(ctx, path, offset, length)
+}
+
 // Compile-time interface check.
 var _ ObjectStorage = (*SeaweedFSAdapter)(nil)
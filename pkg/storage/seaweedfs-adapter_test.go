@@ -119,6 +119,21 @@ func TestSeaweedFSAdapter_Delete(t *testing.T) {
 	require.NoError(t, err)
 }
 
+func TestSeaweedFSAdapter_Delete_NotFound(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	client := seaweedfs.NewSeaweedFSClient(server.URL)
+	adapter := NewSeaweedFSAdapter(client, "test-bucket")
+
+	err := adapter.Delete(context.Background(), "missing-key")
+	require.NoError(t, err, "deleting a missing file must succeed like S3")
+}
+
 func TestSeaweedFSAdapter_Exists_True(t *testing.T) {
 	t.Parallel()
 
@@ -167,6 +182,62 @@ func TestSeaweedFSAdapter_GeneratePresignedURL(t *testing.T) {
 	assert.Equal(t, "http://localhost:8888/test-bucket/my-file.pdf", url)
 }
 
+func TestSeaweedFSAdapter_GeneratePresignedDownloadURL_NotSupported(t *testing.T) {
+	t.Parallel()
+
+	client := seaweedfs.NewSeaweedFSClient("http://localhost:8888")
+	adapter := NewSeaweedFSAdapter(client, "test-bucket")
+
+	url, err := adapter.GeneratePresignedDownloadURL(context.Background(), "my-file.pdf", "report.pdf", time.Hour)
+	assert.Empty(t, url)
+	assert.ErrorIs(t, err, ErrPresignNotSupported)
+}
+
+func TestSeaweedFSAdapter_Size(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodHead, r.Method)
+		assert.Equal(t, "/test-bucket/size-key", r.URL.Path)
+		w.Header().Set("Content-Length", "42")
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	client := seaweedfs.NewSeaweedFSClient(server.URL)
+	adapter := NewSeaweedFSAdapter(client, "test-bucket")
+
+	size, err := adapter.Size(context.Background(), "size-key")
+	require.NoError(t, err)
+	assert.Equal(t, int64(42), size)
+}
+
+func TestSeaweedFSAdapter_DownloadRange(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/test-bucket/range-key", r.URL.Path)
+		assert.Equal(t, "bytes=2-5", r.Header.Get("Range"))
+		w.WriteHeader(http.StatusPartialContent)
+		_, _ = w.Write([]byte("2345"))
+	}))
+	defer server.Close()
+
+	client := seaweedfs.NewSeaweedFSClient(server.URL)
+	adapter := NewSeaweedFSAdapter(client, "test-bucket")
+
+	reader, err := adapter.DownloadRange(context.Background(), "range-key", 2, 4)
+	require.NoError(t, err)
+	defer reader.Close()
+
+	data, err := io.ReadAll(reader)
+	require.NoError(t, err)
+	assert.Equal(t, "2345", string(data))
+
+	_, err = adapter.DownloadRange(context.Background(), "range-key", 0, 0)
+	assert.ErrorIs(t, err, ErrInvalidRange)
+}
+
 func TestSeaweedFSAdapter_Upload_Error(t *testing.T) {
 	t.Parallel()
 
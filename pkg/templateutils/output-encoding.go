@@ -0,0 +1,176 @@
+// Copyright (c) 2026 Lerian Studio. All rights reserved.
+// Use of this source code is governed by the Elastic License 2.0
+// that can be found in the LICENSE file.
+
+package templateutils
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+
+	"github.com/LerianStudio/reporter/pkg/constant"
+	"github.com/LerianStudio/reporter/pkg/model"
+
+	"golang.org/x/text/encoding/charmap"
+	"golang.org/x/text/unicode/norm"
+)
+
+// utf8BOM is the byte order mark written at the start of utf-8 files when the template asks for one.
+const utf8BOM = "\uFEFF"
+
+// charmaps maps the single-byte encodings of the output options to their character maps.
+var charmaps = map[string]*charmap.Charmap{
+	constant.EncodingISO88591:    charmap.ISO8859_1,
+	constant.EncodingWindows1252: charmap.Windows1252,
+}
+
+// transliterations holds the substitutes of common characters that have no decomposition into a
+// base letter. Every substitute is a single character, so fixed-width records keep their length.
+var transliterations = map[rune]rune{
+	'\u2002': ' ', '\u2003': ' ', '\u2009': ' ', '\u202F': ' ', // en, em, thin and narrow no-break spaces
+	'\u2010': '-', '\u2011': '-', '\u2012': '-', '\u2013': '-', '\u2014': '-', '\u2212': '-', // hyphens, dashes and minus
+	'\u2018': '\'', '\u2019': '\'', '\u201A': '\'', '\u2032': '\'', // single quotes and prime
+	'\u201C': '"', '\u201D': '"', '\u201E': '"', '\u2033': '"', // double quotes and double prime
+	'\u2022': '*', '\u2026': '.', // bullet and ellipsis
+	'Œ': 'O', 'œ': 'o', 'Ł': 'L', 'ł': 'l', 'Đ': 'D', 'đ': 'd',
+}
+
+// IsTextOutput reports whether reports of the output format are text, written with the output options.
+func IsTextOutput(outputFormat string) bool {
+	return strings.ToLower(outputFormat) != "pdf"
+}
+
+// EncodeOutput writes the rendered output with the output options of the template: line endings are
+// normalized, the trailing newline policy is applied and the text is encoded in the target charset,
+// with a byte order mark when asked. Without options the output is written as utf-8, unchanged.
+func EncodeOutput(output string, options *model.OutputOptions) ([]byte, error) {
+	if options == nil {
+		return []byte(output), nil
+	}
+
+	output = normalizeLineEndings(output, options.LineEnding)
+	output = applyTrailingNewline(output, options.TrailingNewline, options.LineEnding)
+
+	cm, ok := charmaps[options.Charset()]
+	if !ok {
+		if options.BOM {
+			output = utf8BOM + output
+		}
+
+		return []byte(output), nil
+	}
+
+	return encodeCharmap(output, cm, options)
+}
+
+// WithCharset appends the charset of the output options to the content type of a text report.
+// Reports of templates without output options keep their content type unchanged.
+func WithCharset(contentType string, options *model.OutputOptions) string {
+	if options == nil || (!strings.HasPrefix(contentType, "text/") && contentType != "application/xml") {
+		return contentType
+	}
+
+	return contentType + "; charset=" + options.Charset()
+}
+
+// normalizeLineEndings rewrites every "\r\n", "\r" and "\n" as the line ending of the options.
+func normalizeLineEndings(output, lineEnding string) string {
+	var ending string
+
+	switch lineEnding {
+	case constant.LineEndingLF:
+		ending = "\n"
+	case constant.LineEndingCRLF:
+		ending = "\r\n"
+	default:
+		return output
+	}
+
+	output = strings.ReplaceAll(output, "\r\n", "\n")
+	output = strings.ReplaceAll(output, "\r", "\n")
+
+	if ending == "\n" {
+		return output
+	}
+
+	return strings.ReplaceAll(output, "\n", ending)
+}
+
+// applyTrailingNewline ensures or strips the line ending at the end of the output. An ensured line
+// ending follows the line ending of the options, or the first line ending of the output when kept.
+func applyTrailingNewline(output, policy, lineEnding string) string {
+	switch policy {
+	case constant.TrailingNewlineEnsure:
+		if output == "" || strings.HasSuffix(output, "\n") || strings.HasSuffix(output, "\r") {
+			return output
+		}
+
+		ending := "\n"
+		if lineEnding == constant.LineEndingCRLF || (lineEnding != constant.LineEndingLF && strings.Contains(output, "\r\n")) {
+			ending = "\r\n"
+		}
+
+		return output + ending
+	case constant.TrailingNewlineStrip:
+		return strings.TrimRight(output, "\r\n")
+	default:
+		return output
+	}
+}
+
+// encodeCharmap encodes the output in a single-byte charset, handling the characters it cannot
+// represent with the unmappable strategy of the options.
+func encodeCharmap(output string, cm *charmap.Charmap, options *model.OutputOptions) ([]byte, error) {
+	encoded := make([]byte, 0, len(output))
+	line, column := 1, 0
+
+	for _, r := range output {
+		column++
+
+		if r == '\n' {
+			line, column = line+1, 0
+		}
+
+		if b, ok := cm.EncodeRune(r); ok {
+			encoded = append(encoded, b)
+
+			continue
+		}
+
+		switch options.Unmappable {
+		case constant.UnmappableReplace:
+			encoded = append(encoded, constant.UnmappableReplacement)
+		case constant.UnmappableTransliterate:
+			encoded = append(encoded, transliterate(r, cm))
+		default:
+			return nil, fmt.Errorf("character %q (U+%04X) at line %d, column %d cannot be encoded in %s", r, r, line, column, options.Charset())
+		}
+	}
+
+	return encoded, nil
+}
+
+// transliterate returns the byte of a similar character the charset can represent: the base letter
+// of an accented character, a substitute from the transliterations table, or "?".
+func transliterate(r rune, cm *charmap.Charmap) byte {
+	if substitute, ok := transliterations[r]; ok {
+		if b, okSubstitute := cm.EncodeRune(substitute); okSubstitute {
+			return b
+		}
+	}
+
+	for _, d := range norm.NFD.String(string(r)) {
+		if unicode.Is(unicode.Mn, d) {
+			continue
+		}
+
+		if b, ok := cm.EncodeRune(d); ok {
+			return b
+		}
+
+		break
+	}
+
+	return constant.UnmappableReplacement
+}
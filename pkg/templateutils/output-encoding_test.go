@@ -0,0 +1,138 @@
+// Copyright (c) 2026 Lerian Studio. All rights reserved.
+// Use of this source code is governed by the Elastic License 2.0
+// that can be found in the LICENSE file.
+
+package templateutils
+
+import (
+	"testing"
+
+	"github.com/LerianStudio/reporter/pkg/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEncodeOutput(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		output   string
+		options  *model.OutputOptions
+		expected []byte
+	}{
+		{
+			name:     "no options writes utf-8 unchanged",
+			output:   "São Paulo\n",
+			options:  nil,
+			expected: []byte("São Paulo\n"),
+		},
+		{
+			name:     "utf-8 with byte order mark",
+			output:   "a;b",
+			options:  &model.OutputOptions{BOM: true},
+			expected: []byte("\xEF\xBB\xBFa;b"),
+		},
+		{
+			name:     "line endings normalized to crlf",
+			output:   "a\nb\r\nc\rd",
+			options:  &model.OutputOptions{LineEnding: "crlf"},
+			expected: []byte("a\r\nb\r\nc\r\nd"),
+		},
+		{
+			name:     "line endings normalized to lf",
+			output:   "a\r\nb\rc",
+			options:  &model.OutputOptions{LineEnding: "lf"},
+			expected: []byte("a\nb\nc"),
+		},
+		{
+			name:     "trailing newline ensured with the line ending",
+			output:   "a\nb",
+			options:  &model.OutputOptions{LineEnding: "crlf", TrailingNewline: "ensure"},
+			expected: []byte("a\r\nb\r\n"),
+		},
+		{
+			name:     "trailing newline ensured follows kept crlf",
+			output:   "a\r\nb",
+			options:  &model.OutputOptions{TrailingNewline: "ensure"},
+			expected: []byte("a\r\nb\r\n"),
+		},
+		{
+			name:     "trailing newline ensured on empty output",
+			output:   "",
+			options:  &model.OutputOptions{TrailingNewline: "ensure"},
+			expected: []byte(""),
+		},
+		{
+			name:     "trailing newlines stripped",
+			output:   "a\r\n\r\n",
+			options:  &model.OutputOptions{TrailingNewline: "strip"},
+			expected: []byte("a"),
+		},
+		{
+			name:     "iso-8859-1",
+			output:   "Ação",
+			options:  &model.OutputOptions{Encoding: "iso-8859-1"},
+			expected: []byte{'A', 0xE7, 0xE3, 'o'},
+		},
+		{
+			name:     "windows-1252 encodes the euro sign and typographic quotes",
+			output:   "€ “x”",
+			options:  &model.OutputOptions{Encoding: "windows-1252"},
+			expected: []byte{0x80, ' ', 0x93, 'x', 0x94},
+		},
+		{
+			name:     "unmappable characters replaced",
+			output:   "€ Łódź",
+			options:  &model.OutputOptions{Encoding: "iso-8859-1", Unmappable: "replace"},
+			expected: []byte{'?', ' ', '?', 0xF3, 'd', '?'},
+		},
+		{
+			name:     "unmappable characters transliterated",
+			output:   "Łódź – “ok” 日",
+			options:  &model.OutputOptions{Encoding: "iso-8859-1", Unmappable: "transliterate"},
+			expected: []byte{'L', 0xF3, 'd', 'z', ' ', '-', ' ', '"', 'o', 'k', '"', ' ', '?'},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			encoded, err := EncodeOutput(tt.output, tt.options)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, encoded)
+		})
+	}
+}
+
+func TestEncodeOutput_UnmappableFails(t *testing.T) {
+	t.Parallel()
+
+	_, err := EncodeOutput("ok\nnão ő", &model.OutputOptions{Encoding: "windows-1252"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "U+0151")
+	assert.Contains(t, err.Error(), "line 2, column 5")
+	assert.Contains(t, err.Error(), "windows-1252")
+}
+
+func TestWithCharset(t *testing.T) {
+	t.Parallel()
+
+	options := &model.OutputOptions{Encoding: "iso-8859-1"}
+
+	assert.Equal(t, "text/csv", WithCharset("text/csv", nil))
+	assert.Equal(t, "text/csv; charset=iso-8859-1", WithCharset("text/csv", options))
+	assert.Equal(t, "application/xml; charset=iso-8859-1", WithCharset("application/xml", options))
+	assert.Equal(t, "text/plain; charset=utf-8", WithCharset("text/plain", &model.OutputOptions{LineEnding: "crlf"}))
+	assert.Equal(t, "application/pdf", WithCharset("application/pdf", options))
+}
+
+func TestIsTextOutput(t *testing.T) {
+	t.Parallel()
+
+	assert.True(t, IsTextOutput("csv"))
+	assert.True(t, IsTextOutput("fixed-width"))
+	assert.False(t, IsTextOutput("PDF"))
+}
@@ -0,0 +1,153 @@
+// Copyright (c) 2026 Lerian Studio. All rights reserved.
+// Use of this source code is governed by the Elastic License 2.0
+// that can be found in the LICENSE file.
+
+package templateutils
+
+import (
+	"fmt"
+	"path"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/LerianStudio/reporter/pkg/constant"
+)
+
+// partialNamePattern matches partial names made of "/"-separated segments, e.g. "layouts/corporate".
+var partialNamePattern = regexp.MustCompile(`^[A-Za-z0-9_-]+(/[A-Za-z0-9_-]+)*$`)
+
+// partialTagPattern matches include, extends and import tags referencing a partial by a literal name.
+// Names built from variables cannot be resolved before rendering and are left to the renderer.
+var partialTagPattern = regexp.MustCompile(`{%-?\s*(include|extends|import)\s+(?:"([^"]+)"|'([^']+)')[^%]*?-?%}`)
+
+// PartialResolver returns the content of a partial template by its resolved name.
+type PartialResolver func(name string) (string, error)
+
+// PartialError reports a partial referenced by a template that is invalid, missing or cyclic.
+type PartialError struct {
+	// Err is the business error, e.g. constant.ErrPartialNotFound.
+	Err error
+
+	// Name is the resolved name of the offending partial.
+	Name string
+}
+
+// Error implements the error interface.
+func (e *PartialError) Error() string {
+	return fmt.Sprintf("%s: partial %q", e.Err.Error(), e.Name)
+}
+
+// Unwrap returns the business error.
+func (e *PartialError) Unwrap() error {
+	return e.Err
+}
+
+// ValidatePartialName checks that a partial name is made of letters, digits, '-' and '_' in
+// "/"-separated segments and does not exceed constant.MaxPartialNameLength.
+func ValidatePartialName(name string) error {
+	if len(name) > constant.MaxPartialNameLength || !partialNamePattern.MatchString(name) {
+		return constant.ErrInvalidPartialName
+	}
+
+	return nil
+}
+
+// ResolvePartialName resolves the name used in an include, extends or import tag.
+// Names starting with "./" or "../" are relative to the directory of the referencing partial;
+// any other name is absolute. A trailing ".tpl" extension is ignored.
+func ResolvePartialName(base, name string) string {
+	name = strings.TrimSuffix(strings.TrimSpace(name), ".tpl")
+
+	if strings.HasPrefix(name, "./") || strings.HasPrefix(name, "../") {
+		return path.Join(path.Dir(base), name)
+	}
+
+	return name
+}
+
+// ExpandPartials inlines every partial referenced by the template so it can be analyzed as a whole.
+// Included partials replace their tag, so loop and with variables of the including template stay in
+// scope; extended and imported partials are appended to the template.
+func ExpandPartials(templateFile string, resolve PartialResolver) (string, error) {
+	return expandPartials(templateFile, "", resolve, nil)
+}
+
+// ExpandPartial expands the content of the partial stored under name. Relative references are
+// resolved against name and a reference back to name itself is reported as a cycle.
+func ExpandPartial(name, content string, resolve PartialResolver) (string, error) {
+	return expandPartials(content, name, resolve, []string{name})
+}
+
+// expandPartials expands the partials of content, tracking the chain of partials being expanded
+// to detect cycles and excessive nesting.
+func expandPartials(content, base string, resolve PartialResolver, chain []string) (string, error) {
+	if len(chain) > constant.MaxPartialIncludeDepth {
+		return "", &PartialError{Err: constant.ErrPartialIncludeCycle, Name: base}
+	}
+
+	var (
+		appended []string
+		err      error
+	)
+
+	expanded := partialTagPattern.ReplaceAllStringFunc(content, func(tag string) string {
+		if err != nil {
+			return tag
+		}
+
+		match := partialTagPattern.FindStringSubmatch(tag)
+
+		name := ResolvePartialName(base, match[2]+match[3])
+
+		if ValidatePartialName(name) != nil {
+			err = &PartialError{Err: constant.ErrInvalidPartialName, Name: name}
+			return tag
+		}
+
+		if slices.Contains(chain, name) {
+			err = &PartialError{Err: constant.ErrPartialIncludeCycle, Name: name}
+			return tag
+		}
+
+		partial, resolveErr := resolve(name)
+		if resolveErr != nil {
+			err = resolveErr
+			return tag
+		}
+
+		nested, expandErr := expandPartials(partial, name, resolve, append(slices.Clone(chain), name))
+		if expandErr != nil {
+			err = expandErr
+			return tag
+		}
+
+		if match[1] == "include" {
+			return nested
+		}
+
+		appended = append(appended, nested)
+
+		return ""
+	})
+	if err != nil {
+		return "", err
+	}
+
+	if len(appended) == 0 {
+		return expanded, nil
+	}
+
+	return expanded + "\n" + strings.Join(appended, "\n"), nil
+}
+
+// MappedFieldsOfTemplateWithPartials returns the mapped fields of a template including the fields
+// used by every partial it includes, extends or imports.
+func MappedFieldsOfTemplateWithPartials(templateFile string, resolve PartialResolver) (map[string]map[string][]string, error) {
+	expanded, err := ExpandPartials(templateFile, resolve)
+	if err != nil {
+		return nil, err
+	}
+
+	return MappedFieldsOfTemplate(expanded), nil
+}
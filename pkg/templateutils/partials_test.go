@@ -0,0 +1,156 @@
+// Copyright (c) 2026 Lerian Studio. All rights reserved.
+// Use of this source code is governed by the Elastic License 2.0
+// that can be found in the LICENSE file.
+
+package templateutils
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/LerianStudio/reporter/pkg/constant"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// mapPartialResolver resolves partials from memory and fails for unknown names.
+func mapPartialResolver(partials map[string]string) PartialResolver {
+	return func(name string) (string, error) {
+		content, ok := partials[name]
+		if !ok {
+			return "", &PartialError{Err: constant.ErrPartialNotFound, Name: name}
+		}
+
+		return content, nil
+	}
+}
+
+func TestValidatePartialName(t *testing.T) {
+	t.Parallel()
+
+	for _, name := range []string{"header", "layouts/corporate", "shared/v2/foot_er-1"} {
+		assert.NoError(t, ValidatePartialName(name), name)
+	}
+
+	for _, name := range []string{"", "/header", "layouts/", "../header", "layouts//corporate", "a b", "header.tpl", strings.Repeat("a", constant.MaxPartialNameLength+1)} {
+		assert.ErrorIs(t, ValidatePartialName(name), constant.ErrInvalidPartialName, name)
+	}
+}
+
+func TestResolvePartialName(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		base     string
+		name     string
+		expected string
+	}{
+		{"", "layouts/corporate", "layouts/corporate"},
+		{"", " layouts/corporate.tpl ", "layouts/corporate"},
+		{"layouts/corporate", "./header", "layouts/header"},
+		{"layouts/corporate", "../shared/footer", "shared/footer"},
+		{"layouts/corporate", "shared/footer", "shared/footer"},
+		{"", "../escape", "../escape"},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.expected, ResolvePartialName(tt.base, tt.name), "ResolvePartialName(%q, %q)", tt.base, tt.name)
+	}
+}
+
+func TestMappedFieldsOfTemplateWithPartials(t *testing.T) {
+	t.Parallel()
+
+	resolve := mapPartialResolver(map[string]string{
+		"layouts/corporate": `<h1>{{ midaz_onboarding.organization.0.legal_name }}</h1>{% include "./footer" %}{% block content %}{% endblock %}`,
+		"layouts/footer":    `{{ midaz_onboarding.organization.0.legal_document }}`,
+		"rows/account":      `{{ account.alias }}`,
+	})
+
+	template := `{% extends "layouts/corporate" %}{% block content %}
+{% for account in midaz_onboarding.account %}{% include "rows/account" with x=1 %}{% endfor %}
+{% endblock %}`
+
+	mappedFields, err := MappedFieldsOfTemplateWithPartials(template, resolve)
+	require.NoError(t, err)
+
+	assert.ElementsMatch(t, []string{"legal_name", "legal_document"}, mappedFields["midaz_onboarding"]["organization"])
+	assert.Contains(t, mappedFields["midaz_onboarding"]["account"], "alias")
+}
+
+func TestExpandPartials_Errors(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		template string
+		partials map[string]string
+		expected error
+		partial  string
+	}{
+		{
+			name:     "missing partial",
+			template: `{% include "layouts/missing" %}`,
+			expected: constant.ErrPartialNotFound,
+			partial:  "layouts/missing",
+		},
+		{
+			name:     "invalid partial name",
+			template: `{% include "../escape" %}`,
+			expected: constant.ErrInvalidPartialName,
+			partial:  "../escape",
+		},
+		{
+			name:     "include cycle",
+			template: `{% include "a" %}`,
+			partials: map[string]string{"a": `{% include "b" %}`, "b": `{% include "a" %}`},
+			expected: constant.ErrPartialIncludeCycle,
+			partial:  "a",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := ExpandPartials(tt.template, mapPartialResolver(tt.partials))
+			require.ErrorIs(t, err, tt.expected)
+
+			var partialErr *PartialError
+			require.True(t, errors.As(err, &partialErr))
+			assert.Equal(t, tt.partial, partialErr.Name)
+		})
+	}
+}
+
+func TestExpandPartials_NoPartials(t *testing.T) {
+	t.Parallel()
+
+	template := `{% include some_variable %}{{ midaz_onboarding.account.0.id }}`
+
+	expanded, err := ExpandPartials(template, mapPartialResolver(nil))
+	require.NoError(t, err)
+	assert.Equal(t, template, expanded)
+}
+
+func TestExpandPartial(t *testing.T) {
+	t.Parallel()
+
+	resolve := mapPartialResolver(map[string]string{
+		"layouts/header": `<h1>{{ organization.name }}</h1>`,
+		"layouts/loop":   `{% include "./corporate" %}`,
+	})
+
+	expanded, err := ExpandPartial("layouts/corporate", `{% include "./header" %}<main>{% block body %}{% endblock %}</main>`, resolve)
+	require.NoError(t, err)
+	assert.Equal(t, `<h1>{{ organization.name }}</h1><main>{% block body %}{% endblock %}</main>`, expanded)
+
+	_, err = ExpandPartial("layouts/corporate", `{% include "./loop" %}`, resolve)
+
+	var partialErr *PartialError
+
+	require.True(t, errors.As(err, &partialErr))
+	assert.ErrorIs(t, err, constant.ErrPartialIncludeCycle)
+	assert.Equal(t, "layouts/corporate", partialErr.Name)
+}
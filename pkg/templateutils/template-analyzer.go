@@ -0,0 +1,623 @@
+// Copyright (c) 2026 Lerian Studio. All rights reserved.
+// Use of this source code is governed by the Elastic License 2.0
+// that can be found in the LICENSE file.
+
+package templateutils
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/LerianStudio/reporter/pkg/constant"
+)
+
+// aggregationTags are the custom tags that aggregate a field of a collection.
+var aggregationTags = map[string]bool{
+	"sum_by": true, "count_by": true, "avg_by": true, "min_by": true, "max_by": true,
+}
+
+// groupsStep ends the path of the groups made by group_by from a collection. It cannot be a field
+// name, so the groups are told apart from the fields of the collection.
+const groupsStep = "|group_by"
+
+// analyzerScope holds the variables defined by a block. A variable bound to a nil path holds a value
+// that does not come from a data source, e.g. a loop counter or a macro argument.
+type analyzerScope struct {
+	parent *analyzerScope
+	vars   map[string][]string
+}
+
+// fieldAnalyzer walks a parsed template and collects the data source fields it reads.
+type fieldAnalyzer struct {
+	fields map[string]map[string][]string
+	scope  *analyzerScope
+
+	// item is the collection whose items are in scope while analyzing an aggregation condition.
+	item []string
+}
+
+// analyzeTemplateFields parses a template and returns the fields it reads, grouped by data source and table.
+func analyzeTemplateFields(templateFile string) map[string]map[string][]string {
+	a := &fieldAnalyzer{
+		fields: map[string]map[string][]string{},
+		scope:  &analyzerScope{vars: map[string][]string{}},
+	}
+
+	a.analyzeNodes(parseTemplate(templateFile))
+
+	return a.fields
+}
+
+// pushScope opens a new scope for a block body.
+func (a *fieldAnalyzer) pushScope() {
+	a.scope = &analyzerScope{parent: a.scope, vars: map[string][]string{}}
+}
+
+// popScope closes the innermost scope.
+func (a *fieldAnalyzer) popScope() {
+	a.scope = a.scope.parent
+}
+
+// bind defines a variable in the innermost scope.
+func (a *fieldAnalyzer) bind(name string, path []string) {
+	a.scope.vars[name] = path
+}
+
+// lookup resolves a variable through the enclosing scopes.
+func (a *fieldAnalyzer) lookup(name string) ([]string, bool) {
+	for s := a.scope; s != nil; s = s.parent {
+		if path, ok := s.vars[name]; ok {
+			return path, true
+		}
+	}
+
+	return nil, false
+}
+
+// record registers a path read by the template. The first two elements are the data source and the
+// table; every following element is a field, registered with each of its parents, e.g. "a" and "a.b".
+func (a *fieldAnalyzer) record(path []string) {
+	if i := slices.Index(path, groupsStep); i >= 0 {
+		path = path[:i]
+	}
+
+	if len(path) < constant.MinPathParts {
+		return
+	}
+
+	tables, ok := a.fields[path[0]]
+	if !ok {
+		tables = map[string][]string{}
+		a.fields[path[0]] = tables
+	}
+
+	fields, ok := tables[path[1]]
+	if !ok {
+		fields = []string{}
+	}
+
+	for i := constant.MinPathParts; i < len(path); i++ {
+		field := strings.Join(path[constant.MinPathParts:i+1], ".")
+		if !slices.Contains(fields, field) {
+			fields = append(fields, field)
+		}
+	}
+
+	tables[path[1]] = fields
+}
+
+// recordBinding registers a path bound to a variable. Binding a whole collection reads none of its
+// fields, so only the fields read by the block are registered for it.
+func (a *fieldAnalyzer) recordBinding(path []string) {
+	if len(path) > constant.MinPathParts {
+		a.record(path)
+	}
+}
+
+// recordField registers a dotted field of a collection.
+func (a *fieldAnalyzer) recordField(collection []string, field string) {
+	field = strings.TrimSpace(field)
+	if len(collection) < constant.MinPathParts || field == "" {
+		return
+	}
+
+	a.record(appendPath(collection, strings.Split(field, ".")...))
+}
+
+// use registers every path read by an expression.
+func (a *fieldAnalyzer) use(e expr) {
+	a.record(a.resolve(e))
+}
+
+// resolve returns the data source path an expression evaluates to, or nil when the value does not
+// come from a data source, registering every path read along the way.
+func (a *fieldAnalyzer) resolve(e expr) []string {
+	switch e := e.(type) {
+	case variableExpr:
+		return a.resolveVariable(e)
+	case filterExpr:
+		return a.resolveFilter(e)
+	case binaryExpr:
+		a.use(e.left)
+		a.use(e.right)
+	case unaryExpr:
+		a.use(e.operand)
+	}
+
+	return nil
+}
+
+// resolveVariable resolves a variable against the scopes. Unbound variables are data source paths,
+// except inside an aggregation condition, where a bare field refers to the items of the collection.
+func (a *fieldAnalyzer) resolveVariable(v variableExpr) []string {
+	steps := v.steps
+
+	path, bound := a.lookup(v.root)
+
+	switch {
+	case bound:
+		path = slices.Clone(path)
+	case v.root == "filter" && len(steps) > 0 && steps[0].call:
+		path = a.resolveFilterCall(steps[0].args)
+		steps = steps[1:]
+	case a.item != nil && countAttributes(steps) <= 1:
+		path = appendPath(a.item, v.root)
+	default:
+		path = []string{v.root}
+	}
+
+	for _, step := range steps {
+		switch {
+		case step.call:
+			a.record(path)
+
+			for _, arg := range step.args {
+				a.use(arg)
+			}
+
+			path = nil
+		case step.index != nil:
+			if lit, ok := step.index.(literalExpr); ok && lit.kind == tokenString && path != nil {
+				path = append(path, lit.value)
+			} else if !ok {
+				a.use(step.index)
+			}
+		case path != nil && path[len(path)-1] == groupsStep:
+			path = resolveGroupAttribute(path, step.attr)
+		case path != nil:
+			path = append(path, step.attr)
+		}
+	}
+
+	return path
+}
+
+// resolveGroupAttribute resolves an attribute of a group made by group_by: its items are items of the
+// grouped collection and its key is the grouping field, already registered.
+func resolveGroupAttribute(path []string, attr string) []string {
+	if attr == "items" {
+		return path[:len(path)-1]
+	}
+
+	return nil
+}
+
+// conditionField returns the field of a where or count condition, e.g. "amount" for "amount>=100".
+func conditionField(condition string) string {
+	if i := strings.IndexAny(condition, ":=!<> "); i >= 0 {
+		return condition[:i]
+	}
+
+	return ""
+}
+
+// resolveFilterCall resolves filter(collection, "field", value), which selects the items of the
+// collection whose field equals the value.
+func (a *fieldAnalyzer) resolveFilterCall(args []expr) []string {
+	if len(args) == 0 {
+		return nil
+	}
+
+	collection := a.resolve(args[0])
+
+	if len(args) > 1 {
+		if lit, ok := args[1].(literalExpr); ok && lit.kind == tokenString {
+			a.recordField(collection, lit.value)
+		} else {
+			a.use(args[1])
+		}
+	}
+
+	for _, arg := range args[min(len(args), constant.MinPathParts):] {
+		a.use(arg)
+	}
+
+	return collection
+}
+
+// resolveFilter resolves a filtered expression. Filters selecting, ordering or aggregating items of a
+// collection keep its path, so chained filters apply to the same collection; the fields referenced by
+// their arguments are registered on the collection. pluck resolves to the plucked field and group_by
+// to the groups of the collection.
+func (a *fieldAnalyzer) resolveFilter(f filterExpr) []string {
+	path := a.resolve(f.base)
+
+	lit, isString := f.arg.(literalExpr)
+	isString = isString && lit.kind == tokenString
+
+	if f.arg != nil && !isString {
+		a.use(f.arg)
+	}
+
+	switch f.name {
+	case "where", "count":
+		if isString {
+			a.recordField(path, conditionField(lit.value))
+		}
+
+		return path
+	case "sum", "unique":
+		if isString {
+			a.recordField(path, strings.Trim(lit.value, `"' `))
+		}
+
+		return path
+	case "sort_by":
+		if isString {
+			for _, key := range strings.Split(lit.value, ",") {
+				a.recordField(path, strings.TrimPrefix(strings.TrimSpace(key), "-"))
+			}
+		}
+
+		return path
+	case "pluck":
+		if isString && len(path) >= constant.MinPathParts {
+			a.recordField(path, lit.value)
+			return appendPath(path, strings.Split(strings.TrimSpace(lit.value), ".")...)
+		}
+	case "group_by":
+		if isString && len(path) >= constant.MinPathParts {
+			a.recordField(path, lit.value)
+			return appendPath(path, groupsStep)
+		}
+	case "first", "last", "random", "slice", "first_n", "last_n":
+		return path
+	}
+
+	a.record(path)
+
+	return nil
+}
+
+// analyzeNodes analyzes a list of nodes in the current scope.
+func (a *fieldAnalyzer) analyzeNodes(nodes []*templateNode) {
+	for _, node := range nodes {
+		a.analyzeNode(node)
+	}
+}
+
+// analyzeNode analyzes an output or a tag.
+func (a *fieldAnalyzer) analyzeNode(node *templateNode) {
+	if node.output {
+		a.scanExpressions(node.tokens)
+		return
+	}
+
+	switch {
+	case node.name == "for":
+		a.analyzeFor(node)
+	case node.name == "if":
+		for _, branch := range node.branches {
+			a.scanExpressions(branch.tokens)
+			a.analyzeNodes(branch.nodes)
+		}
+	case node.name == "with":
+		a.analyzeWith(node)
+	case node.name == "set":
+		a.analyzeSet(node.tokens)
+	case node.name == "macro":
+		a.analyzeMacro(node)
+	case node.name == "last_item_by_group":
+		a.analyzeLastItemByGroup(node.tokens)
+	case aggregationTags[node.name]:
+		a.analyzeAggregation(node.tokens)
+	default:
+		a.scanExpressions(node.tokens)
+
+		for _, branch := range node.branches {
+			a.scanExpressions(branch.tokens)
+			a.analyzeNodes(branch.nodes)
+		}
+	}
+}
+
+// analyzeFor analyzes {% for item in collection %}. The loop variable is bound to the collection in
+// the scope of the body; the empty clause is analyzed in the enclosing scope.
+func (a *fieldAnalyzer) analyzeFor(node *templateNode) {
+	p := newExprParser(node.branches[0].tokens)
+
+	var names []string
+
+	for {
+		name, ok := p.matchIdentifier()
+		if !ok {
+			break
+		}
+
+		names = append(names, name)
+
+		if _, ok := p.match(","); !ok {
+			break
+		}
+	}
+
+	var collection []string
+
+	if _, ok := p.match("in"); ok {
+		if e, ok := p.parseExpression(); ok {
+			collection = a.resolve(e)
+		}
+	}
+
+	a.recordBinding(collection)
+	a.pushScope()
+	a.bind("forloop", nil)
+
+	for _, name := range names {
+		a.bind(name, nil)
+	}
+
+	if len(names) == 1 {
+		a.bind(names[0], collection)
+	}
+
+	a.analyzeNodes(node.branches[0].nodes)
+	a.popScope()
+
+	for _, branch := range node.branches[1:] {
+		a.analyzeNodes(branch.nodes)
+	}
+}
+
+// analyzeWith analyzes {% with name=value %} and {% with value as name %}, binding the names in the
+// scope of the body.
+func (a *fieldAnalyzer) analyzeWith(node *templateNode) {
+	p := newExprParser(node.branches[0].tokens)
+	bindings := map[string][]string{}
+
+	for !p.done() {
+		if p.peekKind(0, tokenIdentifier) && p.peekIs(1, "=") {
+			name, _ := p.matchIdentifier()
+			p.pos++
+
+			if e, ok := p.parseExpression(); ok {
+				bindings[name] = a.resolve(e)
+				a.recordBinding(bindings[name])
+			}
+
+			continue
+		}
+
+		e, ok := p.parseExpression()
+		if !ok {
+			p.pos++
+			continue
+		}
+
+		path := a.resolve(e)
+
+		if _, ok := p.match("as"); ok {
+			if name, ok := p.matchIdentifier(); ok {
+				bindings[name] = path
+				a.recordBinding(path)
+
+				continue
+			}
+		}
+
+		a.record(path)
+	}
+
+	a.pushScope()
+
+	for name, path := range bindings {
+		a.bind(name, path)
+	}
+
+	a.analyzeNodes(node.branches[0].nodes)
+	a.popScope()
+}
+
+// analyzeSet analyzes {% set name = value %}, binding the name in the current scope.
+func (a *fieldAnalyzer) analyzeSet(tokens []token) {
+	p := newExprParser(tokens)
+
+	name, ok := p.matchIdentifier()
+	if !ok {
+		a.scanExpressions(tokens)
+		return
+	}
+
+	var path []string
+
+	if _, ok := p.match("="); ok {
+		if e, ok := p.parseExpression(); ok {
+			path = a.resolve(e)
+		}
+	}
+
+	a.recordBinding(path)
+	a.bind(name, path)
+}
+
+// analyzeMacro analyzes {% macro name(arg, arg=default) %}. Arguments are bound in the scope of the
+// body and hold values that do not come from a data source.
+func (a *fieldAnalyzer) analyzeMacro(node *templateNode) {
+	p := newExprParser(node.branches[0].tokens)
+
+	a.pushScope()
+
+	if _, ok := p.matchIdentifier(); ok {
+		p.match("(")
+
+		for !p.done() && !p.peekIs(0, ")") {
+			name, ok := p.matchIdentifier()
+			if !ok {
+				p.pos++
+				continue
+			}
+
+			a.bind(name, nil)
+
+			if _, ok := p.match("="); ok {
+				if e, ok := p.parseExpression(); ok {
+					a.use(e)
+				}
+			}
+
+			p.match(",")
+		}
+	}
+
+	a.analyzeNodes(node.branches[0].nodes)
+	a.popScope()
+}
+
+// analyzeAggregation analyzes {% sum_by collection by "field" if condition %} and the other
+// aggregation tags. The field and the bare names of the condition refer to items of the collection.
+func (a *fieldAnalyzer) analyzeAggregation(tokens []token) {
+	p := newExprParser(tokens)
+
+	e, ok := p.parseExpression()
+	if !ok {
+		a.scanExpressions(tokens)
+		return
+	}
+
+	collection := a.resolve(e)
+	a.record(collection)
+
+	if _, ok := p.match("by"); ok {
+		if field, ok := p.parseExpression(); ok {
+			a.useItemField(collection, field)
+		}
+	}
+
+	if _, ok := p.match("if"); ok {
+		if condition, ok := p.parseExpression(); ok {
+			a.useCondition(collection, condition)
+		}
+	}
+
+	a.scanExpressions(p.tokens[p.pos:])
+}
+
+// analyzeLastItemByGroup analyzes
+// {% last_item_by_group collection group_by "a,b" order_by "c" if condition as name %},
+// binding name to the collection in the current scope.
+func (a *fieldAnalyzer) analyzeLastItemByGroup(tokens []token) {
+	p := newExprParser(tokens)
+
+	e, ok := p.parseExpression()
+	if !ok {
+		a.scanExpressions(tokens)
+		return
+	}
+
+	collection := a.resolve(e)
+	a.record(collection)
+
+	for _, keyword := range []string{"group_by", "order_by"} {
+		if _, ok := p.match(keyword); !ok {
+			continue
+		}
+
+		if field, ok := p.parseExpression(); ok {
+			a.useItemField(collection, field)
+		}
+	}
+
+	if _, ok := p.match("if"); ok {
+		if condition, ok := p.parseExpression(); ok {
+			a.useCondition(collection, condition)
+		}
+	}
+
+	if _, ok := p.match("as"); ok {
+		if name, ok := p.matchIdentifier(); ok {
+			a.bind(name, collection)
+		}
+	}
+
+	a.scanExpressions(p.tokens[p.pos:])
+}
+
+// useItemField registers the field named by a string literal on the collection. Comma-separated
+// names are registered separately; any other expression is analyzed as usual.
+func (a *fieldAnalyzer) useItemField(collection []string, field expr) {
+	lit, ok := field.(literalExpr)
+	if !ok || lit.kind != tokenString {
+		a.use(field)
+		return
+	}
+
+	for _, name := range strings.Split(lit.value, ",") {
+		a.recordField(collection, name)
+	}
+}
+
+// useCondition analyzes a condition evaluated against each item of the collection.
+func (a *fieldAnalyzer) useCondition(collection []string, condition expr) {
+	if len(collection) < constant.MinPathParts {
+		a.use(condition)
+		return
+	}
+
+	outer := a.item
+	a.item = collection
+	a.use(condition)
+	a.item = outer
+}
+
+// scanExpressions analyzes every expression found in a list of tokens, skipping the tokens that do
+// not start an expression. A trailing "as name" binds name in the current scope.
+func (a *fieldAnalyzer) scanExpressions(tokens []token) {
+	p := newExprParser(tokens)
+
+	for !p.done() {
+		if p.peekIs(0, "as") && p.peekKind(1, tokenIdentifier) {
+			a.bind(p.tokens[p.pos+1].value, nil)
+			p.pos += 2
+
+			continue
+		}
+
+		start := p.pos
+
+		if e, ok := p.parseExpression(); ok {
+			a.use(e)
+		}
+
+		if p.pos == start {
+			p.pos++
+		}
+	}
+}
+
+// countAttributes returns the number of attribute steps of a variable.
+func countAttributes(steps []pathStep) int {
+	count := 0
+
+	for _, step := range steps {
+		if step.attr != "" {
+			count++
+		}
+	}
+
+	return count
+}
+
+// appendPath returns a new path made of path followed by elems.
+func appendPath(path []string, elems ...string) []string {
+	return append(slices.Clone(path), elems...)
+}
@@ -0,0 +1,200 @@
+// Copyright (c) 2026 Lerian Studio. All rights reserved.
+// Use of this source code is governed by the Elastic License 2.0
+// that can be found in the LICENSE file.
+
+package templateutils
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// differentialTemplateGlobs lists the template corpora both analyzers must agree on.
+var differentialTemplateGlobs = []string{
+	"../../templates/examples/*.tpl",
+	"../../tests/fuzzy/templates/*.tpl",
+	"../../tests/chaos/templates/*.tpl",
+}
+
+// fieldSet flattens mapped fields into a set of "datasource.table" and "datasource.table.field" keys,
+// ignoring the order in which fields were found.
+func fieldSet(fields map[string]map[string][]string) map[string]bool {
+	set := map[string]bool{}
+
+	for ds, tables := range fields {
+		for table, names := range tables {
+			set[ds+"."+table] = true
+
+			for _, name := range names {
+				set[ds+"."+table+"."+name] = true
+			}
+		}
+	}
+
+	return set
+}
+
+func TestMappedFieldsOfTemplate_MatchesRegexImplementation(t *testing.T) {
+	t.Parallel()
+
+	var files []string
+
+	for _, pattern := range differentialTemplateGlobs {
+		matches, err := filepath.Glob(pattern)
+		require.NoError(t, err)
+
+		files = append(files, matches...)
+	}
+
+	require.NotEmpty(t, files)
+
+	for _, file := range files {
+		t.Run(filepath.Base(file), func(t *testing.T) {
+			t.Parallel()
+
+			content, err := os.ReadFile(file)
+			require.NoError(t, err)
+
+			expected := fieldSet(regexMappedFieldsOfTemplate(string(content)))
+			actual := fieldSet(MappedFieldsOfTemplate(string(content)))
+
+			assert.Equal(t, expected, actual)
+		})
+	}
+}
+
+func TestMappedFieldsOfTemplate_Scoping(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		template string
+		expected map[string]map[string][]string
+	}{
+		{
+			name: "loop variable reused for different tables",
+			template: `{% for item in db.accounts %}{{ item.alias }}{% endfor %}
+{% for item in db.balances %}{{ item.available }}{% endfor %}`,
+			expected: map[string]map[string][]string{
+				"db": {"accounts": {"alias"}, "balances": {"available"}},
+			},
+		},
+		{
+			name:     "loop variable shadows a data source",
+			template: `{% for db in other.items %}{{ db.name }}{% endfor %}{{ db.accounts.id }}`,
+			expected: map[string]map[string][]string{
+				"other": {"items": {"name"}},
+				"db":    {"accounts": {"id"}},
+			},
+		},
+		{
+			name:     "with variable is scoped to its block",
+			template: `{% with acc = db.accounts %}{{ acc.id }}{% endwith %}{{ acc.legacy.id }}`,
+			expected: map[string]map[string][]string{
+				"db":  {"accounts": {"id"}},
+				"acc": {"legacy": {"id"}},
+			},
+		},
+		{
+			name:     "with as syntax",
+			template: `{% with db.accounts|where:"type:cacc" as accounts %}{% for a in accounts %}{{ a.id }}{% endfor %}{% endwith %}`,
+			expected: map[string]map[string][]string{
+				"db": {"accounts": {"type", "id"}},
+			},
+		},
+		{
+			name:     "set binds in the enclosing scope",
+			template: `{% set first = db.accounts.0 %}{{ first.alias }}`,
+			expected: map[string]map[string][]string{
+				"db": {"accounts": {"alias"}},
+			},
+		},
+		{
+			name:     "nested loop over a field of the outer loop",
+			template: `{% for h in crm.holders %}{% for c in h.contacts %}{{ c.email }}{% endfor %}{% endfor %}`,
+			expected: map[string]map[string][]string{
+				"crm": {"holders": {"contacts", "contacts.email"}},
+			},
+		},
+		{
+			name:     "loop counters and macro arguments are not data sources",
+			template: `{% macro row(item, sep=",") %}{{ item.name }}{{ sep }}{% endmacro %}{% for a in db.accounts %}{{ forloop.counter }}{{ row(a) }}{% endfor %}`,
+			expected: map[string]map[string][]string{
+				"db": {"accounts": {}},
+			},
+		},
+		{
+			name:     "aggregation condition fields belong to the collection",
+			template: `{% for r in db.routes %}{% sum_by db.operations by "amount.value" if r.id == route and status == "DONE" %}{% endfor %}`,
+			expected: map[string]map[string][]string{
+				"db": {"routes": {"id"}, "operations": {"amount", "amount.value", "route", "status"}},
+			},
+		},
+		{
+			name:     "last_item_by_group result is bound to the collection",
+			template: `{% last_item_by_group db.operations group_by "account_id,asset" order_by "created_at" as latest %}{% for op in latest %}{{ op.amount }}{% endfor %}`,
+			expected: map[string]map[string][]string{
+				"db": {"operations": {"account_id", "asset", "created_at", "amount"}},
+			},
+		},
+		{
+			name:     "where conditions with operators",
+			template: `{{ db.operations|where:"amount.value>=100"|where:"type in PIX,TED"|count:"status:DONE" }}`,
+			expected: map[string]map[string][]string{
+				"db": {"operations": {"amount", "amount.value", "type", "status"}},
+			},
+		},
+		{
+			name:     "collection filters keep the collection",
+			template: `{% for op in db.operations|sort_by:"account_id,-created_at"|unique:"code"|first_n:5 %}{{ op.amount }}{% endfor %}`,
+			expected: map[string]map[string][]string{
+				"db": {"operations": {"account_id", "created_at", "code", "amount"}},
+			},
+		},
+		{
+			name:     "pluck resolves to the field",
+			template: `{% for doc in crm.holders|pluck:"document"|unique %}{{ doc }}{% endfor %}`,
+			expected: map[string]map[string][]string{
+				"crm": {"holders": {"document"}},
+			},
+		},
+		{
+			name:     "group_by items are items of the collection",
+			template: `{% for g in db.transactions|group_by:"account_id" %}{{ g.key }}{% for t in g.items %}{{ t.amount }}{% endfor %}{% endfor %}`,
+			expected: map[string]map[string][]string{
+				"db": {"transactions": {"account_id", "amount"}},
+			},
+		},
+		{
+			name:     "strings and comments are ignored",
+			template: `{# {{ db.hidden.field }} #}{{ "db.literal.field" }}{% comment %}{{ db.commented.field }}{% endcomment %}{{ db.accounts["alias"] }}`,
+			expected: map[string]map[string][]string{
+				"db": {"accounts": {"alias"}},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, tt.expected, MappedFieldsOfTemplate(tt.template))
+		})
+	}
+}
+
+func TestMappedFieldsOfTemplate_MalformedTemplate(t *testing.T) {
+	t.Parallel()
+
+	template := `{% for a in db.accounts %}{{ a.id }}{% endif %}{{ a.alias }}{% endfor %}{{ db.balances.amount`
+
+	result := MappedFieldsOfTemplate(template)
+
+	require.Contains(t, result, "db")
+	assert.Equal(t, []string{"id", "alias"}, result["db"]["accounts"])
+	assert.NotContains(t, result["db"], "balances")
+}
@@ -0,0 +1,230 @@
+// Copyright (c) 2026 Lerian Studio. All rights reserved.
+// Use of this source code is governed by the Elastic License 2.0
+// that can be found in the LICENSE file.
+
+package templateutils
+
+import (
+	"strings"
+)
+
+// segmentKind identifies the kind of a template segment.
+type segmentKind int
+
+const (
+	segmentText segmentKind = iota
+	segmentVariable
+	segmentTag
+)
+
+// segment is a piece of a template: plain text, a {{ variable }} or a {% tag %}.
+// Comments are dropped while splitting.
+type segment struct {
+	kind    segmentKind
+	content string
+}
+
+// tokenKind identifies the kind of a token inside a variable or tag.
+type tokenKind int
+
+const (
+	tokenIdentifier tokenKind = iota
+	tokenNumber
+	tokenString
+	tokenSymbol
+)
+
+// token is a lexical token of a variable or tag.
+type token struct {
+	kind  tokenKind
+	value string
+}
+
+// tokenSymbols lists the symbols recognized inside variables and tags, two-character symbols first.
+var tokenSymbols = []string{
+	"==", "!=", "<>", "<=", ">=", "&&", "||",
+	"+", "-", "*", "/", "%", "^", "(", ")", "[", "]", ",", ".", ":", "|", "=", "<", ">", "!",
+}
+
+// splitTemplate splits a template into text, variable and tag segments, dropping comments
+// and whitespace control markers. An unterminated variable, tag or comment is kept as text,
+// so malformed templates can still be analyzed up to the point where they break.
+func splitTemplate(src string) []segment {
+	var segments []segment
+
+	for len(src) > 0 {
+		start := indexOfDelimiter(src)
+		if start == -1 {
+			segments = append(segments, segment{kind: segmentText, content: src})
+			break
+		}
+
+		if start > 0 {
+			segments = append(segments, segment{kind: segmentText, content: src[:start]})
+		}
+
+		opening := src[start : start+2]
+		body := src[start+2:]
+
+		if opening == "{#" {
+			end := strings.Index(body, "#}")
+			if end == -1 {
+				segments = append(segments, segment{kind: segmentText, content: src[start:]})
+				break
+			}
+
+			src = body[end+2:]
+
+			continue
+		}
+
+		closing := "}}"
+		kind := segmentVariable
+
+		if opening == "{%" {
+			closing = "%}"
+			kind = segmentTag
+		}
+
+		end := indexOfClosing(body, closing)
+		if end == -1 {
+			segments = append(segments, segment{kind: segmentText, content: src[start:]})
+			break
+		}
+
+		content := strings.TrimPrefix(body[:end], "-")
+		content = strings.TrimSuffix(content, "-")
+
+		segments = append(segments, segment{kind: kind, content: strings.TrimSpace(content)})
+		src = body[end+2:]
+	}
+
+	return segments
+}
+
+// indexOfDelimiter returns the index of the first "{{", "{%" or "{#" in src, or -1.
+func indexOfDelimiter(src string) int {
+	for i := 0; i+1 < len(src); i++ {
+		if src[i] == '{' && (src[i+1] == '{' || src[i+1] == '%' || src[i+1] == '#') {
+			return i
+		}
+	}
+
+	return -1
+}
+
+// indexOfClosing returns the index of closing in body, skipping quoted strings, or -1.
+func indexOfClosing(body, closing string) int {
+	var quote byte
+
+	for i := 0; i < len(body); i++ {
+		c := body[i]
+
+		switch {
+		case quote != 0:
+			if c == '\\' {
+				i++
+			} else if c == quote {
+				quote = 0
+			}
+		case c == '"' || c == '\'':
+			quote = c
+		case strings.HasPrefix(body[i:], closing):
+			return i
+		}
+	}
+
+	// An unbalanced quote must not hide the closing delimiter
+	if quote != 0 {
+		return strings.Index(body, closing)
+	}
+
+	return -1
+}
+
+// tokenize splits the content of a variable or tag into tokens. Characters that are not part
+// of the grammar become single-character symbols so the parser can skip them.
+func tokenize(src string) []token {
+	var tokens []token
+
+	for i := 0; i < len(src); {
+		c := src[i]
+
+		switch {
+		case c == ' ' || c == '\t' || c == '\n' || c == '\r':
+			i++
+		case isIdentifierStart(c):
+			j := i + 1
+			for j < len(src) && isIdentifierPart(src[j]) {
+				j++
+			}
+
+			tokens = append(tokens, token{kind: tokenIdentifier, value: src[i:j]})
+			i = j
+		case isDigit(c):
+			j := i + 1
+			for j < len(src) && isDigit(src[j]) {
+				j++
+			}
+
+			if j+1 < len(src) && src[j] == '.' && isDigit(src[j+1]) {
+				j += 2
+				for j < len(src) && isDigit(src[j]) {
+					j++
+				}
+			}
+
+			tokens = append(tokens, token{kind: tokenNumber, value: src[i:j]})
+			i = j
+		case c == '"' || c == '\'':
+			value, next := readString(src, i)
+			tokens = append(tokens, token{kind: tokenString, value: value})
+			i = next
+		default:
+			symbol := src[i : i+1]
+
+			for _, s := range tokenSymbols {
+				if strings.HasPrefix(src[i:], s) {
+					symbol = s
+					break
+				}
+			}
+
+			tokens = append(tokens, token{kind: tokenSymbol, value: symbol})
+			i += len(symbol)
+		}
+	}
+
+	return tokens
+}
+
+// readString reads the quoted string starting at src[start] and returns its unescaped value
+// and the index right after the closing quote.
+func readString(src string, start int) (string, int) {
+	quote := src[start]
+
+	var sb strings.Builder
+
+	i := start + 1
+	for ; i < len(src) && src[i] != quote; i++ {
+		if src[i] == '\\' && i+1 < len(src) {
+			i++
+		}
+
+		sb.WriteByte(src[i])
+	}
+
+	return sb.String(), i + 1
+}
+
+func isIdentifierStart(c byte) bool {
+	return c == '_' || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+}
+
+func isIdentifierPart(c byte) bool {
+	return isIdentifierStart(c) || isDigit(c)
+}
+
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
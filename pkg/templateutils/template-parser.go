@@ -0,0 +1,490 @@
+// Copyright (c) 2026 Lerian Studio. All rights reserved.
+// Use of this source code is governed by the Elastic License 2.0
+// that can be found in the LICENSE file.
+
+package templateutils
+
+// expr is a node of a parsed template expression.
+type expr interface {
+	exprNode()
+}
+
+// literalExpr is a string, number or keyword literal such as true or nil.
+type literalExpr struct {
+	kind  tokenKind
+	value string
+}
+
+// variableExpr is a variable followed by attribute, index and call steps, e.g. a.b[0].c(d).
+type variableExpr struct {
+	root  string
+	steps []pathStep
+}
+
+// pathStep is a single step of a variable: an attribute, an index or a call.
+type pathStep struct {
+	attr  string
+	index expr
+	call  bool
+	args  []expr
+}
+
+// filterExpr applies a filter with an optional argument, e.g. base|where:"status:done".
+type filterExpr struct {
+	base expr
+	name string
+	arg  expr
+}
+
+// binaryExpr is a binary operation such as a + b or a and b.
+type binaryExpr struct {
+	op          string
+	left, right expr
+}
+
+// unaryExpr is a unary operation such as not a or -a.
+type unaryExpr struct {
+	op      string
+	operand expr
+}
+
+func (literalExpr) exprNode()  {}
+func (variableExpr) exprNode() {}
+func (filterExpr) exprNode()   {}
+func (binaryExpr) exprNode()   {}
+func (unaryExpr) exprNode()    {}
+
+// literalKeywords are identifiers evaluated as literals.
+var literalKeywords = map[string]bool{
+	"true": true, "false": true, "True": true, "False": true, "nil": true, "None": true,
+}
+
+// reservedKeywords are identifiers that can never start an operand, so parsing stops on them.
+var reservedKeywords = map[string]bool{
+	"and": true, "or": true, "not": true, "in": true, "as": true, "by": true,
+	"if": true, "else": true, "with": true, "only": true,
+}
+
+// exprParser parses expressions from the tokens of a variable or tag.
+type exprParser struct {
+	tokens []token
+	pos    int
+}
+
+func newExprParser(tokens []token) *exprParser {
+	return &exprParser{tokens: tokens}
+}
+
+// done reports whether every token was consumed.
+func (p *exprParser) done() bool {
+	return p.pos >= len(p.tokens)
+}
+
+// peekIs reports whether the token at offset from the current position has the given value.
+func (p *exprParser) peekIs(offset int, value string) bool {
+	i := p.pos + offset
+	return i < len(p.tokens) && p.tokens[i].kind != tokenString && p.tokens[i].value == value
+}
+
+// peekKind reports whether the token at offset from the current position has the given kind.
+func (p *exprParser) peekKind(offset int, kind tokenKind) bool {
+	i := p.pos + offset
+	return i < len(p.tokens) && p.tokens[i].kind == kind
+}
+
+// match consumes the current token when it has one of the given values.
+func (p *exprParser) match(values ...string) (string, bool) {
+	for _, v := range values {
+		if p.peekIs(0, v) {
+			p.pos++
+			return v, true
+		}
+	}
+
+	return "", false
+}
+
+// matchIdentifier consumes the current token when it is an identifier.
+func (p *exprParser) matchIdentifier() (string, bool) {
+	if !p.peekKind(0, tokenIdentifier) {
+		return "", false
+	}
+
+	p.pos++
+
+	return p.tokens[p.pos-1].value, true
+}
+
+// parseExpression parses a full expression. It returns false when no operand could be parsed.
+func (p *exprParser) parseExpression() (expr, bool) {
+	return p.parseOr()
+}
+
+func (p *exprParser) parseOr() (expr, bool) {
+	return p.parseBinary(p.parseAnd, "or", "||")
+}
+
+func (p *exprParser) parseAnd() (expr, bool) {
+	return p.parseBinary(p.parseNot, "and", "&&")
+}
+
+func (p *exprParser) parseNot() (expr, bool) {
+	if op, ok := p.match("not", "!"); ok {
+		operand, ok := p.parseNot()
+		if !ok {
+			return nil, false
+		}
+
+		return unaryExpr{op: op, operand: operand}, true
+	}
+
+	return p.parseComparison()
+}
+
+func (p *exprParser) parseComparison() (expr, bool) {
+	left, ok := p.parseAdditive()
+	if !ok {
+		return nil, false
+	}
+
+	for {
+		op, ok := p.match("==", "!=", "<>", "<=", ">=", "<", ">", "in")
+		if !ok && p.peekIs(0, "not") && p.peekIs(1, "in") {
+			p.pos += 2
+			op, ok = "not in", true
+		}
+
+		if !ok {
+			return left, true
+		}
+
+		right, ok := p.parseAdditive()
+		if !ok {
+			return left, true
+		}
+
+		left = binaryExpr{op: op, left: left, right: right}
+	}
+}
+
+func (p *exprParser) parseAdditive() (expr, bool) {
+	return p.parseBinary(p.parseMultiplicative, "+", "-")
+}
+
+func (p *exprParser) parseMultiplicative() (expr, bool) {
+	return p.parseBinary(p.parsePower, "*", "/", "%")
+}
+
+func (p *exprParser) parsePower() (expr, bool) {
+	return p.parseBinary(p.parseUnary, "^")
+}
+
+// parseBinary parses a left-associative chain of operands joined by the given operators.
+// A trailing operator without a right operand is left unconsumed.
+func (p *exprParser) parseBinary(operand func() (expr, bool), ops ...string) (expr, bool) {
+	left, ok := operand()
+	if !ok {
+		return nil, false
+	}
+
+	for {
+		start := p.pos
+
+		op, ok := p.match(ops...)
+		if !ok {
+			return left, true
+		}
+
+		right, ok := operand()
+		if !ok {
+			p.pos = start
+			return left, true
+		}
+
+		left = binaryExpr{op: op, left: left, right: right}
+	}
+}
+
+func (p *exprParser) parseUnary() (expr, bool) {
+	if op, ok := p.match("-", "+"); ok {
+		operand, ok := p.parseUnary()
+		if !ok {
+			return nil, false
+		}
+
+		return unaryExpr{op: op, operand: operand}, true
+	}
+
+	return p.parseFiltered()
+}
+
+// parseFiltered parses an operand followed by any number of filters.
+func (p *exprParser) parseFiltered() (expr, bool) {
+	base, ok := p.parsePrimary()
+	if !ok {
+		return nil, false
+	}
+
+	for p.peekIs(0, "|") && p.peekKind(1, tokenIdentifier) {
+		p.pos++
+
+		name, _ := p.matchIdentifier()
+		filter := filterExpr{base: base, name: name}
+
+		if _, ok := p.match(":"); ok {
+			filter.arg, _ = p.parsePrimary()
+		}
+
+		base = filter
+	}
+
+	return base, true
+}
+
+// parsePrimary parses a literal, a parenthesized expression or a variable.
+func (p *exprParser) parsePrimary() (expr, bool) {
+	if p.done() {
+		return nil, false
+	}
+
+	tok := p.tokens[p.pos]
+
+	switch tok.kind {
+	case tokenNumber, tokenString:
+		p.pos++
+		return literalExpr{kind: tok.kind, value: tok.value}, true
+	case tokenSymbol:
+		if tok.value != "(" {
+			return nil, false
+		}
+
+		p.pos++
+
+		inner, ok := p.parseExpression()
+		if !ok {
+			return nil, false
+		}
+
+		p.match(")")
+
+		return inner, true
+	case tokenIdentifier:
+		if reservedKeywords[tok.value] {
+			return nil, false
+		}
+
+		p.pos++
+
+		if literalKeywords[tok.value] {
+			return literalExpr{kind: tokenIdentifier, value: tok.value}, true
+		}
+
+		return p.parseVariable(tok.value), true
+	}
+
+	return nil, false
+}
+
+// parseVariable parses the steps following the root identifier of a variable. The explicit schema
+// syntax database:schema.table is folded into a "schema__table" attribute of the database.
+func (p *exprParser) parseVariable(root string) variableExpr {
+	v := variableExpr{root: root}
+
+	if p.peekIs(0, ":") && p.peekKind(1, tokenIdentifier) && p.peekIs(2, ".") && p.peekKind(3, tokenIdentifier) {
+		v.steps = append(v.steps, pathStep{attr: p.tokens[p.pos+1].value + "__" + p.tokens[p.pos+3].value})
+		p.pos += 4
+	}
+
+	for !p.done() {
+		switch {
+		case p.peekIs(0, ".") && p.peekKind(1, tokenIdentifier):
+			v.steps = append(v.steps, pathStep{attr: p.tokens[p.pos+1].value})
+			p.pos += 2
+		case p.peekIs(0, ".") && p.peekKind(1, tokenNumber):
+			v.steps = append(v.steps, pathStep{index: literalExpr{kind: tokenNumber, value: p.tokens[p.pos+1].value}})
+			p.pos += 2
+		case p.peekIs(0, "["):
+			p.pos++
+
+			index, _ := p.parseExpression()
+			p.match("]")
+
+			v.steps = append(v.steps, pathStep{index: index})
+		case p.peekIs(0, "("):
+			p.pos++
+
+			step := pathStep{call: true}
+
+			for !p.done() && !p.peekIs(0, ")") {
+				arg, ok := p.parseExpression()
+				if !ok {
+					p.pos++
+					continue
+				}
+
+				step.args = append(step.args, arg)
+				p.match(",")
+			}
+
+			p.match(")")
+
+			v.steps = append(v.steps, step)
+		default:
+			return v
+		}
+	}
+
+	return v
+}
+
+// templateNode is a node of a parsed template: an output {{ expression }} or a {% tag %}.
+// Block tags hold one branch per clause, e.g. the body of an if followed by its elif and else.
+type templateNode struct {
+	output   bool
+	name     string
+	tokens   []token
+	branches []templateBranch
+}
+
+// templateBranch is a clause of a block tag and the nodes it contains.
+type templateBranch struct {
+	name   string
+	tokens []token
+	nodes  []*templateNode
+}
+
+// blockTag describes a tag that encloses a body.
+type blockTag struct {
+	end      string
+	branches []string
+}
+
+// blockTags lists the tags that enclose a body, their end tag and their intermediate clauses.
+var blockTags = map[string]blockTag{
+	"for":        {end: "endfor", branches: []string{"empty"}},
+	"if":         {end: "endif", branches: []string{"elif", "else"}},
+	"with":       {end: "endwith"},
+	"block":      {end: "endblock"},
+	"macro":      {end: "endmacro"},
+	"filter":     {end: "endfilter"},
+	"autoescape": {end: "endautoescape"},
+	"spaceless":  {end: "endspaceless"},
+	"ifchanged":  {end: "endifchanged", branches: []string{"else"}},
+	"ifequal":    {end: "endifequal", branches: []string{"else"}},
+	"ifnotequal": {end: "endifnotequal", branches: []string{"else"}},
+	"comment":    {end: "endcomment"},
+	"verbatim":   {end: "endverbatim"},
+}
+
+// rawTags are block tags whose body is not parsed.
+var rawTags = map[string]bool{"comment": true, "verbatim": true}
+
+// parseTemplate parses a template into a tree of nodes. Parsing is tolerant: unknown clauses and
+// unmatched end tags are ignored and blocks left open at the end of the template are closed.
+func parseTemplate(src string) []*templateNode {
+	root := &templateNode{branches: []templateBranch{{}}}
+	stack := []*templateNode{root}
+	skipUntil := ""
+
+	for _, seg := range splitTemplate(src) {
+		current := stack[len(stack)-1]
+		branch := &current.branches[len(current.branches)-1]
+
+		if seg.kind == segmentText {
+			continue
+		}
+
+		if seg.kind == segmentVariable {
+			if skipUntil == "" {
+				branch.nodes = append(branch.nodes, &templateNode{output: true, tokens: tokenize(seg.content)})
+			}
+
+			continue
+		}
+
+		name, args := splitTagName(seg.content)
+
+		if skipUntil != "" {
+			if name == skipUntil {
+				skipUntil = ""
+			}
+
+			continue
+		}
+
+		if tag, ok := blockTags[name]; ok {
+			if rawTags[name] {
+				skipUntil = tag.end
+				continue
+			}
+
+			node := &templateNode{name: name, branches: []templateBranch{{name: name, tokens: tokenize(args)}}}
+			branch.nodes = append(branch.nodes, node)
+			stack = append(stack, node)
+
+			continue
+		}
+
+		if current != root && isBranchOf(current.name, name) {
+			current.branches = append(current.branches, templateBranch{name: name, tokens: tokenize(args)})
+			continue
+		}
+
+		if depth := indexOfOpenBlock(stack, name); depth > 0 {
+			stack = stack[:depth]
+			continue
+		}
+
+		if isEndTag(name) {
+			continue
+		}
+
+		branch.nodes = append(branch.nodes, &templateNode{name: name, tokens: tokenize(args)})
+	}
+
+	return root.branches[0].nodes
+}
+
+// splitTagName splits the content of a tag into its name and its arguments.
+func splitTagName(content string) (string, string) {
+	i := 0
+	for i < len(content) && isIdentifierPart(content[i]) {
+		i++
+	}
+
+	return content[:i], content[i:]
+}
+
+// isBranchOf reports whether name is an intermediate clause of the block tag.
+func isBranchOf(tag, name string) bool {
+	for _, b := range blockTags[tag].branches {
+		if b == name {
+			return true
+		}
+	}
+
+	return false
+}
+
+// isEndTag reports whether name closes any known block tag.
+func isEndTag(name string) bool {
+	for _, tag := range blockTags {
+		if tag.end == name {
+			return true
+		}
+	}
+
+	return false
+}
+
+// indexOfOpenBlock returns the stack index of the innermost open block closed by the end tag name,
+// or -1 when no open block matches.
+func indexOfOpenBlock(stack []*templateNode, name string) int {
+	for i := len(stack) - 1; i > 0; i-- {
+		if blockTags[stack[i].name].end == name {
+			return i
+		}
+	}
+
+	return -1
+}
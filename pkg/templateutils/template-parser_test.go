@@ -0,0 +1,93 @@
+// Copyright (c) 2026 Lerian Studio. All rights reserved.
+// Use of this source code is governed by the Elastic License 2.0
+// that can be found in the LICENSE file.
+
+package templateutils
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSplitTemplate(t *testing.T) {
+	t.Parallel()
+
+	segments := splitTemplate(`a{{ x }}{#- note -#}{%- if y == "%}" -%}b{{ unterminated`)
+
+	assert.Equal(t, []segment{
+		{kind: segmentText, content: "a"},
+		{kind: segmentVariable, content: "x"},
+		{kind: segmentTag, content: `if y == "%}"`},
+		{kind: segmentText, content: "b"},
+		{kind: segmentText, content: "{{ unterminated"},
+	}, segments)
+}
+
+func TestTokenize(t *testing.T) {
+	t.Parallel()
+
+	tokens := tokenize(`db:s.t.0.amount|where:"k:v" >= 1.5 and not 'it\'s'`)
+
+	assert.Equal(t, []token{
+		{tokenIdentifier, "db"}, {tokenSymbol, ":"}, {tokenIdentifier, "s"}, {tokenSymbol, "."},
+		{tokenIdentifier, "t"}, {tokenSymbol, "."}, {tokenNumber, "0"}, {tokenSymbol, "."},
+		{tokenIdentifier, "amount"}, {tokenSymbol, "|"}, {tokenIdentifier, "where"}, {tokenSymbol, ":"},
+		{tokenString, "k:v"}, {tokenSymbol, ">="}, {tokenNumber, "1.5"}, {tokenIdentifier, "and"},
+		{tokenIdentifier, "not"}, {tokenString, "it's"},
+	}, tokens)
+}
+
+func TestExprParser_ParseExpression(t *testing.T) {
+	t.Parallel()
+
+	e, ok := newExprParser(tokenize(`db:s.t.0.amount|sum:"v" + 2 * x > 3 or not y`)).parseExpression()
+	require.True(t, ok)
+
+	expected := binaryExpr{
+		op: "or",
+		left: binaryExpr{
+			op: ">",
+			left: binaryExpr{
+				op: "+",
+				left: filterExpr{
+					base: variableExpr{root: "db", steps: []pathStep{
+						{attr: "s__t"},
+						{index: literalExpr{kind: tokenNumber, value: "0"}},
+						{attr: "amount"},
+					}},
+					name: "sum",
+					arg:  literalExpr{kind: tokenString, value: "v"},
+				},
+				right: binaryExpr{op: "*", left: literalExpr{kind: tokenNumber, value: "2"}, right: variableExpr{root: "x"}},
+			},
+			right: literalExpr{kind: tokenNumber, value: "3"},
+		},
+		right: unaryExpr{op: "not", operand: variableExpr{root: "y"}},
+	}
+
+	assert.Equal(t, expected, e)
+}
+
+func TestParseTemplate(t *testing.T) {
+	t.Parallel()
+
+	nodes := parseTemplate(`{% for a in b %}{{ a }}{% empty %}none{% endfor %}{% if x %}1{% elif y %}{% endwith %}2{% else %}3{% endif %}{% set z = 1 %}{% with q=1 %}`)
+
+	require.Len(t, nodes, 4)
+
+	assert.Equal(t, "for", nodes[0].name)
+	require.Len(t, nodes[0].branches, 2)
+	assert.Len(t, nodes[0].branches[0].nodes, 1)
+	assert.Equal(t, "empty", nodes[0].branches[1].name)
+
+	assert.Equal(t, "if", nodes[1].name)
+	require.Len(t, nodes[1].branches, 3)
+	assert.Equal(t, []token{{tokenIdentifier, "y"}}, nodes[1].branches[1].tokens)
+
+	assert.Equal(t, "set", nodes[2].name)
+	assert.Nil(t, nodes[2].branches)
+
+	assert.Equal(t, "with", nodes[3].name, "blocks left open are closed at the end of the template")
+}
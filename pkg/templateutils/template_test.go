@@ -65,6 +65,11 @@ func TestGetMimeType(t *testing.T) {
 			outputFormat: "Html",
 			expected:     "text/html",
 		},
+		{
+			name:         "fixed-width format is plain text",
+			outputFormat: "fixed-width",
+			expected:     "text/plain",
+		},
 	}
 
 	for _, tt := range tests {
@@ -77,6 +82,15 @@ func TestGetMimeType(t *testing.T) {
 	}
 }
 
+func TestGetFileExtension(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, "csv", GetFileExtension("CSV"))
+	assert.Equal(t, "pdf", GetFileExtension("pdf"))
+	assert.Equal(t, "txt", GetFileExtension("fixed-width"))
+	assert.Equal(t, "txt", GetFileExtension("FIXED-WIDTH"))
+}
+
 func TestRegexBlockForWithFilterOnPlaceholder(t *testing.T) {
 	t.Parallel()
 
@@ -0,0 +1,28 @@
+// Copyright (c) 2026 Lerian Studio. All rights reserved.
+// Use of this source code is governed by the Elastic License 2.0
+// that can be found in the LICENSE file.
+
+package pkg
+
+import (
+	"github.com/LerianStudio/reporter/pkg/constant"
+
+	"github.com/google/uuid"
+)
+
+// TenantObjectName prefixes a storage object name with the owning organization ID so files of one
+// tenant never share a key space with another's. The default tenant (uuid.Nil) keeps the unprefixed
+// layout used before tenancy existed.
+func TenantObjectName(organizationID uuid.UUID, objectName string) string {
+	if organizationID == uuid.Nil {
+		return objectName
+	}
+
+	return organizationID.String() + "/" + objectName
+}
+
+// TenantPartialObjectName returns the storage object name of a partial template of the organization.
+// Partials are stored by name so the renderer resolves {% include %} and {% extends %} without a database lookup.
+func TenantPartialObjectName(organizationID uuid.UUID, partialName string) string {
+	return TenantObjectName(organizationID, constant.PartialObjectPrefix+partialName+".tpl")
+}
@@ -0,0 +1,55 @@
+// Copyright (c) 2026 Lerian Studio. All rights reserved.
+// Use of this source code is governed by the Elastic License 2.0
+// that can be found in the LICENSE file.
+
+package pkg
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTenantObjectName(t *testing.T) {
+	t.Parallel()
+
+	organizationID := uuid.MustParse("0195f1a2-0000-7000-8000-000000000001")
+
+	tests := []struct {
+		name           string
+		organizationID uuid.UUID
+		objectName     string
+		expected       string
+	}{
+		{
+			name:           "Default tenant keeps the unprefixed layout",
+			organizationID: uuid.Nil,
+			objectName:     "template-id/report-id.html",
+			expected:       "template-id/report-id.html",
+		},
+		{
+			name:           "Organization ID prefixes the object name",
+			organizationID: organizationID,
+			objectName:     "template-id/report-id.html",
+			expected:       "0195f1a2-0000-7000-8000-000000000001/template-id/report-id.html",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, tt.expected, TenantObjectName(tt.organizationID, tt.objectName))
+		})
+	}
+}
+
+func TestTenantPartialObjectName(t *testing.T) {
+	t.Parallel()
+
+	organizationID := uuid.MustParse("0195f1a2-0000-7000-8000-000000000001")
+
+	assert.Equal(t, "partials/layouts/corporate.tpl", TenantPartialObjectName(uuid.Nil, "layouts/corporate"))
+	assert.Equal(t, "0195f1a2-0000-7000-8000-000000000001/partials/layouts/corporate.tpl", TenantPartialObjectName(organizationID, "layouts/corporate"))
+}
@@ -5,13 +5,17 @@
 package pkg
 
 import (
+	"encoding/json"
 	"math"
 	"os/exec"
 	"reflect"
 	"regexp"
+	"slices"
+	"strconv"
 	"strings"
 
 	"github.com/LerianStudio/reporter/pkg/constant"
+	"github.com/LerianStudio/reporter/pkg/model"
 )
 
 // GetMapNumKinds get the map of numeric kinds to use in validations and conversions.
@@ -74,9 +78,64 @@ func ValidateFormDataFields(outFormat, description *string) error {
 // IsOutputFormatValuesValid returns a boolean indicating if the output format value is valid
 func IsOutputFormatValuesValid(outFormat *string) bool {
 	outFormatUpper := strings.ToUpper(*outFormat)
-	return outFormatUpper == "HTML" || outFormatUpper == "PDF" || outFormatUpper == "CSV" || outFormatUpper == "XML" || outFormatUpper == "TXT"
+	return outFormatUpper == "HTML" || outFormatUpper == "PDF" || outFormatUpper == "CSV" || outFormatUpper == "XML" || outFormatUpper == "TXT" ||
+		outFormatUpper == strings.ToUpper(constant.OutputFormatFixedWidth)
 }
 
+// ParseOutputOptions parses the JSON output options of a template form. An empty value means no options.
+func ParseOutputOptions(value string) (*model.OutputOptions, error) {
+	if strings.TrimSpace(value) == "" {
+		return nil, nil
+	}
+
+	var options model.OutputOptions
+
+	decoder := json.NewDecoder(strings.NewReader(value))
+	decoder.DisallowUnknownFields()
+
+	if err := decoder.Decode(&options); err != nil {
+		return nil, ValidateBusinessError(constant.ErrInvalidOutputOptions, "", err.Error())
+	}
+
+	if err := options.Validate(); err != nil {
+		return nil, ValidateBusinessError(constant.ErrInvalidOutputOptions, "", err.Error())
+	}
+
+	return &options, nil
+}
+
+// ValidateTemplatePriority returns error if the generation priority of a template form is not high, normal or low.
+// An empty value means no priority.
+func ValidateTemplatePriority(priority string) error {
+	if priority == "" {
+		return nil
+	}
+
+	if !slices.Contains([]string{constant.ReportPriorityHigh, constant.ReportPriorityNormal, constant.ReportPriorityLow}, priority) {
+		return ValidateBusinessError(constant.ErrInvalidTemplatePriority, "", priority)
+	}
+
+	return nil
+}
+
+// ParseTemplateRetentionDays parses the retention days of a template form, between 0 and constant.MaxReportRetentionDays.
+// An empty value means no retention; zero applies the retention of the tenant or the global one.
+func ParseTemplateRetentionDays(value string) (*int, error) {
+	if strings.TrimSpace(value) == "" {
+		return nil, nil
+	}
+
+	retentionDays, err := strconv.Atoi(strings.TrimSpace(value))
+	if err != nil || retentionDays < 0 || retentionDays > constant.MaxReportRetentionDays {
+		return nil, ValidateBusinessError(constant.ErrInvalidTemplateRetention, "", value, constant.MaxReportRetentionDays)
+	}
+
+	return &retentionDays, nil
+}
+
+// fixedWidthTagPattern matches the fixed_width tag declaring the layout of a fixed-width template.
+var fixedWidthTagPattern = regexp.MustCompile(`{%-?\s*fixed_width\s`)
+
 var formatValidators = map[string]func(string) bool{
 	"HTML": isValidHTML,
 	"PDF":  isValidHTML,
@@ -90,6 +149,7 @@ var formatValidators = map[string]func(string) bool{
 	"TXT": func(content string) bool {
 		return len(strings.TrimSpace(content)) > 0
 	},
+	"FIXED-WIDTH": fixedWidthTagPattern.MatchString,
 }
 
 func isValidHTML(content string) bool {
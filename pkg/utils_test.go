@@ -9,6 +9,8 @@ import (
 	"reflect"
 	"testing"
 
+	"github.com/LerianStudio/reporter/pkg/constant"
+	"github.com/LerianStudio/reporter/pkg/model"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -190,6 +192,11 @@ func TestIsOutputFormatValuesValid(t *testing.T) {
 			input:    "txt",
 			expected: true,
 		},
+		{
+			name:     "fixed-width lowercase",
+			input:    "fixed-width",
+			expected: true,
+		},
 		{
 			name:     "Invalid format - JSON",
 			input:    "JSON",
@@ -304,6 +311,138 @@ func TestValidateFormDataFields(t *testing.T) {
 	}
 }
 
+func TestParseOutputOptions(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		value       string
+		expected    *model.OutputOptions
+		expectError bool
+	}{
+		{name: "Empty value", value: "", expected: nil},
+		{name: "Blank value", value: "  ", expected: nil},
+		{
+			name:     "Valid options",
+			value:    `{"encoding":"windows-1252","unmappable":"replace","lineEnding":"crlf","trailingNewline":"ensure"}`,
+			expected: &model.OutputOptions{Encoding: "windows-1252", Unmappable: "replace", LineEnding: "crlf", TrailingNewline: "ensure"},
+		},
+		{
+			name:     "Valid PDF options",
+			value:    `{"pdf":{"paperSize":"a4","orientation":"landscape","footerTemplate":"<span class=\"pageNumber\"></span>"}}`,
+			expected: &model.OutputOptions{PDF: &model.PDFOptions{PaperSize: "a4", Orientation: "landscape", FooterTemplate: `<span class="pageNumber"></span>`}},
+		},
+		{name: "Invalid JSON", value: `{"encoding":`, expectError: true},
+		{name: "Unknown field", value: `{"charset":"utf-8"}`, expectError: true},
+		{name: "Unknown PDF field", value: `{"pdf":{"paper":"a4"}}`, expectError: true},
+		{name: "Unsupported encoding", value: `{"encoding":"ebcdic"}`, expectError: true},
+		{name: "Unsupported paper size", value: `{"pdf":{"paperSize":"b5"}}`, expectError: true},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			options, err := ParseOutputOptions(tt.value)
+			if tt.expectError {
+				require.Error(t, err)
+
+				var validationErr ValidationError
+				require.ErrorAs(t, err, &validationErr)
+				assert.Equal(t, constant.ErrInvalidOutputOptions.Error(), validationErr.Code)
+
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, options)
+		})
+	}
+}
+
+func TestValidateTemplatePriority(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		priority    string
+		expectError bool
+	}{
+		{name: "Empty priority", priority: ""},
+		{name: "High priority", priority: constant.ReportPriorityHigh},
+		{name: "Normal priority", priority: constant.ReportPriorityNormal},
+		{name: "Low priority", priority: constant.ReportPriorityLow},
+		{name: "Unsupported priority", priority: "urgent", expectError: true},
+		{name: "Uppercase priority", priority: "HIGH", expectError: true},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			err := ValidateTemplatePriority(tt.priority)
+			if tt.expectError {
+				var validationErr ValidationError
+				require.ErrorAs(t, err, &validationErr)
+				assert.Equal(t, constant.ErrInvalidTemplatePriority.Error(), validationErr.Code)
+
+				return
+			}
+
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestParseTemplateRetentionDays(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		value       string
+		expected    int
+		expectNil   bool
+		expectError bool
+	}{
+		{name: "Empty value", value: "", expectNil: true},
+		{name: "Zero days", value: "0", expected: 0},
+		{name: "Ninety days", value: " 90 ", expected: 90},
+		{name: "Longest retention", value: "36500", expected: constant.MaxReportRetentionDays},
+		{name: "Negative days", value: "-1", expectError: true},
+		{name: "Retention too long", value: "36501", expectError: true},
+		{name: "Not a number", value: "ninety", expectError: true},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			retentionDays, err := ParseTemplateRetentionDays(tt.value)
+			if tt.expectError {
+				var validationErr ValidationError
+				require.ErrorAs(t, err, &validationErr)
+				assert.Equal(t, constant.ErrInvalidTemplateRetention.Error(), validationErr.Code)
+
+				return
+			}
+
+			require.NoError(t, err)
+
+			if tt.expectNil {
+				assert.Nil(t, retentionDays)
+
+				return
+			}
+
+			require.NotNil(t, retentionDays)
+			assert.Equal(t, tt.expected, *retentionDays)
+		})
+	}
+}
+
 func TestValidateFileFormat(t *testing.T) {
 	t.Parallel()
 
@@ -414,6 +553,19 @@ func TestValidateFileFormat(t *testing.T) {
 			templateFile: "   \n\t\n   ",
 			expectError:  true,
 		},
+		// Fixed-width tests
+		{
+			name:         "Valid fixed-width declaring a layout",
+			outFormat:    "fixed-width",
+			templateFile: "{% fixed_width \"layouts/cnab240\" %}{% record \"header\" %}",
+			expectError:  false,
+		},
+		{
+			name:         "Invalid fixed-width without layout",
+			outFormat:    "fixed-width",
+			templateFile: "{% record \"header\" %}",
+			expectError:  true,
+		},
 		// Case insensitivity
 		{
 			name:         "Lowercase html format",